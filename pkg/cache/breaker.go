@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/circuitbreaker"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBreakerMaxFailures  = 5
+	defaultBreakerResetTimeout = 5 * time.Second
+)
+
+// breakerHook fails Redis commands fast while the breaker is open, so an
+// outage costs callers nothing instead of a dial or read timeout per command
+type breakerHook struct {
+	breaker *circuitbreaker.CircuitBreaker
+}
+
+func newBreakerHook(cfg Config, logger *zap.Logger) breakerHook {
+	maxFailures := cfg.BreakerMaxFailures
+	if maxFailures == 0 {
+		maxFailures = defaultBreakerMaxFailures
+	}
+	resetTimeout := cfg.BreakerResetTimeout
+	if resetTimeout <= 0 {
+		resetTimeout = defaultBreakerResetTimeout
+	}
+
+	breaker := circuitbreaker.New(circuitbreaker.Config{
+		MaxFailures:  maxFailures,
+		ResetTimeout: resetTimeout,
+	})
+	breaker.OnStateChange(func(from, to circuitbreaker.State) {
+		logger.Warn("redis circuit breaker state changed",
+			zap.Stringer("from", from),
+			zap.Stringer("to", to),
+		)
+	})
+	return breakerHook{breaker: breaker}
+}
+
+func (h breakerHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h breakerHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := h.breaker.Allow(); err != nil {
+			return err
+		}
+		err := next(ctx, cmd)
+		h.record(ctx, err)
+		return err
+	}
+}
+
+func (h breakerHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := h.breaker.Allow(); err != nil {
+			return err
+		}
+		err := next(ctx, cmds)
+		h.record(ctx, err)
+		return err
+	}
+}
+
+// record counts only failures to reach Redis. Replies such as redis.Nil show
+// Redis is up; the caller's own cancellation says nothing either way.
+func (h breakerHook) record(ctx context.Context, err error) {
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+	var reply redis.Error
+	if errors.As(err, &reply) {
+		err = nil
+	}
+	h.breaker.Record(err)
+}
+
+// isUnavailable reports whether err is a command short-circuited by the breaker
+func isUnavailable(err error) bool {
+	return errors.Is(err, circuitbreaker.ErrCircuitOpen)
+}
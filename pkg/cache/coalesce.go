@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// CoalesceMode controls how concurrent cache misses for one key are collapsed
+type CoalesceMode int
+
+const (
+	// CoalesceInProcess collapses misses within this process only
+	CoalesceInProcess CoalesceMode = iota
+	// CoalesceCrossProcess also takes a short Redis lock so only one replica
+	// loads; the others poll the cache until the value appears
+	CoalesceCrossProcess
+)
+
+const (
+	lockKeyPrefix       = "lock:"
+	defaultLockTTL      = 5 * time.Second
+	defaultLockWait     = 500 * time.Millisecond
+	defaultPollInterval = 25 * time.Millisecond
+)
+
+// unlockScript deletes the lock only if this caller still holds it
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Loader loads a value on a cache miss
+type Loader func(ctx context.Context) (interface{}, error)
+
+// GetOrSetJSON reads key into dest, calling load on a miss and caching its
+// result for ttl. Concurrent misses for the same key share a single load;
+// see CoalesceMode. Load errors are returned and nothing is cached.
+func (r *RedisCache) GetOrSetJSON(ctx context.Context, key string, dest interface{}, ttl time.Duration, load Loader) error {
+	found, err := r.GetJSON(ctx, key, dest)
+	if err != nil {
+		r.logger.Warn("cache get failed", zap.String("key", key), zap.Error(err))
+	}
+	if found {
+		return nil
+	}
+
+	data, err, _ := r.loads.Do(key, func() (interface{}, error) {
+		// Detach from the first caller's cancellation; other callers share this load
+		loadCtx := context.WithoutCancel(ctx)
+		if r.coalesce == CoalesceCrossProcess {
+			return r.loadLocked(loadCtx, key, ttl, load)
+		}
+		return r.loadAndSet(loadCtx, key, ttl, load)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Each caller decodes its own copy
+	if err := json.Unmarshal(data.([]byte), dest); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return nil
+}
+
+// loadLocked loads under a Redis lock, or waits for the lock holder's result
+func (r *RedisCache) loadLocked(ctx context.Context, key string, ttl time.Duration, load Loader) ([]byte, error) {
+	lockKey := lockKeyPrefix + key
+	token := uuid.New().String()
+
+	acquired, err := r.SetNX(ctx, lockKey, token, r.lockTTL)
+	if err != nil {
+		// Redis trouble shouldn't block reads; load without the lock
+		r.logger.Warn("cache lock failed", zap.String("key", key), zap.Error(err))
+		return r.loadAndSet(ctx, key, ttl, load)
+	}
+
+	if acquired {
+		defer func() {
+			if err := unlockScript.Run(ctx, r.client, []string{lockKey}, token).Err(); err != nil && !errors.Is(err, redis.Nil) {
+				r.logger.Warn("cache unlock failed", zap.String("key", key), zap.Error(err))
+			}
+		}()
+		return r.loadAndSet(ctx, key, ttl, load)
+	}
+
+	// Another replica is loading; poll briefly for its result
+	deadline := time.Now().Add(r.lockWait)
+	for time.Now().Before(deadline) {
+		time.Sleep(r.pollInterval)
+
+		val, err := r.client.Get(ctx, key).Bytes()
+		if err == nil && json.Valid(val) {
+			return val, nil
+		}
+		if err != nil && !errors.Is(err, redis.Nil) {
+			break
+		}
+	}
+
+	r.logger.Debug("cache lock wait expired, loading", zap.String("key", key))
+	return r.loadAndSet(ctx, key, ttl, load)
+}
+
+func (r *RedisCache) loadAndSet(ctx context.Context, key string, ttl time.Duration, load Loader) ([]byte, error) {
+	value, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	if err := r.Set(ctx, key, data, ttl); err != nil && !isUnavailable(err) {
+		r.logger.Warn("cache set failed", zap.String("key", key), zap.Error(err))
+	}
+
+	return data, nil
+}
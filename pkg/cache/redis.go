@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 // Config holds Redis configuration
@@ -20,12 +22,43 @@ type Config struct {
 	DialTimeout  time.Duration
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+
+	// Coalesce selects how GetOrSetJSON collapses concurrent misses
+	Coalesce CoalesceMode
+	// LockTTL bounds how long a cross-process load lock is held
+	LockTTL time.Duration
+	// LockWait is how long other replicas poll for the lock holder's result
+	// before loading themselves
+	LockWait time.Duration
+
+	// FailFast opens a circuit breaker after BreakerMaxFailures consecutive
+	// failures to reach Redis. While it is open, reads are misses and writes
+	// fail immediately instead of waiting out timeouts, until a probe after
+	// BreakerResetTimeout succeeds. Without it every command waits.
+	FailFast            bool
+	BreakerMaxFailures  uint32
+	BreakerResetTimeout time.Duration
+
+	// Namespace and Version prefix every key built with Key, e.g.
+	// "prod:v3:product:42". Namespace keeps environments sharing a Redis
+	// apart; bumping Version orphans every key written under the old one,
+	// which then expire by their TTL.
+	Namespace string
+	Version   string
 }
 
 // RedisCache wraps Redis client
 type RedisCache struct {
 	client *redis.Client
 	logger *zap.Logger
+	// keyPrefix is prepended by Key; empty without a namespace or version
+	keyPrefix string
+
+	coalesce     CoalesceMode
+	lockTTL      time.Duration
+	lockWait     time.Duration
+	pollInterval time.Duration
+	loads        singleflight.Group
 }
 
 // NewRedisCache creates a new Redis cache
@@ -51,16 +84,52 @@ func NewRedisCache(ctx context.Context, cfg Config, logger *zap.Logger) (*RedisC
 
 	logger.Info("Redis connection established", zap.String("addr", cfg.Addr))
 
+	if cfg.FailFast {
+		client.AddHook(newBreakerHook(cfg, logger))
+	}
+
+	lockTTL := cfg.LockTTL
+	if lockTTL <= 0 {
+		lockTTL = defaultLockTTL
+	}
+	lockWait := cfg.LockWait
+	if lockWait <= 0 {
+		lockWait = defaultLockWait
+	}
+
 	return &RedisCache{
-		client: client,
-		logger: logger,
+		client:       client,
+		logger:       logger,
+		keyPrefix:    keyPrefix(cfg.Namespace, cfg.Version),
+		coalesce:     cfg.Coalesce,
+		lockTTL:      lockTTL,
+		lockWait:     lockWait,
+		pollInterval: defaultPollInterval,
 	}, nil
 }
 
+// keyPrefix returns the prefix for keys in namespace at version
+func keyPrefix(namespace, version string) string {
+	var prefix string
+	if namespace != "" {
+		prefix += namespace + ":"
+	}
+	if version != "" {
+		prefix += "v" + version + ":"
+	}
+	return prefix
+}
+
+// Key joins parts with ":" and prefixes the result with the configured
+// namespace and version
+func (r *RedisCache) Key(parts ...string) string {
+	return r.keyPrefix + strings.Join(parts, ":")
+}
+
 // Get retrieves a value from cache
 func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
 	val, err := r.client.Get(ctx, key).Result()
-	if err == redis.Nil {
+	if err == redis.Nil || isUnavailable(err) {
 		return "", nil
 	}
 	if err != nil {
@@ -69,6 +138,25 @@ func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
 	return val, nil
 }
 
+// MGet retrieves several values in one round trip; missing keys yield ""
+func (r *RedisCache) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	vals, err := r.client.MGet(ctx, keys...).Result()
+	if isUnavailable(err) {
+		return make([]string, len(keys)), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keys: %w", err)
+	}
+
+	result := make([]string, len(vals))
+	for i, val := range vals {
+		if s, ok := val.(string); ok {
+			result[i] = s
+		}
+	}
+	return result, nil
+}
+
 // Set stores a value in cache with TTL
 func (r *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	err := r.client.Set(ctx, key, value, ttl).Err()
@@ -81,7 +169,7 @@ func (r *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl
 // GetJSON retrieves and unmarshals JSON value
 func (r *RedisCache) GetJSON(ctx context.Context, key string, dest interface{}) (bool, error) {
 	val, err := r.client.Get(ctx, key).Result()
-	if err == redis.Nil {
+	if err == redis.Nil || isUnavailable(err) {
 		return false, nil
 	}
 	if err != nil {
@@ -114,6 +202,31 @@ func (r *RedisCache) Delete(ctx context.Context, keys ...string) error {
 	return nil
 }
 
+// DeleteByPattern removes every key matching a glob pattern and returns how
+// many were deleted. Keys are found with SCAN, so it doesn't block Redis the
+// way KEYS would, but keys written during the scan may survive it.
+func (r *RedisCache) DeleteByPattern(ctx context.Context, pattern string) (int64, error) {
+	var deleted int64
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		if len(keys) > 0 {
+			n, err := r.client.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("failed to delete keys: %w", err)
+			}
+			deleted += n
+		}
+		if next == 0 {
+			return deleted, nil
+		}
+		cursor = next
+	}
+}
+
 // Exists checks if key exists
 func (r *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
 	count, err := r.client.Exists(ctx, key).Result()
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/redistest"
+	"go.uber.org/zap"
+)
+
+func newTestCache(t *testing.T, server *redistest.Server, namespace, version string) *RedisCache {
+	t.Helper()
+	c, err := NewRedisCache(context.Background(), Config{Addr: server.Addr(), Namespace: namespace, Version: version}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewRedisCache failed: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestKey(t *testing.T) {
+	tests := map[string]struct {
+		namespace string
+		version   string
+		want      string
+	}{
+		"no namespace or version": {want: "product:42"},
+		"namespace":               {namespace: "prod", want: "prod:product:42"},
+		"version":                 {version: "3", want: "v3:product:42"},
+		"namespace and version":   {namespace: "prod", version: "3", want: "prod:v3:product:42"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &RedisCache{keyPrefix: keyPrefix(tt.namespace, tt.version)}
+			if got := c.Key("product", "42"); got != tt.want {
+				t.Errorf("Key = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedKeyIsNamespaced(t *testing.T) {
+	c := &RedisCache{keyPrefix: keyPrefix("prod", "3")}
+	typed := NewTyped[string](c, "product:")
+
+	if got := typed.Key("42"); got != "prod:v3:product:42" {
+		t.Errorf("Key = %q, want prod:v3:product:42", got)
+	}
+}
+
+func TestVersionBumpOrphansOldKeys(t *testing.T) {
+	ctx := context.Background()
+	server := redistest.NewServer(t)
+	v1 := NewTyped[string](newTestCache(t, server, "prod", "1"), "product:")
+	v2 := NewTyped[string](newTestCache(t, server, "prod", "2"), "product:")
+	staging := NewTyped[string](newTestCache(t, server, "staging", "1"), "product:")
+
+	if err := v1.Set(ctx, "42", "old shape", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if _, found, err := v2.Get(ctx, "42"); err != nil || found {
+		t.Errorf("v2 Get = %v, %v; want a miss for a key written under v1", found, err)
+	}
+	if _, found, err := staging.Get(ctx, "42"); err != nil || found {
+		t.Errorf("staging Get = %v, %v; want a miss for a prod key", found, err)
+	}
+	if value, found, err := v1.Get(ctx, "42"); err != nil || !found || value != "old shape" {
+		t.Errorf("v1 Get = %q, %v, %v; want its own value", value, found, err)
+	}
+
+	// Old keys keep their TTL and expire on their own
+	if ttl := server.TTL("prod:v1:product:42"); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("v1 key TTL = %v, want the 1m it was written with", ttl)
+	}
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Typed is a type-safe view of a RedisCache for JSON values of type T kept
+// under a common key prefix. Keys passed to its methods are relative to the
+// prefix, which is itself relative to the cache's namespace and version.
+type Typed[T any] struct {
+	cache  *RedisCache
+	prefix string
+}
+
+// NewTyped creates a new typed cache
+func NewTyped[T any](cache *RedisCache, prefix string) *Typed[T] {
+	return &Typed[T]{cache: cache, prefix: prefix}
+}
+
+// Key returns the full Redis key for key
+func (t *Typed[T]) Key(key string) string {
+	return t.cache.Key(t.prefix + key)
+}
+
+// Get returns the value stored under key, reporting whether it was found
+func (t *Typed[T]) Get(ctx context.Context, key string) (T, bool, error) {
+	var value T
+	found, err := t.cache.GetJSON(ctx, t.Key(key), &value)
+	if err != nil || !found {
+		var zero T
+		return zero, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value under key for ttl
+func (t *Typed[T]) Set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	return t.cache.SetJSON(ctx, t.Key(key), value, ttl)
+}
+
+// Delete removes keys
+func (t *Typed[T]) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	full := make([]string, len(keys))
+	for i, key := range keys {
+		full[i] = t.Key(key)
+	}
+	return t.cache.Delete(ctx, full...)
+}
+
+// GetOrLoad returns the value under key, calling load on a miss and caching
+// its result for ttl. Concurrent misses are coalesced as in GetOrSetJSON.
+func (t *Typed[T]) GetOrLoad(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+	err := t.cache.GetOrSetJSON(ctx, t.Key(key), &value, ttl, func(ctx context.Context) (interface{}, error) {
+		loaded, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return loaded, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
@@ -21,6 +21,18 @@ const (
 	StateOpen
 )
 
+// String returns the state name
+func (s State) String() string {
+	switch s {
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
 // Config holds circuit breaker configuration
 type Config struct {
 	MaxFailures  uint32
@@ -82,6 +94,24 @@ func (cb *CircuitBreaker) Execute(ctx context.Context, fn func() error) error {
 	}
 }
 
+// Allow returns ErrCircuitOpen if a call may not proceed. It is for callers
+// that run the call themselves; they report its outcome with Record.
+func (cb *CircuitBreaker) Allow() error {
+	if !cb.canAttempt() {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// Record reports the outcome of a call admitted by Allow
+func (cb *CircuitBreaker) Record(err error) {
+	if err != nil {
+		cb.recordFailure()
+		return
+	}
+	cb.recordSuccess()
+}
+
 func (cb *CircuitBreaker) canAttempt() bool {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
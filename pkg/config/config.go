@@ -0,0 +1,207 @@
+// Package config loads service configuration from environment variables into
+// typed structs, so a malformed or missing value stops the service at startup
+// instead of surfacing as a runtime failure.
+//
+// Fields are described with struct tags:
+//
+//	type Config struct {
+//		Env      string        `env:"ENV" default:"development" validate:"oneof=development staging production"`
+//		GRPCPort int           `env:"GRPC_PORT" default:"50051" validate:"port"`
+//		Secret   string        `env:"SECRET" required:"true"`
+//		Timeout  time.Duration `env:"TIMEOUT" validate:"min=1s"`
+//	}
+//
+// An unset or empty variable takes the default tag, or keeps the value the
+// field already holds when there is none, so defaults defined as Go constants
+// can be set on the struct before Load. Untagged struct fields are loaded
+// recursively.
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvDevelopment is the ENV value of local development
+	EnvDevelopment = "development"
+	// EnvProduction is the ENV value of production deployments
+	EnvProduction = "production"
+)
+
+// InsecureJWTSecret is the placeholder JWT secret the services fall back to
+// in development
+const InsecureJWTSecret = "your-secret-key-change-in-production"
+
+// Validator is implemented by config structs that check constraints spanning
+// several fields. Load calls it once every field has loaded.
+type Validator interface {
+	Validate() error
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load fills cfg, a pointer to a struct, from the environment. Every invalid
+// or missing required variable is reported in the returned error.
+func Load(cfg any) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to a struct, got %T", cfg)
+	}
+
+	if err := errors.Join(loadStruct(v.Elem())...); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if validator, ok := cfg.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	return nil
+}
+
+// CheckJWTSecret rejects an empty JWT secret, and the placeholder one
+// outside development
+func CheckJWTSecret(env, secret string) error {
+	if secret == "" {
+		return errors.New("JWT_SECRET is required")
+	}
+	if env != EnvDevelopment && secret == InsecureJWTSecret {
+		return fmt.Errorf("JWT_SECRET must be changed from its default in %s", env)
+	}
+	return nil
+}
+
+func loadStruct(v reflect.Value) []error {
+	var errs []error
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				errs = append(errs, loadStruct(v.Field(i))...)
+			}
+			continue
+		}
+
+		if err := loadField(v.Field(i), field, name); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errs
+}
+
+func loadField(v reflect.Value, field reflect.StructField, name string) error {
+	value := os.Getenv(name)
+	if value == "" {
+		if field.Tag.Get("required") == "true" {
+			return errors.New("is required")
+		}
+		value = field.Tag.Get("default")
+	}
+
+	if value != "" {
+		if err := setValue(v, value); err != nil {
+			return err
+		}
+	}
+
+	if rules := field.Tag.Get("validate"); rules != "" {
+		return validate(v, rules)
+	}
+	return nil
+}
+
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		v.SetInt(i)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// validate checks v against comma separated rules: port, min=N, max=N and
+// oneof=a b c
+func validate(v reflect.Value, rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		rule, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch rule {
+		case "port":
+			if v.Kind() != reflect.Int || v.Int() < 1 || v.Int() > 65535 {
+				return fmt.Errorf("invalid port %v", v.Interface())
+			}
+		case "min", "max":
+			bound := reflect.New(v.Type()).Elem()
+			if err := setValue(bound, arg); err != nil {
+				return fmt.Errorf("invalid %s rule: %w", rule, err)
+			}
+			c, err := compare(v, bound)
+			if err != nil {
+				return err
+			}
+			if (rule == "min" && c < 0) || (rule == "max" && c > 0) {
+				return fmt.Errorf("%v is out of range (%s %s)", v.Interface(), rule, arg)
+			}
+		case "oneof":
+			if !slices.Contains(strings.Fields(arg), v.String()) {
+				return fmt.Errorf("%q is not one of %s", v.String(), arg)
+			}
+		default:
+			return fmt.Errorf("unknown validation rule %q", rule)
+		}
+	}
+	return nil
+}
+
+func compare(a, b reflect.Value) (int, error) {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), nil
+	case reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), nil
+	default:
+		return 0, fmt.Errorf("range rules need a numeric field, got %s", a.Type())
+	}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Env     string        `env:"TEST_ENV" default:"development" validate:"oneof=development staging production"`
+	Port    int           `env:"TEST_PORT" default:"8080" validate:"port"`
+	Timeout time.Duration `env:"TEST_TIMEOUT" validate:"min=1s"`
+	Secret  string        `env:"TEST_SECRET" default:"your-secret-key-change-in-production"`
+
+	DB struct {
+		Name string `env:"TEST_DB_NAME" required:"true"`
+	}
+}
+
+func (c *testConfig) Validate() error {
+	return CheckJWTSecret(c.Env, c.Secret)
+}
+
+func TestLoadDefaults(t *testing.T) {
+	t.Setenv("TEST_DB_NAME", "coldy")
+
+	cfg := &testConfig{Timeout: 5 * time.Second}
+	if err := Load(cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Env != "development" {
+		t.Errorf("Env = %q, want the default tag", cfg.Env)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("Port = %d, want the default tag", cfg.Port)
+	}
+	// Without a default tag the value set before Load is kept
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %s, want the preset 5s", cfg.Timeout)
+	}
+	if cfg.DB.Name != "coldy" {
+		t.Errorf("DB.Name = %q, want it loaded from the nested struct", cfg.DB.Name)
+	}
+}
+
+func TestLoadOverridesDefaults(t *testing.T) {
+	t.Setenv("TEST_DB_NAME", "coldy")
+	t.Setenv("TEST_ENV", "staging")
+	t.Setenv("TEST_SECRET", "a-real-secret")
+	t.Setenv("TEST_PORT", "9090")
+	t.Setenv("TEST_TIMEOUT", "30s")
+
+	cfg := &testConfig{Timeout: 5 * time.Second}
+	if err := Load(cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Env != "staging" || cfg.Port != 9090 || cfg.Timeout != 30*time.Second {
+		t.Errorf("got Env=%q Port=%d Timeout=%s, want the environment's values", cfg.Env, cfg.Port, cfg.Timeout)
+	}
+}
+
+func TestLoadRequiredMissing(t *testing.T) {
+	cfg := &testConfig{Timeout: 5 * time.Second}
+	err := Load(cfg)
+	if err == nil {
+		t.Fatal("expected an error for the missing required variable")
+	}
+	if !strings.Contains(err.Error(), "TEST_DB_NAME: is required") {
+		t.Errorf("error %q does not name the missing variable", err)
+	}
+}
+
+func TestLoadReportsEveryInvalidValue(t *testing.T) {
+	t.Setenv("TEST_DB_NAME", "coldy")
+	t.Setenv("TEST_ENV", "prod")
+	t.Setenv("TEST_PORT", "70000")
+	t.Setenv("TEST_TIMEOUT", "soon")
+
+	err := Load(&testConfig{})
+	if err == nil {
+		t.Fatal("expected an error for the invalid values")
+	}
+	for _, name := range []string{"TEST_ENV", "TEST_PORT", "TEST_TIMEOUT"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not report %s", err, name)
+		}
+	}
+}
+
+func TestLoadRangeRule(t *testing.T) {
+	t.Setenv("TEST_DB_NAME", "coldy")
+	t.Setenv("TEST_TIMEOUT", "500ms")
+
+	err := Load(&testConfig{})
+	if err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("expected an out of range error, got %v", err)
+	}
+}
+
+func TestLoadRejectsInsecureSecretOutsideDevelopment(t *testing.T) {
+	for _, env := range []string{"staging", EnvProduction} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv("TEST_DB_NAME", "coldy")
+			t.Setenv("TEST_ENV", env)
+
+			err := Load(&testConfig{Timeout: 5 * time.Second})
+			if err == nil || !strings.Contains(err.Error(), "must be changed from its default") {
+				t.Errorf("expected the default secret to be rejected in %s, got %v", env, err)
+			}
+
+			t.Setenv("TEST_SECRET", "a-real-secret")
+			if err := Load(&testConfig{Timeout: 5 * time.Second}); err != nil {
+				t.Errorf("unexpected error with a real secret: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadAllowsInsecureSecretInDevelopment(t *testing.T) {
+	t.Setenv("TEST_DB_NAME", "coldy")
+
+	if err := Load(&testConfig{Timeout: 5 * time.Second}); err != nil {
+		t.Errorf("unexpected error in development: %v", err)
+	}
+}
+
+func TestCheckJWTSecretRejectsEmptySecret(t *testing.T) {
+	if err := CheckJWTSecret(EnvDevelopment, ""); err == nil {
+		t.Error("expected an empty secret to be rejected")
+	}
+}
+
+func TestLoadRejectsNonPointer(t *testing.T) {
+	if err := Load(testConfig{}); err == nil {
+		t.Error("expected an error for a non-pointer config")
+	}
+}
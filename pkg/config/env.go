@@ -0,0 +1,39 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Getenv returns the environment variable key, or defaultValue when it is
+// unset or empty
+func Getenv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// GetenvInt returns the environment variable key as an int, or defaultValue
+// when it is unset or not an integer. Use Load to reject invalid values.
+func GetenvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+// GetenvDuration returns the environment variable key as a duration, or
+// defaultValue when it is unset or not a duration. Use Load to reject
+// invalid values.
+func GetenvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
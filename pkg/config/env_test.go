@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetenv(t *testing.T) {
+	t.Setenv("TEST_VALUE", "set")
+	t.Setenv("TEST_EMPTY", "")
+
+	if got := Getenv("TEST_VALUE", "default"); got != "set" {
+		t.Errorf("Getenv = %q, want set", got)
+	}
+	if got := Getenv("TEST_EMPTY", "default"); got != "default" {
+		t.Errorf("Getenv of an empty variable = %q, want default", got)
+	}
+}
+
+func TestGetenvIntFallsBackOnInvalidValue(t *testing.T) {
+	t.Setenv("TEST_INT", "42")
+	if got := GetenvInt("TEST_INT", 7); got != 42 {
+		t.Errorf("GetenvInt = %d, want 42", got)
+	}
+
+	t.Setenv("TEST_INT", "forty-two")
+	if got := GetenvInt("TEST_INT", 7); got != 7 {
+		t.Errorf("GetenvInt of an invalid value = %d, want 7", got)
+	}
+}
+
+func TestGetenvDurationFallsBackOnInvalidValue(t *testing.T) {
+	t.Setenv("TEST_DURATION", "90s")
+	if got := GetenvDuration("TEST_DURATION", time.Second); got != 90*time.Second {
+		t.Errorf("GetenvDuration = %s, want 1m30s", got)
+	}
+
+	t.Setenv("TEST_DURATION", "90")
+	if got := GetenvDuration("TEST_DURATION", time.Second); got != time.Second {
+		t.Errorf("GetenvDuration of an invalid value = %s, want 1s", got)
+	}
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// queryComments enables Annotate; set from Config.QueryComments
+var queryComments atomic.Bool
+
+// safeID matches the IDs Annotate is willing to embed in SQL: hex digits and
+// dashes only, so a value can never close the comment
+var safeID = regexp.MustCompile(`^[0-9a-fA-F-]{1,64}$`)
+
+type requestIDKey struct{}
+
+// WithRequestID stores the request ID that Annotate adds to queries
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// Annotate prefixes query with a comment carrying the request and trace IDs
+// from ctx, e.g. "/* request_id=..., trace_id=... */", so a query seen in
+// pg_stat_activity can be tied to its request. It returns query unchanged
+// unless query comments are enabled. Only IDs made of hex digits and dashes,
+// such as UUIDs, are embedded; anything else is left out.
+func Annotate(ctx context.Context, query string) string {
+	if !queryComments.Load() {
+		return query
+	}
+
+	var comment string
+	if requestID, _ := ctx.Value(requestIDKey{}).(string); safeID.MatchString(requestID) {
+		comment = "request_id=" + requestID
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		if comment != "" {
+			comment += ", "
+		}
+		comment += "trace_id=" + sc.TraceID().String()
+	}
+
+	if comment == "" {
+		return query
+	}
+	return "/* " + comment + " */ " + query
+}
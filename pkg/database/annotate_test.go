@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func enableQueryComments(t *testing.T) {
+	t.Helper()
+	queryComments.Store(true)
+	t.Cleanup(func() { queryComments.Store(false) })
+}
+
+func withTraceID(ctx context.Context, t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatalf("TraceIDFromHex failed: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatalf("SpanIDFromHex failed: %v", err)
+	}
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+}
+
+func TestAnnotate(t *testing.T) {
+	enableQueryComments(t)
+	const query = "SELECT * FROM orders WHERE id = $1"
+	requestID := "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+	tests := map[string]struct {
+		ctx  context.Context
+		want string
+	}{
+		"request and trace IDs": {
+			ctx:  withTraceID(WithRequestID(context.Background(), requestID), t),
+			want: "/* request_id=" + requestID + ", trace_id=4bf92f3577b34da6a3ce929d0e0e4736 */ " + query,
+		},
+		"request ID only": {
+			ctx:  WithRequestID(context.Background(), requestID),
+			want: "/* request_id=" + requestID + " */ " + query,
+		},
+		"trace ID only": {
+			ctx:  withTraceID(context.Background(), t),
+			want: "/* trace_id=4bf92f3577b34da6a3ce929d0e0e4736 */ " + query,
+		},
+		"no IDs": {
+			ctx:  context.Background(),
+			want: query,
+		},
+		"comment injection": {
+			ctx:  WithRequestID(context.Background(), "1 */ DROP TABLE orders; /*"),
+			want: query,
+		},
+		"non-hex request ID": {
+			ctx:  WithRequestID(context.Background(), "req-xyz"),
+			want: query,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Annotate(tt.ctx, query); got != tt.want {
+				t.Errorf("Annotate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotateIsOptIn(t *testing.T) {
+	const query = "SELECT 1"
+	ctx := withTraceID(WithRequestID(context.Background(), "0f8fad5b"), t)
+	if got := Annotate(ctx, query); got != query {
+		t.Errorf("Annotate = %q with query comments disabled, want the query unchanged", got)
+	}
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DefaultHealthCheckTimeout bounds a health check when no timeout is given
+const DefaultHealthCheckTimeout = 2 * time.Second
+
+// Status is the result of HealthCheckDetailed
+type Status struct {
+	Healthy   bool    `json:"healthy"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+
+	// Saturated is set when every connection the pool may open is in use,
+	// so new queries wait for a connection
+	Saturated      bool    `json:"saturated"`
+	MaxOpen        int     `json:"max_open"`
+	Open           int     `json:"open"`
+	InUse          int     `json:"in_use"`
+	Idle           int     `json:"idle"`
+	WaitCount      int64   `json:"wait_count"`
+	WaitDurationMS float64 `json:"wait_duration_ms"`
+}
+
+// HealthCheckDetailed runs a cheap query bounded by timeout, independent of
+// any longer deadline on ctx, and reports it together with pool statistics.
+// A saturated pool is reported but is not an error.
+func HealthCheckDetailed(ctx context.Context, db *sql.DB, timeout time.Duration) (Status, error) {
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	var one int
+	err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+
+	stats := db.Stats()
+	status := Status{
+		Healthy:        err == nil,
+		LatencyMS:      float64(time.Since(start)) / float64(time.Millisecond),
+		Saturated:      stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections,
+		MaxOpen:        stats.MaxOpenConnections,
+		Open:           stats.OpenConnections,
+		InUse:          stats.InUse,
+		Idle:           stats.Idle,
+		WaitCount:      stats.WaitCount,
+		WaitDurationMS: float64(stats.WaitDuration) / float64(time.Millisecond),
+	}
+	if err != nil {
+		status.Error = err.Error()
+		return status, fmt.Errorf("database health check failed: %w", err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// healthDriver answers SELECT 1. Connections opened with the "hang" DSN
+// block until the query's context is done, like an overloaded server.
+type healthDriver struct{}
+
+var registerHealthDriver sync.Once
+
+func openHealthDB(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+	registerHealthDriver.Do(func() { sql.Register("database-health-fake", healthDriver{}) })
+
+	db, err := sql.Open("database-health-fake", dsn)
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func (healthDriver) Open(dsn string) (driver.Conn, error) {
+	return &healthConn{hang: dsn == "hang"}, nil
+}
+
+type healthConn struct {
+	hang bool
+}
+
+func (c *healthConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *healthConn) Close() error { return nil }
+
+func (c *healthConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (c *healthConn) QueryContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if query != "SELECT 1" {
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+	if c.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &oneRow{}, nil
+}
+
+type oneRow struct {
+	done bool
+}
+
+func (r *oneRow) Columns() []string { return []string{"?column?"} }
+
+func (r *oneRow) Close() error { return nil }
+
+func (r *oneRow) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func TestHealthCheckDetailedHealthy(t *testing.T) {
+	db := openHealthDB(t, "ok")
+	db.SetMaxOpenConns(4)
+
+	status, err := HealthCheckDetailed(context.Background(), db, time.Second)
+	if err != nil {
+		t.Fatalf("HealthCheckDetailed failed: %v", err)
+	}
+	if !status.Healthy || status.Error != "" {
+		t.Errorf("status = %+v, want healthy", status)
+	}
+	if status.Saturated {
+		t.Error("idle pool reported saturated")
+	}
+	if status.MaxOpen != 4 || status.Open != 1 || status.Idle != 1 || status.InUse != 0 {
+		t.Errorf("pool stats = max %d open %d idle %d in use %d, want 4/1/1/0",
+			status.MaxOpen, status.Open, status.Idle, status.InUse)
+	}
+}
+
+func TestHealthCheckDetailedTimeout(t *testing.T) {
+	db := openHealthDB(t, "hang")
+
+	// The caller's deadline is far off; the health check bounds itself
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	status, err := HealthCheckDetailed(ctx, db, 50*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("health check took %s, want it bounded by its timeout", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if status.Healthy || status.Error == "" {
+		t.Errorf("status = %+v, want unhealthy with the error", status)
+	}
+}
+
+func TestHealthCheckDetailedSaturatedPool(t *testing.T) {
+	db := openHealthDB(t, "ok")
+	db.SetMaxOpenConns(1)
+
+	// Hold the only connection, as a long-running query would
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("failed to take a connection: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	status, err := HealthCheckDetailed(context.Background(), db, 50*time.Millisecond)
+	if err == nil {
+		t.Error("expected the health check to time out waiting for a connection")
+	}
+	if !status.Saturated {
+		t.Errorf("status = %+v, want saturated", status)
+	}
+	if status.InUse != 1 || status.MaxOpen != 1 {
+		t.Errorf("pool stats = in use %d of %d, want 1 of 1", status.InUse, status.MaxOpen)
+	}
+	if status.WaitCount < 1 {
+		t.Errorf("WaitCount = %d, want the health check counted as waiting", status.WaitCount)
+	}
+}
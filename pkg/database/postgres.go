@@ -22,10 +22,14 @@ type Config struct {
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+	// QueryComments makes Annotate prefix queries with request and trace IDs
+	QueryComments bool
 }
 
 // NewPostgresDB creates a new Postgres connection
 func NewPostgresDB(ctx context.Context, cfg Config, logger *zap.Logger) (*sql.DB, error) {
+	queryComments.Store(cfg.QueryComments)
+
 	dsn := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Database, cfg.SSLMode,
@@ -61,10 +65,8 @@ func NewPostgresDB(ctx context.Context, cfg Config, logger *zap.Logger) (*sql.DB
 
 // HealthCheck checks database health
 func HealthCheck(ctx context.Context, db *sql.DB) error {
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
-	defer cancel()
-
-	return db.PingContext(ctx)
+	_, err := HealthCheckDetailed(ctx, db, DefaultHealthCheckTimeout)
+	return err
 }
 
 // GetStats returns database statistics
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/mumumio1/coldy/pkg/database"
+
+// StartSpan starts a child span for a database query. The statement is
+// recorded with whitespace collapsed; parameter values are never recorded.
+// Use the returned context for the query and finish the span with EndSpan.
+func StartSpan(ctx context.Context, operation, query string) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName)
+	return tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", normalizeStatement(query)),
+		),
+	)
+}
+
+// EndSpan records err on the span, if any, and ends it. sql.ErrNoRows is
+// an expected outcome and is not recorded as an error.
+func EndSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func normalizeStatement(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
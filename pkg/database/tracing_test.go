@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startRPCSpan starts a span standing in for the gRPC server span
+func startRPCSpan(t *testing.T) (context.Context, trace.Span, *tracetest.SpanRecorder) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "orders.OrderService/GetOrder")
+	return ctx, span, recorder
+}
+
+func TestStartSpanCreatesChildSpan(t *testing.T) {
+	ctx, rpcSpan, recorder := startRPCSpan(t)
+
+	_, span := StartSpan(ctx, "orders.get_by_id", `
+		SELECT id, user_id, status
+		FROM orders
+		WHERE id = $1`)
+	EndSpan(span, nil)
+	rpcSpan.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(ended))
+	}
+	dbSpan := ended[0]
+
+	if dbSpan.Name() != "orders.get_by_id" {
+		t.Errorf("span name = %q, want orders.get_by_id", dbSpan.Name())
+	}
+	if dbSpan.Parent().SpanID() != rpcSpan.SpanContext().SpanID() {
+		t.Error("database span is not a child of the RPC span")
+	}
+	if dbSpan.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span kind = %s, want client", dbSpan.SpanKind())
+	}
+
+	attrs := make(map[attribute.Key]string)
+	for _, kv := range dbSpan.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+	want := map[attribute.Key]string{
+		"db.system":    "postgresql",
+		"db.operation": "orders.get_by_id",
+		"db.statement": "SELECT id, user_id, status FROM orders WHERE id = $1",
+	}
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("%s = %q, want %q", key, attrs[key], value)
+		}
+	}
+}
+
+func TestEndSpanStatus(t *testing.T) {
+	tests := map[string]struct {
+		err        error
+		wantStatus codes.Code
+	}{
+		"success":      {err: nil, wantStatus: codes.Unset},
+		"no rows":      {err: sql.ErrNoRows, wantStatus: codes.Unset},
+		"query failed": {err: errors.New("connection reset"), wantStatus: codes.Error},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, _, recorder := startRPCSpan(t)
+
+			_, span := StartSpan(ctx, "orders.get_by_id", "SELECT 1")
+			EndSpan(span, tt.err)
+
+			ended := recorder.Ended()
+			if len(ended) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(ended))
+			}
+			if got := ended[0].Status().Code; got != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
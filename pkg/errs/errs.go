@@ -0,0 +1,152 @@
+package errs
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is reported in the ErrorInfo detail of mapped statuses
+const Domain = "coldy"
+
+// Kind classifies a domain error for transport mapping
+type Kind int
+
+const (
+	// KindInternal is any error without a domain classification
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindInvalidArgument
+	KindFailedPrecondition
+	KindUnauthenticated
+	KindUnavailable
+	KindResourceExhausted
+)
+
+// Error is a domain error with a kind and a machine-readable reason.
+// Declare them as package sentinels and wrap with fmt.Errorf("%w: ...") to add detail;
+// errors.Is keeps working against the sentinel and the kind survives wrapping.
+type Error struct {
+	Kind    Kind
+	Reason  string // Stable, upper snake case, e.g. "INSUFFICIENT_STOCK"
+	Message string
+}
+
+// New creates a domain error
+func New(kind Kind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NotFound creates a not-found error
+func NotFound(reason, message string) *Error {
+	return New(KindNotFound, reason, message)
+}
+
+// Conflict creates a conflict error, e.g. a concurrent modification
+func Conflict(reason, message string) *Error {
+	return New(KindConflict, reason, message)
+}
+
+// InvalidArgument creates an invalid-argument error
+func InvalidArgument(reason, message string) *Error {
+	return New(KindInvalidArgument, reason, message)
+}
+
+// FailedPrecondition creates an error for operations not allowed in the current state
+func FailedPrecondition(reason, message string) *Error {
+	return New(KindFailedPrecondition, reason, message)
+}
+
+// Unauthenticated creates an unauthenticated error
+func Unauthenticated(reason, message string) *Error {
+	return New(KindUnauthenticated, reason, message)
+}
+
+// Unavailable creates an error for a dependency that is temporarily
+// unreachable; clients should retry
+func Unavailable(reason, message string) *Error {
+	return New(KindUnavailable, reason, message)
+}
+
+// ResourceExhausted creates an error for a request rejected by a quota or limit
+func ResourceExhausted(reason, message string) *Error {
+	return New(KindResourceExhausted, reason, message)
+}
+
+// KindOf returns the kind of the first domain error in err's chain
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// ReasonOf returns the reason of the first domain error in err's chain
+func ReasonOf(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	return ""
+}
+
+// Code returns the gRPC code for a kind
+func (k Kind) Code() codes.Code {
+	switch k {
+	case KindNotFound:
+		return codes.NotFound
+	case KindConflict:
+		return codes.Aborted
+	case KindInvalidArgument:
+		return codes.InvalidArgument
+	case KindFailedPrecondition:
+		return codes.FailedPrecondition
+	case KindUnauthenticated:
+		return codes.Unauthenticated
+	case KindUnavailable:
+		return codes.Unavailable
+	case KindResourceExhausted:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// ToGRPCStatus maps err to a gRPC status. Domain errors keep their full message
+// and carry an ErrorInfo detail with the reason; existing statuses pass through;
+// anything else becomes a generic Internal status so internals aren't leaked.
+func ToGRPCStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		st := status.New(e.Kind.Code(), err.Error())
+		if e.Reason == "" {
+			return st
+		}
+		withDetails, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+			Reason: e.Reason,
+			Domain: Domain,
+		})
+		if detailErr != nil {
+			return st
+		}
+		return withDetails
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	return status.New(codes.Internal, "internal error")
+}
@@ -0,0 +1,113 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToGRPCStatusMapsKinds(t *testing.T) {
+	tests := map[string]struct {
+		err  *Error
+		want codes.Code
+	}{
+		"not found":           {err: NotFound("ORDER_NOT_FOUND", "order not found"), want: codes.NotFound},
+		"conflict":            {err: Conflict("CONCURRENT_UPDATE", "concurrent update"), want: codes.Aborted},
+		"invalid argument":    {err: InvalidArgument("INVALID_QUANTITY", "invalid quantity"), want: codes.InvalidArgument},
+		"failed precondition": {err: FailedPrecondition("INSUFFICIENT_STOCK", "insufficient stock"), want: codes.FailedPrecondition},
+		"unauthenticated":     {err: Unauthenticated("INVALID_TOKEN", "invalid token"), want: codes.Unauthenticated},
+		"unavailable":         {err: Unavailable("PROVIDER_UNAVAILABLE", "provider unavailable"), want: codes.Unavailable},
+		"resource exhausted":  {err: ResourceExhausted("RATE_LIMITED", "rate limited"), want: codes.ResourceExhausted},
+		"internal":            {err: New(KindInternal, "BROKEN", "broken"), want: codes.Internal},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Wrapping adds detail without losing the kind or reason
+			wrapped := fmt.Errorf("%w: order o-1", tt.err)
+
+			st := ToGRPCStatus(wrapped)
+			if st.Code() != tt.want {
+				t.Errorf("code = %s, want %s", st.Code(), tt.want)
+			}
+			if st.Message() != wrapped.Error() {
+				t.Errorf("message = %q, want %q", st.Message(), wrapped.Error())
+			}
+
+			info := errorInfo(t, st)
+			if info == nil {
+				t.Fatal("status has no ErrorInfo detail")
+			}
+			if info.Reason != tt.err.Reason || info.Domain != Domain {
+				t.Errorf("ErrorInfo = %s/%s, want %s/%s", info.Domain, info.Reason, Domain, tt.err.Reason)
+			}
+		})
+	}
+}
+
+func TestToGRPCStatusWithoutReason(t *testing.T) {
+	st := ToGRPCStatus(NotFound("", "user not found"))
+	if st.Code() != codes.NotFound {
+		t.Errorf("code = %s, want NotFound", st.Code())
+	}
+	if info := errorInfo(t, st); info != nil {
+		t.Errorf("expected no ErrorInfo detail, got %v", info)
+	}
+}
+
+func TestToGRPCStatusPassesStatusesThrough(t *testing.T) {
+	st := ToGRPCStatus(status.Error(codes.PermissionDenied, "not your order"))
+	if st.Code() != codes.PermissionDenied || st.Message() != "not your order" {
+		t.Errorf("status = %s %q, want the original", st.Code(), st.Message())
+	}
+}
+
+func TestToGRPCStatusHidesInternalErrors(t *testing.T) {
+	st := ToGRPCStatus(errors.New("pq: connection refused to 10.0.0.5"))
+	if st.Code() != codes.Internal {
+		t.Errorf("code = %s, want Internal", st.Code())
+	}
+	if st.Message() != "internal error" {
+		t.Errorf("message = %q leaks the underlying error", st.Message())
+	}
+}
+
+func TestToGRPCStatusNil(t *testing.T) {
+	if st := ToGRPCStatus(nil); st.Code() != codes.OK {
+		t.Errorf("code = %s, want OK", st.Code())
+	}
+}
+
+func TestKindAndReasonOf(t *testing.T) {
+	errStock := FailedPrecondition("INSUFFICIENT_STOCK", "insufficient stock")
+	err := fmt.Errorf("failed to reserve: %w", fmt.Errorf("%w: product p-1", errStock))
+
+	if !errors.Is(err, errStock) {
+		t.Error("errors.Is lost the sentinel")
+	}
+	if got := KindOf(err); got != KindFailedPrecondition {
+		t.Errorf("KindOf = %d, want KindFailedPrecondition", got)
+	}
+	if got := ReasonOf(err); got != "INSUFFICIENT_STOCK" {
+		t.Errorf("ReasonOf = %q, want INSUFFICIENT_STOCK", got)
+	}
+
+	plain := errors.New("boom")
+	if KindOf(plain) != KindInternal || ReasonOf(plain) != "" {
+		t.Errorf("plain error classified as %d/%q", KindOf(plain), ReasonOf(plain))
+	}
+}
+
+func errorInfo(t *testing.T, st *status.Status) *errdetails.ErrorInfo {
+	t.Helper()
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+// Package events defines the payloads services write to their outbox and
+// publish to Pub/Sub. Producers marshal these types instead of ad hoc maps and
+// consumers decode into them, so a renamed field breaks the build rather than
+// a consumer. JSON field names are part of the wire format and must not change.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Order event types. Status changes are published as "order.<status>".
+const (
+	TypeOrderCreated          = "order.created"
+	TypeOrderUpdated          = "order.updated"
+	TypeOrderCanceled         = "order.canceled"
+	TypeOrderRefundRequested  = "order.refund_requested"
+	TypeOrderVelocityExceeded = "order.velocity_exceeded"
+)
+
+// Payment event types
+const (
+	TypePaymentSucceeded = "payment.succeeded"
+	TypePaymentFailed    = "payment.failed"
+	TypePaymentCanceled  = "payment.canceled"
+	TypePaymentRefunded  = "payment.refunded"
+)
+
+// Event is implemented by every event payload
+type Event interface {
+	EventType() string
+}
+
+// OrderItem is an order line as carried in order events
+type OrderItem struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	Quantity    int32  `json:"quantity"`
+	// UnitPrice is in minor units of the order currency
+	UnitPrice int64 `json:"unit_price"`
+}
+
+// OrderCreated is published when an order is placed
+type OrderCreated struct {
+	OrderID  string      `json:"order_id"`
+	UserID   string      `json:"user_id"`
+	Total    int64       `json:"total"`
+	Currency string      `json:"currency"`
+	Status   string      `json:"status"`
+	Items    []OrderItem `json:"items"`
+}
+
+// EventType returns order.created
+func (OrderCreated) EventType() string { return TypeOrderCreated }
+
+// OrderUpdated is published when the items of a pending order change
+type OrderUpdated struct {
+	OrderID       string      `json:"order_id"`
+	UserID        string      `json:"user_id"`
+	Total         int64       `json:"total"`
+	PreviousTotal int64       `json:"previous_total"`
+	Currency      string      `json:"currency"`
+	Items         []OrderItem `json:"items"`
+}
+
+// EventType returns order.updated
+func (OrderUpdated) EventType() string { return TypeOrderUpdated }
+
+// OrderStatusChanged is published when an order moves to a new status
+type OrderStatusChanged struct {
+	OrderID string `json:"order_id"`
+	Status  string `json:"status"`
+}
+
+// EventType returns order.<status>
+func (e OrderStatusChanged) EventType() string { return "order." + e.Status }
+
+// OrderCanceled is published when an order is canceled
+type OrderCanceled struct {
+	OrderID string `json:"order_id"`
+	UserID  string `json:"user_id"`
+	Reason  string `json:"reason"`
+}
+
+// EventType returns order.canceled
+func (OrderCanceled) EventType() string { return TypeOrderCanceled }
+
+// OrderRefundRequested is published when a paid order is canceled, asking
+// payments to refund it. It is written once per order; payments moves the
+// order to refunded once the refund succeeds.
+type OrderRefundRequested struct {
+	OrderID   string `json:"order_id"`
+	UserID    string `json:"user_id"`
+	PaymentID string `json:"payment_id,omitempty"`
+	// Amount is the order total in minor units of Currency
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Reason   string `json:"reason"`
+}
+
+// EventType returns order.refund_requested
+func (OrderRefundRequested) EventType() string { return TypeOrderRefundRequested }
+
+// OrderVelocityExceeded is recorded for review when a user's order is
+// rejected by the velocity limits
+type OrderVelocityExceeded struct {
+	UserID          string `json:"user_id"`
+	Currency        string `json:"currency"`
+	AttemptedAmount int64  `json:"attempted_amount"`
+	WindowOrders    int64  `json:"window_orders"`
+	WindowAmount    int64  `json:"window_amount"`
+	MaxOrders       int64  `json:"max_orders"`
+	MaxAmount       int64  `json:"max_amount"`
+}
+
+// EventType returns order.velocity_exceeded
+func (OrderVelocityExceeded) EventType() string { return TypeOrderVelocityExceeded }
+
+// Reconciliation is set on payment events emitted when a payment stuck in
+// processing is corrected from the provider's record, instead of by the
+// charge, cancel or refund itself
+type Reconciliation struct {
+	Reconciled     bool   `json:"reconciled,omitempty"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+}
+
+// PaymentSucceeded is published when a payment is charged
+type PaymentSucceeded struct {
+	PaymentID     string `json:"payment_id"`
+	OrderID       string `json:"order_id"`
+	UserID        string `json:"user_id"`
+	TransactionID string `json:"transaction_id"`
+	Reconciliation
+}
+
+// EventType returns payment.succeeded
+func (PaymentSucceeded) EventType() string { return TypePaymentSucceeded }
+
+// PaymentFailed is published when a charge fails
+type PaymentFailed struct {
+	PaymentID string `json:"payment_id"`
+	OrderID   string `json:"order_id"`
+	UserID    string `json:"user_id"`
+	Error     string `json:"error"`
+	Reconciliation
+}
+
+// EventType returns payment.failed
+func (PaymentFailed) EventType() string { return TypePaymentFailed }
+
+// PaymentCanceled is published when a payment is canceled before it settles
+type PaymentCanceled struct {
+	PaymentID string `json:"payment_id"`
+	OrderID   string `json:"order_id"`
+	UserID    string `json:"user_id"`
+	Reason    string `json:"reason,omitempty"`
+	Reconciliation
+}
+
+// EventType returns payment.canceled
+func (PaymentCanceled) EventType() string { return TypePaymentCanceled }
+
+// PaymentRefunded is published for every refund, partial or full
+type PaymentRefunded struct {
+	PaymentID string `json:"payment_id"`
+	OrderID   string `json:"order_id"`
+	UserID    string `json:"user_id"`
+	RefundID  string `json:"refund_id,omitempty"`
+	// Amount is this refund and RefundedAmount the sum of the refunds so far,
+	// both in minor units of Currency. A reconciled refund carries neither.
+	Amount         int64  `json:"amount,omitempty"`
+	RefundedAmount int64  `json:"refunded_amount,omitempty"`
+	Currency       string `json:"currency"`
+	Reason         string `json:"reason,omitempty"`
+	Reconciliation
+}
+
+// EventType returns payment.refunded
+func (PaymentRefunded) EventType() string { return TypePaymentRefunded }
+
+// Decode decodes an event payload, e.g. the data of a Pub/Sub message
+func Decode[T Event](data []byte) (T, error) {
+	var event T
+	if err := json.Unmarshal(data, &event); err != nil {
+		return event, fmt.Errorf("failed to decode %s event: %w", event.EventType(), err)
+	}
+	return event, nil
+}
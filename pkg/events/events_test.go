@@ -0,0 +1,163 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// roundTrip marshals event as the outbox does and decodes it as a consumer does
+func roundTrip[T Event](t *testing.T, event T) T {
+	t.Helper()
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", event.EventType(), err)
+	}
+	decoded, err := Decode[T](data)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", event.EventType(), err)
+	}
+	return decoded
+}
+
+// jsonFields returns the sorted top-level JSON field names of event
+func jsonFields(t *testing.T, event Event) []string {
+	t.Helper()
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", event.EventType(), err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", event.EventType(), err)
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRoundTrip(t *testing.T) {
+	items := []OrderItem{{ProductID: "p-1", ProductName: "Mug", Quantity: 2, UnitPrice: 1250}}
+	reconciliation := Reconciliation{Reconciled: true, PreviousStatus: "processing"}
+
+	tests := []Event{
+		OrderCreated{OrderID: "o-1", UserID: "u-1", Total: 2500, Currency: "USD", Status: "pending", Items: items},
+		OrderUpdated{OrderID: "o-1", UserID: "u-1", Total: 3750, PreviousTotal: 2500, Currency: "USD", Items: items},
+		OrderStatusChanged{OrderID: "o-1", Status: "paid"},
+		OrderCanceled{OrderID: "o-1", UserID: "u-1", Reason: "changed my mind"},
+		OrderRefundRequested{OrderID: "o-1", UserID: "u-1", PaymentID: "pay-1", Amount: 2500, Currency: "USD", Reason: "canceled"},
+		OrderVelocityExceeded{UserID: "u-1", Currency: "USD", AttemptedAmount: 100, WindowOrders: 5, WindowAmount: 9000, MaxOrders: 5, MaxAmount: 10000},
+		PaymentSucceeded{PaymentID: "pay-1", OrderID: "o-1", UserID: "u-1", TransactionID: "txn-1", Reconciliation: reconciliation},
+		PaymentFailed{PaymentID: "pay-1", OrderID: "o-1", UserID: "u-1", Error: "declined"},
+		PaymentCanceled{PaymentID: "pay-1", OrderID: "o-1", UserID: "u-1", Reason: "duplicate"},
+		PaymentRefunded{PaymentID: "pay-1", OrderID: "o-1", UserID: "u-1", RefundID: "r-1", Amount: 500, RefundedAmount: 1500, Currency: "USD", Reason: "damaged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.EventType(), func(t *testing.T) {
+			var decoded Event
+			switch event := tt.(type) {
+			case OrderCreated:
+				decoded = roundTrip(t, event)
+			case OrderUpdated:
+				decoded = roundTrip(t, event)
+			case OrderStatusChanged:
+				decoded = roundTrip(t, event)
+			case OrderCanceled:
+				decoded = roundTrip(t, event)
+			case OrderRefundRequested:
+				decoded = roundTrip(t, event)
+			case OrderVelocityExceeded:
+				decoded = roundTrip(t, event)
+			case PaymentSucceeded:
+				decoded = roundTrip(t, event)
+			case PaymentFailed:
+				decoded = roundTrip(t, event)
+			case PaymentCanceled:
+				decoded = roundTrip(t, event)
+			case PaymentRefunded:
+				decoded = roundTrip(t, event)
+			default:
+				t.Fatalf("no round trip for %T", event)
+			}
+			if !reflect.DeepEqual(decoded, tt) {
+				t.Errorf("round trip changed the event:\n got %+v\nwant %+v", decoded, tt)
+			}
+		})
+	}
+}
+
+// TestFieldNames pins the wire format consumers depend on
+func TestFieldNames(t *testing.T) {
+	tests := []struct {
+		event Event
+		want  []string
+	}{
+		{
+			OrderCreated{Items: []OrderItem{}},
+			[]string{"currency", "items", "order_id", "status", "total", "user_id"},
+		},
+		{
+			OrderUpdated{Items: []OrderItem{}},
+			[]string{"currency", "items", "order_id", "previous_total", "total", "user_id"},
+		},
+		{
+			OrderStatusChanged{Status: "paid"},
+			[]string{"order_id", "status"},
+		},
+		{
+			OrderCanceled{},
+			[]string{"order_id", "reason", "user_id"},
+		},
+		{
+			OrderRefundRequested{PaymentID: "pay-1"},
+			[]string{"amount", "currency", "order_id", "payment_id", "reason", "user_id"},
+		},
+		{
+			OrderVelocityExceeded{},
+			[]string{"attempted_amount", "currency", "max_amount", "max_orders", "user_id", "window_amount", "window_orders"},
+		},
+		{
+			PaymentSucceeded{Reconciliation: Reconciliation{Reconciled: true, PreviousStatus: "processing"}},
+			[]string{"order_id", "payment_id", "previous_status", "reconciled", "transaction_id", "user_id"},
+		},
+		{
+			PaymentFailed{},
+			[]string{"error", "order_id", "payment_id", "user_id"},
+		},
+		{
+			PaymentCanceled{Reason: "duplicate"},
+			[]string{"order_id", "payment_id", "reason", "user_id"},
+		},
+		{
+			PaymentRefunded{RefundID: "r-1", Amount: 500, RefundedAmount: 500, Reason: "damaged"},
+			[]string{"amount", "currency", "order_id", "payment_id", "reason", "refund_id", "refunded_amount", "user_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.event.EventType(), func(t *testing.T) {
+			if got := jsonFields(t, tt.event); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	data, err := json.Marshal(OrderItem{ProductName: "Mug"})
+	if err != nil {
+		t.Fatalf("failed to marshal order item: %v", err)
+	}
+	if want := `{"product_id":"","product_name":"Mug","quantity":0,"unit_price":0}`; string(data) != want {
+		t.Errorf("order item = %s, want %s", data, want)
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	if _, err := Decode[OrderCreated]([]byte(`{"total":"lots"}`)); err == nil {
+		t.Error("expected an error for a mistyped field")
+	}
+}
@@ -0,0 +1,119 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/config"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// KeepaliveConfig configures server-side connection keepalive
+type KeepaliveConfig struct {
+	// MaxConnectionIdle closes connections with no active RPCs for this long
+	MaxConnectionIdle time.Duration `env:"GRPC_MAX_CONNECTION_IDLE" validate:"min=0s"`
+	// Time is how long a connection may be silent before the server pings it
+	Time time.Duration `env:"GRPC_KEEPALIVE_TIME" validate:"min=0s"`
+	// Timeout is how long the server waits for a ping ack before closing
+	Timeout time.Duration `env:"GRPC_KEEPALIVE_TIMEOUT" validate:"min=0s"`
+	// MinTime is the shortest client ping interval allowed; faster clients are disconnected
+	MinTime time.Duration `env:"GRPC_KEEPALIVE_MIN_TIME" validate:"min=0s"`
+	// PermitWithoutStream allows client pings when no RPC is active
+	PermitWithoutStream bool
+}
+
+// DefaultKeepaliveConfig returns the default keepalive configuration
+func DefaultKeepaliveConfig() KeepaliveConfig {
+	return KeepaliveConfig{
+		MaxConnectionIdle:   5 * time.Minute,
+		Time:                time.Minute,
+		Timeout:             20 * time.Second,
+		MinTime:             30 * time.Second,
+		PermitWithoutStream: true,
+	}
+}
+
+// LoadKeepaliveConfig loads the keepalive settings from the environment over
+// DefaultKeepaliveConfig
+func LoadKeepaliveConfig() (KeepaliveConfig, error) {
+	cfg := DefaultKeepaliveConfig()
+	if err := config.Load(&cfg); err != nil {
+		return KeepaliveConfig{}, err
+	}
+	return cfg, nil
+}
+
+// New creates a gRPC server with keepalive parameters and enforcement applied
+func New(cfg KeepaliveConfig, opts ...grpc.ServerOption) *grpc.Server {
+	defaults := DefaultKeepaliveConfig()
+	if cfg.MaxConnectionIdle <= 0 {
+		cfg.MaxConnectionIdle = defaults.MaxConnectionIdle
+	}
+	if cfg.Time <= 0 {
+		cfg.Time = defaults.Time
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.MinTime <= 0 {
+		cfg.MinTime = defaults.MinTime
+	}
+
+	opts = append([]grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle: cfg.MaxConnectionIdle,
+			Time:              cfg.Time,
+			Timeout:           cfg.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.MinTime,
+			PermitWithoutStream: cfg.PermitWithoutStream,
+		}),
+	}, opts...)
+
+	return grpc.NewServer(opts...)
+}
+
+// DefaultMaxConcurrentStreams caps concurrent streams per client connection
+const DefaultMaxConcurrentStreams = 100
+
+// MaxConcurrentStreams returns the server option capping concurrent streams
+// per connection at n, or at DefaultMaxConcurrentStreams when n is not positive
+func MaxConcurrentStreams(n int) grpc.ServerOption {
+	if n <= 0 {
+		n = DefaultMaxConcurrentStreams
+	}
+	return grpc.MaxConcurrentStreams(uint32(n))
+}
+
+// DefaultShutdownTimeout bounds how long Stop waits for in-flight RPCs
+const DefaultShutdownTimeout = 20 * time.Second
+
+// Stop gracefully stops the server, forcing it closed if in-flight RPCs have
+// not finished within timeout. It reports whether the stop was forced.
+func Stop(server *grpc.Server, timeout time.Duration, logger *zap.Logger) bool {
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return false
+	case <-timer.C:
+		logger.Warn("graceful stop timed out, forcing shutdown", zap.Duration("timeout", timeout))
+		// Stop closes remaining connections, which also unblocks GracefulStop
+		server.Stop()
+		<-done
+		return true
+	}
+}
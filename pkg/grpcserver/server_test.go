@@ -0,0 +1,39 @@
+package grpcserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadKeepaliveConfigDefaults(t *testing.T) {
+	cfg, err := LoadKeepaliveConfig()
+	if err != nil {
+		t.Fatalf("LoadKeepaliveConfig failed: %v", err)
+	}
+	if cfg != DefaultKeepaliveConfig() {
+		t.Errorf("got %+v, want the defaults %+v", cfg, DefaultKeepaliveConfig())
+	}
+}
+
+func TestLoadKeepaliveConfigOverrides(t *testing.T) {
+	t.Setenv("GRPC_KEEPALIVE_TIME", "2m")
+
+	cfg, err := LoadKeepaliveConfig()
+	if err != nil {
+		t.Fatalf("LoadKeepaliveConfig failed: %v", err)
+	}
+	if cfg.Time != 2*time.Minute {
+		t.Errorf("Time = %s, want 2m0s", cfg.Time)
+	}
+	if cfg.Timeout != DefaultKeepaliveConfig().Timeout {
+		t.Errorf("Timeout = %s, want the default", cfg.Timeout)
+	}
+}
+
+func TestLoadKeepaliveConfigRejectsInvalidValue(t *testing.T) {
+	t.Setenv("GRPC_MAX_CONNECTION_IDLE", "five minutes")
+
+	if _, err := LoadKeepaliveConfig(); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+}
@@ -0,0 +1,146 @@
+package grpcserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultMinTLSVersion is the lowest TLS version accepted unless configured higher
+const DefaultMinTLSVersion = tls.VersionTLS12
+
+// ErrNoCertificate is returned when TLS has no certificate and plaintext was
+// not explicitly allowed
+var ErrNoCertificate = errors.New("no TLS certificate configured and insecure mode is off")
+
+// TLSConfig configures server transport security
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables mutual TLS: clients must present a certificate
+	// signed by one of its CAs
+	ClientCAFile string
+	// MinVersion is the lowest accepted TLS version; below TLS 1.2 is refused
+	MinVersion uint16
+	// Insecure serves plaintext when no certificate is configured. Only for
+	// local development or behind a mesh that terminates TLS.
+	Insecure bool
+}
+
+// Credentials returns the server option applying cfg's transport security
+func Credentials(cfg TLSConfig) (grpc.ServerOption, error) {
+	if cfg.CertFile == "" && cfg.KeyFile == "" {
+		if !cfg.Insecure {
+			return nil, ErrNoCertificate
+		}
+		return grpc.Creds(insecure.NewCredentials()), nil
+	}
+
+	minVersion, err := minTLSVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   minVersion,
+	}
+	if cfg.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
+
+// ClientTLSConfig configures transport security for calls to other services
+type ClientTLSConfig struct {
+	// CAFile verifies servers; empty uses the system roots
+	CAFile string
+	// CertFile and KeyFile are presented to servers that require mutual TLS
+	CertFile string
+	KeyFile  string
+	// MinVersion is the lowest accepted TLS version; below TLS 1.2 is refused
+	MinVersion uint16
+	// Insecure dials in plaintext when no CA is configured
+	Insecure bool
+}
+
+// ClientCredentials returns the dial option applying cfg's transport security
+func ClientCredentials(cfg ClientTLSConfig) (grpc.DialOption, error) {
+	if cfg.CAFile == "" && cfg.Insecure {
+		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
+	}
+
+	minVersion, err := minTLSVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{MinVersion: minVersion}
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
+
+// ParseTLSVersion parses a TLS version such as "1.2" or "1.3"
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q", version)
+	}
+}
+
+func minTLSVersion(version uint16) (uint16, error) {
+	if version == 0 {
+		return DefaultMinTLSVersion, nil
+	}
+	if version < tls.VersionTLS12 {
+		return 0, fmt.Errorf("minimum TLS version %s is below TLS 1.2", tls.VersionName(version))
+	}
+	return version, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,74 @@
+package grpcserver
+
+import (
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/config"
+	"google.golang.org/grpc"
+)
+
+// TransportConfig is a service's gRPC transport security, for both the server
+// and its clients of other services. Plaintext is only allowed with
+// GRPC_INSECURE=true, whatever the environment.
+type TransportConfig struct {
+	// Insecure serves and dials plaintext where no certificate or CA is
+	// configured, for local development or behind a mesh that terminates TLS
+	Insecure   bool   `env:"GRPC_INSECURE" default:"false"`
+	MinVersion string `env:"GRPC_TLS_MIN_VERSION" default:"1.2" validate:"oneof=1.2 1.3"`
+
+	CertFile     string `env:"GRPC_TLS_CERT_FILE"`
+	KeyFile      string `env:"GRPC_TLS_KEY_FILE"`
+	ClientCAFile string `env:"GRPC_TLS_CLIENT_CA_FILE"`
+
+	CAFile         string `env:"GRPC_TLS_CA_FILE"`
+	ClientCertFile string `env:"GRPC_TLS_CLIENT_CERT_FILE"`
+	ClientKeyFile  string `env:"GRPC_TLS_CLIENT_KEY_FILE"`
+}
+
+// LoadTransportConfig loads the transport security from the environment
+func LoadTransportConfig() (TransportConfig, error) {
+	var cfg TransportConfig
+	if err := config.Load(&cfg); err != nil {
+		return TransportConfig{}, err
+	}
+	return cfg, nil
+}
+
+// ServerCredentials returns the server option applying the transport security
+func (c TransportConfig) ServerCredentials() (grpc.ServerOption, error) {
+	minVersion, err := ParseTLSVersion(c.MinVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GRPC_TLS_MIN_VERSION: %w", err)
+	}
+	creds, err := Credentials(TLSConfig{
+		CertFile:     c.CertFile,
+		KeyFile:      c.KeyFile,
+		ClientCAFile: c.ClientCAFile,
+		MinVersion:   minVersion,
+		Insecure:     c.Insecure,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gRPC server credentials: %w", err)
+	}
+	return creds, nil
+}
+
+// ClientCredentials returns the dial option applying the transport security
+// to calls to other services
+func (c TransportConfig) ClientCredentials() (grpc.DialOption, error) {
+	minVersion, err := ParseTLSVersion(c.MinVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GRPC_TLS_MIN_VERSION: %w", err)
+	}
+	creds, err := ClientCredentials(ClientTLSConfig{
+		CAFile:     c.CAFile,
+		CertFile:   c.ClientCertFile,
+		KeyFile:    c.ClientKeyFile,
+		MinVersion: minVersion,
+		Insecure:   c.Insecure,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gRPC client credentials: %w", err)
+	}
+	return creds, nil
+}
@@ -0,0 +1,45 @@
+package grpcserver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransportRequiresExplicitInsecure(t *testing.T) {
+	// A development environment alone must not turn on plaintext
+	t.Setenv("ENV", "development")
+
+	transport, err := LoadTransportConfig()
+	if err != nil {
+		t.Fatalf("LoadTransportConfig failed: %v", err)
+	}
+	if transport.Insecure {
+		t.Fatal("insecure mode is on without GRPC_INSECURE")
+	}
+	if _, err := transport.ServerCredentials(); !errors.Is(err, ErrNoCertificate) {
+		t.Errorf("expected ErrNoCertificate without a certificate, got %v", err)
+	}
+}
+
+func TestTransportInsecure(t *testing.T) {
+	t.Setenv("GRPC_INSECURE", "true")
+
+	transport, err := LoadTransportConfig()
+	if err != nil {
+		t.Fatalf("LoadTransportConfig failed: %v", err)
+	}
+	if _, err := transport.ServerCredentials(); err != nil {
+		t.Errorf("unexpected server credentials error: %v", err)
+	}
+	if _, err := transport.ClientCredentials(); err != nil {
+		t.Errorf("unexpected client credentials error: %v", err)
+	}
+}
+
+func TestTransportRejectsOldTLSVersion(t *testing.T) {
+	t.Setenv("GRPC_TLS_MIN_VERSION", "1.1")
+
+	if _, err := LoadTransportConfig(); err == nil {
+		t.Error("expected TLS 1.1 to be rejected")
+	}
+}
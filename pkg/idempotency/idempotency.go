@@ -6,8 +6,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/mumumio1/coldy/pkg/circuitbreaker"
+	"github.com/mumumio1/coldy/pkg/errs"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -16,18 +19,128 @@ const (
 	KeyPrefix  = "idempotency:"
 )
 
+// ErrUnavailable is returned when the idempotency store cannot be reached
+var ErrUnavailable = errs.Unavailable("IDEMPOTENCY_UNAVAILABLE", "idempotency store unavailable")
+
+// FailurePolicy decides how callers treat an unreachable store
+type FailurePolicy int
+
+const (
+	// FailOpen proceeds without idempotency, risking duplicates on retry
+	FailOpen FailurePolicy = iota
+	// FailClosed rejects the request so the client retries later
+	FailClosed
+)
+
+// Config configures a Store
+type Config struct {
+	Policy FailurePolicy
+	// TTL is how long results are kept; DefaultTTL when zero
+	TTL time.Duration
+	// OperationTTL overrides TTL per operation, e.g. a shorter TTL for
+	// high-volume operations whose retries arrive within minutes
+	OperationTTL map[string]time.Duration
+	// Breaker guards the Redis calls so a sustained outage fails fast
+	// instead of adding latency to every request
+	Breaker circuitbreaker.Config
+}
+
+// DefaultConfig returns the default store configuration
+func DefaultConfig() Config {
+	return Config{
+		Policy: FailOpen,
+		TTL:    DefaultTTL,
+		Breaker: circuitbreaker.Config{
+			MaxFailures:  5,
+			Timeout:      500 * time.Millisecond,
+			ResetTimeout: 10 * time.Second,
+		},
+	}
+}
+
 // Store handles idempotency keys
 type Store struct {
-	redis *redis.Client
+	redis        *redis.Client
+	policy       FailurePolicy
+	breaker      *circuitbreaker.CircuitBreaker
+	ttl          time.Duration
+	operationTTL map[string]time.Duration
 }
 
-// NewStore creates a new idempotency store
+// NewStore creates a new idempotency store with the default configuration
 func NewStore(redis *redis.Client) *Store {
+	return NewStoreWithConfig(redis, DefaultConfig())
+}
+
+// NewStoreWithConfig creates a new idempotency store
+func NewStoreWithConfig(redis *redis.Client, cfg Config) *Store {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	for operation, opTTL := range cfg.OperationTTL {
+		keyTTL.WithLabelValues(operation).Set(opTTL.Seconds())
+	}
 	return &Store{
-		redis: redis,
+		redis:        redis,
+		policy:       cfg.Policy,
+		breaker:      circuitbreaker.New(cfg.Breaker),
+		ttl:          ttl,
+		operationTTL: cfg.OperationTTL,
 	}
 }
 
+// TTL returns how long results of operation are kept
+func (s *Store) TTL(operation string) time.Duration {
+	if ttl, ok := s.operationTTL[operation]; ok && ttl > 0 {
+		return ttl
+	}
+	return s.ttl
+}
+
+// ParseOperationTTL parses "operation=duration" pairs separated by commas,
+// e.g. "create_order=6h,cancel_order=1h"
+func ParseOperationTTL(s string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		operation, value, ok := strings.Cut(pair, "=")
+		if !ok || operation == "" {
+			return nil, fmt.Errorf("invalid operation TTL %q", pair)
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation TTL %q: %w", pair, err)
+		}
+		if ttl <= 0 {
+			return nil, fmt.Errorf("invalid operation TTL %q: must be positive", pair)
+		}
+		ttls[operation] = ttl
+	}
+	return ttls, nil
+}
+
+// FailOpen reports whether callers should proceed without idempotency when
+// the store returns ErrUnavailable
+func (s *Store) FailOpen() bool {
+	return s.policy == FailOpen
+}
+
+// do runs a Redis call through the circuit breaker; failures are wrapped in
+// ErrUnavailable
+func (s *Store) do(ctx context.Context, op string, fn func(ctx context.Context) error) error {
+	err := s.breaker.Execute(ctx, func() error {
+		return fn(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrUnavailable, op, err)
+	}
+	return nil
+}
+
 // Result represents a cached result
 type Result struct {
 	StatusCode int             `json:"status_code"`
@@ -42,26 +155,40 @@ func GenerateKey(userID, operation, idempotencyKey string) string {
 	return KeyPrefix + hex.EncodeToString(hash[:])
 }
 
-// Get retrieves a cached result
-func (s *Store) Get(ctx context.Context, key string) (*Result, bool, error) {
-	data, err := s.redis.Get(ctx, key).Bytes()
-	if err == redis.Nil {
-		return nil, false, nil
-	}
+// Get retrieves a cached result. operation labels the lookup metrics and
+// should match the operation passed to GenerateKey.
+func (s *Store) Get(ctx context.Context, operation, key string) (*Result, bool, error) {
+	var data []byte
+	err := s.do(ctx, "get", func(ctx context.Context) error {
+		var err error
+		data, err = s.redis.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			data = nil
+			return nil
+		}
+		return err
+	})
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to get idempotency key: %w", err)
+		lookups.WithLabelValues(operation, ResultError).Inc()
+		return nil, false, err
+	}
+	if data == nil {
+		lookups.WithLabelValues(operation, ResultMiss).Inc()
+		return nil, false, nil
 	}
 
 	var result Result
 	if err := json.Unmarshal(data, &result); err != nil {
+		lookups.WithLabelValues(operation, ResultError).Inc()
 		return nil, false, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
 
+	lookups.WithLabelValues(operation, ResultHit).Inc()
 	return &result, true, nil
 }
 
-// Set stores a result with an idempotency key
-func (s *Store) Set(ctx context.Context, key string, statusCode int, body interface{}) error {
+// Set stores a result with an idempotency key for the TTL of operation
+func (s *Store) Set(ctx context.Context, operation, key string, statusCode int, body interface{}) error {
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("failed to marshal body: %w", err)
@@ -78,11 +205,11 @@ func (s *Store) Set(ctx context.Context, key string, statusCode int, body interf
 		return fmt.Errorf("failed to marshal result: %w", err)
 	}
 
-	if err := s.redis.Set(ctx, key, data, DefaultTTL).Err(); err != nil {
-		return fmt.Errorf("failed to set idempotency key: %w", err)
-	}
-
-	return nil
+	ttl := s.TTL(operation)
+	keyTTL.WithLabelValues(operation).Set(ttl.Seconds())
+	return s.do(ctx, "set", func(ctx context.Context) error {
+		return s.redis.Set(ctx, key, data, ttl).Err()
+	})
 }
 
 // Delete removes an idempotency key
@@ -93,11 +220,15 @@ func (s *Store) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
-// SetNX sets a key only if it doesn't exist (for lock-based idempotency)
-func (s *Store) SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+// SetNX sets a key only if it doesn't exist (for lock-based idempotency).
+// A held lock is counted as contention for operation.
+func (s *Store) SetNX(ctx context.Context, operation, key string, ttl time.Duration) (bool, error) {
 	ok, err := s.redis.SetNX(ctx, key, "locked", ttl).Result()
 	if err != nil {
 		return false, fmt.Errorf("failed to setnx: %w", err)
 	}
+	if !ok {
+		lockContentions.WithLabelValues(operation).Inc()
+	}
 	return ok, nil
 }
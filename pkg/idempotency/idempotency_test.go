@@ -0,0 +1,131 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/redistest"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestParseOperationTTL(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		"empty":         {input: "", want: map[string]time.Duration{}},
+		"single":        {input: "create_order=6h", want: map[string]time.Duration{"create_order": 6 * time.Hour}},
+		"several":       {input: " create_order=6h , cancel_order=1h,", want: map[string]time.Duration{"create_order": 6 * time.Hour, "cancel_order": time.Hour}},
+		"missing value": {input: "create_order", wantErr: true},
+		"no operation":  {input: "=1h", wantErr: true},
+		"bad duration":  {input: "create_order=soon", wantErr: true},
+		"zero duration": {input: "create_order=0s", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseOperationTTL(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOperationTTL failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for operation, ttl := range tt.want {
+				if got[operation] != ttl {
+					t.Errorf("%s = %v, want %v", operation, got[operation], ttl)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreTTL(t *testing.T) {
+	s := NewStoreWithConfig(nil, Config{
+		TTL:          2 * time.Hour,
+		OperationTTL: map[string]time.Duration{"create_order": 10 * time.Minute},
+	})
+	if got := s.TTL("create_order"); got != 10*time.Minute {
+		t.Errorf("TTL(create_order) = %v, want 10m", got)
+	}
+	if got := s.TTL("cancel_order"); got != 2*time.Hour {
+		t.Errorf("TTL(cancel_order) = %v, want the store TTL", got)
+	}
+	if got := NewStoreWithConfig(nil, Config{}).TTL("create_order"); got != DefaultTTL {
+		t.Errorf("TTL without configuration = %v, want %v", got, DefaultTTL)
+	}
+}
+
+func TestSetUsesOperationTTL(t *testing.T) {
+	ctx := context.Background()
+	server := redistest.NewServer(t)
+	cfg := DefaultConfig()
+	cfg.OperationTTL = map[string]time.Duration{"create_order": 10 * time.Minute}
+	s := NewStoreWithConfig(server.NewClient(t), cfg)
+
+	key := GenerateKey("user-1", "create_order", "key-1")
+	if err := s.Set(ctx, "create_order", key, 200, map[string]string{"order_id": "order-1"}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if ttl := server.TTL(key); ttl <= 9*time.Minute || ttl > 10*time.Minute {
+		t.Errorf("key TTL = %v, want the 10m operation TTL", ttl)
+	}
+
+	result, found, err := s.Get(ctx, "create_order", key)
+	if err != nil || !found {
+		t.Fatalf("Get = %v, %v; want the stored result", found, err)
+	}
+	if result.StatusCode != 200 || string(result.Body) != `{"order_id":"order-1"}` {
+		t.Errorf("result = %d %s", result.StatusCode, result.Body)
+	}
+}
+
+func TestGetMiss(t *testing.T) {
+	s := NewStore(redistest.NewServer(t).NewClient(t))
+
+	if _, found, err := s.Get(context.Background(), "create_order", GenerateKey("user-1", "create_order", "key-1")); err != nil || found {
+		t.Errorf("Get = %v, %v; want a miss", found, err)
+	}
+}
+
+func TestStoreUnavailable(t *testing.T) {
+	// Nothing listens on the discard port, so every Redis call fails
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:9", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer func() { _ = client.Close() }()
+	s := NewStore(client)
+
+	if _, _, err := s.Get(context.Background(), "create_order", "key"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Get: expected ErrUnavailable, got %v", err)
+	}
+	if err := s.Set(context.Background(), "create_order", "key", 200, nil); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Set: expected ErrUnavailable, got %v", err)
+	}
+	if !s.FailOpen() {
+		t.Error("default store does not fail open")
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	key := GenerateKey("user-1", "create_order", "key-1")
+	if key != GenerateKey("user-1", "create_order", "key-1") {
+		t.Error("GenerateKey differs for the same request")
+	}
+	for _, other := range []string{
+		GenerateKey("user-2", "create_order", "key-1"),
+		GenerateKey("user-1", "cancel_order", "key-1"),
+		GenerateKey("user-1", "create_order", "key-2"),
+	} {
+		if other == key {
+			t.Errorf("key %s shared by different requests", key)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package idempotency
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Lookup results
+const (
+	ResultHit   = "hit"   // a duplicate request was answered from the store
+	ResultMiss  = "miss"  // the request is new
+	ResultError = "error" // the store could not be read
+)
+
+var (
+	lookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "coldy",
+		Subsystem: "idempotency",
+		Name:      "lookups_total",
+		Help:      "Total number of idempotency key lookups by operation and result",
+	}, []string{"operation", "result"})
+	lockContentions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "coldy",
+		Subsystem: "idempotency",
+		Name:      "lock_contentions_total",
+		Help:      "Total number of idempotency locks found already held, by operation",
+	}, []string{"operation"})
+	keyTTL = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "coldy",
+		Subsystem: "idempotency",
+		Name:      "key_ttl_seconds",
+		Help:      "Effective TTL of idempotency keys by operation",
+	}, []string{"operation"})
+	liveKeys = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "coldy",
+		Subsystem: "idempotency",
+		Name:      "keys",
+		Help:      "Number of idempotency keys in Redis as of the last sweep",
+	})
+	keysWithoutTTL = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "coldy",
+		Subsystem: "idempotency",
+		Name:      "keys_without_ttl_total",
+		Help:      "Total number of idempotency keys found without a TTL and given one by the sweeper",
+	})
+)
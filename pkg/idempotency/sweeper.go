@@ -0,0 +1,94 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// sweepBatchSize is the SCAN count hint used by the sweeper
+const sweepBatchSize = 1000
+
+// Sweep scans the idempotency keys, updates the key count metric and gives
+// keys that have no TTL the default one, so a key written without an expiry
+// cannot stay in Redis forever. Redis expires the rest itself. It returns
+// the number of keys found.
+func (s *Store) Sweep(ctx context.Context) (int64, error) {
+	var count int64
+	var cursor uint64
+	for {
+		keys, next, err := s.redis.Scan(ctx, cursor, KeyPrefix+"*", sweepBatchSize).Result()
+		if err != nil {
+			return count, fmt.Errorf("failed to scan idempotency keys: %w", err)
+		}
+		count += int64(len(keys))
+
+		if len(keys) > 0 {
+			if err := s.expireWithoutTTL(ctx, keys); err != nil {
+				return count, err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	liveKeys.Set(float64(count))
+	return count, nil
+}
+
+// expireWithoutTTL sets the default TTL on those of keys that have none
+func (s *Store) expireWithoutTTL(ctx context.Context, keys []string) error {
+	pipe := s.redis.Pipeline()
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		ttls[i] = pipe.TTL(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to read idempotency key TTLs: %w", err)
+	}
+
+	// TTL reports -1 for a key without an expiry; -2 means it expired since the scan
+	pipe = s.redis.Pipeline()
+	missing := 0
+	for i, ttl := range ttls {
+		if ttl.Val() == -1 {
+			pipe.Expire(ctx, keys[i], s.ttl)
+			missing++
+		}
+	}
+	if missing == 0 {
+		return nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to expire idempotency keys: %w", err)
+	}
+	keysWithoutTTL.Add(float64(missing))
+	return nil
+}
+
+// RunSweeper sweeps every interval until ctx is done
+func (s *Store) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.Sweep(ctx)
+			if err != nil {
+				logger.FromContext(ctx).Warn("idempotency key sweep failed", zap.Error(err))
+				continue
+			}
+			logger.FromContext(ctx).Debug("idempotency keys swept", zap.Int64("keys", count))
+		}
+	}
+}
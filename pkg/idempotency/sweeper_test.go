@@ -0,0 +1,42 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/redistest"
+)
+
+func TestSweepExpiresKeysWithoutTTL(t *testing.T) {
+	server := redistest.NewServer(t)
+	cfg := DefaultConfig()
+	cfg.TTL = time.Hour
+	s := NewStoreWithConfig(server.NewClient(t), cfg)
+
+	// More keys than one SCAN batch, so the sweep pages
+	for i := 0; i < sweepBatchSize+10; i++ {
+		server.Set(fmt.Sprintf("%skept-%d", KeyPrefix, i), "{}", 10*time.Minute)
+	}
+	server.Set(KeyPrefix+"forever", "{}", 0)
+	server.Set("other:forever", "{}", 0)
+
+	count, err := s.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if want := int64(sweepBatchSize + 11); count != want {
+		t.Errorf("Sweep counted %d keys, want %d", count, want)
+	}
+
+	if ttl := server.TTL(KeyPrefix + "forever"); ttl <= 59*time.Minute {
+		t.Errorf("key without a TTL now has %v, want the 1h store TTL", ttl)
+	}
+	if ttl := server.TTL(KeyPrefix + "kept-0"); ttl > 10*time.Minute {
+		t.Errorf("existing TTL changed to %v", ttl)
+	}
+	if ttl := server.TTL("other:forever"); ttl != 0 {
+		t.Errorf("key outside the idempotency prefix given TTL %v", ttl)
+	}
+}
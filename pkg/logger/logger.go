@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 	"os"
+	"sync"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -45,14 +46,25 @@ func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
 	return context.WithValue(ctx, loggerKey, logger)
 }
 
+var (
+	defaultLogger     *zap.Logger
+	defaultLoggerOnce sync.Once
+)
+
 // FromContext extracts logger from context
 func FromContext(ctx context.Context) *zap.Logger {
 	if logger, ok := ctx.Value(loggerKey).(*zap.Logger); ok {
 		return logger
 	}
-	// Return a default logger if not found
-	logger, _ := zap.NewProduction()
-	return logger
+	// Return a shared default logger if not found
+	defaultLoggerOnce.Do(func() {
+		logger, err := zap.NewProduction()
+		if err != nil {
+			logger = zap.NewNop()
+		}
+		defaultLogger = logger
+	})
+	return defaultLogger
 }
 
 // WithFields adds fields to logger in context
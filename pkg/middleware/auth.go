@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizationHeader carries the bearer token
+const AuthorizationHeader = "authorization"
+
+// DefaultJWTLeeway tolerates clock drift between the issuing and validating
+// services when checking exp, nbf and iat
+const DefaultJWTLeeway = 30 * time.Second
+
+// AuthInfo describes the authenticated caller
+type AuthInfo struct {
+	UserID string
+	Scopes []string
+}
+
+// HasScope reports whether the caller was granted scope
+func (a *AuthInfo) HasScope(scope string) bool {
+	return slices.Contains(a.Scopes, scope)
+}
+
+// TokenValidator validates a bearer token and returns the caller it identifies
+type TokenValidator func(ctx context.Context, token string) (*AuthInfo, error)
+
+// jwtClaims are the claims of tokens issued by the users service
+type jwtClaims struct {
+	UserID string   `json:"user_id"`
+	Scopes []string `json:"scopes,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// JWTValidator returns a TokenValidator for HMAC-signed tokens from issuer,
+// accepting only tokens issued for audience. Tokens must carry exp, iat and a
+// user ID; time checks allow leeway of clock drift.
+func JWTValidator(secret, issuer, audience string, leeway time.Duration) TokenValidator {
+	key := []byte(secret)
+	return func(ctx context.Context, token string) (*AuthInfo, error) {
+		var claims jwtClaims
+		_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return key, nil
+		}, jwt.WithIssuer(issuer), jwt.WithAudience(audience),
+			jwt.WithExpirationRequired(), jwt.WithIssuedAt(), jwt.WithLeeway(leeway))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse token: %w", err)
+		}
+		// WithIssuedAt only checks an iat that is present
+		if claims.IssuedAt == nil {
+			return nil, fmt.Errorf("failed to parse token: missing iat claim")
+		}
+		if claims.UserID == "" {
+			return nil, fmt.Errorf("failed to parse token: missing user_id claim")
+		}
+		return &AuthInfo{UserID: claims.UserID, Scopes: claims.Scopes}, nil
+	}
+}
+
+// AuthConfig configures AuthInterceptor
+type AuthConfig struct {
+	Validate TokenValidator
+	// PublicMethods are full method names callable without a token
+	PublicMethods []string
+	// MethodScopes maps full method names to the scope they require;
+	// other methods only require a valid token
+	MethodScopes map[string]string
+}
+
+type authInfoKey struct{}
+
+// AuthFromContext returns the caller authenticated by AuthInterceptor
+func AuthFromContext(ctx context.Context) (*AuthInfo, bool) {
+	info, ok := ctx.Value(authInfoKey{}).(*AuthInfo)
+	return info, ok
+}
+
+// AuthInterceptor returns a gRPC unary server interceptor that authenticates
+// bearer tokens and enforces per-method scopes
+func AuthInterceptor(cfg AuthConfig) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if slices.Contains(cfg.PublicMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		token, ok := strings.CutPrefix(getMetadataValue(md, AuthorizationHeader), "Bearer ")
+		if !ok || token == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+
+		authInfo, err := cfg.Validate(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		if scope, ok := cfg.MethodScopes[info.FullMethod]; ok && !authInfo.HasScope(scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "missing scope %s", scope)
+		}
+
+		return handler(context.WithValue(ctx, authInfoKey{}, authInfo), req)
+	}
+}
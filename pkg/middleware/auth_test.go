@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptorPublicMethods(t *testing.T) {
+	interceptor := AuthInterceptor(AuthConfig{
+		Validate: func(ctx context.Context, token string) (*AuthInfo, error) {
+			return &AuthInfo{UserID: "user-1"}, nil
+		},
+		PublicMethods: []string{grpc_health_v1.Health_Check_FullMethodName},
+	})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: grpc_health_v1.Health_Check_FullMethodName}, handler)
+	if err != nil {
+		t.Errorf("health check without a token failed: %v", err)
+	}
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/coldy.users.v1.UserService/GetMe"}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a token, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+
+	loggerpkg "github.com/mumumio1/coldy/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultCompressMinSize is the smallest response, in bytes, worth compressing
+const DefaultCompressMinSize = 1024
+
+// CompressionInterceptor returns a gRPC unary server interceptor that gzips
+// responses of at least minSize bytes for clients that accept gzip. Smaller
+// responses are sent uncompressed, where compression costs more than it saves.
+// Importing this package registers the gzip compressor.
+func CompressionInterceptor(minSize int) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		msg, ok := resp.(proto.Message)
+		if !ok {
+			return resp, nil
+		}
+
+		compressor := encoding.Identity
+		if proto.Size(msg) >= minSize {
+			accepted, _ := grpc.ClientSupportedCompressors(ctx)
+			if slices.Contains(accepted, gzip.Name) {
+				compressor = gzip.Name
+			}
+		}
+		if err := grpc.SetSendCompressor(ctx, compressor); err != nil {
+			loggerpkg.FromContext(ctx).Debug("failed to set response compressor", zap.Error(err))
+		}
+
+		return resp, nil
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/mumumio1/coldy/pkg/errs"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Metadata keys of the ErrorInfo detail attached to error responses
+const (
+	ErrorInfoRequestID     = "request_id"
+	ErrorInfoCorrelationID = "correlation_id"
+)
+
+// withErrorEnvelope returns err as a status whose ErrorInfo detail carries the
+// request and correlation IDs the server logged, so a client can quote them to
+// support. A domain ErrorInfo keeps its reason; otherwise the reason is the
+// status code, e.g. "INTERNAL".
+func withErrorEnvelope(err error, requestID, correlationID string) error {
+	st := status.Convert(err)
+
+	info := &errdetails.ErrorInfo{
+		Reason: code.Code_name[int32(st.Code())],
+		Domain: errs.Domain,
+	}
+	var details []protoadapt.MessageV1
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info.Reason, info.Domain, info.Metadata = d.Reason, d.Domain, d.Metadata
+		case protoadapt.MessageV1:
+			details = append(details, d)
+		}
+	}
+
+	if info.Metadata == nil {
+		info.Metadata = make(map[string]string, 2)
+	}
+	info.Metadata[ErrorInfoRequestID] = requestID
+	info.Metadata[ErrorInfoCorrelationID] = correlationID
+
+	withDetails, detailErr := status.New(st.Code(), st.Message()).WithDetails(append([]protoadapt.MessageV1{info}, details...)...)
+	if detailErr != nil {
+		return err
+	}
+	return withDetails.Err()
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/database"
+	loggerpkg "github.com/mumumio1/coldy/pkg/logger"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -21,7 +23,8 @@ const (
 	SpanIDHeader        = "x-span-id"
 )
 
-// UnaryServerInterceptor returns a gRPC unary server interceptor with logging and tracing
+// UnaryServerInterceptor returns a gRPC unary server interceptor with logging and tracing.
+// Error responses get an ErrorInfo detail with the request and correlation IDs.
 func UnaryServerInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -65,6 +68,10 @@ func UnaryServerInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 
 		reqLogger.Info("gRPC request started")
 
+		// Make the request-scoped logger available to handlers and services
+		ctx = loggerpkg.WithLogger(ctx, reqLogger)
+		ctx = database.WithRequestID(ctx, requestID)
+
 		// Call handler
 		resp, err := handler(ctx, req)
 
@@ -72,6 +79,8 @@ func UnaryServerInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 
 		// Log response
 		if err != nil {
+			// Every error response carries the IDs logged here
+			err = withErrorEnvelope(err, requestID, correlationID)
 			st, _ := status.FromError(err)
 			reqLogger.Error("gRPC request failed",
 				zap.Duration("duration", duration),
@@ -141,7 +150,10 @@ func StreamServerInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
 
 		reqLogger.Info("gRPC stream started")
 
-		err := handler(srv, ss)
+		err := handler(srv, &loggingServerStream{
+			ServerStream: ss,
+			ctx:          loggerpkg.WithLogger(ctx, reqLogger),
+		})
 
 		duration := time.Since(start)
 
@@ -160,6 +172,16 @@ func StreamServerInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
 	}
 }
 
+// loggingServerStream overrides the stream context to carry the request-scoped logger
+type loggingServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggingServerStream) Context() context.Context {
+	return s.ctx
+}
+
 // RecoveryInterceptor recovers from panics and returns internal error
 func RecoveryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testSecret   = "test-secret"
+	testIssuer   = "coldy-users"
+	testAudience = "coldy-access"
+)
+
+func signTestToken(t *testing.T, secret string, claims jwtClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// validClaims returns claims the validator accepts, issued at now
+func validClaims(now time.Time) jwtClaims {
+	return jwtClaims{
+		UserID: "user-1",
+		Scopes: []string{"orders:admin"},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(15 * time.Minute)),
+		},
+	}
+}
+
+func TestJWTValidatorAcceptsValidToken(t *testing.T) {
+	validate := JWTValidator(testSecret, testIssuer, testAudience, DefaultJWTLeeway)
+
+	info, err := validate(context.Background(), signTestToken(t, testSecret, validClaims(time.Now())))
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if info.UserID != "user-1" || !info.HasScope("orders:admin") {
+		t.Errorf("info = %+v, want user-1 with orders:admin", info)
+	}
+}
+
+func TestJWTValidatorRejectsInvalidTokens(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		secret string
+		claims func(*jwtClaims)
+	}{
+		"wrong secret": {secret: "other-secret"},
+		"wrong issuer": {claims: func(c *jwtClaims) { c.Issuer = "someone-else" }},
+		"refresh audience": {claims: func(c *jwtClaims) {
+			c.Audience = jwt.ClaimStrings{"coldy-refresh"}
+		}},
+		"expired beyond leeway": {claims: func(c *jwtClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+		}},
+		"issued in the future beyond leeway": {claims: func(c *jwtClaims) {
+			c.IssuedAt = jwt.NewNumericDate(now.Add(time.Minute))
+		}},
+		"no exp":     {claims: func(c *jwtClaims) { c.ExpiresAt = nil }},
+		"no iat":     {claims: func(c *jwtClaims) { c.IssuedAt = nil }},
+		"no user ID": {claims: func(c *jwtClaims) { c.UserID = "" }},
+	}
+
+	validate := JWTValidator(testSecret, testIssuer, testAudience, DefaultJWTLeeway)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims := validClaims(now)
+			if tt.claims != nil {
+				tt.claims(&claims)
+			}
+			secret := testSecret
+			if tt.secret != "" {
+				secret = tt.secret
+			}
+
+			if _, err := validate(context.Background(), signTestToken(t, secret, claims)); err == nil {
+				t.Error("validate accepted the token")
+			}
+		})
+	}
+}
+
+func TestJWTValidatorLeeway(t *testing.T) {
+	now := time.Now()
+	tests := map[string]func(*jwtClaims){
+		"just expired": func(c *jwtClaims) { c.ExpiresAt = jwt.NewNumericDate(now.Add(-10 * time.Second)) },
+		"issued slightly ahead": func(c *jwtClaims) {
+			c.IssuedAt = jwt.NewNumericDate(now.Add(10 * time.Second))
+		},
+	}
+
+	for name, adjust := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims := validClaims(now)
+			adjust(&claims)
+			token := signTestToken(t, testSecret, claims)
+
+			if _, err := JWTValidator(testSecret, testIssuer, testAudience, 30*time.Second)(context.Background(), token); err != nil {
+				t.Errorf("validate with 30s leeway failed: %v", err)
+			}
+			if _, err := JWTValidator(testSecret, testIssuer, testAudience, 0)(context.Background(), token); err == nil {
+				t.Error("validate without leeway accepted the token")
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mumumio1/coldy/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxInFlight caps concurrent unary RPCs per server. It sits a few
+// multiples above the database pool so bursts queue on the pool briefly
+// instead of piling up behind it.
+const DefaultMaxInFlight = 100
+
+var requestsShed = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "coldy",
+	Subsystem: "grpc",
+	Name:      "requests_shed_total",
+	Help:      "Unary RPCs rejected because a concurrency limit was reached",
+}, []string{"method"})
+
+// ConcurrencyConfig limits in-flight unary RPCs. A limit of zero or less
+// disables that limit.
+type ConcurrencyConfig struct {
+	// MaxInFlight caps RPCs across all methods
+	MaxInFlight int `env:"GRPC_MAX_IN_FLIGHT" validate:"min=0"`
+	// MethodLimits caps RPCs per full method name, inside the global limit
+	MethodLimits map[string]int
+}
+
+// LoadConcurrencyConfig loads the limits from GRPC_MAX_IN_FLIGHT, defaulting
+// to DefaultMaxInFlight, and GRPC_METHOD_LIMITS
+func LoadConcurrencyConfig() (ConcurrencyConfig, error) {
+	cfg := ConcurrencyConfig{MaxInFlight: DefaultMaxInFlight}
+	if err := config.Load(&cfg); err != nil {
+		return ConcurrencyConfig{}, err
+	}
+
+	limits, err := ParseMethodLimits(os.Getenv("GRPC_METHOD_LIMITS"))
+	if err != nil {
+		return ConcurrencyConfig{}, fmt.Errorf("invalid GRPC_METHOD_LIMITS: %w", err)
+	}
+	cfg.MethodLimits = limits
+	return cfg, nil
+}
+
+// ParseMethodLimits parses "/pkg.Service/Method=N" pairs separated by commas
+func ParseMethodLimits(s string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(pair, "=")
+		if !ok || !strings.HasPrefix(method, "/") {
+			return nil, fmt.Errorf("invalid method limit %q", pair)
+		}
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid method limit %q: %w", pair, err)
+		}
+		limits[method] = limit
+	}
+	return limits, nil
+}
+
+// ConcurrencyLimitInterceptor rejects unary RPCs with ResourceExhausted once
+// a limit is reached. Excess calls are shed immediately rather than queued so
+// clients can back off or retry elsewhere.
+func ConcurrencyLimitInterceptor(cfg ConcurrencyConfig) grpc.UnaryServerInterceptor {
+	global := newSemaphore(cfg.MaxInFlight)
+	methods := make(map[string]semaphore, len(cfg.MethodLimits))
+	for method, limit := range cfg.MethodLimits {
+		if sem := newSemaphore(limit); sem != nil {
+			methods[method] = sem
+		}
+	}
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !global.tryAcquire() {
+			requestsShed.WithLabelValues(info.FullMethod).Inc()
+			return nil, status.Error(codes.ResourceExhausted, "server is overloaded")
+		}
+		defer global.release()
+
+		method := methods[info.FullMethod]
+		if !method.tryAcquire() {
+			requestsShed.WithLabelValues(info.FullMethod).Inc()
+			return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent %s requests", info.FullMethod)
+		}
+		defer method.release()
+
+		return handler(ctx, req)
+	}
+}
+
+// semaphore is a counting semaphore; a nil semaphore never blocks
+type semaphore chan struct{}
+
+func newSemaphore(limit int) semaphore {
+	if limit <= 0 {
+		return nil
+	}
+	return make(semaphore, limit)
+}
+
+func (s semaphore) tryAcquire() bool {
+	if s == nil {
+		return true
+	}
+	select {
+	case s <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import "testing"
+
+func TestLoadConcurrencyConfig(t *testing.T) {
+	t.Setenv("GRPC_MAX_IN_FLIGHT", "50")
+	t.Setenv("GRPC_METHOD_LIMITS", "/coldy.orders.v1.OrderService/CreateOrder=10")
+
+	cfg, err := LoadConcurrencyConfig()
+	if err != nil {
+		t.Fatalf("LoadConcurrencyConfig failed: %v", err)
+	}
+	if cfg.MaxInFlight != 50 {
+		t.Errorf("MaxInFlight = %d, want 50", cfg.MaxInFlight)
+	}
+	if got := cfg.MethodLimits["/coldy.orders.v1.OrderService/CreateOrder"]; got != 10 {
+		t.Errorf("CreateOrder limit = %d, want 10", got)
+	}
+}
+
+func TestLoadConcurrencyConfigDefaults(t *testing.T) {
+	cfg, err := LoadConcurrencyConfig()
+	if err != nil {
+		t.Fatalf("LoadConcurrencyConfig failed: %v", err)
+	}
+	if cfg.MaxInFlight != DefaultMaxInFlight || len(cfg.MethodLimits) != 0 {
+		t.Errorf("got %+v, want MaxInFlight %d and no method limits", cfg, DefaultMaxInFlight)
+	}
+}
+
+func TestLoadConcurrencyConfigRejectsInvalidValues(t *testing.T) {
+	tests := map[string]struct{ key, value string }{
+		"negative in-flight": {"GRPC_MAX_IN_FLIGHT", "-1"},
+		"malformed limits":   {"GRPC_METHOD_LIMITS", "CreateOrder=10"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			if _, err := LoadConcurrencyConfig(); err == nil {
+				t.Errorf("expected an error for %s=%s", tt.key, tt.value)
+			}
+		})
+	}
+}
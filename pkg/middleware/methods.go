@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// InfrastructureMethods are the health and reflection RPCs every server
+// registers. Probes and tooling call them without tokens or tenant headers.
+var InfrastructureMethods = []string{
+	grpc_health_v1.Health_Check_FullMethodName,
+	grpc_health_v1.Health_List_FullMethodName,
+	grpc_health_v1.Health_Watch_FullMethodName,
+	grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName,
+	grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+}
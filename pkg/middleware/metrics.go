@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Status classes group gRPC codes the way HTTP groups 2xx, 4xx and 5xx
+const (
+	StatusClassOK          = "ok"
+	StatusClassClientError = "client_error"
+	StatusClassServerError = "server_error"
+)
+
+// Labels are limited to the method, the code name and its class so series
+// stay bounded no matter what error messages handlers return
+var handlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "coldy",
+	Subsystem: "grpc",
+	Name:      "server_handling_seconds",
+	Help:      "Unary RPC handling time by method, status code and status class",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "code", "class"})
+
+// StatusClass maps a gRPC code to ok, client_error or server_error. Codes the
+// caller can fix by changing the request are client errors; the rest point at
+// the server or its dependencies.
+func StatusClass(code codes.Code) string {
+	switch code {
+	case codes.OK:
+		return StatusClassOK
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented,
+		codes.Unavailable, codes.DeadlineExceeded:
+		return StatusClassServerError
+	default:
+		return StatusClassClientError
+	}
+}
+
+// MetricsInterceptor records how long each unary RPC took, labelled with its
+// status code and class. It should run first in the chain so recovered panics
+// and shed requests are counted too.
+func MetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		handlingSeconds.WithLabelValues(info.FullMethod, code.String(), StatusClass(code)).
+			Observe(time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusClass(t *testing.T) {
+	tests := map[codes.Code]string{
+		codes.OK:                 StatusClassOK,
+		codes.InvalidArgument:    StatusClassClientError,
+		codes.NotFound:           StatusClassClientError,
+		codes.PermissionDenied:   StatusClassClientError,
+		codes.ResourceExhausted:  StatusClassClientError,
+		codes.FailedPrecondition: StatusClassClientError,
+		codes.Canceled:           StatusClassClientError,
+		codes.Internal:           StatusClassServerError,
+		codes.Unknown:            StatusClassServerError,
+		codes.Unavailable:        StatusClassServerError,
+		codes.DeadlineExceeded:   StatusClassServerError,
+		codes.Unimplemented:      StatusClassServerError,
+		codes.DataLoss:           StatusClassServerError,
+	}
+
+	for code, want := range tests {
+		if got := StatusClass(code); got != want {
+			t.Errorf("StatusClass(%s) = %s, want %s", code, got, want)
+		}
+	}
+}
+
+// handledCount returns how many RPCs the handling histogram has recorded for
+// method, code and class
+func handledCount(t *testing.T, method, code, class string) uint64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "coldy_grpc_server_handling_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["method"] == method && labels["code"] == code && labels["class"] == class {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestMetricsInterceptorRecordsStatus(t *testing.T) {
+	tests := map[string]struct {
+		err   error
+		code  string
+		class string
+	}{
+		"success":      {nil, "OK", StatusClassOK},
+		"client error": {status.Error(codes.NotFound, "order not found"), "NotFound", StatusClassClientError},
+		"server error": {status.Error(codes.Unavailable, "database down"), "Unavailable", StatusClassServerError},
+	}
+
+	interceptor := MetricsInterceptor()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			info := &grpc.UnaryServerInfo{FullMethod: "/coldy.test.v1.TestService/" + tt.code}
+			before := handledCount(t, info.FullMethod, tt.code, tt.class)
+
+			_, err := interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+				return nil, tt.err
+			})
+			if err != tt.err {
+				t.Errorf("interceptor returned %v, want the handler's error %v", err, tt.err)
+			}
+
+			if got := handledCount(t, info.FullMethod, tt.code, tt.class); got != before+1 {
+				t.Errorf("recorded %d RPCs, want %d", got, before+1)
+			}
+		})
+	}
+}
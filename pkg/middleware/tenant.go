@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TenantHeader carries the tenant a request acts for
+const TenantHeader = "x-tenant-id"
+
+// TenantConfig configures TenantInterceptor
+type TenantConfig struct {
+	// Required rejects requests without a tenant; set in multi-tenant mode
+	Required bool
+	// ExemptMethods are full method names that may omit the tenant
+	ExemptMethods []string
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a context scoped to tenantID
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant a request acts for. It is empty in
+// single-tenant deployments and for background work, which repositories treat
+// as unscoped.
+func TenantFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantKey{}).(string)
+	return tenantID
+}
+
+// TenantInterceptor returns a gRPC unary server interceptor that scopes the
+// context to the tenant in TenantHeader and forwards it on outgoing calls
+func TenantInterceptor(cfg TenantConfig) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		tenantID := getMetadataValue(md, TenantHeader)
+		if tenantID == "" {
+			if cfg.Required && !slices.Contains(cfg.ExemptMethods, info.FullMethod) {
+				return nil, status.Error(codes.InvalidArgument, "missing tenant")
+			}
+			return handler(ctx, req)
+		}
+
+		ctx = metadata.AppendToOutgoingContext(ctx, TenantHeader, tenantID)
+		return handler(WithTenant(ctx, tenantID), req)
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantInterceptorRequired(t *testing.T) {
+	interceptor := TenantInterceptor(TenantConfig{Required: true, ExemptMethods: InfrastructureMethods})
+
+	var gotTenant string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		gotTenant = TenantFromContext(ctx)
+		return nil, nil
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		tenant   string
+		wantCode codes.Code
+	}{
+		{name: "missing tenant", method: "/coldy.orders.v1.OrderService/GetOrder", wantCode: codes.InvalidArgument},
+		{name: "with tenant", method: "/coldy.orders.v1.OrderService/GetOrder", tenant: "acme", wantCode: codes.OK},
+		{name: "health check", method: grpc_health_v1.Health_Check_FullMethodName, wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTenant = ""
+			ctx := context.Background()
+			if tt.tenant != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(TenantHeader, tt.tenant))
+			}
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %s, want %s", code, tt.wantCode)
+			}
+			if gotTenant != tt.tenant {
+				t.Errorf("handler saw tenant %q, want %q", gotTenant, tt.tenant)
+			}
+		})
+	}
+}
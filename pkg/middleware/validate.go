@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Validator is implemented by request messages that check their own fields,
+// such as messages generated by protoc-gen-validate
+type Validator interface {
+	Validate() error
+}
+
+// allValidator is implemented by protoc-gen-validate messages that can report
+// every violation instead of only the first
+type allValidator interface {
+	ValidateAll() error
+}
+
+// ValidateFunc validates a request message
+type ValidateFunc func(req interface{}) error
+
+// ValidationInterceptor returns a gRPC unary server interceptor that rejects
+// invalid requests with InvalidArgument before they reach the handler.
+// validators maps full method names to validators for messages that do not
+// implement Validator; a message that implements it is checked by both.
+func ValidationInterceptor(validators map[string]ValidateFunc) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := validateRequest(req, validators[info.FullMethod]); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return handler(ctx, req)
+	}
+}
+
+func validateRequest(req interface{}, validate ValidateFunc) error {
+	switch v := req.(type) {
+	case allValidator:
+		if err := v.ValidateAll(); err != nil {
+			return err
+		}
+	case Validator:
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if validate != nil {
+		return validate(req)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrInvalidCurrency is returned for codes that are not ISO 4217 currencies
+	ErrInvalidCurrency = errors.New("invalid currency")
+	// ErrCurrencyMismatch is returned when combining amounts in different currencies
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrOverflow is returned when an arithmetic result does not fit in int64
+	ErrOverflow = errors.New("amount overflow")
+)
+
+// Money is an amount in the currency's minor unit (e.g. cents)
+type Money struct {
+	Currency string
+	Amount   int64
+}
+
+// New creates a Money after validating the currency code
+func New(amount int64, currency string) (Money, error) {
+	if err := ValidateCurrency(currency); err != nil {
+		return Money{}, err
+	}
+	return Money{Currency: currency, Amount: amount}, nil
+}
+
+// ValidateCurrency checks that code is an active ISO 4217 alphabetic code.
+// Codes must be upper case.
+func ValidateCurrency(code string) error {
+	if _, ok := currencies[code]; !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	return nil
+}
+
+// NormalizeCurrency trims and upper-cases a currency code, then validates it
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if err := ValidateCurrency(code); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// Add returns m + other; both must share a currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Currency: m.Currency, Amount: m.Amount + other.Amount}, nil
+}
+
+// Multiply returns m * n
+func (m Money) Multiply(n int64) (Money, error) {
+	if m.Amount == 0 || n == 0 {
+		return Money{Currency: m.Currency}, nil
+	}
+
+	result := m.Amount * n
+	if result/n != m.Amount || (n == -1 && m.Amount == math.MinInt64) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Currency: m.Currency, Amount: result}, nil
+}
+
+// Sum adds amounts that must all share one valid currency. An empty list is an error
+// because the currency of the result would be unknown.
+func Sum(values ...Money) (Money, error) {
+	if len(values) == 0 {
+		return Money{}, errors.New("no amounts to sum")
+	}
+	if err := ValidateCurrency(values[0].Currency); err != nil {
+		return Money{}, err
+	}
+
+	total := Money{Currency: values[0].Currency}
+	for _, v := range values {
+		var err error
+		if total, err = total.Add(v); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// String formats the amount assuming two minor-unit digits, e.g. "12.34 USD"
+func (m Money) String() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, amount/100, amount%100, m.Currency)
+}
@@ -0,0 +1,174 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidateCurrency(t *testing.T) {
+	tests := map[string]struct {
+		code    string
+		wantErr bool
+	}{
+		"usd":          {code: "USD"},
+		"euro":         {code: "EUR"},
+		"lower case":   {code: "usd", wantErr: true},
+		"unknown code": {code: "XYZ", wantErr: true},
+		"empty":        {code: "", wantErr: true},
+		"retired code": {code: "DEM", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCurrency(tt.code)
+			if tt.wantErr != errors.Is(err, ErrInvalidCurrency) {
+				t.Errorf("ValidateCurrency(%q) = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	got, err := NormalizeCurrency(" usd ")
+	if err != nil || got != "USD" {
+		t.Errorf("NormalizeCurrency = %q, %v; want USD", got, err)
+	}
+	if _, err := NormalizeCurrency("dollars"); !errors.Is(err, ErrInvalidCurrency) {
+		t.Errorf("expected ErrInvalidCurrency, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	m, err := New(1250, "USD")
+	if err != nil || m != (Money{Currency: "USD", Amount: 1250}) {
+		t.Errorf("New = %+v, %v", m, err)
+	}
+	if _, err := New(1250, "US$"); !errors.Is(err, ErrInvalidCurrency) {
+		t.Errorf("expected ErrInvalidCurrency, got %v", err)
+	}
+}
+
+func TestSum(t *testing.T) {
+	tests := map[string]struct {
+		values  []Money
+		want    Money
+		wantErr error
+	}{
+		"single currency": {
+			values: []Money{{"USD", 1250}, {"USD", 750}, {"USD", -500}},
+			want:   Money{"USD", 1500},
+		},
+		"one amount": {
+			values: []Money{{"EUR", 999}},
+			want:   Money{"EUR", 999},
+		},
+		"mixed currencies": {
+			values:  []Money{{"USD", 1250}, {"EUR", 750}},
+			wantErr: ErrCurrencyMismatch,
+		},
+		"unknown currency": {
+			values:  []Money{{"XYZ", 1250}, {"XYZ", 750}},
+			wantErr: ErrInvalidCurrency,
+		},
+		"overflow": {
+			values:  []Money{{"USD", math.MaxInt64}, {"USD", 1}},
+			wantErr: ErrOverflow,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Sum(tt.values...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Sum error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sum failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Sum = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSumEmpty(t *testing.T) {
+	if _, err := Sum(); err == nil {
+		t.Error("expected an error for an empty sum")
+	}
+}
+
+func TestAddOverflow(t *testing.T) {
+	tests := map[string]struct {
+		a, b int64
+	}{
+		"positive": {a: math.MaxInt64, b: 1},
+		"negative": {a: math.MinInt64, b: -1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Money{"USD", tt.a}.Add(Money{"USD", tt.b})
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("expected ErrOverflow, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	tests := map[string]struct {
+		amount, n int64
+		want      int64
+		wantErr   bool
+	}{
+		"quantity":       {amount: 1250, n: 3, want: 3750},
+		"zero quantity":  {amount: 1250, n: 0, want: 0},
+		"zero amount":    {amount: 0, n: math.MaxInt64, want: 0},
+		"negative":       {amount: 1250, n: -2, want: -2500},
+		"overflow":       {amount: math.MaxInt64 / 2, n: 3, wantErr: true},
+		"min int negate": {amount: math.MinInt64, n: -1, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Money{"USD", tt.amount}.Multiply(tt.n)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOverflow) {
+					t.Errorf("expected ErrOverflow, got %+v, %v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Multiply failed: %v", err)
+			}
+			if got != (Money{"USD", tt.want}) {
+				t.Errorf("Multiply = %+v, want %d USD", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := map[string]struct {
+		m    Money
+		want string
+	}{
+		"whole":    {m: Money{"USD", 1200}, want: "12.00 USD"},
+		"cents":    {m: Money{"EUR", 1234}, want: "12.34 EUR"},
+		"small":    {m: Money{"USD", 5}, want: "0.05 USD"},
+		"negative": {m: Money{"USD", -1234}, want: "-12.34 USD"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.m.String(); got != tt.want {
+				t.Errorf("String = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Package outbox publishes events that services write to an outbox table in
+// the same transaction as the change they describe. A Publisher polls a
+// service's Store for unpublished events, publishes them to Pub/Sub and marks
+// them published, so an event is sent at least once after its change commits.
+package outbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/pubsub"
+	"go.uber.org/zap"
+)
+
+// DefaultBatchSize is the number of events a Publisher reads per run
+const DefaultBatchSize = 100
+
+// DefaultMaxAttempts is the number of failed publishes after which services
+// dead-letter an event by default. At a 5s interval that is several minutes
+// of failures, long enough to ride out a brief Pub/Sub outage.
+const DefaultMaxAttempts = 100
+
+// Event is an outbox row awaiting publication. Data is the JSON payload.
+type Event struct {
+	ID            string
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Data          []byte
+	// Attempts is the number of failed publishes so far
+	Attempts int
+}
+
+// Store is a service's outbox table
+type Store interface {
+	// GetUnpublished returns up to limit unpublished events, oldest first
+	GetUnpublished(ctx context.Context, limit int) ([]Event, error)
+	// MarkPublished records that an event was published
+	MarkPublished(ctx context.Context, eventID string) error
+	// MarkFailed records a failed publish attempt; the event stays
+	// unpublished and is retried on the next run
+	MarkFailed(ctx context.Context, eventID string, cause error) error
+	// MarkDeadLettered records a failed publish attempt and stops retrying
+	// the event; GetUnpublished no longer returns it
+	MarkDeadLettered(ctx context.Context, eventID string, cause error) error
+}
+
+// MessagePublisher sends a message to a topic and returns its server ID
+type MessagePublisher interface {
+	Publish(ctx context.Context, topic string, data []byte, attrs map[string]string) (string, error)
+}
+
+// Publisher processes outbox events and publishes to Pub/Sub
+type Publisher struct {
+	store     Store
+	publisher MessagePublisher
+	topics    pubsub.TopicResolver
+	logger    *zap.Logger
+	interval  time.Duration
+	// maxAttempts is the number of failed publishes after which an event is
+	// dead-lettered; zero retries forever
+	maxAttempts int
+	// heartbeat is the UnixNano time of the last completed tick
+	heartbeat atomic.Int64
+}
+
+// NewPublisher creates a new outbox publisher. Events that fail to publish
+// maxAttempts times are dead-lettered; zero retries them forever.
+func NewPublisher(
+	store Store,
+	publisher MessagePublisher,
+	topics pubsub.TopicResolver,
+	logger *zap.Logger,
+	interval time.Duration,
+	maxAttempts int,
+) *Publisher {
+	p := &Publisher{
+		store:       store,
+		publisher:   publisher,
+		topics:      topics,
+		logger:      logger,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+	}
+	p.heartbeat.Store(time.Now().UnixNano())
+	return p
+}
+
+// CheckHeartbeat returns an error when the worker has not completed a tick
+// within maxMissed intervals, e.g. because its goroutine died
+func (p *Publisher) CheckHeartbeat(maxMissed int) error {
+	last := time.Unix(0, p.heartbeat.Load())
+	if since := time.Since(last); since > time.Duration(maxMissed)*p.interval {
+		return fmt.Errorf("outbox publisher stalled: last run %s ago", since.Round(time.Second))
+	}
+	return nil
+}
+
+// Start starts the outbox publisher worker
+func (p *Publisher) Start(ctx context.Context) error {
+	p.logger.Info("starting outbox publisher")
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("stopping outbox publisher")
+			return ctx.Err()
+		case <-ticker.C:
+			p.tick(ctx)
+		}
+	}
+}
+
+// tick processes one batch. A panic is logged and the batch abandoned so
+// the worker keeps running; the heartbeat is updated either way.
+func (p *Publisher) tick(ctx context.Context) {
+	defer p.heartbeat.Store(time.Now().UnixNano())
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error("panic while processing outbox events", zap.Any("panic", r), zap.Stack("stack"))
+		}
+	}()
+
+	if err := p.ProcessEvents(ctx); err != nil {
+		p.logger.Error("failed to process events", zap.Error(err))
+	}
+}
+
+// ProcessEvents publishes one batch of unpublished events. Failures of
+// single events are recorded with MarkFailed and do not stop the batch.
+func (p *Publisher) ProcessEvents(ctx context.Context) error {
+	events, err := p.store.GetUnpublished(ctx, DefaultBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get unpublished events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	p.logger.Info("processing outbox events", zap.Int("count", len(events)))
+
+	for i, event := range events {
+		// Stop between events on shutdown; the rest stay unpublished for the next run
+		if ctx.Err() != nil {
+			p.logger.Info("outbox batch interrupted by shutdown", zap.Int("remaining", len(events)-i))
+			return nil
+		}
+
+		if err := p.publishEvent(ctx, event); err != nil {
+			p.logger.Error("failed to publish event",
+				zap.String("event_id", event.ID),
+				zap.Error(err),
+			)
+			p.markFailed(context.WithoutCancel(ctx), event, err)
+			continue
+		}
+
+		// Mark as published even if shutdown began mid-publish, otherwise the
+		// event would be sent again on restart
+		if err := p.store.MarkPublished(context.WithoutCancel(ctx), event.ID); err != nil {
+			p.logger.Error("failed to mark event published",
+				zap.String("event_id", event.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		p.logger.Info("event published",
+			zap.String("event_id", event.ID),
+			zap.String("event_type", event.EventType),
+		)
+	}
+
+	return nil
+}
+
+// markFailed records a failed publish, dead-lettering the event once it has
+// used up its attempts
+func (p *Publisher) markFailed(ctx context.Context, event Event, cause error) {
+	if p.maxAttempts > 0 && event.Attempts+1 >= p.maxAttempts {
+		if err := p.store.MarkDeadLettered(ctx, event.ID, cause); err != nil {
+			p.logger.Error("failed to dead-letter event",
+				zap.String("event_id", event.ID),
+				zap.Error(err),
+			)
+			return
+		}
+		p.logger.Warn("event dead-lettered",
+			zap.String("event_id", event.ID),
+			zap.String("event_type", event.EventType),
+			zap.Int("attempts", event.Attempts+1),
+		)
+		return
+	}
+
+	if err := p.store.MarkFailed(ctx, event.ID, cause); err != nil {
+		p.logger.Error("failed to mark event failed",
+			zap.String("event_id", event.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (p *Publisher) publishEvent(ctx context.Context, event Event) error {
+	// Deduplication via message ID
+	messageID := MessageID(event.ID)
+
+	attrs := map[string]string{
+		"event_id":       event.ID,
+		"aggregate_type": event.AggregateType,
+		"aggregate_id":   event.AggregateID,
+		"event_type":     event.EventType,
+		"message_id":     messageID,
+	}
+
+	topic := p.topics.Topic(event.EventType)
+	pubsubMessageID, err := p.publisher.Publish(ctx, topic, event.Data, attrs)
+	if err != nil {
+		return fmt.Errorf("failed to publish to pubsub: %w", err)
+	}
+
+	p.logger.Debug("published to pubsub",
+		zap.String("event_id", event.ID),
+		zap.String("topic", topic),
+		zap.String("message_id", pubsubMessageID),
+	)
+
+	return nil
+}
+
+// MessageID derives the message_id attribute consumers dedup on from an
+// outbox event ID, so a redelivered event carries the same one
+func MessageID(eventID string) string {
+	hash := sha256.Sum256([]byte(eventID))
+	return hex.EncodeToString(hash[:])
+}
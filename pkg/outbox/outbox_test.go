@@ -0,0 +1,210 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/pubsub"
+	"go.uber.org/zap"
+)
+
+// fakeStore is an in-memory Store recording how each event was marked
+type fakeStore struct {
+	mu           sync.Mutex
+	events       []Event
+	published    []string
+	failed       []string
+	deadLettered []string
+}
+
+func (s *fakeStore) GetUnpublished(ctx context.Context, limit int) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.events) > limit {
+		return s.events[:limit], nil
+	}
+	return s.events, nil
+}
+
+func (s *fakeStore) MarkPublished(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.published = append(s.published, eventID)
+	return nil
+}
+
+func (s *fakeStore) MarkFailed(ctx context.Context, eventID string, cause error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = append(s.failed, eventID)
+	return nil
+}
+
+func (s *fakeStore) MarkDeadLettered(ctx context.Context, eventID string, cause error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadLettered = append(s.deadLettered, eventID)
+	return nil
+}
+
+type publishedMessage struct {
+	topic string
+	data  []byte
+	attrs map[string]string
+}
+
+// fakePublisher records messages and fails events listed in fail. A
+// non-nil onPublish runs before each publish.
+type fakePublisher struct {
+	messages  []publishedMessage
+	fail      map[string]bool
+	onPublish func()
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, topic string, data []byte, attrs map[string]string) (string, error) {
+	if p.onPublish != nil {
+		p.onPublish()
+	}
+	if p.fail[attrs["event_id"]] {
+		return "", errors.New("pubsub unavailable")
+	}
+	p.messages = append(p.messages, publishedMessage{topic: topic, data: data, attrs: attrs})
+	return "server-id", nil
+}
+
+func newTestPublisher(store Store, publisher MessagePublisher, maxAttempts int) *Publisher {
+	return NewPublisher(store, publisher, pubsub.NewPrefixResolver("test-", nil), zap.NewNop(), time.Second, maxAttempts)
+}
+
+func TestProcessEventsPublishes(t *testing.T) {
+	store := &fakeStore{events: []Event{
+		{ID: "evt-1", AggregateType: "order", AggregateID: "order-1", EventType: "order.created", Data: []byte(`{"id":"order-1"}`)},
+	}}
+	publisher := &fakePublisher{}
+
+	if err := newTestPublisher(store, publisher, DefaultMaxAttempts).ProcessEvents(context.Background()); err != nil {
+		t.Fatalf("ProcessEvents failed: %v", err)
+	}
+
+	if len(publisher.messages) != 1 {
+		t.Fatalf("published %d messages, want 1", len(publisher.messages))
+	}
+	msg := publisher.messages[0]
+	if msg.topic != "test-order.created" {
+		t.Errorf("topic = %q, want test-order.created", msg.topic)
+	}
+	if string(msg.data) != `{"id":"order-1"}` {
+		t.Errorf("data = %s", msg.data)
+	}
+	want := map[string]string{
+		"event_id":       "evt-1",
+		"aggregate_type": "order",
+		"aggregate_id":   "order-1",
+		"event_type":     "order.created",
+		"message_id":     MessageID("evt-1"),
+	}
+	for key, value := range want {
+		if msg.attrs[key] != value {
+			t.Errorf("attribute %s = %q, want %q", key, msg.attrs[key], value)
+		}
+	}
+	if len(store.published) != 1 || store.published[0] != "evt-1" {
+		t.Errorf("published = %v, want [evt-1]", store.published)
+	}
+}
+
+func TestProcessEventsFailures(t *testing.T) {
+	tests := map[string]struct {
+		attempts        int
+		maxAttempts     int
+		wantFailed      bool
+		wantDeadLetters bool
+	}{
+		"first failure":         {attempts: 0, maxAttempts: 3, wantFailed: true},
+		"below max attempts":    {attempts: 1, maxAttempts: 3, wantFailed: true},
+		"reaching max attempts": {attempts: 2, maxAttempts: 3, wantDeadLetters: true},
+		"retrying forever":      {attempts: 1000, maxAttempts: 0, wantFailed: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := &fakeStore{events: []Event{
+				{ID: "evt-1", EventType: "order.created", Attempts: tt.attempts},
+				{ID: "evt-2", EventType: "order.created"},
+			}}
+			publisher := &fakePublisher{fail: map[string]bool{"evt-1": true}}
+
+			if err := newTestPublisher(store, publisher, tt.maxAttempts).ProcessEvents(context.Background()); err != nil {
+				t.Fatalf("ProcessEvents failed: %v", err)
+			}
+
+			if got := len(store.failed) == 1; got != tt.wantFailed {
+				t.Errorf("failed = %v, want marked failed %v", store.failed, tt.wantFailed)
+			}
+			if got := len(store.deadLettered) == 1; got != tt.wantDeadLetters {
+				t.Errorf("dead-lettered = %v, want dead-lettered %v", store.deadLettered, tt.wantDeadLetters)
+			}
+			// A failed event does not hold up the rest of the batch
+			if len(store.published) != 1 || store.published[0] != "evt-2" {
+				t.Errorf("published = %v, want [evt-2]", store.published)
+			}
+		})
+	}
+}
+
+func TestProcessEventsStopsOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := &fakeStore{events: []Event{{ID: "evt-1"}, {ID: "evt-2"}}}
+	// Shutdown begins while the first event is being published
+	publisher := &fakePublisher{onPublish: cancel}
+
+	if err := newTestPublisher(store, publisher, DefaultMaxAttempts).ProcessEvents(ctx); err != nil {
+		t.Fatalf("ProcessEvents failed: %v", err)
+	}
+
+	if len(store.published) != 1 || store.published[0] != "evt-1" {
+		t.Errorf("published = %v, want only the in-flight evt-1 marked", store.published)
+	}
+	if len(publisher.messages) != 1 {
+		t.Errorf("published %d messages after shutdown, want 1", len(publisher.messages))
+	}
+}
+
+func TestTickRecoversFromPanic(t *testing.T) {
+	store := &fakeStore{events: []Event{{ID: "evt-1"}}}
+	publisher := &fakePublisher{onPublish: func() { panic("boom") }}
+	p := newTestPublisher(store, publisher, DefaultMaxAttempts)
+	p.heartbeat.Store(time.Now().Add(-time.Hour).UnixNano())
+
+	p.tick(context.Background())
+
+	if err := p.CheckHeartbeat(3); err != nil {
+		t.Errorf("heartbeat not updated after a panicking tick: %v", err)
+	}
+}
+
+func TestCheckHeartbeat(t *testing.T) {
+	p := newTestPublisher(&fakeStore{}, &fakePublisher{}, DefaultMaxAttempts)
+	if err := p.CheckHeartbeat(3); err != nil {
+		t.Errorf("fresh publisher reported stalled: %v", err)
+	}
+
+	p.heartbeat.Store(time.Now().Add(-5 * time.Second).UnixNano())
+	if err := p.CheckHeartbeat(3); err == nil {
+		t.Error("publisher 5 intervals behind not reported stalled")
+	}
+}
+
+func TestMessageIDIsStable(t *testing.T) {
+	if MessageID("evt-1") != MessageID("evt-1") {
+		t.Error("MessageID differs for the same event")
+	}
+	if MessageID("evt-1") == MessageID("evt-2") {
+		t.Error("MessageID is the same for different events")
+	}
+}
@@ -0,0 +1,97 @@
+// Package pagination applies page size limits to list endpoints.
+package pagination
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mumumio1/coldy/pkg/config"
+	"github.com/mumumio1/coldy/pkg/errs"
+)
+
+// Page sizes used when a service doesn't configure its own
+const (
+	DefaultPageSize    = 20
+	DefaultMaxPageSize = 100
+)
+
+// ErrInvalidPageSize is returned for a negative page size
+var ErrInvalidPageSize = errs.InvalidArgument("INVALID_PAGE_SIZE", "invalid page size")
+
+// Clamp returns the page size to use for a requested size: defaultSize when
+// none was requested, the request capped at maxSize otherwise. A negative
+// request fails with ErrInvalidPageSize.
+func Clamp(requested int32, defaultSize, maxSize int) (int, error) {
+	if requested < 0 {
+		return 0, fmt.Errorf("%w: page_size must not be negative, got %d", ErrInvalidPageSize, requested)
+	}
+
+	size := int(requested)
+	if size == 0 {
+		size = defaultSize
+	}
+	if size > maxSize {
+		size = maxSize
+	}
+	return size, nil
+}
+
+// Config holds the page sizes of a service's list endpoints
+type Config struct {
+	DefaultSize int `env:"PAGE_SIZE_DEFAULT" validate:"min=1"`
+	MaxSize     int `env:"PAGE_SIZE_MAX" validate:"min=1"`
+	// MethodMaxSize overrides MaxSize per full RPC method name
+	MethodMaxSize map[string]int
+}
+
+// LoadConfig loads the page sizes from the environment over the package
+// defaults, with per-method maximums from PAGE_SIZE_METHOD_MAX
+func LoadConfig() (Config, error) {
+	cfg := Config{DefaultSize: DefaultPageSize, MaxSize: DefaultMaxPageSize}
+	if err := config.Load(&cfg); err != nil {
+		return Config{}, err
+	}
+
+	sizes, err := ParseMethodMaxSize(os.Getenv("PAGE_SIZE_METHOD_MAX"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid PAGE_SIZE_METHOD_MAX: %w", err)
+	}
+	cfg.MethodMaxSize = sizes
+	return cfg, nil
+}
+
+// Max returns the maximum page size of method
+func (c Config) Max(method string) int {
+	if size, ok := c.MethodMaxSize[method]; ok {
+		return size
+	}
+	return c.MaxSize
+}
+
+// Clamp applies Clamp with the config's default and method's maximum
+func (c Config) Clamp(method string, requested int32) (int, error) {
+	return Clamp(requested, c.DefaultSize, c.Max(method))
+}
+
+// ParseMethodMaxSize parses "/pkg.Service/Method=N" pairs separated by commas
+func ParseMethodMaxSize(s string) (map[string]int, error) {
+	sizes := make(map[string]int)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(pair, "=")
+		if !ok || !strings.HasPrefix(method, "/") {
+			return nil, fmt.Errorf("invalid max page size %q", pair)
+		}
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid max page size %q", pair)
+		}
+		sizes[method] = size
+	}
+	return sizes, nil
+}
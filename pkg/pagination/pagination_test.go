@@ -0,0 +1,39 @@
+package pagination
+
+import "testing"
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("PAGE_SIZE_MAX", "50")
+	t.Setenv("PAGE_SIZE_METHOD_MAX", "/coldy.catalog.v1.CatalogService/ListProducts=200")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.DefaultSize != DefaultPageSize {
+		t.Errorf("DefaultSize = %d, want %d", cfg.DefaultSize, DefaultPageSize)
+	}
+	if got := cfg.Max("/coldy.users.v1.UserService/ListUsers"); got != 50 {
+		t.Errorf("Max = %d, want 50", got)
+	}
+	if got := cfg.Max("/coldy.catalog.v1.CatalogService/ListProducts"); got != 200 {
+		t.Errorf("ListProducts Max = %d, want 200", got)
+	}
+}
+
+func TestLoadConfigRejectsInvalidValues(t *testing.T) {
+	tests := map[string]struct{ key, value string }{
+		"zero default":    {"PAGE_SIZE_DEFAULT", "0"},
+		"non-numeric max": {"PAGE_SIZE_MAX", "lots"},
+		"malformed sizes": {"PAGE_SIZE_METHOD_MAX", "ListProducts=200"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			if _, err := LoadConfig(); err == nil {
+				t.Errorf("expected an error for %s=%s", tt.key, tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Subscription defaults applied by Bootstrap when a spec leaves them unset
+const (
+	DefaultAckDeadline         = 60 * time.Second
+	DefaultMaxDeliveryAttempts = 5
+
+	// Pub/Sub's own retry bounds, used to fill in half a retry policy
+	defaultMinBackoff = 10 * time.Second
+	defaultMaxBackoff = 600 * time.Second
+)
+
+// TopicSpec declares a topic and the subscriptions attached to it
+type TopicSpec struct {
+	Name          string
+	Subscriptions []SubscriptionSpec
+}
+
+// SubscriptionSpec declares a subscription. Zero values fall back to the
+// defaults above or, for the retry policy, to Pub/Sub's own defaults.
+type SubscriptionSpec struct {
+	Name        string
+	AckDeadline time.Duration
+	// DeadLetterTopic is the name of a topic that receives messages after
+	// MaxDeliveryAttempts failed deliveries; it is created if missing
+	DeadLetterTopic     string
+	MaxDeliveryAttempts int
+	// MinBackoff and MaxBackoff bound the redelivery delay of nacked messages
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Bootstrap ensures every topic in spec and its subscriptions exist. Existing
+// subscriptions have their ack deadline, dead-letter and retry settings
+// brought in line with the spec, so running it again with the same spec makes
+// no changes. Dead-lettering also needs the Pub/Sub service account to be
+// allowed to publish to the dead-letter topic, which is left to the project
+// setup.
+func Bootstrap(ctx context.Context, client *pubsub.Client, spec []TopicSpec) error {
+	for _, topicSpec := range spec {
+		topic, err := ensureTopic(ctx, client, topicSpec.Name)
+		if err != nil {
+			return err
+		}
+
+		for _, subSpec := range topicSpec.Subscriptions {
+			config, err := subscriptionConfig(ctx, client, topic, subSpec)
+			if err != nil {
+				return err
+			}
+			if err := ensureSubscription(ctx, client, subSpec.Name, config); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RunBootstrap connects to projectID and runs Bootstrap. Services call it on
+// startup when topic and subscription creation is enabled.
+func RunBootstrap(ctx context.Context, projectID string, spec []TopicSpec) error {
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to create pubsub client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	return Bootstrap(ctx, client, spec)
+}
+
+func ensureTopic(ctx context.Context, client *pubsub.Client, name string) (*pubsub.Topic, error) {
+	topic := client.Topic(name)
+	exists, err := topic.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check topic %s existence: %w", name, err)
+	}
+	if exists {
+		return topic, nil
+	}
+
+	topic, err = client.CreateTopic(ctx, name)
+	if status.Code(err) == codes.AlreadyExists {
+		// Another instance created it first
+		return client.Topic(name), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create topic %s: %w", name, err)
+	}
+
+	logger.FromContext(ctx).Info("created topic", zap.String("topic", name))
+	return topic, nil
+}
+
+func subscriptionConfig(ctx context.Context, client *pubsub.Client, topic *pubsub.Topic, spec SubscriptionSpec) (pubsub.SubscriptionConfig, error) {
+	config := pubsub.SubscriptionConfig{
+		Topic:       topic,
+		AckDeadline: spec.AckDeadline,
+	}
+	if config.AckDeadline <= 0 {
+		config.AckDeadline = DefaultAckDeadline
+	}
+
+	if spec.DeadLetterTopic != "" {
+		deadLetter, err := ensureTopic(ctx, client, spec.DeadLetterTopic)
+		if err != nil {
+			return config, err
+		}
+		attempts := spec.MaxDeliveryAttempts
+		if attempts <= 0 {
+			attempts = DefaultMaxDeliveryAttempts
+		}
+		config.DeadLetterPolicy = &pubsub.DeadLetterPolicy{
+			DeadLetterTopic:     deadLetter.String(),
+			MaxDeliveryAttempts: attempts,
+		}
+	}
+
+	if spec.MinBackoff > 0 || spec.MaxBackoff > 0 {
+		// Set both bounds so the policy compares equal to what Pub/Sub returns
+		minBackoff, maxBackoff := spec.MinBackoff, spec.MaxBackoff
+		if minBackoff <= 0 {
+			minBackoff = defaultMinBackoff
+		}
+		if maxBackoff <= 0 {
+			maxBackoff = defaultMaxBackoff
+		}
+		config.RetryPolicy = &pubsub.RetryPolicy{
+			MinimumBackoff: minBackoff,
+			MaximumBackoff: maxBackoff,
+		}
+	}
+
+	return config, nil
+}
+
+func ensureSubscription(ctx context.Context, client *pubsub.Client, name string, want pubsub.SubscriptionConfig) error {
+	sub := client.Subscription(name)
+	exists, err := sub.Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check subscription %s existence: %w", name, err)
+	}
+
+	if !exists {
+		_, err = client.CreateSubscription(ctx, name, want)
+		if status.Code(err) != codes.AlreadyExists {
+			if err != nil {
+				return fmt.Errorf("failed to create subscription %s: %w", name, err)
+			}
+			logger.FromContext(ctx).Info("created subscription",
+				zap.String("subscription", name),
+				zap.String("topic", want.Topic.ID()),
+			)
+			return nil
+		}
+		// Another instance created it first; reconcile it below
+	}
+
+	current, err := sub.Config(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription %s config: %w", name, err)
+	}
+	if current.Topic == nil || current.Topic.String() != want.Topic.String() {
+		return fmt.Errorf("subscription %s is attached to a different topic than %s", name, want.Topic.ID())
+	}
+
+	update, changed := subscriptionUpdate(current, want)
+	if !changed {
+		return nil
+	}
+	if _, err := sub.Update(ctx, update); err != nil {
+		return fmt.Errorf("failed to update subscription %s: %w", name, err)
+	}
+
+	logger.FromContext(ctx).Info("updated subscription", zap.String("subscription", name))
+	return nil
+}
+
+// subscriptionUpdate returns the changes needed to bring current in line with
+// want, and whether there are any
+func subscriptionUpdate(current, want pubsub.SubscriptionConfig) (pubsub.SubscriptionConfigToUpdate, bool) {
+	var update pubsub.SubscriptionConfigToUpdate
+	changed := false
+
+	if current.AckDeadline != want.AckDeadline {
+		update.AckDeadline = want.AckDeadline
+		changed = true
+	}
+
+	if !sameDeadLetterPolicy(current.DeadLetterPolicy, want.DeadLetterPolicy) {
+		// An empty policy removes dead-lettering
+		update.DeadLetterPolicy = &pubsub.DeadLetterPolicy{}
+		if want.DeadLetterPolicy != nil {
+			update.DeadLetterPolicy = want.DeadLetterPolicy
+		}
+		changed = true
+	}
+
+	if !sameRetryPolicy(current.RetryPolicy, want.RetryPolicy) {
+		// An empty policy restores the default retry behavior
+		update.RetryPolicy = &pubsub.RetryPolicy{}
+		if want.RetryPolicy != nil {
+			update.RetryPolicy = want.RetryPolicy
+		}
+		changed = true
+	}
+
+	return update, changed
+}
+
+func sameDeadLetterPolicy(a, b *pubsub.DeadLetterPolicy) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.DeadLetterTopic == b.DeadLetterTopic && a.MaxDeliveryAttempts == b.MaxDeliveryAttempts
+}
+
+func sameRetryPolicy(a, b *pubsub.RetryPolicy) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.MinimumBackoff == b.MinimumBackoff && a.MaximumBackoff == b.MaximumBackoff
+}
@@ -0,0 +1,179 @@
+package pubsub
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+)
+
+func orderSpec() []TopicSpec {
+	return []TopicSpec{{
+		Name: "order.created",
+		Subscriptions: []SubscriptionSpec{{
+			Name:            "order-created-sub",
+			DeadLetterTopic: "order.created.dlq",
+			MinBackoff:      5 * time.Second,
+		}},
+	}}
+}
+
+func TestBootstrapCreatesTopicsAndSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if err := Bootstrap(ctx, client, orderSpec()); err != nil {
+		t.Fatalf("Bootstrap failed: %v", err)
+	}
+
+	for _, name := range []string{"order.created", "order.created.dlq"} {
+		if exists, err := client.Topic(name).Exists(ctx); err != nil || !exists {
+			t.Errorf("topic %s exists = %v (%v), want created", name, exists, err)
+		}
+	}
+
+	config, err := client.Subscription("order-created-sub").Config(ctx)
+	if err != nil {
+		t.Fatalf("failed to get subscription config: %v", err)
+	}
+	if config.Topic.ID() != "order.created" {
+		t.Errorf("subscription topic = %s, want order.created", config.Topic.ID())
+	}
+	if config.AckDeadline != DefaultAckDeadline {
+		t.Errorf("ack deadline = %s, want the default %s", config.AckDeadline, DefaultAckDeadline)
+	}
+	if config.DeadLetterPolicy == nil || !strings.HasSuffix(config.DeadLetterPolicy.DeadLetterTopic, "/order.created.dlq") ||
+		config.DeadLetterPolicy.MaxDeliveryAttempts != DefaultMaxDeliveryAttempts {
+		t.Errorf("dead-letter policy = %+v, want order.created.dlq after %d attempts", config.DeadLetterPolicy, DefaultMaxDeliveryAttempts)
+	}
+	if config.RetryPolicy == nil || config.RetryPolicy.MinimumBackoff != 5*time.Second ||
+		config.RetryPolicy.MaximumBackoff != defaultMaxBackoff {
+		t.Errorf("retry policy = %+v, want 5s to the default maximum", config.RetryPolicy)
+	}
+}
+
+func TestBootstrapIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if err := Bootstrap(ctx, client, orderSpec()); err != nil {
+		t.Fatalf("first Bootstrap failed: %v", err)
+	}
+	if err := Bootstrap(ctx, client, orderSpec()); err != nil {
+		t.Fatalf("second Bootstrap failed: %v", err)
+	}
+
+	config, err := client.Subscription("order-created-sub").Config(ctx)
+	if err != nil {
+		t.Fatalf("failed to get subscription config: %v", err)
+	}
+	want, err := subscriptionConfig(ctx, client, client.Topic("order.created"), orderSpec()[0].Subscriptions[0])
+	if err != nil {
+		t.Fatalf("subscriptionConfig failed: %v", err)
+	}
+	if _, changed := subscriptionUpdate(config, want); changed {
+		t.Error("a second run left the subscription differing from its spec")
+	}
+}
+
+func TestBootstrapUpdatesExistingSubscription(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	topic, err := client.CreateTopic(ctx, "order.created")
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	_, err = client.CreateSubscription(ctx, "order-created-sub", pubsub.SubscriptionConfig{
+		Topic:       topic,
+		AckDeadline: 10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	if err := Bootstrap(ctx, client, orderSpec()); err != nil {
+		t.Fatalf("Bootstrap failed: %v", err)
+	}
+
+	config, err := client.Subscription("order-created-sub").Config(ctx)
+	if err != nil {
+		t.Fatalf("failed to get subscription config: %v", err)
+	}
+	if config.AckDeadline != DefaultAckDeadline {
+		t.Errorf("ack deadline = %s, want it updated to %s", config.AckDeadline, DefaultAckDeadline)
+	}
+	if config.DeadLetterPolicy == nil {
+		t.Error("dead-letter policy was not added")
+	}
+}
+
+func TestBootstrapRejectsSubscriptionOfAnotherTopic(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	other, err := client.CreateTopic(ctx, "payment.succeeded")
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	if _, err := client.CreateSubscription(ctx, "order-created-sub", pubsub.SubscriptionConfig{Topic: other}); err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	err = Bootstrap(ctx, client, orderSpec())
+	if err == nil || !strings.Contains(err.Error(), "different topic") {
+		t.Errorf("expected a different-topic error, got %v", err)
+	}
+}
+
+func TestSubscriptionUpdate(t *testing.T) {
+	deadLetter := &pubsub.DeadLetterPolicy{DeadLetterTopic: "projects/p/topics/dlq", MaxDeliveryAttempts: 5}
+	retry := &pubsub.RetryPolicy{MinimumBackoff: 10 * time.Second, MaximumBackoff: time.Minute}
+
+	tests := map[string]struct {
+		current, want  pubsub.SubscriptionConfig
+		wantChanged    bool
+		wantDeadLetter *pubsub.DeadLetterPolicy
+		wantRetry      *pubsub.RetryPolicy
+	}{
+		"unchanged": {
+			current: pubsub.SubscriptionConfig{AckDeadline: time.Minute, DeadLetterPolicy: deadLetter, RetryPolicy: retry},
+			want:    pubsub.SubscriptionConfig{AckDeadline: time.Minute, DeadLetterPolicy: deadLetter, RetryPolicy: retry},
+		},
+		"dead-lettering removed": {
+			current:        pubsub.SubscriptionConfig{AckDeadline: time.Minute, DeadLetterPolicy: deadLetter},
+			want:           pubsub.SubscriptionConfig{AckDeadline: time.Minute},
+			wantChanged:    true,
+			wantDeadLetter: &pubsub.DeadLetterPolicy{},
+		},
+		"retry policy reset": {
+			current:     pubsub.SubscriptionConfig{AckDeadline: time.Minute, RetryPolicy: retry},
+			want:        pubsub.SubscriptionConfig{AckDeadline: time.Minute},
+			wantChanged: true,
+			wantRetry:   &pubsub.RetryPolicy{},
+		},
+		"attempts changed": {
+			current:        pubsub.SubscriptionConfig{AckDeadline: time.Minute, DeadLetterPolicy: deadLetter},
+			want:           pubsub.SubscriptionConfig{AckDeadline: time.Minute, DeadLetterPolicy: &pubsub.DeadLetterPolicy{DeadLetterTopic: deadLetter.DeadLetterTopic, MaxDeliveryAttempts: 10}},
+			wantChanged:    true,
+			wantDeadLetter: &pubsub.DeadLetterPolicy{DeadLetterTopic: deadLetter.DeadLetterTopic, MaxDeliveryAttempts: 10},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			update, changed := subscriptionUpdate(tt.current, tt.want)
+			if changed != tt.wantChanged {
+				t.Fatalf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !sameDeadLetterPolicy(update.DeadLetterPolicy, tt.wantDeadLetter) {
+				t.Errorf("dead-letter update = %+v, want %+v", update.DeadLetterPolicy, tt.wantDeadLetter)
+			}
+			if !sameRetryPolicy(update.RetryPolicy, tt.wantRetry) {
+				t.Errorf("retry update = %+v, want %+v", update.RetryPolicy, tt.wantRetry)
+			}
+		})
+	}
+}
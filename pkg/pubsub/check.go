@@ -0,0 +1,73 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/pubsub"
+)
+
+// ErrMissingTopics is returned when expected topics don't exist and may not
+// be created
+var ErrMissingTopics = errors.New("missing pubsub topics")
+
+// TopicCheck verifies that the topics a service publishes to exist, so a
+// misconfigured project shows up at startup and in readiness rather than at
+// the first failed publish
+type TopicCheck struct {
+	client *pubsub.Client
+	topics []string
+	create bool
+
+	mu       sync.Mutex
+	verified bool
+}
+
+// NewTopicCheck creates a check of topics. With create set, missing topics
+// are created instead of reported.
+func (p *Publisher) NewTopicCheck(topics []string, create bool) *TopicCheck {
+	return &TopicCheck{
+		client: p.client,
+		topics: topics,
+		create: create,
+	}
+}
+
+// Verify checks every topic and fails with ErrMissingTopics naming those that
+// are missing. Once all topics were verified it returns nil without calling
+// Pub/Sub, so it is cheap enough for a readiness probe.
+func (c *TopicCheck) Verify(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.verified {
+		return nil
+	}
+
+	var missing []string
+	for _, name := range c.topics {
+		if c.create {
+			if _, err := ensureTopic(ctx, c.client, name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		exists, err := c.client.Topic(name).Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check topic %s existence: %w", name, err)
+		}
+		if !exists {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingTopics, strings.Join(missing, ", "))
+	}
+
+	c.verified = true
+	return nil
+}
@@ -0,0 +1,84 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	"go.uber.org/zap"
+)
+
+// Message attributes set by the outbox publishers
+const (
+	AttrEventType   = "event_type"
+	AttrAggregateID = "aggregate_id"
+)
+
+// ErrUnknownEventType is returned for messages with no registered handler
+// when the dispatcher nacks unknown events
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// UnknownEventPolicy decides what happens to messages with no registered handler
+type UnknownEventPolicy int
+
+const (
+	// AckUnknown acknowledges and drops unknown events
+	AckUnknown UnknownEventPolicy = iota
+	// NackUnknown nacks unknown events so they are redelivered
+	NackUnknown
+)
+
+// Dispatcher routes messages to typed handlers by their event_type attribute
+type Dispatcher struct {
+	handlers map[string]MessageHandler
+	unknown  UnknownEventPolicy
+	logger   *zap.Logger
+}
+
+// NewDispatcher creates a new dispatcher
+func NewDispatcher(unknown UnknownEventPolicy, logger *zap.Logger) *Dispatcher {
+	return &Dispatcher{
+		handlers: make(map[string]MessageHandler),
+		unknown:  unknown,
+		logger:   logger,
+	}
+}
+
+// Handle registers a handler for an event type. The message data is decoded
+// from JSON into T; payloads that fail to decode are logged and acked, since
+// redelivery would never succeed. A later registration replaces an earlier one.
+func Handle[T any](d *Dispatcher, eventType string, handler func(ctx context.Context, attrs map[string]string, event T) error) {
+	d.handlers[eventType] = func(ctx context.Context, msg *pubsub.Message) error {
+		var event T
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			d.logger.Error("failed to decode event",
+				zap.String("event_type", eventType),
+				zap.String("message_id", msg.ID),
+				zap.Error(err),
+			)
+			return nil
+		}
+		return handler(ctx, msg.Attributes, event)
+	}
+}
+
+// HandleMessage dispatches a message; it satisfies MessageHandler
+func (d *Dispatcher) HandleMessage(ctx context.Context, msg *pubsub.Message) error {
+	eventType := msg.Attributes[AttrEventType]
+
+	handler, ok := d.handlers[eventType]
+	if !ok {
+		if d.unknown == NackUnknown {
+			return fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+		}
+		d.logger.Debug("dropping unknown event",
+			zap.String("event_type", eventType),
+			zap.String("message_id", msg.ID),
+		)
+		return nil
+	}
+
+	return handler(ctx, msg)
+}
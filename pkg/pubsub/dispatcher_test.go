@@ -0,0 +1,119 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"go.uber.org/zap"
+)
+
+type testEvent struct {
+	OrderID string `json:"order_id"`
+}
+
+func newDispatchMessage(eventType, data string) *pubsub.Message {
+	return &pubsub.Message{
+		ID:         "msg-1",
+		Data:       []byte(data),
+		Attributes: map[string]string{AttrEventType: eventType, "event_id": "evt-1"},
+	}
+}
+
+func TestDispatcherDecodesTypedEvents(t *testing.T) {
+	d := NewDispatcher(NackUnknown, zap.NewNop())
+
+	var got testEvent
+	var gotAttrs map[string]string
+	Handle(d, "order.created", func(_ context.Context, attrs map[string]string, event testEvent) error {
+		got, gotAttrs = event, attrs
+		return nil
+	})
+
+	if err := d.HandleMessage(context.Background(), newDispatchMessage("order.created", `{"order_id":"o-1"}`)); err != nil {
+		t.Fatalf("HandleMessage failed: %v", err)
+	}
+	if got.OrderID != "o-1" {
+		t.Errorf("OrderID = %q, want o-1", got.OrderID)
+	}
+	if gotAttrs["event_id"] != "evt-1" {
+		t.Errorf("attrs = %v, want the message attributes", gotAttrs)
+	}
+}
+
+func TestDispatcherHandlerError(t *testing.T) {
+	d := NewDispatcher(NackUnknown, zap.NewNop())
+	errDown := errors.New("downstream unavailable")
+	Handle(d, "order.created", func(context.Context, map[string]string, testEvent) error {
+		return errDown
+	})
+
+	err := d.HandleMessage(context.Background(), newDispatchMessage("order.created", `{}`))
+	if !errors.Is(err, errDown) {
+		t.Errorf("expected the handler error, got %v", err)
+	}
+}
+
+func TestDispatcherAcksMalformedPayload(t *testing.T) {
+	d := NewDispatcher(NackUnknown, zap.NewNop())
+	called := false
+	Handle(d, "order.created", func(context.Context, map[string]string, testEvent) error {
+		called = true
+		return nil
+	})
+
+	if err := d.HandleMessage(context.Background(), newDispatchMessage("order.created", `{"order_id":7}`)); err != nil {
+		t.Errorf("expected a malformed payload to be acked, got %v", err)
+	}
+	if called {
+		t.Error("handler called for a payload that failed to decode")
+	}
+}
+
+func TestDispatcherUnknownEvents(t *testing.T) {
+	tests := map[string]struct {
+		policy  UnknownEventPolicy
+		wantErr error
+	}{
+		"ack":  {policy: AckUnknown},
+		"nack": {policy: NackUnknown, wantErr: ErrUnknownEventType},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := NewDispatcher(tt.policy, zap.NewNop())
+			Handle(d, "order.created", func(context.Context, map[string]string, testEvent) error {
+				t.Error("handler called for another event type")
+				return nil
+			})
+
+			for _, eventType := range []string{"order.teleported", ""} {
+				err := d.HandleMessage(context.Background(), newDispatchMessage(eventType, `{}`))
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("event type %q: err = %v, want %v", eventType, err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatcherLaterRegistrationReplaces(t *testing.T) {
+	d := NewDispatcher(NackUnknown, zap.NewNop())
+	var calls []string
+	Handle(d, "order.created", func(context.Context, map[string]string, testEvent) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	Handle(d, "order.created", func(context.Context, map[string]string, testEvent) error {
+		calls = append(calls, "second")
+		return nil
+	})
+
+	if err := d.HandleMessage(context.Background(), newDispatchMessage("order.created", `{}`)); err != nil {
+		t.Fatalf("HandleMessage failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "second" {
+		t.Errorf("calls = %v, want only the second handler", calls)
+	}
+}
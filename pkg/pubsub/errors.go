@@ -0,0 +1,63 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/pubsub"
+	"go.uber.org/zap"
+)
+
+// PermanentError marks a handler error that redelivery cannot fix, such as
+// a payload that references data which will never exist
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return "permanent: " + e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps err so WithErrorPolicy acks the message instead of
+// nacking it; a nil err stays nil
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// WithErrorPolicy wraps a handler so permanent errors are logged and acked,
+// while transient errors are nacked at once. The subscription's retry policy
+// spaces out redeliveries and its dead-letter policy ends them; waiting here
+// instead would hold the message's flow-control slot and ack deadline.
+func WithErrorPolicy(logger *zap.Logger) func(MessageHandler) MessageHandler {
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, msg *pubsub.Message) error {
+			err := next(ctx, msg)
+			if err == nil {
+				return nil
+			}
+
+			if IsPermanent(err) {
+				logger.Error("dropping message after permanent failure",
+					zap.String("message_id", msg.ID),
+					zap.Error(err),
+				)
+				return nil
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"go.uber.org/zap"
+)
+
+func TestWithErrorPolicyAcksPermanentErrors(t *testing.T) {
+	h := WithErrorPolicy(zap.NewNop())(func(context.Context, *pubsub.Message) error {
+		return Permanent(errors.New("order does not exist"))
+	})
+
+	if err := h(context.Background(), &pubsub.Message{ID: "msg-1"}); err != nil {
+		t.Errorf("expected a permanent error to be acked, got %v", err)
+	}
+}
+
+func TestWithErrorPolicyNacksTransientErrorsAtOnce(t *testing.T) {
+	errDown := errors.New("smtp unavailable")
+	h := WithErrorPolicy(zap.NewNop())(func(context.Context, *pubsub.Message) error {
+		return errDown
+	})
+
+	// A late delivery attempt must not hold the message while backing off;
+	// the subscription's retry policy delays the redelivery
+	attempt := 10
+	start := time.Now()
+	err := h(context.Background(), &pubsub.Message{ID: "msg-1", DeliveryAttempt: &attempt})
+	if !errors.Is(err, errDown) {
+		t.Fatalf("expected the transient error to be returned, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("nack took %s, want no wait", elapsed)
+	}
+}
+
+func TestWithErrorPolicyPassesSuccess(t *testing.T) {
+	h := WithErrorPolicy(zap.NewNop())(func(context.Context, *pubsub.Message) error {
+		return nil
+	})
+
+	if err := h(context.Background(), &pubsub.Message{ID: "msg-1"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
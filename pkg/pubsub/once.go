@@ -0,0 +1,99 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Pub/Sub delivers at least once, so consumers must tolerate redelivery.
+// ProcessOnce records a marker per message ID and only runs the handler for
+// the first delivery.
+//
+// The marker is written before the handler runs and is kept after it
+// succeeds (mark-before-ack). A crash after processing but before the ack
+// leads to a redelivery that finds the marker and is skipped, so the side
+// effect happens once. The cost is the opposite crash window: if the process
+// dies while the handler is running, the "processing" marker blocks retries
+// until its lease expires. Acking before marking would avoid that delay but
+// loses the message entirely on a crash, which is worse for anything that
+// cannot be recomputed.
+
+const (
+	// DefaultProcessingLease bounds how long a crashed delivery blocks retries
+	DefaultProcessingLease = time.Minute
+
+	markerProcessing = "processing"
+	markerDone       = "done"
+)
+
+// ErrInProgress is returned when another delivery of the same message is
+// still being processed; nack and let Pub/Sub redeliver later
+var ErrInProgress = errors.New("message is being processed")
+
+// OnceStore is the subset of cache operations ProcessOnce needs
+type OnceStore interface {
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Once deduplicates message processing across consumer replicas
+type Once struct {
+	store     OnceStore
+	keyPrefix string
+	lease     time.Duration
+}
+
+// NewOnce creates a new Once; lease defaults to DefaultProcessingLease
+func NewOnce(store OnceStore, keyPrefix string, lease time.Duration) *Once {
+	if lease <= 0 {
+		lease = DefaultProcessingLease
+	}
+	return &Once{
+		store:     store,
+		keyPrefix: keyPrefix,
+		lease:     lease,
+	}
+}
+
+// ProcessOnce runs fn unless messageID was already processed within ttl.
+// It reports whether fn ran. When fn fails the marker is removed so a
+// redelivery retries it. If fn succeeded but the marker could not be
+// updated, processed is true alongside the error and the message should
+// still be acked.
+func (o *Once) ProcessOnce(ctx context.Context, messageID string, ttl time.Duration, fn func() error) (bool, error) {
+	key := o.keyPrefix + messageID
+
+	acquired, err := o.store.SetNX(ctx, key, markerProcessing, o.lease)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire message marker: %w", err)
+	}
+	if !acquired {
+		marker, err := o.store.Get(ctx, key)
+		if err != nil {
+			return false, fmt.Errorf("failed to read message marker: %w", err)
+		}
+		if marker == markerProcessing || marker == "" {
+			// Still processing, or the lease expired between SetNX and Get;
+			// either way a later redelivery retries
+			return false, ErrInProgress
+		}
+		return false, nil
+	}
+
+	if err := fn(); err != nil {
+		if delErr := o.store.Delete(context.WithoutCancel(ctx), key); delErr != nil {
+			return true, errors.Join(err, fmt.Errorf("failed to release message marker: %w", delErr))
+		}
+		return true, err
+	}
+
+	if err := o.store.Set(context.WithoutCancel(ctx), key, markerDone, ttl); err != nil {
+		return true, fmt.Errorf("failed to mark message processed: %w", err)
+	}
+
+	return true, nil
+}
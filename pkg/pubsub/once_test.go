@@ -0,0 +1,201 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// onceStore is an in-memory OnceStore that records TTLs and can fail writes
+type onceStore struct {
+	mu      sync.Mutex
+	values  map[string]string
+	ttls    map[string]time.Duration
+	failSet error
+	failNX  error
+}
+
+func newOnceStore() *onceStore {
+	return &onceStore{values: make(map[string]string), ttls: make(map[string]time.Duration)}
+}
+
+func (s *onceStore) SetNX(_ context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failNX != nil {
+		return false, s.failNX
+	}
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+	s.values[key] = fmt.Sprint(value)
+	s.ttls[key] = ttl
+	return true, nil
+}
+
+func (s *onceStore) Get(_ context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key], nil
+}
+
+func (s *onceStore) Set(_ context.Context, key string, value interface{}, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failSet != nil {
+		return s.failSet
+	}
+	s.values[key] = fmt.Sprint(value)
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *onceStore) Delete(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.values, key)
+		delete(s.ttls, key)
+	}
+	return nil
+}
+
+func (s *onceStore) marker(key string) (string, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key], s.ttls[key]
+}
+
+func TestProcessOnceFirstDeliveryAndRedelivery(t *testing.T) {
+	store := newOnceStore()
+	once := NewOnce(store, "test:", time.Minute)
+	ctx := context.Background()
+
+	calls := 0
+	fn := func() error {
+		calls++
+		return nil
+	}
+
+	processed, err := once.ProcessOnce(ctx, "msg-1", time.Hour, fn)
+	if !processed || err != nil {
+		t.Fatalf("first delivery: processed=%v err=%v, want processed", processed, err)
+	}
+	if marker, ttl := store.marker("test:msg-1"); marker != markerDone || ttl != time.Hour {
+		t.Errorf("marker = %q for %s, want %q for 1h", marker, ttl, markerDone)
+	}
+
+	processed, err = once.ProcessOnce(ctx, "msg-1", time.Hour, fn)
+	if processed || err != nil {
+		t.Errorf("redelivery: processed=%v err=%v, want skipped", processed, err)
+	}
+	if calls != 1 {
+		t.Errorf("fn ran %d times, want 1", calls)
+	}
+
+	// Other messages are unaffected
+	if processed, _ := once.ProcessOnce(ctx, "msg-2", time.Hour, fn); !processed || calls != 2 {
+		t.Errorf("another message: processed=%v calls=%d, want processed", processed, calls)
+	}
+}
+
+func TestProcessOnceFailureThenRetry(t *testing.T) {
+	store := newOnceStore()
+	once := NewOnce(store, "test:", time.Minute)
+	ctx := context.Background()
+
+	errHandler := errors.New("smtp unavailable")
+	processed, err := once.ProcessOnce(ctx, "msg-1", time.Hour, func() error { return errHandler })
+	if !processed || !errors.Is(err, errHandler) {
+		t.Fatalf("failed delivery: processed=%v err=%v, want the handler error", processed, err)
+	}
+	if marker, _ := store.marker("test:msg-1"); marker != "" {
+		t.Errorf("marker %q kept after a failure; a redelivery could not retry", marker)
+	}
+
+	calls := 0
+	processed, err = once.ProcessOnce(ctx, "msg-1", time.Hour, func() error {
+		calls++
+		return nil
+	})
+	if !processed || err != nil || calls != 1 {
+		t.Errorf("retry: processed=%v err=%v calls=%d, want processed once", processed, err, calls)
+	}
+}
+
+func TestProcessOnceInProgress(t *testing.T) {
+	store := newOnceStore()
+	once := NewOnce(store, "test:", 30*time.Second)
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := once.ProcessOnce(ctx, "msg-1", time.Hour, func() error {
+			close(started)
+			<-release
+			return nil
+		})
+		done <- err
+	}()
+	<-started
+
+	// The processing marker is held for the lease, not the dedup TTL
+	if marker, ttl := store.marker("test:msg-1"); marker != markerProcessing || ttl != 30*time.Second {
+		t.Errorf("marker = %q for %s, want %q for the 30s lease", marker, ttl, markerProcessing)
+	}
+
+	processed, err := once.ProcessOnce(ctx, "msg-1", time.Hour, func() error {
+		t.Error("fn ran for a message that is still being processed")
+		return nil
+	})
+	if processed || !errors.Is(err, ErrInProgress) {
+		t.Errorf("concurrent delivery: processed=%v err=%v, want ErrInProgress", processed, err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("first delivery failed: %v", err)
+	}
+}
+
+func TestProcessOnceStoreErrors(t *testing.T) {
+	errStore := errors.New("redis unavailable")
+	ctx := context.Background()
+
+	t.Run("acquire", func(t *testing.T) {
+		store := newOnceStore()
+		store.failNX = errStore
+		once := NewOnce(store, "test:", 0)
+
+		processed, err := once.ProcessOnce(ctx, "msg-1", time.Hour, func() error {
+			t.Error("fn ran without a marker")
+			return nil
+		})
+		if processed || !errors.Is(err, errStore) {
+			t.Errorf("processed=%v err=%v, want the store error before fn runs", processed, err)
+		}
+	})
+
+	t.Run("mark done", func(t *testing.T) {
+		store := newOnceStore()
+		store.failSet = errStore
+		once := NewOnce(store, "test:", 0)
+
+		// fn succeeded, so the caller acks despite the error
+		processed, err := once.ProcessOnce(ctx, "msg-1", time.Hour, func() error { return nil })
+		if !processed || !errors.Is(err, errStore) {
+			t.Errorf("processed=%v err=%v, want processed with the store error", processed, err)
+		}
+	})
+}
+
+func TestNewOnceDefaultLease(t *testing.T) {
+	if once := NewOnce(newOnceStore(), "test:", 0); once.lease != DefaultProcessingLease {
+		t.Errorf("lease = %s, want %s", once.lease, DefaultProcessingLease)
+	}
+}
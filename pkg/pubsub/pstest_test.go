@@ -0,0 +1,33 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const testProject = "coldy-test"
+
+// newTestClient returns a client of an in-memory Pub/Sub server
+func newTestClient(t *testing.T) *pubsub.Client {
+	t.Helper()
+
+	srv := pstest.NewServer()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	conn, err := grpc.NewClient(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial fake pubsub: %v", err)
+	}
+	client, err := pubsub.NewClient(context.Background(), testProject, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("failed to create pubsub client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
@@ -7,6 +7,8 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Publisher wraps Google Cloud Pub/Sub publisher
@@ -14,6 +16,9 @@ type Publisher struct {
 	client *pubsub.Client
 	topics map[string]*pubsub.Topic
 	mu     sync.RWMutex
+	// inits holds a *sync.Mutex per topic name, serializing the lookup of
+	// one topic without holding mu across its network round trips
+	inits  sync.Map
 	logger *zap.Logger
 }
 
@@ -31,6 +36,21 @@ func NewPublisher(ctx context.Context, projectID string, logger *zap.Logger) (*P
 	}, nil
 }
 
+// RefreshTopic drops the cached handle for a topic, flushing its pending
+// messages, so the next publish looks the topic up again. Use it when the
+// topic was deleted or recreated externally.
+func (p *Publisher) RefreshTopic(topicName string) {
+	p.mu.Lock()
+	topic, exists := p.topics[topicName]
+	delete(p.topics, topicName)
+	p.mu.Unlock()
+
+	if exists {
+		// Stop blocks until pending messages are sent; don't hold the lock
+		topic.Stop()
+	}
+}
+
 // GetTopic returns or creates a topic
 func (p *Publisher) GetTopic(ctx context.Context, topicName string) (*pubsub.Topic, error) {
 	p.mu.RLock()
@@ -41,11 +61,17 @@ func (p *Publisher) GetTopic(ctx context.Context, topicName string) (*pubsub.Top
 		return topic, nil
 	}
 
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	// Concurrent first publishes to the same topic wait for one lookup;
+	// those to other topics go ahead in parallel
+	lock := p.topicLock(topicName)
+	lock.Lock()
+	defer lock.Unlock()
 
-	// Double-check after acquiring write lock
-	if topic, exists := p.topics[topicName]; exists {
+	// Double-check after acquiring the topic lock
+	p.mu.RLock()
+	topic, exists = p.topics[topicName]
+	p.mu.RUnlock()
+	if exists {
 		return topic, nil
 	}
 
@@ -65,10 +91,19 @@ func (p *Publisher) GetTopic(ctx context.Context, topicName string) (*pubsub.Top
 		p.logger.Info("created topic", zap.String("topic", topicName))
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.topics[topicName] = topic
 	return topic, nil
 }
 
+// topicLock returns the lock serializing lookups of a topic
+func (p *Publisher) topicLock(topicName string) *sync.Mutex {
+	lock, _ := p.inits.LoadOrStore(topicName, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
 // Publish publishes a message to a topic
 func (p *Publisher) Publish(ctx context.Context, topicName string, data []byte, attrs map[string]string) (string, error) {
 	topic, err := p.GetTopic(ctx, topicName)
@@ -83,6 +118,11 @@ func (p *Publisher) Publish(ctx context.Context, topicName string, data []byte,
 
 	messageID, err := result.Get(ctx)
 	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			// The topic was deleted behind our back; look it up again next time
+			p.logger.Warn("topic not found, dropping cached handle", zap.String("topic", topicName))
+			p.RefreshTopic(topicName)
+		}
 		return "", fmt.Errorf("failed to publish message: %w", err)
 	}
 
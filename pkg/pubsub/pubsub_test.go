@@ -0,0 +1,116 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"go.uber.org/zap"
+)
+
+// newTestPublisher returns a Publisher over an in-memory Pub/Sub server. The
+// client is closed by newTestClient, so tests don't call Close.
+func newTestPublisher(t *testing.T) *Publisher {
+	t.Helper()
+	p := &Publisher{
+		client: newTestClient(t),
+		topics: make(map[string]*pubsub.Topic),
+		logger: zap.NewNop(),
+	}
+	t.Cleanup(func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, topic := range p.topics {
+			topic.Stop()
+		}
+	})
+	return p
+}
+
+func TestGetTopicConcurrentFirstLookup(t *testing.T) {
+	ctx := context.Background()
+	p := newTestPublisher(t)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	topics := make([]*pubsub.Topic, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			topics[i], errs[i] = p.GetTopic(ctx, "order.created")
+		}()
+	}
+	wg.Wait()
+
+	// One caller creates the topic; the rest wait for it instead of racing
+	// to create it and failing with AlreadyExists
+	for i := range topics {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: GetTopic failed: %v", i, errs[i])
+		}
+		if topics[i] != topics[0] {
+			t.Errorf("caller %d got a different topic handle", i)
+		}
+	}
+}
+
+func TestGetTopicLocksPerTopic(t *testing.T) {
+	ctx := context.Background()
+	p := newTestPublisher(t)
+
+	// Hold the lookup lock of one topic, as a slow lookup would
+	lock := p.topicLock("order.created")
+	lock.Lock()
+
+	blocked := make(chan error, 1)
+	go func() {
+		_, err := p.GetTopic(ctx, "order.created")
+		blocked <- err
+	}()
+
+	lookupCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := p.GetTopic(lookupCtx, "payment.succeeded"); err != nil {
+		t.Fatalf("lookup of another topic failed while one was locked: %v", err)
+	}
+
+	select {
+	case err := <-blocked:
+		t.Fatalf("lookup of the locked topic finished early: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	lock.Unlock()
+	if err := <-blocked; err != nil {
+		t.Errorf("lookup of the unlocked topic failed: %v", err)
+	}
+}
+
+func TestPublishAndRefreshTopic(t *testing.T) {
+	ctx := context.Background()
+	p := newTestPublisher(t)
+
+	if _, err := p.Publish(ctx, "order.created", []byte(`{}`), map[string]string{"event_id": "evt-1"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	first, err := p.GetTopic(ctx, "order.created")
+	if err != nil {
+		t.Fatalf("GetTopic failed: %v", err)
+	}
+
+	p.RefreshTopic("order.created")
+	second, err := p.GetTopic(ctx, "order.created")
+	if err != nil {
+		t.Fatalf("GetTopic after refresh failed: %v", err)
+	}
+	if first == second {
+		t.Error("RefreshTopic kept the cached handle")
+	}
+	if _, err := p.Publish(ctx, "order.created", []byte(`{}`), nil); err != nil {
+		t.Errorf("Publish after refresh failed: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Subscription is a handle to a subscription started with StartSubscription.
+// It can be stopped and resumed independently of other subscriptions.
+type Subscription struct {
+	subscriber *Subscriber
+	name       string
+	handler    MessageHandler
+	parent     context.Context
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+	running bool
+	err     error
+}
+
+// StartSubscription starts receiving from a subscription in the background and
+// returns immediately. The subscription runs until Stop is called or ctx is canceled.
+func (s *Subscriber) StartSubscription(ctx context.Context, subscriptionName string, handler MessageHandler) *Subscription {
+	sub := &Subscription{
+		subscriber: s,
+		name:       subscriptionName,
+		handler:    handler,
+		parent:     ctx,
+	}
+	sub.Resume()
+	return sub
+}
+
+// Name returns the subscription name
+func (sub *Subscription) Name() string {
+	return sub.name
+}
+
+// Resume starts receiving again after Stop; it is a no-op while running
+func (sub *Subscription) Resume() {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(sub.parent)
+	done := make(chan struct{})
+	sub.cancel = cancel
+	sub.done = done
+	sub.running = true
+	sub.err = nil
+
+	go func() {
+		defer close(done)
+		defer cancel()
+
+		err := sub.subscriber.Subscribe(ctx, sub.name, sub.handler)
+		if err != nil {
+			sub.subscriber.logger.Error("subscription stopped with error",
+				zap.String("subscription", sub.name),
+				zap.Error(err),
+			)
+		}
+
+		sub.mu.Lock()
+		// A newer run may have started after Stop; only clear our own state
+		if sub.done == done {
+			sub.running = false
+			sub.err = err
+		}
+		sub.mu.Unlock()
+	}()
+}
+
+// Stop stops receiving and waits for in-flight messages to finish
+func (sub *Subscription) Stop() {
+	sub.mu.Lock()
+	cancel, done := sub.cancel, sub.done
+	sub.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+
+	sub.subscriber.logger.Info("subscription stopped", zap.String("subscription", sub.name))
+}
+
+// Running reports whether the subscription is receiving messages
+func (sub *Subscription) Running() bool {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.running
+}
+
+// Err returns the error the last run ended with, if any
+func (sub *Subscription) Err() error {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.err
+}
@@ -0,0 +1,135 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"go.uber.org/zap"
+)
+
+// newTestSubscription creates a topic and a subscription to it, returning
+// the topic to publish to
+func newTestSubscription(t *testing.T, client *pubsub.Client, topicName, subName string) *pubsub.Topic {
+	t.Helper()
+	ctx := context.Background()
+
+	topic, err := client.CreateTopic(ctx, topicName)
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	t.Cleanup(topic.Stop)
+	if _, err := client.CreateSubscription(ctx, subName, pubsub.SubscriptionConfig{Topic: topic}); err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	return topic
+}
+
+func publishTestMessage(t *testing.T, topic *pubsub.Topic, data string) {
+	t.Helper()
+	if _, err := topic.Publish(context.Background(), &pubsub.Message{Data: []byte(data)}).Get(context.Background()); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+}
+
+// receiver forwards the data of handled messages to a channel
+func receiver() (MessageHandler, <-chan string) {
+	received := make(chan string, 10)
+	return func(_ context.Context, msg *pubsub.Message) error {
+		received <- string(msg.Data)
+		return nil
+	}, received
+}
+
+func expectMessage(t *testing.T, received <-chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-received:
+		if got != want {
+			t.Errorf("received %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %q", want)
+	}
+}
+
+func TestStopOneSubscription(t *testing.T) {
+	client := newTestClient(t)
+	orders := newTestSubscription(t, client, "order.created", "order-created-sub")
+	payments := newTestSubscription(t, client, "payment.succeeded", "payment-succeeded-sub")
+	subscriber := &Subscriber{client: client, logger: zap.NewNop()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orderHandler, orderMessages := receiver()
+	paymentHandler, paymentMessages := receiver()
+	orderSub := subscriber.StartSubscription(ctx, "order-created-sub", orderHandler)
+	paymentSub := subscriber.StartSubscription(ctx, "payment-succeeded-sub", paymentHandler)
+	defer paymentSub.Stop()
+
+	publishTestMessage(t, orders, "order-1")
+	publishTestMessage(t, payments, "payment-1")
+	expectMessage(t, orderMessages, "order-1")
+	expectMessage(t, paymentMessages, "payment-1")
+
+	orderSub.Stop()
+	if orderSub.Running() {
+		t.Error("stopped subscription reports running")
+	}
+	if !paymentSub.Running() {
+		t.Error("the other subscription stopped too")
+	}
+
+	publishTestMessage(t, orders, "order-2")
+	publishTestMessage(t, payments, "payment-2")
+	expectMessage(t, paymentMessages, "payment-2")
+
+	select {
+	case got := <-orderMessages:
+		t.Errorf("stopped subscription received %q", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// Messages published while stopped are delivered once resumed
+	orderSub.Resume()
+	defer orderSub.Stop()
+	if !orderSub.Running() {
+		t.Error("resumed subscription reports not running")
+	}
+	expectMessage(t, orderMessages, "order-2")
+}
+
+func TestSubscriptionStopsWithParentContext(t *testing.T) {
+	client := newTestClient(t)
+	newTestSubscription(t, client, "order.created", "order-created-sub")
+	subscriber := &Subscriber{client: client, logger: zap.NewNop()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	handler, _ := receiver()
+	sub := subscriber.StartSubscription(ctx, "order-created-sub", handler)
+
+	cancel()
+	sub.Stop()
+	if sub.Running() {
+		t.Error("subscription reports running after its context was canceled")
+	}
+}
+
+func TestSubscriptionReportsMissingSubscription(t *testing.T) {
+	subscriber := &Subscriber{client: newTestClient(t), logger: zap.NewNop()}
+	handler, _ := receiver()
+	sub := subscriber.StartSubscription(context.Background(), "missing-sub", handler)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for sub.Running() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if sub.Running() {
+		t.Fatal("subscription to a missing subscription kept running")
+	}
+	if sub.Err() == nil {
+		t.Error("expected Err to report the missing subscription")
+	}
+}
@@ -0,0 +1,62 @@
+package pubsub
+
+// TopicResolver maps an event type to the topic it is published to
+type TopicResolver interface {
+	Topic(eventType string) string
+}
+
+// IdentityResolver publishes each event type to a topic of the same name
+type IdentityResolver struct{}
+
+// Topic returns eventType unchanged
+func (IdentityResolver) Topic(eventType string) string {
+	return eventType
+}
+
+// PrefixResolver prepends a fixed prefix (e.g. "staging-") to the topic
+// chosen by the wrapped resolver
+type PrefixResolver struct {
+	prefix string
+	next   TopicResolver
+}
+
+// NewPrefixResolver creates a new prefix resolver; a nil next resolves by identity
+func NewPrefixResolver(prefix string, next TopicResolver) *PrefixResolver {
+	if next == nil {
+		next = IdentityResolver{}
+	}
+	return &PrefixResolver{prefix: prefix, next: next}
+}
+
+// Topic returns the prefixed topic for eventType
+func (r *PrefixResolver) Topic(eventType string) string {
+	return r.prefix + r.next.Topic(eventType)
+}
+
+// MapResolver routes event types through an explicit table, so several event
+// types can share one topic. Unmapped event types fall back to the wrapped
+// resolver.
+type MapResolver struct {
+	topics   map[string]string
+	fallback TopicResolver
+}
+
+// NewMapResolver creates a new map resolver; a nil fallback resolves by identity
+func NewMapResolver(topics map[string]string, fallback TopicResolver) *MapResolver {
+	if fallback == nil {
+		fallback = IdentityResolver{}
+	}
+	copied := make(map[string]string, len(topics))
+	for eventType, topic := range topics {
+		copied[eventType] = topic
+	}
+	return &MapResolver{topics: copied, fallback: fallback}
+}
+
+// Topic returns the mapped topic for eventType
+func (r *MapResolver) Topic(eventType string) string {
+	if topic, ok := r.topics[eventType]; ok {
+		return topic
+	}
+	return r.fallback.Topic(eventType)
+}
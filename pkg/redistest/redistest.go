@@ -0,0 +1,523 @@
+// Package redistest runs an in-memory Redis server for tests. It speaks
+// enough RESP2 for the commands this repository issues: strings with
+// expiry, DEL, EXISTS, MGET, INCR, SCAN, TTL/EXPIRE and channel
+// PUBLISH/SUBSCRIBE. Expired keys are dropped lazily against the real clock.
+package redistest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type entry struct {
+	value    string
+	expireAt time.Time
+}
+
+// Server is an in-memory Redis server
+type Server struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	data        map[string]entry
+	subscribers map[string]map[*conn]struct{}
+}
+
+// conn is a client connection; mu serializes replies with messages
+// published to it
+type conn struct {
+	mu       sync.Mutex
+	w        *bufio.Writer
+	channels map[string]struct{}
+}
+
+// NewServer starts a server on a loopback port; it is closed when t ends
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &Server{
+		listener:    listener,
+		data:        make(map[string]entry),
+		subscribers: make(map[string]map[*conn]struct{}),
+	}
+	go s.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+	return s
+}
+
+// Addr returns the address clients connect to
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// NewClient returns a client for the server; it is closed when t ends
+func (s *Server) NewClient(t testing.TB) *redis.Client {
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// Set stores value under key with ttl; zero keeps it forever
+func (s *Server) Set(key, value string, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(key, value, ttl)
+}
+
+// Get returns the value under key, reporting whether it exists
+func (s *Server) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.lookup(key)
+	return e.value, ok
+}
+
+// TTL returns the remaining time to live of key, zero when it has none
+func (s *Server) TTL(key string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.lookup(key)
+	if !ok || e.expireAt.IsZero() {
+		return 0
+	}
+	return time.Until(e.expireAt)
+}
+
+// Keys returns the live keys, sorted
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.match("*")
+}
+
+// Subscribers returns how many connections are subscribed to channel
+func (s *Server) Subscribers(channel string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers[channel])
+}
+
+func (s *Server) set(key, value string, ttl time.Duration) {
+	e := entry{value: value}
+	if ttl > 0 {
+		e.expireAt = time.Now().Add(ttl)
+	}
+	s.data[key] = e
+}
+
+// lookup returns the entry under key, dropping it if it expired
+func (s *Server) lookup(key string) (entry, bool) {
+	e, ok := s.data[key]
+	if ok && !e.expireAt.IsZero() && !time.Now().Before(e.expireAt) {
+		delete(s.data, key)
+		return entry{}, false
+	}
+	return e, ok
+}
+
+func (s *Server) match(pattern string) []string {
+	var keys []string
+	for key := range s.data {
+		if _, ok := s.lookup(key); !ok {
+			continue
+		}
+		if ok, _ := path.Match(pattern, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(netConn net.Conn) {
+	defer func() { _ = netConn.Close() }()
+
+	r := bufio.NewReader(netConn)
+	c := &conn{w: bufio.NewWriter(netConn), channels: make(map[string]struct{})}
+	defer s.unsubscribe(c, nil)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		replies := s.execConn(c, args)
+		s.mu.Unlock()
+
+		c.mu.Lock()
+		for _, reply := range replies {
+			writeReply(c.w, reply)
+		}
+		// Flush once the pipelined commands already read are answered
+		if r.Buffered() == 0 {
+			err = c.w.Flush()
+		}
+		c.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// execConn runs a command that may concern the connection's subscriptions.
+// A subscription command replies once per channel.
+func (s *Server) execConn(c *conn, args []string) []interface{} {
+	if len(args) == 0 {
+		return []interface{}{s.exec(args)}
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "SUBSCRIBE":
+		if len(args) < 2 {
+			return []interface{}{wrongArgs("subscribe")}
+		}
+		replies := make([]interface{}, 0, len(args)-1)
+		for _, channel := range args[1:] {
+			c.channels[channel] = struct{}{}
+			if s.subscribers[channel] == nil {
+				s.subscribers[channel] = make(map[*conn]struct{})
+			}
+			s.subscribers[channel][c] = struct{}{}
+			replies = append(replies, []interface{}{"subscribe", channel, int64(len(c.channels))})
+		}
+		return replies
+	case "UNSUBSCRIBE":
+		return s.unsubscribeLocked(c, args[1:])
+	case "PUBLISH":
+		if len(args) != 3 {
+			return []interface{}{wrongArgs("publish")}
+		}
+		return []interface{}{s.publish(args[1], args[2])}
+	case "PING":
+		if len(c.channels) > 0 {
+			return []interface{}{[]interface{}{"pong", ""}}
+		}
+	}
+	return []interface{}{s.exec(args)}
+}
+
+// publish delivers message to the subscribers of channel and returns how
+// many received it
+func (s *Server) publish(channel, message string) int64 {
+	var n int64
+	for c := range s.subscribers[channel] {
+		c.mu.Lock()
+		writeReply(c.w, []interface{}{"message", channel, message})
+		err := c.w.Flush()
+		c.mu.Unlock()
+		if err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Server) unsubscribe(c *conn, channels []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unsubscribeLocked(c, channels)
+}
+
+// unsubscribeLocked drops the connection's subscriptions to channels, or to
+// every channel when none are given
+func (s *Server) unsubscribeLocked(c *conn, channels []string) []interface{} {
+	if len(channels) == 0 {
+		for channel := range c.channels {
+			channels = append(channels, channel)
+		}
+		sort.Strings(channels)
+		if len(channels) == 0 {
+			return []interface{}{[]interface{}{"unsubscribe", nil, int64(0)}}
+		}
+	}
+
+	replies := make([]interface{}, 0, len(channels))
+	for _, channel := range channels {
+		delete(c.channels, channel)
+		delete(s.subscribers[channel], c)
+		if len(s.subscribers[channel]) == 0 {
+			delete(s.subscribers, channel)
+		}
+		replies = append(replies, []interface{}{"unsubscribe", channel, int64(len(c.channels))})
+	}
+	return replies
+}
+
+// replyError is a RESP error reply
+type replyError string
+
+// status is a RESP simple string reply
+type status string
+
+func (s *Server) exec(args []string) interface{} {
+	if len(args) == 0 {
+		return replyError("ERR empty command")
+	}
+	cmd, args := strings.ToUpper(args[0]), args[1:]
+
+	switch cmd {
+	case "PING":
+		return status("PONG")
+	case "CLIENT", "SELECT":
+		return status("OK")
+	case "GET":
+		if len(args) != 1 {
+			return wrongArgs(cmd)
+		}
+		if e, ok := s.lookup(args[0]); ok {
+			return e.value
+		}
+		return nil
+	case "MGET":
+		values := make([]interface{}, len(args))
+		for i, key := range args {
+			if e, ok := s.lookup(key); ok {
+				values[i] = e.value
+			}
+		}
+		return values
+	case "SET":
+		return s.execSet(args)
+	case "DEL":
+		var n int64
+		for _, key := range args {
+			if _, ok := s.lookup(key); ok {
+				delete(s.data, key)
+				n++
+			}
+		}
+		return n
+	case "EXISTS":
+		var n int64
+		for _, key := range args {
+			if _, ok := s.lookup(key); ok {
+				n++
+			}
+		}
+		return n
+	case "INCR":
+		if len(args) != 1 {
+			return wrongArgs(cmd)
+		}
+		e, _ := s.lookup(args[0])
+		n := int64(0)
+		if e.value != "" {
+			var err error
+			if n, err = strconv.ParseInt(e.value, 10, 64); err != nil {
+				return replyError("ERR value is not an integer or out of range")
+			}
+		}
+		e.value = strconv.FormatInt(n+1, 10)
+		s.data[args[0]] = e
+		return n + 1
+	case "EXPIRE", "PEXPIRE":
+		if len(args) < 2 {
+			return wrongArgs(cmd)
+		}
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return replyError("ERR value is not an integer or out of range")
+		}
+		e, ok := s.lookup(args[0])
+		if !ok {
+			return int64(0)
+		}
+		unit := time.Second
+		if cmd == "PEXPIRE" {
+			unit = time.Millisecond
+		}
+		e.expireAt = time.Now().Add(time.Duration(n) * unit)
+		s.data[args[0]] = e
+		return int64(1)
+	case "TTL", "PTTL":
+		if len(args) != 1 {
+			return wrongArgs(cmd)
+		}
+		e, ok := s.lookup(args[0])
+		switch {
+		case !ok:
+			return int64(-2)
+		case e.expireAt.IsZero():
+			return int64(-1)
+		case cmd == "PTTL":
+			return time.Until(e.expireAt).Milliseconds()
+		default:
+			return int64(time.Until(e.expireAt).Round(time.Second) / time.Second)
+		}
+	case "SCAN":
+		return s.execScan(args)
+	default:
+		return replyError(fmt.Sprintf("ERR unknown command '%s'", cmd))
+	}
+}
+
+// execSet handles SET key value [EX seconds|PX milliseconds] [NX]
+func (s *Server) execSet(args []string) interface{} {
+	if len(args) < 2 {
+		return wrongArgs("SET")
+	}
+	key, value := args[0], args[1]
+
+	var ttl time.Duration
+	var nx bool
+	for i := 2; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			nx = true
+		case "EX", "PX":
+			if i+1 >= len(args) {
+				return replyError("ERR syntax error")
+			}
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return replyError("ERR invalid expire time in 'set' command")
+			}
+			unit := time.Second
+			if strings.EqualFold(args[i], "PX") {
+				unit = time.Millisecond
+			}
+			ttl = time.Duration(n) * unit
+			i++
+		default:
+			return replyError("ERR syntax error")
+		}
+	}
+
+	if _, ok := s.lookup(key); ok && nx {
+		return nil
+	}
+	s.set(key, value, ttl)
+	return status("OK")
+}
+
+// execScan handles SCAN cursor [MATCH pattern] [COUNT count]. The cursor
+// is an offset into the sorted matching keys.
+func (s *Server) execScan(args []string) interface{} {
+	if len(args) < 1 {
+		return wrongArgs("SCAN")
+	}
+	cursor, err := strconv.Atoi(args[0])
+	if err != nil {
+		return replyError("ERR invalid cursor")
+	}
+	pattern, count := "*", 10
+	for i := 1; i+1 < len(args); i += 2 {
+		switch strings.ToUpper(args[i]) {
+		case "MATCH":
+			pattern = args[i+1]
+		case "COUNT":
+			if count, err = strconv.Atoi(args[i+1]); err != nil || count <= 0 {
+				return replyError("ERR syntax error")
+			}
+		}
+	}
+
+	keys := s.match(pattern)
+	if cursor > len(keys) {
+		cursor = len(keys)
+	}
+	end := cursor + count
+	next := end
+	if end >= len(keys) {
+		end, next = len(keys), 0
+	}
+	page := make([]interface{}, 0, end-cursor)
+	for _, key := range keys[cursor:end] {
+		page = append(page, key)
+	}
+	return []interface{}{strconv.Itoa(next), page}
+}
+
+func wrongArgs(cmd string) replyError {
+	return replyError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(cmd)))
+}
+
+// readCommand reads a RESP array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		return nil, errors.New("expected a command array")
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, errors.New("expected a bulk string")
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
+
+func writeReply(w *bufio.Writer, reply interface{}) {
+	switch v := reply.(type) {
+	case nil:
+		_, _ = w.WriteString("$-1\r\n")
+	case status:
+		_, _ = fmt.Fprintf(w, "+%s\r\n", v)
+	case replyError:
+		_, _ = fmt.Fprintf(w, "-%s\r\n", v)
+	case int64:
+		_, _ = fmt.Fprintf(w, ":%d\r\n", v)
+	case string:
+		_, _ = fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+	case []interface{}:
+		_, _ = fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	}
+}
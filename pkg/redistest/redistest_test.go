@@ -0,0 +1,118 @@
+package redistest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestServerCommands(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(t)
+	client := s.NewClient(t)
+
+	if err := client.Set(ctx, "a", "1", time.Minute).Err(); err != nil {
+		t.Fatalf("SET failed: %v", err)
+	}
+	if got, err := client.Get(ctx, "a").Result(); err != nil || got != "1" {
+		t.Errorf("GET = %q, %v; want 1", got, err)
+	}
+	if _, err := client.Get(ctx, "missing").Result(); !errors.Is(err, redis.Nil) {
+		t.Errorf("GET of a missing key: expected redis.Nil, got %v", err)
+	}
+	if ok, err := client.SetNX(ctx, "a", "2", time.Minute).Result(); err != nil || ok {
+		t.Errorf("SETNX on an existing key = %v, %v; want false", ok, err)
+	}
+	if ttl := client.TTL(ctx, "a").Val(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("TTL = %v, want about a minute", ttl)
+	}
+	if n, err := client.Incr(ctx, "counter").Result(); err != nil || n != 1 {
+		t.Errorf("INCR = %d, %v; want 1", n, err)
+	}
+	if n := client.Del(ctx, "a", "missing").Val(); n != 1 {
+		t.Errorf("DEL removed %d keys, want 1", n)
+	}
+}
+
+func TestServerScan(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(t)
+	client := s.NewClient(t)
+	for i := 0; i < 25; i++ {
+		s.Set(fmt.Sprintf("item:%02d", i), "x", 0)
+	}
+	s.Set("other", "x", 0)
+
+	var found []string
+	var cursor uint64
+	for {
+		keys, next, err := client.Scan(ctx, cursor, "item:*", 10).Result()
+		if err != nil {
+			t.Fatalf("SCAN failed: %v", err)
+		}
+		found = append(found, keys...)
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	if len(found) != 25 {
+		t.Errorf("SCAN found %d keys, want 25", len(found))
+	}
+}
+
+func TestServerExpiry(t *testing.T) {
+	s := NewServer(t)
+	s.Set("short", "x", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := s.Get("short"); ok {
+		t.Error("expired key still present")
+	}
+}
+
+func TestServerPubSub(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(t)
+	client := s.NewClient(t)
+
+	sub := client.Subscribe(ctx, "news")
+	defer func() { _ = sub.Close() }()
+	if _, err := sub.Receive(ctx); err != nil {
+		t.Fatalf("SUBSCRIBE failed: %v", err)
+	}
+	if n := s.Subscribers("news"); n != 1 {
+		t.Fatalf("news has %d subscribers, want 1", n)
+	}
+
+	if n, err := client.Publish(ctx, "news", "hello").Result(); err != nil || n != 1 {
+		t.Fatalf("PUBLISH = %d, %v; want 1", n, err)
+	}
+	if n := client.Publish(ctx, "other", "ignored").Val(); n != 0 {
+		t.Errorf("PUBLISH to an unwatched channel reached %d subscribers, want 0", n)
+	}
+
+	select {
+	case msg := <-sub.Channel():
+		if msg.Channel != "news" || msg.Payload != "hello" {
+			t.Errorf("received %s on %s, want hello on news", msg.Payload, msg.Channel)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+	}
+
+	if err := sub.Unsubscribe(ctx, "news"); err != nil {
+		t.Fatalf("UNSUBSCRIBE failed: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for s.Subscribers("news") != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription still present after UNSUBSCRIBE")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,130 @@
+package retry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// Policy configures retry behavior
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Retryable reports whether an error should be retried. Nil retries every error.
+	Retryable func(err error) bool
+}
+
+// DefaultPolicy returns a policy suitable for short transient failures
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+	}
+}
+
+// permanentError marks an error as non-retryable regardless of policy
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error so that Do stops retrying immediately
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether the error was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// Do runs fn until it succeeds, returns a non-retryable error, or attempts are exhausted
+func Do(ctx context.Context, policy Policy, fn func() error) error {
+	_, err := DoWithResult(ctx, policy, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// DoWithResult runs fn with retries and returns its result
+func DoWithResult[T any](ctx context.Context, policy Policy, fn func() (T, error)) (T, error) {
+	var zero T
+
+	attempts := policy.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		var pe *permanentError
+		if errors.As(err, &pe) {
+			return zero, pe.err
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return zero, err
+		}
+		if attempt == attempts-1 {
+			break
+		}
+
+		// Wait before next attempt, honoring cancellation
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return zero, lastErr
+}
+
+// Backoff returns the delay before the given (zero-based) retry using
+// exponential backoff with full jitter: a random duration in [0, min(MaxDelay, BaseDelay*2^attempt)]
+func (p Policy) Backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	ceiling := p.BaseDelay
+	for i := 0; i < attempt; i++ {
+		ceiling *= 2
+		if p.MaxDelay > 0 && ceiling >= p.MaxDelay {
+			ceiling = p.MaxDelay
+			break
+		}
+	}
+	if p.MaxDelay > 0 && ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+
+	return time.Duration(randomInt63n(int64(ceiling) + 1))
+}
+
+func randomInt63n(n int64) int64 {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return int64(binary.LittleEndian.Uint64(b[:])>>1) % n
+}
@@ -0,0 +1,198 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+func TestDoAttempts(t *testing.T) {
+	errFatal := errors.New("fatal")
+
+	tests := map[string]struct {
+		policy Policy
+		// failures is how many calls fail before one succeeds
+		failures  int
+		err       error
+		wantCalls int
+		wantErr   error
+	}{
+		"succeeds first time": {
+			policy:    Policy{MaxAttempts: 3},
+			wantCalls: 1,
+		},
+		"succeeds on last attempt": {
+			policy:    Policy{MaxAttempts: 3},
+			failures:  2,
+			err:       errTransient,
+			wantCalls: 3,
+		},
+		"stops at attempt limit": {
+			policy:    Policy{MaxAttempts: 3},
+			failures:  5,
+			err:       errTransient,
+			wantCalls: 3,
+			wantErr:   errTransient,
+		},
+		"zero attempts runs once": {
+			policy:    Policy{},
+			failures:  5,
+			err:       errTransient,
+			wantCalls: 1,
+			wantErr:   errTransient,
+		},
+		"permanent error stops retrying": {
+			policy:    Policy{MaxAttempts: 3},
+			failures:  5,
+			err:       Permanent(errFatal),
+			wantCalls: 1,
+			wantErr:   errFatal,
+		},
+		"non-retryable error stops retrying": {
+			policy: Policy{
+				MaxAttempts: 3,
+				Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+			},
+			failures:  5,
+			err:       errFatal,
+			wantCalls: 1,
+			wantErr:   errFatal,
+		},
+		"retryable error is retried": {
+			policy: Policy{
+				MaxAttempts: 3,
+				Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+			},
+			failures:  1,
+			err:       errTransient,
+			wantCalls: 2,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			err := Do(context.Background(), tt.policy, func() error {
+				calls++
+				if calls <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if IsPermanent(err) {
+				t.Error("Do returned the Permanent wrapper instead of the error inside it")
+			}
+		})
+	}
+}
+
+func TestDoWithResultReturnsResult(t *testing.T) {
+	calls := 0
+	got, err := DoWithResult(context.Background(), Policy{MaxAttempts: 2}, func() (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errTransient
+		}
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatalf("DoWithResult failed: %v", err)
+	}
+	if got != "done" {
+		t.Errorf("result = %q, want done", got)
+	}
+}
+
+func TestDoContextCancellation(t *testing.T) {
+	tests := map[string]struct {
+		// cancelAfter cancels the context after that many calls; zero cancels
+		// it before Do starts
+		cancelAfter int
+		wantCalls   int
+	}{
+		"canceled before the first attempt": {cancelAfter: 0, wantCalls: 0},
+		"canceled during the backoff":       {cancelAfter: 1, wantCalls: 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelAfter == 0 {
+				cancel()
+			}
+
+			// A long backoff that only cancellation can cut short
+			policy := Policy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+			calls := 0
+			start := time.Now()
+			err := Do(ctx, policy, func() error {
+				calls++
+				if calls == tt.cancelAfter {
+					cancel()
+				}
+				return errTransient
+			})
+
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("error = %v, want context.Canceled", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Do took %s to notice the cancellation", elapsed)
+			}
+		})
+	}
+}
+
+func TestBackoffJitterBounds(t *testing.T) {
+	tests := map[string]struct {
+		policy  Policy
+		attempt int
+		ceiling time.Duration
+	}{
+		"first retry":       {Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}, 0, 100 * time.Millisecond},
+		"doubles":           {Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}, 2, 400 * time.Millisecond},
+		"capped at max":     {Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}, 10, 2 * time.Second},
+		"base above max":    {Policy{BaseDelay: 5 * time.Second, MaxDelay: 2 * time.Second}, 0, 2 * time.Second},
+		"no max":            {Policy{BaseDelay: time.Millisecond}, 4, 16 * time.Millisecond},
+		"no base, no delay": {Policy{MaxDelay: time.Second}, 3, 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				if d := tt.policy.Backoff(tt.attempt); d < 0 || d > tt.ceiling {
+					t.Fatalf("Backoff(%d) = %s, want within [0, %s]", tt.attempt, d, tt.ceiling)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoffIsJittered(t *testing.T) {
+	policy := Policy{BaseDelay: time.Second, MaxDelay: time.Second}
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		seen[policy.Backoff(0)] = true
+	}
+	if len(seen) < 2 {
+		t.Error("Backoff returned the same delay every time, want random jitter")
+	}
+}
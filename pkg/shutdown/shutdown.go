@@ -0,0 +1,146 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultDrainDelay gives load balancers time to stop routing to an
+	// instance that reports NOT_SERVING
+	DefaultDrainDelay = 5 * time.Second
+	// DefaultTimeout bounds a whole shutdown; it leaves room for the drain
+	// delay and the gRPC server's own shutdown timeout
+	DefaultTimeout = 30 * time.Second
+)
+
+// Config configures a graceful shutdown
+type Config struct {
+	// DrainDelay is waited between the pre-stop hooks and the shutdown hooks
+	DrainDelay time.Duration
+	// Timeout bounds the whole shutdown; hooks still running when it passes
+	// are abandoned
+	Timeout time.Duration
+}
+
+// DefaultConfig returns the default shutdown settings
+func DefaultConfig() Config {
+	return Config{
+		DrainDelay: DefaultDrainDelay,
+		Timeout:    DefaultTimeout,
+	}
+}
+
+// Hook is a named shutdown step
+type Hook struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+// Manager runs ordered shutdown hooks once the process is asked to stop
+type Manager struct {
+	config  Config
+	logger  *zap.Logger
+	preStop []Hook
+	hooks   []Hook
+}
+
+// New creates a shutdown manager. A zero Timeout uses DefaultTimeout; a zero
+// DrainDelay skips the drain.
+func New(config Config, logger *zap.Logger) *Manager {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	return &Manager{
+		config: config,
+		logger: logger,
+	}
+}
+
+// PreStop registers a hook run before the drain delay, such as flipping
+// health checks to NOT_SERVING
+func (m *Manager) PreStop(name string, fn func(ctx context.Context) error) {
+	m.preStop = append(m.preStop, Hook{Name: name, Fn: fn})
+}
+
+// OnShutdown registers a hook run after the drain delay. Hooks run in
+// registration order.
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.hooks = append(m.hooks, Hook{Name: name, Fn: fn})
+}
+
+// Wait blocks until SIGINT or SIGTERM is received, or ctx is done, then
+// runs the shutdown
+func (m *Manager) Wait(ctx context.Context) error {
+	sigCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	<-sigCtx.Done()
+	stop()
+
+	m.logger.Info("shutting down gracefully...")
+	return m.Shutdown(context.WithoutCancel(ctx))
+}
+
+// Shutdown runs the pre-stop hooks, waits the drain delay and runs the
+// shutdown hooks, all within the timeout. A failing hook doesn't stop later
+// ones; their errors are joined. Once the timeout passes the remaining hooks
+// are skipped and the error includes context.DeadlineExceeded.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+
+	var errs []error
+	for _, hook := range m.preStop {
+		if err := m.run(ctx, hook); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if m.config.DrainDelay > 0 {
+		timer := time.NewTimer(m.config.DrainDelay)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+
+	for _, hook := range m.hooks {
+		if err := m.run(ctx, hook); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// run runs a hook, giving up on it when ctx is done
+func (m *Manager) run(ctx context.Context, hook Hook) error {
+	if err := ctx.Err(); err != nil {
+		m.logger.Warn("shutdown hook skipped", zap.String("hook", hook.Name))
+		return fmt.Errorf("%s: %w", hook.Name, err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- hook.Fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			m.logger.Error("shutdown hook failed", zap.String("hook", hook.Name), zap.Error(err))
+			return fmt.Errorf("%s: %w", hook.Name, err)
+		}
+		return nil
+	case <-ctx.Done():
+		m.logger.Warn("shutdown hook abandoned at deadline", zap.String("hook", hook.Name))
+		return fmt.Errorf("%s: %w", hook.Name, ctx.Err())
+	}
+}
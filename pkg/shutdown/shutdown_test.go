@@ -0,0 +1,154 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// recorder records the order hooks run in
+type recorder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (r *recorder) hook(name string) func(context.Context) error {
+	return func(context.Context) error {
+		r.record(name)
+		return nil
+	}
+}
+
+func (r *recorder) record(step string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+func (r *recorder) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.steps...)
+}
+
+func TestShutdownRunsHooksInOrder(t *testing.T) {
+	rec := &recorder{}
+	m := New(Config{DrainDelay: 20 * time.Millisecond, Timeout: time.Second}, zap.NewNop())
+
+	var drainStart time.Time
+	m.OnShutdown("grpc", rec.hook("grpc"))
+	m.PreStop("health", func(context.Context) error {
+		rec.record("health")
+		drainStart = time.Now()
+		return nil
+	})
+	m.OnShutdown("outbox", rec.hook("outbox"))
+	m.PreStop("readiness", rec.hook("readiness"))
+	var drained time.Duration
+	m.OnShutdown("database", func(context.Context) error {
+		drained = time.Since(drainStart)
+		rec.record("database")
+		return nil
+	})
+
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	want := []string{"health", "readiness", "grpc", "outbox", "database"}
+	if got := rec.recorded(); !reflect.DeepEqual(got, want) {
+		t.Errorf("hooks ran as %v, want %v", got, want)
+	}
+	if drained < 20*time.Millisecond {
+		t.Errorf("shutdown hooks started %s after the pre-stop hooks, want at least the drain delay", drained)
+	}
+}
+
+func TestShutdownRunsLaterHooksAfterFailure(t *testing.T) {
+	rec := &recorder{}
+	m := New(Config{Timeout: time.Second}, zap.NewNop())
+
+	errFlush := errors.New("flush failed")
+	m.OnShutdown("outbox", func(context.Context) error { return errFlush })
+	m.OnShutdown("database", rec.hook("database"))
+
+	err := m.Shutdown(context.Background())
+	if !errors.Is(err, errFlush) {
+		t.Errorf("expected the hook error, got %v", err)
+	}
+	if got := rec.recorded(); !reflect.DeepEqual(got, []string{"database"}) {
+		t.Errorf("hooks after the failure ran as %v, want database", got)
+	}
+}
+
+func TestShutdownDeadlineForcesCompletion(t *testing.T) {
+	rec := &recorder{}
+	m := New(Config{Timeout: 50 * time.Millisecond}, zap.NewNop())
+
+	release := make(chan struct{})
+	defer close(release)
+	m.OnShutdown("grpc", func(context.Context) error {
+		// Ignores its context, like a server stuck on a long stream
+		<-release
+		return nil
+	})
+	m.OnShutdown("database", rec.hook("database"))
+
+	start := time.Now()
+	err := m.Shutdown(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %s, want it bounded by the timeout", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if got := rec.recorded(); len(got) != 0 {
+		t.Errorf("hooks after the deadline ran: %v", got)
+	}
+}
+
+func TestShutdownDeadlineCutsDrainShort(t *testing.T) {
+	rec := &recorder{}
+	m := New(Config{DrainDelay: time.Minute, Timeout: 50 * time.Millisecond}, zap.NewNop())
+	m.PreStop("health", rec.hook("health"))
+	m.OnShutdown("grpc", rec.hook("grpc"))
+
+	start := time.Now()
+	err := m.Shutdown(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %s, want the drain cut short at the timeout", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if got := rec.recorded(); !reflect.DeepEqual(got, []string{"health"}) {
+		t.Errorf("hooks ran as %v, want only the pre-stop hook", got)
+	}
+}
+
+func TestWaitShutsDownWhenContextIsDone(t *testing.T) {
+	rec := &recorder{}
+	m := New(Config{Timeout: time.Second}, zap.NewNop())
+	m.OnShutdown("grpc", func(ctx context.Context) error {
+		// The hooks get a live context even though the parent was canceled
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		rec.record("grpc")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.Wait(ctx); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if got := rec.recorded(); !reflect.DeepEqual(got, []string{"grpc"}) {
+		t.Errorf("hooks ran as %v, want grpc", got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	v1 "github.com/mumumio1/coldy/proto/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -23,6 +24,107 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SearchMode int32
+
+const (
+	SearchMode_SEARCH_MODE_UNSPECIFIED SearchMode = 0 // Full text
+	SearchMode_SEARCH_MODE_FULL_TEXT   SearchMode = 1 // Whole words
+	SearchMode_SEARCH_MODE_PREFIX      SearchMode = 2 // Words as prefixes, "blu" matches "blue"
+	SearchMode_SEARCH_MODE_FUZZY       SearchMode = 3 // Trigram similarity, tolerates typos; prefix matching without pg_trgm
+)
+
+// Enum value maps for SearchMode.
+var (
+	SearchMode_name = map[int32]string{
+		0: "SEARCH_MODE_UNSPECIFIED",
+		1: "SEARCH_MODE_FULL_TEXT",
+		2: "SEARCH_MODE_PREFIX",
+		3: "SEARCH_MODE_FUZZY",
+	}
+	SearchMode_value = map[string]int32{
+		"SEARCH_MODE_UNSPECIFIED": 0,
+		"SEARCH_MODE_FULL_TEXT":   1,
+		"SEARCH_MODE_PREFIX":      2,
+		"SEARCH_MODE_FUZZY":       3,
+	}
+)
+
+func (x SearchMode) Enum() *SearchMode {
+	p := new(SearchMode)
+	*p = x
+	return p
+}
+
+func (x SearchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_v1_catalog_proto_enumTypes[0].Descriptor()
+}
+
+func (SearchMode) Type() protoreflect.EnumType {
+	return &file_proto_catalog_v1_catalog_proto_enumTypes[0]
+}
+
+func (x SearchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchMode.Descriptor instead.
+func (SearchMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+type ReconcileDirection int32
+
+const (
+	ReconcileDirection_RECONCILE_DIRECTION_UNSPECIFIED    ReconcileDirection = 0 // Report only
+	ReconcileDirection_RECONCILE_DIRECTION_FROM_INVENTORY ReconcileDirection = 1 // Set catalog stock to the inventory total
+	ReconcileDirection_RECONCILE_DIRECTION_FROM_CATALOG   ReconcileDirection = 2 // Adjust the inventory total to catalog stock
+)
+
+// Enum value maps for ReconcileDirection.
+var (
+	ReconcileDirection_name = map[int32]string{
+		0: "RECONCILE_DIRECTION_UNSPECIFIED",
+		1: "RECONCILE_DIRECTION_FROM_INVENTORY",
+		2: "RECONCILE_DIRECTION_FROM_CATALOG",
+	}
+	ReconcileDirection_value = map[string]int32{
+		"RECONCILE_DIRECTION_UNSPECIFIED":    0,
+		"RECONCILE_DIRECTION_FROM_INVENTORY": 1,
+		"RECONCILE_DIRECTION_FROM_CATALOG":   2,
+	}
+)
+
+func (x ReconcileDirection) Enum() *ReconcileDirection {
+	p := new(ReconcileDirection)
+	*p = x
+	return p
+}
+
+func (x ReconcileDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReconcileDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_v1_catalog_proto_enumTypes[1].Descriptor()
+}
+
+func (ReconcileDirection) Type() protoreflect.EnumType {
+	return &file_proto_catalog_v1_catalog_proto_enumTypes[1]
+}
+
+func (x ReconcileDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReconcileDirection.Descriptor instead.
+func (ReconcileDirection) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{1}
+}
+
 type Product struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -140,11 +242,13 @@ func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
 }
 
 type GetProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Metadata  *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// include_inventory adds the product's live stock from the inventory service
+	IncludeInventory bool `protobuf:"varint,3,opt,name=include_inventory,json=includeInventory,proto3" json:"include_inventory,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GetProductRequest) Reset() {
@@ -191,27 +295,38 @@ func (x *GetProductRequest) GetProductId() string {
 	return ""
 }
 
-type GetProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetProductRequest) GetIncludeInventory() bool {
+	if x != nil {
+		return x.IncludeInventory
+	}
+	return false
 }
 
-func (x *GetProductResponse) Reset() {
-	*x = GetProductResponse{}
+// ProductInventory is a product's stock as held by the inventory service
+type ProductInventory struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AvailableQuantity int32                  `protobuf:"varint,1,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	ReservedQuantity  int32                  `protobuf:"varint,2,opt,name=reserved_quantity,json=reservedQuantity,proto3" json:"reserved_quantity,omitempty"`
+	TotalQuantity     int32                  `protobuf:"varint,3,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ProductInventory) Reset() {
+	*x = ProductInventory{}
 	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductResponse) String() string {
+func (x *ProductInventory) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductResponse) ProtoMessage() {}
+func (*ProductInventory) ProtoMessage() {}
 
-func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+func (x *ProductInventory) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -223,42 +338,66 @@ func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
-func (*GetProductResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProductInventory.ProtoReflect.Descriptor instead.
+func (*ProductInventory) Descriptor() ([]byte, []int) {
 	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetProductResponse) GetProduct() *Product {
+func (x *ProductInventory) GetAvailableQuantity() int32 {
 	if x != nil {
-		return x.Product
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
+func (x *ProductInventory) GetReservedQuantity() int32 {
+	if x != nil {
+		return x.ReservedQuantity
+	}
+	return 0
+}
+
+func (x *ProductInventory) GetTotalQuantity() int32 {
+	if x != nil {
+		return x.TotalQuantity
+	}
+	return 0
+}
+
+func (x *ProductInventory) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
 	}
 	return nil
 }
 
-type ListProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Pagination    *v1.PaginationRequest  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
-	SearchQuery   string                 `protobuf:"bytes,4,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetProductResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// inventory is set when include_inventory was requested and the inventory
+	// service answered
+	Inventory *ProductInventory `protobuf:"bytes,2,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	// inventory_stale is set when include_inventory was requested but the
+	// inventory service could not be reached; only stock_quantity is available
+	InventoryStale bool `protobuf:"varint,3,opt,name=inventory_stale,json=inventoryStale,proto3" json:"inventory_stale,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ListProductsRequest) Reset() {
-	*x = ListProductsRequest{}
+func (x *GetProductResponse) Reset() {
+	*x = GetProductResponse{}
 	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsRequest) String() string {
+func (x *GetProductResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsRequest) ProtoMessage() {}
+func (*GetProductResponse) ProtoMessage() {}
 
-func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -270,61 +409,54 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
+func (*GetProductResponse) Descriptor() ([]byte, []int) {
 	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ListProductsRequest) GetMetadata() *v1.RequestMetadata {
+func (x *GetProductResponse) GetProduct() *Product {
 	if x != nil {
-		return x.Metadata
+		return x.Product
 	}
 	return nil
 }
 
-func (x *ListProductsRequest) GetPagination() *v1.PaginationRequest {
+func (x *GetProductResponse) GetInventory() *ProductInventory {
 	if x != nil {
-		return x.Pagination
+		return x.Inventory
 	}
 	return nil
 }
 
-func (x *ListProductsRequest) GetCategory() string {
-	if x != nil {
-		return x.Category
-	}
-	return ""
-}
-
-func (x *ListProductsRequest) GetSearchQuery() string {
+func (x *GetProductResponse) GetInventoryStale() bool {
 	if x != nil {
-		return x.SearchQuery
+		return x.InventoryStale
 	}
-	return ""
+	return false
 }
 
-type ListProductsResponse struct {
+type GetProductBySKURequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
+func (x *GetProductBySKURequest) Reset() {
+	*x = GetProductBySKURequest{}
 	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *GetProductBySKURequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*GetProductBySKURequest) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+func (x *GetProductBySKURequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -336,53 +468,46 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetProductBySKURequest.ProtoReflect.Descriptor instead.
+func (*GetProductBySKURequest) Descriptor() ([]byte, []int) {
 	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
+func (x *GetProductBySKURequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
-		return x.Products
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *ListProductsResponse) GetPagination() *v1.PaginationResponse {
+func (x *GetProductBySKURequest) GetSku() string {
 	if x != nil {
-		return x.Pagination
+		return x.Sku
 	}
-	return nil
+	return ""
 }
 
-type CreateProductRequest struct {
+type GetProductBySKUResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
-	Price         *v1.Money              `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
-	StockQuantity int32                  `protobuf:"varint,6,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
-	Category      string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
-	ImageUrls     []string               `protobuf:"bytes,8,rep,name=image_urls,json=imageUrls,proto3" json:"image_urls,omitempty"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProductRequest) Reset() {
-	*x = CreateProductRequest{}
+func (x *GetProductBySKUResponse) Reset() {
+	*x = GetProductBySKUResponse{}
 	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductRequest) String() string {
+func (x *GetProductBySKUResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductRequest) ProtoMessage() {}
+func (*GetProductBySKUResponse) ProtoMessage() {}
 
-func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+func (x *GetProductBySKUResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -394,89 +519,134 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
-func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetProductBySKUResponse.ProtoReflect.Descriptor instead.
+func (*GetProductBySKUResponse) Descriptor() ([]byte, []int) {
 	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CreateProductRequest) GetMetadata() *v1.RequestMetadata {
+func (x *GetProductBySKUResponse) GetProduct() *Product {
 	if x != nil {
-		return x.Metadata
+		return x.Product
 	}
 	return nil
 }
 
-func (x *CreateProductRequest) GetName() string {
+type ListProductsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Metadata       *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Pagination     *v1.PaginationRequest  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Category       string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	SearchQuery    string                 `protobuf:"bytes,4,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
+	Facets         bool                   `protobuf:"varint,5,opt,name=facets,proto3" json:"facets,omitempty"` // Also return per-category counts for the search, ignoring category
+	SearchMode     SearchMode             `protobuf:"varint,6,opt,name=search_mode,json=searchMode,proto3,enum=catalog.v1.SearchMode" json:"search_mode,omitempty"`
+	FuzzyThreshold float32                `protobuf:"fixed32,7,opt,name=fuzzy_threshold,json=fuzzyThreshold,proto3" json:"fuzzy_threshold,omitempty"` // Minimum similarity for SEARCH_MODE_FUZZY; 0 uses the server default
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[6]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreateProductRequest) GetDescription() string {
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListProductsRequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
-		return x.Description
+		return x.Metadata
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateProductRequest) GetSku() string {
+func (x *ListProductsRequest) GetPagination() *v1.PaginationRequest {
 	if x != nil {
-		return x.Sku
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
 	}
 	return ""
 }
 
-func (x *CreateProductRequest) GetPrice() *v1.Money {
+func (x *ListProductsRequest) GetSearchQuery() string {
 	if x != nil {
-		return x.Price
+		return x.SearchQuery
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateProductRequest) GetStockQuantity() int32 {
+func (x *ListProductsRequest) GetFacets() bool {
 	if x != nil {
-		return x.StockQuantity
+		return x.Facets
 	}
-	return 0
+	return false
 }
 
-func (x *CreateProductRequest) GetCategory() string {
+func (x *ListProductsRequest) GetSearchMode() SearchMode {
 	if x != nil {
-		return x.Category
+		return x.SearchMode
 	}
-	return ""
+	return SearchMode_SEARCH_MODE_UNSPECIFIED
 }
 
-func (x *CreateProductRequest) GetImageUrls() []string {
+func (x *ListProductsRequest) GetFuzzyThreshold() float32 {
 	if x != nil {
-		return x.ImageUrls
+		return x.FuzzyThreshold
 	}
-	return nil
+	return 0
 }
 
-type CreateProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ListProductsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Products       []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Pagination     *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	CategoryCounts map[string]int64       `protobuf:"bytes,3,rep,name=category_counts,json=categoryCounts,proto3" json:"category_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Set when facets is requested
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *CreateProductResponse) Reset() {
-	*x = CreateProductResponse{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[6]
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductResponse) String() string {
+func (x *ListProductsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductResponse) ProtoMessage() {}
+func (*ListProductsResponse) ProtoMessage() {}
 
-func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[6]
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,45 +657,799 @@ func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
-func (*CreateProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CreateProductResponse) GetProduct() *Product {
+func (x *ListProductsResponse) GetProducts() []*Product {
 	if x != nil {
-		return x.Product
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetCategoryCounts() map[string]int64 {
+	if x != nil {
+		return x.CategoryCounts
+	}
+	return nil
+}
+
+type PriceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OldPrice      *v1.Money              `protobuf:"bytes,2,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice      *v1.Money              `protobuf:"bytes,3,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PriceChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceChange) GetOldPrice() *v1.Money {
+	if x != nil {
+		return x.OldPrice
+	}
+	return nil
+}
+
+func (x *PriceChange) GetNewPrice() *v1.Money {
+	if x != nil {
+		return x.NewPrice
+	}
+	return nil
+}
+
+func (x *PriceChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type GetProductPriceHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductPriceHistoryRequest) Reset() {
+	*x = GetProductPriceHistoryRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetProductPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetProductPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetProductPriceHistoryRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetProductPriceHistoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetProductPriceHistoryRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetProductPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*PriceChange         `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"` // Newest first
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductPriceHistoryResponse) Reset() {
+	*x = GetProductPriceHistoryResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetProductPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetProductPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetProductPriceHistoryResponse) GetChanges() []*PriceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetProductPriceHistoryResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type CreateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	Price         *v1.Money              `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	StockQuantity int32                  `protobuf:"varint,6,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	Category      string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	ImageUrls     []string               `protobuf:"bytes,8,rep,name=image_urls,json=imageUrls,proto3" json:"image_urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateProductRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetStockQuantity() int32 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetImageUrls() []string {
+	if x != nil {
+		return x.ImageUrls
+	}
+	return nil
+}
+
+type CreateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductResponse) Reset() {
+	*x = CreateProductResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductResponse) ProtoMessage() {}
+
+func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
 	}
 	return nil
 }
 
 type UpdateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Metadata    *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ProductId   string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Price       *v1.Money              `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Category    string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	// Fields to update: name, description, price, category. Listed fields are
+	// set even when empty; an empty mask changes nothing. Without a mask only
+	// non-empty fields are updated.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateProductRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductResponse) Reset() {
+	*x = UpdateProductResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductResponse) ProtoMessage() {}
+
+func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteProductRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DeleteProductRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{16}
+}
+
+type UpdateStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityDelta int32                  `protobuf:"varint,3,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"` // Can be positive or negative
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStockRequest) Reset() {
+	*x = UpdateStockRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockRequest) ProtoMessage() {}
+
+func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateStockRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetQuantityDelta() int32 {
+	if x != nil {
+		return x.QuantityDelta
+	}
+	return 0
+}
+
+type UpdateStockResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NewStockQuantity int32                  `protobuf:"varint,1,opt,name=new_stock_quantity,json=newStockQuantity,proto3" json:"new_stock_quantity,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateStockResponse) Reset() {
+	*x = UpdateStockResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockResponse) ProtoMessage() {}
+
+func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateStockResponse) GetNewStockQuantity() int32 {
+	if x != nil {
+		return x.NewStockQuantity
+	}
+	return 0
+}
+
+type FlushCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlushCacheRequest) Reset() {
+	*x = FlushCacheRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlushCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlushCacheRequest) ProtoMessage() {}
+
+func (x *FlushCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlushCacheRequest.ProtoReflect.Descriptor instead.
+func (*FlushCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FlushCacheRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type FlushCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeysDeleted   int64                  `protobuf:"varint,1,opt,name=keys_deleted,json=keysDeleted,proto3" json:"keys_deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlushCacheResponse) Reset() {
+	*x = FlushCacheResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlushCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlushCacheResponse) ProtoMessage() {}
+
+func (x *FlushCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlushCacheResponse.ProtoReflect.Descriptor instead.
+func (*FlushCacheResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FlushCacheResponse) GetKeysDeleted() int64 {
+	if x != nil {
+		return x.KeysDeleted
+	}
+	return 0
+}
+
+type ReconcileStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Price         *v1.Money              `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
-	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	Direction     ReconcileDirection     `protobuf:"varint,3,opt,name=direction,proto3,enum=catalog.v1.ReconcileDirection" json:"direction,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductRequest) Reset() {
-	*x = UpdateProductRequest{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[7]
+func (x *ReconcileStockRequest) Reset() {
+	*x = ReconcileStockRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductRequest) String() string {
+func (x *ReconcileStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductRequest) ProtoMessage() {}
+func (*ReconcileStockRequest) ProtoMessage() {}
 
-func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[7]
+func (x *ReconcileStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -536,75 +1460,280 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ReconcileStockRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *UpdateProductRequest) GetMetadata() *v1.RequestMetadata {
+func (x *ReconcileStockRequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *UpdateProductRequest) GetProductId() string {
+func (x *ReconcileStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReconcileStockRequest) GetDirection() ReconcileDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return ReconcileDirection_RECONCILE_DIRECTION_UNSPECIFIED
+}
+
+type ReconcileStockResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CatalogStock   int32                  `protobuf:"varint,1,opt,name=catalog_stock,json=catalogStock,proto3" json:"catalog_stock,omitempty"`       // Before any correction
+	InventoryTotal int32                  `protobuf:"varint,2,opt,name=inventory_total,json=inventoryTotal,proto3" json:"inventory_total,omitempty"` // Before any correction
+	Drift          int32                  `protobuf:"varint,3,opt,name=drift,proto3" json:"drift,omitempty"`                                         // catalog_stock - inventory_total
+	Corrected      bool                   `protobuf:"varint,4,opt,name=corrected,proto3" json:"corrected,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReconcileStockResponse) Reset() {
+	*x = ReconcileStockResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileStockResponse) ProtoMessage() {}
+
+func (x *ReconcileStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileStockResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReconcileStockResponse) GetCatalogStock() int32 {
+	if x != nil {
+		return x.CatalogStock
+	}
+	return 0
+}
+
+func (x *ReconcileStockResponse) GetInventoryTotal() int32 {
+	if x != nil {
+		return x.InventoryTotal
+	}
+	return 0
+}
+
+func (x *ReconcileStockResponse) GetDrift() int32 {
+	if x != nil {
+		return x.Drift
+	}
+	return 0
+}
+
+func (x *ReconcileStockResponse) GetCorrected() bool {
+	if x != nil {
+		return x.Corrected
+	}
+	return false
+}
+
+type CheckAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Items         []*StockCheck          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityRequest) Reset() {
+	*x = CheckAvailabilityRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CheckAvailabilityRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CheckAvailabilityRequest) GetItems() []*StockCheck {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type StockCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockCheck) Reset() {
+	*x = StockCheck{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockCheck) ProtoMessage() {}
+
+func (x *StockCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockCheck.ProtoReflect.Descriptor instead.
+func (*StockCheck) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StockCheck) GetProductId() string {
 	if x != nil {
 		return x.ProductId
 	}
 	return ""
 }
 
-func (x *UpdateProductRequest) GetName() string {
+func (x *StockCheck) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type CheckAvailabilityResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Available        bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	UnavailableItems []*UnavailableItem     `protobuf:"bytes,2,rep,name=unavailable_items,json=unavailableItems,proto3" json:"unavailable_items,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityResponse) Reset() {
+	*x = CheckAvailabilityResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[25]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateProductRequest) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
+// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *UpdateProductRequest) GetPrice() *v1.Money {
+func (x *CheckAvailabilityResponse) GetAvailable() bool {
 	if x != nil {
-		return x.Price
+		return x.Available
 	}
-	return nil
+	return false
 }
 
-func (x *UpdateProductRequest) GetCategory() string {
+func (x *CheckAvailabilityResponse) GetUnavailableItems() []*UnavailableItem {
 	if x != nil {
-		return x.Category
+		return x.UnavailableItems
 	}
-	return ""
+	return nil
 }
 
-type UpdateProductResponse struct {
+type UnavailableItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Requested     int32                  `protobuf:"varint,2,opt,name=requested,proto3" json:"requested,omitempty"`
+	Available     int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductResponse) Reset() {
-	*x = UpdateProductResponse{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[8]
+func (x *UnavailableItem) Reset() {
+	*x = UnavailableItem{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductResponse) String() string {
+func (x *UnavailableItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductResponse) ProtoMessage() {}
+func (*UnavailableItem) ProtoMessage() {}
 
-func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[8]
+func (x *UnavailableItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,42 +1744,57 @@ func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use UnavailableItem.ProtoReflect.Descriptor instead.
+func (*UnavailableItem) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *UpdateProductResponse) GetProduct() *Product {
+func (x *UnavailableItem) GetProductId() string {
 	if x != nil {
-		return x.Product
+		return x.ProductId
 	}
-	return nil
+	return ""
 }
 
-type UpdateStockRequest struct {
+func (x *UnavailableItem) GetRequested() int32 {
+	if x != nil {
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *UnavailableItem) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+type ReserveIfAvailableRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	QuantityDelta int32                  `protobuf:"varint,3,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"` // Can be positive or negative
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Optional; generated when empty
+	Items         []*StockCheck          `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	TtlSeconds    int32                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // Reservation TTL (default 15 min)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateStockRequest) Reset() {
-	*x = UpdateStockRequest{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[9]
+func (x *ReserveIfAvailableRequest) Reset() {
+	*x = ReserveIfAvailableRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStockRequest) String() string {
+func (x *ReserveIfAvailableRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStockRequest) ProtoMessage() {}
+func (*ReserveIfAvailableRequest) ProtoMessage() {}
 
-func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[9]
+func (x *ReserveIfAvailableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -661,54 +1805,63 @@ func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
-func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ReserveIfAvailableRequest.ProtoReflect.Descriptor instead.
+func (*ReserveIfAvailableRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *UpdateStockRequest) GetMetadata() *v1.RequestMetadata {
+func (x *ReserveIfAvailableRequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *UpdateStockRequest) GetProductId() string {
+func (x *ReserveIfAvailableRequest) GetReservationId() string {
 	if x != nil {
-		return x.ProductId
+		return x.ReservationId
 	}
 	return ""
 }
 
-func (x *UpdateStockRequest) GetQuantityDelta() int32 {
+func (x *ReserveIfAvailableRequest) GetItems() []*StockCheck {
 	if x != nil {
-		return x.QuantityDelta
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveIfAvailableRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
 	}
 	return 0
 }
 
-type UpdateStockResponse struct {
+type ReserveIfAvailableResponse struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
-	NewStockQuantity int32                  `protobuf:"varint,1,opt,name=new_stock_quantity,json=newStockQuantity,proto3" json:"new_stock_quantity,omitempty"`
+	Reserved         bool                   `protobuf:"varint,1,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	ReservationId    string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Set when reserved
+	UnavailableItems []*UnavailableItem     `protobuf:"bytes,3,rep,name=unavailable_items,json=unavailableItems,proto3" json:"unavailable_items,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
-func (x *UpdateStockResponse) Reset() {
-	*x = UpdateStockResponse{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[10]
+func (x *ReserveIfAvailableResponse) Reset() {
+	*x = ReserveIfAvailableResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStockResponse) String() string {
+func (x *ReserveIfAvailableResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStockResponse) ProtoMessage() {}
+func (*ReserveIfAvailableResponse) ProtoMessage() {}
 
-func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[10]
+func (x *ReserveIfAvailableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,19 +1872,33 @@ func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
-func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ReserveIfAvailableResponse.ProtoReflect.Descriptor instead.
+func (*ReserveIfAvailableResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *UpdateStockResponse) GetNewStockQuantity() int32 {
+func (x *ReserveIfAvailableResponse) GetReserved() bool {
 	if x != nil {
-		return x.NewStockQuantity
+		return x.Reserved
 	}
-	return 0
+	return false
 }
 
-type CheckAvailabilityRequest struct {
+func (x *ReserveIfAvailableResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReserveIfAvailableResponse) GetUnavailableItems() []*UnavailableItem {
+	if x != nil {
+		return x.UnavailableItems
+	}
+	return nil
+}
+
+type QuoteItemsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Items         []*StockCheck          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
@@ -739,21 +1906,21 @@ type CheckAvailabilityRequest struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckAvailabilityRequest) Reset() {
-	*x = CheckAvailabilityRequest{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[11]
+func (x *QuoteItemsRequest) Reset() {
+	*x = QuoteItemsRequest{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckAvailabilityRequest) String() string {
+func (x *QuoteItemsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAvailabilityRequest) ProtoMessage() {}
+func (*QuoteItemsRequest) ProtoMessage() {}
 
-func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[11]
+func (x *QuoteItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,48 +1931,47 @@ func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
-func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use QuoteItemsRequest.ProtoReflect.Descriptor instead.
+func (*QuoteItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *CheckAvailabilityRequest) GetMetadata() *v1.RequestMetadata {
+func (x *QuoteItemsRequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *CheckAvailabilityRequest) GetItems() []*StockCheck {
+func (x *QuoteItemsRequest) GetItems() []*StockCheck {
 	if x != nil {
 		return x.Items
 	}
 	return nil
 }
 
-type StockCheck struct {
+type QuoteItemsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quotes        []*ItemQuote           `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StockCheck) Reset() {
-	*x = StockCheck{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[12]
+func (x *QuoteItemsResponse) Reset() {
+	*x = QuoteItemsResponse{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StockCheck) String() string {
+func (x *QuoteItemsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StockCheck) ProtoMessage() {}
+func (*QuoteItemsResponse) ProtoMessage() {}
 
-func (x *StockCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[12]
+func (x *QuoteItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -816,48 +1982,46 @@ func (x *StockCheck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StockCheck.ProtoReflect.Descriptor instead.
-func (*StockCheck) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *StockCheck) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
+// Deprecated: Use QuoteItemsResponse.ProtoReflect.Descriptor instead.
+func (*QuoteItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *StockCheck) GetQuantity() int32 {
+func (x *QuoteItemsResponse) GetQuotes() []*ItemQuote {
 	if x != nil {
-		return x.Quantity
+		return x.Quotes
 	}
-	return 0
+	return nil
 }
 
-type CheckAvailabilityResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Available        bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
-	UnavailableItems []*UnavailableItem     `protobuf:"bytes,2,rep,name=unavailable_items,json=unavailableItems,proto3" json:"unavailable_items,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type ItemQuote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"` // False for unknown products
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Price         *v1.Money              `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Requested     int32                  `protobuf:"varint,5,opt,name=requested,proto3" json:"requested,omitempty"`
+	Available     int32                  `protobuf:"varint,6,opt,name=available,proto3" json:"available,omitempty"`
+	InStock       bool                   `protobuf:"varint,7,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckAvailabilityResponse) Reset() {
-	*x = CheckAvailabilityResponse{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[13]
+func (x *ItemQuote) Reset() {
+	*x = ItemQuote{}
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckAvailabilityResponse) String() string {
+func (x *ItemQuote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAvailabilityResponse) ProtoMessage() {}
+func (*ItemQuote) ProtoMessage() {}
 
-func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[13]
+func (x *ItemQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -868,91 +2032,66 @@ func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
-func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ItemQuote.ProtoReflect.Descriptor instead.
+func (*ItemQuote) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *CheckAvailabilityResponse) GetAvailable() bool {
+func (x *ItemQuote) GetProductId() string {
 	if x != nil {
-		return x.Available
+		return x.ProductId
 	}
-	return false
+	return ""
 }
 
-func (x *CheckAvailabilityResponse) GetUnavailableItems() []*UnavailableItem {
+func (x *ItemQuote) GetFound() bool {
 	if x != nil {
-		return x.UnavailableItems
+		return x.Found
 	}
-	return nil
-}
-
-type UnavailableItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Requested     int32                  `protobuf:"varint,2,opt,name=requested,proto3" json:"requested,omitempty"`
-	Available     int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UnavailableItem) Reset() {
-	*x = UnavailableItem{}
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UnavailableItem) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return false
 }
 
-func (*UnavailableItem) ProtoMessage() {}
-
-func (x *UnavailableItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_v1_catalog_proto_msgTypes[14]
+func (x *ItemQuote) GetName() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Name
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UnavailableItem.ProtoReflect.Descriptor instead.
-func (*UnavailableItem) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_v1_catalog_proto_rawDescGZIP(), []int{14}
+	return ""
 }
 
-func (x *UnavailableItem) GetProductId() string {
+func (x *ItemQuote) GetPrice() *v1.Money {
 	if x != nil {
-		return x.ProductId
+		return x.Price
 	}
-	return ""
+	return nil
 }
 
-func (x *UnavailableItem) GetRequested() int32 {
+func (x *ItemQuote) GetRequested() int32 {
 	if x != nil {
 		return x.Requested
 	}
 	return 0
 }
 
-func (x *UnavailableItem) GetAvailable() int32 {
+func (x *ItemQuote) GetAvailable() int32 {
 	if x != nil {
 		return x.Available
 	}
 	return 0
 }
 
+func (x *ItemQuote) GetInStock() bool {
+	if x != nil {
+		return x.InStock
+	}
+	return false
+}
+
 var File_proto_catalog_v1_catalog_proto protoreflect.FileDescriptor
 
 const file_proto_catalog_v1_catalog_proto_rawDesc = "" +
 	"\n" +
 	"\x1eproto/catalog/v1/catalog.proto\x12\n" +
-	"catalog.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/common/v1/common.proto\"\xe1\x02\n" +
+	"catalog.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/common/v1/common.proto\"\xe1\x02\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -967,24 +2106,64 @@ const file_proto_catalog_v1_catalog_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"j\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x97\x01\n" +
 	"\x11GetProductRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
 	"\n" +
-	"product_id\x18\x02 \x01(\tR\tproductId\"C\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12+\n" +
+	"\x11include_inventory\x18\x03 \x01(\bR\x10includeInventory\"\xd0\x01\n" +
+	"\x10ProductInventory\x12-\n" +
+	"\x12available_quantity\x18\x01 \x01(\x05R\x11availableQuantity\x12+\n" +
+	"\x11reserved_quantity\x18\x02 \x01(\x05R\x10reservedQuantity\x12%\n" +
+	"\x0etotal_quantity\x18\x03 \x01(\x05R\rtotalQuantity\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa8\x01\n" +
 	"\x12GetProductResponse\x12-\n" +
-	"\aproduct\x18\x01 \x01(\v2\x13.catalog.v1.ProductR\aproduct\"\xca\x01\n" +
+	"\aproduct\x18\x01 \x01(\v2\x13.catalog.v1.ProductR\aproduct\x12:\n" +
+	"\tinventory\x18\x02 \x01(\v2\x1c.catalog.v1.ProductInventoryR\tinventory\x12'\n" +
+	"\x0finventory_stale\x18\x03 \x01(\bR\x0einventoryStale\"b\n" +
+	"\x16GetProductBySKURequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\"H\n" +
+	"\x17GetProductBySKUResponse\x12-\n" +
+	"\aproduct\x18\x01 \x01(\v2\x13.catalog.v1.ProductR\aproduct\"\xc4\x02\n" +
 	"\x13ListProductsRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12<\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
 	"pagination\x12\x1a\n" +
 	"\bcategory\x18\x03 \x01(\tR\bcategory\x12!\n" +
-	"\fsearch_query\x18\x04 \x01(\tR\vsearchQuery\"\x86\x01\n" +
+	"\fsearch_query\x18\x04 \x01(\tR\vsearchQuery\x12\x16\n" +
+	"\x06facets\x18\x05 \x01(\bR\x06facets\x127\n" +
+	"\vsearch_mode\x18\x06 \x01(\x0e2\x16.catalog.v1.SearchModeR\n" +
+	"searchMode\x12'\n" +
+	"\x0ffuzzy_threshold\x18\a \x01(\x02R\x0efuzzyThreshold\"\xa8\x02\n" +
 	"\x14ListProductsResponse\x12/\n" +
 	"\bproducts\x18\x01 \x03(\v2\x13.catalog.v1.ProductR\bproducts\x12=\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"pagination\x12]\n" +
+	"\x0fcategory_counts\x18\x03 \x03(\v24.catalog.v1.ListProductsResponse.CategoryCountsEntryR\x0ecategoryCounts\x1aA\n" +
+	"\x13CategoryCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xb6\x01\n" +
+	"\vPriceChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\told_price\x18\x02 \x01(\v2\x10.common.v1.MoneyR\boldPrice\x12-\n" +
+	"\tnew_price\x18\x03 \x01(\v2\x10.common.v1.MoneyR\bnewPrice\x129\n" +
+	"\n" +
+	"changed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"\xb4\x01\n" +
+	"\x1dGetProductPriceHistoryRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12<\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
+	"pagination\"\x92\x01\n" +
+	"\x1eGetProductPriceHistoryResponse\x121\n" +
+	"\achanges\x18\x01 \x03(\v2\x17.catalog.v1.PriceChangeR\achanges\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
 	"pagination\"\xa0\x02\n" +
 	"\x14CreateProductRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x12\n" +
@@ -997,7 +2176,7 @@ const file_proto_catalog_v1_catalog_proto_rawDesc = "" +
 	"\n" +
 	"image_urls\x18\b \x03(\tR\timageUrls\"F\n" +
 	"\x15CreateProductResponse\x12-\n" +
-	"\aproduct\x18\x01 \x01(\v2\x13.catalog.v1.ProductR\aproduct\"\xe7\x01\n" +
+	"\aproduct\x18\x01 \x01(\v2\x13.catalog.v1.ProductR\aproduct\"\xa4\x02\n" +
 	"\x14UpdateProductRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
 	"\n" +
@@ -1005,16 +2184,37 @@ const file_proto_catalog_v1_catalog_proto_rawDesc = "" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12&\n" +
 	"\x05price\x18\x05 \x01(\v2\x10.common.v1.MoneyR\x05price\x12\x1a\n" +
-	"\bcategory\x18\x06 \x01(\tR\bcategory\"F\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x12;\n" +
+	"\vupdate_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"F\n" +
 	"\x15UpdateProductResponse\x12-\n" +
-	"\aproduct\x18\x01 \x01(\v2\x13.catalog.v1.ProductR\aproduct\"\x92\x01\n" +
+	"\aproduct\x18\x01 \x01(\v2\x13.catalog.v1.ProductR\aproduct\"m\n" +
+	"\x14DeleteProductRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\"\x17\n" +
+	"\x15DeleteProductResponse\"\x92\x01\n" +
 	"\x12UpdateStockRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x02 \x01(\tR\tproductId\x12%\n" +
 	"\x0equantity_delta\x18\x03 \x01(\x05R\rquantityDelta\"C\n" +
 	"\x13UpdateStockResponse\x12,\n" +
-	"\x12new_stock_quantity\x18\x01 \x01(\x05R\x10newStockQuantity\"\x80\x01\n" +
+	"\x12new_stock_quantity\x18\x01 \x01(\x05R\x10newStockQuantity\"K\n" +
+	"\x11FlushCacheRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\"7\n" +
+	"\x12FlushCacheResponse\x12!\n" +
+	"\fkeys_deleted\x18\x01 \x01(\x03R\vkeysDeleted\"\xac\x01\n" +
+	"\x15ReconcileStockRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12<\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x1e.catalog.v1.ReconcileDirectionR\tdirection\"\x9a\x01\n" +
+	"\x16ReconcileStockResponse\x12#\n" +
+	"\rcatalog_stock\x18\x01 \x01(\x05R\fcatalogStock\x12'\n" +
+	"\x0finventory_total\x18\x02 \x01(\x05R\x0einventoryTotal\x12\x14\n" +
+	"\x05drift\x18\x03 \x01(\x05R\x05drift\x12\x1c\n" +
+	"\tcorrected\x18\x04 \x01(\bR\tcorrected\"\x80\x01\n" +
 	"\x18CheckAvailabilityRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12,\n" +
 	"\x05items\x18\x02 \x03(\v2\x16.catalog.v1.StockCheckR\x05items\"G\n" +
@@ -1030,15 +2230,58 @@ const file_proto_catalog_v1_catalog_proto_rawDesc = "" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1c\n" +
 	"\trequested\x18\x02 \x01(\x05R\trequested\x12\x1c\n" +
-	"\tavailable\x18\x03 \x01(\x05R\tavailable2\x8e\x04\n" +
+	"\tavailable\x18\x03 \x01(\x05R\tavailable\"\xc9\x01\n" +
+	"\x19ReserveIfAvailableRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x12,\n" +
+	"\x05items\x18\x03 \x03(\v2\x16.catalog.v1.StockCheckR\x05items\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\"\xa9\x01\n" +
+	"\x1aReserveIfAvailableResponse\x12\x1a\n" +
+	"\breserved\x18\x01 \x01(\bR\breserved\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x12H\n" +
+	"\x11unavailable_items\x18\x03 \x03(\v2\x1b.catalog.v1.UnavailableItemR\x10unavailableItems\"y\n" +
+	"\x11QuoteItemsRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12,\n" +
+	"\x05items\x18\x02 \x03(\v2\x16.catalog.v1.StockCheckR\x05items\"C\n" +
+	"\x12QuoteItemsResponse\x12-\n" +
+	"\x06quotes\x18\x01 \x03(\v2\x15.catalog.v1.ItemQuoteR\x06quotes\"\xd3\x01\n" +
+	"\tItemQuote\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12&\n" +
+	"\x05price\x18\x04 \x01(\v2\x10.common.v1.MoneyR\x05price\x12\x1c\n" +
+	"\trequested\x18\x05 \x01(\x05R\trequested\x12\x1c\n" +
+	"\tavailable\x18\x06 \x01(\x05R\tavailable\x12\x19\n" +
+	"\bin_stock\x18\a \x01(\bR\ainStock*s\n" +
+	"\n" +
+	"SearchMode\x12\x1b\n" +
+	"\x17SEARCH_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15SEARCH_MODE_FULL_TEXT\x10\x01\x12\x16\n" +
+	"\x12SEARCH_MODE_PREFIX\x10\x02\x12\x15\n" +
+	"\x11SEARCH_MODE_FUZZY\x10\x03*\x87\x01\n" +
+	"\x12ReconcileDirection\x12#\n" +
+	"\x1fRECONCILE_DIRECTION_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"RECONCILE_DIRECTION_FROM_INVENTORY\x10\x01\x12$\n" +
+	" RECONCILE_DIRECTION_FROM_CATALOG\x10\x022\x89\t\n" +
 	"\x0eCatalogService\x12K\n" +
 	"\n" +
-	"GetProduct\x12\x1d.catalog.v1.GetProductRequest\x1a\x1e.catalog.v1.GetProductResponse\x12Q\n" +
-	"\fListProducts\x12\x1f.catalog.v1.ListProductsRequest\x1a .catalog.v1.ListProductsResponse\x12T\n" +
+	"GetProduct\x12\x1d.catalog.v1.GetProductRequest\x1a\x1e.catalog.v1.GetProductResponse\x12Z\n" +
+	"\x0fGetProductBySKU\x12\".catalog.v1.GetProductBySKURequest\x1a#.catalog.v1.GetProductBySKUResponse\x12Q\n" +
+	"\fListProducts\x12\x1f.catalog.v1.ListProductsRequest\x1a .catalog.v1.ListProductsResponse\x12o\n" +
+	"\x16GetProductPriceHistory\x12).catalog.v1.GetProductPriceHistoryRequest\x1a*.catalog.v1.GetProductPriceHistoryResponse\x12T\n" +
 	"\rCreateProduct\x12 .catalog.v1.CreateProductRequest\x1a!.catalog.v1.CreateProductResponse\x12T\n" +
-	"\rUpdateProduct\x12 .catalog.v1.UpdateProductRequest\x1a!.catalog.v1.UpdateProductResponse\x12N\n" +
+	"\rUpdateProduct\x12 .catalog.v1.UpdateProductRequest\x1a!.catalog.v1.UpdateProductResponse\x12T\n" +
+	"\rDeleteProduct\x12 .catalog.v1.DeleteProductRequest\x1a!.catalog.v1.DeleteProductResponse\x12N\n" +
 	"\vUpdateStock\x12\x1e.catalog.v1.UpdateStockRequest\x1a\x1f.catalog.v1.UpdateStockResponse\x12`\n" +
-	"\x11CheckAvailability\x12$.catalog.v1.CheckAvailabilityRequest\x1a%.catalog.v1.CheckAvailabilityResponseB6Z4github.com/mumumio1/coldy/proto/catalog/v1;catalogv1b\x06proto3"
+	"\x11CheckAvailability\x12$.catalog.v1.CheckAvailabilityRequest\x1a%.catalog.v1.CheckAvailabilityResponse\x12c\n" +
+	"\x12ReserveIfAvailable\x12%.catalog.v1.ReserveIfAvailableRequest\x1a&.catalog.v1.ReserveIfAvailableResponse\x12K\n" +
+	"\n" +
+	"QuoteItems\x12\x1d.catalog.v1.QuoteItemsRequest\x1a\x1e.catalog.v1.QuoteItemsResponse\x12W\n" +
+	"\x0eReconcileStock\x12!.catalog.v1.ReconcileStockRequest\x1a\".catalog.v1.ReconcileStockResponse\x12K\n" +
+	"\n" +
+	"FlushCache\x12\x1d.catalog.v1.FlushCacheRequest\x1a\x1e.catalog.v1.FlushCacheResponseB6Z4github.com/mumumio1/coldy/proto/catalog/v1;catalogv1b\x06proto3"
 
 var (
 	file_proto_catalog_v1_catalog_proto_rawDescOnce sync.Once
@@ -1052,66 +2295,127 @@ func file_proto_catalog_v1_catalog_proto_rawDescGZIP() []byte {
 	return file_proto_catalog_v1_catalog_proto_rawDescData
 }
 
-var file_proto_catalog_v1_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_catalog_v1_catalog_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_catalog_v1_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_proto_catalog_v1_catalog_proto_goTypes = []any{
-	(*Product)(nil),                   // 0: catalog.v1.Product
-	(*GetProductRequest)(nil),         // 1: catalog.v1.GetProductRequest
-	(*GetProductResponse)(nil),        // 2: catalog.v1.GetProductResponse
-	(*ListProductsRequest)(nil),       // 3: catalog.v1.ListProductsRequest
-	(*ListProductsResponse)(nil),      // 4: catalog.v1.ListProductsResponse
-	(*CreateProductRequest)(nil),      // 5: catalog.v1.CreateProductRequest
-	(*CreateProductResponse)(nil),     // 6: catalog.v1.CreateProductResponse
-	(*UpdateProductRequest)(nil),      // 7: catalog.v1.UpdateProductRequest
-	(*UpdateProductResponse)(nil),     // 8: catalog.v1.UpdateProductResponse
-	(*UpdateStockRequest)(nil),        // 9: catalog.v1.UpdateStockRequest
-	(*UpdateStockResponse)(nil),       // 10: catalog.v1.UpdateStockResponse
-	(*CheckAvailabilityRequest)(nil),  // 11: catalog.v1.CheckAvailabilityRequest
-	(*StockCheck)(nil),                // 12: catalog.v1.StockCheck
-	(*CheckAvailabilityResponse)(nil), // 13: catalog.v1.CheckAvailabilityResponse
-	(*UnavailableItem)(nil),           // 14: catalog.v1.UnavailableItem
-	(*v1.Money)(nil),                  // 15: common.v1.Money
-	(*timestamppb.Timestamp)(nil),     // 16: google.protobuf.Timestamp
-	(*v1.RequestMetadata)(nil),        // 17: common.v1.RequestMetadata
-	(*v1.PaginationRequest)(nil),      // 18: common.v1.PaginationRequest
-	(*v1.PaginationResponse)(nil),     // 19: common.v1.PaginationResponse
+	(SearchMode)(0),                        // 0: catalog.v1.SearchMode
+	(ReconcileDirection)(0),                // 1: catalog.v1.ReconcileDirection
+	(*Product)(nil),                        // 2: catalog.v1.Product
+	(*GetProductRequest)(nil),              // 3: catalog.v1.GetProductRequest
+	(*ProductInventory)(nil),               // 4: catalog.v1.ProductInventory
+	(*GetProductResponse)(nil),             // 5: catalog.v1.GetProductResponse
+	(*GetProductBySKURequest)(nil),         // 6: catalog.v1.GetProductBySKURequest
+	(*GetProductBySKUResponse)(nil),        // 7: catalog.v1.GetProductBySKUResponse
+	(*ListProductsRequest)(nil),            // 8: catalog.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),           // 9: catalog.v1.ListProductsResponse
+	(*PriceChange)(nil),                    // 10: catalog.v1.PriceChange
+	(*GetProductPriceHistoryRequest)(nil),  // 11: catalog.v1.GetProductPriceHistoryRequest
+	(*GetProductPriceHistoryResponse)(nil), // 12: catalog.v1.GetProductPriceHistoryResponse
+	(*CreateProductRequest)(nil),           // 13: catalog.v1.CreateProductRequest
+	(*CreateProductResponse)(nil),          // 14: catalog.v1.CreateProductResponse
+	(*UpdateProductRequest)(nil),           // 15: catalog.v1.UpdateProductRequest
+	(*UpdateProductResponse)(nil),          // 16: catalog.v1.UpdateProductResponse
+	(*DeleteProductRequest)(nil),           // 17: catalog.v1.DeleteProductRequest
+	(*DeleteProductResponse)(nil),          // 18: catalog.v1.DeleteProductResponse
+	(*UpdateStockRequest)(nil),             // 19: catalog.v1.UpdateStockRequest
+	(*UpdateStockResponse)(nil),            // 20: catalog.v1.UpdateStockResponse
+	(*FlushCacheRequest)(nil),              // 21: catalog.v1.FlushCacheRequest
+	(*FlushCacheResponse)(nil),             // 22: catalog.v1.FlushCacheResponse
+	(*ReconcileStockRequest)(nil),          // 23: catalog.v1.ReconcileStockRequest
+	(*ReconcileStockResponse)(nil),         // 24: catalog.v1.ReconcileStockResponse
+	(*CheckAvailabilityRequest)(nil),       // 25: catalog.v1.CheckAvailabilityRequest
+	(*StockCheck)(nil),                     // 26: catalog.v1.StockCheck
+	(*CheckAvailabilityResponse)(nil),      // 27: catalog.v1.CheckAvailabilityResponse
+	(*UnavailableItem)(nil),                // 28: catalog.v1.UnavailableItem
+	(*ReserveIfAvailableRequest)(nil),      // 29: catalog.v1.ReserveIfAvailableRequest
+	(*ReserveIfAvailableResponse)(nil),     // 30: catalog.v1.ReserveIfAvailableResponse
+	(*QuoteItemsRequest)(nil),              // 31: catalog.v1.QuoteItemsRequest
+	(*QuoteItemsResponse)(nil),             // 32: catalog.v1.QuoteItemsResponse
+	(*ItemQuote)(nil),                      // 33: catalog.v1.ItemQuote
+	nil,                                    // 34: catalog.v1.ListProductsResponse.CategoryCountsEntry
+	(*v1.Money)(nil),                       // 35: common.v1.Money
+	(*timestamppb.Timestamp)(nil),          // 36: google.protobuf.Timestamp
+	(*v1.RequestMetadata)(nil),             // 37: common.v1.RequestMetadata
+	(*v1.PaginationRequest)(nil),           // 38: common.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),          // 39: common.v1.PaginationResponse
+	(*fieldmaskpb.FieldMask)(nil),          // 40: google.protobuf.FieldMask
 }
 var file_proto_catalog_v1_catalog_proto_depIdxs = []int32{
-	15, // 0: catalog.v1.Product.price:type_name -> common.v1.Money
-	16, // 1: catalog.v1.Product.created_at:type_name -> google.protobuf.Timestamp
-	16, // 2: catalog.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
-	17, // 3: catalog.v1.GetProductRequest.metadata:type_name -> common.v1.RequestMetadata
-	0,  // 4: catalog.v1.GetProductResponse.product:type_name -> catalog.v1.Product
-	17, // 5: catalog.v1.ListProductsRequest.metadata:type_name -> common.v1.RequestMetadata
-	18, // 6: catalog.v1.ListProductsRequest.pagination:type_name -> common.v1.PaginationRequest
-	0,  // 7: catalog.v1.ListProductsResponse.products:type_name -> catalog.v1.Product
-	19, // 8: catalog.v1.ListProductsResponse.pagination:type_name -> common.v1.PaginationResponse
-	17, // 9: catalog.v1.CreateProductRequest.metadata:type_name -> common.v1.RequestMetadata
-	15, // 10: catalog.v1.CreateProductRequest.price:type_name -> common.v1.Money
-	0,  // 11: catalog.v1.CreateProductResponse.product:type_name -> catalog.v1.Product
-	17, // 12: catalog.v1.UpdateProductRequest.metadata:type_name -> common.v1.RequestMetadata
-	15, // 13: catalog.v1.UpdateProductRequest.price:type_name -> common.v1.Money
-	0,  // 14: catalog.v1.UpdateProductResponse.product:type_name -> catalog.v1.Product
-	17, // 15: catalog.v1.UpdateStockRequest.metadata:type_name -> common.v1.RequestMetadata
-	17, // 16: catalog.v1.CheckAvailabilityRequest.metadata:type_name -> common.v1.RequestMetadata
-	12, // 17: catalog.v1.CheckAvailabilityRequest.items:type_name -> catalog.v1.StockCheck
-	14, // 18: catalog.v1.CheckAvailabilityResponse.unavailable_items:type_name -> catalog.v1.UnavailableItem
-	1,  // 19: catalog.v1.CatalogService.GetProduct:input_type -> catalog.v1.GetProductRequest
-	3,  // 20: catalog.v1.CatalogService.ListProducts:input_type -> catalog.v1.ListProductsRequest
-	5,  // 21: catalog.v1.CatalogService.CreateProduct:input_type -> catalog.v1.CreateProductRequest
-	7,  // 22: catalog.v1.CatalogService.UpdateProduct:input_type -> catalog.v1.UpdateProductRequest
-	9,  // 23: catalog.v1.CatalogService.UpdateStock:input_type -> catalog.v1.UpdateStockRequest
-	11, // 24: catalog.v1.CatalogService.CheckAvailability:input_type -> catalog.v1.CheckAvailabilityRequest
-	2,  // 25: catalog.v1.CatalogService.GetProduct:output_type -> catalog.v1.GetProductResponse
-	4,  // 26: catalog.v1.CatalogService.ListProducts:output_type -> catalog.v1.ListProductsResponse
-	6,  // 27: catalog.v1.CatalogService.CreateProduct:output_type -> catalog.v1.CreateProductResponse
-	8,  // 28: catalog.v1.CatalogService.UpdateProduct:output_type -> catalog.v1.UpdateProductResponse
-	10, // 29: catalog.v1.CatalogService.UpdateStock:output_type -> catalog.v1.UpdateStockResponse
-	13, // 30: catalog.v1.CatalogService.CheckAvailability:output_type -> catalog.v1.CheckAvailabilityResponse
-	25, // [25:31] is the sub-list for method output_type
-	19, // [19:25] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	35, // 0: catalog.v1.Product.price:type_name -> common.v1.Money
+	36, // 1: catalog.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	36, // 2: catalog.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
+	37, // 3: catalog.v1.GetProductRequest.metadata:type_name -> common.v1.RequestMetadata
+	36, // 4: catalog.v1.ProductInventory.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 5: catalog.v1.GetProductResponse.product:type_name -> catalog.v1.Product
+	4,  // 6: catalog.v1.GetProductResponse.inventory:type_name -> catalog.v1.ProductInventory
+	37, // 7: catalog.v1.GetProductBySKURequest.metadata:type_name -> common.v1.RequestMetadata
+	2,  // 8: catalog.v1.GetProductBySKUResponse.product:type_name -> catalog.v1.Product
+	37, // 9: catalog.v1.ListProductsRequest.metadata:type_name -> common.v1.RequestMetadata
+	38, // 10: catalog.v1.ListProductsRequest.pagination:type_name -> common.v1.PaginationRequest
+	0,  // 11: catalog.v1.ListProductsRequest.search_mode:type_name -> catalog.v1.SearchMode
+	2,  // 12: catalog.v1.ListProductsResponse.products:type_name -> catalog.v1.Product
+	39, // 13: catalog.v1.ListProductsResponse.pagination:type_name -> common.v1.PaginationResponse
+	34, // 14: catalog.v1.ListProductsResponse.category_counts:type_name -> catalog.v1.ListProductsResponse.CategoryCountsEntry
+	35, // 15: catalog.v1.PriceChange.old_price:type_name -> common.v1.Money
+	35, // 16: catalog.v1.PriceChange.new_price:type_name -> common.v1.Money
+	36, // 17: catalog.v1.PriceChange.changed_at:type_name -> google.protobuf.Timestamp
+	37, // 18: catalog.v1.GetProductPriceHistoryRequest.metadata:type_name -> common.v1.RequestMetadata
+	38, // 19: catalog.v1.GetProductPriceHistoryRequest.pagination:type_name -> common.v1.PaginationRequest
+	10, // 20: catalog.v1.GetProductPriceHistoryResponse.changes:type_name -> catalog.v1.PriceChange
+	39, // 21: catalog.v1.GetProductPriceHistoryResponse.pagination:type_name -> common.v1.PaginationResponse
+	37, // 22: catalog.v1.CreateProductRequest.metadata:type_name -> common.v1.RequestMetadata
+	35, // 23: catalog.v1.CreateProductRequest.price:type_name -> common.v1.Money
+	2,  // 24: catalog.v1.CreateProductResponse.product:type_name -> catalog.v1.Product
+	37, // 25: catalog.v1.UpdateProductRequest.metadata:type_name -> common.v1.RequestMetadata
+	35, // 26: catalog.v1.UpdateProductRequest.price:type_name -> common.v1.Money
+	40, // 27: catalog.v1.UpdateProductRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 28: catalog.v1.UpdateProductResponse.product:type_name -> catalog.v1.Product
+	37, // 29: catalog.v1.DeleteProductRequest.metadata:type_name -> common.v1.RequestMetadata
+	37, // 30: catalog.v1.UpdateStockRequest.metadata:type_name -> common.v1.RequestMetadata
+	37, // 31: catalog.v1.FlushCacheRequest.metadata:type_name -> common.v1.RequestMetadata
+	37, // 32: catalog.v1.ReconcileStockRequest.metadata:type_name -> common.v1.RequestMetadata
+	1,  // 33: catalog.v1.ReconcileStockRequest.direction:type_name -> catalog.v1.ReconcileDirection
+	37, // 34: catalog.v1.CheckAvailabilityRequest.metadata:type_name -> common.v1.RequestMetadata
+	26, // 35: catalog.v1.CheckAvailabilityRequest.items:type_name -> catalog.v1.StockCheck
+	28, // 36: catalog.v1.CheckAvailabilityResponse.unavailable_items:type_name -> catalog.v1.UnavailableItem
+	37, // 37: catalog.v1.ReserveIfAvailableRequest.metadata:type_name -> common.v1.RequestMetadata
+	26, // 38: catalog.v1.ReserveIfAvailableRequest.items:type_name -> catalog.v1.StockCheck
+	28, // 39: catalog.v1.ReserveIfAvailableResponse.unavailable_items:type_name -> catalog.v1.UnavailableItem
+	37, // 40: catalog.v1.QuoteItemsRequest.metadata:type_name -> common.v1.RequestMetadata
+	26, // 41: catalog.v1.QuoteItemsRequest.items:type_name -> catalog.v1.StockCheck
+	33, // 42: catalog.v1.QuoteItemsResponse.quotes:type_name -> catalog.v1.ItemQuote
+	35, // 43: catalog.v1.ItemQuote.price:type_name -> common.v1.Money
+	3,  // 44: catalog.v1.CatalogService.GetProduct:input_type -> catalog.v1.GetProductRequest
+	6,  // 45: catalog.v1.CatalogService.GetProductBySKU:input_type -> catalog.v1.GetProductBySKURequest
+	8,  // 46: catalog.v1.CatalogService.ListProducts:input_type -> catalog.v1.ListProductsRequest
+	11, // 47: catalog.v1.CatalogService.GetProductPriceHistory:input_type -> catalog.v1.GetProductPriceHistoryRequest
+	13, // 48: catalog.v1.CatalogService.CreateProduct:input_type -> catalog.v1.CreateProductRequest
+	15, // 49: catalog.v1.CatalogService.UpdateProduct:input_type -> catalog.v1.UpdateProductRequest
+	17, // 50: catalog.v1.CatalogService.DeleteProduct:input_type -> catalog.v1.DeleteProductRequest
+	19, // 51: catalog.v1.CatalogService.UpdateStock:input_type -> catalog.v1.UpdateStockRequest
+	25, // 52: catalog.v1.CatalogService.CheckAvailability:input_type -> catalog.v1.CheckAvailabilityRequest
+	29, // 53: catalog.v1.CatalogService.ReserveIfAvailable:input_type -> catalog.v1.ReserveIfAvailableRequest
+	31, // 54: catalog.v1.CatalogService.QuoteItems:input_type -> catalog.v1.QuoteItemsRequest
+	23, // 55: catalog.v1.CatalogService.ReconcileStock:input_type -> catalog.v1.ReconcileStockRequest
+	21, // 56: catalog.v1.CatalogService.FlushCache:input_type -> catalog.v1.FlushCacheRequest
+	5,  // 57: catalog.v1.CatalogService.GetProduct:output_type -> catalog.v1.GetProductResponse
+	7,  // 58: catalog.v1.CatalogService.GetProductBySKU:output_type -> catalog.v1.GetProductBySKUResponse
+	9,  // 59: catalog.v1.CatalogService.ListProducts:output_type -> catalog.v1.ListProductsResponse
+	12, // 60: catalog.v1.CatalogService.GetProductPriceHistory:output_type -> catalog.v1.GetProductPriceHistoryResponse
+	14, // 61: catalog.v1.CatalogService.CreateProduct:output_type -> catalog.v1.CreateProductResponse
+	16, // 62: catalog.v1.CatalogService.UpdateProduct:output_type -> catalog.v1.UpdateProductResponse
+	18, // 63: catalog.v1.CatalogService.DeleteProduct:output_type -> catalog.v1.DeleteProductResponse
+	20, // 64: catalog.v1.CatalogService.UpdateStock:output_type -> catalog.v1.UpdateStockResponse
+	27, // 65: catalog.v1.CatalogService.CheckAvailability:output_type -> catalog.v1.CheckAvailabilityResponse
+	30, // 66: catalog.v1.CatalogService.ReserveIfAvailable:output_type -> catalog.v1.ReserveIfAvailableResponse
+	32, // 67: catalog.v1.CatalogService.QuoteItems:output_type -> catalog.v1.QuoteItemsResponse
+	24, // 68: catalog.v1.CatalogService.ReconcileStock:output_type -> catalog.v1.ReconcileStockResponse
+	22, // 69: catalog.v1.CatalogService.FlushCache:output_type -> catalog.v1.FlushCacheResponse
+	57, // [57:70] is the sub-list for method output_type
+	44, // [44:57] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_proto_catalog_v1_catalog_proto_init() }
@@ -1124,13 +2428,14 @@ func file_proto_catalog_v1_catalog_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_catalog_v1_catalog_proto_rawDesc), len(file_proto_catalog_v1_catalog_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   15,
+			NumEnums:      2,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_catalog_v1_catalog_proto_goTypes,
 		DependencyIndexes: file_proto_catalog_v1_catalog_proto_depIdxs,
+		EnumInfos:         file_proto_catalog_v1_catalog_proto_enumTypes,
 		MessageInfos:      file_proto_catalog_v1_catalog_proto_msgTypes,
 	}.Build()
 	File_proto_catalog_v1_catalog_proto = out.File
@@ -19,12 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CatalogService_GetProduct_FullMethodName        = "/catalog.v1.CatalogService/GetProduct"
-	CatalogService_ListProducts_FullMethodName      = "/catalog.v1.CatalogService/ListProducts"
-	CatalogService_CreateProduct_FullMethodName     = "/catalog.v1.CatalogService/CreateProduct"
-	CatalogService_UpdateProduct_FullMethodName     = "/catalog.v1.CatalogService/UpdateProduct"
-	CatalogService_UpdateStock_FullMethodName       = "/catalog.v1.CatalogService/UpdateStock"
-	CatalogService_CheckAvailability_FullMethodName = "/catalog.v1.CatalogService/CheckAvailability"
+	CatalogService_GetProduct_FullMethodName             = "/catalog.v1.CatalogService/GetProduct"
+	CatalogService_GetProductBySKU_FullMethodName        = "/catalog.v1.CatalogService/GetProductBySKU"
+	CatalogService_ListProducts_FullMethodName           = "/catalog.v1.CatalogService/ListProducts"
+	CatalogService_GetProductPriceHistory_FullMethodName = "/catalog.v1.CatalogService/GetProductPriceHistory"
+	CatalogService_CreateProduct_FullMethodName          = "/catalog.v1.CatalogService/CreateProduct"
+	CatalogService_UpdateProduct_FullMethodName          = "/catalog.v1.CatalogService/UpdateProduct"
+	CatalogService_DeleteProduct_FullMethodName          = "/catalog.v1.CatalogService/DeleteProduct"
+	CatalogService_UpdateStock_FullMethodName            = "/catalog.v1.CatalogService/UpdateStock"
+	CatalogService_CheckAvailability_FullMethodName      = "/catalog.v1.CatalogService/CheckAvailability"
+	CatalogService_ReserveIfAvailable_FullMethodName     = "/catalog.v1.CatalogService/ReserveIfAvailable"
+	CatalogService_QuoteItems_FullMethodName             = "/catalog.v1.CatalogService/QuoteItems"
+	CatalogService_ReconcileStock_FullMethodName         = "/catalog.v1.CatalogService/ReconcileStock"
+	CatalogService_FlushCache_FullMethodName             = "/catalog.v1.CatalogService/FlushCache"
 )
 
 // CatalogServiceClient is the client API for CatalogService service.
@@ -32,11 +39,19 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CatalogServiceClient interface {
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductResponse, error)
+	GetProductBySKU(ctx context.Context, in *GetProductBySKURequest, opts ...grpc.CallOption) (*GetProductBySKUResponse, error)
 	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	GetProductPriceHistory(ctx context.Context, in *GetProductPriceHistoryRequest, opts ...grpc.CallOption) (*GetProductPriceHistoryResponse, error)
 	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error)
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*UpdateProductResponse, error)
+	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error)
 	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error)
 	CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error)
+	// ReserveIfAvailable checks and reserves stock in one atomic step
+	ReserveIfAvailable(ctx context.Context, in *ReserveIfAvailableRequest, opts ...grpc.CallOption) (*ReserveIfAvailableResponse, error)
+	QuoteItems(ctx context.Context, in *QuoteItemsRequest, opts ...grpc.CallOption) (*QuoteItemsResponse, error)
+	ReconcileStock(ctx context.Context, in *ReconcileStockRequest, opts ...grpc.CallOption) (*ReconcileStockResponse, error)
+	FlushCache(ctx context.Context, in *FlushCacheRequest, opts ...grpc.CallOption) (*FlushCacheResponse, error)
 }
 
 type catalogServiceClient struct {
@@ -57,6 +72,16 @@ func (c *catalogServiceClient) GetProduct(ctx context.Context, in *GetProductReq
 	return out, nil
 }
 
+func (c *catalogServiceClient) GetProductBySKU(ctx context.Context, in *GetProductBySKURequest, opts ...grpc.CallOption) (*GetProductBySKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductBySKUResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetProductBySKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogServiceClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListProductsResponse)
@@ -67,6 +92,16 @@ func (c *catalogServiceClient) ListProducts(ctx context.Context, in *ListProduct
 	return out, nil
 }
 
+func (c *catalogServiceClient) GetProductPriceHistory(ctx context.Context, in *GetProductPriceHistoryRequest, opts ...grpc.CallOption) (*GetProductPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetProductPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogServiceClient) CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateProductResponse)
@@ -87,6 +122,16 @@ func (c *catalogServiceClient) UpdateProduct(ctx context.Context, in *UpdateProd
 	return out, nil
 }
 
+func (c *catalogServiceClient) DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProductResponse)
+	err := c.cc.Invoke(ctx, CatalogService_DeleteProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogServiceClient) UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateStockResponse)
@@ -107,16 +152,64 @@ func (c *catalogServiceClient) CheckAvailability(ctx context.Context, in *CheckA
 	return out, nil
 }
 
+func (c *catalogServiceClient) ReserveIfAvailable(ctx context.Context, in *ReserveIfAvailableRequest, opts ...grpc.CallOption) (*ReserveIfAvailableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveIfAvailableResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReserveIfAvailable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) QuoteItems(ctx context.Context, in *QuoteItemsRequest, opts ...grpc.CallOption) (*QuoteItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteItemsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_QuoteItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ReconcileStock(ctx context.Context, in *ReconcileStockRequest, opts ...grpc.CallOption) (*ReconcileStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileStockResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReconcileStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) FlushCache(ctx context.Context, in *FlushCacheRequest, opts ...grpc.CallOption) (*FlushCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlushCacheResponse)
+	err := c.cc.Invoke(ctx, CatalogService_FlushCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatalogServiceServer is the server API for CatalogService service.
 // All implementations must embed UnimplementedCatalogServiceServer
 // for forward compatibility.
 type CatalogServiceServer interface {
 	GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error)
+	GetProductBySKU(context.Context, *GetProductBySKURequest) (*GetProductBySKUResponse, error)
 	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	GetProductPriceHistory(context.Context, *GetProductPriceHistoryRequest) (*GetProductPriceHistoryResponse, error)
 	CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
 	UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
 	UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error)
 	CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error)
+	// ReserveIfAvailable checks and reserves stock in one atomic step
+	ReserveIfAvailable(context.Context, *ReserveIfAvailableRequest) (*ReserveIfAvailableResponse, error)
+	QuoteItems(context.Context, *QuoteItemsRequest) (*QuoteItemsResponse, error)
+	ReconcileStock(context.Context, *ReconcileStockRequest) (*ReconcileStockResponse, error)
+	FlushCache(context.Context, *FlushCacheRequest) (*FlushCacheResponse, error)
 	mustEmbedUnimplementedCatalogServiceServer()
 }
 
@@ -130,21 +223,42 @@ type UnimplementedCatalogServiceServer struct{}
 func (UnimplementedCatalogServiceServer) GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
 }
+func (UnimplementedCatalogServiceServer) GetProductBySKU(context.Context, *GetProductBySKURequest) (*GetProductBySKUResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductBySKU not implemented")
+}
 func (UnimplementedCatalogServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
 }
+func (UnimplementedCatalogServiceServer) GetProductPriceHistory(context.Context, *GetProductPriceHistoryRequest) (*GetProductPriceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductPriceHistory not implemented")
+}
 func (UnimplementedCatalogServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
 }
 func (UnimplementedCatalogServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
 }
+func (UnimplementedCatalogServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+}
 func (UnimplementedCatalogServiceServer) UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateStock not implemented")
 }
 func (UnimplementedCatalogServiceServer) CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckAvailability not implemented")
 }
+func (UnimplementedCatalogServiceServer) ReserveIfAvailable(context.Context, *ReserveIfAvailableRequest) (*ReserveIfAvailableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveIfAvailable not implemented")
+}
+func (UnimplementedCatalogServiceServer) QuoteItems(context.Context, *QuoteItemsRequest) (*QuoteItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteItems not implemented")
+}
+func (UnimplementedCatalogServiceServer) ReconcileStock(context.Context, *ReconcileStockRequest) (*ReconcileStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileStock not implemented")
+}
+func (UnimplementedCatalogServiceServer) FlushCache(context.Context, *FlushCacheRequest) (*FlushCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushCache not implemented")
+}
 func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
 func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
 
@@ -184,6 +298,24 @@ func _CatalogService_GetProduct_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_GetProductBySKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductBySKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetProductBySKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetProductBySKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetProductBySKU(ctx, req.(*GetProductBySKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CatalogService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListProductsRequest)
 	if err := dec(in); err != nil {
@@ -202,6 +334,24 @@ func _CatalogService_ListProducts_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_GetProductPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetProductPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetProductPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetProductPriceHistory(ctx, req.(*GetProductPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CatalogService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateProductRequest)
 	if err := dec(in); err != nil {
@@ -238,6 +388,24 @@ func _CatalogService_UpdateProduct_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeleteProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CatalogService_UpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateStockRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +442,78 @@ func _CatalogService_CheckAvailability_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_ReserveIfAvailable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveIfAvailableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReserveIfAvailable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReserveIfAvailable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReserveIfAvailable(ctx, req.(*ReserveIfAvailableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_QuoteItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).QuoteItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_QuoteItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).QuoteItems(ctx, req.(*QuoteItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ReconcileStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReconcileStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReconcileStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReconcileStock(ctx, req.(*ReconcileStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_FlushCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).FlushCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_FlushCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).FlushCache(ctx, req.(*FlushCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -285,10 +525,18 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProduct",
 			Handler:    _CatalogService_GetProduct_Handler,
 		},
+		{
+			MethodName: "GetProductBySKU",
+			Handler:    _CatalogService_GetProductBySKU_Handler,
+		},
 		{
 			MethodName: "ListProducts",
 			Handler:    _CatalogService_ListProducts_Handler,
 		},
+		{
+			MethodName: "GetProductPriceHistory",
+			Handler:    _CatalogService_GetProductPriceHistory_Handler,
+		},
 		{
 			MethodName: "CreateProduct",
 			Handler:    _CatalogService_CreateProduct_Handler,
@@ -297,6 +545,10 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateProduct",
 			Handler:    _CatalogService_UpdateProduct_Handler,
 		},
+		{
+			MethodName: "DeleteProduct",
+			Handler:    _CatalogService_DeleteProduct_Handler,
+		},
 		{
 			MethodName: "UpdateStock",
 			Handler:    _CatalogService_UpdateStock_Handler,
@@ -305,6 +557,22 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckAvailability",
 			Handler:    _CatalogService_CheckAvailability_Handler,
 		},
+		{
+			MethodName: "ReserveIfAvailable",
+			Handler:    _CatalogService_ReserveIfAvailable_Handler,
+		},
+		{
+			MethodName: "QuoteItems",
+			Handler:    _CatalogService_QuoteItems_Handler,
+		},
+		{
+			MethodName: "ReconcileStock",
+			Handler:    _CatalogService_ReconcileStock_Handler,
+		},
+		{
+			MethodName: "FlushCache",
+			Handler:    _CatalogService_FlushCache_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/catalog/v1/catalog.proto",
@@ -547,6 +547,110 @@ func (x *CommitStockResponse) GetSuccess() bool {
 	return false
 }
 
+type CommitStockPartialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	Items         []*ReservationRequest  `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"` // Quantities to commit; the rest stays reserved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitStockPartialRequest) Reset() {
+	*x = CommitStockPartialRequest{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitStockPartialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitStockPartialRequest) ProtoMessage() {}
+
+func (x *CommitStockPartialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitStockPartialRequest.ProtoReflect.Descriptor instead.
+func (*CommitStockPartialRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CommitStockPartialRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CommitStockPartialRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CommitStockPartialRequest) GetItems() []*ReservationRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CommitStockPartialResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitStockPartialResponse) Reset() {
+	*x = CommitStockPartialResponse{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitStockPartialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitStockPartialResponse) ProtoMessage() {}
+
+func (x *CommitStockPartialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitStockPartialResponse.ProtoReflect.Descriptor instead.
+func (*CommitStockPartialResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CommitStockPartialResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 type GetInventoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
@@ -555,21 +659,659 @@ type GetInventoryRequest struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInventoryRequest) Reset() {
-	*x = GetInventoryRequest{}
-	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[9]
+func (x *GetInventoryRequest) Reset() {
+	*x = GetInventoryRequest{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryRequest) ProtoMessage() {}
+
+func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
+func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetInventoryRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetInventoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type GetInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inventory     *Inventory             `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoryResponse) Reset() {
+	*x = GetInventoryResponse{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryResponse) ProtoMessage() {}
+
+func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryResponse.ProtoReflect.Descriptor instead.
+func (*GetInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetInventoryResponse) GetInventory() *Inventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+type AdjustInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityDelta int32                  `protobuf:"varint,3,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"` // Can be positive or negative
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                     // Restock, damage, etc.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustInventoryRequest) Reset() {
+	*x = AdjustInventoryRequest{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustInventoryRequest) ProtoMessage() {}
+
+func (x *AdjustInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustInventoryRequest.ProtoReflect.Descriptor instead.
+func (*AdjustInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AdjustInventoryRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AdjustInventoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AdjustInventoryRequest) GetQuantityDelta() int32 {
+	if x != nil {
+		return x.QuantityDelta
+	}
+	return 0
+}
+
+func (x *AdjustInventoryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AdjustInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inventory     *Inventory             `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustInventoryResponse) Reset() {
+	*x = AdjustInventoryResponse{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustInventoryResponse) ProtoMessage() {}
+
+func (x *AdjustInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustInventoryResponse.ProtoReflect.Descriptor instead.
+func (*AdjustInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AdjustInventoryResponse) GetInventory() *Inventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+type InventoryAdjustment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityDelta int32                  `protobuf:"varint,2,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"` // Can be positive or negative
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryAdjustment) Reset() {
+	*x = InventoryAdjustment{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryAdjustment) ProtoMessage() {}
+
+func (x *InventoryAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryAdjustment.ProtoReflect.Descriptor instead.
+func (*InventoryAdjustment) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *InventoryAdjustment) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *InventoryAdjustment) GetQuantityDelta() int32 {
+	if x != nil {
+		return x.QuantityDelta
+	}
+	return 0
+}
+
+type BulkAdjustInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Adjustments   []*InventoryAdjustment `protobuf:"bytes,2,rep,name=adjustments,proto3" json:"adjustments,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`    // Restock, damage, etc.
+	Partial       bool                   `protobuf:"varint,4,opt,name=partial,proto3" json:"partial,omitempty"` // Apply the adjustments that succeed instead of rolling back all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkAdjustInventoryRequest) Reset() {
+	*x = BulkAdjustInventoryRequest{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkAdjustInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAdjustInventoryRequest) ProtoMessage() {}
+
+func (x *BulkAdjustInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAdjustInventoryRequest.ProtoReflect.Descriptor instead.
+func (*BulkAdjustInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BulkAdjustInventoryRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BulkAdjustInventoryRequest) GetAdjustments() []*InventoryAdjustment {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+func (x *BulkAdjustInventoryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BulkAdjustInventoryRequest) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+type AdjustmentResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Inventory     *Inventory             `protobuf:"bytes,2,opt,name=inventory,proto3" json:"inventory,omitempty"` // Unset if the adjustment failed
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`         // Only in partial mode
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustmentResult) Reset() {
+	*x = AdjustmentResult{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustmentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustmentResult) ProtoMessage() {}
+
+func (x *AdjustmentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustmentResult.ProtoReflect.Descriptor instead.
+func (*AdjustmentResult) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AdjustmentResult) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AdjustmentResult) GetInventory() *Inventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *AdjustmentResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BulkAdjustInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*AdjustmentResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // In request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkAdjustInventoryResponse) Reset() {
+	*x = BulkAdjustInventoryResponse{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkAdjustInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAdjustInventoryResponse) ProtoMessage() {}
+
+func (x *BulkAdjustInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAdjustInventoryResponse.ProtoReflect.Descriptor instead.
+func (*BulkAdjustInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BulkAdjustInventoryResponse) GetResults() []*AdjustmentResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type Reservation struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReservationId     string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	ProductId         string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity          int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // active, committed, released
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CommittedQuantity int32                  `protobuf:"varint,9,opt,name=committed_quantity,json=committedQuantity,proto3" json:"committed_quantity,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Reservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reservation) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *Reservation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Reservation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Reservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCommittedQuantity() int32 {
+	if x != nil {
+		return x.CommittedQuantity
+	}
+	return 0
+}
+
+type GetReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReservationRequest) Reset() {
+	*x = GetReservationRequest{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReservationRequest) ProtoMessage() {}
+
+func (x *GetReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReservationRequest.ProtoReflect.Descriptor instead.
+func (*GetReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetReservationRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type GetReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Reservation         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // One per reserved product
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReservationResponse) Reset() {
+	*x = GetReservationResponse{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReservationResponse) ProtoMessage() {}
+
+func (x *GetReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReservationResponse.ProtoReflect.Descriptor instead.
+func (*GetReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetReservationResponse) GetItems() []*Reservation {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ListReservationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // Optional: active, expired, committed, released
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReservationsRequest) Reset() {
+	*x = ListReservationsRequest{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInventoryRequest) String() string {
+func (x *ListReservationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryRequest) ProtoMessage() {}
+func (*ListReservationsRequest) ProtoMessage() {}
 
-func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[9]
+func (x *ListReservationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,47 +1322,62 @@ func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
-func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListReservationsRequest.ProtoReflect.Descriptor instead.
+func (*ListReservationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *GetInventoryRequest) GetMetadata() *v1.RequestMetadata {
+func (x *ListReservationsRequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *GetInventoryRequest) GetProductId() string {
+func (x *ListReservationsRequest) GetProductId() string {
 	if x != nil {
 		return x.ProductId
 	}
 	return ""
 }
 
-type GetInventoryResponse struct {
+func (x *ListReservationsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListReservationsRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListReservationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inventory     *Inventory             `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	Reservations  []*Reservation         `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInventoryResponse) Reset() {
-	*x = GetInventoryResponse{}
-	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[10]
+func (x *ListReservationsResponse) Reset() {
+	*x = ListReservationsResponse{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInventoryResponse) String() string {
+func (x *ListReservationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryResponse) ProtoMessage() {}
+func (*ListReservationsResponse) ProtoMessage() {}
 
-func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[10]
+func (x *ListReservationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -631,43 +1388,51 @@ func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryResponse.ProtoReflect.Descriptor instead.
-func (*GetInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListReservationsResponse.ProtoReflect.Descriptor instead.
+func (*ListReservationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *GetInventoryResponse) GetInventory() *Inventory {
+func (x *ListReservationsResponse) GetReservations() []*Reservation {
 	if x != nil {
-		return x.Inventory
+		return x.Reservations
 	}
 	return nil
 }
 
-type AdjustInventoryRequest struct {
+func (x *ListReservationsResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetInventoryHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	QuantityDelta int32                  `protobuf:"varint,3,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"` // Can be positive or negative
-	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                     // Restock, damage, etc.
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"` // Inclusive, optional
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`     // Exclusive, optional
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdjustInventoryRequest) Reset() {
-	*x = AdjustInventoryRequest{}
-	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[11]
+func (x *GetInventoryHistoryRequest) Reset() {
+	*x = GetInventoryHistoryRequest{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdjustInventoryRequest) String() string {
+func (x *GetInventoryHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdjustInventoryRequest) ProtoMessage() {}
+func (*GetInventoryHistoryRequest) ProtoMessage() {}
 
-func (x *AdjustInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[11]
+func (x *GetInventoryHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,61 +1443,248 @@ func (x *AdjustInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdjustInventoryRequest.ProtoReflect.Descriptor instead.
-func (*AdjustInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetInventoryHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetInventoryHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *AdjustInventoryRequest) GetMetadata() *v1.RequestMetadata {
+func (x *GetInventoryHistoryRequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *AdjustInventoryRequest) GetProductId() string {
+func (x *GetInventoryHistoryRequest) GetProductId() string {
 	if x != nil {
 		return x.ProductId
 	}
 	return ""
 }
 
-func (x *AdjustInventoryRequest) GetQuantityDelta() int32 {
+func (x *GetInventoryHistoryRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.QuantityDelta
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetInventoryHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetInventoryHistoryRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type InventoryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`           // adjustment, reserved, committed, released, expired
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`  // Signed delta for adjustments
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"` // Reservation ID, or batch ID of a bulk adjustment
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`       // Adjustment reason
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryEvent) Reset() {
+	*x = InventoryEvent{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryEvent) ProtoMessage() {}
+
+func (x *InventoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryEvent.ProtoReflect.Descriptor instead.
+func (*InventoryEvent) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *InventoryEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *InventoryEvent) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
 	}
 	return 0
 }
 
-func (x *AdjustInventoryRequest) GetReason() string {
+func (x *InventoryEvent) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *InventoryEvent) GetReason() string {
 	if x != nil {
 		return x.Reason
 	}
 	return ""
 }
 
-type AdjustInventoryResponse struct {
+func (x *InventoryEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type GetInventoryHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*InventoryEvent      `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoryHistoryResponse) Reset() {
+	*x = GetInventoryHistoryResponse{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryHistoryResponse) ProtoMessage() {}
+
+func (x *GetInventoryHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetInventoryHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetInventoryHistoryResponse) GetEvents() []*InventoryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetInventoryHistoryResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type WatchInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ProductIds    []string               `protobuf:"bytes,2,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchInventoryRequest) Reset() {
+	*x = WatchInventoryRequest{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInventoryRequest) ProtoMessage() {}
+
+func (x *WatchInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInventoryRequest.ProtoReflect.Descriptor instead.
+func (*WatchInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WatchInventoryRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *WatchInventoryRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+type WatchInventoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Inventory     *Inventory             `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdjustInventoryResponse) Reset() {
-	*x = AdjustInventoryResponse{}
-	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[12]
+func (x *WatchInventoryResponse) Reset() {
+	*x = WatchInventoryResponse{}
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdjustInventoryResponse) String() string {
+func (x *WatchInventoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdjustInventoryResponse) ProtoMessage() {}
+func (*WatchInventoryResponse) ProtoMessage() {}
 
-func (x *AdjustInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[12]
+func (x *WatchInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_v1_inventory_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -743,12 +1695,12 @@ func (x *AdjustInventoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdjustInventoryResponse.ProtoReflect.Descriptor instead.
-func (*AdjustInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use WatchInventoryResponse.ProtoReflect.Descriptor instead.
+func (*WatchInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_v1_inventory_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *AdjustInventoryResponse) GetInventory() *Inventory {
+func (x *WatchInventoryResponse) GetInventory() *Inventory {
 	if x != nil {
 		return x.Inventory
 	}
@@ -798,6 +1750,12 @@ const file_proto_inventory_v1_inventory_proto_rawDesc = "" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12%\n" +
 	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\"/\n" +
 	"\x13CommitStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb2\x01\n" +
+	"\x19CommitStockPartialRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x126\n" +
+	"\x05items\x18\x03 \x03(\v2 .inventory.v1.ReservationRequestR\x05items\"6\n" +
+	"\x1aCommitStockPartialResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"l\n" +
 	"\x13GetInventoryRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
@@ -812,13 +1770,94 @@ const file_proto_inventory_v1_inventory_proto_rawDesc = "" +
 	"\x0equantity_delta\x18\x03 \x01(\x05R\rquantityDelta\x12\x16\n" +
 	"\x06reason\x18\x04 \x01(\tR\x06reason\"P\n" +
 	"\x17AdjustInventoryResponse\x125\n" +
-	"\tinventory\x18\x01 \x01(\v2\x17.inventory.v1.InventoryR\tinventory2\xcb\x03\n" +
+	"\tinventory\x18\x01 \x01(\v2\x17.inventory.v1.InventoryR\tinventory\"[\n" +
+	"\x13InventoryAdjustment\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12%\n" +
+	"\x0equantity_delta\x18\x02 \x01(\x05R\rquantityDelta\"\xcb\x01\n" +
+	"\x1aBulkAdjustInventoryRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12C\n" +
+	"\vadjustments\x18\x02 \x03(\v2!.inventory.v1.InventoryAdjustmentR\vadjustments\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\apartial\x18\x04 \x01(\bR\apartial\"~\n" +
+	"\x10AdjustmentResult\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x125\n" +
+	"\tinventory\x18\x02 \x01(\v2\x17.inventory.v1.InventoryR\tinventory\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"W\n" +
+	"\x1bBulkAdjustInventoryResponse\x128\n" +
+	"\aresults\x18\x01 \x03(\v2\x1e.inventory.v1.AdjustmentResultR\aresults\"\xf7\x02\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12-\n" +
+	"\x12committed_quantity\x18\t \x01(\x05R\x11committedQuantity\"v\n" +
+	"\x15GetReservationRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\"I\n" +
+	"\x16GetReservationResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.inventory.v1.ReservationR\x05items\"\xc6\x01\n" +
+	"\x17ListReservationsRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12<\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
+	"pagination\"\x98\x01\n" +
+	"\x18ListReservationsResponse\x12=\n" +
+	"\freservations\x18\x01 \x03(\v2\x19.inventory.v1.ReservationR\freservations\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"pagination\"\x8d\x02\n" +
+	"\x1aGetInventoryHistoryRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12<\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
+	"pagination\"\xb3\x01\n" +
+	"\x0eInventoryEvent\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x92\x01\n" +
+	"\x1bGetInventoryHistoryResponse\x124\n" +
+	"\x06events\x18\x01 \x03(\v2\x1c.inventory.v1.InventoryEventR\x06events\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"pagination\"p\n" +
+	"\x15WatchInventoryRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1f\n" +
+	"\vproduct_ids\x18\x02 \x03(\tR\n" +
+	"productIds\"O\n" +
+	"\x16WatchInventoryResponse\x125\n" +
+	"\tinventory\x18\x01 \x01(\v2\x17.inventory.v1.InventoryR\tinventory2\xab\b\n" +
 	"\x10InventoryService\x12U\n" +
 	"\fReserveStock\x12!.inventory.v1.ReserveStockRequest\x1a\".inventory.v1.ReserveStockResponse\x12U\n" +
 	"\fReleaseStock\x12!.inventory.v1.ReleaseStockRequest\x1a\".inventory.v1.ReleaseStockResponse\x12R\n" +
-	"\vCommitStock\x12 .inventory.v1.CommitStockRequest\x1a!.inventory.v1.CommitStockResponse\x12U\n" +
+	"\vCommitStock\x12 .inventory.v1.CommitStockRequest\x1a!.inventory.v1.CommitStockResponse\x12g\n" +
+	"\x12CommitStockPartial\x12'.inventory.v1.CommitStockPartialRequest\x1a(.inventory.v1.CommitStockPartialResponse\x12U\n" +
 	"\fGetInventory\x12!.inventory.v1.GetInventoryRequest\x1a\".inventory.v1.GetInventoryResponse\x12^\n" +
-	"\x0fAdjustInventory\x12$.inventory.v1.AdjustInventoryRequest\x1a%.inventory.v1.AdjustInventoryResponseB:Z8github.com/mumumio1/coldy/proto/inventory/v1;inventoryv1b\x06proto3"
+	"\x0fAdjustInventory\x12$.inventory.v1.AdjustInventoryRequest\x1a%.inventory.v1.AdjustInventoryResponse\x12j\n" +
+	"\x13BulkAdjustInventory\x12(.inventory.v1.BulkAdjustInventoryRequest\x1a).inventory.v1.BulkAdjustInventoryResponse\x12[\n" +
+	"\x0eGetReservation\x12#.inventory.v1.GetReservationRequest\x1a$.inventory.v1.GetReservationResponse\x12a\n" +
+	"\x10ListReservations\x12%.inventory.v1.ListReservationsRequest\x1a&.inventory.v1.ListReservationsResponse\x12j\n" +
+	"\x13GetInventoryHistory\x12(.inventory.v1.GetInventoryHistoryRequest\x1a).inventory.v1.GetInventoryHistoryResponse\x12]\n" +
+	"\x0eWatchInventory\x12#.inventory.v1.WatchInventoryRequest\x1a$.inventory.v1.WatchInventoryResponse0\x01B:Z8github.com/mumumio1/coldy/proto/inventory/v1;inventoryv1b\x06proto3"
 
 var (
 	file_proto_inventory_v1_inventory_proto_rawDescOnce sync.Once
@@ -832,50 +1871,104 @@ func file_proto_inventory_v1_inventory_proto_rawDescGZIP() []byte {
 	return file_proto_inventory_v1_inventory_proto_rawDescData
 }
 
-var file_proto_inventory_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_inventory_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_proto_inventory_v1_inventory_proto_goTypes = []any{
-	(*Inventory)(nil),               // 0: inventory.v1.Inventory
-	(*ReservationRequest)(nil),      // 1: inventory.v1.ReservationRequest
-	(*ReserveStockRequest)(nil),     // 2: inventory.v1.ReserveStockRequest
-	(*ReserveStockResponse)(nil),    // 3: inventory.v1.ReserveStockResponse
-	(*ReservationFailure)(nil),      // 4: inventory.v1.ReservationFailure
-	(*ReleaseStockRequest)(nil),     // 5: inventory.v1.ReleaseStockRequest
-	(*ReleaseStockResponse)(nil),    // 6: inventory.v1.ReleaseStockResponse
-	(*CommitStockRequest)(nil),      // 7: inventory.v1.CommitStockRequest
-	(*CommitStockResponse)(nil),     // 8: inventory.v1.CommitStockResponse
-	(*GetInventoryRequest)(nil),     // 9: inventory.v1.GetInventoryRequest
-	(*GetInventoryResponse)(nil),    // 10: inventory.v1.GetInventoryResponse
-	(*AdjustInventoryRequest)(nil),  // 11: inventory.v1.AdjustInventoryRequest
-	(*AdjustInventoryResponse)(nil), // 12: inventory.v1.AdjustInventoryResponse
-	(*timestamppb.Timestamp)(nil),   // 13: google.protobuf.Timestamp
-	(*v1.RequestMetadata)(nil),      // 14: common.v1.RequestMetadata
+	(*Inventory)(nil),                   // 0: inventory.v1.Inventory
+	(*ReservationRequest)(nil),          // 1: inventory.v1.ReservationRequest
+	(*ReserveStockRequest)(nil),         // 2: inventory.v1.ReserveStockRequest
+	(*ReserveStockResponse)(nil),        // 3: inventory.v1.ReserveStockResponse
+	(*ReservationFailure)(nil),          // 4: inventory.v1.ReservationFailure
+	(*ReleaseStockRequest)(nil),         // 5: inventory.v1.ReleaseStockRequest
+	(*ReleaseStockResponse)(nil),        // 6: inventory.v1.ReleaseStockResponse
+	(*CommitStockRequest)(nil),          // 7: inventory.v1.CommitStockRequest
+	(*CommitStockResponse)(nil),         // 8: inventory.v1.CommitStockResponse
+	(*CommitStockPartialRequest)(nil),   // 9: inventory.v1.CommitStockPartialRequest
+	(*CommitStockPartialResponse)(nil),  // 10: inventory.v1.CommitStockPartialResponse
+	(*GetInventoryRequest)(nil),         // 11: inventory.v1.GetInventoryRequest
+	(*GetInventoryResponse)(nil),        // 12: inventory.v1.GetInventoryResponse
+	(*AdjustInventoryRequest)(nil),      // 13: inventory.v1.AdjustInventoryRequest
+	(*AdjustInventoryResponse)(nil),     // 14: inventory.v1.AdjustInventoryResponse
+	(*InventoryAdjustment)(nil),         // 15: inventory.v1.InventoryAdjustment
+	(*BulkAdjustInventoryRequest)(nil),  // 16: inventory.v1.BulkAdjustInventoryRequest
+	(*AdjustmentResult)(nil),            // 17: inventory.v1.AdjustmentResult
+	(*BulkAdjustInventoryResponse)(nil), // 18: inventory.v1.BulkAdjustInventoryResponse
+	(*Reservation)(nil),                 // 19: inventory.v1.Reservation
+	(*GetReservationRequest)(nil),       // 20: inventory.v1.GetReservationRequest
+	(*GetReservationResponse)(nil),      // 21: inventory.v1.GetReservationResponse
+	(*ListReservationsRequest)(nil),     // 22: inventory.v1.ListReservationsRequest
+	(*ListReservationsResponse)(nil),    // 23: inventory.v1.ListReservationsResponse
+	(*GetInventoryHistoryRequest)(nil),  // 24: inventory.v1.GetInventoryHistoryRequest
+	(*InventoryEvent)(nil),              // 25: inventory.v1.InventoryEvent
+	(*GetInventoryHistoryResponse)(nil), // 26: inventory.v1.GetInventoryHistoryResponse
+	(*WatchInventoryRequest)(nil),       // 27: inventory.v1.WatchInventoryRequest
+	(*WatchInventoryResponse)(nil),      // 28: inventory.v1.WatchInventoryResponse
+	(*timestamppb.Timestamp)(nil),       // 29: google.protobuf.Timestamp
+	(*v1.RequestMetadata)(nil),          // 30: common.v1.RequestMetadata
+	(*v1.PaginationRequest)(nil),        // 31: common.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),       // 32: common.v1.PaginationResponse
 }
 var file_proto_inventory_v1_inventory_proto_depIdxs = []int32{
-	13, // 0: inventory.v1.Inventory.updated_at:type_name -> google.protobuf.Timestamp
-	14, // 1: inventory.v1.ReserveStockRequest.metadata:type_name -> common.v1.RequestMetadata
+	29, // 0: inventory.v1.Inventory.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 1: inventory.v1.ReserveStockRequest.metadata:type_name -> common.v1.RequestMetadata
 	1,  // 2: inventory.v1.ReserveStockRequest.items:type_name -> inventory.v1.ReservationRequest
 	4,  // 3: inventory.v1.ReserveStockResponse.failures:type_name -> inventory.v1.ReservationFailure
-	14, // 4: inventory.v1.ReleaseStockRequest.metadata:type_name -> common.v1.RequestMetadata
-	14, // 5: inventory.v1.CommitStockRequest.metadata:type_name -> common.v1.RequestMetadata
-	14, // 6: inventory.v1.GetInventoryRequest.metadata:type_name -> common.v1.RequestMetadata
-	0,  // 7: inventory.v1.GetInventoryResponse.inventory:type_name -> inventory.v1.Inventory
-	14, // 8: inventory.v1.AdjustInventoryRequest.metadata:type_name -> common.v1.RequestMetadata
-	0,  // 9: inventory.v1.AdjustInventoryResponse.inventory:type_name -> inventory.v1.Inventory
-	2,  // 10: inventory.v1.InventoryService.ReserveStock:input_type -> inventory.v1.ReserveStockRequest
-	5,  // 11: inventory.v1.InventoryService.ReleaseStock:input_type -> inventory.v1.ReleaseStockRequest
-	7,  // 12: inventory.v1.InventoryService.CommitStock:input_type -> inventory.v1.CommitStockRequest
-	9,  // 13: inventory.v1.InventoryService.GetInventory:input_type -> inventory.v1.GetInventoryRequest
-	11, // 14: inventory.v1.InventoryService.AdjustInventory:input_type -> inventory.v1.AdjustInventoryRequest
-	3,  // 15: inventory.v1.InventoryService.ReserveStock:output_type -> inventory.v1.ReserveStockResponse
-	6,  // 16: inventory.v1.InventoryService.ReleaseStock:output_type -> inventory.v1.ReleaseStockResponse
-	8,  // 17: inventory.v1.InventoryService.CommitStock:output_type -> inventory.v1.CommitStockResponse
-	10, // 18: inventory.v1.InventoryService.GetInventory:output_type -> inventory.v1.GetInventoryResponse
-	12, // 19: inventory.v1.InventoryService.AdjustInventory:output_type -> inventory.v1.AdjustInventoryResponse
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	30, // 4: inventory.v1.ReleaseStockRequest.metadata:type_name -> common.v1.RequestMetadata
+	30, // 5: inventory.v1.CommitStockRequest.metadata:type_name -> common.v1.RequestMetadata
+	30, // 6: inventory.v1.CommitStockPartialRequest.metadata:type_name -> common.v1.RequestMetadata
+	1,  // 7: inventory.v1.CommitStockPartialRequest.items:type_name -> inventory.v1.ReservationRequest
+	30, // 8: inventory.v1.GetInventoryRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 9: inventory.v1.GetInventoryResponse.inventory:type_name -> inventory.v1.Inventory
+	30, // 10: inventory.v1.AdjustInventoryRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 11: inventory.v1.AdjustInventoryResponse.inventory:type_name -> inventory.v1.Inventory
+	30, // 12: inventory.v1.BulkAdjustInventoryRequest.metadata:type_name -> common.v1.RequestMetadata
+	15, // 13: inventory.v1.BulkAdjustInventoryRequest.adjustments:type_name -> inventory.v1.InventoryAdjustment
+	0,  // 14: inventory.v1.AdjustmentResult.inventory:type_name -> inventory.v1.Inventory
+	17, // 15: inventory.v1.BulkAdjustInventoryResponse.results:type_name -> inventory.v1.AdjustmentResult
+	29, // 16: inventory.v1.Reservation.expires_at:type_name -> google.protobuf.Timestamp
+	29, // 17: inventory.v1.Reservation.created_at:type_name -> google.protobuf.Timestamp
+	29, // 18: inventory.v1.Reservation.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 19: inventory.v1.GetReservationRequest.metadata:type_name -> common.v1.RequestMetadata
+	19, // 20: inventory.v1.GetReservationResponse.items:type_name -> inventory.v1.Reservation
+	30, // 21: inventory.v1.ListReservationsRequest.metadata:type_name -> common.v1.RequestMetadata
+	31, // 22: inventory.v1.ListReservationsRequest.pagination:type_name -> common.v1.PaginationRequest
+	19, // 23: inventory.v1.ListReservationsResponse.reservations:type_name -> inventory.v1.Reservation
+	32, // 24: inventory.v1.ListReservationsResponse.pagination:type_name -> common.v1.PaginationResponse
+	30, // 25: inventory.v1.GetInventoryHistoryRequest.metadata:type_name -> common.v1.RequestMetadata
+	29, // 26: inventory.v1.GetInventoryHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	29, // 27: inventory.v1.GetInventoryHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	31, // 28: inventory.v1.GetInventoryHistoryRequest.pagination:type_name -> common.v1.PaginationRequest
+	29, // 29: inventory.v1.InventoryEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	25, // 30: inventory.v1.GetInventoryHistoryResponse.events:type_name -> inventory.v1.InventoryEvent
+	32, // 31: inventory.v1.GetInventoryHistoryResponse.pagination:type_name -> common.v1.PaginationResponse
+	30, // 32: inventory.v1.WatchInventoryRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 33: inventory.v1.WatchInventoryResponse.inventory:type_name -> inventory.v1.Inventory
+	2,  // 34: inventory.v1.InventoryService.ReserveStock:input_type -> inventory.v1.ReserveStockRequest
+	5,  // 35: inventory.v1.InventoryService.ReleaseStock:input_type -> inventory.v1.ReleaseStockRequest
+	7,  // 36: inventory.v1.InventoryService.CommitStock:input_type -> inventory.v1.CommitStockRequest
+	9,  // 37: inventory.v1.InventoryService.CommitStockPartial:input_type -> inventory.v1.CommitStockPartialRequest
+	11, // 38: inventory.v1.InventoryService.GetInventory:input_type -> inventory.v1.GetInventoryRequest
+	13, // 39: inventory.v1.InventoryService.AdjustInventory:input_type -> inventory.v1.AdjustInventoryRequest
+	16, // 40: inventory.v1.InventoryService.BulkAdjustInventory:input_type -> inventory.v1.BulkAdjustInventoryRequest
+	20, // 41: inventory.v1.InventoryService.GetReservation:input_type -> inventory.v1.GetReservationRequest
+	22, // 42: inventory.v1.InventoryService.ListReservations:input_type -> inventory.v1.ListReservationsRequest
+	24, // 43: inventory.v1.InventoryService.GetInventoryHistory:input_type -> inventory.v1.GetInventoryHistoryRequest
+	27, // 44: inventory.v1.InventoryService.WatchInventory:input_type -> inventory.v1.WatchInventoryRequest
+	3,  // 45: inventory.v1.InventoryService.ReserveStock:output_type -> inventory.v1.ReserveStockResponse
+	6,  // 46: inventory.v1.InventoryService.ReleaseStock:output_type -> inventory.v1.ReleaseStockResponse
+	8,  // 47: inventory.v1.InventoryService.CommitStock:output_type -> inventory.v1.CommitStockResponse
+	10, // 48: inventory.v1.InventoryService.CommitStockPartial:output_type -> inventory.v1.CommitStockPartialResponse
+	12, // 49: inventory.v1.InventoryService.GetInventory:output_type -> inventory.v1.GetInventoryResponse
+	14, // 50: inventory.v1.InventoryService.AdjustInventory:output_type -> inventory.v1.AdjustInventoryResponse
+	18, // 51: inventory.v1.InventoryService.BulkAdjustInventory:output_type -> inventory.v1.BulkAdjustInventoryResponse
+	21, // 52: inventory.v1.InventoryService.GetReservation:output_type -> inventory.v1.GetReservationResponse
+	23, // 53: inventory.v1.InventoryService.ListReservations:output_type -> inventory.v1.ListReservationsResponse
+	26, // 54: inventory.v1.InventoryService.GetInventoryHistory:output_type -> inventory.v1.GetInventoryHistoryResponse
+	28, // 55: inventory.v1.InventoryService.WatchInventory:output_type -> inventory.v1.WatchInventoryResponse
+	45, // [45:56] is the sub-list for method output_type
+	34, // [34:45] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_proto_inventory_v1_inventory_proto_init() }
@@ -889,7 +1982,7 @@ func file_proto_inventory_v1_inventory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_inventory_v1_inventory_proto_rawDesc), len(file_proto_inventory_v1_inventory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
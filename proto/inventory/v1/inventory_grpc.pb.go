@@ -19,11 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryService_ReserveStock_FullMethodName    = "/inventory.v1.InventoryService/ReserveStock"
-	InventoryService_ReleaseStock_FullMethodName    = "/inventory.v1.InventoryService/ReleaseStock"
-	InventoryService_CommitStock_FullMethodName     = "/inventory.v1.InventoryService/CommitStock"
-	InventoryService_GetInventory_FullMethodName    = "/inventory.v1.InventoryService/GetInventory"
-	InventoryService_AdjustInventory_FullMethodName = "/inventory.v1.InventoryService/AdjustInventory"
+	InventoryService_ReserveStock_FullMethodName        = "/inventory.v1.InventoryService/ReserveStock"
+	InventoryService_ReleaseStock_FullMethodName        = "/inventory.v1.InventoryService/ReleaseStock"
+	InventoryService_CommitStock_FullMethodName         = "/inventory.v1.InventoryService/CommitStock"
+	InventoryService_CommitStockPartial_FullMethodName  = "/inventory.v1.InventoryService/CommitStockPartial"
+	InventoryService_GetInventory_FullMethodName        = "/inventory.v1.InventoryService/GetInventory"
+	InventoryService_AdjustInventory_FullMethodName     = "/inventory.v1.InventoryService/AdjustInventory"
+	InventoryService_BulkAdjustInventory_FullMethodName = "/inventory.v1.InventoryService/BulkAdjustInventory"
+	InventoryService_GetReservation_FullMethodName      = "/inventory.v1.InventoryService/GetReservation"
+	InventoryService_ListReservations_FullMethodName    = "/inventory.v1.InventoryService/ListReservations"
+	InventoryService_GetInventoryHistory_FullMethodName = "/inventory.v1.InventoryService/GetInventoryHistory"
+	InventoryService_WatchInventory_FullMethodName      = "/inventory.v1.InventoryService/WatchInventory"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -33,8 +39,18 @@ type InventoryServiceClient interface {
 	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
 	ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*ReleaseStockResponse, error)
 	CommitStock(ctx context.Context, in *CommitStockRequest, opts ...grpc.CallOption) (*CommitStockResponse, error)
+	CommitStockPartial(ctx context.Context, in *CommitStockPartialRequest, opts ...grpc.CallOption) (*CommitStockPartialResponse, error)
 	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryResponse, error)
 	AdjustInventory(ctx context.Context, in *AdjustInventoryRequest, opts ...grpc.CallOption) (*AdjustInventoryResponse, error)
+	// BulkAdjustInventory applies several adjustments in one transaction
+	BulkAdjustInventory(ctx context.Context, in *BulkAdjustInventoryRequest, opts ...grpc.CallOption) (*BulkAdjustInventoryResponse, error)
+	GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*GetReservationResponse, error)
+	ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error)
+	// GetInventoryHistory merges ledger adjustments and reservation events, oldest first
+	GetInventoryHistory(ctx context.Context, in *GetInventoryHistoryRequest, opts ...grpc.CallOption) (*GetInventoryHistoryResponse, error)
+	// WatchInventory sends the current inventory of each product, then the
+	// latest inventory whenever it changes
+	WatchInventory(ctx context.Context, in *WatchInventoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchInventoryResponse], error)
 }
 
 type inventoryServiceClient struct {
@@ -75,6 +91,16 @@ func (c *inventoryServiceClient) CommitStock(ctx context.Context, in *CommitStoc
 	return out, nil
 }
 
+func (c *inventoryServiceClient) CommitStockPartial(ctx context.Context, in *CommitStockPartialRequest, opts ...grpc.CallOption) (*CommitStockPartialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitStockPartialResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CommitStockPartial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryServiceClient) GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetInventoryResponse)
@@ -95,6 +121,65 @@ func (c *inventoryServiceClient) AdjustInventory(ctx context.Context, in *Adjust
 	return out, nil
 }
 
+func (c *inventoryServiceClient) BulkAdjustInventory(ctx context.Context, in *BulkAdjustInventoryRequest, opts ...grpc.CallOption) (*BulkAdjustInventoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkAdjustInventoryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_BulkAdjustInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*GetReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReservationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReservationsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListReservations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetInventoryHistory(ctx context.Context, in *GetInventoryHistoryRequest, opts ...grpc.CallOption) (*GetInventoryHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInventoryHistoryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetInventoryHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) WatchInventory(ctx context.Context, in *WatchInventoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchInventoryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[0], InventoryService_WatchInventory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchInventoryRequest, WatchInventoryResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchInventoryClient = grpc.ServerStreamingClient[WatchInventoryResponse]
+
 // InventoryServiceServer is the server API for InventoryService service.
 // All implementations must embed UnimplementedInventoryServiceServer
 // for forward compatibility.
@@ -102,8 +187,18 @@ type InventoryServiceServer interface {
 	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
 	ReleaseStock(context.Context, *ReleaseStockRequest) (*ReleaseStockResponse, error)
 	CommitStock(context.Context, *CommitStockRequest) (*CommitStockResponse, error)
+	CommitStockPartial(context.Context, *CommitStockPartialRequest) (*CommitStockPartialResponse, error)
 	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error)
 	AdjustInventory(context.Context, *AdjustInventoryRequest) (*AdjustInventoryResponse, error)
+	// BulkAdjustInventory applies several adjustments in one transaction
+	BulkAdjustInventory(context.Context, *BulkAdjustInventoryRequest) (*BulkAdjustInventoryResponse, error)
+	GetReservation(context.Context, *GetReservationRequest) (*GetReservationResponse, error)
+	ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+	// GetInventoryHistory merges ledger adjustments and reservation events, oldest first
+	GetInventoryHistory(context.Context, *GetInventoryHistoryRequest) (*GetInventoryHistoryResponse, error)
+	// WatchInventory sends the current inventory of each product, then the
+	// latest inventory whenever it changes
+	WatchInventory(*WatchInventoryRequest, grpc.ServerStreamingServer[WatchInventoryResponse]) error
 	mustEmbedUnimplementedInventoryServiceServer()
 }
 
@@ -123,12 +218,30 @@ func (UnimplementedInventoryServiceServer) ReleaseStock(context.Context, *Releas
 func (UnimplementedInventoryServiceServer) CommitStock(context.Context, *CommitStockRequest) (*CommitStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CommitStock not implemented")
 }
+func (UnimplementedInventoryServiceServer) CommitStockPartial(context.Context, *CommitStockPartialRequest) (*CommitStockPartialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitStockPartial not implemented")
+}
 func (UnimplementedInventoryServiceServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
 }
 func (UnimplementedInventoryServiceServer) AdjustInventory(context.Context, *AdjustInventoryRequest) (*AdjustInventoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AdjustInventory not implemented")
 }
+func (UnimplementedInventoryServiceServer) BulkAdjustInventory(context.Context, *BulkAdjustInventoryRequest) (*BulkAdjustInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkAdjustInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetReservation(context.Context, *GetReservationRequest) (*GetReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReservation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetInventoryHistory(context.Context, *GetInventoryHistoryRequest) (*GetInventoryHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventoryHistory not implemented")
+}
+func (UnimplementedInventoryServiceServer) WatchInventory(*WatchInventoryRequest, grpc.ServerStreamingServer[WatchInventoryResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInventory not implemented")
+}
 func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
 func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
@@ -204,6 +317,24 @@ func _InventoryService_CommitStock_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_CommitStockPartial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitStockPartialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CommitStockPartial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CommitStockPartial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CommitStockPartial(ctx, req.(*CommitStockPartialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInventoryRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +371,89 @@ func _InventoryService_AdjustInventory_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_BulkAdjustInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkAdjustInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).BulkAdjustInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_BulkAdjustInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).BulkAdjustInventory(ctx, req.(*BulkAdjustInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetReservation(ctx, req.(*GetReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListReservations(ctx, req.(*ListReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetInventoryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetInventoryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetInventoryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetInventoryHistory(ctx, req.(*GetInventoryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_WatchInventory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInventoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServiceServer).WatchInventory(m, &grpc.GenericServerStream[WatchInventoryRequest, WatchInventoryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchInventoryServer = grpc.ServerStreamingServer[WatchInventoryResponse]
+
 // InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +473,10 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CommitStock",
 			Handler:    _InventoryService_CommitStock_Handler,
 		},
+		{
+			MethodName: "CommitStockPartial",
+			Handler:    _InventoryService_CommitStockPartial_Handler,
+		},
 		{
 			MethodName: "GetInventory",
 			Handler:    _InventoryService_GetInventory_Handler,
@@ -267,7 +485,29 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AdjustInventory",
 			Handler:    _InventoryService_AdjustInventory_Handler,
 		},
+		{
+			MethodName: "BulkAdjustInventory",
+			Handler:    _InventoryService_BulkAdjustInventory_Handler,
+		},
+		{
+			MethodName: "GetReservation",
+			Handler:    _InventoryService_GetReservation_Handler,
+		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _InventoryService_ListReservations_Handler,
+		},
+		{
+			MethodName: "GetInventoryHistory",
+			Handler:    _InventoryService_GetInventoryHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchInventory",
+			Handler:       _InventoryService_WatchInventory_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/inventory/v1/inventory.proto",
 }
@@ -33,7 +33,7 @@ const (
 	OrderStatus_ORDER_STATUS_PROCESSING  OrderStatus = 4
 	OrderStatus_ORDER_STATUS_SHIPPED     OrderStatus = 5
 	OrderStatus_ORDER_STATUS_DELIVERED   OrderStatus = 6
-	OrderStatus_ORDER_STATUS_CANCELED   OrderStatus = 7
+	OrderStatus_ORDER_STATUS_CANCELED    OrderStatus = 7
 	OrderStatus_ORDER_STATUS_REFUNDED    OrderStatus = 8
 )
 
@@ -58,7 +58,7 @@ var (
 		"ORDER_STATUS_PROCESSING":  4,
 		"ORDER_STATUS_SHIPPED":     5,
 		"ORDER_STATUS_DELIVERED":   6,
-		"ORDER_STATUS_CANCELED":   7,
+		"ORDER_STATUS_CANCELED":    7,
 		"ORDER_STATUS_REFUNDED":    8,
 	}
 )
@@ -281,6 +281,7 @@ type CreateOrderRequest struct {
 	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Items           []*OrderItemRequest    `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
 	ShippingAddress *v1.Address            `protobuf:"bytes,5,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	Currency        string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217; optional when the service has a default currency
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -350,10 +351,18 @@ func (x *CreateOrderRequest) GetShippingAddress() *v1.Address {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type OrderItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // Either product_id or sku
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"` // Resolved to a product_id through the catalog
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -402,6 +411,13 @@ func (x *OrderItemRequest) GetQuantity() int32 {
 	return 0
 }
 
+func (x *OrderItemRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
 type CreateOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
@@ -670,29 +686,31 @@ func (x *ListOrdersResponse) GetPagination() *v1.PaginationResponse {
 	return nil
 }
 
-type CancelOrderRequest struct {
+type ListOrdersByStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=orders.v1.OrderStatus" json:"status,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // Inclusive, optional
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // Exclusive, optional
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelOrderRequest) Reset() {
-	*x = CancelOrderRequest{}
+func (x *ListOrdersByStatusRequest) Reset() {
+	*x = ListOrdersByStatusRequest{}
 	mi := &file_proto_orders_v1_orders_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelOrderRequest) String() string {
+func (x *ListOrdersByStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderRequest) ProtoMessage() {}
+func (*ListOrdersByStatusRequest) ProtoMessage() {}
 
-func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *ListOrdersByStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orders_v1_orders_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -704,53 +722,68 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
-func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOrdersByStatusRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersByStatusRequest) Descriptor() ([]byte, []int) {
 	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CancelOrderRequest) GetMetadata() *v1.RequestMetadata {
+func (x *ListOrdersByStatusRequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *CancelOrderRequest) GetOrderId() string {
+func (x *ListOrdersByStatusRequest) GetStatus() OrderStatus {
 	if x != nil {
-		return x.OrderId
+		return x.Status
 	}
-	return ""
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
 }
 
-func (x *CancelOrderRequest) GetReason() string {
+func (x *ListOrdersByStatusRequest) GetCreatedFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Reason
+		return x.CreatedFrom
 	}
-	return ""
+	return nil
 }
 
-type CancelOrderResponse struct {
+func (x *ListOrdersByStatusRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListOrdersByStatusRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListOrdersByStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelOrderResponse) Reset() {
-	*x = CancelOrderResponse{}
+func (x *ListOrdersByStatusResponse) Reset() {
+	*x = ListOrdersByStatusResponse{}
 	mi := &file_proto_orders_v1_orders_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelOrderResponse) String() string {
+func (x *ListOrdersByStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderResponse) ProtoMessage() {}
+func (*ListOrdersByStatusResponse) ProtoMessage() {}
 
-func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+func (x *ListOrdersByStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orders_v1_orders_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -762,41 +795,49 @@ func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
-func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOrdersByStatusResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersByStatusResponse) Descriptor() ([]byte, []int) {
 	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CancelOrderResponse) GetOrder() *Order {
+func (x *ListOrdersByStatusResponse) GetOrders() []*Order {
 	if x != nil {
-		return x.Order
+		return x.Orders
 	}
 	return nil
 }
 
-type UpdateOrderStatusRequest struct {
+func (x *ListOrdersByStatusResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListOrdersByProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=orders.v1.OrderStatus" json:"status,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	StatusFilter  OrderStatus            `protobuf:"varint,3,opt,name=status_filter,json=statusFilter,proto3,enum=orders.v1.OrderStatus" json:"status_filter,omitempty"` // Optional
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderStatusRequest) Reset() {
-	*x = UpdateOrderStatusRequest{}
+func (x *ListOrdersByProductRequest) Reset() {
+	*x = ListOrdersByProductRequest{}
 	mi := &file_proto_orders_v1_orders_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderStatusRequest) String() string {
+func (x *ListOrdersByProductRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderStatusRequest) ProtoMessage() {}
+func (*ListOrdersByProductRequest) ProtoMessage() {}
 
-func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *ListOrdersByProductRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orders_v1_orders_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -808,53 +849,61 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOrdersByProductRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersByProductRequest) Descriptor() ([]byte, []int) {
 	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *UpdateOrderStatusRequest) GetMetadata() *v1.RequestMetadata {
+func (x *ListOrdersByProductRequest) GetMetadata() *v1.RequestMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *UpdateOrderStatusRequest) GetOrderId() string {
+func (x *ListOrdersByProductRequest) GetProductId() string {
 	if x != nil {
-		return x.OrderId
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *UpdateOrderStatusRequest) GetStatus() OrderStatus {
+func (x *ListOrdersByProductRequest) GetStatusFilter() OrderStatus {
 	if x != nil {
-		return x.Status
+		return x.StatusFilter
 	}
 	return OrderStatus_ORDER_STATUS_UNSPECIFIED
 }
 
-type UpdateOrderStatusResponse struct {
+func (x *ListOrdersByProductRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListOrdersByProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderStatusResponse) Reset() {
-	*x = UpdateOrderStatusResponse{}
+func (x *ListOrdersByProductResponse) Reset() {
+	*x = ListOrdersByProductResponse{}
 	mi := &file_proto_orders_v1_orders_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderStatusResponse) String() string {
+func (x *ListOrdersByProductResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderStatusResponse) ProtoMessage() {}
+func (*ListOrdersByProductResponse) ProtoMessage() {}
 
-func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *ListOrdersByProductResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orders_v1_orders_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -866,88 +915,1292 @@ func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOrdersByProductResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersByProductResponse) Descriptor() ([]byte, []int) {
 	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateOrderStatusResponse) GetOrder() *Order {
+func (x *ListOrdersByProductResponse) GetOrders() []*Order {
 	if x != nil {
-		return x.Order
+		return x.Orders
 	}
 	return nil
 }
 
-var File_proto_orders_v1_orders_proto protoreflect.FileDescriptor
+func (x *ListOrdersByProductResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
 
-const file_proto_orders_v1_orders_proto_rawDesc = "" +
-	"\n" +
-	"\x1cproto/orders/v1/orders.proto\x12\torders.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/common/v1/common.proto\"\x95\x03\n" +
-	"\x05Order\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12*\n" +
-	"\x05items\x18\x03 \x03(\v2\x14.orders.v1.OrderItemR\x05items\x123\n" +
-	"\ftotal_amount\x18\x04 \x01(\v2\x10.common.v1.MoneyR\vtotalAmount\x12.\n" +
-	"\x06status\x18\x05 \x01(\x0e2\x16.orders.v1.OrderStatusR\x06status\x12=\n" +
-	"\x10shipping_address\x18\x06 \x01(\v2\x12.common.v1.AddressR\x0fshippingAddress\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\a \x01(\tR\tpaymentId\x129\n" +
-	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xcd\x01\n" +
-	"\tOrderItem\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
-	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12/\n" +
-	"\n" +
-	"unit_price\x18\x04 \x01(\v2\x10.common.v1.MoneyR\tunitPrice\x121\n" +
-	"\vtotal_price\x18\x05 \x01(\v2\x10.common.v1.MoneyR\n" +
-	"totalPrice\"\x80\x02\n" +
-	"\x12CreateOrderRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12'\n" +
-	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\x121\n" +
-	"\x05items\x18\x04 \x03(\v2\x1b.orders.v1.OrderItemRequestR\x05items\x12=\n" +
-	"\x10shipping_address\x18\x05 \x01(\v2\x12.common.v1.AddressR\x0fshippingAddress\"M\n" +
-	"\x10OrderItemRequest\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\\\n" +
-	"\x13CreateOrderResponse\x12&\n" +
-	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order\x12\x1d\n" +
-	"\n" +
-	"from_cache\x18\x02 \x01(\bR\tfromCache\"d\n" +
-	"\x0fGetOrderRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\":\n" +
-	"\x10GetOrderResponse\x12&\n" +
-	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order\"\xdf\x01\n" +
-	"\x11ListOrdersRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12<\n" +
-	"\n" +
-	"pagination\x18\x03 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
-	"pagination\x12;\n" +
-	"\rstatus_filter\x18\x04 \x01(\x0e2\x16.orders.v1.OrderStatusR\fstatusFilter\"}\n" +
-	"\x12ListOrdersResponse\x12(\n" +
-	"\x06orders\x18\x01 \x03(\v2\x10.orders.v1.OrderR\x06orders\x12=\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
-	"pagination\"\x7f\n" +
-	"\x12CancelOrderRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"=\n" +
-	"\x13CancelOrderResponse\x12&\n" +
-	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order\"\x9d\x01\n" +
-	"\x18UpdateOrderStatusRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\x12.\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x16.orders.v1.OrderStatusR\x06status\"C\n" +
-	"\x19UpdateOrderStatusResponse\x12&\n" +
-	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order*\x82\x02\n" +
+// Buckets are UTC days; the range may span at most 366 days
+type GetOrdersSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                               // Optional; empty summarizes all users
+	StatusFilter  OrderStatus            `protobuf:"varint,3,opt,name=status_filter,json=statusFilter,proto3,enum=orders.v1.OrderStatus" json:"status_filter,omitempty"` // Optional
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`                                // Inclusive
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`                                      // Exclusive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersSummaryRequest) Reset() {
+	*x = GetOrdersSummaryRequest{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersSummaryRequest) ProtoMessage() {}
+
+func (x *GetOrdersSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrdersSummaryRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetOrdersSummaryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetOrdersSummaryRequest) GetStatusFilter() OrderStatus {
+	if x != nil {
+		return x.StatusFilter
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *GetOrdersSummaryRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *GetOrdersSummaryRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+type DailyOrderSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	OrderCount    int64                  `protobuf:"varint,2,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	TotalAmount   *v1.Money              `protobuf:"bytes,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyOrderSummary) Reset() {
+	*x = DailyOrderSummary{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyOrderSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyOrderSummary) ProtoMessage() {}
+
+func (x *DailyOrderSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyOrderSummary.ProtoReflect.Descriptor instead.
+func (*DailyOrderSummary) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DailyOrderSummary) GetDay() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Day
+	}
+	return nil
+}
+
+func (x *DailyOrderSummary) GetOrderCount() int64 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+func (x *DailyOrderSummary) GetTotalAmount() *v1.Money {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return nil
+}
+
+// One entry per day and currency, ordered by day then currency
+type GetOrdersSummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*DailyOrderSummary   `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersSummaryResponse) Reset() {
+	*x = GetOrdersSummaryResponse{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersSummaryResponse) ProtoMessage() {}
+
+func (x *GetOrdersSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetOrdersSummaryResponse) GetDays() []*DailyOrderSummary {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+// Select events either by ID or by aggregate (order) ID; the created range
+// only applies to aggregate_id.
+type ReplayOutboxEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	EventIds      []string               `protobuf:"bytes,2,rep,name=event_ids,json=eventIds,proto3" json:"event_ids,omitempty"`
+	AggregateId   string                 `protobuf:"bytes,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // Inclusive, optional
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // Exclusive, optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayOutboxEventsRequest) Reset() {
+	*x = ReplayOutboxEventsRequest{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayOutboxEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayOutboxEventsRequest) ProtoMessage() {}
+
+func (x *ReplayOutboxEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayOutboxEventsRequest.ProtoReflect.Descriptor instead.
+func (*ReplayOutboxEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReplayOutboxEventsRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ReplayOutboxEventsRequest) GetEventIds() []string {
+	if x != nil {
+		return x.EventIds
+	}
+	return nil
+}
+
+func (x *ReplayOutboxEventsRequest) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *ReplayOutboxEventsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ReplayOutboxEventsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+type ReplayOutboxEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplayedCount int64                  `protobuf:"varint,1,opt,name=replayed_count,json=replayedCount,proto3" json:"replayed_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayOutboxEventsResponse) Reset() {
+	*x = ReplayOutboxEventsResponse{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayOutboxEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayOutboxEventsResponse) ProtoMessage() {}
+
+func (x *ReplayOutboxEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayOutboxEventsResponse.ProtoReflect.Descriptor instead.
+func (*ReplayOutboxEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReplayOutboxEventsResponse) GetReplayedCount() int64 {
+	if x != nil {
+		return x.ReplayedCount
+	}
+	return 0
+}
+
+// OutboxEvent is an outbox event the publisher gave up on
+type OutboxEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AggregateType  string                 `protobuf:"bytes,2,opt,name=aggregate_type,json=aggregateType,proto3" json:"aggregate_type,omitempty"`
+	AggregateId    string                 `protobuf:"bytes,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	EventType      string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Payload        string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"` // JSON
+	Attempts       int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError      string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeadLetteredAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=dead_lettered_at,json=deadLetteredAt,proto3" json:"dead_lettered_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OutboxEvent) Reset() {
+	*x = OutboxEvent{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxEvent) ProtoMessage() {}
+
+func (x *OutboxEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxEvent.ProtoReflect.Descriptor instead.
+func (*OutboxEvent) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OutboxEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetAggregateType() string {
+	if x != nil {
+		return x.AggregateType
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *OutboxEvent) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OutboxEvent) GetDeadLetteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeadLetteredAt
+	}
+	return nil
+}
+
+type ListDeadLetteredEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLetteredEventsRequest) Reset() {
+	*x = ListDeadLetteredEventsRequest{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLetteredEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLetteredEventsRequest) ProtoMessage() {}
+
+func (x *ListDeadLetteredEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLetteredEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLetteredEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListDeadLetteredEventsRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListDeadLetteredEventsRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListDeadLetteredEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*OutboxEvent         `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLetteredEventsResponse) Reset() {
+	*x = ListDeadLetteredEventsResponse{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLetteredEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLetteredEventsResponse) ProtoMessage() {}
+
+func (x *ListDeadLetteredEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLetteredEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLetteredEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListDeadLetteredEventsResponse) GetEvents() []*OutboxEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListDeadLetteredEventsResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type RequeueDeadLetteredEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	EventIds      []string               `protobuf:"bytes,2,rep,name=event_ids,json=eventIds,proto3" json:"event_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueDeadLetteredEventsRequest) Reset() {
+	*x = RequeueDeadLetteredEventsRequest{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueDeadLetteredEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueDeadLetteredEventsRequest) ProtoMessage() {}
+
+func (x *RequeueDeadLetteredEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueDeadLetteredEventsRequest.ProtoReflect.Descriptor instead.
+func (*RequeueDeadLetteredEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RequeueDeadLetteredEventsRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RequeueDeadLetteredEventsRequest) GetEventIds() []string {
+	if x != nil {
+		return x.EventIds
+	}
+	return nil
+}
+
+type RequeueDeadLetteredEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequeuedCount int64                  `protobuf:"varint,1,opt,name=requeued_count,json=requeuedCount,proto3" json:"requeued_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueDeadLetteredEventsResponse) Reset() {
+	*x = RequeueDeadLetteredEventsResponse{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueDeadLetteredEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueDeadLetteredEventsResponse) ProtoMessage() {}
+
+func (x *RequeueDeadLetteredEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueDeadLetteredEventsResponse.ProtoReflect.Descriptor instead.
+func (*RequeueDeadLetteredEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RequeueDeadLetteredEventsResponse) GetRequeuedCount() int64 {
+	if x != nil {
+		return x.RequeuedCount
+	}
+	return 0
+}
+
+type CancelOrderRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Metadata       *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // Optional; retries with the same key are no-ops
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CancelOrderRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CancelOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type UpdateOrderStatusRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Metadata       *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status         OrderStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=orders.v1.OrderStatus" json:"status,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // Optional; retries with the same key are no-ops
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateOrderStatusRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateOrderStatusRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type UpdateOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusResponse) Reset() {
+	*x = UpdateOrderStatusResponse{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateOrderStatusResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type UpdateOrderItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Changes       []*OrderItemChange     `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderItemsRequest) Reset() {
+	*x = UpdateOrderItemsRequest{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderItemsRequest) ProtoMessage() {}
+
+func (x *UpdateOrderItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderItemsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateOrderItemsRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateOrderItemsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateOrderItemsRequest) GetChanges() []*OrderItemChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Sets the quantity of a product in the order; 0 removes it
+type OrderItemChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // Either product_id or sku
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemChange) Reset() {
+	*x = OrderItemChange{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemChange) ProtoMessage() {}
+
+func (x *OrderItemChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemChange.ProtoReflect.Descriptor instead.
+func (*OrderItemChange) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *OrderItemChange) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItemChange) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderItemChange) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type UpdateOrderItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderItemsResponse) Reset() {
+	*x = UpdateOrderItemsResponse{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderItemsResponse) ProtoMessage() {}
+
+func (x *UpdateOrderItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderItemsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateOrderItemsResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type VerifyOrderTotalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyOrderTotalRequest) Reset() {
+	*x = VerifyOrderTotalRequest{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyOrderTotalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyOrderTotalRequest) ProtoMessage() {}
+
+func (x *VerifyOrderTotalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyOrderTotalRequest.ProtoReflect.Descriptor instead.
+func (*VerifyOrderTotalRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *VerifyOrderTotalRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *VerifyOrderTotalRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Fails with FailedPrecondition when the stored total doesn't match the items
+type VerifyOrderTotalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         *v1.Money              `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyOrderTotalResponse) Reset() {
+	*x = VerifyOrderTotalResponse{}
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyOrderTotalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyOrderTotalResponse) ProtoMessage() {}
+
+func (x *VerifyOrderTotalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_v1_orders_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyOrderTotalResponse.ProtoReflect.Descriptor instead.
+func (*VerifyOrderTotalResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_v1_orders_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VerifyOrderTotalResponse) GetTotal() *v1.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+var File_proto_orders_v1_orders_proto protoreflect.FileDescriptor
+
+const file_proto_orders_v1_orders_proto_rawDesc = "" +
+	"\n" +
+	"\x1cproto/orders/v1/orders.proto\x12\torders.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/common/v1/common.proto\"\x95\x03\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12*\n" +
+	"\x05items\x18\x03 \x03(\v2\x14.orders.v1.OrderItemR\x05items\x123\n" +
+	"\ftotal_amount\x18\x04 \x01(\v2\x10.common.v1.MoneyR\vtotalAmount\x12.\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x16.orders.v1.OrderStatusR\x06status\x12=\n" +
+	"\x10shipping_address\x18\x06 \x01(\v2\x12.common.v1.AddressR\x0fshippingAddress\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\a \x01(\tR\tpaymentId\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xcd\x01\n" +
+	"\tOrderItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12/\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\v2\x10.common.v1.MoneyR\tunitPrice\x121\n" +
+	"\vtotal_price\x18\x05 \x01(\v2\x10.common.v1.MoneyR\n" +
+	"totalPrice\"\x9c\x02\n" +
+	"\x12CreateOrderRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12'\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x121\n" +
+	"\x05items\x18\x04 \x03(\v2\x1b.orders.v1.OrderItemRequestR\x05items\x12=\n" +
+	"\x10shipping_address\x18\x05 \x01(\v2\x12.common.v1.AddressR\x0fshippingAddress\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\"_\n" +
+	"\x10OrderItemRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\"\\\n" +
+	"\x13CreateOrderResponse\x12&\n" +
+	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order\x12\x1d\n" +
+	"\n" +
+	"from_cache\x18\x02 \x01(\bR\tfromCache\"d\n" +
+	"\x0fGetOrderRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\":\n" +
+	"\x10GetOrderResponse\x12&\n" +
+	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order\"\xdf\x01\n" +
+	"\x11ListOrdersRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12<\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
+	"pagination\x12;\n" +
+	"\rstatus_filter\x18\x04 \x01(\x0e2\x16.orders.v1.OrderStatusR\fstatusFilter\"}\n" +
+	"\x12ListOrdersResponse\x12(\n" +
+	"\x06orders\x18\x01 \x03(\v2\x10.orders.v1.OrderR\x06orders\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"pagination\"\xbb\x02\n" +
+	"\x19ListOrdersByStatusRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.orders.v1.OrderStatusR\x06status\x12=\n" +
+	"\fcreated_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12<\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
+	"pagination\"\x85\x01\n" +
+	"\x1aListOrdersByStatusResponse\x12(\n" +
+	"\x06orders\x18\x01 \x03(\v2\x10.orders.v1.OrderR\x06orders\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"pagination\"\xee\x01\n" +
+	"\x1aListOrdersByProductRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12;\n" +
+	"\rstatus_filter\x18\x03 \x01(\x0e2\x16.orders.v1.OrderStatusR\fstatusFilter\x12<\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
+	"pagination\"\x86\x01\n" +
+	"\x1bListOrdersByProductResponse\x12(\n" +
+	"\x06orders\x18\x01 \x03(\v2\x10.orders.v1.OrderR\x06orders\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"pagination\"\xa1\x02\n" +
+	"\x17GetOrdersSummaryRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12;\n" +
+	"\rstatus_filter\x18\x03 \x01(\x0e2\x16.orders.v1.OrderStatusR\fstatusFilter\x12=\n" +
+	"\fcreated_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\"\x97\x01\n" +
+	"\x11DailyOrderSummary\x12,\n" +
+	"\x03day\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x03day\x12\x1f\n" +
+	"\vorder_count\x18\x02 \x01(\x03R\n" +
+	"orderCount\x123\n" +
+	"\ftotal_amount\x18\x03 \x01(\v2\x10.common.v1.MoneyR\vtotalAmount\"L\n" +
+	"\x18GetOrdersSummaryResponse\x120\n" +
+	"\x04days\x18\x01 \x03(\v2\x1c.orders.v1.DailyOrderSummaryR\x04days\"\x8d\x02\n" +
+	"\x19ReplayOutboxEventsRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1b\n" +
+	"\tevent_ids\x18\x02 \x03(\tR\beventIds\x12!\n" +
+	"\faggregate_id\x18\x03 \x01(\tR\vaggregateId\x12=\n" +
+	"\fcreated_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\"C\n" +
+	"\x1aReplayOutboxEventsResponse\x12%\n" +
+	"\x0ereplayed_count\x18\x01 \x01(\x03R\rreplayedCount\"\xdc\x02\n" +
+	"\vOutboxEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0eaggregate_type\x18\x02 \x01(\tR\raggregateType\x12!\n" +
+	"\faggregate_id\x18\x03 \x01(\tR\vaggregateId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12D\n" +
+	"\x10dead_lettered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0edeadLetteredAt\"\x95\x01\n" +
+	"\x1dListDeadLetteredEventsRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
+	"pagination\"\x8f\x01\n" +
+	"\x1eListDeadLetteredEventsResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.orders.v1.OutboxEventR\x06events\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"pagination\"w\n" +
+	" RequeueDeadLetteredEventsRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1b\n" +
+	"\tevent_ids\x18\x02 \x03(\tR\beventIds\"J\n" +
+	"!RequeueDeadLetteredEventsResponse\x12%\n" +
+	"\x0erequeued_count\x18\x01 \x01(\x03R\rrequeuedCount\"\xa8\x01\n" +
+	"\x12CancelOrderRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"=\n" +
+	"\x13CancelOrderResponse\x12&\n" +
+	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order\"\xc6\x01\n" +
+	"\x18UpdateOrderStatusRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12.\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x16.orders.v1.OrderStatusR\x06status\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"C\n" +
+	"\x19UpdateOrderStatusResponse\x12&\n" +
+	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order\"\xa2\x01\n" +
+	"\x17UpdateOrderItemsRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x124\n" +
+	"\achanges\x18\x03 \x03(\v2\x1a.orders.v1.OrderItemChangeR\achanges\"^\n" +
+	"\x0fOrderItemChange\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"B\n" +
+	"\x18UpdateOrderItemsResponse\x12&\n" +
+	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order\"l\n" +
+	"\x17VerifyOrderTotalRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"B\n" +
+	"\x18VerifyOrderTotalResponse\x12&\n" +
+	"\x05total\x18\x01 \x01(\v2\x10.common.v1.MoneyR\x05total*\x81\x02\n" +
 	"\vOrderStatus\x12\x1c\n" +
 	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x1a\n" +
@@ -955,16 +2208,24 @@ const file_proto_orders_v1_orders_proto_rawDesc = "" +
 	"\x11ORDER_STATUS_PAID\x10\x03\x12\x1b\n" +
 	"\x17ORDER_STATUS_PROCESSING\x10\x04\x12\x18\n" +
 	"\x14ORDER_STATUS_SHIPPED\x10\x05\x12\x1a\n" +
-	"\x16ORDER_STATUS_DELIVERED\x10\x06\x12\x1a\n" +
-	"\x16ORDER_STATUS_CANCELED\x10\a\x12\x19\n" +
-	"\x15ORDER_STATUS_REFUNDED\x10\b2\x9a\x03\n" +
+	"\x16ORDER_STATUS_DELIVERED\x10\x06\x12\x19\n" +
+	"\x15ORDER_STATUS_CANCELED\x10\a\x12\x19\n" +
+	"\x15ORDER_STATUS_REFUNDED\x10\b2\xc4\t\n" +
 	"\fOrderService\x12L\n" +
 	"\vCreateOrder\x12\x1d.orders.v1.CreateOrderRequest\x1a\x1e.orders.v1.CreateOrderResponse\x12C\n" +
 	"\bGetOrder\x12\x1a.orders.v1.GetOrderRequest\x1a\x1b.orders.v1.GetOrderResponse\x12I\n" +
 	"\n" +
 	"ListOrders\x12\x1c.orders.v1.ListOrdersRequest\x1a\x1d.orders.v1.ListOrdersResponse\x12L\n" +
 	"\vCancelOrder\x12\x1d.orders.v1.CancelOrderRequest\x1a\x1e.orders.v1.CancelOrderResponse\x12^\n" +
-	"\x11UpdateOrderStatus\x12#.orders.v1.UpdateOrderStatusRequest\x1a$.orders.v1.UpdateOrderStatusResponseB4Z2github.com/mumumio1/coldy/proto/orders/v1;ordersv1b\x06proto3"
+	"\x11UpdateOrderStatus\x12#.orders.v1.UpdateOrderStatusRequest\x1a$.orders.v1.UpdateOrderStatusResponse\x12[\n" +
+	"\x10UpdateOrderItems\x12\".orders.v1.UpdateOrderItemsRequest\x1a#.orders.v1.UpdateOrderItemsResponse\x12[\n" +
+	"\x10VerifyOrderTotal\x12\".orders.v1.VerifyOrderTotalRequest\x1a#.orders.v1.VerifyOrderTotalResponse\x12a\n" +
+	"\x12ListOrdersByStatus\x12$.orders.v1.ListOrdersByStatusRequest\x1a%.orders.v1.ListOrdersByStatusResponse\x12a\n" +
+	"\x12ReplayOutboxEvents\x12$.orders.v1.ReplayOutboxEventsRequest\x1a%.orders.v1.ReplayOutboxEventsResponse\x12m\n" +
+	"\x16ListDeadLetteredEvents\x12(.orders.v1.ListDeadLetteredEventsRequest\x1a).orders.v1.ListDeadLetteredEventsResponse\x12v\n" +
+	"\x19RequeueDeadLetteredEvents\x12+.orders.v1.RequeueDeadLetteredEventsRequest\x1a,.orders.v1.RequeueDeadLetteredEventsResponse\x12d\n" +
+	"\x13ListOrdersByProduct\x12%.orders.v1.ListOrdersByProductRequest\x1a&.orders.v1.ListOrdersByProductResponse\x12[\n" +
+	"\x10GetOrdersSummary\x12\".orders.v1.GetOrdersSummaryRequest\x1a#.orders.v1.GetOrdersSummaryResponseB4Z2github.com/mumumio1/coldy/proto/orders/v1;ordersv1b\x06proto3"
 
 var (
 	file_proto_orders_v1_orders_proto_rawDescOnce sync.Once
@@ -979,69 +2240,138 @@ func file_proto_orders_v1_orders_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_orders_v1_orders_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_orders_v1_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_orders_v1_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_proto_orders_v1_orders_proto_goTypes = []any{
-	(OrderStatus)(0),                  // 0: orders.v1.OrderStatus
-	(*Order)(nil),                     // 1: orders.v1.Order
-	(*OrderItem)(nil),                 // 2: orders.v1.OrderItem
-	(*CreateOrderRequest)(nil),        // 3: orders.v1.CreateOrderRequest
-	(*OrderItemRequest)(nil),          // 4: orders.v1.OrderItemRequest
-	(*CreateOrderResponse)(nil),       // 5: orders.v1.CreateOrderResponse
-	(*GetOrderRequest)(nil),           // 6: orders.v1.GetOrderRequest
-	(*GetOrderResponse)(nil),          // 7: orders.v1.GetOrderResponse
-	(*ListOrdersRequest)(nil),         // 8: orders.v1.ListOrdersRequest
-	(*ListOrdersResponse)(nil),        // 9: orders.v1.ListOrdersResponse
-	(*CancelOrderRequest)(nil),        // 10: orders.v1.CancelOrderRequest
-	(*CancelOrderResponse)(nil),       // 11: orders.v1.CancelOrderResponse
-	(*UpdateOrderStatusRequest)(nil),  // 12: orders.v1.UpdateOrderStatusRequest
-	(*UpdateOrderStatusResponse)(nil), // 13: orders.v1.UpdateOrderStatusResponse
-	(*v1.Money)(nil),                  // 14: common.v1.Money
-	(*v1.Address)(nil),                // 15: common.v1.Address
-	(*timestamppb.Timestamp)(nil),     // 16: google.protobuf.Timestamp
-	(*v1.RequestMetadata)(nil),        // 17: common.v1.RequestMetadata
-	(*v1.PaginationRequest)(nil),      // 18: common.v1.PaginationRequest
-	(*v1.PaginationResponse)(nil),     // 19: common.v1.PaginationResponse
+	(OrderStatus)(0),                          // 0: orders.v1.OrderStatus
+	(*Order)(nil),                             // 1: orders.v1.Order
+	(*OrderItem)(nil),                         // 2: orders.v1.OrderItem
+	(*CreateOrderRequest)(nil),                // 3: orders.v1.CreateOrderRequest
+	(*OrderItemRequest)(nil),                  // 4: orders.v1.OrderItemRequest
+	(*CreateOrderResponse)(nil),               // 5: orders.v1.CreateOrderResponse
+	(*GetOrderRequest)(nil),                   // 6: orders.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),                  // 7: orders.v1.GetOrderResponse
+	(*ListOrdersRequest)(nil),                 // 8: orders.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),                // 9: orders.v1.ListOrdersResponse
+	(*ListOrdersByStatusRequest)(nil),         // 10: orders.v1.ListOrdersByStatusRequest
+	(*ListOrdersByStatusResponse)(nil),        // 11: orders.v1.ListOrdersByStatusResponse
+	(*ListOrdersByProductRequest)(nil),        // 12: orders.v1.ListOrdersByProductRequest
+	(*ListOrdersByProductResponse)(nil),       // 13: orders.v1.ListOrdersByProductResponse
+	(*GetOrdersSummaryRequest)(nil),           // 14: orders.v1.GetOrdersSummaryRequest
+	(*DailyOrderSummary)(nil),                 // 15: orders.v1.DailyOrderSummary
+	(*GetOrdersSummaryResponse)(nil),          // 16: orders.v1.GetOrdersSummaryResponse
+	(*ReplayOutboxEventsRequest)(nil),         // 17: orders.v1.ReplayOutboxEventsRequest
+	(*ReplayOutboxEventsResponse)(nil),        // 18: orders.v1.ReplayOutboxEventsResponse
+	(*OutboxEvent)(nil),                       // 19: orders.v1.OutboxEvent
+	(*ListDeadLetteredEventsRequest)(nil),     // 20: orders.v1.ListDeadLetteredEventsRequest
+	(*ListDeadLetteredEventsResponse)(nil),    // 21: orders.v1.ListDeadLetteredEventsResponse
+	(*RequeueDeadLetteredEventsRequest)(nil),  // 22: orders.v1.RequeueDeadLetteredEventsRequest
+	(*RequeueDeadLetteredEventsResponse)(nil), // 23: orders.v1.RequeueDeadLetteredEventsResponse
+	(*CancelOrderRequest)(nil),                // 24: orders.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),               // 25: orders.v1.CancelOrderResponse
+	(*UpdateOrderStatusRequest)(nil),          // 26: orders.v1.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),         // 27: orders.v1.UpdateOrderStatusResponse
+	(*UpdateOrderItemsRequest)(nil),           // 28: orders.v1.UpdateOrderItemsRequest
+	(*OrderItemChange)(nil),                   // 29: orders.v1.OrderItemChange
+	(*UpdateOrderItemsResponse)(nil),          // 30: orders.v1.UpdateOrderItemsResponse
+	(*VerifyOrderTotalRequest)(nil),           // 31: orders.v1.VerifyOrderTotalRequest
+	(*VerifyOrderTotalResponse)(nil),          // 32: orders.v1.VerifyOrderTotalResponse
+	(*v1.Money)(nil),                          // 33: common.v1.Money
+	(*v1.Address)(nil),                        // 34: common.v1.Address
+	(*timestamppb.Timestamp)(nil),             // 35: google.protobuf.Timestamp
+	(*v1.RequestMetadata)(nil),                // 36: common.v1.RequestMetadata
+	(*v1.PaginationRequest)(nil),              // 37: common.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),             // 38: common.v1.PaginationResponse
 }
 var file_proto_orders_v1_orders_proto_depIdxs = []int32{
 	2,  // 0: orders.v1.Order.items:type_name -> orders.v1.OrderItem
-	14, // 1: orders.v1.Order.total_amount:type_name -> common.v1.Money
+	33, // 1: orders.v1.Order.total_amount:type_name -> common.v1.Money
 	0,  // 2: orders.v1.Order.status:type_name -> orders.v1.OrderStatus
-	15, // 3: orders.v1.Order.shipping_address:type_name -> common.v1.Address
-	16, // 4: orders.v1.Order.created_at:type_name -> google.protobuf.Timestamp
-	16, // 5: orders.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
-	14, // 6: orders.v1.OrderItem.unit_price:type_name -> common.v1.Money
-	14, // 7: orders.v1.OrderItem.total_price:type_name -> common.v1.Money
-	17, // 8: orders.v1.CreateOrderRequest.metadata:type_name -> common.v1.RequestMetadata
+	34, // 3: orders.v1.Order.shipping_address:type_name -> common.v1.Address
+	35, // 4: orders.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	35, // 5: orders.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	33, // 6: orders.v1.OrderItem.unit_price:type_name -> common.v1.Money
+	33, // 7: orders.v1.OrderItem.total_price:type_name -> common.v1.Money
+	36, // 8: orders.v1.CreateOrderRequest.metadata:type_name -> common.v1.RequestMetadata
 	4,  // 9: orders.v1.CreateOrderRequest.items:type_name -> orders.v1.OrderItemRequest
-	15, // 10: orders.v1.CreateOrderRequest.shipping_address:type_name -> common.v1.Address
+	34, // 10: orders.v1.CreateOrderRequest.shipping_address:type_name -> common.v1.Address
 	1,  // 11: orders.v1.CreateOrderResponse.order:type_name -> orders.v1.Order
-	17, // 12: orders.v1.GetOrderRequest.metadata:type_name -> common.v1.RequestMetadata
+	36, // 12: orders.v1.GetOrderRequest.metadata:type_name -> common.v1.RequestMetadata
 	1,  // 13: orders.v1.GetOrderResponse.order:type_name -> orders.v1.Order
-	17, // 14: orders.v1.ListOrdersRequest.metadata:type_name -> common.v1.RequestMetadata
-	18, // 15: orders.v1.ListOrdersRequest.pagination:type_name -> common.v1.PaginationRequest
+	36, // 14: orders.v1.ListOrdersRequest.metadata:type_name -> common.v1.RequestMetadata
+	37, // 15: orders.v1.ListOrdersRequest.pagination:type_name -> common.v1.PaginationRequest
 	0,  // 16: orders.v1.ListOrdersRequest.status_filter:type_name -> orders.v1.OrderStatus
 	1,  // 17: orders.v1.ListOrdersResponse.orders:type_name -> orders.v1.Order
-	19, // 18: orders.v1.ListOrdersResponse.pagination:type_name -> common.v1.PaginationResponse
-	17, // 19: orders.v1.CancelOrderRequest.metadata:type_name -> common.v1.RequestMetadata
-	1,  // 20: orders.v1.CancelOrderResponse.order:type_name -> orders.v1.Order
-	17, // 21: orders.v1.UpdateOrderStatusRequest.metadata:type_name -> common.v1.RequestMetadata
-	0,  // 22: orders.v1.UpdateOrderStatusRequest.status:type_name -> orders.v1.OrderStatus
-	1,  // 23: orders.v1.UpdateOrderStatusResponse.order:type_name -> orders.v1.Order
-	3,  // 24: orders.v1.OrderService.CreateOrder:input_type -> orders.v1.CreateOrderRequest
-	6,  // 25: orders.v1.OrderService.GetOrder:input_type -> orders.v1.GetOrderRequest
-	8,  // 26: orders.v1.OrderService.ListOrders:input_type -> orders.v1.ListOrdersRequest
-	10, // 27: orders.v1.OrderService.CancelOrder:input_type -> orders.v1.CancelOrderRequest
-	12, // 28: orders.v1.OrderService.UpdateOrderStatus:input_type -> orders.v1.UpdateOrderStatusRequest
-	5,  // 29: orders.v1.OrderService.CreateOrder:output_type -> orders.v1.CreateOrderResponse
-	7,  // 30: orders.v1.OrderService.GetOrder:output_type -> orders.v1.GetOrderResponse
-	9,  // 31: orders.v1.OrderService.ListOrders:output_type -> orders.v1.ListOrdersResponse
-	11, // 32: orders.v1.OrderService.CancelOrder:output_type -> orders.v1.CancelOrderResponse
-	13, // 33: orders.v1.OrderService.UpdateOrderStatus:output_type -> orders.v1.UpdateOrderStatusResponse
-	29, // [29:34] is the sub-list for method output_type
-	24, // [24:29] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	38, // 18: orders.v1.ListOrdersResponse.pagination:type_name -> common.v1.PaginationResponse
+	36, // 19: orders.v1.ListOrdersByStatusRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 20: orders.v1.ListOrdersByStatusRequest.status:type_name -> orders.v1.OrderStatus
+	35, // 21: orders.v1.ListOrdersByStatusRequest.created_from:type_name -> google.protobuf.Timestamp
+	35, // 22: orders.v1.ListOrdersByStatusRequest.created_to:type_name -> google.protobuf.Timestamp
+	37, // 23: orders.v1.ListOrdersByStatusRequest.pagination:type_name -> common.v1.PaginationRequest
+	1,  // 24: orders.v1.ListOrdersByStatusResponse.orders:type_name -> orders.v1.Order
+	38, // 25: orders.v1.ListOrdersByStatusResponse.pagination:type_name -> common.v1.PaginationResponse
+	36, // 26: orders.v1.ListOrdersByProductRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 27: orders.v1.ListOrdersByProductRequest.status_filter:type_name -> orders.v1.OrderStatus
+	37, // 28: orders.v1.ListOrdersByProductRequest.pagination:type_name -> common.v1.PaginationRequest
+	1,  // 29: orders.v1.ListOrdersByProductResponse.orders:type_name -> orders.v1.Order
+	38, // 30: orders.v1.ListOrdersByProductResponse.pagination:type_name -> common.v1.PaginationResponse
+	36, // 31: orders.v1.GetOrdersSummaryRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 32: orders.v1.GetOrdersSummaryRequest.status_filter:type_name -> orders.v1.OrderStatus
+	35, // 33: orders.v1.GetOrdersSummaryRequest.created_from:type_name -> google.protobuf.Timestamp
+	35, // 34: orders.v1.GetOrdersSummaryRequest.created_to:type_name -> google.protobuf.Timestamp
+	35, // 35: orders.v1.DailyOrderSummary.day:type_name -> google.protobuf.Timestamp
+	33, // 36: orders.v1.DailyOrderSummary.total_amount:type_name -> common.v1.Money
+	15, // 37: orders.v1.GetOrdersSummaryResponse.days:type_name -> orders.v1.DailyOrderSummary
+	36, // 38: orders.v1.ReplayOutboxEventsRequest.metadata:type_name -> common.v1.RequestMetadata
+	35, // 39: orders.v1.ReplayOutboxEventsRequest.created_from:type_name -> google.protobuf.Timestamp
+	35, // 40: orders.v1.ReplayOutboxEventsRequest.created_to:type_name -> google.protobuf.Timestamp
+	35, // 41: orders.v1.OutboxEvent.created_at:type_name -> google.protobuf.Timestamp
+	35, // 42: orders.v1.OutboxEvent.dead_lettered_at:type_name -> google.protobuf.Timestamp
+	36, // 43: orders.v1.ListDeadLetteredEventsRequest.metadata:type_name -> common.v1.RequestMetadata
+	37, // 44: orders.v1.ListDeadLetteredEventsRequest.pagination:type_name -> common.v1.PaginationRequest
+	19, // 45: orders.v1.ListDeadLetteredEventsResponse.events:type_name -> orders.v1.OutboxEvent
+	38, // 46: orders.v1.ListDeadLetteredEventsResponse.pagination:type_name -> common.v1.PaginationResponse
+	36, // 47: orders.v1.RequeueDeadLetteredEventsRequest.metadata:type_name -> common.v1.RequestMetadata
+	36, // 48: orders.v1.CancelOrderRequest.metadata:type_name -> common.v1.RequestMetadata
+	1,  // 49: orders.v1.CancelOrderResponse.order:type_name -> orders.v1.Order
+	36, // 50: orders.v1.UpdateOrderStatusRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 51: orders.v1.UpdateOrderStatusRequest.status:type_name -> orders.v1.OrderStatus
+	1,  // 52: orders.v1.UpdateOrderStatusResponse.order:type_name -> orders.v1.Order
+	36, // 53: orders.v1.UpdateOrderItemsRequest.metadata:type_name -> common.v1.RequestMetadata
+	29, // 54: orders.v1.UpdateOrderItemsRequest.changes:type_name -> orders.v1.OrderItemChange
+	1,  // 55: orders.v1.UpdateOrderItemsResponse.order:type_name -> orders.v1.Order
+	36, // 56: orders.v1.VerifyOrderTotalRequest.metadata:type_name -> common.v1.RequestMetadata
+	33, // 57: orders.v1.VerifyOrderTotalResponse.total:type_name -> common.v1.Money
+	3,  // 58: orders.v1.OrderService.CreateOrder:input_type -> orders.v1.CreateOrderRequest
+	6,  // 59: orders.v1.OrderService.GetOrder:input_type -> orders.v1.GetOrderRequest
+	8,  // 60: orders.v1.OrderService.ListOrders:input_type -> orders.v1.ListOrdersRequest
+	24, // 61: orders.v1.OrderService.CancelOrder:input_type -> orders.v1.CancelOrderRequest
+	26, // 62: orders.v1.OrderService.UpdateOrderStatus:input_type -> orders.v1.UpdateOrderStatusRequest
+	28, // 63: orders.v1.OrderService.UpdateOrderItems:input_type -> orders.v1.UpdateOrderItemsRequest
+	31, // 64: orders.v1.OrderService.VerifyOrderTotal:input_type -> orders.v1.VerifyOrderTotalRequest
+	10, // 65: orders.v1.OrderService.ListOrdersByStatus:input_type -> orders.v1.ListOrdersByStatusRequest
+	17, // 66: orders.v1.OrderService.ReplayOutboxEvents:input_type -> orders.v1.ReplayOutboxEventsRequest
+	20, // 67: orders.v1.OrderService.ListDeadLetteredEvents:input_type -> orders.v1.ListDeadLetteredEventsRequest
+	22, // 68: orders.v1.OrderService.RequeueDeadLetteredEvents:input_type -> orders.v1.RequeueDeadLetteredEventsRequest
+	12, // 69: orders.v1.OrderService.ListOrdersByProduct:input_type -> orders.v1.ListOrdersByProductRequest
+	14, // 70: orders.v1.OrderService.GetOrdersSummary:input_type -> orders.v1.GetOrdersSummaryRequest
+	5,  // 71: orders.v1.OrderService.CreateOrder:output_type -> orders.v1.CreateOrderResponse
+	7,  // 72: orders.v1.OrderService.GetOrder:output_type -> orders.v1.GetOrderResponse
+	9,  // 73: orders.v1.OrderService.ListOrders:output_type -> orders.v1.ListOrdersResponse
+	25, // 74: orders.v1.OrderService.CancelOrder:output_type -> orders.v1.CancelOrderResponse
+	27, // 75: orders.v1.OrderService.UpdateOrderStatus:output_type -> orders.v1.UpdateOrderStatusResponse
+	30, // 76: orders.v1.OrderService.UpdateOrderItems:output_type -> orders.v1.UpdateOrderItemsResponse
+	32, // 77: orders.v1.OrderService.VerifyOrderTotal:output_type -> orders.v1.VerifyOrderTotalResponse
+	11, // 78: orders.v1.OrderService.ListOrdersByStatus:output_type -> orders.v1.ListOrdersByStatusResponse
+	18, // 79: orders.v1.OrderService.ReplayOutboxEvents:output_type -> orders.v1.ReplayOutboxEventsResponse
+	21, // 80: orders.v1.OrderService.ListDeadLetteredEvents:output_type -> orders.v1.ListDeadLetteredEventsResponse
+	23, // 81: orders.v1.OrderService.RequeueDeadLetteredEvents:output_type -> orders.v1.RequeueDeadLetteredEventsResponse
+	13, // 82: orders.v1.OrderService.ListOrdersByProduct:output_type -> orders.v1.ListOrdersByProductResponse
+	16, // 83: orders.v1.OrderService.GetOrdersSummary:output_type -> orders.v1.GetOrdersSummaryResponse
+	71, // [71:84] is the sub-list for method output_type
+	58, // [58:71] is the sub-list for method input_type
+	58, // [58:58] is the sub-list for extension type_name
+	58, // [58:58] is the sub-list for extension extendee
+	0,  // [0:58] is the sub-list for field type_name
 }
 
 func init() { file_proto_orders_v1_orders_proto_init() }
@@ -1055,7 +2385,7 @@ func file_proto_orders_v1_orders_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_orders_v1_orders_proto_rawDesc), len(file_proto_orders_v1_orders_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   13,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
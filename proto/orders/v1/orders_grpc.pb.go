@@ -19,11 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrderService_CreateOrder_FullMethodName       = "/orders.v1.OrderService/CreateOrder"
-	OrderService_GetOrder_FullMethodName          = "/orders.v1.OrderService/GetOrder"
-	OrderService_ListOrders_FullMethodName        = "/orders.v1.OrderService/ListOrders"
-	OrderService_CancelOrder_FullMethodName       = "/orders.v1.OrderService/CancelOrder"
-	OrderService_UpdateOrderStatus_FullMethodName = "/orders.v1.OrderService/UpdateOrderStatus"
+	OrderService_CreateOrder_FullMethodName               = "/orders.v1.OrderService/CreateOrder"
+	OrderService_GetOrder_FullMethodName                  = "/orders.v1.OrderService/GetOrder"
+	OrderService_ListOrders_FullMethodName                = "/orders.v1.OrderService/ListOrders"
+	OrderService_CancelOrder_FullMethodName               = "/orders.v1.OrderService/CancelOrder"
+	OrderService_UpdateOrderStatus_FullMethodName         = "/orders.v1.OrderService/UpdateOrderStatus"
+	OrderService_UpdateOrderItems_FullMethodName          = "/orders.v1.OrderService/UpdateOrderItems"
+	OrderService_VerifyOrderTotal_FullMethodName          = "/orders.v1.OrderService/VerifyOrderTotal"
+	OrderService_ListOrdersByStatus_FullMethodName        = "/orders.v1.OrderService/ListOrdersByStatus"
+	OrderService_ReplayOutboxEvents_FullMethodName        = "/orders.v1.OrderService/ReplayOutboxEvents"
+	OrderService_ListDeadLetteredEvents_FullMethodName    = "/orders.v1.OrderService/ListDeadLetteredEvents"
+	OrderService_RequeueDeadLetteredEvents_FullMethodName = "/orders.v1.OrderService/RequeueDeadLetteredEvents"
+	OrderService_ListOrdersByProduct_FullMethodName       = "/orders.v1.OrderService/ListOrdersByProduct"
+	OrderService_GetOrdersSummary_FullMethodName          = "/orders.v1.OrderService/GetOrdersSummary"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -35,6 +43,14 @@ type OrderServiceClient interface {
 	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
 	UpdateOrderStatus(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*UpdateOrderStatusResponse, error)
+	UpdateOrderItems(ctx context.Context, in *UpdateOrderItemsRequest, opts ...grpc.CallOption) (*UpdateOrderItemsResponse, error)
+	VerifyOrderTotal(ctx context.Context, in *VerifyOrderTotalRequest, opts ...grpc.CallOption) (*VerifyOrderTotalResponse, error)
+	ListOrdersByStatus(ctx context.Context, in *ListOrdersByStatusRequest, opts ...grpc.CallOption) (*ListOrdersByStatusResponse, error)
+	ReplayOutboxEvents(ctx context.Context, in *ReplayOutboxEventsRequest, opts ...grpc.CallOption) (*ReplayOutboxEventsResponse, error)
+	ListDeadLetteredEvents(ctx context.Context, in *ListDeadLetteredEventsRequest, opts ...grpc.CallOption) (*ListDeadLetteredEventsResponse, error)
+	RequeueDeadLetteredEvents(ctx context.Context, in *RequeueDeadLetteredEventsRequest, opts ...grpc.CallOption) (*RequeueDeadLetteredEventsResponse, error)
+	ListOrdersByProduct(ctx context.Context, in *ListOrdersByProductRequest, opts ...grpc.CallOption) (*ListOrdersByProductResponse, error)
+	GetOrdersSummary(ctx context.Context, in *GetOrdersSummaryRequest, opts ...grpc.CallOption) (*GetOrdersSummaryResponse, error)
 }
 
 type orderServiceClient struct {
@@ -95,6 +111,86 @@ func (c *orderServiceClient) UpdateOrderStatus(ctx context.Context, in *UpdateOr
 	return out, nil
 }
 
+func (c *orderServiceClient) UpdateOrderItems(ctx context.Context, in *UpdateOrderItemsRequest, opts ...grpc.CallOption) (*UpdateOrderItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateOrderItemsResponse)
+	err := c.cc.Invoke(ctx, OrderService_UpdateOrderItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) VerifyOrderTotal(ctx context.Context, in *VerifyOrderTotalRequest, opts ...grpc.CallOption) (*VerifyOrderTotalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyOrderTotalResponse)
+	err := c.cc.Invoke(ctx, OrderService_VerifyOrderTotal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListOrdersByStatus(ctx context.Context, in *ListOrdersByStatusRequest, opts ...grpc.CallOption) (*ListOrdersByStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrdersByStatusResponse)
+	err := c.cc.Invoke(ctx, OrderService_ListOrdersByStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ReplayOutboxEvents(ctx context.Context, in *ReplayOutboxEventsRequest, opts ...grpc.CallOption) (*ReplayOutboxEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayOutboxEventsResponse)
+	err := c.cc.Invoke(ctx, OrderService_ReplayOutboxEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListDeadLetteredEvents(ctx context.Context, in *ListDeadLetteredEventsRequest, opts ...grpc.CallOption) (*ListDeadLetteredEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLetteredEventsResponse)
+	err := c.cc.Invoke(ctx, OrderService_ListDeadLetteredEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RequeueDeadLetteredEvents(ctx context.Context, in *RequeueDeadLetteredEventsRequest, opts ...grpc.CallOption) (*RequeueDeadLetteredEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequeueDeadLetteredEventsResponse)
+	err := c.cc.Invoke(ctx, OrderService_RequeueDeadLetteredEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListOrdersByProduct(ctx context.Context, in *ListOrdersByProductRequest, opts ...grpc.CallOption) (*ListOrdersByProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrdersByProductResponse)
+	err := c.cc.Invoke(ctx, OrderService_ListOrdersByProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrdersSummary(ctx context.Context, in *GetOrdersSummaryRequest, opts ...grpc.CallOption) (*GetOrdersSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrdersSummaryResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetOrdersSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
@@ -104,6 +200,14 @@ type OrderServiceServer interface {
 	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
 	UpdateOrderStatus(context.Context, *UpdateOrderStatusRequest) (*UpdateOrderStatusResponse, error)
+	UpdateOrderItems(context.Context, *UpdateOrderItemsRequest) (*UpdateOrderItemsResponse, error)
+	VerifyOrderTotal(context.Context, *VerifyOrderTotalRequest) (*VerifyOrderTotalResponse, error)
+	ListOrdersByStatus(context.Context, *ListOrdersByStatusRequest) (*ListOrdersByStatusResponse, error)
+	ReplayOutboxEvents(context.Context, *ReplayOutboxEventsRequest) (*ReplayOutboxEventsResponse, error)
+	ListDeadLetteredEvents(context.Context, *ListDeadLetteredEventsRequest) (*ListDeadLetteredEventsResponse, error)
+	RequeueDeadLetteredEvents(context.Context, *RequeueDeadLetteredEventsRequest) (*RequeueDeadLetteredEventsResponse, error)
+	ListOrdersByProduct(context.Context, *ListOrdersByProductRequest) (*ListOrdersByProductResponse, error)
+	GetOrdersSummary(context.Context, *GetOrdersSummaryRequest) (*GetOrdersSummaryResponse, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -129,6 +233,30 @@ func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrder
 func (UnimplementedOrderServiceServer) UpdateOrderStatus(context.Context, *UpdateOrderStatusRequest) (*UpdateOrderStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderStatus not implemented")
 }
+func (UnimplementedOrderServiceServer) UpdateOrderItems(context.Context, *UpdateOrderItemsRequest) (*UpdateOrderItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderItems not implemented")
+}
+func (UnimplementedOrderServiceServer) VerifyOrderTotal(context.Context, *VerifyOrderTotalRequest) (*VerifyOrderTotalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyOrderTotal not implemented")
+}
+func (UnimplementedOrderServiceServer) ListOrdersByStatus(context.Context, *ListOrdersByStatusRequest) (*ListOrdersByStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrdersByStatus not implemented")
+}
+func (UnimplementedOrderServiceServer) ReplayOutboxEvents(context.Context, *ReplayOutboxEventsRequest) (*ReplayOutboxEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayOutboxEvents not implemented")
+}
+func (UnimplementedOrderServiceServer) ListDeadLetteredEvents(context.Context, *ListDeadLetteredEventsRequest) (*ListDeadLetteredEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetteredEvents not implemented")
+}
+func (UnimplementedOrderServiceServer) RequeueDeadLetteredEvents(context.Context, *RequeueDeadLetteredEventsRequest) (*RequeueDeadLetteredEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueDeadLetteredEvents not implemented")
+}
+func (UnimplementedOrderServiceServer) ListOrdersByProduct(context.Context, *ListOrdersByProductRequest) (*ListOrdersByProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrdersByProduct not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrdersSummary(context.Context, *GetOrdersSummaryRequest) (*GetOrdersSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrdersSummary not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -240,6 +368,150 @@ func _OrderService_UpdateOrderStatus_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_UpdateOrderItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateOrderItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_UpdateOrderItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateOrderItems(ctx, req.(*UpdateOrderItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_VerifyOrderTotal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyOrderTotalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).VerifyOrderTotal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_VerifyOrderTotal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).VerifyOrderTotal(ctx, req.(*VerifyOrderTotalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListOrdersByStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersByStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrdersByStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListOrdersByStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrdersByStatus(ctx, req.(*ListOrdersByStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ReplayOutboxEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayOutboxEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ReplayOutboxEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ReplayOutboxEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ReplayOutboxEvents(ctx, req.(*ReplayOutboxEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListDeadLetteredEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLetteredEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListDeadLetteredEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListDeadLetteredEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListDeadLetteredEvents(ctx, req.(*ListDeadLetteredEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RequeueDeadLetteredEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueDeadLetteredEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RequeueDeadLetteredEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RequeueDeadLetteredEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RequeueDeadLetteredEvents(ctx, req.(*RequeueDeadLetteredEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListOrdersByProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersByProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrdersByProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListOrdersByProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrdersByProduct(ctx, req.(*ListOrdersByProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrdersSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrdersSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrdersSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrdersSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrdersSummary(ctx, req.(*GetOrdersSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +539,38 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateOrderStatus",
 			Handler:    _OrderService_UpdateOrderStatus_Handler,
 		},
+		{
+			MethodName: "UpdateOrderItems",
+			Handler:    _OrderService_UpdateOrderItems_Handler,
+		},
+		{
+			MethodName: "VerifyOrderTotal",
+			Handler:    _OrderService_VerifyOrderTotal_Handler,
+		},
+		{
+			MethodName: "ListOrdersByStatus",
+			Handler:    _OrderService_ListOrdersByStatus_Handler,
+		},
+		{
+			MethodName: "ReplayOutboxEvents",
+			Handler:    _OrderService_ReplayOutboxEvents_Handler,
+		},
+		{
+			MethodName: "ListDeadLetteredEvents",
+			Handler:    _OrderService_ListDeadLetteredEvents_Handler,
+		},
+		{
+			MethodName: "RequeueDeadLetteredEvents",
+			Handler:    _OrderService_RequeueDeadLetteredEvents_Handler,
+		},
+		{
+			MethodName: "ListOrdersByProduct",
+			Handler:    _OrderService_ListOrdersByProduct_Handler,
+		},
+		{
+			MethodName: "GetOrdersSummary",
+			Handler:    _OrderService_GetOrdersSummary_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/orders/v1/orders.proto",
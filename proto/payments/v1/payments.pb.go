@@ -31,7 +31,7 @@ const (
 	PaymentStatus_PAYMENT_STATUS_PROCESSING  PaymentStatus = 2
 	PaymentStatus_PAYMENT_STATUS_SUCCEEDED   PaymentStatus = 3
 	PaymentStatus_PAYMENT_STATUS_FAILED      PaymentStatus = 4
-	PaymentStatus_PAYMENT_STATUS_CANCELED   PaymentStatus = 5
+	PaymentStatus_PAYMENT_STATUS_CANCELED    PaymentStatus = 5
 	PaymentStatus_PAYMENT_STATUS_REFUNDED    PaymentStatus = 6
 )
 
@@ -52,7 +52,7 @@ var (
 		"PAYMENT_STATUS_PROCESSING":  2,
 		"PAYMENT_STATUS_SUCCEEDED":   3,
 		"PAYMENT_STATUS_FAILED":      4,
-		"PAYMENT_STATUS_CANCELED":   5,
+		"PAYMENT_STATUS_CANCELED":    5,
 		"PAYMENT_STATUS_REFUNDED":    6,
 	}
 )
@@ -136,6 +136,111 @@ func (PaymentMethod) EnumDescriptor() ([]byte, []int) {
 	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{1}
 }
 
+type CircuitState int32
+
+const (
+	CircuitState_CIRCUIT_STATE_UNSPECIFIED CircuitState = 0
+	CircuitState_CIRCUIT_STATE_CLOSED      CircuitState = 1
+	CircuitState_CIRCUIT_STATE_HALF_OPEN   CircuitState = 2
+	CircuitState_CIRCUIT_STATE_OPEN        CircuitState = 3
+)
+
+// Enum value maps for CircuitState.
+var (
+	CircuitState_name = map[int32]string{
+		0: "CIRCUIT_STATE_UNSPECIFIED",
+		1: "CIRCUIT_STATE_CLOSED",
+		2: "CIRCUIT_STATE_HALF_OPEN",
+		3: "CIRCUIT_STATE_OPEN",
+	}
+	CircuitState_value = map[string]int32{
+		"CIRCUIT_STATE_UNSPECIFIED": 0,
+		"CIRCUIT_STATE_CLOSED":      1,
+		"CIRCUIT_STATE_HALF_OPEN":   2,
+		"CIRCUIT_STATE_OPEN":        3,
+	}
+)
+
+func (x CircuitState) Enum() *CircuitState {
+	p := new(CircuitState)
+	*p = x
+	return p
+}
+
+func (x CircuitState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CircuitState) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payments_v1_payments_proto_enumTypes[2].Descriptor()
+}
+
+func (CircuitState) Type() protoreflect.EnumType {
+	return &file_proto_payments_v1_payments_proto_enumTypes[2]
+}
+
+func (x CircuitState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CircuitState.Descriptor instead.
+func (CircuitState) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{2}
+}
+
+// ProviderCircuit is the state of the circuit breaker guarding the payment provider
+type ProviderCircuit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         CircuitState           `protobuf:"varint,1,opt,name=state,proto3,enum=payments.v1.CircuitState" json:"state,omitempty"`
+	Failures      uint32                 `protobuf:"varint,2,opt,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderCircuit) Reset() {
+	*x = ProviderCircuit{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderCircuit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderCircuit) ProtoMessage() {}
+
+func (x *ProviderCircuit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderCircuit.ProtoReflect.Descriptor instead.
+func (*ProviderCircuit) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProviderCircuit) GetState() CircuitState {
+	if x != nil {
+		return x.State
+	}
+	return CircuitState_CIRCUIT_STATE_UNSPECIFIED
+}
+
+func (x *ProviderCircuit) GetFailures() uint32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
 type Payment struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -148,13 +253,14 @@ type Payment struct {
 	ErrorMessage          string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RefundedAmount        *v1.Money              `protobuf:"bytes,11,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"` // Sum of the refunds issued so far
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
 
 func (x *Payment) Reset() {
 	*x = Payment{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[0]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -166,7 +272,7 @@ func (x *Payment) String() string {
 func (*Payment) ProtoMessage() {}
 
 func (x *Payment) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[0]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -179,7 +285,7 @@ func (x *Payment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Payment.ProtoReflect.Descriptor instead.
 func (*Payment) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{0}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Payment) GetId() string {
@@ -252,6 +358,13 @@ func (x *Payment) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Payment) GetRefundedAmount() *v1.Money {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return nil
+}
+
 type CreatePaymentRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Metadata       *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
@@ -267,7 +380,7 @@ type CreatePaymentRequest struct {
 
 func (x *CreatePaymentRequest) Reset() {
 	*x = CreatePaymentRequest{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[1]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -279,7 +392,7 @@ func (x *CreatePaymentRequest) String() string {
 func (*CreatePaymentRequest) ProtoMessage() {}
 
 func (x *CreatePaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[1]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -292,7 +405,7 @@ func (x *CreatePaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePaymentRequest.ProtoReflect.Descriptor instead.
 func (*CreatePaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{1}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreatePaymentRequest) GetMetadata() *v1.RequestMetadata {
@@ -354,7 +467,7 @@ type CreatePaymentResponse struct {
 
 func (x *CreatePaymentResponse) Reset() {
 	*x = CreatePaymentResponse{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[2]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -366,7 +479,7 @@ func (x *CreatePaymentResponse) String() string {
 func (*CreatePaymentResponse) ProtoMessage() {}
 
 func (x *CreatePaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[2]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,7 +492,7 @@ func (x *CreatePaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePaymentResponse.ProtoReflect.Descriptor instead.
 func (*CreatePaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{2}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreatePaymentResponse) GetPayment() *Payment {
@@ -406,7 +519,7 @@ type GetPaymentRequest struct {
 
 func (x *GetPaymentRequest) Reset() {
 	*x = GetPaymentRequest{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[3]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -418,7 +531,7 @@ func (x *GetPaymentRequest) String() string {
 func (*GetPaymentRequest) ProtoMessage() {}
 
 func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[3]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -431,7 +544,7 @@ func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentRequest.ProtoReflect.Descriptor instead.
 func (*GetPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{3}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetPaymentRequest) GetMetadata() *v1.RequestMetadata {
@@ -457,7 +570,7 @@ type GetPaymentResponse struct {
 
 func (x *GetPaymentResponse) Reset() {
 	*x = GetPaymentResponse{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[4]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -469,7 +582,7 @@ func (x *GetPaymentResponse) String() string {
 func (*GetPaymentResponse) ProtoMessage() {}
 
 func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[4]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +595,7 @@ func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentResponse.ProtoReflect.Descriptor instead.
 func (*GetPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{4}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetPaymentResponse) GetPayment() *Payment {
@@ -492,6 +605,102 @@ func (x *GetPaymentResponse) GetPayment() *Payment {
 	return nil
 }
 
+type GetPaymentsByOrderIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentsByOrderIDRequest) Reset() {
+	*x = GetPaymentsByOrderIDRequest{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentsByOrderIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentsByOrderIDRequest) ProtoMessage() {}
+
+func (x *GetPaymentsByOrderIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentsByOrderIDRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentsByOrderIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPaymentsByOrderIDRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetPaymentsByOrderIDRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetPaymentsByOrderIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"` // Newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentsByOrderIDResponse) Reset() {
+	*x = GetPaymentsByOrderIDResponse{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentsByOrderIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentsByOrderIDResponse) ProtoMessage() {}
+
+func (x *GetPaymentsByOrderIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentsByOrderIDResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentsByOrderIDResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPaymentsByOrderIDResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
 type ConfirmPaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
@@ -502,7 +711,7 @@ type ConfirmPaymentRequest struct {
 
 func (x *ConfirmPaymentRequest) Reset() {
 	*x = ConfirmPaymentRequest{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[5]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -514,7 +723,7 @@ func (x *ConfirmPaymentRequest) String() string {
 func (*ConfirmPaymentRequest) ProtoMessage() {}
 
 func (x *ConfirmPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[5]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -527,7 +736,7 @@ func (x *ConfirmPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfirmPaymentRequest.ProtoReflect.Descriptor instead.
 func (*ConfirmPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{5}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ConfirmPaymentRequest) GetMetadata() *v1.RequestMetadata {
@@ -553,7 +762,7 @@ type ConfirmPaymentResponse struct {
 
 func (x *ConfirmPaymentResponse) Reset() {
 	*x = ConfirmPaymentResponse{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[6]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -565,7 +774,7 @@ func (x *ConfirmPaymentResponse) String() string {
 func (*ConfirmPaymentResponse) ProtoMessage() {}
 
 func (x *ConfirmPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[6]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -578,7 +787,7 @@ func (x *ConfirmPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfirmPaymentResponse.ProtoReflect.Descriptor instead.
 func (*ConfirmPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{6}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ConfirmPaymentResponse) GetPayment() *Payment {
@@ -599,7 +808,7 @@ type CancelPaymentRequest struct {
 
 func (x *CancelPaymentRequest) Reset() {
 	*x = CancelPaymentRequest{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[7]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -611,7 +820,7 @@ func (x *CancelPaymentRequest) String() string {
 func (*CancelPaymentRequest) ProtoMessage() {}
 
 func (x *CancelPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[7]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -624,7 +833,7 @@ func (x *CancelPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelPaymentRequest.ProtoReflect.Descriptor instead.
 func (*CancelPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{7}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CancelPaymentRequest) GetMetadata() *v1.RequestMetadata {
@@ -657,7 +866,7 @@ type CancelPaymentResponse struct {
 
 func (x *CancelPaymentResponse) Reset() {
 	*x = CancelPaymentResponse{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[8]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -669,7 +878,7 @@ func (x *CancelPaymentResponse) String() string {
 func (*CancelPaymentResponse) ProtoMessage() {}
 
 func (x *CancelPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[8]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -682,7 +891,7 @@ func (x *CancelPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelPaymentResponse.ProtoReflect.Descriptor instead.
 func (*CancelPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{8}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CancelPaymentResponse) GetPayment() *Payment {
@@ -704,7 +913,7 @@ type RefundPaymentRequest struct {
 
 func (x *RefundPaymentRequest) Reset() {
 	*x = RefundPaymentRequest{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[9]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -716,7 +925,7 @@ func (x *RefundPaymentRequest) String() string {
 func (*RefundPaymentRequest) ProtoMessage() {}
 
 func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[9]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -729,7 +938,7 @@ func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
 func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{9}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RefundPaymentRequest) GetMetadata() *v1.RequestMetadata {
@@ -770,7 +979,7 @@ type RefundPaymentResponse struct {
 
 func (x *RefundPaymentResponse) Reset() {
 	*x = RefundPaymentResponse{}
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[10]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -782,7 +991,7 @@ func (x *RefundPaymentResponse) String() string {
 func (*RefundPaymentResponse) ProtoMessage() {}
 
 func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payments_v1_payments_proto_msgTypes[10]
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,7 +1004,7 @@ func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
 func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{10}
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RefundPaymentResponse) GetPayment() *Payment {
@@ -812,88 +1021,654 @@ func (x *RefundPaymentResponse) GetRefundId() string {
 	return ""
 }
 
-var File_proto_payments_v1_payments_proto protoreflect.FileDescriptor
+type GetProviderCircuitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_payments_v1_payments_proto_rawDesc = "" +
-	"\n" +
-	" proto/payments/v1/payments.proto\x12\vpayments.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/common/v1/common.proto\"\xb2\x03\n" +
-	"\aPayment\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\x12(\n" +
-	"\x06amount\x18\x04 \x01(\v2\x10.common.v1.MoneyR\x06amount\x122\n" +
-	"\x06status\x18\x05 \x01(\x0e2\x1a.payments.v1.PaymentStatusR\x06status\x122\n" +
-	"\x06method\x18\x06 \x01(\x0e2\x1a.payments.v1.PaymentMethodR\x06method\x126\n" +
-	"\x17provider_transaction_id\x18\a \x01(\tR\x15providerTransactionId\x12#\n" +
-	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x129\n" +
-	"\n" +
-	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xac\x03\n" +
-	"\x14CreatePaymentRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12'\n" +
-	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\x12\x19\n" +
-	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x17\n" +
-	"\auser_id\x18\x04 \x01(\tR\x06userId\x12(\n" +
-	"\x06amount\x18\x05 \x01(\v2\x10.common.v1.MoneyR\x06amount\x122\n" +
-	"\x06method\x18\x06 \x01(\x0e2\x1a.payments.v1.PaymentMethodR\x06method\x12^\n" +
-	"\x0fpayment_details\x18\a \x03(\v25.payments.v1.CreatePaymentRequest.PaymentDetailsEntryR\x0epaymentDetails\x1aA\n" +
-	"\x13PaymentDetailsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"f\n" +
-	"\x15CreatePaymentResponse\x12.\n" +
-	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\x12\x1d\n" +
-	"\n" +
-	"from_cache\x18\x02 \x01(\bR\tfromCache\"j\n" +
-	"\x11GetPaymentRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x02 \x01(\tR\tpaymentId\"D\n" +
-	"\x12GetPaymentResponse\x12.\n" +
-	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\"n\n" +
-	"\x15ConfirmPaymentRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x02 \x01(\tR\tpaymentId\"H\n" +
-	"\x16ConfirmPaymentResponse\x12.\n" +
-	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\"\x85\x01\n" +
-	"\x14CancelPaymentRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"G\n" +
-	"\x15CancelPaymentResponse\x12.\n" +
-	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\"\xaf\x01\n" +
-	"\x14RefundPaymentRequest\x126\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12(\n" +
+func (x *GetProviderCircuitRequest) Reset() {
+	*x = GetProviderCircuitRequest{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProviderCircuitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderCircuitRequest) ProtoMessage() {}
+
+func (x *GetProviderCircuitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderCircuitRequest.ProtoReflect.Descriptor instead.
+func (*GetProviderCircuitRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetProviderCircuitRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetProviderCircuitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Circuit       *ProviderCircuit       `protobuf:"bytes,1,opt,name=circuit,proto3" json:"circuit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProviderCircuitResponse) Reset() {
+	*x = GetProviderCircuitResponse{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProviderCircuitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderCircuitResponse) ProtoMessage() {}
+
+func (x *GetProviderCircuitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderCircuitResponse.ProtoReflect.Descriptor instead.
+func (*GetProviderCircuitResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetProviderCircuitResponse) GetCircuit() *ProviderCircuit {
+	if x != nil {
+		return x.Circuit
+	}
+	return nil
+}
+
+type ReconcilePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcilePaymentRequest) Reset() {
+	*x = ReconcilePaymentRequest{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcilePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcilePaymentRequest) ProtoMessage() {}
+
+func (x *ReconcilePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcilePaymentRequest.ProtoReflect.Descriptor instead.
+func (*ReconcilePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReconcilePaymentRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ReconcilePaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+type ReconcilePaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	Corrected     bool                   `protobuf:"varint,2,opt,name=corrected,proto3" json:"corrected,omitempty"` // The local status disagreed with the provider and was updated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcilePaymentResponse) Reset() {
+	*x = ReconcilePaymentResponse{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcilePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcilePaymentResponse) ProtoMessage() {}
+
+func (x *ReconcilePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcilePaymentResponse.ProtoReflect.Descriptor instead.
+func (*ReconcilePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReconcilePaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *ReconcilePaymentResponse) GetCorrected() bool {
+	if x != nil {
+		return x.Corrected
+	}
+	return false
+}
+
+type ListPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Status        PaymentStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=payments.v1.PaymentStatus" json:"status,omitempty"` // UNSPECIFIED matches every status
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // Inclusive; unset leaves the range open
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // Exclusive; unset leaves the range open
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsRequest) Reset() {
+	*x = ListPaymentsRequest{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListPaymentsRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *ListPaymentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+// PaymentStatusTotal counts and sums the matching payments in one status and currency
+type PaymentStatusTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        PaymentStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=payments.v1.PaymentStatus" json:"status,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Amount        *v1.Money              `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentStatusTotal) Reset() {
+	*x = PaymentStatusTotal{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentStatusTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentStatusTotal) ProtoMessage() {}
+
+func (x *PaymentStatusTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentStatusTotal.ProtoReflect.Descriptor instead.
+func (*PaymentStatusTotal) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PaymentStatusTotal) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *PaymentStatusTotal) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PaymentStatusTotal) GetAmount() *v1.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type ListPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"` // Newest first
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Totals        []*PaymentStatusTotal  `protobuf:"bytes,3,rep,name=totals,proto3" json:"totals,omitempty"` // Over every matching payment, not just this page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsResponse) Reset() {
+	*x = ListPaymentsResponse{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *ListPaymentsResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListPaymentsResponse) GetTotals() []*PaymentStatusTotal {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+type ResetProviderCircuitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetProviderCircuitRequest) Reset() {
+	*x = ResetProviderCircuitRequest{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetProviderCircuitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetProviderCircuitRequest) ProtoMessage() {}
+
+func (x *ResetProviderCircuitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetProviderCircuitRequest.ProtoReflect.Descriptor instead.
+func (*ResetProviderCircuitRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResetProviderCircuitRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ResetProviderCircuitRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResetProviderCircuitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Previous      *ProviderCircuit       `protobuf:"bytes,1,opt,name=previous,proto3" json:"previous,omitempty"` // State before the reset
+	Circuit       *ProviderCircuit       `protobuf:"bytes,2,opt,name=circuit,proto3" json:"circuit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetProviderCircuitResponse) Reset() {
+	*x = ResetProviderCircuitResponse{}
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetProviderCircuitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetProviderCircuitResponse) ProtoMessage() {}
+
+func (x *ResetProviderCircuitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payments_v1_payments_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetProviderCircuitResponse.ProtoReflect.Descriptor instead.
+func (*ResetProviderCircuitResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payments_v1_payments_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResetProviderCircuitResponse) GetPrevious() *ProviderCircuit {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *ResetProviderCircuitResponse) GetCircuit() *ProviderCircuit {
+	if x != nil {
+		return x.Circuit
+	}
+	return nil
+}
+
+var File_proto_payments_v1_payments_proto protoreflect.FileDescriptor
+
+const file_proto_payments_v1_payments_proto_rawDesc = "" +
+	"\n" +
+	" proto/payments/v1/payments.proto\x12\vpayments.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/common/v1/common.proto\"^\n" +
+	"\x0fProviderCircuit\x12/\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x19.payments.v1.CircuitStateR\x05state\x12\x1a\n" +
+	"\bfailures\x18\x02 \x01(\rR\bfailures\"\xed\x03\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12(\n" +
+	"\x06amount\x18\x04 \x01(\v2\x10.common.v1.MoneyR\x06amount\x122\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1a.payments.v1.PaymentStatusR\x06status\x122\n" +
+	"\x06method\x18\x06 \x01(\x0e2\x1a.payments.v1.PaymentMethodR\x06method\x126\n" +
+	"\x17provider_transaction_id\x18\a \x01(\tR\x15providerTransactionId\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\x0frefunded_amount\x18\v \x01(\v2\x10.common.v1.MoneyR\x0erefundedAmount\"\xac\x03\n" +
+	"\x14CreatePaymentRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12'\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12(\n" +
+	"\x06amount\x18\x05 \x01(\v2\x10.common.v1.MoneyR\x06amount\x122\n" +
+	"\x06method\x18\x06 \x01(\x0e2\x1a.payments.v1.PaymentMethodR\x06method\x12^\n" +
+	"\x0fpayment_details\x18\a \x03(\v25.payments.v1.CreatePaymentRequest.PaymentDetailsEntryR\x0epaymentDetails\x1aA\n" +
+	"\x13PaymentDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"f\n" +
+	"\x15CreatePaymentResponse\x12.\n" +
+	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\x12\x1d\n" +
+	"\n" +
+	"from_cache\x18\x02 \x01(\bR\tfromCache\"j\n" +
+	"\x11GetPaymentRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\"D\n" +
+	"\x12GetPaymentResponse\x12.\n" +
+	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\"p\n" +
+	"\x1bGetPaymentsByOrderIDRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"P\n" +
+	"\x1cGetPaymentsByOrderIDResponse\x120\n" +
+	"\bpayments\x18\x01 \x03(\v2\x14.payments.v1.PaymentR\bpayments\"n\n" +
+	"\x15ConfirmPaymentRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\"H\n" +
+	"\x16ConfirmPaymentResponse\x12.\n" +
+	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\"\x85\x01\n" +
+	"\x14CancelPaymentRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"G\n" +
+	"\x15CancelPaymentResponse\x12.\n" +
+	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\"\xaf\x01\n" +
+	"\x14RefundPaymentRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12(\n" +
 	"\x06amount\x18\x03 \x01(\v2\x10.common.v1.MoneyR\x06amount\x12\x16\n" +
 	"\x06reason\x18\x04 \x01(\tR\x06reason\"d\n" +
 	"\x15RefundPaymentResponse\x12.\n" +
 	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\x12\x1b\n" +
-	"\trefund_id\x18\x02 \x01(\tR\brefundId*\xde\x01\n" +
+	"\trefund_id\x18\x02 \x01(\tR\brefundId\"S\n" +
+	"\x19GetProviderCircuitRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\"T\n" +
+	"\x1aGetProviderCircuitResponse\x126\n" +
+	"\acircuit\x18\x01 \x01(\v2\x1c.payments.v1.ProviderCircuitR\acircuit\"p\n" +
+	"\x17ReconcilePaymentRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\"h\n" +
+	"\x18ReconcilePaymentResponse\x12.\n" +
+	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\x12\x1c\n" +
+	"\tcorrected\x18\x02 \x01(\bR\tcorrected\"\xed\x02\n" +
+	"\x13ListPaymentsRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
+	"pagination\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.payments.v1.PaymentStatusR\x06status\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\x12=\n" +
+	"\fcreated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\"\x88\x01\n" +
+	"\x12PaymentStatusTotal\x122\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1a.payments.v1.PaymentStatusR\x06status\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12(\n" +
+	"\x06amount\x18\x03 \x01(\v2\x10.common.v1.MoneyR\x06amount\"\xc0\x01\n" +
+	"\x14ListPaymentsResponse\x120\n" +
+	"\bpayments\x18\x01 \x03(\v2\x14.payments.v1.PaymentR\bpayments\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"pagination\x127\n" +
+	"\x06totals\x18\x03 \x03(\v2\x1f.payments.v1.PaymentStatusTotalR\x06totals\"m\n" +
+	"\x1bResetProviderCircuitRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x90\x01\n" +
+	"\x1cResetProviderCircuitResponse\x128\n" +
+	"\bprevious\x18\x01 \x01(\v2\x1c.payments.v1.ProviderCircuitR\bprevious\x126\n" +
+	"\acircuit\x18\x02 \x01(\v2\x1c.payments.v1.ProviderCircuitR\acircuit*\xdd\x01\n" +
 	"\rPaymentStatus\x12\x1e\n" +
 	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x1d\n" +
 	"\x19PAYMENT_STATUS_PROCESSING\x10\x02\x12\x1c\n" +
 	"\x18PAYMENT_STATUS_SUCCEEDED\x10\x03\x12\x19\n" +
-	"\x15PAYMENT_STATUS_FAILED\x10\x04\x12\x1c\n" +
-	"\x18PAYMENT_STATUS_CANCELED\x10\x05\x12\x1b\n" +
+	"\x15PAYMENT_STATUS_FAILED\x10\x04\x12\x1b\n" +
+	"\x17PAYMENT_STATUS_CANCELED\x10\x05\x12\x1b\n" +
 	"\x17PAYMENT_STATUS_REFUNDED\x10\x06*\x85\x01\n" +
 	"\rPaymentMethod\x12\x1e\n" +
 	"\x1aPAYMENT_METHOD_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13PAYMENT_METHOD_CARD\x10\x01\x12\x19\n" +
 	"\x15PAYMENT_METHOD_PAYPAL\x10\x02\x12 \n" +
-	"\x1cPAYMENT_METHOD_BANK_TRANSFER\x10\x032\xc2\x03\n" +
+	"\x1cPAYMENT_METHOD_BANK_TRANSFER\x10\x03*|\n" +
+	"\fCircuitState\x12\x1d\n" +
+	"\x19CIRCUIT_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14CIRCUIT_STATE_CLOSED\x10\x01\x12\x1b\n" +
+	"\x17CIRCUIT_STATE_HALF_OPEN\x10\x02\x12\x16\n" +
+	"\x12CIRCUIT_STATE_OPEN\x10\x032\xb9\a\n" +
 	"\x0ePaymentService\x12V\n" +
 	"\rCreatePayment\x12!.payments.v1.CreatePaymentRequest\x1a\".payments.v1.CreatePaymentResponse\x12M\n" +
 	"\n" +
-	"GetPayment\x12\x1e.payments.v1.GetPaymentRequest\x1a\x1f.payments.v1.GetPaymentResponse\x12Y\n" +
+	"GetPayment\x12\x1e.payments.v1.GetPaymentRequest\x1a\x1f.payments.v1.GetPaymentResponse\x12k\n" +
+	"\x14GetPaymentsByOrderID\x12(.payments.v1.GetPaymentsByOrderIDRequest\x1a).payments.v1.GetPaymentsByOrderIDResponse\x12Y\n" +
 	"\x0eConfirmPayment\x12\".payments.v1.ConfirmPaymentRequest\x1a#.payments.v1.ConfirmPaymentResponse\x12V\n" +
 	"\rCancelPayment\x12!.payments.v1.CancelPaymentRequest\x1a\".payments.v1.CancelPaymentResponse\x12V\n" +
-	"\rRefundPayment\x12!.payments.v1.RefundPaymentRequest\x1a\".payments.v1.RefundPaymentResponseB8Z6github.com/mumumio1/coldy/proto/payments/v1;paymentsv1b\x06proto3"
+	"\rRefundPayment\x12!.payments.v1.RefundPaymentRequest\x1a\".payments.v1.RefundPaymentResponse\x12e\n" +
+	"\x12GetProviderCircuit\x12&.payments.v1.GetProviderCircuitRequest\x1a'.payments.v1.GetProviderCircuitResponse\x12k\n" +
+	"\x14ResetProviderCircuit\x12(.payments.v1.ResetProviderCircuitRequest\x1a).payments.v1.ResetProviderCircuitResponse\x12_\n" +
+	"\x10ReconcilePayment\x12$.payments.v1.ReconcilePaymentRequest\x1a%.payments.v1.ReconcilePaymentResponse\x12S\n" +
+	"\fListPayments\x12 .payments.v1.ListPaymentsRequest\x1a!.payments.v1.ListPaymentsResponseB8Z6github.com/mumumio1/coldy/proto/payments/v1;paymentsv1b\x06proto3"
 
 var (
 	file_proto_payments_v1_payments_proto_rawDescOnce sync.Once
@@ -907,62 +1682,108 @@ func file_proto_payments_v1_payments_proto_rawDescGZIP() []byte {
 	return file_proto_payments_v1_payments_proto_rawDescData
 }
 
-var file_proto_payments_v1_payments_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_payments_v1_payments_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_payments_v1_payments_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_payments_v1_payments_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_proto_payments_v1_payments_proto_goTypes = []any{
-	(PaymentStatus)(0),             // 0: payments.v1.PaymentStatus
-	(PaymentMethod)(0),             // 1: payments.v1.PaymentMethod
-	(*Payment)(nil),                // 2: payments.v1.Payment
-	(*CreatePaymentRequest)(nil),   // 3: payments.v1.CreatePaymentRequest
-	(*CreatePaymentResponse)(nil),  // 4: payments.v1.CreatePaymentResponse
-	(*GetPaymentRequest)(nil),      // 5: payments.v1.GetPaymentRequest
-	(*GetPaymentResponse)(nil),     // 6: payments.v1.GetPaymentResponse
-	(*ConfirmPaymentRequest)(nil),  // 7: payments.v1.ConfirmPaymentRequest
-	(*ConfirmPaymentResponse)(nil), // 8: payments.v1.ConfirmPaymentResponse
-	(*CancelPaymentRequest)(nil),   // 9: payments.v1.CancelPaymentRequest
-	(*CancelPaymentResponse)(nil),  // 10: payments.v1.CancelPaymentResponse
-	(*RefundPaymentRequest)(nil),   // 11: payments.v1.RefundPaymentRequest
-	(*RefundPaymentResponse)(nil),  // 12: payments.v1.RefundPaymentResponse
-	nil,                            // 13: payments.v1.CreatePaymentRequest.PaymentDetailsEntry
-	(*v1.Money)(nil),               // 14: common.v1.Money
-	(*timestamppb.Timestamp)(nil),  // 15: google.protobuf.Timestamp
-	(*v1.RequestMetadata)(nil),     // 16: common.v1.RequestMetadata
+	(PaymentStatus)(0),                   // 0: payments.v1.PaymentStatus
+	(PaymentMethod)(0),                   // 1: payments.v1.PaymentMethod
+	(CircuitState)(0),                    // 2: payments.v1.CircuitState
+	(*ProviderCircuit)(nil),              // 3: payments.v1.ProviderCircuit
+	(*Payment)(nil),                      // 4: payments.v1.Payment
+	(*CreatePaymentRequest)(nil),         // 5: payments.v1.CreatePaymentRequest
+	(*CreatePaymentResponse)(nil),        // 6: payments.v1.CreatePaymentResponse
+	(*GetPaymentRequest)(nil),            // 7: payments.v1.GetPaymentRequest
+	(*GetPaymentResponse)(nil),           // 8: payments.v1.GetPaymentResponse
+	(*GetPaymentsByOrderIDRequest)(nil),  // 9: payments.v1.GetPaymentsByOrderIDRequest
+	(*GetPaymentsByOrderIDResponse)(nil), // 10: payments.v1.GetPaymentsByOrderIDResponse
+	(*ConfirmPaymentRequest)(nil),        // 11: payments.v1.ConfirmPaymentRequest
+	(*ConfirmPaymentResponse)(nil),       // 12: payments.v1.ConfirmPaymentResponse
+	(*CancelPaymentRequest)(nil),         // 13: payments.v1.CancelPaymentRequest
+	(*CancelPaymentResponse)(nil),        // 14: payments.v1.CancelPaymentResponse
+	(*RefundPaymentRequest)(nil),         // 15: payments.v1.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),        // 16: payments.v1.RefundPaymentResponse
+	(*GetProviderCircuitRequest)(nil),    // 17: payments.v1.GetProviderCircuitRequest
+	(*GetProviderCircuitResponse)(nil),   // 18: payments.v1.GetProviderCircuitResponse
+	(*ReconcilePaymentRequest)(nil),      // 19: payments.v1.ReconcilePaymentRequest
+	(*ReconcilePaymentResponse)(nil),     // 20: payments.v1.ReconcilePaymentResponse
+	(*ListPaymentsRequest)(nil),          // 21: payments.v1.ListPaymentsRequest
+	(*PaymentStatusTotal)(nil),           // 22: payments.v1.PaymentStatusTotal
+	(*ListPaymentsResponse)(nil),         // 23: payments.v1.ListPaymentsResponse
+	(*ResetProviderCircuitRequest)(nil),  // 24: payments.v1.ResetProviderCircuitRequest
+	(*ResetProviderCircuitResponse)(nil), // 25: payments.v1.ResetProviderCircuitResponse
+	nil,                                  // 26: payments.v1.CreatePaymentRequest.PaymentDetailsEntry
+	(*v1.Money)(nil),                     // 27: common.v1.Money
+	(*timestamppb.Timestamp)(nil),        // 28: google.protobuf.Timestamp
+	(*v1.RequestMetadata)(nil),           // 29: common.v1.RequestMetadata
+	(*v1.PaginationRequest)(nil),         // 30: common.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),        // 31: common.v1.PaginationResponse
 }
 var file_proto_payments_v1_payments_proto_depIdxs = []int32{
-	14, // 0: payments.v1.Payment.amount:type_name -> common.v1.Money
-	0,  // 1: payments.v1.Payment.status:type_name -> payments.v1.PaymentStatus
-	1,  // 2: payments.v1.Payment.method:type_name -> payments.v1.PaymentMethod
-	15, // 3: payments.v1.Payment.created_at:type_name -> google.protobuf.Timestamp
-	15, // 4: payments.v1.Payment.updated_at:type_name -> google.protobuf.Timestamp
-	16, // 5: payments.v1.CreatePaymentRequest.metadata:type_name -> common.v1.RequestMetadata
-	14, // 6: payments.v1.CreatePaymentRequest.amount:type_name -> common.v1.Money
-	1,  // 7: payments.v1.CreatePaymentRequest.method:type_name -> payments.v1.PaymentMethod
-	13, // 8: payments.v1.CreatePaymentRequest.payment_details:type_name -> payments.v1.CreatePaymentRequest.PaymentDetailsEntry
-	2,  // 9: payments.v1.CreatePaymentResponse.payment:type_name -> payments.v1.Payment
-	16, // 10: payments.v1.GetPaymentRequest.metadata:type_name -> common.v1.RequestMetadata
-	2,  // 11: payments.v1.GetPaymentResponse.payment:type_name -> payments.v1.Payment
-	16, // 12: payments.v1.ConfirmPaymentRequest.metadata:type_name -> common.v1.RequestMetadata
-	2,  // 13: payments.v1.ConfirmPaymentResponse.payment:type_name -> payments.v1.Payment
-	16, // 14: payments.v1.CancelPaymentRequest.metadata:type_name -> common.v1.RequestMetadata
-	2,  // 15: payments.v1.CancelPaymentResponse.payment:type_name -> payments.v1.Payment
-	16, // 16: payments.v1.RefundPaymentRequest.metadata:type_name -> common.v1.RequestMetadata
-	14, // 17: payments.v1.RefundPaymentRequest.amount:type_name -> common.v1.Money
-	2,  // 18: payments.v1.RefundPaymentResponse.payment:type_name -> payments.v1.Payment
-	3,  // 19: payments.v1.PaymentService.CreatePayment:input_type -> payments.v1.CreatePaymentRequest
-	5,  // 20: payments.v1.PaymentService.GetPayment:input_type -> payments.v1.GetPaymentRequest
-	7,  // 21: payments.v1.PaymentService.ConfirmPayment:input_type -> payments.v1.ConfirmPaymentRequest
-	9,  // 22: payments.v1.PaymentService.CancelPayment:input_type -> payments.v1.CancelPaymentRequest
-	11, // 23: payments.v1.PaymentService.RefundPayment:input_type -> payments.v1.RefundPaymentRequest
-	4,  // 24: payments.v1.PaymentService.CreatePayment:output_type -> payments.v1.CreatePaymentResponse
-	6,  // 25: payments.v1.PaymentService.GetPayment:output_type -> payments.v1.GetPaymentResponse
-	8,  // 26: payments.v1.PaymentService.ConfirmPayment:output_type -> payments.v1.ConfirmPaymentResponse
-	10, // 27: payments.v1.PaymentService.CancelPayment:output_type -> payments.v1.CancelPaymentResponse
-	12, // 28: payments.v1.PaymentService.RefundPayment:output_type -> payments.v1.RefundPaymentResponse
-	24, // [24:29] is the sub-list for method output_type
-	19, // [19:24] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	2,  // 0: payments.v1.ProviderCircuit.state:type_name -> payments.v1.CircuitState
+	27, // 1: payments.v1.Payment.amount:type_name -> common.v1.Money
+	0,  // 2: payments.v1.Payment.status:type_name -> payments.v1.PaymentStatus
+	1,  // 3: payments.v1.Payment.method:type_name -> payments.v1.PaymentMethod
+	28, // 4: payments.v1.Payment.created_at:type_name -> google.protobuf.Timestamp
+	28, // 5: payments.v1.Payment.updated_at:type_name -> google.protobuf.Timestamp
+	27, // 6: payments.v1.Payment.refunded_amount:type_name -> common.v1.Money
+	29, // 7: payments.v1.CreatePaymentRequest.metadata:type_name -> common.v1.RequestMetadata
+	27, // 8: payments.v1.CreatePaymentRequest.amount:type_name -> common.v1.Money
+	1,  // 9: payments.v1.CreatePaymentRequest.method:type_name -> payments.v1.PaymentMethod
+	26, // 10: payments.v1.CreatePaymentRequest.payment_details:type_name -> payments.v1.CreatePaymentRequest.PaymentDetailsEntry
+	4,  // 11: payments.v1.CreatePaymentResponse.payment:type_name -> payments.v1.Payment
+	29, // 12: payments.v1.GetPaymentRequest.metadata:type_name -> common.v1.RequestMetadata
+	4,  // 13: payments.v1.GetPaymentResponse.payment:type_name -> payments.v1.Payment
+	29, // 14: payments.v1.GetPaymentsByOrderIDRequest.metadata:type_name -> common.v1.RequestMetadata
+	4,  // 15: payments.v1.GetPaymentsByOrderIDResponse.payments:type_name -> payments.v1.Payment
+	29, // 16: payments.v1.ConfirmPaymentRequest.metadata:type_name -> common.v1.RequestMetadata
+	4,  // 17: payments.v1.ConfirmPaymentResponse.payment:type_name -> payments.v1.Payment
+	29, // 18: payments.v1.CancelPaymentRequest.metadata:type_name -> common.v1.RequestMetadata
+	4,  // 19: payments.v1.CancelPaymentResponse.payment:type_name -> payments.v1.Payment
+	29, // 20: payments.v1.RefundPaymentRequest.metadata:type_name -> common.v1.RequestMetadata
+	27, // 21: payments.v1.RefundPaymentRequest.amount:type_name -> common.v1.Money
+	4,  // 22: payments.v1.RefundPaymentResponse.payment:type_name -> payments.v1.Payment
+	29, // 23: payments.v1.GetProviderCircuitRequest.metadata:type_name -> common.v1.RequestMetadata
+	3,  // 24: payments.v1.GetProviderCircuitResponse.circuit:type_name -> payments.v1.ProviderCircuit
+	29, // 25: payments.v1.ReconcilePaymentRequest.metadata:type_name -> common.v1.RequestMetadata
+	4,  // 26: payments.v1.ReconcilePaymentResponse.payment:type_name -> payments.v1.Payment
+	29, // 27: payments.v1.ListPaymentsRequest.metadata:type_name -> common.v1.RequestMetadata
+	30, // 28: payments.v1.ListPaymentsRequest.pagination:type_name -> common.v1.PaginationRequest
+	0,  // 29: payments.v1.ListPaymentsRequest.status:type_name -> payments.v1.PaymentStatus
+	28, // 30: payments.v1.ListPaymentsRequest.created_from:type_name -> google.protobuf.Timestamp
+	28, // 31: payments.v1.ListPaymentsRequest.created_to:type_name -> google.protobuf.Timestamp
+	0,  // 32: payments.v1.PaymentStatusTotal.status:type_name -> payments.v1.PaymentStatus
+	27, // 33: payments.v1.PaymentStatusTotal.amount:type_name -> common.v1.Money
+	4,  // 34: payments.v1.ListPaymentsResponse.payments:type_name -> payments.v1.Payment
+	31, // 35: payments.v1.ListPaymentsResponse.pagination:type_name -> common.v1.PaginationResponse
+	22, // 36: payments.v1.ListPaymentsResponse.totals:type_name -> payments.v1.PaymentStatusTotal
+	29, // 37: payments.v1.ResetProviderCircuitRequest.metadata:type_name -> common.v1.RequestMetadata
+	3,  // 38: payments.v1.ResetProviderCircuitResponse.previous:type_name -> payments.v1.ProviderCircuit
+	3,  // 39: payments.v1.ResetProviderCircuitResponse.circuit:type_name -> payments.v1.ProviderCircuit
+	5,  // 40: payments.v1.PaymentService.CreatePayment:input_type -> payments.v1.CreatePaymentRequest
+	7,  // 41: payments.v1.PaymentService.GetPayment:input_type -> payments.v1.GetPaymentRequest
+	9,  // 42: payments.v1.PaymentService.GetPaymentsByOrderID:input_type -> payments.v1.GetPaymentsByOrderIDRequest
+	11, // 43: payments.v1.PaymentService.ConfirmPayment:input_type -> payments.v1.ConfirmPaymentRequest
+	13, // 44: payments.v1.PaymentService.CancelPayment:input_type -> payments.v1.CancelPaymentRequest
+	15, // 45: payments.v1.PaymentService.RefundPayment:input_type -> payments.v1.RefundPaymentRequest
+	17, // 46: payments.v1.PaymentService.GetProviderCircuit:input_type -> payments.v1.GetProviderCircuitRequest
+	24, // 47: payments.v1.PaymentService.ResetProviderCircuit:input_type -> payments.v1.ResetProviderCircuitRequest
+	19, // 48: payments.v1.PaymentService.ReconcilePayment:input_type -> payments.v1.ReconcilePaymentRequest
+	21, // 49: payments.v1.PaymentService.ListPayments:input_type -> payments.v1.ListPaymentsRequest
+	6,  // 50: payments.v1.PaymentService.CreatePayment:output_type -> payments.v1.CreatePaymentResponse
+	8,  // 51: payments.v1.PaymentService.GetPayment:output_type -> payments.v1.GetPaymentResponse
+	10, // 52: payments.v1.PaymentService.GetPaymentsByOrderID:output_type -> payments.v1.GetPaymentsByOrderIDResponse
+	12, // 53: payments.v1.PaymentService.ConfirmPayment:output_type -> payments.v1.ConfirmPaymentResponse
+	14, // 54: payments.v1.PaymentService.CancelPayment:output_type -> payments.v1.CancelPaymentResponse
+	16, // 55: payments.v1.PaymentService.RefundPayment:output_type -> payments.v1.RefundPaymentResponse
+	18, // 56: payments.v1.PaymentService.GetProviderCircuit:output_type -> payments.v1.GetProviderCircuitResponse
+	25, // 57: payments.v1.PaymentService.ResetProviderCircuit:output_type -> payments.v1.ResetProviderCircuitResponse
+	20, // 58: payments.v1.PaymentService.ReconcilePayment:output_type -> payments.v1.ReconcilePaymentResponse
+	23, // 59: payments.v1.PaymentService.ListPayments:output_type -> payments.v1.ListPaymentsResponse
+	50, // [50:60] is the sub-list for method output_type
+	40, // [40:50] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_proto_payments_v1_payments_proto_init() }
@@ -975,8 +1796,8 @@ func file_proto_payments_v1_payments_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_payments_v1_payments_proto_rawDesc), len(file_proto_payments_v1_payments_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   12,
+			NumEnums:      3,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PaymentService_CreatePayment_FullMethodName  = "/payments.v1.PaymentService/CreatePayment"
-	PaymentService_GetPayment_FullMethodName     = "/payments.v1.PaymentService/GetPayment"
-	PaymentService_ConfirmPayment_FullMethodName = "/payments.v1.PaymentService/ConfirmPayment"
-	PaymentService_CancelPayment_FullMethodName  = "/payments.v1.PaymentService/CancelPayment"
-	PaymentService_RefundPayment_FullMethodName  = "/payments.v1.PaymentService/RefundPayment"
+	PaymentService_CreatePayment_FullMethodName        = "/payments.v1.PaymentService/CreatePayment"
+	PaymentService_GetPayment_FullMethodName           = "/payments.v1.PaymentService/GetPayment"
+	PaymentService_GetPaymentsByOrderID_FullMethodName = "/payments.v1.PaymentService/GetPaymentsByOrderID"
+	PaymentService_ConfirmPayment_FullMethodName       = "/payments.v1.PaymentService/ConfirmPayment"
+	PaymentService_CancelPayment_FullMethodName        = "/payments.v1.PaymentService/CancelPayment"
+	PaymentService_RefundPayment_FullMethodName        = "/payments.v1.PaymentService/RefundPayment"
+	PaymentService_GetProviderCircuit_FullMethodName   = "/payments.v1.PaymentService/GetProviderCircuit"
+	PaymentService_ResetProviderCircuit_FullMethodName = "/payments.v1.PaymentService/ResetProviderCircuit"
+	PaymentService_ReconcilePayment_FullMethodName     = "/payments.v1.PaymentService/ReconcilePayment"
+	PaymentService_ListPayments_FullMethodName         = "/payments.v1.PaymentService/ListPayments"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -32,9 +37,14 @@ const (
 type PaymentServiceClient interface {
 	CreatePayment(ctx context.Context, in *CreatePaymentRequest, opts ...grpc.CallOption) (*CreatePaymentResponse, error)
 	GetPayment(ctx context.Context, in *GetPaymentRequest, opts ...grpc.CallOption) (*GetPaymentResponse, error)
+	GetPaymentsByOrderID(ctx context.Context, in *GetPaymentsByOrderIDRequest, opts ...grpc.CallOption) (*GetPaymentsByOrderIDResponse, error)
 	ConfirmPayment(ctx context.Context, in *ConfirmPaymentRequest, opts ...grpc.CallOption) (*ConfirmPaymentResponse, error)
 	CancelPayment(ctx context.Context, in *CancelPaymentRequest, opts ...grpc.CallOption) (*CancelPaymentResponse, error)
 	RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error)
+	GetProviderCircuit(ctx context.Context, in *GetProviderCircuitRequest, opts ...grpc.CallOption) (*GetProviderCircuitResponse, error)
+	ResetProviderCircuit(ctx context.Context, in *ResetProviderCircuitRequest, opts ...grpc.CallOption) (*ResetProviderCircuitResponse, error)
+	ReconcilePayment(ctx context.Context, in *ReconcilePaymentRequest, opts ...grpc.CallOption) (*ReconcilePaymentResponse, error)
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -65,6 +75,16 @@ func (c *paymentServiceClient) GetPayment(ctx context.Context, in *GetPaymentReq
 	return out, nil
 }
 
+func (c *paymentServiceClient) GetPaymentsByOrderID(ctx context.Context, in *GetPaymentsByOrderIDRequest, opts ...grpc.CallOption) (*GetPaymentsByOrderIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPaymentsByOrderIDResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetPaymentsByOrderID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *paymentServiceClient) ConfirmPayment(ctx context.Context, in *ConfirmPaymentRequest, opts ...grpc.CallOption) (*ConfirmPaymentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ConfirmPaymentResponse)
@@ -95,15 +115,60 @@ func (c *paymentServiceClient) RefundPayment(ctx context.Context, in *RefundPaym
 	return out, nil
 }
 
+func (c *paymentServiceClient) GetProviderCircuit(ctx context.Context, in *GetProviderCircuitRequest, opts ...grpc.CallOption) (*GetProviderCircuitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProviderCircuitResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetProviderCircuit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ResetProviderCircuit(ctx context.Context, in *ResetProviderCircuitRequest, opts ...grpc.CallOption) (*ResetProviderCircuitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetProviderCircuitResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ResetProviderCircuit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ReconcilePayment(ctx context.Context, in *ReconcilePaymentRequest, opts ...grpc.CallOption) (*ReconcilePaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcilePaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ReconcilePayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPaymentsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility.
 type PaymentServiceServer interface {
 	CreatePayment(context.Context, *CreatePaymentRequest) (*CreatePaymentResponse, error)
 	GetPayment(context.Context, *GetPaymentRequest) (*GetPaymentResponse, error)
+	GetPaymentsByOrderID(context.Context, *GetPaymentsByOrderIDRequest) (*GetPaymentsByOrderIDResponse, error)
 	ConfirmPayment(context.Context, *ConfirmPaymentRequest) (*ConfirmPaymentResponse, error)
 	CancelPayment(context.Context, *CancelPaymentRequest) (*CancelPaymentResponse, error)
 	RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error)
+	GetProviderCircuit(context.Context, *GetProviderCircuitRequest) (*GetProviderCircuitResponse, error)
+	ResetProviderCircuit(context.Context, *ResetProviderCircuitRequest) (*ResetProviderCircuitResponse, error)
+	ReconcilePayment(context.Context, *ReconcilePaymentRequest) (*ReconcilePaymentResponse, error)
+	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -120,6 +185,9 @@ func (UnimplementedPaymentServiceServer) CreatePayment(context.Context, *CreateP
 func (UnimplementedPaymentServiceServer) GetPayment(context.Context, *GetPaymentRequest) (*GetPaymentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPayment not implemented")
 }
+func (UnimplementedPaymentServiceServer) GetPaymentsByOrderID(context.Context, *GetPaymentsByOrderIDRequest) (*GetPaymentsByOrderIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentsByOrderID not implemented")
+}
 func (UnimplementedPaymentServiceServer) ConfirmPayment(context.Context, *ConfirmPaymentRequest) (*ConfirmPaymentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPayment not implemented")
 }
@@ -129,6 +197,18 @@ func (UnimplementedPaymentServiceServer) CancelPayment(context.Context, *CancelP
 func (UnimplementedPaymentServiceServer) RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefundPayment not implemented")
 }
+func (UnimplementedPaymentServiceServer) GetProviderCircuit(context.Context, *GetProviderCircuitRequest) (*GetProviderCircuitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProviderCircuit not implemented")
+}
+func (UnimplementedPaymentServiceServer) ResetProviderCircuit(context.Context, *ResetProviderCircuitRequest) (*ResetProviderCircuitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetProviderCircuit not implemented")
+}
+func (UnimplementedPaymentServiceServer) ReconcilePayment(context.Context, *ReconcilePaymentRequest) (*ReconcilePaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcilePayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPayments not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
 
@@ -186,6 +266,24 @@ func _PaymentService_GetPayment_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_GetPaymentsByOrderID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPaymentsByOrderIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetPaymentsByOrderID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetPaymentsByOrderID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetPaymentsByOrderID(ctx, req.(*GetPaymentsByOrderIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PaymentService_ConfirmPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ConfirmPaymentRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +338,78 @@ func _PaymentService_RefundPayment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_GetProviderCircuit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderCircuitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetProviderCircuit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetProviderCircuit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetProviderCircuit(ctx, req.(*GetProviderCircuitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ResetProviderCircuit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetProviderCircuitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ResetProviderCircuit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ResetProviderCircuit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ResetProviderCircuit(ctx, req.(*ResetProviderCircuitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ReconcilePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcilePaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ReconcilePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ReconcilePayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ReconcilePayment(ctx, req.(*ReconcilePaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -255,6 +425,10 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPayment",
 			Handler:    _PaymentService_GetPayment_Handler,
 		},
+		{
+			MethodName: "GetPaymentsByOrderID",
+			Handler:    _PaymentService_GetPaymentsByOrderID_Handler,
+		},
 		{
 			MethodName: "ConfirmPayment",
 			Handler:    _PaymentService_ConfirmPayment_Handler,
@@ -267,6 +441,22 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefundPayment",
 			Handler:    _PaymentService_RefundPayment_Handler,
 		},
+		{
+			MethodName: "GetProviderCircuit",
+			Handler:    _PaymentService_GetProviderCircuit_Handler,
+		},
+		{
+			MethodName: "ResetProviderCircuit",
+			Handler:    _PaymentService_ResetProviderCircuit_Handler,
+		},
+		{
+			MethodName: "ReconcilePayment",
+			Handler:    _PaymentService_ReconcilePayment_Handler,
+		},
+		{
+			MethodName: "ListPayments",
+			Handler:    _PaymentService_ListPayments_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/payments/v1/payments.proto",
@@ -10,6 +10,7 @@ import (
 	v1 "github.com/mumumio1/coldy/proto/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -467,20 +468,112 @@ func (x *GetUserResponse) GetUser() *User {
 	return nil
 }
 
-type UpdateUserRequest struct {
+type GetMeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
-	Address       *v1.Address            `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMeRequest) Reset() {
+	*x = GetMeRequest{}
+	mi := &file_proto_users_v1_users_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeRequest) ProtoMessage() {}
+
+func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_users_v1_users_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeRequest.ProtoReflect.Descriptor instead.
+func (*GetMeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMeRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMeResponse) Reset() {
+	*x = GetMeResponse{}
+	mi := &file_proto_users_v1_users_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeResponse) ProtoMessage() {}
+
+func (x *GetMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_users_v1_users_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeResponse.ProtoReflect.Descriptor instead.
+func (*GetMeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetMeResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type UpdateUserRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Metadata *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FullName string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Phone    string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Address  *v1.Address            `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	// Fields to update: full_name, phone. Listed fields are set even when
+	// empty; an empty mask changes nothing. Without a mask only non-empty
+	// fields are updated.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_proto_users_v1_users_proto_msgTypes[7]
+	mi := &file_proto_users_v1_users_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -492,7 +585,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_users_v1_users_proto_msgTypes[7]
+	mi := &file_proto_users_v1_users_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +598,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{7}
+	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateUserRequest) GetMetadata() *v1.RequestMetadata {
@@ -543,6 +636,13 @@ func (x *UpdateUserRequest) GetAddress() *v1.Address {
 	return nil
 }
 
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -552,7 +652,7 @@ type UpdateUserResponse struct {
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_proto_users_v1_users_proto_msgTypes[8]
+	mi := &file_proto_users_v1_users_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -564,7 +664,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_users_v1_users_proto_msgTypes[8]
+	mi := &file_proto_users_v1_users_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -577,7 +677,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{8}
+	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateUserResponse) GetUser() *User {
@@ -587,6 +687,102 @@ func (x *UpdateUserResponse) GetUser() *User {
 	return nil
 }
 
+type GetUserByEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByEmailRequest) Reset() {
+	*x = GetUserByEmailRequest{}
+	mi := &file_proto_users_v1_users_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByEmailRequest) ProtoMessage() {}
+
+func (x *GetUserByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_users_v1_users_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByEmailRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetUserByEmailRequest) GetMetadata() *v1.RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetUserByEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type GetUserByEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByEmailResponse) Reset() {
+	*x = GetUserByEmailResponse{}
+	mi := &file_proto_users_v1_users_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByEmailResponse) ProtoMessage() {}
+
+func (x *GetUserByEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_users_v1_users_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByEmailResponse.ProtoReflect.Descriptor instead.
+func (*GetUserByEmailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUserByEmailResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 type ListUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *v1.RequestMetadata    `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
@@ -597,7 +793,7 @@ type ListUsersRequest struct {
 
 func (x *ListUsersRequest) Reset() {
 	*x = ListUsersRequest{}
-	mi := &file_proto_users_v1_users_proto_msgTypes[9]
+	mi := &file_proto_users_v1_users_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -609,7 +805,7 @@ func (x *ListUsersRequest) String() string {
 func (*ListUsersRequest) ProtoMessage() {}
 
 func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_users_v1_users_proto_msgTypes[9]
+	mi := &file_proto_users_v1_users_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,7 +818,7 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
 func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{9}
+	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListUsersRequest) GetMetadata() *v1.RequestMetadata {
@@ -649,7 +845,7 @@ type ListUsersResponse struct {
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_proto_users_v1_users_proto_msgTypes[10]
+	mi := &file_proto_users_v1_users_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -661,7 +857,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_users_v1_users_proto_msgTypes[10]
+	mi := &file_proto_users_v1_users_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -674,7 +870,7 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{10}
+	return file_proto_users_v1_users_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListUsersResponse) GetUsers() []*User {
@@ -695,7 +891,7 @@ var File_proto_users_v1_users_proto protoreflect.FileDescriptor
 
 const file_proto_users_v1_users_proto_rawDesc = "" +
 	"\n" +
-	"\x1aproto/users/v1/users.proto\x12\busers.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/common/v1/common.proto\"\x83\x02\n" +
+	"\x1aproto/users/v1/users.proto\x12\busers.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/common/v1/common.proto\"\x83\x02\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1b\n" +
@@ -728,14 +924,25 @@ const file_proto_users_v1_users_proto_rawDesc = "" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\"5\n" +
 	"\x0fGetUserResponse\x12\"\n" +
-	"\x04user\x18\x01 \x01(\v2\x0e.users.v1.UserR\x04user\"\xc5\x01\n" +
+	"\x04user\x18\x01 \x01(\v2\x0e.users.v1.UserR\x04user\"F\n" +
+	"\fGetMeRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\"3\n" +
+	"\rGetMeResponse\x12\"\n" +
+	"\x04user\x18\x01 \x01(\v2\x0e.users.v1.UserR\x04user\"\x82\x02\n" +
 	"\x11UpdateUserRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
 	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x14\n" +
 	"\x05phone\x18\x04 \x01(\tR\x05phone\x12,\n" +
-	"\aaddress\x18\x05 \x01(\v2\x12.common.v1.AddressR\aaddress\"8\n" +
+	"\aaddress\x18\x05 \x01(\v2\x12.common.v1.AddressR\aaddress\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"8\n" +
 	"\x12UpdateUserResponse\x12\"\n" +
+	"\x04user\x18\x01 \x01(\v2\x0e.users.v1.UserR\x04user\"e\n" +
+	"\x15GetUserByEmailRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"<\n" +
+	"\x16GetUserByEmailResponse\x12\"\n" +
 	"\x04user\x18\x01 \x01(\v2\x0e.users.v1.UserR\x04user\"\x88\x01\n" +
 	"\x10ListUsersRequest\x126\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1a.common.v1.RequestMetadataR\bmetadata\x12<\n" +
@@ -746,14 +953,16 @@ const file_proto_users_v1_users_proto_rawDesc = "" +
 	"\x05users\x18\x01 \x03(\v2\x0e.users.v1.UserR\x05users\x12=\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
-	"pagination2\xd9\x02\n" +
+	"pagination2\xe8\x03\n" +
 	"\vUserService\x12A\n" +
 	"\bRegister\x12\x19.users.v1.RegisterRequest\x1a\x1a.users.v1.RegisterResponse\x128\n" +
 	"\x05Login\x12\x16.users.v1.LoginRequest\x1a\x17.users.v1.LoginResponse\x12>\n" +
-	"\aGetUser\x12\x18.users.v1.GetUserRequest\x1a\x19.users.v1.GetUserResponse\x12G\n" +
+	"\aGetUser\x12\x18.users.v1.GetUserRequest\x1a\x19.users.v1.GetUserResponse\x128\n" +
+	"\x05GetMe\x12\x16.users.v1.GetMeRequest\x1a\x17.users.v1.GetMeResponse\x12G\n" +
 	"\n" +
 	"UpdateUser\x12\x1b.users.v1.UpdateUserRequest\x1a\x1c.users.v1.UpdateUserResponse\x12D\n" +
-	"\tListUsers\x12\x1a.users.v1.ListUsersRequest\x1a\x1b.users.v1.ListUsersResponseB2Z0github.com/mumumio1/coldy/proto/users/v1;usersv1b\x06proto3"
+	"\tListUsers\x12\x1a.users.v1.ListUsersRequest\x1a\x1b.users.v1.ListUsersResponse\x12S\n" +
+	"\x0eGetUserByEmail\x12\x1f.users.v1.GetUserByEmailRequest\x1a .users.v1.GetUserByEmailResponseB2Z0github.com/mumumio1/coldy/proto/users/v1;usersv1b\x06proto3"
 
 var (
 	file_proto_users_v1_users_proto_rawDescOnce sync.Once
@@ -767,57 +976,71 @@ func file_proto_users_v1_users_proto_rawDescGZIP() []byte {
 	return file_proto_users_v1_users_proto_rawDescData
 }
 
-var file_proto_users_v1_users_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_users_v1_users_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_proto_users_v1_users_proto_goTypes = []any{
-	(*User)(nil),                  // 0: users.v1.User
-	(*RegisterRequest)(nil),       // 1: users.v1.RegisterRequest
-	(*RegisterResponse)(nil),      // 2: users.v1.RegisterResponse
-	(*LoginRequest)(nil),          // 3: users.v1.LoginRequest
-	(*LoginResponse)(nil),         // 4: users.v1.LoginResponse
-	(*GetUserRequest)(nil),        // 5: users.v1.GetUserRequest
-	(*GetUserResponse)(nil),       // 6: users.v1.GetUserResponse
-	(*UpdateUserRequest)(nil),     // 7: users.v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil),    // 8: users.v1.UpdateUserResponse
-	(*ListUsersRequest)(nil),      // 9: users.v1.ListUsersRequest
-	(*ListUsersResponse)(nil),     // 10: users.v1.ListUsersResponse
-	(*v1.Address)(nil),            // 11: common.v1.Address
-	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
-	(*v1.RequestMetadata)(nil),    // 13: common.v1.RequestMetadata
-	(*v1.PaginationRequest)(nil),  // 14: common.v1.PaginationRequest
-	(*v1.PaginationResponse)(nil), // 15: common.v1.PaginationResponse
+	(*User)(nil),                   // 0: users.v1.User
+	(*RegisterRequest)(nil),        // 1: users.v1.RegisterRequest
+	(*RegisterResponse)(nil),       // 2: users.v1.RegisterResponse
+	(*LoginRequest)(nil),           // 3: users.v1.LoginRequest
+	(*LoginResponse)(nil),          // 4: users.v1.LoginResponse
+	(*GetUserRequest)(nil),         // 5: users.v1.GetUserRequest
+	(*GetUserResponse)(nil),        // 6: users.v1.GetUserResponse
+	(*GetMeRequest)(nil),           // 7: users.v1.GetMeRequest
+	(*GetMeResponse)(nil),          // 8: users.v1.GetMeResponse
+	(*UpdateUserRequest)(nil),      // 9: users.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),     // 10: users.v1.UpdateUserResponse
+	(*GetUserByEmailRequest)(nil),  // 11: users.v1.GetUserByEmailRequest
+	(*GetUserByEmailResponse)(nil), // 12: users.v1.GetUserByEmailResponse
+	(*ListUsersRequest)(nil),       // 13: users.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),      // 14: users.v1.ListUsersResponse
+	(*v1.Address)(nil),             // 15: common.v1.Address
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
+	(*v1.RequestMetadata)(nil),     // 17: common.v1.RequestMetadata
+	(*fieldmaskpb.FieldMask)(nil),  // 18: google.protobuf.FieldMask
+	(*v1.PaginationRequest)(nil),   // 19: common.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),  // 20: common.v1.PaginationResponse
 }
 var file_proto_users_v1_users_proto_depIdxs = []int32{
-	11, // 0: users.v1.User.address:type_name -> common.v1.Address
-	12, // 1: users.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	12, // 2: users.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	13, // 3: users.v1.RegisterRequest.metadata:type_name -> common.v1.RequestMetadata
+	15, // 0: users.v1.User.address:type_name -> common.v1.Address
+	16, // 1: users.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	16, // 2: users.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	17, // 3: users.v1.RegisterRequest.metadata:type_name -> common.v1.RequestMetadata
 	0,  // 4: users.v1.RegisterResponse.user:type_name -> users.v1.User
-	13, // 5: users.v1.LoginRequest.metadata:type_name -> common.v1.RequestMetadata
+	17, // 5: users.v1.LoginRequest.metadata:type_name -> common.v1.RequestMetadata
 	0,  // 6: users.v1.LoginResponse.user:type_name -> users.v1.User
-	13, // 7: users.v1.GetUserRequest.metadata:type_name -> common.v1.RequestMetadata
+	17, // 7: users.v1.GetUserRequest.metadata:type_name -> common.v1.RequestMetadata
 	0,  // 8: users.v1.GetUserResponse.user:type_name -> users.v1.User
-	13, // 9: users.v1.UpdateUserRequest.metadata:type_name -> common.v1.RequestMetadata
-	11, // 10: users.v1.UpdateUserRequest.address:type_name -> common.v1.Address
-	0,  // 11: users.v1.UpdateUserResponse.user:type_name -> users.v1.User
-	13, // 12: users.v1.ListUsersRequest.metadata:type_name -> common.v1.RequestMetadata
-	14, // 13: users.v1.ListUsersRequest.pagination:type_name -> common.v1.PaginationRequest
-	0,  // 14: users.v1.ListUsersResponse.users:type_name -> users.v1.User
-	15, // 15: users.v1.ListUsersResponse.pagination:type_name -> common.v1.PaginationResponse
-	1,  // 16: users.v1.UserService.Register:input_type -> users.v1.RegisterRequest
-	3,  // 17: users.v1.UserService.Login:input_type -> users.v1.LoginRequest
-	5,  // 18: users.v1.UserService.GetUser:input_type -> users.v1.GetUserRequest
-	7,  // 19: users.v1.UserService.UpdateUser:input_type -> users.v1.UpdateUserRequest
-	9,  // 20: users.v1.UserService.ListUsers:input_type -> users.v1.ListUsersRequest
-	2,  // 21: users.v1.UserService.Register:output_type -> users.v1.RegisterResponse
-	4,  // 22: users.v1.UserService.Login:output_type -> users.v1.LoginResponse
-	6,  // 23: users.v1.UserService.GetUser:output_type -> users.v1.GetUserResponse
-	8,  // 24: users.v1.UserService.UpdateUser:output_type -> users.v1.UpdateUserResponse
-	10, // 25: users.v1.UserService.ListUsers:output_type -> users.v1.ListUsersResponse
-	21, // [21:26] is the sub-list for method output_type
-	16, // [16:21] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	17, // 9: users.v1.GetMeRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 10: users.v1.GetMeResponse.user:type_name -> users.v1.User
+	17, // 11: users.v1.UpdateUserRequest.metadata:type_name -> common.v1.RequestMetadata
+	15, // 12: users.v1.UpdateUserRequest.address:type_name -> common.v1.Address
+	18, // 13: users.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 14: users.v1.UpdateUserResponse.user:type_name -> users.v1.User
+	17, // 15: users.v1.GetUserByEmailRequest.metadata:type_name -> common.v1.RequestMetadata
+	0,  // 16: users.v1.GetUserByEmailResponse.user:type_name -> users.v1.User
+	17, // 17: users.v1.ListUsersRequest.metadata:type_name -> common.v1.RequestMetadata
+	19, // 18: users.v1.ListUsersRequest.pagination:type_name -> common.v1.PaginationRequest
+	0,  // 19: users.v1.ListUsersResponse.users:type_name -> users.v1.User
+	20, // 20: users.v1.ListUsersResponse.pagination:type_name -> common.v1.PaginationResponse
+	1,  // 21: users.v1.UserService.Register:input_type -> users.v1.RegisterRequest
+	3,  // 22: users.v1.UserService.Login:input_type -> users.v1.LoginRequest
+	5,  // 23: users.v1.UserService.GetUser:input_type -> users.v1.GetUserRequest
+	7,  // 24: users.v1.UserService.GetMe:input_type -> users.v1.GetMeRequest
+	9,  // 25: users.v1.UserService.UpdateUser:input_type -> users.v1.UpdateUserRequest
+	13, // 26: users.v1.UserService.ListUsers:input_type -> users.v1.ListUsersRequest
+	11, // 27: users.v1.UserService.GetUserByEmail:input_type -> users.v1.GetUserByEmailRequest
+	2,  // 28: users.v1.UserService.Register:output_type -> users.v1.RegisterResponse
+	4,  // 29: users.v1.UserService.Login:output_type -> users.v1.LoginResponse
+	6,  // 30: users.v1.UserService.GetUser:output_type -> users.v1.GetUserResponse
+	8,  // 31: users.v1.UserService.GetMe:output_type -> users.v1.GetMeResponse
+	10, // 32: users.v1.UserService.UpdateUser:output_type -> users.v1.UpdateUserResponse
+	14, // 33: users.v1.UserService.ListUsers:output_type -> users.v1.ListUsersResponse
+	12, // 34: users.v1.UserService.GetUserByEmail:output_type -> users.v1.GetUserByEmailResponse
+	28, // [28:35] is the sub-list for method output_type
+	21, // [21:28] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_proto_users_v1_users_proto_init() }
@@ -831,7 +1054,7 @@ func file_proto_users_v1_users_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_users_v1_users_proto_rawDesc), len(file_proto_users_v1_users_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -2,21 +2,28 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/mumumio1/coldy/pkg/cache"
+	"github.com/mumumio1/coldy/pkg/config"
 	"github.com/mumumio1/coldy/pkg/database"
+	grpcserverpkg "github.com/mumumio1/coldy/pkg/grpcserver"
 	"github.com/mumumio1/coldy/pkg/logger"
 	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/outbox"
+	"github.com/mumumio1/coldy/pkg/pagination"
+	"github.com/mumumio1/coldy/pkg/pubsub"
+	"github.com/mumumio1/coldy/pkg/shutdown"
 	"github.com/mumumio1/coldy/pkg/telemetry"
 	catalogv1 "github.com/mumumio1/coldy/proto/catalog/v1"
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
 	grpcserver "github.com/mumumio1/coldy/services/catalog/internal/grpc"
+	"github.com/mumumio1/coldy/services/catalog/internal/inventory"
 	"github.com/mumumio1/coldy/services/catalog/internal/repository"
 	"github.com/mumumio1/coldy/services/catalog/internal/service"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -43,17 +50,27 @@ func run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Refuse to start without a JWT secret, or with the placeholder one
+	// outside development
+	jwtSecret := config.Getenv("JWT_SECRET", config.InsecureJWTSecret)
+	if err := config.CheckJWTSecret(config.Getenv("ENV", "development"), jwtSecret); err != nil {
+		return err
+	}
+
 	// Initialize logger
-	log, err := logger.NewLogger(serviceName, getEnv("ENV", "development"))
+	log, err := logger.NewLogger(serviceName, config.Getenv("ENV", "development"))
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	defer func() { _ = log.Sync() }()
 
+	// Background work started from ctx logs with the service logger
+	ctx = logger.WithLogger(ctx, log)
+
 	log.Info("starting catalog service", zap.String("version", version))
 
 	// Initialize tracing
-	tracingEndpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	tracingEndpoint := config.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
 	shutdownTracer, err := telemetry.InitTracer(ctx, serviceName, version, tracingEndpoint)
 	if err != nil {
 		log.Warn("failed to initialize tracer", zap.Error(err))
@@ -66,12 +83,12 @@ func run() error {
 
 	// Initialize database
 	dbConfig := database.Config{
-		Host:            getEnv("DB_HOST", "localhost"),
+		Host:            config.Getenv("DB_HOST", "localhost"),
 		Port:            5432,
-		User:            getEnv("DB_USER", "coldy"),
-		Password:        getEnv("DB_PASSWORD", "coldy123"),
-		Database:        getEnv("DB_NAME", "coldy"),
-		SSLMode:         getEnv("DB_SSLMODE", "disable"),
+		User:            config.Getenv("DB_USER", "coldy"),
+		Password:        config.Getenv("DB_PASSWORD", "coldy123"),
+		Database:        config.Getenv("DB_NAME", "coldy"),
+		SSLMode:         config.Getenv("DB_SSLMODE", "disable"),
 		MaxOpenConns:    25,
 		MaxIdleConns:    5,
 		ConnMaxLifetime: 5 * time.Minute,
@@ -86,14 +103,25 @@ func run() error {
 
 	// Initialize Redis cache
 	redisConfig := cache.Config{
-		Addr:         getEnv("REDIS_ADDR", "localhost:6379"),
-		Password:     getEnv("REDIS_PASSWORD", ""),
-		DB:           0,
+		Addr:         config.Getenv("REDIS_ADDR", "localhost:6379"),
+		Password:     config.Getenv("REDIS_PASSWORD", ""),
+		DB:           config.GetenvInt("REDIS_DB", 0),
 		PoolSize:     10,
 		MinIdleConns: 2,
 		DialTimeout:  5 * time.Second,
 		ReadTimeout:  3 * time.Second,
 		WriteTimeout: 3 * time.Second,
+		// Serve from the database instead of waiting on an unreachable Redis
+		FailFast: config.Getenv("REDIS_FAIL_FAST", "true") == "true",
+		// Keep environments sharing a Redis apart; CACHE_KEY_VERSION overrides
+		// the version to drop every cached entry at once
+		Namespace: config.Getenv("CACHE_KEY_NAMESPACE", config.Getenv("ENV", "development")),
+		Version:   config.Getenv("CACHE_KEY_VERSION", service.CacheVersion),
+	}
+
+	// CACHE_COALESCE=cluster makes a single replica load a missing key
+	if config.Getenv("CACHE_COALESCE", "process") == "cluster" {
+		redisConfig.Coalesce = cache.CoalesceCrossProcess
 	}
 
 	redisCache, err := cache.NewRedisCache(ctx, redisConfig, log)
@@ -102,22 +130,163 @@ func run() error {
 	}
 	defer func() { _ = redisCache.Close() }()
 
+	// Plaintext gRPC is only allowed with GRPC_INSECURE=true
+	transport, err := grpcserverpkg.LoadTransportConfig()
+	if err != nil {
+		return err
+	}
+	serverCreds, err := transport.ServerCredentials()
+	if err != nil {
+		return err
+	}
+	clientCreds, err := transport.ClientCredentials()
+	if err != nil {
+		return err
+	}
+
+	// Connect to inventory service for stock reservations
+	inventoryConn, err := grpc.NewClient(config.Getenv("INVENTORY_ADDR", "localhost:50055"),
+		clientCreds,
+		grpc.WithUnaryInterceptor(middleware.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create inventory client: %w", err)
+	}
+	defer func() { _ = inventoryConn.Close() }()
+
 	// Initialize repository and services
 	productRepo := repository.NewProductRepository(db)
-	catalogService := service.NewCatalogService(productRepo, redisCache, log)
+	inventoryClient := inventory.NewClient(inventoryv1.NewInventoryServiceClient(inventoryConn))
+	cacheConfig := service.DefaultCacheConfig()
+	cacheConfig.ProductTTL = config.GetenvDuration("CACHE_PRODUCT_TTL", cacheConfig.ProductTTL)
+	cacheConfig.ListTTL = config.GetenvDuration("CACHE_LIST_TTL", cacheConfig.ListTTL)
+	cacheConfig.NotFoundTTL = config.GetenvDuration("CACHE_NOT_FOUND_TTL", cacheConfig.NotFoundTTL)
+	cacheConfig.StockTTL = config.GetenvDuration("CACHE_STOCK_TTL", cacheConfig.StockTTL)
+	reconcileConfig := service.ReconcileConfig{
+		Threshold: int32(config.GetenvInt("STOCK_RECONCILE_THRESHOLD", 0)),
+	}
+	catalogService := service.NewCatalogService(productRepo, redisCache, cacheConfig, inventoryClient, reconcileConfig, log)
+
+	projectID := config.Getenv("GCP_PROJECT_ID", "coldy-local")
+	publisher, err := pubsub.NewPublisher(ctx, projectID, log)
+	if err != nil {
+		return fmt.Errorf("failed to create pubsub publisher: %w", err)
+	}
+	defer func() { _ = publisher.Close() }()
+
+	// Check the outbox topics up front so a misconfigured project shows up at
+	// startup rather than at the first publish. Unless PUBSUB_REQUIRE_TOPICS
+	// is set, a failed check only keeps the service unready.
+	topics := pubsub.NewPrefixResolver(config.Getenv("PUBSUB_TOPIC_PREFIX", ""), pubsub.IdentityResolver{})
+	eventTypes := service.EventTypes()
+	topicNames := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		topicNames[i] = topics.Topic(eventType)
+	}
+	topicCheck := publisher.NewTopicCheck(topicNames, config.Getenv("PUBSUB_CREATE_TOPICS", "true") == "true")
+	if err := topicCheck.Verify(ctx); err != nil {
+		if config.Getenv("PUBSUB_REQUIRE_TOPICS", "false") == "true" {
+			return fmt.Errorf("failed to verify pubsub topics: %w", err)
+		}
+		log.Warn("pubsub topics not verified", zap.Error(err))
+	}
+
+	// Publish reconciliation and price change events; events that keep
+	// failing are dead-lettered after OUTBOX_MAX_ATTEMPTS publishes, 0
+	// retries forever
+	outboxPublisher := outbox.NewPublisher(repository.NewOutboxStore(db), publisher, topics, log, 5*time.Second,
+		config.GetenvInt("OUTBOX_MAX_ATTEMPTS", outbox.DefaultMaxAttempts))
+	go func() {
+		if err := outboxPublisher.Start(ctx); err != nil && err != context.Canceled {
+			log.Error("outbox publisher stopped", zap.Error(err))
+		}
+	}()
+
+	// Periodically report stock drift against the inventory service; corrections
+	// are left to operators through ReconcileStock
+	if interval := config.GetenvDuration("STOCK_RECONCILE_INTERVAL", 0); interval > 0 {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					drifts, err := catalogService.ReconcileAllStock(ctx, service.ReconcileReport)
+					if err != nil {
+						log.Error("stock reconciliation failed", zap.Error(err))
+						continue
+					}
+					log.Info("stock reconciliation finished", zap.Int("drifted", len(drifts)))
+				}
+			}
+		}()
+	}
 
 	// Start gRPC server
-	grpcPort := getEnv("GRPC_PORT", "50052")
+	grpcPort := config.Getenv("GRPC_PORT", "50052")
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	grpcServer := grpc.NewServer(
+	keepalive, err := grpcserverpkg.LoadKeepaliveConfig()
+	if err != nil {
+		return err
+	}
+
+	// Shed load past these limits instead of letting it queue on the database pool
+	concurrency, err := middleware.LoadConcurrencyConfig()
+	if err != nil {
+		return err
+	}
+
+	// MULTI_TENANT=true rejects requests without a tenant header, except on
+	// health checks and reflection
+	tenantConfig := middleware.TenantConfig{
+		Required:      config.Getenv("MULTI_TENANT", "false") == "true",
+		ExemptMethods: middleware.InfrastructureMethods,
+	}
+
+	// Access tokens come from the users service. Reads, availability checks
+	// and quotes stay open; product writes and maintenance RPCs require one.
+	authConfig := middleware.AuthConfig{
+		Validate: middleware.JWTValidator(
+			jwtSecret,
+			"coldy-users",
+			"coldy-access",
+			config.GetenvDuration("JWT_LEEWAY", middleware.DefaultJWTLeeway),
+		),
+		// Probes and tooling call the infrastructure RPCs without a token
+		PublicMethods: append([]string{
+			catalogv1.CatalogService_GetProduct_FullMethodName,
+			catalogv1.CatalogService_GetProductBySKU_FullMethodName,
+			catalogv1.CatalogService_ListProducts_FullMethodName,
+			catalogv1.CatalogService_CheckAvailability_FullMethodName,
+			catalogv1.CatalogService_ReserveIfAvailable_FullMethodName,
+			catalogv1.CatalogService_QuoteItems_FullMethodName,
+		}, middleware.InfrastructureMethods...),
+		MethodScopes: map[string]string{
+			catalogv1.CatalogService_ReconcileStock_FullMethodName: service.ScopeCatalogAdmin,
+			catalogv1.CatalogService_FlushCache_FullMethodName:     service.ScopeCatalogAdmin,
+		},
+	}
+
+	grpcServer := grpcserverpkg.New(keepalive,
+		serverCreds,
+		grpcserverpkg.MaxConcurrentStreams(config.GetenvInt("GRPC_MAX_CONCURRENT_STREAMS", grpcserverpkg.DefaultMaxConcurrentStreams)),
 		grpc.ChainUnaryInterceptor(
+			middleware.MetricsInterceptor(),
 			middleware.RecoveryInterceptor(log),
 			middleware.UnaryServerInterceptor(log),
+			middleware.CompressionInterceptor(config.GetenvInt("GRPC_COMPRESS_MIN_SIZE", middleware.DefaultCompressMinSize)),
+			middleware.ConcurrencyLimitInterceptor(concurrency),
 			middleware.TracingInterceptor(serviceName),
+			middleware.AuthInterceptor(authConfig),
+			middleware.TenantInterceptor(tenantConfig),
+			middleware.ValidationInterceptor(nil),
 		),
 		grpc.ChainStreamInterceptor(
 			middleware.StreamServerInterceptor(log),
@@ -125,7 +294,12 @@ func run() error {
 	)
 
 	// Register services
-	catalogv1.RegisterCatalogServiceServer(grpcServer, grpcserver.NewServer(catalogService, log))
+	pages, err := pagination.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	catalogv1.RegisterCatalogServiceServer(grpcServer, grpcserver.NewServer(catalogService, pages, log))
 
 	// Register health check
 	healthServer := health.NewServer()
@@ -133,12 +307,12 @@ func run() error {
 	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_SERVING)
 
 	// Register reflection for development
-	if getEnv("ENV", "development") == "development" {
+	if config.Getenv("ENV", "development") == "development" {
 		reflection.Register(grpcServer)
 	}
 
 	// Start metrics server
-	metricsPort := getEnv("METRICS_PORT", "9091")
+	metricsPort := config.Getenv("METRICS_PORT", "9091")
 	go func() {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
@@ -147,16 +321,32 @@ func run() error {
 			_, _ = w.Write([]byte("OK"))
 		})
 		mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
-			if err := database.HealthCheck(r.Context(), db); err != nil {
+			// The body reports pool saturation alongside the verdict
+			dbStatus, err := database.HealthCheckDetailed(r.Context(), db, database.DefaultHealthCheckTimeout)
+			w.Header().Set("Content-Type", "application/json")
+			if err != nil {
 				w.WriteHeader(http.StatusServiceUnavailable)
+				_ = json.NewEncoder(w).Encode(dbStatus)
 				return
 			}
 			if err := redisCache.HealthCheck(r.Context()); err != nil {
 				w.WriteHeader(http.StatusServiceUnavailable)
 				return
 			}
+			// Events stop flowing if the outbox worker dies or its topics are
+			// missing; report not ready
+			if err := topicCheck.Verify(r.Context()); err != nil {
+				log.Warn("readiness check failed", zap.Error(err))
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if err := outboxPublisher.CheckHeartbeat(3); err != nil {
+				log.Warn("readiness check failed", zap.Error(err))
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
 			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("READY"))
+			_ = json.NewEncoder(w).Encode(dbStatus)
 		})
 
 		log.Info("starting metrics server", zap.String("port", metricsPort))
@@ -189,24 +379,24 @@ func run() error {
 		}
 	}()
 
-	// Wait for interrupt signal
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
-
-	log.Info("shutting down gracefully...")
-
-	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
-	time.Sleep(5 * time.Second)
-	grpcServer.GracefulStop()
+	// Fail health checks, let load balancers drain, then stop the server
+	shutdowner := shutdown.New(shutdown.Config{
+		DrainDelay: config.GetenvDuration("SHUTDOWN_DRAIN_DELAY", shutdown.DefaultDrainDelay),
+		Timeout:    config.GetenvDuration("SHUTDOWN_TIMEOUT", shutdown.DefaultTimeout),
+	}, log)
+	shutdowner.PreStop("health", func(ctx context.Context) error {
+		healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		return nil
+	})
+	shutdowner.OnShutdown("grpc", func(ctx context.Context) error {
+		// Stop forces remaining RPCs closed after its own timeout
+		grpcserverpkg.Stop(grpcServer, config.GetenvDuration("GRPC_SHUTDOWN_TIMEOUT", grpcserverpkg.DefaultShutdownTimeout), log)
+		return nil
+	})
+	if err := shutdowner.Wait(ctx); err != nil {
+		log.Warn("shutdown did not complete cleanly", zap.Error(err))
+	}
 
 	log.Info("server stopped")
 	return nil
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
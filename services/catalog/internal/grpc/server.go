@@ -2,7 +2,11 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/money"
+	"github.com/mumumio1/coldy/pkg/pagination"
 	catalogv1 "github.com/mumumio1/coldy/proto/catalog/v1"
 	commonv1 "github.com/mumumio1/coldy/proto/common/v1"
 	"github.com/mumumio1/coldy/services/catalog/internal/repository"
@@ -17,13 +21,15 @@ import (
 type Server struct {
 	catalogv1.UnimplementedCatalogServiceServer
 	catalogService *service.CatalogService
+	pages          pagination.Config
 	logger         *zap.Logger
 }
 
 // NewServer creates a new gRPC server
-func NewServer(catalogService *service.CatalogService, logger *zap.Logger) *Server {
+func NewServer(catalogService *service.CatalogService, pages pagination.Config, logger *zap.Logger) *Server {
 	return &Server{
 		catalogService: catalogService,
+		pages:          pages,
 		logger:         logger,
 	}
 }
@@ -34,9 +40,33 @@ func (s *Server) GetProduct(ctx context.Context, req *catalogv1.GetProductReques
 		return nil, status.Error(codes.InvalidArgument, "product_id is required")
 	}
 
+	if req.IncludeInventory {
+		result, err := s.catalogService.GetProductWithStock(ctx, req.ProductId)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get product", zap.Error(err))
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+
+		resp := &catalogv1.GetProductResponse{
+			Product:        toProtoProduct(result.Product),
+			InventoryStale: result.StockStale,
+		}
+		if result.Stock != nil {
+			resp.Inventory = &catalogv1.ProductInventory{
+				AvailableQuantity: result.Stock.Available,
+				ReservedQuantity:  result.Stock.Reserved,
+				TotalQuantity:     result.Stock.Total,
+			}
+			if !result.Stock.UpdatedAt.IsZero() {
+				resp.Inventory.UpdatedAt = timestamppb.New(result.Stock.UpdatedAt)
+			}
+		}
+		return resp, nil
+	}
+
 	product, err := s.catalogService.GetProduct(ctx, req.ProductId)
 	if err != nil {
-		s.logger.Error("failed to get product", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to get product", zap.Error(err))
 		return nil, status.Error(codes.NotFound, "product not found")
 	}
 
@@ -45,28 +75,60 @@ func (s *Server) GetProduct(ctx context.Context, req *catalogv1.GetProductReques
 	}, nil
 }
 
+// GetProductBySKU retrieves a product by SKU
+func (s *Server) GetProductBySKU(ctx context.Context, req *catalogv1.GetProductBySKURequest) (*catalogv1.GetProductBySKUResponse, error) {
+	if req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+
+	product, err := s.catalogService.GetProductBySKU(ctx, req.Sku)
+	if errors.Is(err, service.ErrProductNotFound) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to get product by sku", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get product")
+	}
+
+	return &catalogv1.GetProductBySKUResponse{
+		Product: toProtoProduct(product),
+	}, nil
+}
+
 // ListProducts lists products
 func (s *Server) ListProducts(ctx context.Context, req *catalogv1.ListProductsRequest) (*catalogv1.ListProductsResponse, error) {
-	pageSize := int(req.Pagination.PageSize)
-	if pageSize <= 0 {
-		pageSize = 20
+	pageSize, err := s.pages.Clamp(catalogv1.CatalogService_ListProducts_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	search := repository.Search{
+		Query:          req.SearchQuery,
+		Mode:           toRepoSearchMode(req.SearchMode),
+		FuzzyThreshold: float64(req.FuzzyThreshold),
 	}
 
 	products, nextCursor, hasMore, err := s.catalogService.ListProducts(
 		ctx,
 		pageSize,
-		req.Pagination.Cursor,
+		req.GetPagination().GetCursor(),
 		req.Category,
-		req.SearchQuery,
+		search,
 	)
 	if err != nil {
-		s.logger.Error("failed to list products", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to list products", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to list products")
 	}
 
+	var categoryCounts map[string]int64
+	if req.Facets {
+		categoryCounts, err = s.catalogService.CategoryFacets(ctx, search)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to compute category facets", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to list products")
+		}
+	}
+
 	protoProducts := make([]*catalogv1.Product, len(products))
 	for i, product := range products {
 		protoProducts[i] = toProtoProduct(product)
@@ -78,6 +140,7 @@ func (s *Server) ListProducts(ctx context.Context, req *catalogv1.ListProductsRe
 			NextCursor: nextCursor,
 			HasMore:    hasMore,
 		},
+		CategoryCounts: categoryCounts,
 	}, nil
 }
 
@@ -99,7 +162,10 @@ func (s *Server) CreateProduct(ctx context.Context, req *catalogv1.CreateProduct
 	}
 
 	if err := s.catalogService.CreateProduct(ctx, product); err != nil {
-		s.logger.Error("failed to create product", zap.Error(err))
+		if errors.Is(err, repository.ErrDuplicateSKU) {
+			return nil, status.Error(codes.AlreadyExists, "product with this sku already exists")
+		}
+		logger.FromContext(ctx).Error("failed to create product", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to create product")
 	}
 
@@ -114,35 +180,71 @@ func (s *Server) UpdateProduct(ctx context.Context, req *catalogv1.UpdateProduct
 		return nil, status.Error(codes.InvalidArgument, "product_id is required")
 	}
 
-	// Get existing product
-	product, err := s.catalogService.GetProduct(ctx, req.ProductId)
-	if err != nil {
+	update := service.ProductUpdate{
+		Name:        req.Name,
+		Description: req.Description,
+		Category:    req.Category,
+	}
+	if req.Price != nil {
+		update.Price = &money.Money{Currency: req.Price.Currency, Amount: req.Price.Amount}
+	}
+
+	product, err := s.catalogService.UpdateProduct(ctx, req.ProductId, update, productUpdateMask(req))
+	if errors.Is(err, service.ErrProductNotFound) {
 		return nil, status.Error(codes.NotFound, "product not found")
 	}
+	if errors.Is(err, service.ErrInvalidUpdateMask) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to update product", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to update product")
+	}
+
+	return &catalogv1.UpdateProductResponse{
+		Product: toProtoProduct(product),
+	}, nil
+}
+
+// productUpdateMask returns the fields an UpdateProduct request sets. Requests
+// without a mask set their non-empty fields.
+func productUpdateMask(req *catalogv1.UpdateProductRequest) []string {
+	if req.UpdateMask != nil {
+		return req.UpdateMask.Paths
+	}
 
-	// Update fields
+	var mask []string
 	if req.Name != "" {
-		product.Name = req.Name
+		mask = append(mask, "name")
 	}
 	if req.Description != "" {
-		product.Description = req.Description
+		mask = append(mask, "description")
 	}
 	if req.Price != nil {
-		product.PriceCurrency = req.Price.Currency
-		product.PriceAmount = req.Price.Amount
+		mask = append(mask, "price")
 	}
 	if req.Category != "" {
-		product.Category = req.Category
+		mask = append(mask, "category")
 	}
+	return mask
+}
 
-	if err := s.catalogService.UpdateProduct(ctx, product); err != nil {
-		s.logger.Error("failed to update product", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to update product")
+// DeleteProduct soft-deletes a product
+func (s *Server) DeleteProduct(ctx context.Context, req *catalogv1.DeleteProductRequest) (*catalogv1.DeleteProductResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
 	}
 
-	return &catalogv1.UpdateProductResponse{
-		Product: toProtoProduct(product),
-	}, nil
+	err := s.catalogService.DeleteProduct(ctx, req.ProductId)
+	if errors.Is(err, service.ErrProductNotFound) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to delete product", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete product")
+	}
+
+	return &catalogv1.DeleteProductResponse{}, nil
 }
 
 // UpdateStock updates product stock
@@ -153,7 +255,7 @@ func (s *Server) UpdateStock(ctx context.Context, req *catalogv1.UpdateStockRequ
 
 	newQuantity, err := s.catalogService.UpdateStock(ctx, req.ProductId, req.QuantityDelta)
 	if err != nil {
-		s.logger.Error("failed to update stock", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to update stock", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to update stock")
 	}
 
@@ -162,6 +264,89 @@ func (s *Server) UpdateStock(ctx context.Context, req *catalogv1.UpdateStockRequ
 	}, nil
 }
 
+// GetProductPriceHistory lists a product's price changes
+func (s *Server) GetProductPriceHistory(ctx context.Context, req *catalogv1.GetProductPriceHistoryRequest) (*catalogv1.GetProductPriceHistoryResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	pageSize, err := s.pages.Clamp(catalogv1.CatalogService_GetProductPriceHistory_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	changes, nextCursor, hasMore, err := s.catalogService.GetPriceHistory(ctx, req.ProductId, pageSize, req.GetPagination().GetCursor())
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to get price history", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get price history")
+	}
+
+	protoChanges := make([]*catalogv1.PriceChange, len(changes))
+	for i, change := range changes {
+		protoChanges[i] = &catalogv1.PriceChange{
+			Id:        change.ID,
+			OldPrice:  &commonv1.Money{Currency: change.OldCurrency, Amount: change.OldAmount},
+			NewPrice:  &commonv1.Money{Currency: change.NewCurrency, Amount: change.NewAmount},
+			ChangedAt: timestamppb.New(change.ChangedAt),
+		}
+	}
+
+	return &catalogv1.GetProductPriceHistoryResponse{
+		Changes: protoChanges,
+		Pagination: &commonv1.PaginationResponse{
+			NextCursor: nextCursor,
+			HasMore:    hasMore,
+		},
+	}, nil
+}
+
+// FlushCache clears the product, list and stock level caches
+func (s *Server) FlushCache(ctx context.Context, req *catalogv1.FlushCacheRequest) (*catalogv1.FlushCacheResponse, error) {
+	deleted, err := s.catalogService.FlushCache(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to flush cache", zap.Error(err), zap.Int64("keys_deleted", deleted))
+		return nil, status.Error(codes.Internal, "failed to flush cache")
+	}
+
+	return &catalogv1.FlushCacheResponse{KeysDeleted: deleted}, nil
+}
+
+// ReconcileStock compares and optionally corrects a product's catalog stock
+// against its inventory total
+func (s *Server) ReconcileStock(ctx context.Context, req *catalogv1.ReconcileStockRequest) (*catalogv1.ReconcileStockResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	var direction service.ReconcileDirection
+	switch req.Direction {
+	case catalogv1.ReconcileDirection_RECONCILE_DIRECTION_UNSPECIFIED:
+		direction = service.ReconcileReport
+	case catalogv1.ReconcileDirection_RECONCILE_DIRECTION_FROM_INVENTORY:
+		direction = service.ReconcileFromInventory
+	case catalogv1.ReconcileDirection_RECONCILE_DIRECTION_FROM_CATALOG:
+		direction = service.ReconcileFromCatalog
+	default:
+		return nil, status.Error(codes.InvalidArgument, "unknown direction")
+	}
+
+	drift, err := s.catalogService.ReconcileStock(ctx, req.ProductId, direction)
+	if errors.Is(err, service.ErrProductNotFound) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to reconcile stock", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to reconcile stock")
+	}
+
+	return &catalogv1.ReconcileStockResponse{
+		CatalogStock:   drift.CatalogStock,
+		InventoryTotal: drift.InventoryTotal,
+		Drift:          drift.Drift,
+		Corrected:      drift.Corrected,
+	}, nil
+}
+
 // CheckAvailability checks product availability
 func (s *Server) CheckAvailability(ctx context.Context, req *catalogv1.CheckAvailabilityRequest) (*catalogv1.CheckAvailabilityResponse, error) {
 	if len(req.Items) == 0 {
@@ -175,7 +360,7 @@ func (s *Server) CheckAvailability(ctx context.Context, req *catalogv1.CheckAvai
 
 	unavailable, err := s.catalogService.CheckAvailability(ctx, items)
 	if err != nil {
-		s.logger.Error("failed to check availability", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to check availability", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to check availability")
 	}
 
@@ -194,6 +379,88 @@ func (s *Server) CheckAvailability(ctx context.Context, req *catalogv1.CheckAvai
 	}, nil
 }
 
+// ReserveIfAvailable checks availability and reserves stock atomically
+func (s *Server) ReserveIfAvailable(ctx context.Context, req *catalogv1.ReserveIfAvailableRequest) (*catalogv1.ReserveIfAvailableResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items are required")
+	}
+
+	items := make(map[string]int32)
+	for _, item := range req.Items {
+		if item.ProductId == "" || item.Quantity <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "each item needs a product_id and a positive quantity")
+		}
+		items[item.ProductId] += item.Quantity
+	}
+
+	reservationID, unavailable, err := s.catalogService.ReserveIfAvailable(ctx, req.ReservationId, items, req.TtlSeconds)
+	if errors.Is(err, service.ErrProductUnavailable) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to reserve stock", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to reserve stock")
+	}
+
+	protoUnavailable := make([]*catalogv1.UnavailableItem, len(unavailable))
+	for i, item := range unavailable {
+		protoUnavailable[i] = &catalogv1.UnavailableItem{
+			ProductId: item.ProductID,
+			Requested: item.Requested,
+			Available: item.Available,
+		}
+	}
+
+	return &catalogv1.ReserveIfAvailableResponse{
+		Reserved:         len(unavailable) == 0,
+		ReservationId:    reservationID,
+		UnavailableItems: protoUnavailable,
+	}, nil
+}
+
+// QuoteItems returns current price and availability for many products
+func (s *Server) QuoteItems(ctx context.Context, req *catalogv1.QuoteItemsRequest) (*catalogv1.QuoteItemsResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items are required")
+	}
+
+	items := make(map[string]int32)
+	for _, item := range req.Items {
+		if item.ProductId == "" || item.Quantity <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "each item needs a product_id and a positive quantity")
+		}
+		items[item.ProductId] += item.Quantity
+	}
+
+	quotes, err := s.catalogService.QuoteItems(ctx, items)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to quote items", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to quote items")
+	}
+
+	protoQuotes := make([]*catalogv1.ItemQuote, len(quotes))
+	for i, quote := range quotes {
+		protoQuotes[i] = &catalogv1.ItemQuote{
+			ProductId: quote.ProductID,
+			Found:     quote.Found,
+			Name:      quote.Name,
+			Requested: quote.Requested,
+			Available: quote.Available,
+			InStock:   quote.InStock,
+		}
+		if quote.Found {
+			protoQuotes[i].Price = &commonv1.Money{
+				Currency: quote.PriceCurrency,
+				Amount:   quote.PriceAmount,
+			}
+		}
+	}
+
+	return &catalogv1.QuoteItemsResponse{
+		Quotes: protoQuotes,
+	}, nil
+}
+
 func toProtoProduct(product *repository.Product) *catalogv1.Product {
 	return &catalogv1.Product{
 		Id:          product.ID,
@@ -211,3 +478,14 @@ func toProtoProduct(product *repository.Product) *catalogv1.Product {
 		UpdatedAt:     timestamppb.New(product.UpdatedAt),
 	}
 }
+
+func toRepoSearchMode(mode catalogv1.SearchMode) repository.SearchMode {
+	switch mode {
+	case catalogv1.SearchMode_SEARCH_MODE_PREFIX:
+		return repository.SearchPrefix
+	case catalogv1.SearchMode_SEARCH_MODE_FUZZY:
+		return repository.SearchFuzzy
+	default:
+		return repository.SearchFullText
+	}
+}
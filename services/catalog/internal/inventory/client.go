@@ -0,0 +1,139 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+	"github.com/mumumio1/coldy/services/catalog/internal/service"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Client reserves stock through the inventory service
+type Client struct {
+	client inventoryv1.InventoryServiceClient
+}
+
+// NewClient creates a new inventory client
+func NewClient(client inventoryv1.InventoryServiceClient) *Client {
+	return &Client{client: client}
+}
+
+// InventoryTotal returns the total quantity the inventory service holds for a
+// product, zero if it has no record of it
+func (c *Client) InventoryTotal(ctx context.Context, productID string) (int32, error) {
+	resp, err := c.client.GetInventory(ctx, &inventoryv1.GetInventoryRequest{ProductId: productID})
+	if status.Code(err) == codes.NotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("inventory get failed: %w", err)
+	}
+	return resp.Inventory.TotalQuantity, nil
+}
+
+// StockLevel returns a product's available, reserved and total quantities,
+// all zero if the inventory service has no record of it
+func (c *Client) StockLevel(ctx context.Context, productID string) (service.StockLevel, error) {
+	resp, err := c.client.GetInventory(ctx, &inventoryv1.GetInventoryRequest{ProductId: productID})
+	if status.Code(err) == codes.NotFound {
+		return service.StockLevel{}, nil
+	}
+	if err != nil {
+		return service.StockLevel{}, fmt.Errorf("inventory get failed: %w", err)
+	}
+	inv := resp.Inventory
+	level := service.StockLevel{
+		Available: inv.AvailableQuantity,
+		Reserved:  inv.ReservedQuantity,
+		Total:     inv.TotalQuantity,
+	}
+	if inv.UpdatedAt != nil {
+		level.UpdatedAt = inv.UpdatedAt.AsTime()
+	}
+	return level, nil
+}
+
+// AdjustInventory changes a product's inventory total by delta
+func (c *Client) AdjustInventory(ctx context.Context, productID string, delta int32, reason string) error {
+	_, err := c.client.AdjustInventory(ctx, &inventoryv1.AdjustInventoryRequest{
+		ProductId:     productID,
+		QuantityDelta: delta,
+		Reason:        reason,
+	})
+	if err != nil {
+		return fmt.Errorf("inventory adjust failed: %w", err)
+	}
+	return nil
+}
+
+// ReserveStock reserves all items or none, returning the shortfalls when the
+// reservation could not be made
+func (c *Client) ReserveStock(ctx context.Context, reservationID string, items map[string]int32, ttlSeconds int32) ([]service.UnavailableItem, error) {
+	reqItems := make([]*inventoryv1.ReservationRequest, 0, len(items))
+	for productID, quantity := range items {
+		reqItems = append(reqItems, &inventoryv1.ReservationRequest{
+			ProductId: productID,
+			Quantity:  quantity,
+		})
+	}
+
+	resp, err := c.client.ReserveStock(ctx, &inventoryv1.ReserveStockRequest{
+		ReservationId: reservationID,
+		Items:         reqItems,
+		TtlSeconds:    ttlSeconds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("inventory reserve failed: %w", err)
+	}
+	if resp.Success {
+		return nil, nil
+	}
+
+	unavailable := make([]service.UnavailableItem, len(resp.Failures))
+	for i, f := range resp.Failures {
+		unavailable[i] = service.UnavailableItem{
+			ProductID: f.ProductId,
+			Requested: f.Requested,
+			Available: f.Available,
+		}
+	}
+	return unavailable, nil
+}
+
+// maxConcurrentLookups bounds the GetInventory calls AvailableQuantities has
+// in flight at once
+const maxConcurrentLookups = 8
+
+// AvailableQuantities returns the quantity of each product that can still be
+// reserved, i.e. its total less active reservations. Products the inventory
+// service has no record of are left out.
+func (c *Client) AvailableQuantities(ctx context.Context, productIDs []string) (map[string]int32, error) {
+	var mu sync.Mutex
+	available := make(map[string]int32, len(productIDs))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentLookups)
+	for _, productID := range productIDs {
+		g.Go(func() error {
+			resp, err := c.client.GetInventory(gctx, &inventoryv1.GetInventoryRequest{ProductId: productID})
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("inventory get failed for %s: %w", productID, err)
+			}
+			mu.Lock()
+			available[productID] = resp.Inventory.AvailableQuantity
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return available, nil
+}
@@ -0,0 +1,133 @@
+package inventory
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+	"github.com/mumumio1/coldy/services/catalog/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeInventoryClient answers GetInventory from available and tracks how
+// many calls are in flight
+type fakeInventoryClient struct {
+	inventoryv1.InventoryServiceClient
+	available   map[string]int32
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (c *fakeInventoryClient) GetInventory(ctx context.Context, req *inventoryv1.GetInventoryRequest, opts ...grpc.CallOption) (*inventoryv1.GetInventoryResponse, error) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		maxN := c.maxInFlight.Load()
+		if n <= maxN || c.maxInFlight.CompareAndSwap(maxN, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	available, ok := c.available[req.ProductId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "inventory not found")
+	}
+	return &inventoryv1.GetInventoryResponse{Inventory: &inventoryv1.Inventory{
+		ProductId:         req.ProductId,
+		AvailableQuantity: available,
+	}}, nil
+}
+
+func TestAvailableQuantities(t *testing.T) {
+	fake := &fakeInventoryClient{available: map[string]int32{}}
+	productIDs := []string{"unknown"}
+	for i := 0; i < 3*maxConcurrentLookups; i++ {
+		productID := string(rune('a' + i))
+		fake.available[productID] = int32(i)
+		productIDs = append(productIDs, productID)
+	}
+
+	available, err := NewClient(fake).AvailableQuantities(context.Background(), productIDs)
+	if err != nil {
+		t.Fatalf("AvailableQuantities failed: %v", err)
+	}
+
+	if _, ok := available["unknown"]; ok {
+		t.Error("a product unknown to inventory was reported")
+	}
+	if len(available) != len(fake.available) {
+		t.Errorf("got %d quantities, want %d", len(available), len(fake.available))
+	}
+	for productID, want := range fake.available {
+		if available[productID] != want {
+			t.Errorf("%s = %d, want %d", productID, available[productID], want)
+		}
+	}
+	if got := fake.maxInFlight.Load(); got > maxConcurrentLookups {
+		t.Errorf("%d lookups in flight, want at most %d", got, maxConcurrentLookups)
+	}
+}
+
+// levelClient answers GetInventory with a fixed inventory record
+type levelClient struct {
+	inventoryv1.InventoryServiceClient
+	inventory *inventoryv1.Inventory
+	err       error
+}
+
+func (c *levelClient) GetInventory(ctx context.Context, req *inventoryv1.GetInventoryRequest, opts ...grpc.CallOption) (*inventoryv1.GetInventoryResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &inventoryv1.GetInventoryResponse{Inventory: c.inventory}, nil
+}
+
+func TestStockLevel(t *testing.T) {
+	updatedAt := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := map[string]struct {
+		client  *levelClient
+		want    service.StockLevel
+		wantErr bool
+	}{
+		"known product": {
+			client: &levelClient{inventory: &inventoryv1.Inventory{
+				AvailableQuantity: 7,
+				ReservedQuantity:  3,
+				TotalQuantity:     10,
+				UpdatedAt:         timestamppb.New(updatedAt),
+			}},
+			want: service.StockLevel{Available: 7, Reserved: 3, Total: 10, UpdatedAt: updatedAt},
+		},
+		"unknown product": {
+			client: &levelClient{err: status.Error(codes.NotFound, "inventory not found")},
+		},
+		"inventory down": {
+			client:  &levelClient{err: status.Error(codes.Unavailable, "connection refused")},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			level, err := NewClient(tt.client).StockLevel(context.Background(), "product-1")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", level)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StockLevel failed: %v", err)
+			}
+			if level != tt.want {
+				t.Errorf("level = %+v, want %+v", level, tt.want)
+			}
+		})
+	}
+}
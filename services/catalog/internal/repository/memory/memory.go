@@ -0,0 +1,369 @@
+// Package memory provides an in-memory ProductStore for exercising the
+// catalog service without Postgres. It mirrors the SQL repository's
+// observable behavior: tenant scoping, soft deletes, unique live SKUs per
+// tenant, a nil product for an unknown ID and newest-first keyset
+// pagination. Searches match the query as a case-insensitive substring of
+// the name or description rather than by full-text or trigram rules.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+	"github.com/mumumio1/coldy/services/catalog/internal/service"
+)
+
+var _ service.ProductStore = (*ProductStore)(nil)
+
+type storedProduct struct {
+	product  repository.Product
+	tenantID string
+	deleted  bool
+}
+
+// OutboxEvent is an event written to the catalog outbox
+type OutboxEvent struct {
+	AggregateID string
+	EventType   string
+	Payload     []byte
+}
+
+// ProductStore keeps products, their price history and outbox events in
+// memory. It is safe for concurrent use.
+type ProductStore struct {
+	mu       sync.Mutex
+	products map[string]*storedProduct
+	history  []*repository.PriceChange
+	outbox   []OutboxEvent
+	last     time.Time
+}
+
+// NewProductStore creates an empty store
+func NewProductStore() *ProductStore {
+	return &ProductStore{products: make(map[string]*storedProduct)}
+}
+
+// now returns a strictly increasing timestamp so ordering behaves as it does
+// against the database
+func (s *ProductStore) now() time.Time {
+	t := time.Now().UTC()
+	if !t.After(s.last) {
+		t = s.last.Add(time.Microsecond)
+	}
+	s.last = t
+	return t
+}
+
+// lookup returns the live product with id visible to the caller's tenant
+func (s *ProductStore) lookup(ctx context.Context, id string) *storedProduct {
+	stored, ok := s.products[id]
+	if !ok || stored.deleted || !visible(ctx, stored) {
+		return nil
+	}
+	return stored
+}
+
+// visible reports whether the caller's tenant may see stored; callers
+// without a tenant see every tenant's products
+func visible(ctx context.Context, stored *storedProduct) bool {
+	tenantID := middleware.TenantFromContext(ctx)
+	return tenantID == "" || stored.tenantID == tenantID
+}
+
+// Create stores a new product under a fresh ID
+func (s *ProductStore) Create(ctx context.Context, product *repository.Product) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenantID := middleware.TenantFromContext(ctx)
+	for _, stored := range s.products {
+		if !stored.deleted && stored.tenantID == tenantID && stored.product.SKU == product.SKU {
+			return fmt.Errorf("%w: %s", repository.ErrDuplicateSKU, product.SKU)
+		}
+	}
+
+	product.ID = uuid.New().String()
+	now := s.now()
+	product.CreatedAt = now
+	product.UpdatedAt = now
+	s.products[product.ID] = &storedProduct{product: cloneProduct(product), tenantID: tenantID}
+	return nil
+}
+
+// GetByID returns the product with id, or nil if it does not exist
+func (s *ProductStore) GetByID(ctx context.Context, id string) (*repository.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.lookup(ctx, id)
+	if stored == nil {
+		return nil, nil
+	}
+	product := cloneProduct(&stored.product)
+	return &product, nil
+}
+
+// GetBySKU returns the live product with sku, or nil if there is none
+func (s *ProductStore) GetBySKU(ctx context.Context, sku string) (*repository.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.products {
+		if !stored.deleted && visible(ctx, stored) && stored.product.SKU == sku {
+			product := cloneProduct(&stored.product)
+			return &product, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetByIDs returns the live products among ids, keyed by ID
+func (s *ProductStore) GetByIDs(ctx context.Context, ids []string) (map[string]*repository.Product, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	products := make(map[string]*repository.Product, len(ids))
+	for _, id := range ids {
+		if stored := s.lookup(ctx, id); stored != nil {
+			product := cloneProduct(&stored.product)
+			products[id] = &product
+		}
+	}
+	return products, nil
+}
+
+// Update applies apply to the product with id and stores the result,
+// recording a price change in the history and outbox. It returns nil when no
+// live product has id; an error from apply is returned as is.
+func (s *ProductStore) Update(ctx context.Context, id string, apply func(*repository.Product) error) (*repository.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.lookup(ctx, id)
+	if stored == nil {
+		return nil, nil
+	}
+
+	current := cloneProduct(&stored.product)
+	if err := apply(&current); err != nil {
+		return nil, err
+	}
+
+	// Only the columns the SQL repository writes are updated
+	old := stored.product
+	stored.product.Name = current.Name
+	stored.product.Description = current.Description
+	stored.product.PriceCurrency = current.PriceCurrency
+	stored.product.PriceAmount = current.PriceAmount
+	stored.product.Category = current.Category
+	stored.product.UpdatedAt = s.now()
+
+	if old.PriceCurrency != current.PriceCurrency || old.PriceAmount != current.PriceAmount {
+		s.history = append(s.history, &repository.PriceChange{
+			ID:          uuid.New().String(),
+			ProductID:   id,
+			OldCurrency: old.PriceCurrency,
+			OldAmount:   old.PriceAmount,
+			NewCurrency: current.PriceCurrency,
+			NewAmount:   current.PriceAmount,
+			ChangedAt:   stored.product.UpdatedAt,
+		})
+		s.outbox = append(s.outbox, OutboxEvent{AggregateID: id, EventType: repository.EventPriceChanged})
+	}
+
+	product := cloneProduct(&stored.product)
+	return &product, nil
+}
+
+// Delete soft-deletes a product. It reports false when no live product has id.
+func (s *ProductStore) Delete(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.lookup(ctx, id)
+	if stored == nil {
+		return false, nil
+	}
+	stored.deleted = true
+	stored.product.UpdatedAt = s.now()
+	return true, nil
+}
+
+// UpdateStock adds delta to the stock of the product with productID
+func (s *ProductStore) UpdateStock(ctx context.Context, productID string, delta int32) (int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.lookup(ctx, productID)
+	if stored == nil {
+		return 0, fmt.Errorf("failed to update stock: product %s not found", productID)
+	}
+	stored.product.StockQuantity += delta
+	stored.product.UpdatedAt = s.now()
+	return stored.product.StockQuantity, nil
+}
+
+// List returns live products in category matching search, newest first.
+// cursor is the ID of the last product of the previous page.
+func (s *ProductStore) List(ctx context.Context, limit int, cursor, category string, search repository.Search) ([]*repository.Product, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches := s.matching(ctx, search)
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+
+	var after *repository.Product
+	if cursor != "" {
+		if stored, ok := s.products[cursor]; ok {
+			after = &stored.product
+		}
+	}
+
+	var products []*repository.Product
+	for _, product := range matches {
+		if category != "" && product.Category != category {
+			continue
+		}
+		if after != nil && !before(product, after) {
+			continue
+		}
+		clone := cloneProduct(product)
+		products = append(products, &clone)
+	}
+
+	var nextCursor string
+	if len(products) > limit {
+		nextCursor = products[limit-1].ID
+		products = products[:limit]
+	}
+	return products, nextCursor, nil
+}
+
+// before reports whether a sorts after b in newest-first order
+func before(a, b *repository.Product) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+// CategoryCounts counts live products per category matching search
+func (s *ProductStore) CategoryCounts(ctx context.Context, search repository.Search) (map[string]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]int64)
+	for _, product := range s.matching(ctx, search) {
+		counts[product.Category]++
+	}
+	return counts, nil
+}
+
+// matching returns the live products visible to the caller that match search
+func (s *ProductStore) matching(ctx context.Context, search repository.Search) []*repository.Product {
+	query := strings.ToLower(strings.TrimSpace(search.Query))
+
+	var products []*repository.Product
+	for _, stored := range s.products {
+		if stored.deleted || !visible(ctx, stored) {
+			continue
+		}
+		text := strings.ToLower(stored.product.Name + " " + stored.product.Description)
+		if query != "" && !strings.Contains(text, query) {
+			continue
+		}
+		products = append(products, &stored.product)
+	}
+	return products
+}
+
+// GetPriceHistory returns a product's price changes, newest first. cursor is
+// the ID of the last change of the previous page.
+func (s *ProductStore) GetPriceHistory(ctx context.Context, productID string, limit int, cursor string) ([]*repository.PriceChange, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.products[productID]; !ok || !visible(ctx, stored) {
+		return nil, "", nil
+	}
+
+	var changes []*repository.PriceChange
+	seenCursor := cursor == ""
+	for i := len(s.history) - 1; i >= 0; i-- {
+		change := s.history[i]
+		if change.ProductID != productID {
+			continue
+		}
+		if !seenCursor {
+			seenCursor = change.ID == cursor
+			continue
+		}
+		clone := *change
+		changes = append(changes, &clone)
+	}
+
+	var nextCursor string
+	if len(changes) > limit {
+		nextCursor = changes[limit-1].ID
+		changes = changes[:limit]
+	}
+	return changes, nextCursor, nil
+}
+
+// InsertOutboxEvent records a product event
+func (s *ProductStore) InsertOutboxEvent(ctx context.Context, productID, eventType string, payload []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outbox = append(s.outbox, OutboxEvent{AggregateID: productID, EventType: eventType, Payload: payload})
+	return nil
+}
+
+// CheckAvailability returns the stock of each live product in items
+func (s *ProductStore) CheckAvailability(ctx context.Context, items map[string]int32) (map[string]int32, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	available := make(map[string]int32)
+	for productID := range items {
+		if stored := s.lookup(ctx, productID); stored != nil {
+			available[productID] = stored.product.StockQuantity
+		}
+	}
+	return available, nil
+}
+
+// OutboxEvents returns the events written to the outbox, oldest first
+func (s *ProductStore) OutboxEvents() []OutboxEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]OutboxEvent(nil), s.outbox...)
+}
+
+func cloneProduct(product *repository.Product) repository.Product {
+	clone := *product
+	clone.ImageURLs = append([]string(nil), product.ImageURLs...)
+	return clone
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/outbox"
+)
+
+var _ outbox.Store = (*OutboxStore)(nil)
+
+// OutboxStore reads and updates the catalog_outbox table for the outbox
+// publisher
+type OutboxStore struct {
+	db *sql.DB
+}
+
+// NewOutboxStore creates an outbox store over db
+func NewOutboxStore(db *sql.DB) *OutboxStore {
+	return &OutboxStore{db: db}
+}
+
+// GetUnpublished retrieves unpublished outbox events, oldest first
+func (s *OutboxStore) GetUnpublished(ctx context.Context, limit int) ([]outbox.Event, error) {
+	query := `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, attempts
+		FROM catalog_outbox
+		WHERE published = false AND dead_lettered_at IS NULL
+		ORDER BY created_at
+		LIMIT $1
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unpublished events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []outbox.Event
+	for rows.Next() {
+		var event outbox.Event
+		if err := rows.Scan(&event.ID, &event.AggregateType, &event.AggregateID, &event.EventType, &event.Data, &event.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if !json.Valid(event.Data) {
+			return nil, fmt.Errorf("invalid payload for event %s", event.ID)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+// MarkPublished marks an outbox event as published
+func (s *OutboxStore) MarkPublished(ctx context.Context, eventID string) error {
+	query := `
+		UPDATE catalog_outbox
+		SET published = true, published_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	result, err := s.db.ExecContext(ctx, query, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to mark event published: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("event not found")
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed publish attempt of an outbox event
+func (s *OutboxStore) MarkFailed(ctx context.Context, eventID string, cause error) error {
+	query := `
+		UPDATE catalog_outbox
+		SET attempts = attempts + 1, last_error = $2
+		WHERE id = $1
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, eventID, cause.Error()); err != nil {
+		return fmt.Errorf("failed to mark event failed: %w", err)
+	}
+
+	return nil
+}
+
+// MarkDeadLettered records a failed publish attempt and stops retrying the
+// event
+func (s *OutboxStore) MarkDeadLettered(ctx context.Context, eventID string, cause error) error {
+	query := `
+		UPDATE catalog_outbox
+		SET attempts = attempts + 1, last_error = $2, dead_lettered_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, eventID, cause.Error()); err != nil {
+		return fmt.Errorf("failed to dead-letter event: %w", err)
+	}
+
+	return nil
+}
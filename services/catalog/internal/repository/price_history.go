@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/middleware"
+)
+
+// EventPriceChanged is emitted through the catalog outbox when a product's
+// price changes
+const EventPriceChanged = "product.price_changed"
+
+// PriceChange is one entry of a product's price history
+type PriceChange struct {
+	ID          string
+	ProductID   string
+	OldCurrency string
+	OldAmount   int64
+	NewCurrency string
+	NewAmount   int64
+	ChangedAt   time.Time
+}
+
+// recordPriceChange appends change to the price history and queues its
+// EventPriceChanged event within tx
+func (r *ProductRepository) recordPriceChange(ctx context.Context, tx *sql.Tx, change *PriceChange) error {
+	change.ID = uuid.New().String()
+
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO product_price_history (id, product_id, old_currency, old_amount, new_currency, new_amount)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING changed_at
+	`,
+		change.ID,
+		change.ProductID,
+		change.OldCurrency,
+		change.OldAmount,
+		change.NewCurrency,
+		change.NewAmount,
+	).Scan(&change.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record price change: %w", err)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"product_id": change.ProductID,
+		"old_price":  map[string]interface{}{"currency": change.OldCurrency, "amount": change.OldAmount},
+		"new_price":  map[string]interface{}{"currency": change.NewCurrency, "amount": change.NewAmount},
+		"changed_at": change.ChangedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO catalog_outbox (id, aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1, $2, $3, $4, $5)
+	`, uuid.New().String(), "product", change.ProductID, EventPriceChanged, payload)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// GetPriceHistory returns a product's price changes, newest first. cursor is
+// the ID of the last change of the previous page.
+func (r *ProductRepository) GetPriceHistory(ctx context.Context, productID string, limit int, cursor string) ([]*PriceChange, string, error) {
+	query := `
+		SELECT h.id, h.product_id, h.old_currency, h.old_amount, h.new_currency, h.new_amount, h.changed_at
+		FROM product_price_history h
+		JOIN products p ON p.id = h.product_id
+		WHERE h.product_id = $1 AND ($2 = '' OR p.tenant_id = $2)
+	`
+	args := []interface{}{productID, middleware.TenantFromContext(ctx)}
+	argIdx := 3
+
+	if cursor != "" {
+		query += fmt.Sprintf(" AND (h.changed_at, h.id) < (SELECT changed_at, id FROM product_price_history WHERE id = $%d)", argIdx)
+		args = append(args, cursor)
+		argIdx++
+	}
+
+	query += " ORDER BY h.changed_at DESC, h.id DESC"
+	query += fmt.Sprintf(" LIMIT $%d", argIdx)
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get price history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var changes []*PriceChange
+	for rows.Next() {
+		var change PriceChange
+		if err := rows.Scan(
+			&change.ID,
+			&change.ProductID,
+			&change.OldCurrency,
+			&change.OldAmount,
+			&change.NewCurrency,
+			&change.NewAmount,
+			&change.ChangedAt,
+		); err != nil {
+			return nil, "", fmt.Errorf("failed to scan price change: %w", err)
+		}
+		changes = append(changes, &change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("rows error: %w", err)
+	}
+
+	var nextCursor string
+	if len(changes) > limit {
+		nextCursor = changes[limit-1].ID
+		changes = changes[:limit]
+	}
+
+	return changes, nextCursor, nil
+}
@@ -3,11 +3,23 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/mumumio1/coldy/pkg/database"
+	"github.com/mumumio1/coldy/pkg/middleware"
+)
+
+const uniqueViolation = "23505"
+
+var (
+	// ErrDuplicateSKU is returned when a live product of the tenant already has
+	// the SKU; deleted products free theirs
+	ErrDuplicateSKU = errors.New("duplicate sku")
 )
 
 // Product represents a product entity
@@ -27,7 +39,8 @@ type Product struct {
 
 // ProductRepository handles product data access
 type ProductRepository struct {
-	db *sql.DB
+	db   *sql.DB
+	trgm atomic.Int32
 }
 
 // NewProductRepository creates a new product repository
@@ -38,8 +51,8 @@ func NewProductRepository(db *sql.DB) *ProductRepository {
 // Create creates a new product
 func (r *ProductRepository) Create(ctx context.Context, product *Product) error {
 	query := `
-		INSERT INTO products (id, name, description, sku, price_currency, price_amount, stock_quantity, category, image_urls)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO products (id, name, description, sku, price_currency, price_amount, stock_quantity, category, image_urls, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING created_at, updated_at
 	`
 
@@ -55,8 +68,13 @@ func (r *ProductRepository) Create(ctx context.Context, product *Product) error
 		product.StockQuantity,
 		product.Category,
 		pq.Array(product.ImageURLs),
+		middleware.TenantFromContext(ctx),
 	).Scan(&product.CreatedAt, &product.UpdatedAt)
 
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == "idx_products_tenant_sku_live" {
+		return fmt.Errorf("%w: %s", ErrDuplicateSKU, product.SKU)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create product: %w", err)
 	}
@@ -66,16 +84,27 @@ func (r *ProductRepository) Create(ctx context.Context, product *Product) error
 
 // GetByID retrieves a product by ID
 func (r *ProductRepository) GetByID(ctx context.Context, id string) (*Product, error) {
+	return r.getOne(ctx, "id", id)
+}
+
+// GetBySKU retrieves a product by SKU
+func (r *ProductRepository) GetBySKU(ctx context.Context, sku string) (*Product, error) {
+	return r.getOne(ctx, "sku", sku)
+}
+
+// getOne retrieves a single product matching column = value.
+// column must be a trusted identifier, never user input.
+func (r *ProductRepository) getOne(ctx context.Context, column, value string) (*Product, error) {
 	query := `
 		SELECT id, name, description, sku, price_currency, price_amount, stock_quantity, category, image_urls, created_at, updated_at
 		FROM products
-		WHERE id = $1
+		WHERE ` + column + ` = $1 AND ($2 = '' OR tenant_id = $2) AND deleted_at IS NULL
 	`
 
 	var product Product
 	var imageURLs pq.StringArray
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, value, middleware.TenantFromContext(ctx)).Scan(
 		&product.ID,
 		&product.Name,
 		&product.Description,
@@ -100,29 +129,154 @@ func (r *ProductRepository) GetByID(ctx context.Context, id string) (*Product, e
 	return &product, nil
 }
 
-// Update updates a product
-func (r *ProductRepository) Update(ctx context.Context, product *Product) error {
+// GetByIDs retrieves several products in one query, keyed by ID.
+// Missing products are absent from the result.
+func (r *ProductRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*Product, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, name, description, sku, price_currency, price_amount, stock_quantity, category, image_urls, created_at, updated_at
+		FROM products
+		WHERE id = ANY($1) AND ($2 = '' OR tenant_id = $2) AND deleted_at IS NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids), middleware.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	products := make(map[string]*Product, len(ids))
+	for rows.Next() {
+		var product Product
+		var imageURLs pq.StringArray
+
+		if err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.Description,
+			&product.SKU,
+			&product.PriceCurrency,
+			&product.PriceAmount,
+			&product.StockQuantity,
+			&product.Category,
+			&imageURLs,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+
+		product.ImageURLs = imageURLs
+		products[product.ID] = &product
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return products, nil
+}
+
+// Update locks the product with id, applies apply to it and writes the result,
+// so fields apply leaves alone keep their stored values. A price change is
+// recorded in the price history along with an EventPriceChanged outbox event,
+// in the same transaction. It returns nil when no live product has id; an
+// error from apply is returned as is.
+func (r *ProductRepository) Update(ctx context.Context, id string, apply func(*Product) error) (*Product, error) {
+	var product *Product
+	err := database.WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+		var current Product
+		var imageURLs pq.StringArray
+		err := tx.QueryRowContext(ctx, `
+			SELECT id, name, description, sku, price_currency, price_amount, stock_quantity, category, image_urls, created_at, updated_at
+			FROM products
+			WHERE id = $1 AND ($2 = '' OR tenant_id = $2) AND deleted_at IS NULL
+			FOR UPDATE
+		`, id, middleware.TenantFromContext(ctx)).Scan(
+			&current.ID,
+			&current.Name,
+			&current.Description,
+			&current.SKU,
+			&current.PriceCurrency,
+			&current.PriceAmount,
+			&current.StockQuantity,
+			&current.Category,
+			&imageURLs,
+			&current.CreatedAt,
+			&current.UpdatedAt,
+		)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+		current.ImageURLs = imageURLs
+
+		oldCurrency, oldAmount := current.PriceCurrency, current.PriceAmount
+		if err := apply(&current); err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE products
+			SET name = $1, description = $2, price_currency = $3, price_amount = $4, category = $5, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $6
+			RETURNING updated_at
+		`
+
+		err = tx.QueryRowContext(ctx, query,
+			current.Name,
+			current.Description,
+			current.PriceCurrency,
+			current.PriceAmount,
+			current.Category,
+			current.ID,
+		).Scan(&current.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+		product = &current
+
+		if oldCurrency == current.PriceCurrency && oldAmount == current.PriceAmount {
+			return nil
+		}
+		return r.recordPriceChange(ctx, tx, &PriceChange{
+			ProductID:   current.ID,
+			OldCurrency: oldCurrency,
+			OldAmount:   oldAmount,
+			NewCurrency: current.PriceCurrency,
+			NewAmount:   current.PriceAmount,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// Delete soft-deletes a product. It reports false when no live product has id.
+func (r *ProductRepository) Delete(ctx context.Context, id string) (bool, error) {
 	query := `
 		UPDATE products
-		SET name = $1, description = $2, price_currency = $3, price_amount = $4, category = $5, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $6
-		RETURNING updated_at
+		SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND ($2 = '' OR tenant_id = $2) AND deleted_at IS NULL
 	`
 
-	err := r.db.QueryRowContext(ctx, query,
-		product.Name,
-		product.Description,
-		product.PriceCurrency,
-		product.PriceAmount,
-		product.Category,
-		product.ID,
-	).Scan(&product.UpdatedAt)
+	result, err := r.db.ExecContext(ctx, query, id, middleware.TenantFromContext(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete product: %w", err)
+	}
 
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to update product: %w", err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
-	return nil
+	return rowsAffected > 0, nil
 }
 
 // UpdateStock updates product stock quantity
@@ -130,12 +284,12 @@ func (r *ProductRepository) UpdateStock(ctx context.Context, productID string, d
 	query := `
 		UPDATE products
 		SET stock_quantity = stock_quantity + $1, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $2
+		WHERE id = $2 AND ($3 = '' OR tenant_id = $3) AND deleted_at IS NULL
 		RETURNING stock_quantity
 	`
 
 	var newQuantity int32
-	err := r.db.QueryRowContext(ctx, query, delta, productID).Scan(&newQuantity)
+	err := r.db.QueryRowContext(ctx, query, delta, productID, middleware.TenantFromContext(ctx)).Scan(&newQuantity)
 	if err != nil {
 		return 0, fmt.Errorf("failed to update stock: %w", err)
 	}
@@ -144,14 +298,14 @@ func (r *ProductRepository) UpdateStock(ctx context.Context, productID string, d
 }
 
 // List retrieves products with pagination and filters
-func (r *ProductRepository) List(ctx context.Context, limit int, cursor, category, searchQuery string) ([]*Product, string, error) {
+func (r *ProductRepository) List(ctx context.Context, limit int, cursor, category string, search Search) ([]*Product, string, error) {
 	baseQuery := `
 		SELECT id, name, description, sku, price_currency, price_amount, stock_quantity, category, image_urls, created_at, updated_at
 		FROM products
-		WHERE 1=1
+		WHERE deleted_at IS NULL AND ($1 = '' OR tenant_id = $1)
 	`
-	args := []interface{}{}
-	argIdx := 1
+	args := []interface{}{middleware.TenantFromContext(ctx)}
+	argIdx := 2
 
 	// Apply category filter
 	if category != "" {
@@ -161,10 +315,11 @@ func (r *ProductRepository) List(ctx context.Context, limit int, cursor, categor
 	}
 
 	// Apply search filter
-	if searchQuery != "" {
-		baseQuery += fmt.Sprintf(" AND to_tsvector('english', name || ' ' || COALESCE(description, '')) @@ plainto_tsquery('english', $%d)", argIdx)
-		args = append(args, searchQuery)
-		argIdx++
+	filter := r.filterFor(ctx, search, argIdx)
+	if filter.clause != "" {
+		baseQuery += " AND " + filter.clause
+		args = append(args, filter.args...)
+		argIdx += len(filter.args)
 	}
 
 	// Apply cursor pagination
@@ -178,40 +333,36 @@ func (r *ProductRepository) List(ctx context.Context, limit int, cursor, categor
 	baseQuery += fmt.Sprintf(" LIMIT $%d", argIdx)
 	args = append(args, limit+1)
 
-	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to list products: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
-
 	var products []*Product
-	for rows.Next() {
-		var product Product
-		var imageURLs pq.StringArray
-
-		err := rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Description,
-			&product.SKU,
-			&product.PriceCurrency,
-			&product.PriceAmount,
-			&product.StockQuantity,
-			&product.Category,
-			&imageURLs,
-			&product.CreatedAt,
-			&product.UpdatedAt,
-		)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to scan product: %w", err)
+	err := r.querySearch(ctx, filter, baseQuery, args, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var product Product
+			var imageURLs pq.StringArray
+
+			err := rows.Scan(
+				&product.ID,
+				&product.Name,
+				&product.Description,
+				&product.SKU,
+				&product.PriceCurrency,
+				&product.PriceAmount,
+				&product.StockQuantity,
+				&product.Category,
+				&imageURLs,
+				&product.CreatedAt,
+				&product.UpdatedAt,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to scan product: %w", err)
+			}
+
+			product.ImageURLs = imageURLs
+			products = append(products, &product)
 		}
-
-		product.ImageURLs = imageURLs
-		products = append(products, &product)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, "", fmt.Errorf("rows error: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list products: %w", err)
 	}
 
 	// Determine next cursor
@@ -224,6 +375,56 @@ func (r *ProductRepository) List(ctx context.Context, limit int, cursor, categor
 	return products, nextCursor, nil
 }
 
+// CategoryCounts counts products per category matching search (all products if its query is empty)
+func (r *ProductRepository) CategoryCounts(ctx context.Context, search Search) (map[string]int64, error) {
+	query := `
+		SELECT category, COUNT(*)
+		FROM products
+		WHERE deleted_at IS NULL AND ($1 = '' OR tenant_id = $1)
+	`
+	args := []interface{}{middleware.TenantFromContext(ctx)}
+	filter := r.filterFor(ctx, search, 2)
+	if filter.clause != "" {
+		query += " AND " + filter.clause
+		args = append(args, filter.args...)
+	}
+
+	query += " GROUP BY category"
+
+	counts := make(map[string]int64)
+	err := r.querySearch(ctx, filter, query, args, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var category sql.NullString
+			var count int64
+			if err := rows.Scan(&category, &count); err != nil {
+				return fmt.Errorf("failed to scan: %w", err)
+			}
+			counts[category.String] += count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count categories: %w", err)
+	}
+
+	return counts, nil
+}
+
+// InsertOutboxEvent records a product event for asynchronous publishing
+func (r *ProductRepository) InsertOutboxEvent(ctx context.Context, productID, eventType string, payload []byte) error {
+	query := `
+		INSERT INTO catalog_outbox (id, aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, uuid.New().String(), "product", productID, eventType, payload)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+
+	return nil
+}
+
 // CheckAvailability checks if products have sufficient stock
 func (r *ProductRepository) CheckAvailability(ctx context.Context, items map[string]int32) (map[string]int32, error) {
 	if len(items) == 0 {
@@ -239,10 +440,10 @@ func (r *ProductRepository) CheckAvailability(ctx context.Context, items map[str
 	query := `
 		SELECT id, stock_quantity
 		FROM products
-		WHERE id = ANY($1)
+		WHERE id = ANY($1) AND ($2 = '' OR tenant_id = $2) AND deleted_at IS NULL
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs), middleware.TenantFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to check availability: %w", err)
 	}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mumumio1/coldy/pkg/database"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultFuzzyThreshold is the trigram similarity a fuzzy match needs when
+// the search does not set one; it matches pg_trgm's own default
+const DefaultFuzzyThreshold = 0.3
+
+// SearchMode selects how a search query matches products
+type SearchMode int
+
+const (
+	// SearchFullText matches whole (stemmed) words
+	SearchFullText SearchMode = iota
+	// SearchPrefix matches every query word as a word prefix ("blu" finds "blue")
+	SearchPrefix
+	// SearchFuzzy matches names by trigram similarity, tolerating typos. It
+	// needs the pg_trgm extension and falls back to SearchPrefix without it.
+	SearchFuzzy
+)
+
+// Search is a product search. An empty Query matches every product.
+type Search struct {
+	Query string
+	Mode  SearchMode
+	// FuzzyThreshold is the minimum similarity (0-1] for SearchFuzzy; zero
+	// uses DefaultFuzzyThreshold
+	FuzzyThreshold float64
+}
+
+const searchDocument = `to_tsvector('english', name || ' ' || COALESCE(description, ''))`
+
+// Whether pg_trgm is installed; checked on first fuzzy search
+const (
+	trgmUnknown int32 = iota
+	trgmAvailable
+	trgmMissing
+)
+
+// searchFilter is the WHERE condition of a search and its arguments. A fuzzy
+// search also sets the trigram threshold its operators compare against.
+type searchFilter struct {
+	clause    string
+	args      []interface{}
+	threshold float64
+}
+
+// filterFor returns the filter for search with placeholders numbered from
+// argIdx. The clause is "" for an empty query.
+func (r *ProductRepository) filterFor(ctx context.Context, search Search, argIdx int) searchFilter {
+	if search.Query == "" {
+		return searchFilter{}
+	}
+
+	mode := search.Mode
+	if mode == SearchFuzzy && !r.hasTrigram(ctx) {
+		mode = SearchPrefix
+	}
+
+	switch mode {
+	case SearchFuzzy:
+		threshold := search.FuzzyThreshold
+		if threshold <= 0 || threshold > 1 {
+			threshold = DefaultFuzzyThreshold
+		}
+		// The operators, unlike similarity(), can use the trigram index.
+		// <% lets a short query match one word of a longer name.
+		return searchFilter{
+			clause:    fmt.Sprintf("(name %% $%d OR $%d <%% name)", argIdx, argIdx),
+			args:      []interface{}{search.Query},
+			threshold: threshold,
+		}
+	case SearchPrefix:
+		if query := prefixTSQuery(search.Query); query != "" {
+			return searchFilter{
+				clause: fmt.Sprintf("%s @@ to_tsquery('english', $%d)", searchDocument, argIdx),
+				args:   []interface{}{query},
+			}
+		}
+	}
+
+	return searchFilter{
+		clause: fmt.Sprintf("%s @@ plainto_tsquery('english', $%d)", searchDocument, argIdx),
+		args:   []interface{}{search.Query},
+	}
+}
+
+// querySearch runs a query filtered by filter and reads its rows with scan. A fuzzy
+// filter runs in a transaction that first sets the thresholds of the % and <%
+// operators, so they hold for this query only.
+func (r *ProductRepository) querySearch(ctx context.Context, filter searchFilter, query string, args []interface{}, scan func(*sql.Rows) error) error {
+	run := func(q interface {
+		QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	}) error {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		if err := scan(rows); err != nil {
+			return err
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("rows error: %w", err)
+		}
+		return nil
+	}
+
+	if filter.threshold == 0 {
+		return run(r.db)
+	}
+	return database.WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+		threshold := strconv.FormatFloat(filter.threshold, 'f', -1, 64)
+		_, err := tx.ExecContext(ctx, `
+			SELECT set_config('pg_trgm.similarity_threshold', $1, true),
+			       set_config('pg_trgm.word_similarity_threshold', $1, true)
+		`, threshold)
+		if err != nil {
+			return fmt.Errorf("failed to set trigram threshold: %w", err)
+		}
+		return run(tx)
+	})
+}
+
+// prefixTSQuery turns "blu shi" into "blu:* & shi:*". Anything but letters and
+// digits separates words, so user input cannot inject tsquery operators.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = strings.ToLower(word) + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// hasTrigram reports whether the pg_trgm extension is installed. A failed
+// check is not remembered, so it is retried on the next fuzzy search.
+func (r *ProductRepository) hasTrigram(ctx context.Context) bool {
+	switch r.trgm.Load() {
+	case trgmAvailable:
+		return true
+	case trgmMissing:
+		return false
+	}
+
+	var installed bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')").Scan(&installed)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to check for pg_trgm", zap.Error(err))
+		return false
+	}
+
+	if installed {
+		r.trgm.Store(trgmAvailable)
+	} else {
+		logger.FromContext(ctx).Warn("pg_trgm is not installed, fuzzy search falls back to prefix matching")
+		r.trgm.Store(trgmMissing)
+	}
+	return installed
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyFilterUsesTrigramOperators(t *testing.T) {
+	r := &ProductRepository{}
+	r.trgm.Store(trgmAvailable)
+
+	filter := r.filterFor(context.Background(), Search{Query: "blu shrt", Mode: SearchFuzzy}, 3)
+	if filter.clause != "(name % $3 OR $3 <% name)" {
+		t.Errorf("clause = %q, want the indexable %% and <%% operators", filter.clause)
+	}
+	if strings.Contains(filter.clause, "similarity(") {
+		t.Errorf("clause %q calls similarity(), which cannot use the index", filter.clause)
+	}
+	if filter.threshold != DefaultFuzzyThreshold {
+		t.Errorf("threshold = %v, want the default %v", filter.threshold, DefaultFuzzyThreshold)
+	}
+
+	filter = r.filterFor(context.Background(), Search{Query: "blu", Mode: SearchFuzzy, FuzzyThreshold: 0.5}, 2)
+	if filter.threshold != 0.5 {
+		t.Errorf("threshold = %v, want the requested 0.5", filter.threshold)
+	}
+}
+
+func TestFilterWithoutThreshold(t *testing.T) {
+	r := &ProductRepository{}
+	r.trgm.Store(trgmMissing)
+
+	tests := map[string]Search{
+		"full text":        {Query: "blue shirt"},
+		"prefix":           {Query: "blu", Mode: SearchPrefix},
+		"fuzzy without pg": {Query: "blu", Mode: SearchFuzzy},
+	}
+	for name, search := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter := r.filterFor(context.Background(), search, 2)
+			if !strings.Contains(filter.clause, "@@") {
+				t.Errorf("clause = %q, want a text search", filter.clause)
+			}
+			if filter.threshold != 0 {
+				t.Errorf("threshold = %v, want none outside fuzzy search", filter.threshold)
+			}
+		})
+	}
+
+	if filter := r.filterFor(context.Background(), Search{}, 2); filter.clause != "" {
+		t.Errorf("clause = %q, want none for an empty query", filter.clause)
+	}
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCheckAvailabilityCountsReservations(t *testing.T) {
+	ctx := context.Background()
+	f := newCatalogFixture(t)
+	mug := f.createProduct(t, ctx, "MUG-1", 10)
+	plate := f.createProduct(t, ctx, "PLATE-1", 10)
+	unknown := f.createProduct(t, ctx, "BOWL-1", 10)
+	deleted := f.createProduct(t, ctx, "CUP-1", 10)
+	if err := f.svc.DeleteProduct(ctx, deleted.ID); err != nil {
+		t.Fatalf("DeleteProduct failed: %v", err)
+	}
+
+	// The catalog holds 10 of each, but 8 mugs are already reserved
+	f.inventory.set(mug.ID, 2, 8)
+	f.inventory.set(plate.ID, 10, 0)
+	f.inventory.set(deleted.ID, 10, 0)
+
+	unavailable, err := f.svc.CheckAvailability(ctx, map[string]int32{
+		mug.ID:     3,
+		plate.ID:   3,
+		unknown.ID: 1,
+		deleted.ID: 1,
+	})
+	if err != nil {
+		t.Fatalf("CheckAvailability failed: %v", err)
+	}
+
+	got := make(map[string]int32)
+	for _, item := range unavailable {
+		got[item.ProductID] = item.Available
+	}
+	want := map[string]int32{mug.ID: 2, unknown.ID: 0, deleted.ID: 0}
+	if len(got) != len(want) {
+		t.Fatalf("unavailable = %+v, want %v", unavailable, want)
+	}
+	for productID, available := range want {
+		if a, ok := got[productID]; !ok || a != available {
+			t.Errorf("%s: available %d (reported %v), want %d", productID, a, ok, available)
+		}
+	}
+}
+
+func TestQuoteItemsUsesReservableStock(t *testing.T) {
+	ctx := context.Background()
+	f := newCatalogFixture(t)
+	mug := f.createProduct(t, ctx, "MUG-1", 10)
+	f.inventory.set(mug.ID, 4, 6)
+
+	quotes, err := f.svc.QuoteItems(ctx, map[string]int32{mug.ID: 5, "missing": 1})
+	if err != nil {
+		t.Fatalf("QuoteItems failed: %v", err)
+	}
+
+	for _, quote := range quotes {
+		switch quote.ProductID {
+		case mug.ID:
+			if !quote.Found || quote.Available != 4 || quote.InStock {
+				t.Errorf("mug quote = %+v, want 4 available and not in stock", quote)
+			}
+			if quote.PriceAmount != 1000 || quote.PriceCurrency != "USD" {
+				t.Errorf("mug price = %d %s, want 1000 USD", quote.PriceAmount, quote.PriceCurrency)
+			}
+		case "missing":
+			if quote.Found {
+				t.Errorf("unknown product quoted as found: %+v", quote)
+			}
+		}
+	}
+}
+
+func TestCheckAvailabilityFailsWithoutInventory(t *testing.T) {
+	ctx := context.Background()
+	f := newCatalogFixture(t)
+	mug := f.createProduct(t, ctx, "MUG-1", 10)
+	f.inventory.fail(errInventoryDown)
+
+	// The catalog's own stock ignores reservations, so it is no fallback
+	if _, err := f.svc.CheckAvailability(ctx, map[string]int32{mug.ID: 1}); !errors.Is(err, errInventoryDown) {
+		t.Errorf("expected the inventory error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/mumumio1/coldy/pkg/cache"
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+)
+
+// invalidateListCache and FlushCache delete ListCachePrefix+"*", so every
+// cached list page and facet count must be keyed under it
+func TestListCacheKeysShareInvalidatedPrefix(t *testing.T) {
+	s := &CatalogService{cache: &cache.RedisCache{}}
+	ctx := context.Background()
+	prefix := s.cache.Key(ListCachePrefix)
+
+	keys := []string{
+		s.generateListCacheKey(ctx, 20, "", "", repository.Search{}),
+		s.generateListCacheKey(ctx, 20, "cursor-1", "kitchen", repository.Search{Query: "mug", Mode: repository.SearchFuzzy}),
+		s.generateFacetCacheKey(ctx, repository.Search{Query: "mug"}),
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			t.Errorf("key %q is not under %q and would never be invalidated", key, prefix)
+		}
+	}
+}
+
+// invalidateProduct matches "*:"+escapeGlob(id); the escaped ID must only
+// match itself
+func TestEscapeGlobMatchesLiterally(t *testing.T) {
+	tests := map[string]string{
+		"p-1": "p-2",
+		"p*":  "p-1",
+		"p?":  "p1",
+		"[p]": "p",
+		`p\1`: "p1",
+	}
+
+	for id, other := range tests {
+		if ok, err := path.Match(escapeGlob(id), id); err != nil || !ok {
+			t.Errorf("escapeGlob(%q) = %q does not match itself (err %v)", id, escapeGlob(id), err)
+		}
+		if ok, _ := path.Match(escapeGlob(id), other); ok {
+			t.Errorf("escapeGlob(%q) matches %q", id, other)
+		}
+	}
+}
@@ -3,71 +3,223 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/mumumio1/coldy/pkg/cache"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/money"
 	"github.com/mumumio1/coldy/services/catalog/internal/repository"
 	"go.uber.org/zap"
 )
 
-const (
-	// Cache TTLs
-	ProductCacheTTL = 5 * time.Minute
-	ListCacheTTL    = 2 * time.Minute
+// ScopeCatalogAdmin is required for catalog maintenance RPCs
+const ScopeCatalogAdmin = "catalog:admin"
 
+const (
 	// Cache key prefixes
 	ProductCachePrefix = "product:"
 	ListCachePrefix    = "products:list:"
+	// StockLevelCachePrefix keys live stock levels from the inventory service
+	StockLevelCachePrefix = "stock:"
+
+	// CacheVersion is the default cache key version. Bump it when the shape
+	// of cached products or list pages changes, so a deploy doesn't read
+	// entries written by the previous release.
+	CacheVersion = "1"
+
+	// notFoundMarker is cached under a product key when the product does not exist
+	notFoundMarker = "__not_found__"
+)
+
+var (
+	// ErrProductNotFound is returned when a product does not exist
+	ErrProductNotFound = errors.New("product not found")
+	// ErrProductUnavailable is returned when a request references a product
+	// that does not exist or was deleted
+	ErrProductUnavailable = errors.New("product unavailable")
+	// ErrInvalidUpdateMask is returned when an update mask names a field that
+	// cannot be updated or would leave the product invalid
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
 )
 
+// ProductUpdate holds the new values of the fields named in an update mask
+type ProductUpdate struct {
+	Name        string
+	Description string
+	// Price is required when the mask names price
+	Price    *money.Money
+	Category string
+}
+
+// StockReserver reserves stock atomically, all items or none
+type StockReserver interface {
+	// ReserveStock returns the unavailable items when the reservation could not be made
+	ReserveStock(ctx context.Context, reservationID string, items map[string]int32, ttlSeconds int32) ([]UnavailableItem, error)
+}
+
+// StockAvailability reports stock that can still be reserved
+type StockAvailability interface {
+	// AvailableQuantities returns the total less active reservations of each
+	// product; products the inventory service doesn't know are left out
+	AvailableQuantities(ctx context.Context, productIDs []string) (map[string]int32, error)
+}
+
+// Inventory is the catalog's view of the inventory service
+type Inventory interface {
+	StockReserver
+	StockLedger
+	StockAvailability
+	StockLevels
+}
+
+// CacheConfig configures catalog caching
+type CacheConfig struct {
+	ProductTTL time.Duration
+	ListTTL    time.Duration
+	// NotFoundTTL is how long a missing product is remembered; kept shorter than ProductTTL
+	NotFoundTTL time.Duration
+	// StockTTL is how long live stock levels are cached; kept short since
+	// every reservation changes them
+	StockTTL time.Duration
+	// Jitter spreads each TTL randomly by up to this fraction (0.1 = ±10%)
+	// so keys written together don't expire together
+	Jitter float64
+}
+
+// DefaultCacheConfig returns the default cache configuration
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		ProductTTL:  5 * time.Minute,
+		ListTTL:     2 * time.Minute,
+		NotFoundTTL: 30 * time.Second,
+		StockTTL:    5 * time.Second,
+		Jitter:      0.1,
+	}
+}
+
+// ttl returns base randomized within ±Jitter
+func (c CacheConfig) ttl(base time.Duration) time.Duration {
+	if c.Jitter <= 0 || base <= 0 {
+		return base
+	}
+	jitter := math.Min(c.Jitter, 1)
+	factor := 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(base) * factor)
+}
+
 // CatalogService handles catalog business logic
 type CatalogService struct {
-	repo   *repository.ProductRepository
-	cache  *cache.RedisCache
-	logger *zap.Logger
+	repo            ProductStore
+	cache           *cache.RedisCache
+	products        *cache.Typed[repository.Product]
+	stockLevels     *cache.Typed[StockLevel]
+	cacheConfig     CacheConfig
+	inventory       Inventory
+	reconcileConfig ReconcileConfig
+	logger          *zap.Logger
 }
 
 // NewCatalogService creates a new catalog service
-func NewCatalogService(repo *repository.ProductRepository, cache *cache.RedisCache, logger *zap.Logger) *CatalogService {
+func NewCatalogService(repo ProductStore, redisCache *cache.RedisCache, cacheConfig CacheConfig, inventory Inventory, reconcileConfig ReconcileConfig, logger *zap.Logger) *CatalogService {
+	defaults := DefaultCacheConfig()
+	if cacheConfig.ProductTTL <= 0 {
+		cacheConfig.ProductTTL = defaults.ProductTTL
+	}
+	if cacheConfig.ListTTL <= 0 {
+		cacheConfig.ListTTL = defaults.ListTTL
+	}
+	if cacheConfig.NotFoundTTL <= 0 || cacheConfig.NotFoundTTL >= cacheConfig.ProductTTL {
+		cacheConfig.NotFoundTTL = min(defaults.NotFoundTTL, cacheConfig.ProductTTL/2)
+	}
+	if cacheConfig.StockTTL <= 0 {
+		cacheConfig.StockTTL = defaults.StockTTL
+	}
+
 	return &CatalogService{
-		repo:   repo,
-		cache:  cache,
-		logger: logger,
+		repo:            repo,
+		cache:           redisCache,
+		products:        cache.NewTyped[repository.Product](redisCache, ProductCachePrefix),
+		stockLevels:     cache.NewTyped[StockLevel](redisCache, StockLevelCachePrefix),
+		cacheConfig:     cacheConfig,
+		inventory:       inventory,
+		reconcileConfig: reconcileConfig,
+		logger:          logger,
 	}
 }
 
 // GetProduct retrieves a product with cache
 func (s *CatalogService) GetProduct(ctx context.Context, productID string) (*repository.Product, error) {
-	cacheKey := ProductCachePrefix + productID
+	cacheKey := s.products.Key(tenantKey(ctx, productID))
 
 	// Try cache first (read-through pattern)
 	var product repository.Product
-	found, err := s.cache.GetJSON(ctx, cacheKey, &product)
+	cached, err := s.cache.Get(ctx, cacheKey)
 	if err != nil {
-		s.logger.Warn("cache get failed", zap.Error(err))
+		logger.FromContext(ctx).Warn("cache get failed", zap.Error(err))
 	}
-	if found {
-		s.logger.Debug("cache hit", zap.String("product_id", productID))
-		return &product, nil
+	switch {
+	case cached == notFoundMarker:
+		logger.FromContext(ctx).Debug("negative cache hit", zap.String("product_id", productID))
+		return nil, ErrProductNotFound
+	case cached != "":
+		if err := json.Unmarshal([]byte(cached), &product); err == nil {
+			logger.FromContext(ctx).Debug("cache hit", zap.String("product_id", productID))
+			return &product, nil
+		}
+		logger.FromContext(ctx).Warn("cached product is corrupt", zap.String("product_id", productID))
 	}
 
-	// Cache miss - fetch from database
-	s.logger.Debug("cache miss", zap.String("product_id", productID))
-	productPtr, err := s.repo.GetByID(ctx, productID)
+	// Cache miss - fetch from database. The cache collapses concurrent misses
+	// for the same product into a single load, across replicas if configured.
+	logger.FromContext(ctx).Debug("cache miss", zap.String("product_id", productID))
+	product, err = s.products.GetOrLoad(ctx, tenantKey(ctx, productID), s.cacheConfig.ttl(s.cacheConfig.ProductTTL), func(loadCtx context.Context) (repository.Product, error) {
+		productPtr, err := s.repo.GetByID(loadCtx, productID)
+		if err != nil {
+			return repository.Product{}, fmt.Errorf("failed to get product: %w", err)
+		}
+		if productPtr == nil {
+			// Remember the miss briefly so repeated lookups don't reach the database
+			if err := s.cache.Set(loadCtx, cacheKey, notFoundMarker, s.cacheConfig.ttl(s.cacheConfig.NotFoundTTL)); err != nil {
+				logger.FromContext(ctx).Warn("cache set failed", zap.Error(err))
+			}
+			return repository.Product{}, ErrProductNotFound
+		}
+		return *productPtr, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// GetProductBySKU retrieves a product by SKU
+func (s *CatalogService) GetProductBySKU(ctx context.Context, sku string) (*repository.Product, error) {
+	product, err := s.repo.GetBySKU(ctx, sku)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
-	if productPtr == nil {
-		return nil, fmt.Errorf("product not found")
+	if product == nil {
+		return nil, ErrProductNotFound
 	}
+	return product, nil
+}
 
-	// Store in cache
-	if err := s.cache.SetJSON(ctx, cacheKey, productPtr, ProductCacheTTL); err != nil {
-		s.logger.Warn("cache set failed", zap.Error(err))
+// GetPriceHistory returns a product's price changes, newest first
+func (s *CatalogService) GetPriceHistory(ctx context.Context, productID string, limit int, cursor string) ([]*repository.PriceChange, string, bool, error) {
+	changes, nextCursor, err := s.repo.GetPriceHistory(ctx, productID, limit, cursor)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to get price history: %w", err)
 	}
-
-	return productPtr, nil
+	return changes, nextCursor, nextCursor != "", nil
 }
 
 // CreateProduct creates a new product
@@ -76,29 +228,93 @@ func (s *CatalogService) CreateProduct(ctx context.Context, product *repository.
 		return fmt.Errorf("failed to create product: %w", err)
 	}
 
+	// Clear any not-found marker for this ID
+	s.invalidateProduct(ctx, product.ID)
+
 	// Invalidate list cache
 	s.invalidateListCache(ctx)
 
-	s.logger.Info("product created", zap.String("product_id", product.ID))
+	logger.FromContext(ctx).Info("product created", zap.String("product_id", product.ID))
 	return nil
 }
 
-// UpdateProduct updates a product
-func (s *CatalogService) UpdateProduct(ctx context.Context, product *repository.Product) error {
-	if err := s.repo.Update(ctx, product); err != nil {
-		return fmt.Errorf("failed to update product: %w", err)
+// UpdateProduct sets the fields of a product named in mask to their values
+// in update, leaving the others as they are. An empty mask changes nothing.
+func (s *CatalogService) UpdateProduct(ctx context.Context, productID string, update ProductUpdate, mask []string) (*repository.Product, error) {
+	if len(mask) == 0 {
+		return s.GetProduct(ctx, productID)
 	}
 
-	// Invalidate cache
-	cacheKey := ProductCachePrefix + product.ID
-	if err := s.cache.Delete(ctx, cacheKey); err != nil {
-		s.logger.Warn("cache delete failed", zap.Error(err))
+	// The mask is applied to the row read under lock, never to a cached copy,
+	// so fields outside it are written back unchanged
+	product, err := s.repo.Update(ctx, productID, func(product *repository.Product) error {
+		return applyProductMask(product, update, mask)
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidUpdateMask) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	if product == nil {
+		return nil, ErrProductNotFound
 	}
 
+	// Invalidate cache
+	s.invalidateProduct(ctx, product.ID)
+
 	// Invalidate list cache
 	s.invalidateListCache(ctx)
 
-	s.logger.Info("product updated", zap.String("product_id", product.ID))
+	logger.FromContext(ctx).Info("product updated",
+		zap.String("product_id", product.ID),
+		zap.Strings("fields", mask),
+	)
+	return product, nil
+}
+
+// applyProductMask sets the fields of product named in mask to their values
+// in update
+func applyProductMask(product *repository.Product, update ProductUpdate, mask []string) error {
+	for _, path := range mask {
+		switch path {
+		case "name":
+			if update.Name == "" {
+				return fmt.Errorf("%w: name cannot be cleared", ErrInvalidUpdateMask)
+			}
+			product.Name = update.Name
+		case "description":
+			product.Description = update.Description
+		case "price":
+			if update.Price == nil {
+				return fmt.Errorf("%w: price cannot be cleared", ErrInvalidUpdateMask)
+			}
+			product.PriceCurrency = update.Price.Currency
+			product.PriceAmount = update.Price.Amount
+		case "category":
+			product.Category = update.Category
+		default:
+			return fmt.Errorf("%w: field %q cannot be updated", ErrInvalidUpdateMask, path)
+		}
+	}
+	return nil
+}
+
+// DeleteProduct soft-deletes a product. Deleted products disappear from reads
+// and are reported unavailable to availability checks and reservations.
+func (s *CatalogService) DeleteProduct(ctx context.Context, productID string) error {
+	deleted, err := s.repo.Delete(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	if !deleted {
+		return ErrProductNotFound
+	}
+
+	s.invalidateProduct(ctx, productID)
+	s.invalidateListCache(ctx)
+
+	logger.FromContext(ctx).Info("product deleted", zap.String("product_id", productID))
 	return nil
 }
 
@@ -109,13 +325,11 @@ func (s *CatalogService) UpdateStock(ctx context.Context, productID string, delt
 		return 0, fmt.Errorf("failed to update stock: %w", err)
 	}
 
-	// Invalidate cache
-	cacheKey := ProductCachePrefix + productID
-	if err := s.cache.Delete(ctx, cacheKey); err != nil {
-		s.logger.Warn("cache delete failed", zap.Error(err))
-	}
+	// Invalidate cache; list pages carry the stock quantity too
+	s.invalidateProduct(ctx, productID)
+	s.invalidateListCache(ctx)
 
-	s.logger.Info("stock updated",
+	logger.FromContext(ctx).Info("stock updated",
 		zap.String("product_id", productID),
 		zap.Int32("delta", delta),
 		zap.Int32("new_quantity", newQuantity),
@@ -125,9 +339,9 @@ func (s *CatalogService) UpdateStock(ctx context.Context, productID string, delt
 }
 
 // ListProducts lists products with caching
-func (s *CatalogService) ListProducts(ctx context.Context, limit int, cursor, category, searchQuery string) ([]*repository.Product, string, bool, error) {
+func (s *CatalogService) ListProducts(ctx context.Context, limit int, cursor, category string, search repository.Search) ([]*repository.Product, string, bool, error) {
 	// Generate cache key
-	cacheKey := s.generateListCacheKey(limit, cursor, category, searchQuery)
+	cacheKey := s.generateListCacheKey(ctx, limit, cursor, category, search)
 
 	// Try cache first
 	type cachedList struct {
@@ -138,16 +352,16 @@ func (s *CatalogService) ListProducts(ctx context.Context, limit int, cursor, ca
 	var cached cachedList
 	found, err := s.cache.GetJSON(ctx, cacheKey, &cached)
 	if err != nil {
-		s.logger.Warn("cache get failed", zap.Error(err))
+		logger.FromContext(ctx).Warn("cache get failed", zap.Error(err))
 	}
 	if found {
-		s.logger.Debug("list cache hit")
+		logger.FromContext(ctx).Debug("list cache hit")
 		return cached.Products, cached.NextCursor, cached.NextCursor != "", nil
 	}
 
 	// Cache miss - fetch from database
-	s.logger.Debug("list cache miss")
-	products, nextCursor, err := s.repo.List(ctx, limit, cursor, category, searchQuery)
+	logger.FromContext(ctx).Debug("list cache miss")
+	products, nextCursor, err := s.repo.List(ctx, limit, cursor, category, search)
 	if err != nil {
 		return nil, "", false, fmt.Errorf("failed to list products: %w", err)
 	}
@@ -157,19 +371,47 @@ func (s *CatalogService) ListProducts(ctx context.Context, limit int, cursor, ca
 		Products:   products,
 		NextCursor: nextCursor,
 	}
-	if err := s.cache.SetJSON(ctx, cacheKey, cached, ListCacheTTL); err != nil {
-		s.logger.Warn("cache set failed", zap.Error(err))
+	if err := s.cache.SetJSON(ctx, cacheKey, cached, s.cacheConfig.ttl(s.cacheConfig.ListTTL)); err != nil {
+		logger.FromContext(ctx).Warn("cache set failed", zap.Error(err))
 	}
 
 	hasMore := nextCursor != ""
 	return products, nextCursor, hasMore, nil
 }
 
-// CheckAvailability checks if products have sufficient stock
+// CategoryFacets returns product counts per category for a search. Like the
+// list pages it is cached under the list prefix, so it expires and is
+// invalidated together with them.
+func (s *CatalogService) CategoryFacets(ctx context.Context, search repository.Search) (map[string]int64, error) {
+	cacheKey := s.generateFacetCacheKey(ctx, search)
+
+	var counts map[string]int64
+	found, err := s.cache.GetJSON(ctx, cacheKey, &counts)
+	if err != nil {
+		logger.FromContext(ctx).Warn("cache get failed", zap.Error(err))
+	}
+	if found {
+		return counts, nil
+	}
+
+	counts, err = s.repo.CategoryCounts(ctx, search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count categories: %w", err)
+	}
+
+	if err := s.cache.SetJSON(ctx, cacheKey, counts, s.cacheConfig.ttl(s.cacheConfig.ListTTL)); err != nil {
+		logger.FromContext(ctx).Warn("cache set failed", zap.Error(err))
+	}
+
+	return counts, nil
+}
+
+// CheckAvailability checks if products have sufficient stock. Stock held by
+// active reservations counts as unavailable.
 func (s *CatalogService) CheckAvailability(ctx context.Context, items map[string]int32) ([]UnavailableItem, error) {
-	available, err := s.repo.CheckAvailability(ctx, items)
+	available, err := s.availableStock(ctx, items)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check availability: %w", err)
+		return nil, err
 	}
 
 	var unavailable []UnavailableItem
@@ -196,6 +438,196 @@ func (s *CatalogService) CheckAvailability(ctx context.Context, items map[string
 	return unavailable, nil
 }
 
+// availableStock returns the reservable quantity of each product in items
+// that exists in the catalog. The catalog's stock_quantity ignores
+// reservations, so the quantity comes from the inventory service; a product
+// it has no record of has none available.
+func (s *CatalogService) availableStock(ctx context.Context, items map[string]int32) (map[string]int32, error) {
+	available, err := s.repo.CheckAvailability(ctx, items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if len(available) == 0 {
+		return available, nil
+	}
+
+	productIDs := make([]string, 0, len(available))
+	for productID := range available {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+
+	reservable, err := s.inventory.AvailableQuantities(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory availability: %w", err)
+	}
+	for _, productID := range productIDs {
+		available[productID] = reservable[productID]
+	}
+	return available, nil
+}
+
+// ReserveIfAvailable checks availability and reserves the stock in one step by
+// delegating to the inventory service's locked reservation. It returns the
+// reservation ID on success, or the unavailable items when nothing was reserved.
+func (s *CatalogService) ReserveIfAvailable(ctx context.Context, reservationID string, items map[string]int32, ttlSeconds int32) (string, []UnavailableItem, error) {
+	if reservationID == "" {
+		reservationID = uuid.New().String()
+	}
+
+	// Inventory does not know about catalog deletions; refuse deleted
+	// products before any stock is held for them
+	productIDs := make([]string, 0, len(items))
+	for productID := range items {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+
+	products, err := s.getProducts(ctx, productIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, productID := range productIDs {
+		if _, ok := products[productID]; !ok {
+			return "", nil, fmt.Errorf("%w: %s", ErrProductUnavailable, productID)
+		}
+	}
+
+	unavailable, err := s.inventory.ReserveStock(ctx, reservationID, items, ttlSeconds)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	if len(unavailable) > 0 {
+		return "", unavailable, nil
+	}
+
+	logger.FromContext(ctx).Info("stock reserved",
+		zap.String("reservation_id", reservationID),
+		zap.Int("items_count", len(items)),
+	)
+
+	return reservationID, nil, nil
+}
+
+// ItemQuote is the current price and availability of a requested product
+type ItemQuote struct {
+	ProductID string
+	// Found is false for unknown products; the other fields are then zero
+	Found         bool
+	Name          string
+	PriceCurrency string
+	PriceAmount   int64
+	Requested     int32
+	Available     int32
+	// InStock reports whether Available covers Requested
+	InStock bool
+}
+
+// QuoteItems returns the price and availability of many products at once.
+// Product details come from the cache where possible and the misses are
+// loaded with a single query; stock always comes from one batched
+// availability query, since cached stock may be stale.
+func (s *CatalogService) QuoteItems(ctx context.Context, items map[string]int32) ([]ItemQuote, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	productIDs := make([]string, 0, len(items))
+	for productID := range items {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+
+	products, err := s.getProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := s.availableStock(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make([]ItemQuote, len(productIDs))
+	for i, productID := range productIDs {
+		quote := ItemQuote{
+			ProductID: productID,
+			Requested: items[productID],
+		}
+
+		product, ok := products[productID]
+		stock, inDB := available[productID]
+		if ok && inDB {
+			quote.Found = true
+			quote.Name = product.Name
+			quote.PriceCurrency = product.PriceCurrency
+			quote.PriceAmount = product.PriceAmount
+			quote.Available = stock
+			quote.InStock = stock >= quote.Requested
+		}
+
+		quotes[i] = quote
+	}
+
+	return quotes, nil
+}
+
+// getProducts loads products by ID through the cache, fetching all misses in one query
+func (s *CatalogService) getProducts(ctx context.Context, productIDs []string) (map[string]*repository.Product, error) {
+	keys := make([]string, len(productIDs))
+	for i, productID := range productIDs {
+		keys[i] = s.products.Key(tenantKey(ctx, productID))
+	}
+
+	cached, err := s.cache.MGet(ctx, keys...)
+	if err != nil {
+		logger.FromContext(ctx).Warn("cache get failed", zap.Error(err))
+		cached = make([]string, len(keys))
+	}
+
+	products := make(map[string]*repository.Product, len(productIDs))
+	var misses []string
+	for i, productID := range productIDs {
+		switch cached[i] {
+		case notFoundMarker:
+			continue
+		case "":
+		default:
+			var product repository.Product
+			if err := json.Unmarshal([]byte(cached[i]), &product); err == nil {
+				products[productID] = &product
+				continue
+			}
+		}
+		misses = append(misses, productID)
+	}
+
+	if len(misses) == 0 {
+		return products, nil
+	}
+
+	loaded, err := s.repo.GetByIDs(ctx, misses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	for _, productID := range misses {
+		product, ok := loaded[productID]
+		if !ok {
+			if err := s.cache.Set(ctx, s.products.Key(tenantKey(ctx, productID)), notFoundMarker, s.cacheConfig.ttl(s.cacheConfig.NotFoundTTL)); err != nil {
+				logger.FromContext(ctx).Warn("cache set failed", zap.Error(err))
+			}
+			continue
+		}
+		if err := s.products.Set(ctx, tenantKey(ctx, productID), *product, s.cacheConfig.ttl(s.cacheConfig.ProductTTL)); err != nil {
+			logger.FromContext(ctx).Warn("cache set failed", zap.Error(err))
+		}
+		products[productID] = product
+	}
+
+	return products, nil
+}
+
 // UnavailableItem represents an unavailable product
 type UnavailableItem struct {
 	ProductID string
@@ -203,18 +635,87 @@ type UnavailableItem struct {
 	Available int32
 }
 
-func (s *CatalogService) generateListCacheKey(limit int, cursor, category, searchQuery string) string {
+func (s *CatalogService) generateListCacheKey(ctx context.Context, limit int, cursor, category string, search repository.Search) string {
 	data := map[string]interface{}{
-		"limit":  limit,
-		"cursor": cursor,
-		"cat":    category,
-		"search": searchQuery,
+		"tenant":    middleware.TenantFromContext(ctx),
+		"limit":     limit,
+		"cursor":    cursor,
+		"cat":       category,
+		"search":    search.Query,
+		"mode":      search.Mode,
+		"threshold": search.FuzzyThreshold,
 	}
 	jsonData, _ := json.Marshal(data)
-	return ListCachePrefix + string(jsonData)
+	return s.cache.Key(ListCachePrefix + string(jsonData))
+}
+
+func (s *CatalogService) generateFacetCacheKey(ctx context.Context, search repository.Search) string {
+	jsonData, _ := json.Marshal(map[string]interface{}{
+		"tenant":    middleware.TenantFromContext(ctx),
+		"facets":    true,
+		"search":    search.Query,
+		"mode":      search.Mode,
+		"threshold": search.FuzzyThreshold,
+	})
+	return s.cache.Key(ListCachePrefix + string(jsonData))
+}
+
+// FlushCache drops every cached product, list page and stock level so the next
+// reads load from the database and the inventory service, e.g. after a bulk
+// import or price change. It returns the number of keys removed.
+func (s *CatalogService) FlushCache(ctx context.Context) (int64, error) {
+	var total int64
+	for _, prefix := range []string{ProductCachePrefix, ListCachePrefix, StockLevelCachePrefix} {
+		deleted, err := s.cache.DeleteByPattern(ctx, s.cache.Key(prefix+"*"))
+		total += deleted
+		if err != nil {
+			return total, fmt.Errorf("failed to flush %s cache: %w", prefix, err)
+		}
+	}
+
+	logger.FromContext(ctx).Info("flushed catalog cache", zap.Int64("keys", total))
+	return total, nil
+}
+
+// tenantKey scopes a product cache key to the caller's tenant, since product
+// IDs are looked up without knowing which tenant owns them
+func tenantKey(ctx context.Context, productID string) string {
+	if tenantID := middleware.TenantFromContext(ctx); tenantID != "" {
+		return tenantID + ":" + productID
+	}
+	return productID
 }
 
-func (s *CatalogService) invalidateListCache(_ context.Context) {
-	// In production, use Redis SCAN to find and delete all list cache keys
-	s.logger.Debug("invalidating list cache")
+// invalidateProduct drops a cached product under every key it may be cached
+// as: the scope of each tenant that looked it up, and the unscoped ID
+func (s *CatalogService) invalidateProduct(ctx context.Context, productID string) {
+	if err := s.products.Delete(ctx, productID); err != nil {
+		logger.FromContext(ctx).Warn("cache delete failed", zap.Error(err))
+	}
+	if _, err := s.cache.DeleteByPattern(ctx, s.products.Key("*:"+escapeGlob(productID))); err != nil {
+		logger.FromContext(ctx).Warn("cache delete failed", zap.Error(err))
+	}
+}
+
+// escapeGlob escapes the characters Redis treats as glob syntax
+func escapeGlob(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// invalidateListCache drops every cached list page and facet count, since a
+// product write can change what any of them holds
+func (s *CatalogService) invalidateListCache(ctx context.Context) {
+	deleted, err := s.cache.DeleteByPattern(ctx, s.cache.Key(ListCachePrefix+"*"))
+	if err != nil {
+		logger.FromContext(ctx).Warn("list cache invalidation failed", zap.Error(err))
+		return
+	}
+	logger.FromContext(ctx).Debug("invalidated list cache", zap.Int64("keys", deleted))
 }
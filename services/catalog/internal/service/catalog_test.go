@@ -0,0 +1,164 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/cache"
+	"github.com/mumumio1/coldy/pkg/redistest"
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+	"github.com/mumumio1/coldy/services/catalog/internal/repository/memory"
+	"github.com/mumumio1/coldy/services/catalog/internal/service"
+	"go.uber.org/zap"
+)
+
+// fakeInventory is an in-memory inventory service. Products missing from
+// levels are unknown to it.
+type fakeInventory struct {
+	mu      sync.Mutex
+	levels  map[string]service.StockLevel
+	err     error
+	lookups int
+}
+
+func newFakeInventory() *fakeInventory {
+	return &fakeInventory{levels: make(map[string]service.StockLevel)}
+}
+
+func (f *fakeInventory) set(productID string, available, reserved int32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.levels[productID] = service.StockLevel{
+		Available: available,
+		Reserved:  reserved,
+		Total:     available + reserved,
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (f *fakeInventory) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeInventory) lookupCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lookups
+}
+
+func (f *fakeInventory) ReserveStock(ctx context.Context, reservationID string, items map[string]int32, ttlSeconds int32) ([]service.UnavailableItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	var unavailable []service.UnavailableItem
+	for productID, quantity := range items {
+		if level := f.levels[productID]; level.Available < quantity {
+			unavailable = append(unavailable, service.UnavailableItem{ProductID: productID, Requested: quantity, Available: level.Available})
+		}
+	}
+	if len(unavailable) > 0 {
+		return unavailable, nil
+	}
+	for productID, quantity := range items {
+		level := f.levels[productID]
+		level.Available -= quantity
+		level.Reserved += quantity
+		f.levels[productID] = level
+	}
+	return nil, nil
+}
+
+func (f *fakeInventory) InventoryTotal(ctx context.Context, productID string) (int32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.levels[productID].Total, f.err
+}
+
+func (f *fakeInventory) AdjustInventory(ctx context.Context, productID string, delta int32, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	level := f.levels[productID]
+	level.Available += delta
+	level.Total += delta
+	f.levels[productID] = level
+	return f.err
+}
+
+func (f *fakeInventory) AvailableQuantities(ctx context.Context, productIDs []string) (map[string]int32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	available := make(map[string]int32, len(productIDs))
+	for _, productID := range productIDs {
+		if level, ok := f.levels[productID]; ok {
+			available[productID] = level.Available
+		}
+	}
+	return available, nil
+}
+
+func (f *fakeInventory) StockLevel(ctx context.Context, productID string) (service.StockLevel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lookups++
+	if f.err != nil {
+		return service.StockLevel{}, f.err
+	}
+	return f.levels[productID], nil
+}
+
+var errInventoryDown = errors.New("inventory unavailable")
+
+// catalogFixture is a CatalogService over an in-memory store, inventory and Redis
+type catalogFixture struct {
+	svc       *service.CatalogService
+	store     *memory.ProductStore
+	inventory *fakeInventory
+	redis     *redistest.Server
+	cache     *cache.RedisCache
+}
+
+func newCatalogFixture(t *testing.T) *catalogFixture {
+	t.Helper()
+
+	server := redistest.NewServer(t)
+	redisCache, err := cache.NewRedisCache(context.Background(), cache.Config{Addr: server.Addr(), Version: service.CacheVersion}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewRedisCache failed: %v", err)
+	}
+	t.Cleanup(func() { _ = redisCache.Close() })
+
+	store := memory.NewProductStore()
+	inventory := newFakeInventory()
+	svc := service.NewCatalogService(store, redisCache, service.CacheConfig{}, inventory, service.ReconcileConfig{}, zap.NewNop())
+	return &catalogFixture{svc: svc, store: store, inventory: inventory, redis: server, cache: redisCache}
+}
+
+// createProduct adds a product with stock through the service
+func (f *catalogFixture) createProduct(t *testing.T, ctx context.Context, sku string, stock int32) *repository.Product {
+	t.Helper()
+
+	product := &repository.Product{
+		Name:          "Product " + sku,
+		SKU:           sku,
+		PriceCurrency: "USD",
+		PriceAmount:   1000,
+		StockQuantity: stock,
+		Category:      "kitchen",
+	}
+	if err := f.svc.CreateProduct(ctx, product); err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+	return product
+}
@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+	"github.com/mumumio1/coldy/services/catalog/internal/service"
+)
+
+// A product is cached once per tenant scope that read it and once unscoped,
+// so every write must invalidate all of those keys whichever scope made it
+func TestWritesInvalidateEveryProductKey(t *testing.T) {
+	tenantCtx := middleware.WithTenant(context.Background(), "acme")
+	unscopedCtx := context.Background()
+
+	writes := map[string]struct {
+		write func(t *testing.T, f *catalogFixture, ctx context.Context, productID string)
+		check func(t *testing.T, product *repository.Product, err error)
+	}{
+		"update": {
+			write: func(t *testing.T, f *catalogFixture, ctx context.Context, productID string) {
+				if _, err := f.svc.UpdateProduct(ctx, productID, service.ProductUpdate{Name: "Renamed"}, []string{"name"}); err != nil {
+					t.Fatalf("UpdateProduct failed: %v", err)
+				}
+			},
+			check: func(t *testing.T, product *repository.Product, err error) {
+				if err != nil {
+					t.Fatalf("GetProduct failed: %v", err)
+				}
+				if product.Name != "Renamed" {
+					t.Errorf("Name = %q, want the updated name", product.Name)
+				}
+			},
+		},
+		"delete": {
+			write: func(t *testing.T, f *catalogFixture, ctx context.Context, productID string) {
+				if err := f.svc.DeleteProduct(ctx, productID); err != nil {
+					t.Fatalf("DeleteProduct failed: %v", err)
+				}
+			},
+			check: func(t *testing.T, _ *repository.Product, err error) {
+				if !errors.Is(err, service.ErrProductNotFound) {
+					t.Errorf("expected ErrProductNotFound, got %v", err)
+				}
+			},
+		},
+		"stock": {
+			write: func(t *testing.T, f *catalogFixture, ctx context.Context, productID string) {
+				if _, err := f.svc.UpdateStock(ctx, productID, 5); err != nil {
+					t.Fatalf("UpdateStock failed: %v", err)
+				}
+			},
+			check: func(t *testing.T, product *repository.Product, err error) {
+				if err != nil {
+					t.Fatalf("GetProduct failed: %v", err)
+				}
+				if product.StockQuantity != 15 {
+					t.Errorf("StockQuantity = %d, want 15", product.StockQuantity)
+				}
+			},
+		},
+	}
+	scopes := map[string]context.Context{"tenant": tenantCtx, "unscoped": unscopedCtx}
+
+	for name, tt := range writes {
+		for scope, writeCtx := range scopes {
+			t.Run(name+" by "+scope, func(t *testing.T) {
+				f := newCatalogFixture(t)
+				product := f.createProduct(t, tenantCtx, "MUG-1", 10)
+
+				// Cache the product under both key forms
+				for readScope, ctx := range scopes {
+					if _, err := f.svc.GetProduct(ctx, product.ID); err != nil {
+						t.Fatalf("GetProduct (%s) failed: %v", readScope, err)
+					}
+				}
+
+				tt.write(t, f, writeCtx, product.ID)
+
+				for readScope, ctx := range scopes {
+					t.Run("read "+readScope, func(t *testing.T) {
+						got, err := f.svc.GetProduct(ctx, product.ID)
+						tt.check(t, got, err)
+					})
+				}
+			})
+		}
+	}
+}
+
+func TestUpdateStockInvalidatesListCache(t *testing.T) {
+	f := newCatalogFixture(t)
+	ctx := context.Background()
+	product := f.createProduct(t, ctx, "MUG-1", 10)
+
+	list := func() *repository.Product {
+		t.Helper()
+		products, _, _, err := f.svc.ListProducts(ctx, 10, "", "", repository.Search{})
+		if err != nil {
+			t.Fatalf("ListProducts failed: %v", err)
+		}
+		if len(products) != 1 {
+			t.Fatalf("listed %d products, want 1", len(products))
+		}
+		return products[0]
+	}
+
+	// Cache the first page
+	list()
+
+	if _, err := f.svc.UpdateStock(ctx, product.ID, -4); err != nil {
+		t.Fatalf("UpdateStock failed: %v", err)
+	}
+	if got := list().StockQuantity; got != 6 {
+		t.Errorf("listed StockQuantity = %d, want 6", got)
+	}
+}
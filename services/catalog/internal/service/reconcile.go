@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+	"go.uber.org/zap"
+)
+
+// EventReconciliationMismatch is emitted when catalog stock and the inventory
+// total drift apart by more than ReconcileConfig.Threshold
+const EventReconciliationMismatch = "inventory.reconciliation_mismatch"
+
+// EventTypes lists the event types the catalog outbox carries, so the topics
+// they are published to can be verified at startup
+func EventTypes() []string {
+	return []string{
+		EventReconciliationMismatch,
+		repository.EventPriceChanged,
+	}
+}
+
+// ReconcileDirection selects which side a stock reconciliation corrects
+type ReconcileDirection int
+
+const (
+	// ReconcileReport only reports drift
+	ReconcileReport ReconcileDirection = iota
+	// ReconcileFromInventory sets catalog stock to the inventory total
+	ReconcileFromInventory
+	// ReconcileFromCatalog adjusts the inventory total to catalog stock
+	ReconcileFromCatalog
+)
+
+// String returns the direction name used in logs and events
+func (d ReconcileDirection) String() string {
+	switch d {
+	case ReconcileFromInventory:
+		return "from_inventory"
+	case ReconcileFromCatalog:
+		return "from_catalog"
+	default:
+		return "report"
+	}
+}
+
+// StockLedger reads and adjusts the inventory service's stock totals
+type StockLedger interface {
+	// InventoryTotal returns zero for products the inventory service doesn't know
+	InventoryTotal(ctx context.Context, productID string) (int32, error)
+	AdjustInventory(ctx context.Context, productID string, delta int32, reason string) error
+}
+
+// ReconcileConfig configures stock reconciliation
+type ReconcileConfig struct {
+	// Threshold is the absolute drift above which a mismatch event is emitted
+	Threshold int32
+}
+
+// StockDrift is the result of reconciling one product
+type StockDrift struct {
+	ProductID      string
+	CatalogStock   int32
+	InventoryTotal int32
+	// Drift is CatalogStock - InventoryTotal as observed before any correction
+	Drift     int32
+	Corrected bool
+}
+
+// ReconcileStock compares a product's catalog stock with its inventory total
+// and, unless direction is ReconcileReport, corrects the drift on one side.
+func (s *CatalogService) ReconcileStock(ctx context.Context, productID string, direction ReconcileDirection) (*StockDrift, error) {
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, ErrProductNotFound
+	}
+
+	total, err := s.inventory.InventoryTotal(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory total: %w", err)
+	}
+
+	drift := &StockDrift{
+		ProductID:      productID,
+		CatalogStock:   product.StockQuantity,
+		InventoryTotal: total,
+		Drift:          product.StockQuantity - total,
+	}
+	if drift.Drift == 0 {
+		return drift, nil
+	}
+
+	switch direction {
+	case ReconcileFromInventory:
+		if _, err := s.UpdateStock(ctx, productID, -drift.Drift); err != nil {
+			return nil, fmt.Errorf("failed to correct catalog stock: %w", err)
+		}
+		drift.Corrected = true
+	case ReconcileFromCatalog:
+		if err := s.inventory.AdjustInventory(ctx, productID, drift.Drift, "reconciliation"); err != nil {
+			return nil, fmt.Errorf("failed to correct inventory: %w", err)
+		}
+		drift.Corrected = true
+	}
+
+	logger.FromContext(ctx).Warn("stock drift detected",
+		zap.String("product_id", productID),
+		zap.Int32("catalog_stock", drift.CatalogStock),
+		zap.Int32("inventory_total", drift.InventoryTotal),
+		zap.String("direction", direction.String()),
+		zap.Bool("corrected", drift.Corrected),
+	)
+
+	if abs32(drift.Drift) > s.reconcileConfig.Threshold {
+		s.emitMismatch(ctx, drift, direction)
+	}
+
+	return drift, nil
+}
+
+// ReconcileAllStock reconciles every live product and returns the ones that
+// had drifted. A failure on one product is logged and does not stop the run.
+func (s *CatalogService) ReconcileAllStock(ctx context.Context, direction ReconcileDirection) ([]*StockDrift, error) {
+	const pageSize = 100
+
+	var drifts []*StockDrift
+	cursor := ""
+	for {
+		products, nextCursor, err := s.repo.List(ctx, pageSize, cursor, "", repository.Search{})
+		if err != nil {
+			return drifts, fmt.Errorf("failed to list products: %w", err)
+		}
+
+		for _, product := range products {
+			drift, err := s.ReconcileStock(ctx, product.ID, direction)
+			if err != nil {
+				logger.FromContext(ctx).Error("stock reconciliation failed",
+					zap.String("product_id", product.ID),
+					zap.Error(err),
+				)
+				continue
+			}
+			if drift.Drift != 0 {
+				drifts = append(drifts, drift)
+			}
+		}
+
+		if nextCursor == "" {
+			return drifts, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func (s *CatalogService) emitMismatch(ctx context.Context, drift *StockDrift, direction ReconcileDirection) {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"product_id":      drift.ProductID,
+		"catalog_stock":   drift.CatalogStock,
+		"inventory_total": drift.InventoryTotal,
+		"drift":           drift.Drift,
+		"direction":       direction.String(),
+		"corrected":       drift.Corrected,
+	})
+
+	if err := s.repo.InsertOutboxEvent(ctx, drift.ProductID, EventReconciliationMismatch, payload); err != nil {
+		logger.FromContext(ctx).Error("failed to record reconciliation mismatch", zap.Error(err))
+	}
+}
+
+func abs32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+)
+
+func TestEventTypesCoverCatalogOutbox(t *testing.T) {
+	// Every event written to catalog_outbox needs its topic checked at startup
+	for _, eventType := range []string{EventReconciliationMismatch, repository.EventPriceChanged} {
+		if !slices.Contains(EventTypes(), eventType) {
+			t.Errorf("EventTypes is missing %s", eventType)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+	"go.uber.org/zap"
+)
+
+// stockLevelTimeout bounds the inventory lookup of GetProductWithStock so a
+// slow inventory service degrades the response instead of delaying it
+const stockLevelTimeout = time.Second
+
+// StockLevel is a product's stock as held by the inventory service
+type StockLevel struct {
+	Available int32     `json:"available"`
+	Reserved  int32     `json:"reserved"`
+	Total     int32     `json:"total"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StockLevels reports live stock levels
+type StockLevels interface {
+	// StockLevel returns zero quantities for products the inventory service
+	// doesn't know
+	StockLevel(ctx context.Context, productID string) (StockLevel, error)
+}
+
+// ProductWithStock is a product with its live stock level
+type ProductWithStock struct {
+	Product *repository.Product
+	// Stock is nil when StockStale is set
+	Stock *StockLevel
+	// StockStale is set when the inventory service could not be reached;
+	// only the catalog's stock_quantity, which ignores reservations, is known
+	StockStale bool
+}
+
+// GetProductWithStock retrieves a product along with its live stock level,
+// looked up concurrently and cached for StockTTL. The product is still
+// returned, marked stale, when the inventory service is unavailable.
+func (s *CatalogService) GetProductWithStock(ctx context.Context, productID string) (*ProductWithStock, error) {
+	var stock StockLevel
+	var stockErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stock, stockErr = s.stockLevel(ctx, productID)
+	}()
+
+	product, err := s.GetProduct(ctx, productID)
+	<-done
+	if err != nil {
+		return nil, err
+	}
+
+	if stockErr != nil {
+		logger.FromContext(ctx).Warn("live stock unavailable",
+			zap.String("product_id", productID),
+			zap.Error(stockErr),
+		)
+		return &ProductWithStock{Product: product, StockStale: true}, nil
+	}
+	return &ProductWithStock{Product: product, Stock: &stock}, nil
+}
+
+func (s *CatalogService) stockLevel(ctx context.Context, productID string) (StockLevel, error) {
+	return s.stockLevels.GetOrLoad(ctx, tenantKey(ctx, productID), s.cacheConfig.ttl(s.cacheConfig.StockTTL), func(loadCtx context.Context) (StockLevel, error) {
+		loadCtx, cancel := context.WithTimeout(loadCtx, stockLevelTimeout)
+		defer cancel()
+
+		stock, err := s.inventory.StockLevel(loadCtx, productID)
+		if err != nil {
+			return StockLevel{}, fmt.Errorf("failed to get stock level: %w", err)
+		}
+		return stock, nil
+	})
+}
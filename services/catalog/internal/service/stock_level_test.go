@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mumumio1/coldy/services/catalog/internal/service"
+)
+
+func TestGetProductWithStock(t *testing.T) {
+	ctx := context.Background()
+	f := newCatalogFixture(t)
+	mug := f.createProduct(t, ctx, "MUG-1", 10)
+	f.inventory.set(mug.ID, 7, 3)
+
+	result, err := f.svc.GetProductWithStock(ctx, mug.ID)
+	if err != nil {
+		t.Fatalf("GetProductWithStock failed: %v", err)
+	}
+	if result.Product.ID != mug.ID || result.StockStale {
+		t.Fatalf("got %+v, want the product with live stock", result)
+	}
+	if s := result.Stock; s.Available != 7 || s.Reserved != 3 || s.Total != 10 {
+		t.Errorf("stock = %+v, want 7 available, 3 reserved, 10 total", s)
+	}
+
+	// The stock level is cached for StockTTL
+	f.inventory.set(mug.ID, 1, 9)
+	result, err = f.svc.GetProductWithStock(ctx, mug.ID)
+	if err != nil {
+		t.Fatalf("GetProductWithStock failed: %v", err)
+	}
+	if result.Stock.Available != 7 || f.inventory.lookupCount() != 1 {
+		t.Errorf("stock = %+v after %d lookups, want the cached level from one lookup", result.Stock, f.inventory.lookupCount())
+	}
+}
+
+func TestGetProductWithStockDegradesWithoutInventory(t *testing.T) {
+	ctx := context.Background()
+	f := newCatalogFixture(t)
+	mug := f.createProduct(t, ctx, "MUG-1", 10)
+	f.inventory.fail(errInventoryDown)
+
+	result, err := f.svc.GetProductWithStock(ctx, mug.ID)
+	if err != nil {
+		t.Fatalf("GetProductWithStock failed: %v", err)
+	}
+	if !result.StockStale || result.Stock != nil || result.Product.StockQuantity != 10 {
+		t.Errorf("got %+v, want the product marked stale without stock", result)
+	}
+
+	// A failed lookup is not cached; the next request sees inventory again
+	f.inventory.fail(nil)
+	f.inventory.set(mug.ID, 4, 0)
+	result, err = f.svc.GetProductWithStock(ctx, mug.ID)
+	if err != nil {
+		t.Fatalf("GetProductWithStock failed: %v", err)
+	}
+	if result.StockStale || result.Stock == nil || result.Stock.Available != 4 {
+		t.Errorf("got %+v, want live stock once inventory recovers", result)
+	}
+}
+
+func TestGetProductWithStockNotFound(t *testing.T) {
+	f := newCatalogFixture(t)
+
+	if _, err := f.svc.GetProductWithStock(context.Background(), "missing"); !errors.Is(err, service.ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+)
+
+// ProductStore is the product persistence CatalogService depends on. The SQL
+// repository implements it; memory.ProductStore is an in-memory stand-in.
+type ProductStore interface {
+	Create(ctx context.Context, product *repository.Product) error
+	GetByID(ctx context.Context, id string) (*repository.Product, error)
+	GetBySKU(ctx context.Context, sku string) (*repository.Product, error)
+	GetByIDs(ctx context.Context, ids []string) (map[string]*repository.Product, error)
+	Update(ctx context.Context, id string, apply func(*repository.Product) error) (*repository.Product, error)
+	Delete(ctx context.Context, id string) (bool, error)
+	UpdateStock(ctx context.Context, productID string, delta int32) (int32, error)
+	List(ctx context.Context, limit int, cursor, category string, search repository.Search) ([]*repository.Product, string, error)
+	CategoryCounts(ctx context.Context, search repository.Search) (map[string]int64, error)
+	GetPriceHistory(ctx context.Context, productID string, limit int, cursor string) ([]*repository.PriceChange, string, error)
+	InsertOutboxEvent(ctx context.Context, productID, eventType string, payload []byte) error
+	CheckAvailability(ctx context.Context, items map[string]int32) (map[string]int32, error)
+}
+
+var _ ProductStore = (*repository.ProductRepository)(nil)
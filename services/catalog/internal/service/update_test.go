@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mumumio1/coldy/pkg/money"
+	"github.com/mumumio1/coldy/services/catalog/internal/repository"
+)
+
+func newStoredProduct() repository.Product {
+	return repository.Product{
+		ID:            "product-1",
+		Name:          "Mug",
+		Description:   "Stored description",
+		SKU:           "MUG-1",
+		PriceCurrency: "USD",
+		PriceAmount:   1250,
+		Category:      "kitchen",
+	}
+}
+
+func TestApplyProductMaskLeavesOtherFields(t *testing.T) {
+	product := newStoredProduct()
+	update := ProductUpdate{
+		Name:        "Large mug",
+		Description: "Stale description",
+		Price:       &money.Money{Currency: "USD", Amount: 1},
+		Category:    "stale",
+	}
+
+	if err := applyProductMask(&product, update, []string{"name"}); err != nil {
+		t.Fatalf("applyProductMask failed: %v", err)
+	}
+
+	want := newStoredProduct()
+	want.Name = "Large mug"
+	if product.Name != want.Name || product.Description != want.Description ||
+		product.PriceAmount != want.PriceAmount || product.Category != want.Category {
+		t.Errorf("got %+v, want only the name changed: %+v", product, want)
+	}
+}
+
+func TestApplyProductMaskPrice(t *testing.T) {
+	product := newStoredProduct()
+	update := ProductUpdate{Price: &money.Money{Currency: "EUR", Amount: 990}}
+
+	if err := applyProductMask(&product, update, []string{"price"}); err != nil {
+		t.Fatalf("applyProductMask failed: %v", err)
+	}
+	if product.PriceCurrency != "EUR" || product.PriceAmount != 990 {
+		t.Errorf("price = %d %s, want 990 EUR", product.PriceAmount, product.PriceCurrency)
+	}
+}
+
+func TestApplyProductMaskRejectsInvalidPaths(t *testing.T) {
+	tests := map[string]struct {
+		update ProductUpdate
+		mask   []string
+	}{
+		"unknown field": {ProductUpdate{}, []string{"sku"}},
+		"cleared name":  {ProductUpdate{}, []string{"name"}},
+		"cleared price": {ProductUpdate{}, []string{"price"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			product := newStoredProduct()
+			if err := applyProductMask(&product, tt.update, tt.mask); !errors.Is(err, ErrInvalidUpdateMask) {
+				t.Errorf("expected ErrInvalidUpdateMask, got %v", err)
+			}
+		})
+	}
+}
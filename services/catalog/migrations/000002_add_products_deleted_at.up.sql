@@ -0,0 +1,3 @@
+-- Soft delete: deleted products stay referenced by existing orders but are
+-- hidden from reads and reported unavailable
+ALTER TABLE products ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,13 @@
+-- Outbox for catalog events such as inventory.reconciliation_mismatch
+CREATE TABLE IF NOT EXISTS catalog_outbox (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    aggregate_type VARCHAR(100) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    published BOOLEAN DEFAULT false,
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_catalog_outbox_published ON catalog_outbox(published, created_at) WHERE NOT published;
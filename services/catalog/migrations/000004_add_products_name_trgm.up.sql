@@ -0,0 +1,11 @@
+-- Fuzzy product search uses pg_trgm when it can be installed; without it the
+-- catalog falls back to prefix matching, so a missing privilege is not fatal
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS pg_trgm;
+    EXECUTE 'CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (name gin_trgm_ops)';
+EXCEPTION
+    WHEN insufficient_privilege OR undefined_file THEN
+        RAISE NOTICE 'pg_trgm unavailable, fuzzy search will use prefix matching';
+END
+$$;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_products_tenant_created_at;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_sku_key;
+ALTER TABLE products ADD CONSTRAINT products_sku_key UNIQUE (sku);
+
+ALTER TABLE products DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,8 @@
+-- Products belong to a tenant; '' is the single-tenant default
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT '';
+
+-- SKUs only need to be unique within a tenant
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_sku_key;
+ALTER TABLE products ADD CONSTRAINT products_sku_key UNIQUE (tenant_id, sku);
+
+CREATE INDEX IF NOT EXISTS idx_products_tenant_created_at ON products(tenant_id, created_at DESC, id DESC);
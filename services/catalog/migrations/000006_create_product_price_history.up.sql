@@ -0,0 +1,12 @@
+-- One row per product price change, written with the change itself
+CREATE TABLE IF NOT EXISTS product_price_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    old_currency VARCHAR(3) NOT NULL,
+    old_amount BIGINT NOT NULL,
+    new_currency VARCHAR(3) NOT NULL,
+    new_amount BIGINT NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_price_history_product ON product_price_history(product_id, changed_at DESC, id DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE catalog_outbox DROP COLUMN IF EXISTS dead_lettered_at;
+ALTER TABLE catalog_outbox DROP COLUMN IF EXISTS last_error;
+ALTER TABLE catalog_outbox DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,6 @@
+-- Failed publish attempts of an outbox event and the last error, for spotting
+-- events the publisher keeps retrying
+ALTER TABLE catalog_outbox ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE catalog_outbox ADD COLUMN IF NOT EXISTS last_error TEXT;
+-- Events that used up their publish attempts; the publisher skips them
+ALTER TABLE catalog_outbox ADD COLUMN IF NOT EXISTS dead_lettered_at TIMESTAMP WITH TIME ZONE;
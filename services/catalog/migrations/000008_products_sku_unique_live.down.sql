@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_products_tenant_sku_live;
+
+-- Fails if a SKU was reused after its product was deleted
+ALTER TABLE products ADD CONSTRAINT products_sku_key UNIQUE (tenant_id, sku);
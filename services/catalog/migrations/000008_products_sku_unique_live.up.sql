@@ -0,0 +1,3 @@
+-- A deleted product's SKU can be reused: uniqueness only covers live products
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_sku_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_tenant_sku_live ON products(tenant_id, sku) WHERE deleted_at IS NULL;
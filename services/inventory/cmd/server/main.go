@@ -6,16 +6,22 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
+	"github.com/mumumio1/coldy/pkg/config"
 	"github.com/mumumio1/coldy/pkg/database"
+	grpcserverpkg "github.com/mumumio1/coldy/pkg/grpcserver"
 	"github.com/mumumio1/coldy/pkg/logger"
 	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/pagination"
+	"github.com/mumumio1/coldy/pkg/shutdown"
 	"github.com/mumumio1/coldy/pkg/telemetry"
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+	grpcserver "github.com/mumumio1/coldy/services/inventory/internal/grpc"
 	"github.com/mumumio1/coldy/services/inventory/internal/service"
+	"github.com/mumumio1/coldy/services/inventory/internal/watch"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -39,15 +45,18 @@ func run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	log, err := logger.NewLogger(serviceName, getEnv("ENV", "development"))
+	log, err := logger.NewLogger(serviceName, config.Getenv("ENV", "development"))
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	defer func() { _ = log.Sync() }()
 
+	// Background work started from ctx logs with the service logger
+	ctx = logger.WithLogger(ctx, log)
+
 	log.Info("starting inventory service", zap.String("version", version))
 
-	tracingEndpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	tracingEndpoint := config.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
 	shutdownTracer, err := telemetry.InitTracer(ctx, serviceName, version, tracingEndpoint)
 	if err != nil {
 		log.Warn("failed to initialize tracer", zap.Error(err))
@@ -58,16 +67,17 @@ func run() error {
 	metrics := telemetry.NewMetrics("coldy", serviceName)
 
 	dbConfig := database.Config{
-		Host:            getEnv("DB_HOST", "localhost"),
+		Host:            config.Getenv("DB_HOST", "localhost"),
 		Port:            5432,
-		User:            getEnv("DB_USER", "coldy"),
-		Password:        getEnv("DB_PASSWORD", "coldy123"),
-		Database:        getEnv("DB_NAME", "coldy"),
-		SSLMode:         getEnv("DB_SSLMODE", "disable"),
+		User:            config.Getenv("DB_USER", "coldy"),
+		Password:        config.Getenv("DB_PASSWORD", "coldy123"),
+		Database:        config.Getenv("DB_NAME", "coldy"),
+		SSLMode:         config.Getenv("DB_SSLMODE", "disable"),
 		MaxOpenConns:    25,
 		MaxIdleConns:    5,
 		ConnMaxLifetime: 5 * time.Minute,
 		ConnMaxIdleTime: 5 * time.Minute,
+		QueryComments:   config.Getenv("DB_QUERY_COMMENTS", "false") == "true",
 	}
 
 	db, err := database.NewPostgresDB(ctx, dbConfig, log)
@@ -76,7 +86,28 @@ func run() error {
 	}
 	defer func() { _ = db.Close() }()
 
-	inventoryService := service.NewInventoryService(db, log)
+	// Inventory changes fan out to WatchInventory streams on every replica
+	// through Redis pub/sub
+	var watches *watch.Hub
+	var changes service.ChangePublisher
+	if config.Getenv("INVENTORY_WATCH_ENABLED", "true") == "true" {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     config.Getenv("REDIS_ADDR", "localhost:6379"),
+			Password: config.Getenv("REDIS_PASSWORD", ""),
+			DB:       config.GetenvInt("REDIS_DB", 0),
+		})
+		defer func() { _ = redisClient.Close() }()
+
+		watches = watch.NewHub(redisClient, config.Getenv("INVENTORY_WATCH_CHANNEL", watch.DefaultChannel))
+		changes = watches
+		go func() {
+			if err := watches.Run(ctx); err != nil && ctx.Err() == nil {
+				log.Error("inventory watch stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	inventoryService := service.NewInventoryService(db, changes, log)
 
 	// Start cleanup worker for expired reservations
 	go func() {
@@ -88,36 +119,76 @@ func run() error {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if err := inventoryService.CleanupExpiredReservations(ctx); err != nil {
+				if _, err := inventoryService.CleanupExpiredReservations(ctx); err != nil {
 					log.Error("failed to cleanup expired reservations", zap.Error(err))
 				}
 			}
 		}
 	}()
 
-	grpcPort := getEnv("GRPC_PORT", "50055")
+	grpcPort := config.Getenv("GRPC_PORT", "50055")
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	grpcServer := grpc.NewServer(
+	// Plaintext gRPC is only allowed with GRPC_INSECURE=true
+	transport, err := grpcserverpkg.LoadTransportConfig()
+	if err != nil {
+		return err
+	}
+	serverCreds, err := transport.ServerCredentials()
+	if err != nil {
+		return err
+	}
+
+	keepalive, err := grpcserverpkg.LoadKeepaliveConfig()
+	if err != nil {
+		return err
+	}
+
+	// Shed load past these limits instead of letting it queue on the database pool
+	concurrency, err := middleware.LoadConcurrencyConfig()
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpcserverpkg.New(keepalive,
+		serverCreds,
+		grpcserverpkg.MaxConcurrentStreams(config.GetenvInt("GRPC_MAX_CONCURRENT_STREAMS", grpcserverpkg.DefaultMaxConcurrentStreams)),
 		grpc.ChainUnaryInterceptor(
+			middleware.MetricsInterceptor(),
 			middleware.RecoveryInterceptor(log),
 			middleware.UnaryServerInterceptor(log),
+			middleware.CompressionInterceptor(config.GetenvInt("GRPC_COMPRESS_MIN_SIZE", middleware.DefaultCompressMinSize)),
+			middleware.ConcurrencyLimitInterceptor(concurrency),
 			middleware.TracingInterceptor(serviceName),
+			middleware.ValidationInterceptor(grpcserver.Validators()),
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.StreamServerInterceptor(log),
 		),
 	)
 
+	pages, err := pagination.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := pages.MethodMaxSize[inventoryv1.InventoryService_GetInventoryHistory_FullMethodName]; !ok {
+		pages.MethodMaxSize[inventoryv1.InventoryService_GetInventoryHistory_FullMethodName] = grpcserver.HistoryMaxPageSize
+	}
+
+	inventoryv1.RegisterInventoryServiceServer(grpcServer, grpcserver.NewServer(inventoryService, watches, pages, log))
+
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_SERVING)
 
-	if getEnv("ENV", "development") == "development" {
+	if config.Getenv("ENV", "development") == "development" {
 		reflection.Register(grpcServer)
 	}
 
-	metricsPort := getEnv("METRICS_PORT", "9094")
+	metricsPort := config.Getenv("METRICS_PORT", "9094")
 	go func() {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
@@ -154,22 +225,24 @@ func run() error {
 		}
 	}()
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
-
-	log.Info("shutting down gracefully...")
-	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
-	time.Sleep(5 * time.Second)
-	grpcServer.GracefulStop()
+	// Fail health checks, let load balancers drain, then stop the server
+	shutdowner := shutdown.New(shutdown.Config{
+		DrainDelay: config.GetenvDuration("SHUTDOWN_DRAIN_DELAY", shutdown.DefaultDrainDelay),
+		Timeout:    config.GetenvDuration("SHUTDOWN_TIMEOUT", shutdown.DefaultTimeout),
+	}, log)
+	shutdowner.PreStop("health", func(ctx context.Context) error {
+		healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		return nil
+	})
+	shutdowner.OnShutdown("grpc", func(ctx context.Context) error {
+		// Stop forces remaining RPCs closed after its own timeout
+		grpcserverpkg.Stop(grpcServer, config.GetenvDuration("GRPC_SHUTDOWN_TIMEOUT", grpcserverpkg.DefaultShutdownTimeout), log)
+		return nil
+	})
+	if err := shutdowner.Wait(ctx); err != nil {
+		log.Warn("shutdown did not complete cleanly", zap.Error(err))
+	}
 
 	log.Info("server stopped")
 	return nil
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
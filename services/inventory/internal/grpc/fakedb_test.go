@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeInventory is a row of the inventory table
+type fakeInventory struct {
+	available, reserved, total, version int64
+	updatedAt                           time.Time
+}
+
+// fakeDB is an in-memory inventory table answering the lookups and
+// adjustments InventoryService issues through database/sql. Transactions
+// are accepted but not isolated.
+type fakeDB struct {
+	mu        sync.Mutex
+	inventory map[string]fakeInventory
+}
+
+var (
+	registerFakeDriver sync.Once
+	fakeDBs            sync.Map
+)
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	registerFakeDriver.Do(func() { sql.Register("inventory-grpc-fake", fakeDriver{}) })
+
+	fake := &fakeDB{inventory: make(map[string]fakeInventory)}
+	fakeDBs.Store(t.Name(), fake)
+
+	db, err := sql.Open("inventory-grpc-fake", t.Name())
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		fakeDBs.Delete(t.Name())
+	})
+	return db, fake
+}
+
+func (f *fakeDB) addInventory(productID string, available int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inventory[productID] = fakeInventory{available: available, total: available, version: 1, updatedAt: time.Now()}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fake, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("no fake database %q", name)
+	}
+	return &fakeConn{db: fake.(*fakeDB)}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error { return nil }
+
+func (fakeTx) Rollback() error { return nil }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if strings.Contains(query, "INSERT INTO inventory_ledger") {
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement: %s", query)
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	productID := args[0].Value.(string)
+	switch {
+	case strings.Contains(query, "INSERT INTO inventory (product_id"):
+		delta := args[1].Value.(int64)
+		inventory := f.inventory[productID]
+		inventory.available += delta
+		inventory.total += delta
+		inventory.version++
+		inventory.updatedAt = time.Now()
+		f.inventory[productID] = inventory
+		return inventoryRows(productID, inventory), nil
+
+	case strings.Contains(query, "FROM inventory\n") && strings.Contains(query, "WHERE product_id = $1"):
+		inventory, ok := f.inventory[productID]
+		if !ok {
+			return &fakeRows{}, nil
+		}
+		return inventoryRows(productID, inventory), nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+func inventoryRows(productID string, inventory fakeInventory) *fakeRows {
+	return &fakeRows{values: [][]driver.Value{{
+		productID, inventory.available, inventory.reserved, inventory.total, inventory.version, inventory.updatedAt,
+	}}}
+}
+
+type fakeRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"product_id", "available_quantity", "reserved_quantity", "total_quantity", "version", "updated_at"}
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -0,0 +1,303 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/pagination"
+	commonv1 "github.com/mumumio1/coldy/proto/common/v1"
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+	"github.com/mumumio1/coldy/services/inventory/internal/service"
+	"github.com/mumumio1/coldy/services/inventory/internal/watch"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetInventoryHistory pages are larger than other list pages; the maximum
+// can still be overridden per method
+const (
+	HistoryPageSize    = 50
+	HistoryMaxPageSize = 500
+)
+
+// Server implements the Inventory gRPC service
+type Server struct {
+	inventoryv1.UnimplementedInventoryServiceServer
+	inventoryService *service.InventoryService
+	watches          *watch.Hub
+	pages            pagination.Config
+	logger           *zap.Logger
+}
+
+// NewServer creates a new gRPC server. watches serves WatchInventory; nil
+// leaves it unavailable.
+func NewServer(inventoryService *service.InventoryService, watches *watch.Hub, pages pagination.Config, logger *zap.Logger) *Server {
+	return &Server{
+		inventoryService: inventoryService,
+		watches:          watches,
+		pages:            pages,
+		logger:           logger,
+	}
+}
+
+// ReserveStock reserves stock for all items or none. Shortfalls are reported
+// in the response rather than as an error.
+func (s *Server) ReserveStock(ctx context.Context, req *inventoryv1.ReserveStockRequest) (*inventoryv1.ReserveStockResponse, error) {
+	items := make([]service.ReservationItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = service.ReservationItem{
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	err := s.inventoryService.ReserveStock(ctx, req.ReservationId, items, req.TtlSeconds)
+
+	var stockErr *service.InsufficientStockError
+	if errors.As(err, &stockErr) {
+		failures := make([]*inventoryv1.ReservationFailure, len(stockErr.Failures))
+		for i, f := range stockErr.Failures {
+			failures[i] = &inventoryv1.ReservationFailure{
+				ProductId: f.ProductID,
+				Reason:    f.Reason,
+				Available: f.Available,
+				Requested: f.Requested,
+			}
+		}
+		return &inventoryv1.ReserveStockResponse{
+			Success:       false,
+			ReservationId: req.ReservationId,
+			Failures:      failures,
+		}, nil
+	}
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to reserve stock")
+	}
+
+	return &inventoryv1.ReserveStockResponse{
+		Success:       true,
+		ReservationId: req.ReservationId,
+	}, nil
+}
+
+// ReleaseStock releases a reservation
+func (s *Server) ReleaseStock(ctx context.Context, req *inventoryv1.ReleaseStockRequest) (*inventoryv1.ReleaseStockResponse, error) {
+	if err := s.inventoryService.ReleaseStock(ctx, req.ReservationId); err != nil {
+		return nil, s.toStatus(ctx, err, "failed to release stock")
+	}
+
+	return &inventoryv1.ReleaseStockResponse{Success: true}, nil
+}
+
+// CommitStock commits a reservation
+func (s *Server) CommitStock(ctx context.Context, req *inventoryv1.CommitStockRequest) (*inventoryv1.CommitStockResponse, error) {
+	if err := s.inventoryService.CommitStock(ctx, req.ReservationId); err != nil {
+		return nil, s.toStatus(ctx, err, "failed to commit stock")
+	}
+
+	return &inventoryv1.CommitStockResponse{Success: true}, nil
+}
+
+// CommitStockPartial commits part of a reservation
+func (s *Server) CommitStockPartial(ctx context.Context, req *inventoryv1.CommitStockPartialRequest) (*inventoryv1.CommitStockPartialResponse, error) {
+	items := make([]service.ReservationItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = service.ReservationItem{
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	if err := s.inventoryService.CommitStockPartial(ctx, req.ReservationId, items); err != nil {
+		return nil, s.toStatus(ctx, err, "failed to commit stock")
+	}
+
+	return &inventoryv1.CommitStockPartialResponse{Success: true}, nil
+}
+
+// GetInventory retrieves inventory for a product
+func (s *Server) GetInventory(ctx context.Context, req *inventoryv1.GetInventoryRequest) (*inventoryv1.GetInventoryResponse, error) {
+	inventory, err := s.inventoryService.GetInventory(ctx, req.ProductId)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get inventory")
+	}
+
+	return &inventoryv1.GetInventoryResponse{
+		Inventory: toProtoInventory(inventory),
+	}, nil
+}
+
+// AdjustInventory adjusts inventory for restocking, damage, etc.
+func (s *Server) AdjustInventory(ctx context.Context, req *inventoryv1.AdjustInventoryRequest) (*inventoryv1.AdjustInventoryResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.QuantityDelta == 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity_delta must be non-zero")
+	}
+
+	inventory, err := s.inventoryService.AdjustInventory(ctx, req.ProductId, req.QuantityDelta, req.Reason)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to adjust inventory")
+	}
+
+	return &inventoryv1.AdjustInventoryResponse{
+		Inventory: toProtoInventory(inventory),
+	}, nil
+}
+
+// BulkAdjustInventory applies several inventory adjustments in one transaction
+func (s *Server) BulkAdjustInventory(ctx context.Context, req *inventoryv1.BulkAdjustInventoryRequest) (*inventoryv1.BulkAdjustInventoryResponse, error) {
+	adjustments := make([]service.Adjustment, len(req.Adjustments))
+	for i, adj := range req.Adjustments {
+		adjustments[i] = service.Adjustment{
+			ProductID: adj.ProductId,
+			Delta:     adj.QuantityDelta,
+		}
+	}
+
+	results, err := s.inventoryService.BulkAdjust(ctx, adjustments, req.Reason, req.Partial)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to adjust inventory")
+	}
+
+	protoResults := make([]*inventoryv1.AdjustmentResult, len(results))
+	for i, result := range results {
+		protoResults[i] = &inventoryv1.AdjustmentResult{ProductId: result.ProductID}
+		switch {
+		case result.Err == nil:
+			protoResults[i].Inventory = toProtoInventory(result.Inventory)
+		case errs.KindOf(result.Err) == errs.KindInternal:
+			logger.FromContext(ctx).Error("failed to adjust inventory",
+				zap.String("product_id", result.ProductID),
+				zap.Error(result.Err),
+			)
+			protoResults[i].Error = "failed to adjust inventory"
+		default:
+			protoResults[i].Error = result.Err.Error()
+		}
+	}
+
+	return &inventoryv1.BulkAdjustInventoryResponse{Results: protoResults}, nil
+}
+
+// GetReservation returns the items of a reservation
+func (s *Server) GetReservation(ctx context.Context, req *inventoryv1.GetReservationRequest) (*inventoryv1.GetReservationResponse, error) {
+	reservations, err := s.inventoryService.GetReservation(ctx, req.ReservationId)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get reservation")
+	}
+
+	return &inventoryv1.GetReservationResponse{
+		Items: toProtoReservations(reservations),
+	}, nil
+}
+
+// ListReservations lists a product's reservations
+func (s *Server) ListReservations(ctx context.Context, req *inventoryv1.ListReservationsRequest) (*inventoryv1.ListReservationsResponse, error) {
+	pageSize, err := s.pages.Clamp(inventoryv1.InventoryService_ListReservations_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cursor := req.GetPagination().GetCursor()
+
+	reservations, nextCursor, err := s.inventoryService.ListReservationsForProduct(ctx, req.ProductId, req.Status, pageSize, cursor)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to list reservations")
+	}
+
+	return &inventoryv1.ListReservationsResponse{
+		Reservations: toProtoReservations(reservations),
+		Pagination: &commonv1.PaginationResponse{
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
+		},
+	}, nil
+}
+
+// GetInventoryHistory returns a product's stock movements, oldest first
+func (s *Server) GetInventoryHistory(ctx context.Context, req *inventoryv1.GetInventoryHistoryRequest) (*inventoryv1.GetInventoryHistoryResponse, error) {
+	pageSize, err := pagination.Clamp(req.GetPagination().GetPageSize(), HistoryPageSize,
+		s.pages.Max(inventoryv1.InventoryService_GetInventoryHistory_FullMethodName))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cursor := req.GetPagination().GetCursor()
+
+	var from, to time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+
+	events, nextCursor, err := s.inventoryService.GetProductTimeline(ctx, req.ProductId, from, to, pageSize, cursor)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get inventory history")
+	}
+
+	protoEvents := make([]*inventoryv1.InventoryEvent, len(events))
+	for i, e := range events {
+		protoEvents[i] = &inventoryv1.InventoryEvent{
+			Kind:       e.Kind,
+			Quantity:   e.Quantity,
+			Reference:  e.Reference,
+			Reason:     e.Reason,
+			OccurredAt: timestamppb.New(e.OccurredAt),
+		}
+	}
+
+	return &inventoryv1.GetInventoryHistoryResponse{
+		Events: protoEvents,
+		Pagination: &commonv1.PaginationResponse{
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
+		},
+	}, nil
+}
+
+// toStatus maps domain errors to gRPC status codes
+func (s *Server) toStatus(ctx context.Context, err error, msg string) error {
+	if errs.KindOf(err) == errs.KindInternal {
+		logger.FromContext(ctx).Error(msg, zap.Error(err))
+		return status.Error(codes.Internal, msg)
+	}
+	return errs.ToGRPCStatus(err).Err()
+}
+
+func toProtoInventory(inventory *service.Inventory) *inventoryv1.Inventory {
+	return &inventoryv1.Inventory{
+		ProductId:         inventory.ProductID,
+		AvailableQuantity: inventory.AvailableQuantity,
+		ReservedQuantity:  inventory.ReservedQuantity,
+		TotalQuantity:     inventory.TotalQuantity,
+		Version:           inventory.Version,
+		UpdatedAt:         timestamppb.New(inventory.UpdatedAt),
+	}
+}
+
+func toProtoReservations(reservations []*service.Reservation) []*inventoryv1.Reservation {
+	protoReservations := make([]*inventoryv1.Reservation, len(reservations))
+	for i, r := range reservations {
+		protoReservations[i] = &inventoryv1.Reservation{
+			Id:                r.ID,
+			ReservationId:     r.ReservationID,
+			ProductId:         r.ProductID,
+			Quantity:          r.Quantity,
+			CommittedQuantity: r.CommittedQuantity,
+			Status:            r.Status,
+			CreatedAt:         timestamppb.New(r.CreatedAt),
+			UpdatedAt:         timestamppb.New(r.UpdatedAt),
+		}
+		if !r.ExpiresAt.IsZero() {
+			protoReservations[i].ExpiresAt = timestamppb.New(r.ExpiresAt)
+		}
+	}
+	return protoReservations
+}
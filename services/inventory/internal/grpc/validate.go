@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"errors"
+
+	"github.com/mumumio1/coldy/pkg/middleware"
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+)
+
+// Validators returns the request validators for middleware.ValidationInterceptor
+func Validators() map[string]middleware.ValidateFunc {
+	return map[string]middleware.ValidateFunc{
+		inventoryv1.InventoryService_ReserveStock_FullMethodName: func(req interface{}) error {
+			r := req.(*inventoryv1.ReserveStockRequest)
+			if err := requireReservationID(r.ReservationId); err != nil {
+				return err
+			}
+			return validateItems(r.Items)
+		},
+		inventoryv1.InventoryService_ReleaseStock_FullMethodName: func(req interface{}) error {
+			return requireReservationID(req.(*inventoryv1.ReleaseStockRequest).ReservationId)
+		},
+		inventoryv1.InventoryService_CommitStock_FullMethodName: func(req interface{}) error {
+			return requireReservationID(req.(*inventoryv1.CommitStockRequest).ReservationId)
+		},
+		inventoryv1.InventoryService_CommitStockPartial_FullMethodName: func(req interface{}) error {
+			r := req.(*inventoryv1.CommitStockPartialRequest)
+			if err := requireReservationID(r.ReservationId); err != nil {
+				return err
+			}
+			return validateItems(r.Items)
+		},
+		inventoryv1.InventoryService_GetInventory_FullMethodName: func(req interface{}) error {
+			return requireProductID(req.(*inventoryv1.GetInventoryRequest).ProductId)
+		},
+		inventoryv1.InventoryService_GetReservation_FullMethodName: func(req interface{}) error {
+			return requireReservationID(req.(*inventoryv1.GetReservationRequest).ReservationId)
+		},
+		inventoryv1.InventoryService_ListReservations_FullMethodName: func(req interface{}) error {
+			return requireProductID(req.(*inventoryv1.ListReservationsRequest).ProductId)
+		},
+		inventoryv1.InventoryService_GetInventoryHistory_FullMethodName: func(req interface{}) error {
+			r := req.(*inventoryv1.GetInventoryHistoryRequest)
+			if r.From != nil && r.To != nil && !r.From.AsTime().Before(r.To.AsTime()) {
+				return errors.New("from must be before to")
+			}
+			return requireProductID(r.ProductId)
+		},
+	}
+}
+
+func requireReservationID(id string) error {
+	if id == "" {
+		return errors.New("reservation_id is required")
+	}
+	return nil
+}
+
+func requireProductID(id string) error {
+	if id == "" {
+		return errors.New("product_id is required")
+	}
+	return nil
+}
+
+func validateItems(items []*inventoryv1.ReservationRequest) error {
+	if len(items) == 0 {
+		return errors.New("items are required")
+	}
+	for _, item := range items {
+		if item.ProductId == "" || item.Quantity <= 0 {
+			return errors.New("each item needs a product_id and a positive quantity")
+		}
+	}
+	return nil
+}
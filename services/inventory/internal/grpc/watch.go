@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+	"github.com/mumumio1/coldy/services/inventory/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxWatchProducts caps the products one WatchInventory stream may watch
+const MaxWatchProducts = 100
+
+// WatchInventory streams the inventory of the requested products as it
+// changes. Changes that arrive faster than the stream sends are merged, so
+// each update carries the product's latest inventory. The stream ends when
+// the client cancels it.
+func (s *Server) WatchInventory(req *inventoryv1.WatchInventoryRequest, stream grpc.ServerStreamingServer[inventoryv1.WatchInventoryResponse]) error {
+	productIDs, err := watchProductIDs(req.ProductIds)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if s.watches == nil {
+		return status.Error(codes.Unimplemented, "inventory watching is not enabled")
+	}
+
+	ctx := stream.Context()
+
+	// Watch before reading the current inventory so no change in between is missed
+	watcher := s.watches.Watch(productIDs)
+	defer watcher.Close()
+
+	if err := s.sendInventory(stream, productIDs); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-watcher.Ready():
+			if err := s.sendInventory(stream, watcher.Take()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendInventory sends the current inventory of productIDs. Products without
+// an inventory record are skipped.
+func (s *Server) sendInventory(stream grpc.ServerStreamingServer[inventoryv1.WatchInventoryResponse], productIDs []string) error {
+	ctx := stream.Context()
+	for _, productID := range productIDs {
+		inventory, err := s.inventoryService.GetInventory(ctx, productID)
+		if errors.Is(err, service.ErrInventoryNotFound) {
+			continue
+		}
+		if err != nil {
+			return s.toStatus(ctx, err, "failed to get inventory")
+		}
+		if err := stream.Send(&inventoryv1.WatchInventoryResponse{Inventory: toProtoInventory(inventory)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watchProductIDs validates and dedups the products of a watch request
+func watchProductIDs(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return nil, errors.New("product_ids are required")
+	}
+
+	seen := make(map[string]bool, len(requested))
+	productIDs := make([]string, 0, len(requested))
+	for _, id := range requested {
+		if id == "" {
+			return nil, errors.New("product_ids must not be empty")
+		}
+		if !seen[id] {
+			seen[id] = true
+			productIDs = append(productIDs, id)
+		}
+	}
+	if len(productIDs) > MaxWatchProducts {
+		return nil, fmt.Errorf("at most %d product_ids can be watched per stream", MaxWatchProducts)
+	}
+	return productIDs, nil
+}
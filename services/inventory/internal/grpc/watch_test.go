@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/pagination"
+	"github.com/mumumio1/coldy/pkg/redistest"
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+	"github.com/mumumio1/coldy/services/inventory/internal/service"
+	"github.com/mumumio1/coldy/services/inventory/internal/watch"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchStream collects the responses a WatchInventory stream sends
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *inventoryv1.WatchInventoryResponse
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(resp *inventoryv1.WatchInventoryResponse) error {
+	s.sent <- resp
+	return nil
+}
+
+// newWatchServer returns a server whose changes travel through Redis pub/sub
+// the way they do between replicas
+func newWatchServer(t *testing.T) (*Server, *fakeDB) {
+	t.Helper()
+
+	redisServer := redistest.NewServer(t)
+	hub := watch.NewHub(redisServer.NewClient(t), "")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = hub.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	// Changes published before the hub subscribes would be lost
+	deadline := time.Now().Add(time.Second)
+	for redisServer.Subscribers(watch.DefaultChannel) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("hub did not subscribe")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	db, fake := newFakeDB(t)
+	inventoryService := service.NewInventoryService(db, hub, zap.NewNop())
+	return NewServer(inventoryService, hub, pagination.Config{}, zap.NewNop()), fake
+}
+
+func receiveInventory(t *testing.T, stream *watchStream) *inventoryv1.Inventory {
+	t.Helper()
+	select {
+	case resp := <-stream.sent:
+		return resp.Inventory
+	case <-time.After(2 * time.Second):
+		t.Fatal("no inventory update received")
+		return nil
+	}
+}
+
+func TestWatchInventoryStreamsAdjustments(t *testing.T) {
+	s, fake := newWatchServer(t)
+	fake.addInventory("product-1", 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchStream{ctx: ctx, sent: make(chan *inventoryv1.WatchInventoryResponse, 10)}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WatchInventory(&inventoryv1.WatchInventoryRequest{ProductIds: []string{"product-1", "product-2"}}, stream)
+	}()
+
+	// The stream opens with the current inventory; product-2 has none yet
+	if got := receiveInventory(t, stream); got.ProductId != "product-1" || got.AvailableQuantity != 5 {
+		t.Fatalf("initial inventory = %v, want product-1 with 5 available", got)
+	}
+
+	adjust := func(productID string, delta int32) {
+		t.Helper()
+		_, err := s.AdjustInventory(context.Background(), &inventoryv1.AdjustInventoryRequest{ProductId: productID, QuantityDelta: delta, Reason: "restock"})
+		if err != nil {
+			t.Fatalf("AdjustInventory(%s) error = %v", productID, err)
+		}
+	}
+
+	// An unwatched product's change is not sent
+	adjust("product-3", 1)
+	adjust("product-1", 3)
+	if got := receiveInventory(t, stream); got.ProductId != "product-1" || got.AvailableQuantity != 8 {
+		t.Fatalf("update = %v, want product-1 with 8 available", got)
+	}
+
+	adjust("product-2", 4)
+	if got := receiveInventory(t, stream); got.ProductId != "product-2" || got.AvailableQuantity != 4 {
+		t.Fatalf("update = %v, want product-2 with 4 available", got)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if status.Code(err) != codes.Canceled {
+			t.Errorf("WatchInventory() error = %v, want Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not close after its context was cancelled")
+	}
+}
+
+func TestWatchInventoryRequiresHub(t *testing.T) {
+	db, _ := newFakeDB(t)
+	s := NewServer(service.NewInventoryService(db, nil, zap.NewNop()), nil, pagination.Config{}, zap.NewNop())
+
+	stream := &watchStream{ctx: context.Background(), sent: make(chan *inventoryv1.WatchInventoryResponse, 1)}
+	err := s.WatchInventory(&inventoryv1.WatchInventoryRequest{ProductIds: []string{"product-1"}}, stream)
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("WatchInventory() error = %v, want Unimplemented", err)
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mumumio1/coldy/pkg/database"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const checkViolation = "23514"
+
+// ErrInvalidAdjustment is returned for an empty bulk adjustment, a zero delta
+// or a product listed twice
+var ErrInvalidAdjustment = errs.InvalidArgument("INVALID_ADJUSTMENT", "invalid inventory adjustment")
+
+// Adjustment changes one product's inventory by Delta
+type Adjustment struct {
+	ProductID string
+	Delta     int32
+}
+
+// AdjustmentResult is the outcome of one adjustment of a bulk adjust. Err is
+// only set in partial mode; Inventory is the product's resulting inventory.
+type AdjustmentResult struct {
+	ProductID string
+	Inventory *Inventory
+	Err       error
+}
+
+// AdjustInventory adjusts inventory (for restocking, damage, etc.) and records
+// the change in the ledger
+func (s *InventoryService) AdjustInventory(ctx context.Context, productID string, delta int32, reason string) (*Inventory, error) {
+	var inventory *Inventory
+	err := database.WithTransaction(ctx, s.db, func(tx *sql.Tx) error {
+		var err error
+		inventory, err = s.adjust(ctx, tx, productID, delta, reason, "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishChanges(ctx, productID)
+
+	logger.FromContext(ctx).Info("inventory adjusted",
+		zap.String("product_id", productID),
+		zap.Int32("delta", delta),
+		zap.String("reason", reason),
+	)
+
+	return inventory, nil
+}
+
+// BulkAdjust applies several adjustments, e.g. a supplier delivery, in one
+// transaction. Results are in the order of adjustments. Any failure rolls back
+// every adjustment unless partial is set, in which case failed adjustments are
+// skipped and reported in their result.
+func (s *InventoryService) BulkAdjust(ctx context.Context, adjustments []Adjustment, reason string, partial bool) ([]AdjustmentResult, error) {
+	if len(adjustments) == 0 {
+		return nil, fmt.Errorf("%w: no adjustments", ErrInvalidAdjustment)
+	}
+
+	// Lock rows in product order so concurrent bulk adjusts cannot deadlock
+	order := make([]int, len(adjustments))
+	seen := make(map[string]bool, len(adjustments))
+	for i, adj := range adjustments {
+		if adj.ProductID == "" || adj.Delta == 0 {
+			return nil, fmt.Errorf("%w: each adjustment needs a product_id and a non-zero delta", ErrInvalidAdjustment)
+		}
+		if seen[adj.ProductID] {
+			return nil, fmt.Errorf("%w: product %s listed twice", ErrInvalidAdjustment, adj.ProductID)
+		}
+		seen[adj.ProductID] = true
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return adjustments[order[a]].ProductID < adjustments[order[b]].ProductID
+	})
+
+	batchID := uuid.New().String()
+	results := make([]AdjustmentResult, len(adjustments))
+
+	err := database.WithTransaction(ctx, s.db, func(tx *sql.Tx) error {
+		for _, i := range order {
+			adj := adjustments[i]
+			results[i].ProductID = adj.ProductID
+
+			if !partial {
+				inventory, err := s.adjust(ctx, tx, adj.ProductID, adj.Delta, reason, batchID)
+				if err != nil {
+					return err
+				}
+				results[i].Inventory = inventory
+				continue
+			}
+
+			// A savepoint lets one failed adjustment be undone without
+			// aborting the whole transaction
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_adjust"); err != nil {
+				return fmt.Errorf("failed to create savepoint: %w", err)
+			}
+			inventory, err := s.adjust(ctx, tx, adj.ProductID, adj.Delta, reason, batchID)
+			if err != nil {
+				if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_adjust"); rbErr != nil {
+					return fmt.Errorf("failed to roll back to savepoint: %w", rbErr)
+				}
+				results[i].Err = err
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT bulk_adjust"); err != nil {
+				return fmt.Errorf("failed to release savepoint: %w", err)
+			}
+			results[i].Inventory = inventory
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	adjusted := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Err == nil {
+			adjusted = append(adjusted, result.ProductID)
+		}
+	}
+	s.publishChanges(ctx, adjusted...)
+
+	logger.FromContext(ctx).Info("inventory bulk adjusted",
+		zap.String("batch_id", batchID),
+		zap.Int("adjustments", len(adjustments)),
+		zap.String("reason", reason),
+		zap.Bool("partial", partial),
+	)
+
+	return results, nil
+}
+
+// adjust applies delta to a product's inventory within tx and appends a ledger
+// entry. A negative delta larger than the available quantity fails with
+// ErrInsufficientStock.
+func (s *InventoryService) adjust(ctx context.Context, tx *sql.Tx, productID string, delta int32, reason, batchID string) (*Inventory, error) {
+	query := `
+		INSERT INTO inventory (product_id, available_quantity, total_quantity)
+		VALUES ($1, $2, $2)
+		ON CONFLICT (product_id) DO UPDATE
+		SET available_quantity = inventory.available_quantity + $2,
+		    total_quantity = inventory.total_quantity + $2,
+		    version = inventory.version + 1
+		RETURNING product_id, available_quantity, reserved_quantity, total_quantity, version, updated_at
+	`
+
+	var inventory Inventory
+	spanCtx, span := database.StartSpan(ctx, "inventory.adjust", query)
+	err := tx.QueryRowContext(spanCtx, database.Annotate(spanCtx, query), productID, delta).Scan(
+		&inventory.ProductID,
+		&inventory.AvailableQuantity,
+		&inventory.ReservedQuantity,
+		&inventory.TotalQuantity,
+		&inventory.Version,
+		&inventory.UpdatedAt,
+	)
+	database.EndSpan(span, err)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == checkViolation {
+		return nil, fmt.Errorf("%w: product %s cannot be adjusted by %d", ErrInsufficientStock, productID, delta)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust inventory: %w", err)
+	}
+
+	ledgerQuery := `
+		INSERT INTO inventory_ledger (id, product_id, delta, reason, batch_id, total_after)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, $6)
+	`
+	_, err = tx.ExecContext(ctx, ledgerQuery, uuid.New().String(), productID, delta, reason, batchID, inventory.TotalQuantity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record ledger entry: %w", err)
+	}
+
+	return &inventory, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// changeRecorder records the products announced as changed
+type changeRecorder struct {
+	mu       sync.Mutex
+	products []string
+}
+
+func (r *changeRecorder) Publish(_ context.Context, productIDs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.products = append(r.products, productIDs...)
+}
+
+func (r *changeRecorder) published() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Sorted(slices.Values(r.products))
+}
+
+// newAdjustFixture stocks 5 of product-1 and 2 of product-2
+func newAdjustFixture(t *testing.T) (*InventoryService, *fakeDB, *changeRecorder) {
+	t.Helper()
+	db, fake := newFakeDB(t)
+	fake.addInventory("product-1", 5, 0, 5)
+	fake.addInventory("product-2", 2, 0, 2)
+	changes := &changeRecorder{}
+	return NewInventoryService(db, changes, zap.NewNop()), fake, changes
+}
+
+func TestBulkAdjustAppliesEveryAdjustment(t *testing.T) {
+	s, fake, changes := newAdjustFixture(t)
+
+	results, err := s.BulkAdjust(context.Background(), []Adjustment{
+		{ProductID: "product-3", Delta: 7},
+		{ProductID: "product-1", Delta: -2},
+	}, "delivery", false)
+	if err != nil {
+		t.Fatalf("BulkAdjust() error = %v", err)
+	}
+
+	// Results follow the request order, not the locking order
+	if len(results) != 2 || results[0].ProductID != "product-3" || results[1].ProductID != "product-1" {
+		t.Fatalf("results = %+v, want product-3 then product-1", results)
+	}
+	for _, result := range results {
+		if result.Err != nil || result.Inventory == nil {
+			t.Errorf("%s result = %+v, want an inventory", result.ProductID, result)
+		}
+	}
+	if got := results[0].Inventory.TotalQuantity; got != 7 {
+		t.Errorf("product-3 total = %d, want 7", got)
+	}
+	assertInventory(t, fake, "product-3", 7, 0, 7)
+	assertInventory(t, fake, "product-1", 3, 0, 3)
+
+	if got, want := changes.published(), []string{"product-1", "product-3"}; !slices.Equal(got, want) {
+		t.Errorf("published = %v, want %v", got, want)
+	}
+}
+
+func TestBulkAdjustRollsBackOnFailure(t *testing.T) {
+	s, fake, changes := newAdjustFixture(t)
+
+	_, err := s.BulkAdjust(context.Background(), []Adjustment{
+		{ProductID: "product-1", Delta: 4},
+		{ProductID: "product-2", Delta: -3},
+	}, "damage", false)
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("BulkAdjust() error = %v, want ErrInsufficientStock", err)
+	}
+
+	assertInventory(t, fake, "product-1", 5, 0, 5)
+	assertInventory(t, fake, "product-2", 2, 0, 2)
+	if entries := fake.ledgerEntries(); len(entries) != 0 {
+		t.Errorf("ledger = %+v, want no entries", entries)
+	}
+	if got := changes.published(); len(got) != 0 {
+		t.Errorf("published = %v, want nothing", got)
+	}
+}
+
+func TestBulkAdjustPartialSkipsFailures(t *testing.T) {
+	s, fake, changes := newAdjustFixture(t)
+
+	results, err := s.BulkAdjust(context.Background(), []Adjustment{
+		{ProductID: "product-1", Delta: 4},
+		{ProductID: "product-2", Delta: -3},
+	}, "damage", true)
+	if err != nil {
+		t.Fatalf("BulkAdjust() error = %v", err)
+	}
+
+	if results[0].Err != nil || results[0].Inventory == nil {
+		t.Errorf("product-1 result = %+v, want an inventory", results[0])
+	}
+	if !errors.Is(results[1].Err, ErrInsufficientStock) || results[1].Inventory != nil {
+		t.Errorf("product-2 result = %+v, want ErrInsufficientStock", results[1])
+	}
+	assertInventory(t, fake, "product-1", 9, 0, 9)
+	assertInventory(t, fake, "product-2", 2, 0, 2)
+
+	entries := fake.ledgerEntries()
+	if len(entries) != 1 || entries[0].ProductID != "product-1" {
+		t.Errorf("ledger = %+v, want only product-1", entries)
+	}
+	if got, want := changes.published(), []string{"product-1"}; !slices.Equal(got, want) {
+		t.Errorf("published = %v, want %v", got, want)
+	}
+}
+
+func TestBulkAdjustRejectsInvalidAdjustments(t *testing.T) {
+	tests := map[string][]Adjustment{
+		"no adjustments":   nil,
+		"zero delta":       {{ProductID: "product-1", Delta: 0}},
+		"no product":       {{Delta: 1}},
+		"repeated product": {{ProductID: "product-1", Delta: 1}, {ProductID: "product-1", Delta: 2}},
+	}
+
+	for name, adjustments := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, fake, _ := newAdjustFixture(t)
+			if _, err := s.BulkAdjust(context.Background(), adjustments, "", false); !errors.Is(err, ErrInvalidAdjustment) {
+				t.Errorf("BulkAdjust() error = %v, want ErrInvalidAdjustment", err)
+			}
+			assertInventory(t, fake, "product-1", 5, 0, 5)
+		})
+	}
+}
+
+func TestAdjustmentsKeepLedgerConsistent(t *testing.T) {
+	s, fake, _ := newAdjustFixture(t)
+	ctx := context.Background()
+
+	if _, err := s.AdjustInventory(ctx, "product-1", 3, "restock"); err != nil {
+		t.Fatalf("AdjustInventory() error = %v", err)
+	}
+	if _, err := s.BulkAdjust(ctx, []Adjustment{
+		{ProductID: "product-1", Delta: -4},
+		{ProductID: "product-2", Delta: 6},
+	}, "recount", false); err != nil {
+		t.Fatalf("BulkAdjust() error = %v", err)
+	}
+	if _, err := s.BulkAdjust(ctx, []Adjustment{
+		{ProductID: "product-1", Delta: -100},
+	}, "damage", true); err != nil {
+		t.Fatalf("BulkAdjust() error = %v", err)
+	}
+
+	entries := fake.ledgerEntries()
+	if len(entries) != 3 {
+		t.Fatalf("ledger = %+v, want 3 entries", entries)
+	}
+	if entries[0].BatchID != "" {
+		t.Errorf("single adjustment batch = %q, want none", entries[0].BatchID)
+	}
+	if entries[1].BatchID == "" || entries[1].BatchID != entries[2].BatchID {
+		t.Errorf("bulk entries batches = %q, %q; want one shared batch", entries[1].BatchID, entries[2].BatchID)
+	}
+
+	// Each product's deltas add up from its starting total to its current
+	// total, and every entry records the total it left behind
+	totals := map[string]int32{"product-1": 5, "product-2": 2}
+	for _, entry := range entries {
+		totals[entry.ProductID] += entry.Delta
+		if entry.TotalAfter != totals[entry.ProductID] {
+			t.Errorf("%s entry total after = %d, want %d", entry.ProductID, entry.TotalAfter, totals[entry.ProductID])
+		}
+	}
+	for productID, total := range totals {
+		if got := fake.inventoryOf(productID).TotalQuantity; got != total {
+			t.Errorf("%s total = %d, ledger adds up to %d", productID, got, total)
+		}
+	}
+}
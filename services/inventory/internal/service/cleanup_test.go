@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// metricValue returns the value of the counter or gauge named name
+func metricValue(t *testing.T, name string) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if counter := metric.GetCounter(); counter != nil {
+				return counter.GetValue()
+			}
+			return metric.GetGauge().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestCleanupExpiredReservationsCountsReleased(t *testing.T) {
+	db, fake := newFakeDB(t)
+	fake.addInventory("product-1", 0, 9, 9)
+	for _, r := range []Reservation{
+		{ID: "res-expired-1", ReservationID: "order-1", ProductID: "product-1", Quantity: 2, Status: "active", ExpiresAt: fake.now.Add(-time.Minute)},
+		{ID: "res-expired-2", ReservationID: "order-2", ProductID: "product-1", Quantity: 3, CommittedQuantity: 1, Status: "active", ExpiresAt: fake.now.Add(-time.Minute)},
+		{ID: "res-active", ReservationID: "order-3", ProductID: "product-1", Quantity: 4, Status: "active", ExpiresAt: fake.now.Add(time.Hour)},
+	} {
+		fake.addReservation(r)
+	}
+	s := NewInventoryService(db, nil, zap.NewNop())
+
+	before := metricValue(t, "coldy_inventory_reservations_expired_total")
+	released, err := s.CleanupExpiredReservations(context.Background())
+	if err != nil {
+		t.Fatalf("CleanupExpiredReservations() error = %v", err)
+	}
+	if released != 2 {
+		t.Errorf("released = %d, want 2", released)
+	}
+	if got := metricValue(t, "coldy_inventory_reservations_expired_total") - before; got != 2 {
+		t.Errorf("expired counter advanced by %v, want 2", got)
+	}
+	if got := metricValue(t, "coldy_inventory_reservations_active"); got != 1 {
+		t.Errorf("active gauge = %v, want 1", got)
+	}
+	// Only the uncommitted remainder returns to stock
+	assertInventory(t, fake, "product-1", 4, 5, 9)
+
+	// A second run has nothing left to release
+	before = metricValue(t, "coldy_inventory_reservations_expired_total")
+	if released, err = s.CleanupExpiredReservations(context.Background()); err != nil || released != 0 {
+		t.Fatalf("CleanupExpiredReservations() = %d, %v; want 0, nil", released, err)
+	}
+	if got := metricValue(t, "coldy_inventory_reservations_expired_total") - before; got != 0 {
+		t.Errorf("expired counter advanced by %v, want 0", got)
+	}
+}
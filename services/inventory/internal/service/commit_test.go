@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// newCommitFixture reserves 5 of product-1 and 2 of product-2 under order-1
+func newCommitFixture(t *testing.T) (*InventoryService, *fakeDB) {
+	t.Helper()
+	db, fake := newFakeDB(t)
+	fake.addInventory("product-1", 5, 5, 10)
+	fake.addInventory("product-2", 0, 2, 2)
+	fake.addReservation(Reservation{ID: "res-1", ReservationID: "order-1", ProductID: "product-1", Quantity: 5, Status: "active", ExpiresAt: fake.now.Add(time.Hour)})
+	fake.addReservation(Reservation{ID: "res-2", ReservationID: "order-1", ProductID: "product-2", Quantity: 2, Status: "active", ExpiresAt: fake.now.Add(time.Hour)})
+	return NewInventoryService(db, nil, zap.NewNop()), fake
+}
+
+func assertInventory(t *testing.T, fake *fakeDB, productID string, available, reserved, total int32) {
+	t.Helper()
+	got := fake.inventoryOf(productID)
+	if got.AvailableQuantity != available || got.ReservedQuantity != reserved || got.TotalQuantity != total {
+		t.Errorf("%s inventory = %d available, %d reserved, %d total; want %d, %d, %d", productID,
+			got.AvailableQuantity, got.ReservedQuantity, got.TotalQuantity, available, reserved, total)
+	}
+}
+
+func assertReservation(t *testing.T, fake *fakeDB, id, status string, committed int32) {
+	t.Helper()
+	got := fake.reservation(id)
+	if got.Status != status || got.CommittedQuantity != committed {
+		t.Errorf("%s = %s with %d committed, want %s with %d", id, got.Status, got.CommittedQuantity, status, committed)
+	}
+}
+
+func TestCommitStockPartialKeepsRemainderReserved(t *testing.T) {
+	s, fake := newCommitFixture(t)
+
+	err := s.CommitStockPartial(context.Background(), "order-1", []ReservationItem{{ProductID: "product-1", Quantity: 2}})
+	if err != nil {
+		t.Fatalf("CommitStockPartial() error = %v", err)
+	}
+
+	assertReservation(t, fake, "res-1", "active", 2)
+	assertInventory(t, fake, "product-1", 5, 3, 8)
+	assertReservation(t, fake, "res-2", "active", 0)
+	assertInventory(t, fake, "product-2", 0, 2, 2)
+}
+
+func TestCommitStockPartialRejectsOverCommit(t *testing.T) {
+	tests := map[string]struct {
+		// committed of product-1 is committed before the rejected commit
+		committed int32
+		items     []ReservationItem
+	}{
+		"more than reserved":    {items: []ReservationItem{{ProductID: "product-1", Quantity: 6}}},
+		"repeated product":      {items: []ReservationItem{{ProductID: "product-1", Quantity: 3}, {ProductID: "product-1", Quantity: 3}}},
+		"one item of several":   {items: []ReservationItem{{ProductID: "product-1", Quantity: 1}, {ProductID: "product-2", Quantity: 3}}},
+		"more than what's left": {committed: 2, items: []ReservationItem{{ProductID: "product-1", Quantity: 4}}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, fake := newCommitFixture(t)
+			if tt.committed > 0 {
+				err := s.CommitStockPartial(context.Background(), "order-1", []ReservationItem{{ProductID: "product-1", Quantity: tt.committed}})
+				if err != nil {
+					t.Fatalf("CommitStockPartial() error = %v", err)
+				}
+			}
+			before1, before2 := fake.reservation("res-1"), fake.reservation("res-2")
+			inventory1, inventory2 := fake.inventoryOf("product-1"), fake.inventoryOf("product-2")
+
+			err := s.CommitStockPartial(context.Background(), "order-1", tt.items)
+			if !errors.Is(err, ErrOverCommit) {
+				t.Fatalf("CommitStockPartial() error = %v, want ErrOverCommit", err)
+			}
+
+			// Nothing of the rejected commit is kept
+			assertReservation(t, fake, "res-1", before1.Status, before1.CommittedQuantity)
+			assertReservation(t, fake, "res-2", before2.Status, before2.CommittedQuantity)
+			assertInventory(t, fake, "product-1", inventory1.AvailableQuantity, inventory1.ReservedQuantity, inventory1.TotalQuantity)
+			assertInventory(t, fake, "product-2", inventory2.AvailableQuantity, inventory2.ReservedQuantity, inventory2.TotalQuantity)
+		})
+	}
+}
+
+func TestCommitStockPartialRejectsInvalidItems(t *testing.T) {
+	tests := map[string]struct {
+		items []ReservationItem
+		want  error
+	}{
+		"no items":         {items: nil, want: ErrInvalidCommitItems},
+		"zero quantity":    {items: []ReservationItem{{ProductID: "product-1", Quantity: 0}}, want: ErrInvalidCommitItems},
+		"product not held": {items: []ReservationItem{{ProductID: "product-3", Quantity: 1}}, want: ErrReservationNotFound},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, _ := newCommitFixture(t)
+			if err := s.CommitStockPartial(context.Background(), "order-1", tt.items); !errors.Is(err, tt.want) {
+				t.Errorf("CommitStockPartial() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitStockPartialOfRemainderCommitsItem(t *testing.T) {
+	s, fake := newCommitFixture(t)
+
+	for _, quantity := range []int32{2, 3} {
+		err := s.CommitStockPartial(context.Background(), "order-1", []ReservationItem{{ProductID: "product-1", Quantity: quantity}})
+		if err != nil {
+			t.Fatalf("CommitStockPartial(%d) error = %v", quantity, err)
+		}
+	}
+
+	assertReservation(t, fake, "res-1", "committed", 5)
+	assertInventory(t, fake, "product-1", 5, 0, 5)
+
+	// A committed item can't be committed again
+	err := s.CommitStockPartial(context.Background(), "order-1", []ReservationItem{{ProductID: "product-1", Quantity: 1}})
+	if !errors.Is(err, ErrReservationNotFound) {
+		t.Errorf("CommitStockPartial() error = %v, want ErrReservationNotFound", err)
+	}
+}
+
+func TestCommitStockAfterPartialCommitsRemainder(t *testing.T) {
+	s, fake := newCommitFixture(t)
+
+	err := s.CommitStockPartial(context.Background(), "order-1", []ReservationItem{{ProductID: "product-1", Quantity: 2}})
+	if err != nil {
+		t.Fatalf("CommitStockPartial() error = %v", err)
+	}
+	if err := s.CommitStock(context.Background(), "order-1"); err != nil {
+		t.Fatalf("CommitStock() error = %v", err)
+	}
+
+	assertReservation(t, fake, "res-1", "committed", 5)
+	assertInventory(t, fake, "product-1", 5, 0, 5)
+	assertReservation(t, fake, "res-2", "committed", 2)
+	assertInventory(t, fake, "product-2", 0, 0, 0)
+}
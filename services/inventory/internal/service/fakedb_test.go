@@ -0,0 +1,570 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// fakeDB is an in-memory inventory, reservations and ledger answering the
+// statements InventoryService issues through database/sql. CURRENT_TIMESTAMP
+// is the fake's clock, which every write advances by a second so rows keep a
+// strict order. A transaction or savepoint snapshots the tables and a
+// rollback restores them; row locks are not modelled.
+type fakeDB struct {
+	mu  sync.Mutex
+	now time.Time
+	fakeTables
+	// begun holds the tables as of the open transaction's start
+	begun *fakeTables
+	// savepoint holds the tables as of the last SAVEPOINT
+	savepoint *fakeTables
+}
+
+type fakeTables struct {
+	inventory    map[string]Inventory
+	reservations []Reservation
+	ledger       []ledgerEntry
+}
+
+// ledgerEntry is a row of inventory_ledger
+type ledgerEntry struct {
+	ID         string
+	ProductID  string
+	Delta      int32
+	Reason     string
+	BatchID    string
+	TotalAfter int32
+	CreatedAt  time.Time
+}
+
+func (t fakeTables) clone() fakeTables {
+	c := fakeTables{
+		inventory:    make(map[string]Inventory, len(t.inventory)),
+		reservations: append([]Reservation(nil), t.reservations...),
+		ledger:       append([]ledgerEntry(nil), t.ledger...),
+	}
+	for id, inventory := range t.inventory {
+		c.inventory[id] = inventory
+	}
+	return c
+}
+
+var (
+	registerFakeDriver sync.Once
+	fakeDBs            sync.Map
+)
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	registerFakeDriver.Do(func() { sql.Register("inventory-fake", fakeDriver{}) })
+
+	fake := &fakeDB{
+		now:        time.Now().UTC().Truncate(time.Second),
+		fakeTables: fakeTables{inventory: make(map[string]Inventory)},
+	}
+	fakeDBs.Store(t.Name(), fake)
+
+	db, err := sql.Open("inventory-fake", t.Name())
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		fakeDBs.Delete(t.Name())
+	})
+	return db, fake
+}
+
+// addReservation stores r; its timestamps default to the fake's clock
+func (f *fakeDB) addReservation(r Reservation) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.tick()
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = now
+	}
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = now
+	}
+	f.reservations = append(f.reservations, r)
+}
+
+// addInventory stores a product's inventory at version 1
+func (f *fakeDB) addInventory(productID string, available, reserved, total int32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inventory[productID] = Inventory{
+		ProductID:         productID,
+		AvailableQuantity: available,
+		ReservedQuantity:  reserved,
+		TotalQuantity:     total,
+		Version:           1,
+		UpdatedAt:         f.tick(),
+	}
+}
+
+func (f *fakeDB) inventoryOf(productID string) Inventory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.inventory[productID]
+}
+
+func (f *fakeDB) reservation(id string) Reservation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.reservations {
+		if r.ID == id {
+			return r
+		}
+	}
+	return Reservation{}
+}
+
+// addLedgerEntry stores e; its timestamp defaults to the fake's clock
+func (f *fakeDB) addLedgerEntry(e ledgerEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = f.tick()
+	}
+	f.ledger = append(f.ledger, e)
+}
+
+func (f *fakeDB) ledgerEntries() []ledgerEntry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]ledgerEntry(nil), f.ledger...)
+}
+
+func (f *fakeDB) tick() time.Time {
+	f.now = f.now.Add(time.Second)
+	return f.now
+}
+
+// expired reports whether an active reservation is past its expiry
+func (f *fakeDB) expired(r Reservation) bool {
+	return r.Status == "active" && !r.ExpiresAt.IsZero() && r.ExpiresAt.Before(f.now)
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fake, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("no fake database %q", name)
+	}
+	return &fakeConn{db: fake.(*fakeDB)}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.begun != nil {
+		return nil, fmt.Errorf("concurrent transactions are not supported")
+	}
+	begun := f.clone()
+	f.begun = &begun
+	return fakeTx{db: f}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx fakeTx) Commit() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.begun, tx.db.savepoint = nil, nil
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.fakeTables = *tx.db.begun
+	tx.db.begun, tx.db.savepoint = nil, nil
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "ROLLBACK TO SAVEPOINT"):
+		f.fakeTables = f.savepoint.clone()
+		return driver.RowsAffected(0), nil
+
+	case strings.HasPrefix(query, "RELEASE SAVEPOINT"):
+		f.savepoint = nil
+		return driver.RowsAffected(0), nil
+
+	case strings.HasPrefix(query, "SAVEPOINT"):
+		savepoint := f.clone()
+		f.savepoint = &savepoint
+		return driver.RowsAffected(0), nil
+
+	case strings.Contains(query, "INSERT INTO inventory_ledger"):
+		f.ledger = append(f.ledger, ledgerEntry{
+			ID:         args[0].Value.(string),
+			ProductID:  args[1].Value.(string),
+			Delta:      int32(args[2].Value.(int64)),
+			Reason:     args[3].Value.(string),
+			BatchID:    args[4].Value.(string),
+			TotalAfter: int32(args[5].Value.(int64)),
+			CreatedAt:  f.tick(),
+		})
+		return driver.RowsAffected(1), nil
+
+	case strings.Contains(query, "SET reserved_quantity = reserved_quantity - $1"):
+		quantity := int32(args[0].Value.(int64))
+		return f.updateInventory(args[1].Value.(string), func(inventory *Inventory) {
+			inventory.ReservedQuantity -= quantity
+			inventory.TotalQuantity -= quantity
+		}), nil
+
+	case strings.Contains(query, "SET committed_quantity = committed_quantity + $1"):
+		quantity := int32(args[0].Value.(int64))
+		id := args[1].Value.(string)
+		return f.updateReservations(func(r *Reservation) bool { return r.ID == id }, func(r *Reservation) {
+			r.CommittedQuantity += quantity
+			if r.CommittedQuantity == r.Quantity {
+				r.Status = "committed"
+			}
+		}), nil
+
+	case strings.Contains(query, "UPDATE reservations") && strings.Contains(query, "SET status = $1"):
+		status := args[0].Value.(string)
+		reservationID := args[1].Value.(string)
+		return f.updateReservations(func(r *Reservation) bool {
+			return r.ReservationID == reservationID && r.Status == "active"
+		}, func(r *Reservation) {
+			r.Status = status
+			if status == "committed" {
+				r.CommittedQuantity = r.Quantity
+			}
+		}), nil
+	}
+	return nil, fmt.Errorf("unexpected statement: %s", query)
+}
+
+func (f *fakeDB) updateInventory(productID string, update func(*Inventory)) driver.Result {
+	inventory, ok := f.inventory[productID]
+	if !ok {
+		return driver.RowsAffected(0)
+	}
+	update(&inventory)
+	inventory.Version++
+	inventory.UpdatedAt = f.tick()
+	f.inventory[productID] = inventory
+	return driver.RowsAffected(1)
+}
+
+func (f *fakeDB) updateReservations(match func(*Reservation) bool, update func(*Reservation)) driver.Result {
+	var n int64
+	for i := range f.reservations {
+		r := &f.reservations[i]
+		if match(r) {
+			update(r)
+			r.UpdatedAt = f.tick()
+			n++
+		}
+	}
+	return driver.RowsAffected(n)
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case strings.Contains(query, "INSERT INTO inventory (product_id"):
+		inventory, err := f.upsertInventory(args[0].Value.(string), int32(args[1].Value.(int64)))
+		if err != nil {
+			return nil, err
+		}
+		return inventoryRows(inventory), nil
+
+	case strings.Contains(query, "FROM inventory_ledger") && strings.Contains(query, "UNION ALL"):
+		return f.timeline(query, args), nil
+
+	case strings.Contains(query, "SELECT cleanup_expired_reservations()"):
+		return &fakeRows{columns: []string{"released"}, values: [][]driver.Value{{f.cleanupExpired()}}}, nil
+
+	case strings.Contains(query, "SELECT COUNT(*) FROM reservations WHERE status = 'active'"):
+		var active int64
+		for _, r := range f.reservations {
+			if r.Status == "active" {
+				active++
+			}
+		}
+		return &fakeRows{columns: []string{"count"}, values: [][]driver.Value{{active}}}, nil
+
+	case strings.Contains(query, "SELECT id, quantity - committed_quantity"):
+		reservationID, productID := args[0].Value.(string), args[1].Value.(string)
+		rows := &fakeRows{columns: []string{"id", "remaining"}}
+		for _, r := range f.reservations {
+			if r.ReservationID == reservationID && r.ProductID == productID && r.Status == "active" {
+				rows.values = append(rows.values, []driver.Value{r.ID, int64(r.Quantity - r.CommittedQuantity)})
+			}
+		}
+		return rows, nil
+
+	case strings.Contains(query, "SELECT product_id, quantity - committed_quantity"):
+		reservationID := args[0].Value.(string)
+		rows := &fakeRows{columns: []string{"product_id", "remaining"}}
+		for _, r := range f.reservations {
+			if r.ReservationID == reservationID && r.Status == "active" {
+				rows.values = append(rows.values, []driver.Value{r.ProductID, int64(r.Quantity - r.CommittedQuantity)})
+			}
+		}
+		return rows, nil
+
+	case strings.Contains(query, reservationColumns) && strings.Contains(query, "WHERE reservation_id = $1"):
+		return reservationRows(f.reservationItems(args[0].Value.(string))), nil
+
+	case strings.Contains(query, reservationColumns) && strings.Contains(query, "WHERE product_id = $1"):
+		return reservationRows(f.productReservations(query, args)), nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+// upsertInventory answers the adjust upsert, failing like the
+// available_quantity check constraint when stock would go negative
+func (f *fakeDB) upsertInventory(productID string, delta int32) (Inventory, error) {
+	inventory, ok := f.inventory[productID]
+	if !ok {
+		inventory = Inventory{ProductID: productID}
+	}
+	if inventory.AvailableQuantity+delta < 0 {
+		return Inventory{}, &pq.Error{Code: checkViolation, Message: "violates check constraint"}
+	}
+	inventory.AvailableQuantity += delta
+	inventory.TotalQuantity += delta
+	inventory.Version++
+	inventory.UpdatedAt = f.tick()
+	f.inventory[productID] = inventory
+	return inventory, nil
+}
+
+func inventoryRows(inventory Inventory) *fakeRows {
+	return &fakeRows{
+		columns: []string{"product_id", "available_quantity", "reserved_quantity", "total_quantity", "version", "updated_at"},
+		values: [][]driver.Value{{
+			inventory.ProductID, int64(inventory.AvailableQuantity), int64(inventory.ReservedQuantity),
+			int64(inventory.TotalQuantity), int64(inventory.Version), inventory.UpdatedAt,
+		}},
+	}
+}
+
+// timeline answers GetProductTimeline, merging ledger entries and
+// reservation events the way timelineQuery does and reading the optional
+// window and cursor arguments off the query
+func (f *fakeDB) timeline(query string, args []driver.NamedValue) *fakeRows {
+	type event struct {
+		at                           time.Time
+		key, kind, reference, reason string
+		quantity                     int32
+	}
+
+	productID := args[0].Value.(string)
+	var events []event
+	for _, e := range f.ledger {
+		if e.ProductID == productID {
+			events = append(events, event{e.CreatedAt, "l:" + e.ID, TimelineAdjustment, e.BatchID, e.Reason, e.Delta})
+		}
+	}
+	for _, r := range f.reservations {
+		if r.ProductID != productID {
+			continue
+		}
+		events = append(events, event{r.CreatedAt, "r:" + r.ID, TimelineReserved, r.ReservationID, "", r.Quantity})
+		switch {
+		case r.Status == "committed":
+			events = append(events, event{r.UpdatedAt, "f:" + r.ID, TimelineCommitted, r.ReservationID, "", r.CommittedQuantity})
+		case r.Status == "released" && !r.ExpiresAt.IsZero() && !r.ExpiresAt.After(r.UpdatedAt):
+			events = append(events, event{r.UpdatedAt, "f:" + r.ID, TimelineExpired, r.ReservationID, "", r.Quantity - r.CommittedQuantity})
+		case r.Status == "released":
+			events = append(events, event{r.UpdatedAt, "f:" + r.ID, TimelineReleased, r.ReservationID, "", r.Quantity - r.CommittedQuantity})
+		}
+	}
+
+	next := 1
+	var from, to time.Time
+	if strings.Contains(query, "AND occurred_at >= $") {
+		from = args[next].Value.(time.Time)
+		next++
+	}
+	if strings.Contains(query, "AND occurred_at < $") {
+		to = args[next].Value.(time.Time)
+		next++
+	}
+	var afterAt time.Time
+	var afterKey string
+	cursor := strings.Contains(query, "(occurred_at, event_key) >")
+	if cursor {
+		afterAt, afterKey = args[next].Value.(time.Time), args[next+1].Value.(string)
+		next += 2
+	}
+	limit := int(args[next].Value.(int64))
+
+	before := func(at time.Time, key string, e event) bool {
+		return at.Before(e.at) || at.Equal(e.at) && key < e.key
+	}
+	rows := &fakeRows{columns: []string{"occurred_at", "event_key", "kind", "quantity", "reference", "reason"}}
+	sort.Slice(events, func(i, j int) bool { return before(events[i].at, events[i].key, events[j]) })
+	for _, e := range events {
+		switch {
+		case !from.IsZero() && e.at.Before(from),
+			!to.IsZero() && !e.at.Before(to),
+			cursor && !before(afterAt, afterKey, e):
+			continue
+		}
+		if len(rows.values) == limit {
+			break
+		}
+		rows.values = append(rows.values, []driver.Value{e.at, e.key, e.kind, int64(e.quantity), e.reference, e.reason})
+	}
+	return rows
+}
+
+// cleanupExpired mirrors cleanup_expired_reservations(): it returns the
+// uncommitted remainder of expired reservations to stock and counts them
+func (f *fakeDB) cleanupExpired() int64 {
+	var released int64
+	for i := range f.reservations {
+		r := &f.reservations[i]
+		if !f.expired(*r) {
+			continue
+		}
+		remaining := r.Quantity - r.CommittedQuantity
+		f.updateInventory(r.ProductID, func(inventory *Inventory) {
+			inventory.AvailableQuantity += remaining
+			inventory.ReservedQuantity -= remaining
+		})
+		r.Status = "released"
+		r.UpdatedAt = f.tick()
+		released++
+	}
+	return released
+}
+
+// reservationItems answers GetReservation
+func (f *fakeDB) reservationItems(reservationID string) []Reservation {
+	var items []Reservation
+	for _, r := range f.reservations {
+		if r.ReservationID == reservationID {
+			items = append(items, r)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+	return items
+}
+
+// productReservations answers ListReservationsForProduct, reading its
+// optional status and cursor arguments off the query
+func (f *fakeDB) productReservations(query string, args []driver.NamedValue) []Reservation {
+	productID := args[0].Value.(string)
+	next := 1
+	var status string
+	if strings.Contains(query, "AND status = $") {
+		status = args[next].Value.(string)
+		next++
+	}
+	var cursor *Reservation
+	if strings.Contains(query, "(created_at, id) <") {
+		id := args[next].Value.(string)
+		next++
+		for i := range f.reservations {
+			if f.reservations[i].ID == id {
+				cursor = &f.reservations[i]
+			}
+		}
+		if cursor == nil {
+			// The subquery yields NULL, which matches no row
+			return nil
+		}
+	}
+	limit := int(args[next].Value.(int64))
+
+	newer := func(a, b Reservation) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	}
+
+	var matched []Reservation
+	for _, r := range f.reservations {
+		switch {
+		case r.ProductID != productID,
+			status != "" && r.Status != status,
+			strings.Contains(query, "expires_at >= CURRENT_TIMESTAMP") && (r.Status != "active" || f.expired(r)),
+			strings.Contains(query, "expires_at < CURRENT_TIMESTAMP") && !f.expired(r),
+			cursor != nil && !newer(*cursor, r):
+			continue
+		}
+		matched = append(matched, r)
+	}
+	sort.Slice(matched, func(i, j int) bool { return newer(matched[i], matched[j]) })
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched
+}
+
+func reservationRows(reservations []Reservation) *fakeRows {
+	rows := &fakeRows{columns: strings.Split(reservationColumns, ", ")}
+	for _, r := range reservations {
+		var expiresAt driver.Value
+		if !r.ExpiresAt.IsZero() {
+			expiresAt = r.ExpiresAt
+		}
+		rows.values = append(rows.values, []driver.Value{
+			r.ID, r.ReservationID, r.ProductID, int64(r.Quantity), int64(r.CommittedQuantity),
+			r.Status, expiresAt, r.CreatedAt, r.UpdatedAt,
+		})
+	}
+	return rows
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
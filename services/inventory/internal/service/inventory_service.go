@@ -3,24 +3,88 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/database"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/retry"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrInventoryConflict is returned when an optimistic lock check fails
+	ErrInventoryConflict = errs.Conflict("INVENTORY_CONFLICT", "inventory conflict")
+	// ErrInsufficientStock is returned when one or more items cannot be reserved
+	ErrInsufficientStock = errs.FailedPrecondition("INSUFFICIENT_STOCK", "insufficient stock")
+	// ErrInventoryNotFound is returned when a product has no inventory record
+	ErrInventoryNotFound = errs.NotFound("INVENTORY_NOT_FOUND", "inventory not found")
+	// ErrReservationNotFound is returned when a reservation has no active items
+	ErrReservationNotFound = errs.NotFound("RESERVATION_NOT_FOUND", "reservation not found")
+	// ErrInvalidReservationStatus is returned for an unknown reservation status filter
+	ErrInvalidReservationStatus = errs.InvalidArgument("INVALID_RESERVATION_STATUS", "invalid reservation status")
+	// ErrOverCommit is returned when a partial commit exceeds the quantity still reserved
+	ErrOverCommit = errs.FailedPrecondition("OVER_COMMIT", "commit exceeds reserved quantity")
+	// ErrInvalidCommitItems is returned when a partial commit has no items or a non-positive quantity
+	ErrInvalidCommitItems = errs.InvalidArgument("INVALID_COMMIT_ITEMS", "invalid commit items")
+)
+
+// ReservationFailure describes why an item could not be reserved
+type ReservationFailure struct {
+	ProductID string
+	Reason    string
+	Available int32
+	Requested int32
+}
+
+// InsufficientStockError lists every item that could not be reserved
+type InsufficientStockError struct {
+	Failures []ReservationFailure
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("%s for %d item(s)", ErrInsufficientStock, len(e.Failures))
+}
+
+// Unwrap returns ErrInsufficientStock
+func (e *InsufficientStockError) Unwrap() error {
+	return ErrInsufficientStock
+}
+
+// ChangePublisher is told which products' inventory changed once a write
+// commits
+type ChangePublisher interface {
+	Publish(ctx context.Context, productIDs ...string)
+}
+
 // InventoryService handles inventory business logic
 type InventoryService struct {
-	db     *sql.DB
-	logger *zap.Logger
+	db          *sql.DB
+	changes     ChangePublisher
+	logger      *zap.Logger
+	retryPolicy retry.Policy
 }
 
-// NewInventoryService creates a new inventory service
-func NewInventoryService(db *sql.DB, logger *zap.Logger) *InventoryService {
+// NewInventoryService creates a new inventory service. changes may be nil.
+func NewInventoryService(db *sql.DB, changes ChangePublisher, logger *zap.Logger) *InventoryService {
+	// Retry optimistic-lock conflicts caused by concurrent reservations
+	policy := retry.Policy{
+		MaxAttempts: 3,
+		BaseDelay:   20 * time.Millisecond,
+		MaxDelay:    200 * time.Millisecond,
+		Retryable: func(err error) bool {
+			return errors.Is(err, ErrInventoryConflict)
+		},
+	}
+
 	return &InventoryService{
-		db:     db,
-		logger: logger,
+		db:          db,
+		changes:     changes,
+		logger:      logger,
+		retryPolicy: policy,
 	}
 }
 
@@ -40,12 +104,34 @@ type ReservationItem struct {
 	Quantity  int32
 }
 
-// ReserveStock reserves stock for an order with optimistic locking
+// Reservation is a single reserved product within a reservation
+type Reservation struct {
+	ID            string
+	ReservationID string
+	ProductID     string
+	Quantity      int32
+	// CommittedQuantity is the part of Quantity already committed by partial commits
+	CommittedQuantity int32
+	Status            string
+	ExpiresAt         time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// ReserveStock reserves stock for an order with optimistic locking,
+// retrying the whole transaction on version conflicts. Reservation is all-or-nothing:
+// if any item is short, nothing is reserved and an *InsufficientStockError lists every shortfall.
 func (s *InventoryService) ReserveStock(ctx context.Context, reservationID string, items []ReservationItem, ttlSeconds int32) error {
 	if ttlSeconds <= 0 {
 		ttlSeconds = 900 // Default 15 minutes
 	}
 
+	return retry.Do(ctx, s.retryPolicy, func() error {
+		return s.reserveStock(ctx, reservationID, items, ttlSeconds)
+	})
+}
+
+func (s *InventoryService) reserveStock(ctx context.Context, reservationID string, items []ReservationItem, ttlSeconds int32) error {
 	expiresAt := time.Now().Add(time.Duration(ttlSeconds) * time.Second)
 
 	// Start transaction
@@ -56,6 +142,7 @@ func (s *InventoryService) ReserveStock(ctx context.Context, reservationID strin
 	defer func() { _ = tx.Rollback() }()
 
 	// Reserve each item with optimistic locking
+	var failures []ReservationFailure
 	for _, item := range items {
 		// Get current inventory with version (optimistic lock)
 		var inventory Inventory
@@ -66,7 +153,8 @@ func (s *InventoryService) ReserveStock(ctx context.Context, reservationID strin
 			FOR UPDATE
 		`
 
-		err := tx.QueryRowContext(ctx, query, item.ProductID).Scan(
+		spanCtx, span := database.StartSpan(ctx, "inventory.lock", query)
+		err := tx.QueryRowContext(spanCtx, database.Annotate(spanCtx, query), item.ProductID).Scan(
 			&inventory.ProductID,
 			&inventory.AvailableQuantity,
 			&inventory.ReservedQuantity,
@@ -74,18 +162,33 @@ func (s *InventoryService) ReserveStock(ctx context.Context, reservationID strin
 			&inventory.Version,
 			&inventory.UpdatedAt,
 		)
+		database.EndSpan(span, err)
 
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("product %s not found in inventory", item.ProductID)
+			failures = append(failures, ReservationFailure{
+				ProductID: item.ProductID,
+				Reason:    "product not found in inventory",
+				Requested: item.Quantity,
+			})
+			continue
 		}
 		if err != nil {
 			return fmt.Errorf("failed to get inventory: %w", err)
 		}
 
-		// Check if enough stock available
+		// Check if enough stock available; keep checking so every shortfall is reported
 		if inventory.AvailableQuantity < item.Quantity {
-			return fmt.Errorf("insufficient stock for product %s: available=%d, requested=%d",
-				item.ProductID, inventory.AvailableQuantity, item.Quantity)
+			failures = append(failures, ReservationFailure{
+				ProductID: item.ProductID,
+				Reason:    "insufficient stock",
+				Available: inventory.AvailableQuantity,
+				Requested: item.Quantity,
+			})
+			continue
+		}
+		if len(failures) > 0 {
+			// The transaction will be rolled back; no need to write
+			continue
 		}
 
 		// Update inventory with optimistic locking (version check)
@@ -98,7 +201,9 @@ func (s *InventoryService) ReserveStock(ctx context.Context, reservationID strin
 			WHERE product_id = $2 AND version = $3
 		`
 
-		result, err := tx.ExecContext(ctx, updateQuery, item.Quantity, item.ProductID, inventory.Version)
+		spanCtx, span = database.StartSpan(ctx, "inventory.reserve", updateQuery)
+		result, err := tx.ExecContext(spanCtx, database.Annotate(spanCtx, updateQuery), item.Quantity, item.ProductID, inventory.Version)
+		database.EndSpan(span, err)
 		if err != nil {
 			return fmt.Errorf("failed to update inventory: %w", err)
 		}
@@ -110,7 +215,7 @@ func (s *InventoryService) ReserveStock(ctx context.Context, reservationID strin
 
 		// If no rows affected, version mismatch (concurrent update)
 		if rowsAffected == 0 {
-			return fmt.Errorf("inventory conflict for product %s (concurrent update)", item.ProductID)
+			return fmt.Errorf("%w for product %s (concurrent update)", ErrInventoryConflict, item.ProductID)
 		}
 
 		// Create reservation record
@@ -133,11 +238,16 @@ func (s *InventoryService) ReserveStock(ctx context.Context, reservationID strin
 		}
 	}
 
+	if len(failures) > 0 {
+		return &InsufficientStockError{Failures: failures}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	s.publishChanges(ctx, reservationProductIDs(items)...)
 
-	s.logger.Info("stock reserved",
+	logger.FromContext(ctx).Info("stock reserved",
 		zap.String("reservation_id", reservationID),
 		zap.Int("items_count", len(items)),
 	)
@@ -173,6 +283,89 @@ func (s *InventoryService) CommitStock(ctx context.Context, reservationID string
 	})
 }
 
+// CommitStockPartial commits the given quantities of a reservation, e.g. for
+// one shipment of a split order. Whatever is not committed stays reserved; an
+// item is marked committed once its full quantity has been committed.
+func (s *InventoryService) CommitStockPartial(ctx context.Context, reservationID string, items []ReservationItem) error {
+	// Merge repeated products so the over-commit check sees the total
+	quantities := make(map[string]int32, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity for product %s must be positive", ErrInvalidCommitItems, item.ProductID)
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+	if len(quantities) == 0 {
+		return fmt.Errorf("%w: no items to commit", ErrInvalidCommitItems)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for productID, quantity := range quantities {
+		// Lock the reservation row so concurrent commits of the same item serialize
+		var id string
+		var remaining int32
+		query := `
+			SELECT id, quantity - committed_quantity
+			FROM reservations
+			WHERE reservation_id = $1 AND product_id = $2 AND status = 'active'
+			FOR UPDATE
+		`
+
+		err := tx.QueryRowContext(ctx, query, reservationID, productID).Scan(&id, &remaining)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: no active reservation of product %s in %s", ErrReservationNotFound, productID, reservationID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get reservation: %w", err)
+		}
+
+		if quantity > remaining {
+			return fmt.Errorf("%w: product %s has %d reserved, %d requested", ErrOverCommit, productID, remaining, quantity)
+		}
+
+		inventoryQuery := `
+			UPDATE inventory
+			SET reserved_quantity = reserved_quantity - $1,
+			    total_quantity = total_quantity - $1,
+			    version = version + 1
+			WHERE product_id = $2
+		`
+		if _, err := tx.ExecContext(ctx, inventoryQuery, quantity, productID); err != nil {
+			return fmt.Errorf("failed to update inventory: %w", err)
+		}
+
+		reservationQuery := `
+			UPDATE reservations
+			SET committed_quantity = committed_quantity + $1,
+			    status = CASE WHEN committed_quantity + $1 = quantity THEN 'committed' ELSE status END
+			WHERE id = $2
+		`
+		if _, err := tx.ExecContext(ctx, reservationQuery, quantity, id); err != nil {
+			return fmt.Errorf("failed to update reservation: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	productIDs := make([]string, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	s.publishChanges(ctx, productIDs...)
+
+	logger.FromContext(ctx).Info("reservation partially committed",
+		zap.String("reservation_id", reservationID),
+		zap.Int("items_count", len(quantities)),
+	)
+	return nil
+}
+
 func (s *InventoryService) updateReservationStatus(
 	ctx context.Context,
 	reservationID string,
@@ -186,9 +379,10 @@ func (s *InventoryService) updateReservationStatus(
 	defer func() { _ = tx.Rollback() }()
 
 	query := `
-		SELECT product_id, quantity
+		SELECT product_id, quantity - committed_quantity
 		FROM reservations
 		WHERE reservation_id = $1 AND status = 'active'
+		FOR UPDATE
 	`
 
 	rows, err := tx.QueryContext(ctx, query, reservationID)
@@ -208,7 +402,7 @@ func (s *InventoryService) updateReservationStatus(
 	_ = rows.Close()
 
 	if len(items) == 0 {
-		return fmt.Errorf("no active reservations found for %s", reservationID)
+		return fmt.Errorf("%w: no active reservations for %s", ErrReservationNotFound, reservationID)
 	}
 
 	for _, item := range items {
@@ -218,9 +412,11 @@ func (s *InventoryService) updateReservationStatus(
 		}
 	}
 
+	// Committing takes whatever partial commits left over
 	statusQuery := `
 		UPDATE reservations
-		SET status = $1
+		SET status = $1,
+		    committed_quantity = CASE WHEN $1 = 'committed' THEN quantity ELSE committed_quantity END
 		WHERE reservation_id = $2 AND status = 'active'
 	`
 
@@ -231,56 +427,152 @@ func (s *InventoryService) updateReservationStatus(
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	s.publishChanges(ctx, reservationProductIDs(items)...)
 
-	s.logger.Info("reservation updated",
+	logger.FromContext(ctx).Info("reservation updated",
 		zap.String("reservation_id", reservationID),
 		zap.String("status", newStatus),
 	)
 	return nil
 }
 
-// GetInventory retrieves inventory for a product
-func (s *InventoryService) GetInventory(ctx context.Context, productID string) (*Inventory, error) {
+// publishChanges announces committed inventory changes to watchers
+func (s *InventoryService) publishChanges(ctx context.Context, productIDs ...string) {
+	if s.changes != nil {
+		s.changes.Publish(ctx, productIDs...)
+	}
+}
+
+func reservationProductIDs(items []ReservationItem) []string {
+	productIDs := make([]string, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+	return productIDs
+}
+
+// GetReservation returns every item of a reservation regardless of status
+func (s *InventoryService) GetReservation(ctx context.Context, reservationID string) ([]*Reservation, error) {
 	query := `
-		SELECT product_id, available_quantity, reserved_quantity, total_quantity, version, updated_at
-		FROM inventory
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE reservation_id = $1
+		ORDER BY product_id
+	`
+
+	reservations, err := s.queryReservations(ctx, query, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(reservations) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrReservationNotFound, reservationID)
+	}
+
+	return reservations, nil
+}
+
+// ListReservationsForProduct lists a product's reservations newest first.
+// status may be empty, "active" (not yet expired), "expired" (active but past
+// expiry, awaiting cleanup), "committed" or "released".
+func (s *InventoryService) ListReservationsForProduct(ctx context.Context, productID, status string, limit int, cursor string) ([]*Reservation, string, error) {
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
 		WHERE product_id = $1
 	`
 
-	var inventory Inventory
-	err := s.db.QueryRowContext(ctx, query, productID).Scan(
-		&inventory.ProductID,
-		&inventory.AvailableQuantity,
-		&inventory.ReservedQuantity,
-		&inventory.TotalQuantity,
-		&inventory.Version,
-		&inventory.UpdatedAt,
-	)
+	args := []interface{}{productID}
+	argIdx := 2
+
+	switch status {
+	case "":
+	case "active":
+		query += " AND status = 'active' AND (expires_at IS NULL OR expires_at >= CURRENT_TIMESTAMP)"
+	case "expired":
+		query += " AND status = 'active' AND expires_at < CURRENT_TIMESTAMP"
+	case "committed", "released":
+		query += fmt.Sprintf(" AND status = $%d", argIdx)
+		args = append(args, status)
+		argIdx++
+	default:
+		return nil, "", fmt.Errorf("%w: %q", ErrInvalidReservationStatus, status)
+	}
 
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("inventory not found")
+	if cursor != "" {
+		query += fmt.Sprintf(" AND (created_at, id) < (SELECT created_at, id FROM reservations WHERE id = $%d)", argIdx)
+		args = append(args, cursor)
+		argIdx++
 	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+	query += fmt.Sprintf(" LIMIT $%d", argIdx)
+	args = append(args, limit+1)
+
+	reservations, err := s.queryReservations(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get inventory: %w", err)
+		return nil, "", err
 	}
 
-	return &inventory, nil
+	var nextCursor string
+	if len(reservations) > limit {
+		reservations = reservations[:limit]
+		nextCursor = reservations[limit-1].ID
+	}
+
+	return reservations, nextCursor, nil
 }
 
-// AdjustInventory adjusts inventory (for restocking, damage, etc.)
-func (s *InventoryService) AdjustInventory(ctx context.Context, productID string, delta int32, reason string) (*Inventory, error) {
+const reservationColumns = `id, reservation_id, product_id, quantity, committed_quantity, status, expires_at, created_at, updated_at`
+
+func (s *InventoryService) queryReservations(ctx context.Context, query string, args ...interface{}) ([]*Reservation, error) {
+	spanCtx, span := database.StartSpan(ctx, "inventory.query_reservations", query)
+	rows, err := s.db.QueryContext(spanCtx, database.Annotate(spanCtx, query), args...)
+	database.EndSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reservations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var reservations []*Reservation
+	for rows.Next() {
+		var r Reservation
+		var expiresAt sql.NullTime
+		if err := rows.Scan(
+			&r.ID,
+			&r.ReservationID,
+			&r.ProductID,
+			&r.Quantity,
+			&r.CommittedQuantity,
+			&r.Status,
+			&expiresAt,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		if expiresAt.Valid {
+			r.ExpiresAt = expiresAt.Time
+		}
+		reservations = append(reservations, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reservations: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// GetInventory retrieves inventory for a product
+func (s *InventoryService) GetInventory(ctx context.Context, productID string) (*Inventory, error) {
 	query := `
-		INSERT INTO inventory (product_id, available_quantity, total_quantity)
-		VALUES ($1, $2, $2)
-		ON CONFLICT (product_id) DO UPDATE
-		SET available_quantity = inventory.available_quantity + $2,
-		    total_quantity = inventory.total_quantity + $2,
-		    version = inventory.version + 1
-		RETURNING product_id, available_quantity, reserved_quantity, total_quantity, version, updated_at
+		SELECT product_id, available_quantity, reserved_quantity, total_quantity, version, updated_at
+		FROM inventory
+		WHERE product_id = $1
 	`
 
 	var inventory Inventory
-	err := s.db.QueryRowContext(ctx, query, productID, delta).Scan(
+	spanCtx, span := database.StartSpan(ctx, "inventory.get", query)
+	err := s.db.QueryRowContext(spanCtx, database.Annotate(spanCtx, query), productID).Scan(
 		&inventory.ProductID,
 		&inventory.AvailableQuantity,
 		&inventory.ReservedQuantity,
@@ -288,27 +580,35 @@ func (s *InventoryService) AdjustInventory(ctx context.Context, productID string
 		&inventory.Version,
 		&inventory.UpdatedAt,
 	)
+	database.EndSpan(span, err)
 
+	if err == sql.ErrNoRows {
+		return nil, ErrInventoryNotFound
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to adjust inventory: %w", err)
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
 	}
 
-	s.logger.Info("inventory adjusted",
-		zap.String("product_id", productID),
-		zap.Int32("delta", delta),
-		zap.String("reason", reason),
-	)
-
 	return &inventory, nil
 }
 
-// CleanupExpiredReservations cleans up expired reservations
-func (s *InventoryService) CleanupExpiredReservations(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, "SELECT cleanup_expired_reservations()")
+// CleanupExpiredReservations releases expired reservations and returns how
+// many were released
+func (s *InventoryService) CleanupExpiredReservations(ctx context.Context) (int, error) {
+	var released int
+	err := s.db.QueryRowContext(ctx, "SELECT cleanup_expired_reservations()").Scan(&released)
 	if err != nil {
-		return fmt.Errorf("failed to cleanup expired reservations: %w", err)
+		return 0, fmt.Errorf("failed to cleanup expired reservations: %w", err)
 	}
+	reservationsExpired.Add(float64(released))
 
-	s.logger.Info("expired reservations cleaned up")
-	return nil
+	var active int
+	err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM reservations WHERE status = 'active'").Scan(&active)
+	if err != nil {
+		return released, fmt.Errorf("failed to count active reservations: %w", err)
+	}
+	reservationsActive.Set(float64(active))
+
+	logger.FromContext(ctx).Info("expired reservations cleaned up", zap.Int("released", released))
+	return released, nil
 }
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	reservationsExpired = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "coldy",
+		Subsystem: "inventory",
+		Name:      "reservations_expired_total",
+		Help:      "Total number of expired reservations released by cleanup",
+	})
+	reservationsActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "coldy",
+		Subsystem: "inventory",
+		Name:      "reservations_active",
+		Help:      "Number of active reservations as of the last cleanup",
+	})
+)
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// seedReservations stores one reservation of product-1 in every state, oldest
+// first, plus a second item of the active reservation
+func seedReservations(fake *fakeDB) {
+	for _, r := range []Reservation{
+		{ID: "res-active", ReservationID: "order-active", ProductID: "product-1", Quantity: 2, Status: "active", ExpiresAt: fake.now.Add(time.Hour)},
+		{ID: "res-active-2", ReservationID: "order-active", ProductID: "product-0", Quantity: 1, Status: "active", ExpiresAt: fake.now.Add(time.Hour)},
+		{ID: "res-expired", ReservationID: "order-expired", ProductID: "product-1", Quantity: 3, Status: "active", ExpiresAt: fake.now.Add(-time.Hour)},
+		{ID: "res-committed", ReservationID: "order-committed", ProductID: "product-1", Quantity: 4, CommittedQuantity: 4, Status: "committed", ExpiresAt: fake.now.Add(time.Hour)},
+		{ID: "res-released", ReservationID: "order-released", ProductID: "product-1", Quantity: 5, Status: "released", ExpiresAt: fake.now.Add(-time.Hour)},
+	} {
+		fake.addReservation(r)
+	}
+}
+
+func reservationIDs(reservations []*Reservation) []string {
+	ids := make([]string, len(reservations))
+	for i, r := range reservations {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestGetReservation(t *testing.T) {
+	db, fake := newFakeDB(t)
+	seedReservations(fake)
+	s := NewInventoryService(db, nil, zap.NewNop())
+
+	items, err := s.GetReservation(context.Background(), "order-active")
+	if err != nil {
+		t.Fatalf("GetReservation() error = %v", err)
+	}
+	if got, want := reservationIDs(items), []string{"res-active-2", "res-active"}; !slices.Equal(got, want) {
+		t.Errorf("items = %v, want %v ordered by product", got, want)
+	}
+	if items[1].Quantity != 2 || items[1].Status != "active" || items[1].ExpiresAt.IsZero() {
+		t.Errorf("item = %+v, want the stored reservation", items[1])
+	}
+
+	// Finished reservations are still returned
+	items, err = s.GetReservation(context.Background(), "order-committed")
+	if err != nil {
+		t.Fatalf("GetReservation() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Status != "committed" || items[0].CommittedQuantity != 4 {
+		t.Errorf("items = %+v, want the committed reservation", items)
+	}
+
+	if _, err := s.GetReservation(context.Background(), "order-unknown"); !errors.Is(err, ErrReservationNotFound) {
+		t.Errorf("GetReservation() error = %v, want ErrReservationNotFound", err)
+	}
+}
+
+func TestListReservationsForProductFiltersByStatus(t *testing.T) {
+	tests := map[string]struct {
+		status string
+		want   []string
+	}{
+		"all newest first": {status: "", want: []string{"res-released", "res-committed", "res-expired", "res-active"}},
+		"active":           {status: "active", want: []string{"res-active"}},
+		"expired":          {status: "expired", want: []string{"res-expired"}},
+		"committed":        {status: "committed", want: []string{"res-committed"}},
+		"released":         {status: "released", want: []string{"res-released"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			db, fake := newFakeDB(t)
+			seedReservations(fake)
+			s := NewInventoryService(db, nil, zap.NewNop())
+
+			reservations, next, err := s.ListReservationsForProduct(context.Background(), "product-1", tt.status, 10, "")
+			if err != nil {
+				t.Fatalf("ListReservationsForProduct() error = %v", err)
+			}
+			if got := reservationIDs(reservations); !slices.Equal(got, tt.want) {
+				t.Errorf("reservations = %v, want %v", got, tt.want)
+			}
+			if next != "" {
+				t.Errorf("next cursor = %q, want none", next)
+			}
+		})
+	}
+}
+
+func TestListReservationsForProductRejectsUnknownStatus(t *testing.T) {
+	db, _ := newFakeDB(t)
+	s := NewInventoryService(db, nil, zap.NewNop())
+
+	_, _, err := s.ListReservationsForProduct(context.Background(), "product-1", "pending", 10, "")
+	if !errors.Is(err, ErrInvalidReservationStatus) {
+		t.Errorf("ListReservationsForProduct() error = %v, want ErrInvalidReservationStatus", err)
+	}
+}
+
+func TestListReservationsForProductPages(t *testing.T) {
+	db, fake := newFakeDB(t)
+	seedReservations(fake)
+	s := NewInventoryService(db, nil, zap.NewNop())
+
+	var got []string
+	cursor := ""
+	for page := 0; ; page++ {
+		if page > 2 {
+			t.Fatal("pagination did not end")
+		}
+		reservations, next, err := s.ListReservationsForProduct(context.Background(), "product-1", "", 3, cursor)
+		if err != nil {
+			t.Fatalf("ListReservationsForProduct() error = %v", err)
+		}
+		got = append(got, reservationIDs(reservations)...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	want := []string{"res-released", "res-committed", "res-expired", "res-active"}
+	if !slices.Equal(got, want) {
+		t.Errorf("paged reservations = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/database"
+	"github.com/mumumio1/coldy/pkg/errs"
+)
+
+// Timeline event kinds
+const (
+	TimelineAdjustment = "adjustment"
+	TimelineReserved   = "reserved"
+	TimelineCommitted  = "committed"
+	TimelineReleased   = "released"
+	TimelineExpired    = "expired"
+)
+
+// ErrInvalidTimelineCursor is returned when a timeline cursor can't be decoded
+var ErrInvalidTimelineCursor = errs.InvalidArgument("INVALID_TIMELINE_CURSOR", "invalid timeline cursor")
+
+// TimelineEvent is one stock movement of a product
+type TimelineEvent struct {
+	Kind string
+	// Quantity is the signed delta of an adjustment, or the quantity a
+	// reservation event reserved, committed or returned
+	Quantity int32
+	// Reference is the reservation ID, or the batch ID of a bulk adjustment
+	Reference string
+	// Reason is the adjustment reason
+	Reason     string
+	OccurredAt time.Time
+	key        string
+}
+
+// timelineQuery merges ledger entries with reservation lifecycle events.
+// Reservations keep only their latest status, so a reservation contributes
+// its creation and, once finished, its commit or release at updated_at; a
+// release at or after expiry is reported as an expiration. Partial commits of
+// a still active reservation don't show up.
+const timelineQuery = `
+	SELECT occurred_at, event_key, kind, quantity, reference, reason FROM (
+		SELECT created_at AS occurred_at, 'l:' || id::text AS event_key, 'adjustment' AS kind,
+		       delta AS quantity, COALESCE(batch_id::text, '') AS reference, reason
+		FROM inventory_ledger
+		WHERE product_id = $1
+		UNION ALL
+		SELECT created_at, 'r:' || id::text, 'reserved', quantity, reservation_id, ''
+		FROM reservations
+		WHERE product_id = $1
+		UNION ALL
+		SELECT updated_at, 'f:' || id::text,
+		       CASE
+		           WHEN status = 'committed' THEN 'committed'
+		           WHEN expires_at IS NOT NULL AND expires_at <= updated_at THEN 'expired'
+		           ELSE 'released'
+		       END,
+		       CASE WHEN status = 'committed' THEN committed_quantity ELSE quantity - committed_quantity END,
+		       reservation_id, ''
+		FROM reservations
+		WHERE product_id = $1 AND status IN ('committed', 'released')
+	) events
+	WHERE occurred_at IS NOT NULL
+`
+
+// GetProductTimeline returns a product's adjustments and reservation events
+// oldest first within [from, to). Zero from/to leave that end open.
+func (s *InventoryService) GetProductTimeline(ctx context.Context, productID string, from, to time.Time, limit int, cursor string) ([]*TimelineEvent, string, error) {
+	query := timelineQuery
+	args := []interface{}{productID}
+	argIdx := 2
+
+	if !from.IsZero() {
+		query += fmt.Sprintf(" AND occurred_at >= $%d", argIdx)
+		args = append(args, from)
+		argIdx++
+	}
+	if !to.IsZero() {
+		query += fmt.Sprintf(" AND occurred_at < $%d", argIdx)
+		args = append(args, to)
+		argIdx++
+	}
+
+	if cursor != "" {
+		at, key, err := decodeTimelineCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		query += fmt.Sprintf(" AND (occurred_at, event_key) > ($%d, $%d)", argIdx, argIdx+1)
+		args = append(args, at, key)
+		argIdx += 2
+	}
+
+	query += fmt.Sprintf(" ORDER BY occurred_at, event_key LIMIT $%d", argIdx)
+	args = append(args, limit+1)
+
+	spanCtx, span := database.StartSpan(ctx, "inventory.product_timeline", query)
+	rows, err := s.db.QueryContext(spanCtx, database.Annotate(spanCtx, query), args...)
+	database.EndSpan(span, err)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query timeline: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*TimelineEvent
+	for rows.Next() {
+		var e TimelineEvent
+		if err := rows.Scan(&e.OccurredAt, &e.key, &e.Kind, &e.Quantity, &e.Reference, &e.Reason); err != nil {
+			return nil, "", fmt.Errorf("failed to scan timeline event: %w", err)
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to iterate timeline: %w", err)
+	}
+
+	var nextCursor string
+	if len(events) > limit {
+		events = events[:limit]
+		last := events[limit-1]
+		nextCursor = encodeTimelineCursor(last.OccurredAt, last.key)
+	}
+
+	return events, nextCursor, nil
+}
+
+// encodeTimelineCursor encodes the sort position of an event
+func encodeTimelineCursor(at time.Time, key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(at.UTC().Format(time.RFC3339Nano) + "|" + key))
+}
+
+func decodeTimelineCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidTimelineCursor
+	}
+	ts, key, ok := strings.Cut(string(raw), "|")
+	if !ok || key == "" {
+		return time.Time{}, "", ErrInvalidTimelineCursor
+	}
+	at, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidTimelineCursor
+	}
+	return at, key, nil
+}
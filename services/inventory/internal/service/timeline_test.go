@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// seedTimeline stores product-1's history at base+N seconds; two reservations
+// are created at the same instant to exercise the event key tie-break
+func seedTimeline(fake *fakeDB) time.Time {
+	base := fake.now
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+
+	fake.addLedgerEntry(ledgerEntry{ID: "ledger-1", ProductID: "product-1", Delta: 10, Reason: "restock", TotalAfter: 10, CreatedAt: at(1)})
+	fake.addLedgerEntry(ledgerEntry{ID: "ledger-2", ProductID: "product-1", Delta: -2, Reason: "damage", BatchID: "batch-1", TotalAfter: 5, CreatedAt: at(8)})
+	fake.addLedgerEntry(ledgerEntry{ID: "ledger-3", ProductID: "product-2", Delta: 4, TotalAfter: 4, CreatedAt: at(2)})
+	for _, r := range []Reservation{
+		{ID: "res-1", ReservationID: "order-1", ProductID: "product-1", Quantity: 3, CommittedQuantity: 3, Status: "committed", ExpiresAt: at(100), CreatedAt: at(2), UpdatedAt: at(5)},
+		{ID: "res-2", ReservationID: "order-2", ProductID: "product-1", Quantity: 2, Status: "released", ExpiresAt: at(100), CreatedAt: at(3), UpdatedAt: at(4)},
+		{ID: "res-3", ReservationID: "order-3", ProductID: "product-1", Quantity: 4, CommittedQuantity: 1, Status: "released", ExpiresAt: at(6), CreatedAt: at(3), UpdatedAt: at(7)},
+		{ID: "res-4", ReservationID: "order-4", ProductID: "product-1", Quantity: 1, Status: "active", ExpiresAt: at(100), CreatedAt: at(9), UpdatedAt: at(9)},
+	} {
+		fake.addReservation(r)
+	}
+	return base
+}
+
+// timelineEntry is the comparable part of a TimelineEvent
+type timelineEntry struct {
+	kind      string
+	quantity  int32
+	reference string
+	second    int
+}
+
+func timelineEntries(base time.Time, events []*TimelineEvent) []timelineEntry {
+	entries := make([]timelineEntry, len(events))
+	for i, e := range events {
+		entries[i] = timelineEntry{e.Kind, e.Quantity, e.Reference, int(e.OccurredAt.Sub(base) / time.Second)}
+	}
+	return entries
+}
+
+var wantTimeline = []timelineEntry{
+	{TimelineAdjustment, 10, "", 1},
+	{TimelineReserved, 3, "order-1", 2},
+	{TimelineReserved, 2, "order-2", 3},
+	{TimelineReserved, 4, "order-3", 3},
+	{TimelineReleased, 2, "order-2", 4},
+	{TimelineCommitted, 3, "order-1", 5},
+	{TimelineExpired, 3, "order-3", 7},
+	{TimelineAdjustment, -2, "batch-1", 8},
+	{TimelineReserved, 1, "order-4", 9},
+}
+
+func TestGetProductTimelineMergesEventsInOrder(t *testing.T) {
+	db, fake := newFakeDB(t)
+	base := seedTimeline(fake)
+	s := NewInventoryService(db, nil, zap.NewNop())
+
+	events, next, err := s.GetProductTimeline(context.Background(), "product-1", time.Time{}, time.Time{}, 20, "")
+	if err != nil {
+		t.Fatalf("GetProductTimeline() error = %v", err)
+	}
+	if got := timelineEntries(base, events); !slices.Equal(got, wantTimeline) {
+		t.Errorf("timeline =\n%v\nwant\n%v", got, wantTimeline)
+	}
+	if next != "" {
+		t.Errorf("next cursor = %q, want none", next)
+	}
+}
+
+func TestGetProductTimelinePagesAcrossTies(t *testing.T) {
+	db, fake := newFakeDB(t)
+	base := seedTimeline(fake)
+	s := NewInventoryService(db, nil, zap.NewNop())
+
+	// A page of 3 ends between the two reservations created at base+3
+	var got []timelineEntry
+	cursor := ""
+	for page := 0; ; page++ {
+		if page > 3 {
+			t.Fatal("pagination did not end")
+		}
+		events, next, err := s.GetProductTimeline(context.Background(), "product-1", time.Time{}, time.Time{}, 3, cursor)
+		if err != nil {
+			t.Fatalf("GetProductTimeline() error = %v", err)
+		}
+		got = append(got, timelineEntries(base, events)...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if !slices.Equal(got, wantTimeline) {
+		t.Errorf("paged timeline =\n%v\nwant\n%v", got, wantTimeline)
+	}
+}
+
+func TestGetProductTimelineWindow(t *testing.T) {
+	db, fake := newFakeDB(t)
+	base := seedTimeline(fake)
+	s := NewInventoryService(db, nil, zap.NewNop())
+
+	// from is inclusive and to exclusive
+	events, _, err := s.GetProductTimeline(context.Background(), "product-1", base.Add(3*time.Second), base.Add(7*time.Second), 20, "")
+	if err != nil {
+		t.Fatalf("GetProductTimeline() error = %v", err)
+	}
+	if got, want := timelineEntries(base, events), wantTimeline[2:6]; !slices.Equal(got, want) {
+		t.Errorf("timeline =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestGetProductTimelineRejectsInvalidCursor(t *testing.T) {
+	db, _ := newFakeDB(t)
+	s := NewInventoryService(db, nil, zap.NewNop())
+
+	for _, cursor := range []string{"not base64!", encodeTimelineCursor(time.Now(), "")[:4]} {
+		_, _, err := s.GetProductTimeline(context.Background(), "product-1", time.Time{}, time.Time{}, 20, cursor)
+		if !errors.Is(err, ErrInvalidTimelineCursor) {
+			t.Errorf("GetProductTimeline(%q) error = %v, want ErrInvalidTimelineCursor", cursor, err)
+		}
+	}
+}
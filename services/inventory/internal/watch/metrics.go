@@ -0,0 +1,21 @@
+package watch
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	watchersActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "coldy",
+		Subsystem: "inventory",
+		Name:      "watchers_active",
+		Help:      "Number of open WatchInventory streams",
+	})
+	updatesCoalesced = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "coldy",
+		Subsystem: "inventory",
+		Name:      "watch_updates_coalesced_total",
+		Help:      "Total number of inventory changes merged into a pending update of a slow stream",
+	})
+)
@@ -0,0 +1,180 @@
+// Package watch fans out inventory changes to WatchInventory streams. Every
+// replica publishes the IDs of products whose inventory changed to a Redis
+// channel and delivers the IDs it receives to its local watchers, so a stream
+// hears about writes made on any replica.
+package watch
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// DefaultChannel is the Redis channel inventory changes are published on
+const DefaultChannel = "inventory:changes"
+
+// Hub publishes inventory changes and delivers them to local watchers
+type Hub struct {
+	redis   *redis.Client
+	channel string
+
+	mu       sync.Mutex
+	watchers map[string]map[*Watcher]struct{}
+}
+
+// NewHub creates a hub publishing on channel; an empty channel uses
+// DefaultChannel
+func NewHub(client *redis.Client, channel string) *Hub {
+	if channel == "" {
+		channel = DefaultChannel
+	}
+	return &Hub{
+		redis:    client,
+		channel:  channel,
+		watchers: make(map[string]map[*Watcher]struct{}),
+	}
+}
+
+// Publish announces that the inventory of productIDs changed. It is best
+// effort: a failure is logged and watchers miss the update until the
+// product's next change.
+func (h *Hub) Publish(ctx context.Context, productIDs ...string) {
+	if len(productIDs) == 0 {
+		return
+	}
+	// Product IDs are UUIDs, so a comma never appears inside one
+	if err := h.redis.Publish(ctx, h.channel, strings.Join(productIDs, ",")).Err(); err != nil {
+		logger.FromContext(ctx).Warn("failed to publish inventory change",
+			zap.Strings("product_ids", productIDs),
+			zap.Error(err),
+		)
+	}
+}
+
+// Run receives published changes and notifies local watchers until ctx is
+// done. The Redis client reconnects the subscription on its own.
+func (h *Hub) Run(ctx context.Context) error {
+	sub := h.redis.Subscribe(ctx, h.channel)
+	defer func() { _ = sub.Close() }()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			h.notify(strings.Split(msg.Payload, ","))
+		}
+	}
+}
+
+// Watch starts watching productIDs. The watcher must be closed when done.
+func (h *Hub) Watch(productIDs []string) *Watcher {
+	w := &Watcher{
+		hub:        h,
+		productIDs: productIDs,
+		ready:      make(chan struct{}, 1),
+		changed:    make(map[string]struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range productIDs {
+		if h.watchers[id] == nil {
+			h.watchers[id] = make(map[*Watcher]struct{})
+		}
+		h.watchers[id][w] = struct{}{}
+	}
+	watchersActive.Inc()
+	return w
+}
+
+func (h *Hub) notify(productIDs []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range productIDs {
+		for w := range h.watchers[id] {
+			w.mark(id)
+		}
+	}
+}
+
+func (h *Hub) unwatch(w *Watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range w.productIDs {
+		delete(h.watchers[id], w)
+		if len(h.watchers[id]) == 0 {
+			delete(h.watchers, id)
+		}
+	}
+	watchersActive.Dec()
+}
+
+// Watcher collects the watched products whose inventory changed since they
+// were last taken. A product that changes several times before the stream
+// catches up is reported once, so a slow stream only ever sends the latest
+// inventory instead of falling behind.
+type Watcher struct {
+	hub        *Hub
+	productIDs []string
+	ready      chan struct{}
+
+	mu      sync.Mutex
+	changed map[string]struct{}
+	closed  bool
+}
+
+// Ready receives a value when changes are waiting to be taken
+func (w *Watcher) Ready() <-chan struct{} {
+	return w.ready
+}
+
+// Take returns the products that changed and clears them
+func (w *Watcher) Take() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	productIDs := make([]string, 0, len(w.changed))
+	for id := range w.changed {
+		productIDs = append(productIDs, id)
+	}
+	clear(w.changed)
+	return productIDs
+}
+
+// Close stops watching
+func (w *Watcher) Close() {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	w.hub.unwatch(w)
+}
+
+func (w *Watcher) mark(productID string) {
+	w.mu.Lock()
+	_, pending := w.changed[productID]
+	w.changed[productID] = struct{}{}
+	w.mu.Unlock()
+
+	if pending {
+		updatesCoalesced.Inc()
+		return
+	}
+	select {
+	case w.ready <- struct{}{}:
+	default:
+	}
+}
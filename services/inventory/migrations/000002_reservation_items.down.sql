@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_reservations_product_created_at;
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_reservation_id_product_id_key;
+ALTER TABLE reservations ADD CONSTRAINT reservations_reservation_id_key UNIQUE (reservation_id);
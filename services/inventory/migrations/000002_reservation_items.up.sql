@@ -0,0 +1,6 @@
+-- A reservation holds one row per product, so reservation_id alone cannot be unique
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_reservation_id_key;
+ALTER TABLE reservations ADD CONSTRAINT reservations_reservation_id_product_id_key UNIQUE (reservation_id, product_id);
+
+-- Keyset pagination of a product's reservations
+CREATE INDEX IF NOT EXISTS idx_reservations_product_created_at ON reservations(product_id, created_at DESC, id DESC);
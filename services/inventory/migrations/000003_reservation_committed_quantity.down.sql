@@ -0,0 +1,28 @@
+CREATE OR REPLACE FUNCTION cleanup_expired_reservations()
+RETURNS void AS $$
+DECLARE
+    expired_reservation RECORD;
+BEGIN
+    FOR expired_reservation IN
+        SELECT reservation_id, product_id, quantity
+        FROM reservations
+        WHERE status = 'active'
+          AND expires_at < CURRENT_TIMESTAMP
+    LOOP
+        -- Release the expired reservation
+        UPDATE inventory
+        SET available_quantity = available_quantity + expired_reservation.quantity,
+            reserved_quantity = reserved_quantity - expired_reservation.quantity,
+            version = version + 1
+        WHERE product_id = expired_reservation.product_id;
+
+        -- Mark reservation as released
+        UPDATE reservations
+        SET status = 'released'
+        WHERE reservation_id = expired_reservation.reservation_id;
+    END LOOP;
+END;
+$$ language 'plpgsql';
+
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_committed_quantity_check;
+ALTER TABLE reservations DROP COLUMN IF EXISTS committed_quantity;
@@ -0,0 +1,30 @@
+-- Quantity of a reservation item committed so far; the remainder stays reserved
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS committed_quantity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reservations ADD CONSTRAINT reservations_committed_quantity_check
+    CHECK (committed_quantity >= 0 AND committed_quantity <= quantity);
+
+-- Release only the uncommitted remainder of expired reservations
+CREATE OR REPLACE FUNCTION cleanup_expired_reservations()
+RETURNS void AS $$
+DECLARE
+    expired_reservation RECORD;
+BEGIN
+    FOR expired_reservation IN
+        SELECT id, product_id, quantity - committed_quantity AS remaining
+        FROM reservations
+        WHERE status = 'active'
+          AND expires_at < CURRENT_TIMESTAMP
+        FOR UPDATE
+    LOOP
+        UPDATE inventory
+        SET available_quantity = available_quantity + expired_reservation.remaining,
+            reserved_quantity = reserved_quantity - expired_reservation.remaining,
+            version = version + 1
+        WHERE product_id = expired_reservation.product_id;
+
+        UPDATE reservations
+        SET status = 'released'
+        WHERE id = expired_reservation.id;
+    END LOOP;
+END;
+$$ language 'plpgsql';
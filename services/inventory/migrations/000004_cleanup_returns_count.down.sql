@@ -0,0 +1,26 @@
+DROP FUNCTION IF EXISTS cleanup_expired_reservations();
+
+CREATE FUNCTION cleanup_expired_reservations()
+RETURNS void AS $$
+DECLARE
+    expired_reservation RECORD;
+BEGIN
+    FOR expired_reservation IN
+        SELECT id, product_id, quantity - committed_quantity AS remaining
+        FROM reservations
+        WHERE status = 'active'
+          AND expires_at < CURRENT_TIMESTAMP
+        FOR UPDATE
+    LOOP
+        UPDATE inventory
+        SET available_quantity = available_quantity + expired_reservation.remaining,
+            reserved_quantity = reserved_quantity - expired_reservation.remaining,
+            version = version + 1
+        WHERE product_id = expired_reservation.product_id;
+
+        UPDATE reservations
+        SET status = 'released'
+        WHERE id = expired_reservation.id;
+    END LOOP;
+END;
+$$ language 'plpgsql';
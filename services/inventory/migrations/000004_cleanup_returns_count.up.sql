@@ -0,0 +1,33 @@
+-- Report how many expired reservations were released; the return type
+-- changes, so the function has to be dropped first
+DROP FUNCTION IF EXISTS cleanup_expired_reservations();
+
+CREATE FUNCTION cleanup_expired_reservations()
+RETURNS integer AS $$
+DECLARE
+    expired_reservation RECORD;
+    released integer := 0;
+BEGIN
+    FOR expired_reservation IN
+        SELECT id, product_id, quantity - committed_quantity AS remaining
+        FROM reservations
+        WHERE status = 'active'
+          AND expires_at < CURRENT_TIMESTAMP
+        FOR UPDATE
+    LOOP
+        UPDATE inventory
+        SET available_quantity = available_quantity + expired_reservation.remaining,
+            reserved_quantity = reserved_quantity - expired_reservation.remaining,
+            version = version + 1
+        WHERE product_id = expired_reservation.product_id;
+
+        UPDATE reservations
+        SET status = 'released'
+        WHERE id = expired_reservation.id;
+
+        released := released + 1;
+    END LOOP;
+
+    RETURN released;
+END;
+$$ language 'plpgsql';
@@ -0,0 +1,12 @@
+-- Append-only record of inventory adjustments (restocks, damage, corrections)
+CREATE TABLE IF NOT EXISTS inventory_ledger (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL,
+    delta INTEGER NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    batch_id UUID, -- Groups the entries of one bulk adjustment
+    total_after INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_ledger_product_created_at ON inventory_ledger(product_id, created_at DESC, id DESC);
@@ -4,12 +4,20 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
+	"strings"
+	"time"
 
-	"cloud.google.com/go/pubsub"
+	"github.com/mumumio1/coldy/pkg/cache"
+	"github.com/mumumio1/coldy/pkg/config"
+	"github.com/mumumio1/coldy/pkg/database"
 	"github.com/mumumio1/coldy/pkg/logger"
 	pubsubpkg "github.com/mumumio1/coldy/pkg/pubsub"
+	"github.com/mumumio1/coldy/pkg/shutdown"
+	"github.com/mumumio1/coldy/services/notification/internal/handler"
+	"github.com/mumumio1/coldy/services/notification/internal/notifier"
+	"github.com/mumumio1/coldy/services/notification/internal/templates"
+	"github.com/mumumio1/coldy/services/notification/internal/webhooks"
 	"go.uber.org/zap"
 )
 
@@ -29,66 +37,224 @@ func run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	log, err := logger.NewLogger(serviceName, getEnv("ENV", "development"))
+	log, err := logger.NewLogger(serviceName, config.Getenv("ENV", "development"))
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	defer func() { _ = log.Sync() }()
 
+	// Background work started from ctx logs with the service logger
+	ctx = logger.WithLogger(ctx, log)
+
 	log.Info("starting notification service", zap.String("version", version))
 
-	projectID := getEnv("GCP_PROJECT_ID", "coldy-local")
+	// Initialize Redis cache for delivery deduplication
+	redisConfig := cache.Config{
+		Addr:         config.Getenv("REDIS_ADDR", "localhost:6379"),
+		Password:     config.Getenv("REDIS_PASSWORD", ""),
+		DB:           config.GetenvInt("REDIS_DB", 0),
+		PoolSize:     10,
+		MinIdleConns: 2,
+		DialTimeout:  5 * time.Second,
+		ReadTimeout:  3 * time.Second,
+		WriteTimeout: 3 * time.Second,
+	}
+
+	redisCache, err := cache.NewRedisCache(ctx, redisConfig, log)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	defer func() { _ = redisCache.Close() }()
+
+	dedup := handler.DedupMiddleware(redisCache, handler.DefaultDedupTTL, log)
+	errorPolicy := pubsubpkg.WithErrorPolicy(log)
+
+	// Parse templates up front so a broken template fails startup, not delivery
+	renderer, err := templates.New(config.Getenv("NOTIFY_DEFAULT_LOCALE", templates.DefaultLocale))
+	if err != nil {
+		return fmt.Errorf("failed to load notification templates: %w", err)
+	}
+
+	router, err := buildRouter(handler.NewDeliveryLog(redisCache, handler.DefaultDedupTTL), log)
+	if err != nil {
+		return fmt.Errorf("failed to configure notifiers: %w", err)
+	}
+
+	// Subscribe to events
+	subscriptions := map[string]string{
+		"order-created-sub":     "order.created",
+		"payment-succeeded-sub": "payment.succeeded",
+		"payment-failed-sub":    "payment.failed",
+	}
+	webhooksEnabled := config.Getenv("WEBHOOK_DELIVERY_ENABLED", "false") == "true"
+
+	// Create missing topics and subscriptions, e.g. in a fresh project
+	projectID := config.Getenv("GCP_PROJECT_ID", "coldy-local")
+	if config.Getenv("PUBSUB_BOOTSTRAP", "false") == "true" {
+		if err := pubsubpkg.RunBootstrap(ctx, projectID, bootstrapSpec(subscriptions, webhooksEnabled)); err != nil {
+			return fmt.Errorf("failed to bootstrap pubsub: %w", err)
+		}
+	}
+
 	subscriber, err := pubsubpkg.NewSubscriber(ctx, projectID, log)
 	if err != nil {
 		return fmt.Errorf("failed to create subscriber: %w", err)
 	}
 	defer func() { _ = subscriber.Close() }()
 
-	// Subscribe to events
-	go func() {
-		if err := subscriber.Subscribe(ctx, "order-created-sub", handleOrderCreated(log)); err != nil {
-			log.Error("order created subscription failed", zap.Error(err))
+	// Route messages to typed handlers by their event_type attribute
+	dispatcher := pubsubpkg.NewDispatcher(pubsubpkg.AckUnknown, log)
+	handler.RegisterHandlers(dispatcher, renderer, router, log)
+
+	running := make([]*pubsubpkg.Subscription, 0, len(subscriptions))
+	for subID := range subscriptions {
+		running = append(running, subscriber.StartSubscription(ctx, subID, errorPolicy(dedup(dispatcher.HandleMessage))))
+	}
+
+	// Deliver order and payment events to registered customer endpoints.
+	// Delivery attempts are recorded, which also dedups redeliveries.
+	if webhooksEnabled {
+		dbConfig := database.Config{
+			Host:            config.Getenv("DB_HOST", "localhost"),
+			Port:            5432,
+			User:            config.Getenv("DB_USER", "coldy"),
+			Password:        config.Getenv("DB_PASSWORD", "coldy123"),
+			Database:        config.Getenv("DB_NAME", "coldy"),
+			SSLMode:         config.Getenv("DB_SSLMODE", "disable"),
+			MaxOpenConns:    10,
+			MaxIdleConns:    2,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
 		}
-	}()
 
-	go func() {
-		if err := subscriber.Subscribe(ctx, "payment-succeeded-sub", handlePaymentSucceeded(log)); err != nil {
-			log.Error("payment succeeded subscription failed", zap.Error(err))
+		db, err := database.NewPostgresDB(ctx, dbConfig, log)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
 		}
-	}()
+		defer func() { _ = db.Close() }()
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
+		deliverer := webhooks.NewDeliverer(webhooks.NewRepository(db), webhooks.DefaultConfig(), log)
+		for _, eventType := range webhooks.EventTypes {
+			subID := webhookSubscription(eventType)
+			running = append(running, subscriber.StartSubscription(ctx, subID, errorPolicy(deliverer.Handler(eventType))))
+		}
+	}
 
-	log.Info("shutting down...")
+	// Nothing routes traffic here, so there is no drain by default
+	shutdowner := shutdown.New(shutdown.Config{
+		DrainDelay: config.GetenvDuration("SHUTDOWN_DRAIN_DELAY", 0),
+		Timeout:    config.GetenvDuration("SHUTDOWN_TIMEOUT", shutdown.DefaultTimeout),
+	}, log)
+	shutdowner.OnShutdown("subscriptions", func(ctx context.Context) error {
+		// Let in-flight messages finish before the subscriber is closed
+		for _, sub := range running {
+			sub.Stop()
+		}
+		return nil
+	})
+	if err := shutdowner.Wait(ctx); err != nil {
+		log.Warn("shutdown did not complete cleanly", zap.Error(err))
+	}
 	return nil
 }
 
-func handleOrderCreated(log *zap.Logger) pubsubpkg.MessageHandler {
-	return func(ctx context.Context, msg *pubsub.Message) error {
-		log.Info("order created notification",
-			zap.String("message_id", msg.ID),
-			zap.ByteString("data", msg.Data),
-		)
-		// Send email/webhook/slack notification
-		return nil
+// bootstrapSpec declares the topics and dead-lettering subscriptions this service consumes
+func bootstrapSpec(subscriptions map[string]string, webhooksEnabled bool) []pubsubpkg.TopicSpec {
+	deadLetterTopic := config.Getenv("PUBSUB_DEAD_LETTER_TOPIC", "notification.dead-letter")
+	subscription := func(name string) pubsubpkg.SubscriptionSpec {
+		return pubsubpkg.SubscriptionSpec{
+			Name:                name,
+			DeadLetterTopic:     deadLetterTopic,
+			MaxDeliveryAttempts: pubsubpkg.DefaultMaxDeliveryAttempts,
+			MinBackoff:          10 * time.Second,
+			MaxBackoff:          10 * time.Minute,
+		}
+	}
+
+	byTopic := make(map[string][]pubsubpkg.SubscriptionSpec)
+	for subID, eventType := range subscriptions {
+		byTopic[eventType] = append(byTopic[eventType], subscription(subID))
+	}
+	if webhooksEnabled {
+		for _, eventType := range webhooks.EventTypes {
+			byTopic[eventType] = append(byTopic[eventType], subscription(webhookSubscription(eventType)))
+		}
+	}
+
+	spec := make([]pubsubpkg.TopicSpec, 0, len(byTopic))
+	for topic, subs := range byTopic {
+		spec = append(spec, pubsubpkg.TopicSpec{Name: topic, Subscriptions: subs})
 	}
+	return spec
 }
 
-func handlePaymentSucceeded(log *zap.Logger) pubsubpkg.MessageHandler {
-	return func(ctx context.Context, msg *pubsub.Message) error {
-		log.Info("payment succeeded notification",
-			zap.String("message_id", msg.ID),
-			zap.ByteString("data", msg.Data),
+// webhookSubscription returns the subscription webhook deliveries of an event
+// type are received from
+func webhookSubscription(eventType string) string {
+	return "webhooks-" + strings.ReplaceAll(eventType, ".", "-") + "-sub"
+}
+
+// buildRouter constructs the configured notifiers and routes them per event type,
+// tracking deliveries per notifier in deliveries.
+// NOTIFY_ROUTES has the form "order.created=email,slack;payment.succeeded=webhook".
+func buildRouter(deliveries notifier.DeliveryLog, log *zap.Logger) (*notifier.Router, error) {
+	available := make(map[string]notifier.Notifier)
+
+	if host := config.Getenv("SMTP_HOST", ""); host != "" {
+		port, err := strconv.Atoi(config.Getenv("SMTP_PORT", "587"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT: %w", err)
+		}
+		available["email"] = notifier.NewEmailNotifier(notifier.EmailConfig{
+			Host:     host,
+			Port:     port,
+			Username: config.Getenv("SMTP_USERNAME", ""),
+			Password: config.Getenv("SMTP_PASSWORD", ""),
+			From:     config.Getenv("SMTP_FROM", "notifications@coldy.local"),
+			To:       splitList(config.Getenv("SMTP_TO", "")),
+		})
+	}
+
+	if url := config.Getenv("WEBHOOK_URL", ""); url != "" {
+		available["webhook"] = notifier.NewWebhookNotifier(notifier.WebhookConfig{
+			URL:    url,
+			Secret: config.Getenv("WEBHOOK_SECRET", ""),
+		})
+	}
+
+	if url := config.Getenv("SLACK_WEBHOOK_URL", ""); url != "" {
+		available["slack"] = notifier.NewSlackNotifier(url)
+	}
+
+	routes, err := notifier.ParseRoutes(config.Getenv("NOTIFY_ROUTES", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	router := notifier.NewRouter(deliveries, log)
+	for eventType, names := range routes {
+		for _, name := range names {
+			n, ok := available[name]
+			if !ok {
+				return nil, fmt.Errorf("notifier %q routed for %s is not configured", name, eventType)
+			}
+			router.Route(eventType, n)
+		}
+		log.Info("notification route configured",
+			zap.String("event_type", eventType),
+			zap.Strings("notifiers", names),
 		)
-		return nil
 	}
+
+	return router, nil
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
-	return defaultValue
+	return items
 }
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	pubsubpkg "github.com/mumumio1/coldy/pkg/pubsub"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultDedupTTL is how long a delivered message ID is remembered
+	DefaultDedupTTL = 24 * time.Hour
+
+	dedupKeyPrefix    = "notification:dedup:"
+	deliveryKeyPrefix = "notification:delivered:"
+	markerDelivered   = "1"
+)
+
+// DedupStore is the subset of cache operations needed for deduplication
+type DedupStore = pubsubpkg.OnceStore
+
+// DedupMiddleware wraps a handler so that redelivered messages are acked without
+// running the handler again. Messages are keyed by the outbox message_id attribute,
+// falling back to event_id and then the Pub/Sub message ID.
+func DedupMiddleware(store DedupStore, ttl time.Duration, logger *zap.Logger) func(pubsubpkg.MessageHandler) pubsubpkg.MessageHandler {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	once := pubsubpkg.NewOnce(store, dedupKeyPrefix, pubsubpkg.DefaultProcessingLease)
+
+	return func(next pubsubpkg.MessageHandler) pubsubpkg.MessageHandler {
+		return func(ctx context.Context, msg *pubsub.Message) error {
+			id := dedupID(msg)
+
+			var handlerErr error
+			processed, err := once.ProcessOnce(ctx, id, ttl, func() error {
+				handlerErr = next(ctx, msg)
+				return handlerErr
+			})
+
+			switch {
+			case processed && handlerErr == nil && err != nil:
+				// Delivered; a redelivery may repeat it once the lease expires
+				logger.Warn("failed to record processed message", zap.String("dedup_id", id), zap.Error(err))
+				return nil
+			case processed:
+				return err
+			case errors.Is(err, pubsubpkg.ErrInProgress):
+				// Nack so the message comes back after the other delivery settles
+				return err
+			case err != nil:
+				// Nack so the message is retried once the store is reachable
+				return fmt.Errorf("dedup check failed: %w", err)
+			}
+
+			logger.Info("duplicate message skipped",
+				zap.String("message_id", msg.ID),
+				zap.String("dedup_id", id),
+			)
+			return nil
+		}
+	}
+}
+
+func dedupID(msg *pubsub.Message) string {
+	if id := deliveryID(msg.Attributes); id != "" {
+		return id
+	}
+	return msg.ID
+}
+
+// deliveryID identifies an event delivery by the outbox message_id attribute,
+// falling back to event_id
+func deliveryID(attrs map[string]string) string {
+	if id := attrs["message_id"]; id != "" {
+		return id
+	}
+	return attrs["event_id"]
+}
+
+// DeliveryLog records per-notifier deliveries in the dedup store. A message
+// whose handler failed is redelivered in full, and the log lets the router
+// skip the notifiers that already sent it.
+type DeliveryLog struct {
+	store DedupStore
+	ttl   time.Duration
+}
+
+// NewDeliveryLog creates a delivery log; ttl defaults to DefaultDedupTTL
+func NewDeliveryLog(store DedupStore, ttl time.Duration) *DeliveryLog {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	return &DeliveryLog{store: store, ttl: ttl}
+}
+
+// Delivered reports whether notification id was sent through notifier
+func (l *DeliveryLog) Delivered(ctx context.Context, notifier, id string) (bool, error) {
+	marker, err := l.store.Get(ctx, deliveryKey(notifier, id))
+	if err != nil {
+		return false, err
+	}
+	return marker != "", nil
+}
+
+// RecordDelivery records that notification id was sent through notifier
+func (l *DeliveryLog) RecordDelivery(ctx context.Context, notifier, id string) error {
+	return l.store.Set(ctx, deliveryKey(notifier, id), markerDelivered, l.ttl)
+}
+
+func deliveryKey(notifier, id string) string {
+	return deliveryKeyPrefix + notifier + ":" + id
+}
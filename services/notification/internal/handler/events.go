@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/events"
+	pubsubpkg "github.com/mumumio1/coldy/pkg/pubsub"
+	"github.com/mumumio1/coldy/services/notification/internal/notifier"
+	"github.com/mumumio1/coldy/services/notification/internal/templates"
+	"go.uber.org/zap"
+)
+
+// Sender delivers a rendered notification
+type Sender interface {
+	Send(ctx context.Context, n notifier.Notification) error
+}
+
+// Renderer renders event payloads into message content
+type Renderer interface {
+	Render(locale, eventType string, data events.Event) (*templates.Message, error)
+}
+
+// RegisterHandlers registers a handler on d for every event type notifications
+// are sent for. Each handler renders the decoded event and delivers it through
+// the sender. Send errors are returned so the message is nacked, unless the
+// sender marked them permanent.
+func RegisterHandlers(d *pubsubpkg.Dispatcher, renderer Renderer, sender Sender, logger *zap.Logger) {
+	handle[events.OrderCreated](d, renderer, sender, logger)
+	handle[events.PaymentSucceeded](d, renderer, sender, logger)
+	handle[events.PaymentFailed](d, renderer, sender, logger)
+}
+
+func handle[T events.Event](d *pubsubpkg.Dispatcher, renderer Renderer, sender Sender, logger *zap.Logger) {
+	var zero T
+	eventType := zero.EventType()
+
+	pubsubpkg.Handle(d, eventType, func(ctx context.Context, attrs map[string]string, event T) error {
+		id := deliveryID(attrs)
+
+		n, err := renderNotification(renderer, event)
+		if err != nil {
+			logger.Error("failed to render notification",
+				zap.String("event_type", eventType),
+				zap.String("delivery_id", id),
+				zap.Error(err),
+			)
+			return nil
+		}
+		n.ID = id
+
+		if err := sender.Send(ctx, n); err != nil {
+			logger.Warn("failed to send notification",
+				zap.String("event_type", eventType),
+				zap.String("delivery_id", id),
+				zap.Error(err),
+			)
+			return fmt.Errorf("failed to send notification: %w", err)
+		}
+
+		logger.Info("notification sent",
+			zap.String("event_type", eventType),
+			zap.String("delivery_id", id),
+		)
+		return nil
+	})
+}
+
+// renderNotification renders event in the default locale; events carry no
+// recipient locale
+func renderNotification(renderer Renderer, event events.Event) (notifier.Notification, error) {
+	msg, err := renderer.Render("", event.EventType(), event)
+	if err != nil {
+		return notifier.Notification{}, err
+	}
+
+	return notifier.Notification{
+		EventType: event.EventType(),
+		Subject:   msg.Subject,
+		Body:      msg.Body,
+		HTML:      msg.HTML,
+		Data:      event,
+	}, nil
+}
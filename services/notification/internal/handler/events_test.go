@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/mumumio1/coldy/pkg/events"
+	pubsubpkg "github.com/mumumio1/coldy/pkg/pubsub"
+	"github.com/mumumio1/coldy/services/notification/internal/notifier"
+	"github.com/mumumio1/coldy/services/notification/internal/templates"
+	"go.uber.org/zap"
+)
+
+type recordingSender struct {
+	sent []notifier.Notification
+}
+
+func (s *recordingSender) Send(_ context.Context, n notifier.Notification) error {
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+func newMessage(t *testing.T, event events.Event) *pubsub.Message {
+	t.Helper()
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", event.EventType(), err)
+	}
+	return &pubsub.Message{
+		ID:         "msg-1",
+		Data:       data,
+		Attributes: map[string]string{pubsubpkg.AttrEventType: event.EventType()},
+	}
+}
+
+// newHandler dispatches messages to the notification handlers
+func newHandler(renderer Renderer, sender Sender) pubsubpkg.MessageHandler {
+	d := pubsubpkg.NewDispatcher(pubsubpkg.NackUnknown, zap.NewNop())
+	RegisterHandlers(d, renderer, sender, zap.NewNop())
+	return d.HandleMessage
+}
+
+func TestEventHandlerRendersTypedEvents(t *testing.T) {
+	renderer, err := templates.New(templates.DefaultLocale)
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+
+	tests := []struct {
+		event events.Event
+		want  []string
+	}{
+		{
+			events.OrderCreated{OrderID: "o-1", UserID: "u-1", Total: 2550, Currency: "USD", Status: "pending"},
+			[]string{"Order o-1 confirmed", "25.50 USD", "pending"},
+		},
+		{
+			events.PaymentSucceeded{PaymentID: "pay-1", OrderID: "o-1", UserID: "u-1", TransactionID: "txn-1"},
+			[]string{"Payment received for order o-1", "pay-1", "txn-1"},
+		},
+		{
+			events.PaymentFailed{PaymentID: "pay-1", OrderID: "o-1", UserID: "u-1", Error: "card declined"},
+			[]string{"Payment failed for order o-1", "pay-1", "card declined"},
+		},
+	}
+
+	// Every event type with a template must be decoded
+	if len(tests) != len(templates.EventTypes) {
+		t.Fatalf("%d events tested, %d have templates", len(tests), len(templates.EventTypes))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.event.EventType(), func(t *testing.T) {
+			sender := &recordingSender{}
+			h := newHandler(renderer, sender)
+			if err := h(context.Background(), newMessage(t, tt.event)); err != nil {
+				t.Fatalf("handler failed: %v", err)
+			}
+			if len(sender.sent) != 1 {
+				t.Fatalf("expected 1 notification, got %d", len(sender.sent))
+			}
+
+			n := sender.sent[0]
+			content := n.Subject + "\n" + n.Body + "\n" + n.HTML
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("notification does not contain %q:\n%s", want, content)
+				}
+			}
+			if !reflect.DeepEqual(n.Data, tt.event) {
+				t.Errorf("Data = %+v, want the decoded event", n.Data)
+			}
+		})
+	}
+}
+
+func TestEventHandlerAcksMalformedPayload(t *testing.T) {
+	renderer, err := templates.New(templates.DefaultLocale)
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+	sender := &recordingSender{}
+	h := newHandler(renderer, sender)
+
+	msg := &pubsub.Message{
+		ID:         "msg-1",
+		Data:       []byte(`{"order_id":"o-1","total":"lots"}`),
+		Attributes: map[string]string{pubsubpkg.AttrEventType: events.TypeOrderCreated},
+	}
+	if err := h(context.Background(), msg); err != nil {
+		t.Errorf("expected a malformed payload to be acked, got %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Errorf("expected nothing sent, got %d notifications", len(sender.sent))
+	}
+}
+
+func TestEventHandlerUnknownType(t *testing.T) {
+	sender := &recordingSender{}
+	h := newHandler(nil, sender)
+
+	msg := &pubsub.Message{ID: "msg-1", Attributes: map[string]string{pubsubpkg.AttrEventType: "order.teleported"}}
+	if err := h(context.Background(), msg); !errors.Is(err, pubsubpkg.ErrUnknownEventType) {
+		t.Errorf("expected ErrUnknownEventType, got %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Errorf("expected nothing sent, got %d notifications", len(sender.sent))
+	}
+}
+
+// memoryStore is an in-memory DedupStore; TTLs are ignored
+type memoryStore map[string]string
+
+func (m memoryStore) SetNX(_ context.Context, key string, value interface{}, _ time.Duration) (bool, error) {
+	if _, ok := m[key]; ok {
+		return false, nil
+	}
+	m[key] = fmt.Sprint(value)
+	return true, nil
+}
+
+func (m memoryStore) Get(_ context.Context, key string) (string, error) {
+	return m[key], nil
+}
+
+func (m memoryStore) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	m[key] = fmt.Sprint(value)
+	return nil
+}
+
+func (m memoryStore) Delete(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(m, key)
+	}
+	return nil
+}
+
+type flakyNotifier struct {
+	name string
+	err  error
+	sent []string
+}
+
+func (f *flakyNotifier) Name() string { return f.name }
+
+func (f *flakyNotifier) Send(_ context.Context, n notifier.Notification) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, n.ID)
+	return nil
+}
+
+func TestEventHandlerRedeliverySkipsDeliveredNotifiers(t *testing.T) {
+	renderer, err := templates.New(templates.DefaultLocale)
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+
+	email := &flakyNotifier{name: "email", err: errors.New("smtp unavailable")}
+	slack := &flakyNotifier{name: "slack"}
+	store := memoryStore{}
+	router := notifier.NewRouter(NewDeliveryLog(store, 0), zap.NewNop())
+	router.Route(events.TypeOrderCreated, email, slack)
+
+	h := DedupMiddleware(store, 0, zap.NewNop())(newHandler(renderer, router))
+
+	msg := newMessage(t, events.OrderCreated{OrderID: "o-1", UserID: "u-1", Currency: "USD"})
+	msg.Attributes["message_id"] = "outbox-1"
+
+	if err := h(context.Background(), msg); err == nil {
+		t.Fatal("expected the email failure to nack the message")
+	}
+
+	email.err = nil
+	if err := h(context.Background(), msg); err != nil {
+		t.Fatalf("redelivery failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(slack.sent, []string{"outbox-1"}) {
+		t.Errorf("slack sent %v, want it sent once", slack.sent)
+	}
+	if !reflect.DeepEqual(email.sent, []string{"outbox-1"}) {
+		t.Errorf("email sent %v, want it sent once", email.sent)
+	}
+}
+
+func TestEventHandlerRejectedNotificationIsPermanent(t *testing.T) {
+	renderer, err := templates.New(templates.DefaultLocale)
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+
+	webhook := &flakyNotifier{name: "webhook", err: fmt.Errorf("%w: webhook returned status 404", notifier.ErrRejected)}
+	router := notifier.NewRouter(nil, zap.NewNop())
+	router.Route(events.TypePaymentFailed, webhook)
+
+	h := newHandler(renderer, router)
+	err = h(context.Background(), newMessage(t, events.PaymentFailed{PaymentID: "pay-1", OrderID: "o-1"}))
+	if !pubsubpkg.IsPermanent(err) {
+		t.Errorf("expected a permanent error, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+const mimeBoundary = "coldy-notification-boundary"
+
+// EmailConfig holds SMTP configuration
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// EmailNotifier sends notifications over SMTP
+type EmailNotifier struct {
+	config EmailConfig
+}
+
+// NewEmailNotifier creates a new SMTP notifier
+func NewEmailNotifier(config EmailConfig) *EmailNotifier {
+	return &EmailNotifier{
+		config: config,
+	}
+}
+
+// Name returns the notifier name
+func (e *EmailNotifier) Name() string {
+	return "email"
+}
+
+// Send sends the notification as a plain-text email
+func (e *EmailNotifier) Send(ctx context.Context, n Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(e.config.To) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+
+	var auth smtp.Auth
+	if e.config.Username != "" {
+		auth = smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.Host)
+	}
+
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
+	if err := smtp.SendMail(addr, auth, e.config.From, e.config.To, e.buildMessage(n)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+func (e *EmailNotifier) buildMessage(n Notification) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + e.config.From + "\r\n")
+	b.WriteString("To: " + strings.Join(e.config.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + n.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if n.HTML == "" {
+		b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+		b.WriteString("\r\n")
+		b.WriteString(n.Body)
+		return []byte(b.String())
+	}
+
+	// Send both parts so clients without HTML support still get the text body
+	b.WriteString("Content-Type: multipart/alternative; boundary=\"" + mimeBoundary + "\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString("--" + mimeBoundary + "\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	b.WriteString(n.Body + "\r\n")
+	b.WriteString("--" + mimeBoundary + "\r\n")
+	b.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n\r\n")
+	b.WriteString(n.HTML + "\r\n")
+	b.WriteString("--" + mimeBoundary + "--\r\n")
+	return []byte(b.String())
+}
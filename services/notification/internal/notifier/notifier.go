@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mumumio1/coldy/pkg/events"
+	pubsubpkg "github.com/mumumio1/coldy/pkg/pubsub"
+	"go.uber.org/zap"
+)
+
+// ErrRejected is returned when a notifier's endpoint refuses the notification,
+// e.g. with a 4xx status. Redelivering the event cannot fix it.
+var ErrRejected = errors.New("notification rejected")
+
+// Notification is a rendered message ready to be delivered
+type Notification struct {
+	// ID identifies the event delivery; notifiers that already sent a
+	// notification with the same ID are skipped
+	ID        string
+	EventType string
+	Subject   string
+	Body      string
+	HTML      string
+	// Data is the event the notification was rendered from
+	Data events.Event
+}
+
+// Notifier delivers notifications over a single channel
+type Notifier interface {
+	Name() string
+	Send(ctx context.Context, n Notification) error
+}
+
+// DeliveryLog records which notifiers a notification was delivered through
+type DeliveryLog interface {
+	Delivered(ctx context.Context, notifier, id string) (bool, error)
+	RecordDelivery(ctx context.Context, notifier, id string) error
+}
+
+// Router selects notifiers per event type
+type Router struct {
+	routes     map[string][]Notifier
+	deliveries DeliveryLog
+	logger     *zap.Logger
+}
+
+// NewRouter creates an empty router. Deliveries are tracked per notifier in
+// deliveries; a nil log sends to every notifier each time.
+func NewRouter(deliveries DeliveryLog, logger *zap.Logger) *Router {
+	return &Router{
+		routes:     make(map[string][]Notifier),
+		deliveries: deliveries,
+		logger:     logger,
+	}
+}
+
+// Route registers notifiers for an event type
+func (r *Router) Route(eventType string, notifiers ...Notifier) {
+	r.routes[eventType] = append(r.routes[eventType], notifiers...)
+}
+
+// Notifiers returns the notifiers registered for an event type
+func (r *Router) Notifiers(eventType string) []Notifier {
+	return r.routes[eventType]
+}
+
+// Send delivers a notification to every notifier routed for its event type.
+// All notifiers are attempted; their errors are joined. Notifiers that
+// already delivered n.ID are skipped, so a redelivered event is only retried
+// on the notifiers that failed. When every failure is a rejection the error
+// is marked permanent so the message is acked.
+func (r *Router) Send(ctx context.Context, n Notification) error {
+	var errs []error
+	rejected := 0
+	for _, notifier := range r.routes[n.EventType] {
+		delivered, err := r.delivered(ctx, notifier, n.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Name(), err))
+			continue
+		}
+		if delivered {
+			continue
+		}
+
+		if err := notifier.Send(ctx, n); err != nil {
+			if errors.Is(err, ErrRejected) {
+				rejected++
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Name(), err))
+			continue
+		}
+
+		if r.deliveries != nil && n.ID != "" {
+			if err := r.deliveries.RecordDelivery(ctx, notifier.Name(), n.ID); err != nil {
+				// Sent; a redelivery may repeat it through this notifier
+				r.logger.Warn("failed to record notification delivery",
+					zap.String("notifier", notifier.Name()),
+					zap.String("notification_id", n.ID),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && rejected == len(errs) {
+		return pubsubpkg.Permanent(err)
+	}
+	return err
+}
+
+func (r *Router) delivered(ctx context.Context, notifier Notifier, id string) (bool, error) {
+	if r.deliveries == nil || id == "" {
+		return false, nil
+	}
+	delivered, err := r.deliveries.Delivered(ctx, notifier.Name(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to check delivery: %w", err)
+	}
+	return delivered, nil
+}
+
+// ParseRoutes parses a route spec such as "order.created=email,slack;payment.succeeded=webhook"
+// into event type -> notifier names
+func ParseRoutes(spec string) (map[string][]string, error) {
+	routes := make(map[string][]string)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		eventType, names, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(eventType) == "" {
+			return nil, fmt.Errorf("invalid route %q", entry)
+		}
+
+		for _, name := range strings.Split(names, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				routes[strings.TrimSpace(eventType)] = append(routes[strings.TrimSpace(eventType)], name)
+			}
+		}
+	}
+	return routes, nil
+}
@@ -0,0 +1,181 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	pubsubpkg "github.com/mumumio1/coldy/pkg/pubsub"
+	"go.uber.org/zap"
+)
+
+type fakeNotifier struct {
+	name string
+	err  error
+	sent int
+}
+
+func (f *fakeNotifier) Name() string { return f.name }
+
+func (f *fakeNotifier) Send(context.Context, Notification) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent++
+	return nil
+}
+
+type memoryDeliveryLog map[string]bool
+
+func (m memoryDeliveryLog) Delivered(_ context.Context, notifier, id string) (bool, error) {
+	return m[notifier+":"+id], nil
+}
+
+func (m memoryDeliveryLog) RecordDelivery(_ context.Context, notifier, id string) error {
+	m[notifier+":"+id] = true
+	return nil
+}
+
+var errUnavailable = errors.New("smtp unavailable")
+
+func TestRouterSkipsDeliveredNotifiers(t *testing.T) {
+	email := &fakeNotifier{name: "email", err: errUnavailable}
+	slack := &fakeNotifier{name: "slack"}
+	deliveries := memoryDeliveryLog{}
+	router := NewRouter(deliveries, zap.NewNop())
+	router.Route("order.created", email, slack)
+
+	n := Notification{ID: "evt-1", EventType: "order.created"}
+	err := router.Send(context.Background(), n)
+	if !errors.Is(err, errUnavailable) {
+		t.Fatalf("expected the email error, got %v", err)
+	}
+	if pubsubpkg.IsPermanent(err) {
+		t.Errorf("expected a transient error, got %v", err)
+	}
+
+	// The redelivery only retries the notifier that failed
+	email.err = nil
+	if err := router.Send(context.Background(), n); err != nil {
+		t.Fatalf("redelivery failed: %v", err)
+	}
+	if email.sent != 1 || slack.sent != 1 {
+		t.Errorf("sent email %d, slack %d times; want 1 each", email.sent, slack.sent)
+	}
+
+	if err := router.Send(context.Background(), n); err != nil {
+		t.Fatalf("third delivery failed: %v", err)
+	}
+	if email.sent != 1 || slack.sent != 1 {
+		t.Errorf("delivered notification resent: email %d, slack %d", email.sent, slack.sent)
+	}
+}
+
+func TestRouterWithoutIDSendsEveryTime(t *testing.T) {
+	slack := &fakeNotifier{name: "slack"}
+	router := NewRouter(memoryDeliveryLog{}, zap.NewNop())
+	router.Route("order.created", slack)
+
+	for i := 0; i < 2; i++ {
+		if err := router.Send(context.Background(), Notification{EventType: "order.created"}); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	if slack.sent != 2 {
+		t.Errorf("sent %d times, want 2", slack.sent)
+	}
+}
+
+func TestRouterPermanentErrors(t *testing.T) {
+	rejected := fmt.Errorf("%w: webhook returned status 410", ErrRejected)
+
+	tests := map[string]struct {
+		errs          []error
+		wantPermanent bool
+	}{
+		"all rejected": {
+			errs:          []error{rejected, rejected},
+			wantPermanent: true,
+		},
+		"rejected and unavailable": {
+			errs:          []error{rejected, errUnavailable},
+			wantPermanent: false,
+		},
+		"rejected and delivered": {
+			errs:          []error{rejected, nil},
+			wantPermanent: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			router := NewRouter(memoryDeliveryLog{}, zap.NewNop())
+			for i, err := range tt.errs {
+				router.Route("payment.failed", &fakeNotifier{name: fmt.Sprintf("notifier-%d", i), err: err})
+			}
+
+			err := router.Send(context.Background(), Notification{ID: "evt-1", EventType: "payment.failed"})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := pubsubpkg.IsPermanent(err); got != tt.wantPermanent {
+				t.Errorf("IsPermanent = %v, want %v (err %v)", got, tt.wantPermanent, err)
+			}
+		})
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		want    map[string][]string
+		wantErr bool
+	}{
+		"empty": {
+			spec: "",
+			want: map[string][]string{},
+		},
+		"several events": {
+			spec: "order.created=email,slack;payment.succeeded=webhook",
+			want: map[string][]string{
+				"order.created":     {"email", "slack"},
+				"payment.succeeded": {"webhook"},
+			},
+		},
+		"whitespace and empty entries": {
+			spec: " order.created = email , ;; payment.failed=slack ",
+			want: map[string][]string{
+				"order.created":  {"email"},
+				"payment.failed": {"slack"},
+			},
+		},
+		"missing separator": {
+			spec:    "order.created",
+			wantErr: true,
+		},
+		"missing event type": {
+			spec:    "=email",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRoutes(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRoutes failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRoutes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/pkg/retry"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 signature of the request body
+	SignatureHeader = "X-Coldy-Signature"
+	// TimestampHeader carries the unix time the request was signed
+	TimestampHeader = "X-Coldy-Timestamp"
+)
+
+// WebhookConfig holds webhook configuration
+type WebhookConfig struct {
+	URL     string
+	Secret  string
+	Timeout time.Duration
+	Retry   retry.Policy
+}
+
+// WebhookNotifier posts notifications to an HTTP endpoint
+type WebhookNotifier struct {
+	config WebhookConfig
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new webhook notifier
+func NewWebhookNotifier(config WebhookConfig) *WebhookNotifier {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.Retry.MaxAttempts <= 0 {
+		config.Retry = retry.DefaultPolicy()
+	}
+
+	return &WebhookNotifier{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Name returns the notifier name
+func (w *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// webhookPayload is the JSON body posted to webhook endpoints
+type webhookPayload struct {
+	EventType string       `json:"event_type"`
+	Subject   string       `json:"subject"`
+	Body      string       `json:"body"`
+	Data      events.Event `json:"data,omitempty"`
+}
+
+// Send posts the notification, retrying on network errors and 5xx responses.
+// A 4xx response is not retried and yields ErrRejected.
+func (w *WebhookNotifier) Send(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(webhookPayload{
+		EventType: n.EventType,
+		Subject:   n.Subject,
+		Body:      n.Body,
+		Data:      n.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	return retry.Do(ctx, w.config.Retry, func() error {
+		return w.post(ctx, body)
+	})
+}
+
+func (w *WebhookNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("failed to build webhook request: %w", err))
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	if w.config.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.config.Secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	case resp.StatusCode >= 400:
+		return retry.Permanent(fmt.Errorf("%w: webhook returned status %d", ErrRejected, resp.StatusCode))
+	}
+
+	return nil
+}
+
+// Sign returns the signature header value for a timestamped body:
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/retry"
+)
+
+func newTestWebhook(t *testing.T, handler http.HandlerFunc) (*WebhookNotifier, *int32) {
+	t.Helper()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewWebhookNotifier(WebhookConfig{
+		URL:    server.URL,
+		Secret: "shh",
+		Retry: retry.Policy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    time.Millisecond,
+		},
+	}), &calls
+}
+
+func TestWebhookSendStatus(t *testing.T) {
+	tests := map[string]struct {
+		status       int
+		wantRejected bool
+		wantErr      bool
+		wantCalls    int32
+	}{
+		"ok":           {status: http.StatusOK, wantCalls: 1},
+		"client error": {status: http.StatusGone, wantErr: true, wantRejected: true, wantCalls: 1},
+		"server error": {status: http.StatusBadGateway, wantErr: true, wantCalls: 3},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			webhook, calls := newTestWebhook(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			})
+
+			err := webhook.Send(context.Background(), Notification{EventType: "order.created", Subject: "hi"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrRejected); got != tt.wantRejected {
+				t.Errorf("errors.Is(err, ErrRejected) = %v, want %v (err %v)", got, tt.wantRejected, err)
+			}
+			if got := atomic.LoadInt32(calls); got != tt.wantCalls {
+				t.Errorf("endpoint called %d times, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWebhookSendSignsBody(t *testing.T) {
+	var body []byte
+	var timestamp, signature string
+	webhook, _ := newTestWebhook(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		timestamp = r.Header.Get(TimestampHeader)
+		signature = r.Header.Get(SignatureHeader)
+	})
+
+	if err := webhook.Send(context.Background(), Notification{EventType: "order.created"}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if timestamp == "" {
+		t.Fatal("expected a timestamp header")
+	}
+	if want := Sign("shh", timestamp, body); signature != want {
+		t.Errorf("signature = %q, want %q", signature, want)
+	}
+}
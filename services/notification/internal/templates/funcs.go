@@ -0,0 +1,21 @@
+package templates
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// funcs are available to every template
+var funcs = template.FuncMap{
+	"amount": amount,
+}
+
+// amount formats a minor-unit amount (e.g. cents) with its currency
+func amount(minor int64, currency string) string {
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, minor/100, minor%100, currency)
+}
@@ -0,0 +1,190 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/mumumio1/coldy/pkg/events"
+)
+
+// DefaultLocale is used when a message has no locale or its locale has no template
+const DefaultLocale = "en"
+
+// EventTypes lists the event types every default-locale template set must cover
+var EventTypes = []string{
+	events.TypeOrderCreated,
+	events.TypePaymentSucceeded,
+	events.TypePaymentFailed,
+}
+
+var (
+	// ErrTemplateNotFound is returned when no template exists for an event type
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+//go:embed files
+var files embed.FS
+
+// Message is rendered notification content
+type Message struct {
+	Subject string
+	Body    string
+	HTML    string
+}
+
+// eventTemplate holds the parsed templates for one event type in one locale.
+// The text template defines "subject" and "body"; the HTML body is optional.
+type eventTemplate struct {
+	text *template.Template
+	html *htmltemplate.Template
+}
+
+// Renderer renders event payloads into localized messages
+type Renderer struct {
+	defaultLocale string
+	templates     map[string]map[string]*eventTemplate // locale -> event type -> template
+}
+
+// New parses the embedded templates. It fails if any template does not parse or if the
+// default locale is missing a template for one of EventTypes.
+func New(defaultLocale string) (*Renderer, error) {
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+
+	root, err := fs.Sub(files, "files")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open templates: %w", err)
+	}
+
+	r := &Renderer{
+		defaultLocale: defaultLocale,
+		templates:     make(map[string]map[string]*eventTemplate),
+	}
+
+	err = fs.WalkDir(root, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return r.load(root, p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, eventType := range EventTypes {
+		t, ok := r.templates[defaultLocale][eventType]
+		if !ok || t.text == nil {
+			return nil, fmt.Errorf("%w: %s/%s", ErrTemplateNotFound, defaultLocale, eventType)
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Renderer) load(root fs.FS, p string) error {
+	locale, name := path.Split(p)
+	locale = strings.TrimSuffix(locale, "/")
+	if locale == "" || strings.Contains(locale, "/") {
+		return fmt.Errorf("template %s must be in a locale directory", p)
+	}
+
+	content, err := fs.ReadFile(root, p)
+	if err != nil {
+		return fmt.Errorf("failed to read template %s: %w", p, err)
+	}
+
+	if r.templates[locale] == nil {
+		r.templates[locale] = make(map[string]*eventTemplate)
+	}
+
+	switch {
+	case strings.HasSuffix(name, ".html.tmpl"):
+		eventType := strings.TrimSuffix(name, ".html.tmpl")
+		t, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap(funcs)).Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", p, err)
+		}
+		r.entry(locale, eventType).html = t
+
+	case strings.HasSuffix(name, ".tmpl"):
+		eventType := strings.TrimSuffix(name, ".tmpl")
+		t, err := template.New(name).Funcs(funcs).Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", p, err)
+		}
+		if t.Lookup("subject") == nil || t.Lookup("body") == nil {
+			return fmt.Errorf("template %s must define subject and body", p)
+		}
+		r.entry(locale, eventType).text = t
+
+	default:
+		return fmt.Errorf("unexpected template file %s", p)
+	}
+
+	return nil
+}
+
+func (r *Renderer) entry(locale, eventType string) *eventTemplate {
+	t, ok := r.templates[locale][eventType]
+	if !ok {
+		t = &eventTemplate{}
+		r.templates[locale][eventType] = t
+	}
+	return t
+}
+
+// Render renders the event for the given locale. Templates refer to the fields
+// of the typed event, e.g. {{.OrderID}}. Locales fall back from "de-AT" to "de"
+// and then to the default locale.
+func (r *Renderer) Render(locale, eventType string, data events.Event) (*Message, error) {
+	t := r.lookup(locale, eventType)
+	if t == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, eventType)
+	}
+
+	var subject, body bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject: %w", err)
+	}
+	if err := t.text.ExecuteTemplate(&body, "body", data); err != nil {
+		return nil, fmt.Errorf("failed to render body: %w", err)
+	}
+
+	msg := &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	}
+
+	if t.html != nil {
+		var html bytes.Buffer
+		if err := t.html.Execute(&html, data); err != nil {
+			return nil, fmt.Errorf("failed to render html body: %w", err)
+		}
+		msg.HTML = html.String()
+	}
+
+	return msg, nil
+}
+
+func (r *Renderer) lookup(locale, eventType string) *eventTemplate {
+	candidates := []string{locale}
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, base)
+	}
+	candidates = append(candidates, r.defaultLocale)
+
+	for _, candidate := range candidates {
+		if t, ok := r.templates[candidate][eventType]; ok && t.text != nil {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/mumumio1/coldy/pkg/circuitbreaker"
+	pubsubpkg "github.com/mumumio1/coldy/pkg/pubsub"
+	"github.com/mumumio1/coldy/pkg/retry"
+	"github.com/mumumio1/coldy/services/notification/internal/notifier"
+	"go.uber.org/zap"
+)
+
+// EventIDHeader carries the event ID so receivers can drop redeliveries
+const EventIDHeader = "X-Coldy-Event-ID"
+
+// EventTypes are the events delivered to webhook endpoints
+var EventTypes = []string{
+	"order.created",
+	"order.canceled",
+	"payment.succeeded",
+	"payment.failed",
+	"payment.canceled",
+	"payment.refunded",
+}
+
+// ErrRejected is returned when an endpoint answers with a 4xx status. Such
+// deliveries are not retried and don't cause the message to be redelivered.
+var ErrRejected = errors.New("webhook rejected")
+
+// Config configures webhook delivery
+type Config struct {
+	// Timeout bounds a single HTTP request
+	Timeout time.Duration
+	// Retry retries 5xx responses and network errors within one delivery
+	Retry retry.Policy
+	// Breaker is applied per endpoint; its Timeout should exceed Timeout
+	Breaker circuitbreaker.Config
+}
+
+// DefaultConfig returns the default delivery settings
+func DefaultConfig() Config {
+	return Config{
+		Timeout: 10 * time.Second,
+		Retry: retry.Policy{
+			MaxAttempts: 4,
+			BaseDelay:   500 * time.Millisecond,
+			MaxDelay:    10 * time.Second,
+		},
+		Breaker: circuitbreaker.Config{
+			MaxFailures:  5,
+			Timeout:      15 * time.Second,
+			ResetTimeout: time.Minute,
+		},
+	}
+}
+
+// owner is the part of every webhook event naming the user it belongs to
+type owner struct {
+	UserID string `json:"user_id"`
+}
+
+// payload is the JSON body posted to endpoints
+type payload struct {
+	ID        string          `json:"id"`
+	EventType string          `json:"event_type"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Deliverer posts events to the endpoints registered for them
+type Deliverer struct {
+	repo   *Repository
+	client *http.Client
+	config Config
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	breakers map[string]*circuitbreaker.CircuitBreaker
+}
+
+// NewDeliverer creates a new webhook deliverer
+func NewDeliverer(repo *Repository, config Config, logger *zap.Logger) *Deliverer {
+	defaults := DefaultConfig()
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.Retry.MaxAttempts <= 0 {
+		config.Retry = defaults.Retry
+	}
+	if config.Breaker.MaxFailures == 0 {
+		config.Breaker = defaults.Breaker
+	}
+	// An open breaker fails fast; retrying it would only burn attempts
+	config.Retry.Retryable = func(err error) bool {
+		return !errors.Is(err, circuitbreaker.ErrCircuitOpen)
+	}
+
+	return &Deliverer{
+		repo:     repo,
+		client:   &http.Client{Timeout: config.Timeout},
+		config:   config,
+		logger:   logger,
+		breakers: make(map[string]*circuitbreaker.CircuitBreaker),
+	}
+}
+
+// Handler returns a message handler delivering events of eventType to the
+// endpoints of the event's user. Endpoints that already received the event
+// are skipped, so a message nacked because one endpoint failed is not sent
+// again to the others.
+func (d *Deliverer) Handler(eventType string) pubsubpkg.MessageHandler {
+	return func(ctx context.Context, msg *pubsub.Message) error {
+		var eventOwner owner
+		if err := json.Unmarshal(msg.Data, &eventOwner); err != nil {
+			return pubsubpkg.Permanent(fmt.Errorf("invalid %s payload", eventType))
+		}
+		if eventOwner.UserID == "" {
+			// Without a user the event can't be matched to its endpoints
+			return pubsubpkg.Permanent(fmt.Errorf("%s event has no user_id: %w", eventType, ErrNoOwner))
+		}
+
+		eventID := msg.Attributes["event_id"]
+		if eventID == "" {
+			eventID = msg.ID
+		}
+
+		endpoints, err := d.repo.EndpointsFor(ctx, eventType, eventOwner.UserID)
+		if err != nil {
+			return err
+		}
+
+		body, err := json.Marshal(payload{ID: eventID, EventType: eventType, Data: msg.Data})
+		if err != nil {
+			return pubsubpkg.Permanent(fmt.Errorf("failed to marshal webhook payload: %w", err))
+		}
+
+		var failed []error
+		for _, endpoint := range endpoints {
+			delivered, err := d.repo.Delivered(ctx, endpoint.ID, eventID)
+			if err != nil {
+				failed = append(failed, err)
+				continue
+			}
+			if delivered {
+				continue
+			}
+
+			err = d.deliver(ctx, endpoint, eventID, eventType, body)
+			if errors.Is(err, ErrRejected) {
+				d.logger.Error("webhook rejected by endpoint",
+					zap.String("endpoint_id", endpoint.ID),
+					zap.String("event_id", eventID),
+					zap.Error(err),
+				)
+				continue
+			}
+			if err != nil {
+				d.logger.Warn("webhook delivery failed",
+					zap.String("endpoint_id", endpoint.ID),
+					zap.String("event_id", eventID),
+					zap.String("event_type", eventType),
+					zap.Error(err),
+				)
+				failed = append(failed, fmt.Errorf("endpoint %s: %w", endpoint.ID, err))
+			}
+		}
+
+		return errors.Join(failed...)
+	}
+}
+
+// deliver posts body to endpoint through its circuit breaker, retrying
+// transient failures with exponential backoff, and records every attempt
+func (d *Deliverer) deliver(ctx context.Context, endpoint *Endpoint, eventID, eventType string, body []byte) error {
+	breaker := d.breaker(endpoint.ID)
+
+	attempt := 0
+	return retry.Do(ctx, d.config.Retry, func() error {
+		attempt++
+		start := time.Now()
+
+		// The breaker may give up on a slow call before post returns, so the
+		// status code is handed over rather than shared
+		statusCh := make(chan int, 1)
+		err := breaker.Execute(ctx, func() error {
+			statusCode, postErr := d.post(ctx, endpoint, eventID, body)
+			statusCh <- statusCode
+			return postErr
+		})
+		if errors.Is(err, circuitbreaker.ErrCircuitOpen) {
+			return err
+		}
+
+		var statusCode int
+		select {
+		case statusCode = <-statusCh:
+		default:
+		}
+
+		record := Attempt{
+			EndpointID: endpoint.ID,
+			EventID:    eventID,
+			EventType:  eventType,
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Succeeded:  err == nil,
+			Duration:   time.Since(start),
+		}
+		if err != nil {
+			record.Error = err.Error()
+		}
+		if recordErr := d.repo.RecordAttempt(ctx, record); recordErr != nil {
+			d.logger.Error("failed to record webhook attempt", zap.Error(recordErr))
+		}
+
+		return err
+	})
+}
+
+// post sends one signed request
+func (d *Deliverer) post(ctx context.Context, endpoint *Endpoint, eventID string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, retry.Permanent(fmt.Errorf("failed to build webhook request: %w", err))
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, eventID)
+	req.Header.Set(notifier.TimestampHeader, timestamp)
+	req.Header.Set(notifier.SignatureHeader, notifier.Sign(endpoint.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 500:
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	case resp.StatusCode >= 400:
+		return resp.StatusCode, retry.Permanent(fmt.Errorf("%w: status %d", ErrRejected, resp.StatusCode))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// breaker returns the circuit breaker of an endpoint
+func (d *Deliverer) breaker(endpointID string) *circuitbreaker.CircuitBreaker {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cb, ok := d.breakers[endpointID]
+	if !ok {
+		cb = circuitbreaker.New(d.config.Breaker)
+		cb.OnStateChange(func(from, to circuitbreaker.State) {
+			d.logger.Warn("webhook endpoint circuit changed",
+				zap.String("endpoint_id", endpointID),
+				zap.Int("from", int(from)),
+				zap.Int("to", int(to)),
+			)
+		})
+		d.breakers[endpointID] = cb
+	}
+	return cb
+}
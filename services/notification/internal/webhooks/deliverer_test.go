@@ -0,0 +1,37 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	pubsubpkg "github.com/mumumio1/coldy/pkg/pubsub"
+	"go.uber.org/zap"
+)
+
+func TestHandlerRejectsEventWithoutOwner(t *testing.T) {
+	d := NewDeliverer(NewRepository(nil), Config{}, zap.NewNop())
+
+	err := d.Handler("payment.succeeded")(context.Background(), &pubsub.Message{
+		ID:   "msg-1",
+		Data: []byte(`{"payment_id":"p-1","order_id":"o-1"}`),
+	})
+	if !errors.Is(err, ErrNoOwner) {
+		t.Fatalf("expected ErrNoOwner, got %v", err)
+	}
+	if !pubsubpkg.IsPermanent(err) {
+		t.Errorf("expected a permanent error, got %v", err)
+	}
+}
+
+func TestEndpointsRequireOwner(t *testing.T) {
+	repo := NewRepository(nil)
+
+	if err := repo.CreateEndpoint(context.Background(), &Endpoint{URL: "https://example.com"}); !errors.Is(err, ErrNoOwner) {
+		t.Errorf("CreateEndpoint: expected ErrNoOwner, got %v", err)
+	}
+	if _, err := repo.EndpointsFor(context.Background(), "order.created", ""); !errors.Is(err, ErrNoOwner) {
+		t.Errorf("EndpointsFor: expected ErrNoOwner, got %v", err)
+	}
+}
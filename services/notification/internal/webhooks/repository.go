@@ -0,0 +1,156 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Endpoint is a customer URL registered for a set of event types
+type Endpoint struct {
+	ID string
+	// OwnerID is the user who registered the endpoint; only events of that
+	// user are delivered to it
+	OwnerID    string
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  time.Time
+}
+
+// Attempt records one HTTP delivery attempt. StatusCode is zero when no
+// response was received.
+type Attempt struct {
+	EndpointID string
+	EventID    string
+	EventType  string
+	Attempt    int
+	StatusCode int
+	Error      string
+	Succeeded  bool
+	Duration   time.Duration
+}
+
+// ErrNoOwner is returned when an endpoint or event has no owning user
+var ErrNoOwner = errors.New("webhook owner is required")
+
+// Repository stores webhook endpoints and delivery attempts
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new webhook repository
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateEndpoint registers an endpoint for endpoint.OwnerID
+func (r *Repository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	if endpoint.OwnerID == "" {
+		return ErrNoOwner
+	}
+
+	query := `
+		INSERT INTO webhook_endpoints (id, owner_id, url, secret, event_types, active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+
+	endpoint.ID = uuid.New().String()
+
+	err := r.db.QueryRowContext(ctx, query,
+		endpoint.ID,
+		endpoint.OwnerID,
+		endpoint.URL,
+		endpoint.Secret,
+		pq.Array(endpoint.EventTypes),
+		endpoint.Active,
+	).Scan(&endpoint.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// EndpointsFor returns the active endpoints ownerID registered for an event
+// type
+func (r *Repository) EndpointsFor(ctx context.Context, eventType, ownerID string) ([]*Endpoint, error) {
+	if ownerID == "" {
+		return nil, ErrNoOwner
+	}
+
+	query := `
+		SELECT id, owner_id, url, secret, event_types, active, created_at
+		FROM webhook_endpoints
+		WHERE active AND owner_id = $2 AND event_types @> ARRAY[$1]::text[]
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventType, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook endpoints: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var endpoints []*Endpoint
+	for rows.Next() {
+		var e Endpoint
+		if err := rows.Scan(&e.ID, &e.OwnerID, &e.URL, &e.Secret, pq.Array(&e.EventTypes), &e.Active, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		endpoints = append(endpoints, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// Delivered reports whether an event was already delivered to an endpoint
+func (r *Repository) Delivered(ctx context.Context, endpointID, eventID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM webhook_deliveries
+			WHERE endpoint_id = $1 AND event_id = $2 AND succeeded
+		)
+	`
+
+	var delivered bool
+	if err := r.db.QueryRowContext(ctx, query, endpointID, eventID).Scan(&delivered); err != nil {
+		return false, fmt.Errorf("failed to check webhook delivery: %w", err)
+	}
+	return delivered, nil
+}
+
+// RecordAttempt stores a delivery attempt
+func (r *Repository) RecordAttempt(ctx context.Context, attempt Attempt) error {
+	query := `
+		INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, attempt, status_code, error, succeeded, duration_ms)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	statusCode := sql.NullInt64{Int64: int64(attempt.StatusCode), Valid: attempt.StatusCode != 0}
+
+	_, err := r.db.ExecContext(ctx, query,
+		attempt.EndpointID,
+		attempt.EventID,
+		attempt.EventType,
+		attempt.Attempt,
+		statusCode,
+		attempt.Error,
+		attempt.Succeeded,
+		attempt.Duration.Milliseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	return nil
+}
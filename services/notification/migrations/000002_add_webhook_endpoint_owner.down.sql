@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_webhook_endpoints_owner;
+ALTER TABLE webhook_endpoints DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,7 @@
+-- Endpoints only receive the events of the user who registered them
+ALTER TABLE webhook_endpoints ADD COLUMN IF NOT EXISTS owner_id VARCHAR(255);
+
+-- Endpoints registered before ownership can't be attributed to anyone
+UPDATE webhook_endpoints SET active = false WHERE owner_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_owner ON webhook_endpoints(owner_id) WHERE active;
@@ -2,22 +2,31 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/mumumio1/coldy/pkg/config"
 	"github.com/mumumio1/coldy/pkg/database"
+	grpcserverpkg "github.com/mumumio1/coldy/pkg/grpcserver"
+	"github.com/mumumio1/coldy/pkg/idempotency"
 	"github.com/mumumio1/coldy/pkg/logger"
 	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/money"
+	"github.com/mumumio1/coldy/pkg/outbox"
+	"github.com/mumumio1/coldy/pkg/pagination"
 	"github.com/mumumio1/coldy/pkg/pubsub"
+	"github.com/mumumio1/coldy/pkg/shutdown"
 	"github.com/mumumio1/coldy/pkg/telemetry"
+	catalogv1 "github.com/mumumio1/coldy/proto/catalog/v1"
 	ordersv1 "github.com/mumumio1/coldy/proto/orders/v1"
+	"github.com/mumumio1/coldy/services/orders/internal/catalog"
 	grpcserver "github.com/mumumio1/coldy/services/orders/internal/grpc"
-	"github.com/mumumio1/coldy/services/orders/internal/outbox"
 	"github.com/mumumio1/coldy/services/orders/internal/repository"
 	"github.com/mumumio1/coldy/services/orders/internal/service"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -45,17 +54,27 @@ func run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Refuse to start without a JWT secret, or with the placeholder one
+	// outside development
+	jwtSecret := config.Getenv("JWT_SECRET", config.InsecureJWTSecret)
+	if err := config.CheckJWTSecret(config.Getenv("ENV", "development"), jwtSecret); err != nil {
+		return err
+	}
+
 	// Initialize logger
-	log, err := logger.NewLogger(serviceName, getEnv("ENV", "development"))
+	log, err := logger.NewLogger(serviceName, config.Getenv("ENV", "development"))
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	defer func() { _ = log.Sync() }()
 
+	// Background work started from ctx logs with the service logger
+	ctx = logger.WithLogger(ctx, log)
+
 	log.Info("starting orders service", zap.String("version", version))
 
 	// Initialize tracing
-	tracingEndpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	tracingEndpoint := config.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
 	shutdownTracer, err := telemetry.InitTracer(ctx, serviceName, version, tracingEndpoint)
 	if err != nil {
 		log.Warn("failed to initialize tracer", zap.Error(err))
@@ -68,16 +87,17 @@ func run() error {
 
 	// Initialize database
 	dbConfig := database.Config{
-		Host:            getEnv("DB_HOST", "localhost"),
+		Host:            config.Getenv("DB_HOST", "localhost"),
 		Port:            5432,
-		User:            getEnv("DB_USER", "coldy"),
-		Password:        getEnv("DB_PASSWORD", "coldy123"),
-		Database:        getEnv("DB_NAME", "coldy"),
-		SSLMode:         getEnv("DB_SSLMODE", "disable"),
+		User:            config.Getenv("DB_USER", "coldy"),
+		Password:        config.Getenv("DB_PASSWORD", "coldy123"),
+		Database:        config.Getenv("DB_NAME", "coldy"),
+		SSLMode:         config.Getenv("DB_SSLMODE", "disable"),
 		MaxOpenConns:    25,
 		MaxIdleConns:    5,
 		ConnMaxLifetime: 5 * time.Minute,
 		ConnMaxIdleTime: 5 * time.Minute,
+		QueryComments:   config.Getenv("DB_QUERY_COMMENTS", "false") == "true",
 	}
 
 	db, err := database.NewPostgresDB(ctx, dbConfig, log)
@@ -87,15 +107,28 @@ func run() error {
 	defer func() { _ = db.Close() }()
 
 	// Initialize Redis
+	redisDB := config.GetenvInt("REDIS_DB", 0)
 	redisClient := redis.NewClient(&redis.Options{
-		Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
-		Password: getEnv("REDIS_PASSWORD", ""),
-		DB:       0,
+		Addr:     config.Getenv("REDIS_ADDR", "localhost:6379"),
+		Password: config.Getenv("REDIS_PASSWORD", ""),
+		DB:       redisDB,
 	})
 	defer func() { _ = redisClient.Close() }()
 
+	// Idempotency keys can live in their own Redis DB, so they can be
+	// inspected or flushed without touching other keys
+	idempotencyRedis := redisClient
+	if idempotencyDB := config.GetenvInt("IDEMPOTENCY_REDIS_DB", redisDB); idempotencyDB != redisDB {
+		idempotencyRedis = redis.NewClient(&redis.Options{
+			Addr:     config.Getenv("REDIS_ADDR", "localhost:6379"),
+			Password: config.Getenv("REDIS_PASSWORD", ""),
+			DB:       idempotencyDB,
+		})
+		defer func() { _ = idempotencyRedis.Close() }()
+	}
+
 	// Initialize Pub/Sub publisher
-	projectID := getEnv("GCP_PROJECT_ID", "coldy-local")
+	projectID := config.Getenv("GCP_PROJECT_ID", "coldy-local")
 	publisher, err := pubsub.NewPublisher(ctx, projectID, log)
 	if err != nil {
 		return fmt.Errorf("failed to create pubsub publisher: %w", err)
@@ -104,10 +137,105 @@ func run() error {
 
 	// Initialize repository and services
 	orderRepo := repository.NewOrderRepository(db)
-	orderService := service.NewOrderService(orderRepo, redisClient, log)
+	idempotencyConfig := idempotency.DefaultConfig()
+	switch policy := config.Getenv("IDEMPOTENCY_FAILURE_POLICY", "open"); policy {
+	case "open":
+	case "closed":
+		idempotencyConfig.Policy = idempotency.FailClosed
+	default:
+		return fmt.Errorf("invalid IDEMPOTENCY_FAILURE_POLICY %q", policy)
+	}
+	// Results are kept for IDEMPOTENCY_TTL; IDEMPOTENCY_OPERATION_TTL has the
+	// form "create_order=6h,cancel_order=1h"
+	idempotencyConfig.TTL = config.GetenvDuration("IDEMPOTENCY_TTL", idempotency.DefaultTTL)
+	if idempotencyConfig.OperationTTL, err = idempotency.ParseOperationTTL(config.Getenv("IDEMPOTENCY_OPERATION_TTL", "")); err != nil {
+		return fmt.Errorf("invalid IDEMPOTENCY_OPERATION_TTL: %w", err)
+	}
+	// Per-user order velocity limits; ORDER_VELOCITY_MAX_AMOUNT has the form
+	// "USD=500000,EUR=450000" in minor units
+	velocityConfig := service.VelocityConfig{
+		Window: config.GetenvDuration("ORDER_VELOCITY_WINDOW", time.Hour),
+	}
+	if v := config.Getenv("ORDER_VELOCITY_MAX_ORDERS", ""); v != "" {
+		maxOrders, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid ORDER_VELOCITY_MAX_ORDERS: %w", err)
+		}
+		velocityConfig.MaxOrders = maxOrders
+	}
+	velocityConfig.MaxAmount, err = parseAmountLimits(config.Getenv("ORDER_VELOCITY_MAX_AMOUNT", ""))
+	if err != nil {
+		return fmt.Errorf("invalid ORDER_VELOCITY_MAX_AMOUNT: %w", err)
+	}
+
+	// Currency for orders that don't name one; set ORDER_DEFAULT_CURRENCY to an
+	// empty value to require a currency on every order
+	defaultCurrency := "USD"
+	if v, ok := os.LookupEnv("ORDER_DEFAULT_CURRENCY"); ok {
+		defaultCurrency = v
+	}
+	if defaultCurrency != "" {
+		if defaultCurrency, err = money.NormalizeCurrency(defaultCurrency); err != nil {
+			return fmt.Errorf("invalid ORDER_DEFAULT_CURRENCY: %w", err)
+		}
+	}
+
+	// Plaintext gRPC is only allowed with GRPC_INSECURE=true
+	transport, err := grpcserverpkg.LoadTransportConfig()
+	if err != nil {
+		return err
+	}
+	serverCreds, err := transport.ServerCredentials()
+	if err != nil {
+		return err
+	}
+	clientCreds, err := transport.ClientCredentials()
+	if err != nil {
+		return err
+	}
 
-	// Start outbox publisher worker
-	outboxPublisher := outbox.NewPublisher(orderRepo, publisher, log, 5*time.Second)
+	// Connect to the catalog service to resolve SKU order items and price
+	// item changes
+	catalogConn, err := grpc.NewClient(config.Getenv("CATALOG_ADDR", "localhost:50052"),
+		clientCreds,
+		grpc.WithUnaryInterceptor(middleware.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create catalog client: %w", err)
+	}
+	defer func() { _ = catalogConn.Close() }()
+	catalogClient := catalog.NewClient(catalogv1.NewCatalogServiceClient(catalogConn))
+
+	idempotencyStore := idempotency.NewStoreWithConfig(idempotencyRedis, idempotencyConfig)
+	orderService := service.NewOrderService(orderRepo, redisClient, idempotencyStore, velocityConfig, catalogClient, defaultCurrency, log)
+
+	// Count idempotency keys for the key metric and expire any left without
+	// a TTL; 0 disables the sweep
+	if sweepInterval := config.GetenvDuration("IDEMPOTENCY_SWEEP_INTERVAL", 5*time.Minute); sweepInterval > 0 {
+		go idempotencyStore.RunSweeper(ctx, sweepInterval)
+	}
+
+	// Check the outbox topics up front so a misconfigured project shows up at
+	// startup rather than at the first publish. Unless PUBSUB_REQUIRE_TOPICS
+	// is set, a failed check only keeps the service unready.
+	topics := pubsub.NewPrefixResolver(config.Getenv("PUBSUB_TOPIC_PREFIX", ""), pubsub.IdentityResolver{})
+	eventTypes := service.EventTypes()
+	topicNames := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		topicNames[i] = topics.Topic(eventType)
+	}
+	topicCheck := publisher.NewTopicCheck(topicNames, config.Getenv("PUBSUB_CREATE_TOPICS", "true") == "true")
+	if err := topicCheck.Verify(ctx); err != nil {
+		if config.Getenv("PUBSUB_REQUIRE_TOPICS", "false") == "true" {
+			return fmt.Errorf("failed to verify pubsub topics: %w", err)
+		}
+		log.Warn("pubsub topics not verified", zap.Error(err))
+	}
+
+	// Start outbox publisher worker; events that keep failing are
+	// dead-lettered after OUTBOX_MAX_ATTEMPTS publishes, 0 retries forever
+	outboxPublisher := outbox.NewPublisher(orderRepo, publisher, topics, log, 5*time.Second,
+		config.GetenvInt("OUTBOX_MAX_ATTEMPTS", outbox.DefaultMaxAttempts))
 	go func() {
 		if err := outboxPublisher.Start(ctx); err != nil && err != context.Canceled {
 			log.Error("outbox publisher stopped", zap.Error(err))
@@ -115,17 +243,69 @@ func run() error {
 	}()
 
 	// Start gRPC server
-	grpcPort := getEnv("GRPC_PORT", "50053")
+	grpcPort := config.Getenv("GRPC_PORT", "50053")
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	grpcServer := grpc.NewServer(
+	// MULTI_TENANT=true rejects requests without a tenant header, except on
+	// health checks and reflection
+	tenantConfig := middleware.TenantConfig{
+		Required:      config.Getenv("MULTI_TENANT", "false") == "true",
+		ExemptMethods: middleware.InfrastructureMethods,
+	}
+
+	// Access tokens come from the users service. Order changes and the admin
+	// query require one; the other RPCs stay open until their callers send tokens.
+	authConfig := middleware.AuthConfig{
+		Validate: middleware.JWTValidator(
+			jwtSecret,
+			"coldy-users",
+			"coldy-access",
+			config.GetenvDuration("JWT_LEEWAY", middleware.DefaultJWTLeeway),
+		),
+		// Probes and tooling call the infrastructure RPCs without a token
+		PublicMethods: append([]string{
+			ordersv1.OrderService_CreateOrder_FullMethodName,
+			ordersv1.OrderService_GetOrder_FullMethodName,
+			ordersv1.OrderService_ListOrders_FullMethodName,
+			ordersv1.OrderService_VerifyOrderTotal_FullMethodName,
+		}, middleware.InfrastructureMethods...),
+		MethodScopes: map[string]string{
+			ordersv1.OrderService_ListOrdersByStatus_FullMethodName:        service.ScopeOrdersAdmin,
+			ordersv1.OrderService_ReplayOutboxEvents_FullMethodName:        service.ScopeOrdersAdmin,
+			ordersv1.OrderService_ListDeadLetteredEvents_FullMethodName:    service.ScopeOrdersAdmin,
+			ordersv1.OrderService_RequeueDeadLetteredEvents_FullMethodName: service.ScopeOrdersAdmin,
+			ordersv1.OrderService_ListOrdersByProduct_FullMethodName:       service.ScopeOrdersAdmin,
+			ordersv1.OrderService_GetOrdersSummary_FullMethodName:          service.ScopeOrdersAdmin,
+		},
+	}
+
+	keepalive, err := grpcserverpkg.LoadKeepaliveConfig()
+	if err != nil {
+		return err
+	}
+
+	// Shed load past these limits instead of letting it queue on the database pool
+	concurrency, err := middleware.LoadConcurrencyConfig()
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpcserverpkg.New(keepalive,
+		serverCreds,
+		grpcserverpkg.MaxConcurrentStreams(config.GetenvInt("GRPC_MAX_CONCURRENT_STREAMS", grpcserverpkg.DefaultMaxConcurrentStreams)),
 		grpc.ChainUnaryInterceptor(
+			middleware.MetricsInterceptor(),
 			middleware.RecoveryInterceptor(log),
 			middleware.UnaryServerInterceptor(log),
+			middleware.CompressionInterceptor(config.GetenvInt("GRPC_COMPRESS_MIN_SIZE", middleware.DefaultCompressMinSize)),
+			middleware.ConcurrencyLimitInterceptor(concurrency),
 			middleware.TracingInterceptor(serviceName),
+			middleware.AuthInterceptor(authConfig),
+			middleware.TenantInterceptor(tenantConfig),
+			middleware.ValidationInterceptor(nil),
 		),
 		grpc.ChainStreamInterceptor(
 			middleware.StreamServerInterceptor(log),
@@ -133,7 +313,12 @@ func run() error {
 	)
 
 	// Register services
-	ordersv1.RegisterOrderServiceServer(grpcServer, grpcserver.NewServer(orderService, log))
+	pages, err := pagination.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	ordersv1.RegisterOrderServiceServer(grpcServer, grpcserver.NewServer(orderService, pages, log))
 
 	// Register health check
 	healthServer := health.NewServer()
@@ -141,12 +326,12 @@ func run() error {
 	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_SERVING)
 
 	// Register reflection for development
-	if getEnv("ENV", "development") == "development" {
+	if config.Getenv("ENV", "development") == "development" {
 		reflection.Register(grpcServer)
 	}
 
 	// Start metrics server
-	metricsPort := getEnv("METRICS_PORT", "9092")
+	metricsPort := config.Getenv("METRICS_PORT", "9092")
 	go func() {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
@@ -155,12 +340,28 @@ func run() error {
 			_, _ = w.Write([]byte("OK"))
 		})
 		mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
-			if err := database.HealthCheck(r.Context(), db); err != nil {
+			// The body reports pool saturation alongside the verdict
+			dbStatus, err := database.HealthCheckDetailed(r.Context(), db, database.DefaultHealthCheckTimeout)
+			w.Header().Set("Content-Type", "application/json")
+			if err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_ = json.NewEncoder(w).Encode(dbStatus)
+				return
+			}
+			// Events stop flowing if the outbox worker dies or its topics are
+			// missing; report not ready
+			if err := topicCheck.Verify(r.Context()); err != nil {
+				log.Warn("readiness check failed", zap.Error(err))
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if err := outboxPublisher.CheckHeartbeat(3); err != nil {
+				log.Warn("readiness check failed", zap.Error(err))
 				w.WriteHeader(http.StatusServiceUnavailable)
 				return
 			}
 			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("READY"))
+			_ = json.NewEncoder(w).Encode(dbStatus)
 		})
 
 		log.Info("starting metrics server", zap.String("port", metricsPort))
@@ -193,24 +394,45 @@ func run() error {
 		}
 	}()
 
-	// Wait for interrupt signal
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
-
-	log.Info("shutting down gracefully...")
-
-	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
-	time.Sleep(5 * time.Second)
-	grpcServer.GracefulStop()
+	// Fail health checks, let load balancers drain, then stop the server
+	shutdowner := shutdown.New(shutdown.Config{
+		DrainDelay: config.GetenvDuration("SHUTDOWN_DRAIN_DELAY", shutdown.DefaultDrainDelay),
+		Timeout:    config.GetenvDuration("SHUTDOWN_TIMEOUT", shutdown.DefaultTimeout),
+	}, log)
+	shutdowner.PreStop("health", func(ctx context.Context) error {
+		healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		return nil
+	})
+	shutdowner.OnShutdown("grpc", func(ctx context.Context) error {
+		// Stop forces remaining RPCs closed after its own timeout
+		grpcserverpkg.Stop(grpcServer, config.GetenvDuration("GRPC_SHUTDOWN_TIMEOUT", grpcserverpkg.DefaultShutdownTimeout), log)
+		return nil
+	})
+	if err := shutdowner.Wait(ctx); err != nil {
+		log.Warn("shutdown did not complete cleanly", zap.Error(err))
+	}
 
 	log.Info("server stopped")
 	return nil
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// parseAmountLimits parses "USD=500000,EUR=450000" into per-currency limits
+func parseAmountLimits(spec string) (map[string]int64, error) {
+	limits := make(map[string]int64)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		currency, amount, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected CURRENCY=AMOUNT, got %q", entry)
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(amount), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount for %s: %w", currency, err)
+		}
+		limits[strings.ToUpper(strings.TrimSpace(currency))] = limit
 	}
-	return defaultValue
+	return limits, nil
 }
@@ -0,0 +1,60 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	catalogv1 "github.com/mumumio1/coldy/proto/catalog/v1"
+	"github.com/mumumio1/coldy/services/orders/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Client looks up products in the catalog service
+type Client struct {
+	client catalogv1.CatalogServiceClient
+}
+
+// NewClient creates a new catalog client
+func NewClient(client catalogv1.CatalogServiceClient) *Client {
+	return &Client{client: client}
+}
+
+// ResolveSKU returns the ID of the product with sku, or "" if there is none
+func (c *Client) ResolveSKU(ctx context.Context, sku string) (string, error) {
+	resp, err := c.client.GetProductBySKU(ctx, &catalogv1.GetProductBySKURequest{Sku: sku})
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("catalog get by sku failed: %w", err)
+	}
+	return resp.Product.Id, nil
+}
+
+// QuotePrices returns the current catalog price and name of each product,
+// keyed by product ID. Unknown products are left out.
+func (c *Client) QuotePrices(ctx context.Context, productIDs []string) (map[string]service.ProductQuote, error) {
+	checks := make([]*catalogv1.StockCheck, len(productIDs))
+	for i, id := range productIDs {
+		checks[i] = &catalogv1.StockCheck{ProductId: id}
+	}
+
+	resp, err := c.client.QuoteItems(ctx, &catalogv1.QuoteItemsRequest{Items: checks})
+	if err != nil {
+		return nil, fmt.Errorf("catalog quote items failed: %w", err)
+	}
+
+	quotes := make(map[string]service.ProductQuote, len(resp.Quotes))
+	for _, quote := range resp.Quotes {
+		if !quote.Found {
+			continue
+		}
+		quotes[quote.ProductId] = service.ProductQuote{
+			Name:     quote.Name,
+			Currency: quote.GetPrice().GetCurrency(),
+			Amount:   quote.GetPrice().GetAmount(),
+		}
+	}
+	return quotes, nil
+}
@@ -2,7 +2,11 @@ package grpc
 
 import (
 	"context"
+	"time"
 
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/pagination"
 	commonv1 "github.com/mumumio1/coldy/proto/common/v1"
 	ordersv1 "github.com/mumumio1/coldy/proto/orders/v1"
 	"github.com/mumumio1/coldy/services/orders/internal/repository"
@@ -17,13 +21,15 @@ import (
 type Server struct {
 	ordersv1.UnimplementedOrderServiceServer
 	orderService *service.OrderService
+	pages        pagination.Config
 	logger       *zap.Logger
 }
 
 // NewServer creates a new gRPC server
-func NewServer(orderService *service.OrderService, logger *zap.Logger) *Server {
+func NewServer(orderService *service.OrderService, pages pagination.Config, logger *zap.Logger) *Server {
 	return &Server{
 		orderService: orderService,
+		pages:        pages,
 		logger:       logger,
 	}
 }
@@ -39,12 +45,16 @@ func (s *Server) CreateOrder(ctx context.Context, req *ordersv1.CreateOrderReque
 	if len(req.Items) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "items are required")
 	}
+	if req.ShippingAddress == nil {
+		return nil, status.Error(codes.InvalidArgument, "shipping_address is required")
+	}
 
 	// Convert request items
 	items := make([]service.OrderItemRequest, len(req.Items))
 	for i, item := range req.Items {
 		items[i] = service.OrderItemRequest{
 			ProductID: item.ProductId,
+			SKU:       item.Sku,
 			Quantity:  item.Quantity,
 		}
 	}
@@ -58,12 +68,12 @@ func (s *Server) CreateOrder(ctx context.Context, req *ordersv1.CreateOrderReque
 		ShippingState:      req.ShippingAddress.State,
 		ShippingPostalCode: req.ShippingAddress.PostalCode,
 		ShippingCountry:    req.ShippingAddress.Country,
+		Currency:           req.Currency,
 	}
 
 	order, fromCache, err := s.orderService.CreateOrder(ctx, req.IdempotencyKey, orderReq)
 	if err != nil {
-		s.logger.Error("failed to create order", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to create order")
+		return nil, s.toStatus(ctx, err, "failed to create order")
 	}
 
 	return &ordersv1.CreateOrderResponse{
@@ -80,8 +90,7 @@ func (s *Server) GetOrder(ctx context.Context, req *ordersv1.GetOrderRequest) (*
 
 	order, err := s.orderService.GetOrder(ctx, req.OrderId)
 	if err != nil {
-		s.logger.Error("failed to get order", zap.Error(err))
-		return nil, status.Error(codes.NotFound, "order not found")
+		return nil, s.toStatus(ctx, err, "failed to get order")
 	}
 
 	return &ordersv1.GetOrderResponse{
@@ -95,12 +104,9 @@ func (s *Server) ListOrders(ctx context.Context, req *ordersv1.ListOrdersRequest
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
 
-	pageSize := int(req.Pagination.PageSize)
-	if pageSize <= 0 {
-		pageSize = 20
-	}
-	if pageSize > 100 {
-		pageSize = 100
+	pageSize, err := s.pages.Clamp(ordersv1.OrderService_ListOrders_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	orderStatus := repository.OrderStatus("")
@@ -113,11 +119,10 @@ func (s *Server) ListOrders(ctx context.Context, req *ordersv1.ListOrdersRequest
 		req.UserId,
 		orderStatus,
 		pageSize,
-		req.Pagination.Cursor,
+		req.GetPagination().GetCursor(),
 	)
 	if err != nil {
-		s.logger.Error("failed to list orders", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to list orders")
+		return nil, s.toStatus(ctx, err, "failed to list orders")
 	}
 
 	protoOrders := make([]*ordersv1.Order, len(orders))
@@ -134,21 +139,168 @@ func (s *Server) ListOrders(ctx context.Context, req *ordersv1.ListOrdersRequest
 	}, nil
 }
 
+// ListOrdersByStatus lists orders of all users in a status
+func (s *Server) ListOrdersByStatus(ctx context.Context, req *ordersv1.ListOrdersByStatusRequest) (*ordersv1.ListOrdersByStatusResponse, error) {
+	if req.Status == ordersv1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
+
+	pageSize, err := s.pages.Clamp(ordersv1.OrderService_ListOrdersByStatus_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cursor := req.GetPagination().GetCursor()
+
+	var createdFrom, createdTo time.Time
+	if req.CreatedFrom != nil {
+		createdFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		createdTo = req.CreatedTo.AsTime()
+	}
+
+	orders, nextCursor, hasMore, err := s.orderService.ListOrdersByStatus(
+		ctx,
+		toRepoStatus(req.Status),
+		createdFrom,
+		createdTo,
+		pageSize,
+		cursor,
+	)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to list orders")
+	}
+
+	protoOrders := make([]*ordersv1.Order, len(orders))
+	for i, order := range orders {
+		protoOrders[i] = toProtoOrder(order)
+	}
+
+	return &ordersv1.ListOrdersByStatusResponse{
+		Orders: protoOrders,
+		Pagination: &commonv1.PaginationResponse{
+			NextCursor: nextCursor,
+			HasMore:    hasMore,
+		},
+	}, nil
+}
+
+// ListOrdersByProduct lists orders of all users containing a product
+func (s *Server) ListOrdersByProduct(ctx context.Context, req *ordersv1.ListOrdersByProductRequest) (*ordersv1.ListOrdersByProductResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	pageSize, err := s.pages.Clamp(ordersv1.OrderService_ListOrdersByProduct_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cursor := req.GetPagination().GetCursor()
+
+	orderStatus := repository.OrderStatus("")
+	if req.StatusFilter != ordersv1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		orderStatus = toRepoStatus(req.StatusFilter)
+	}
+
+	orders, nextCursor, hasMore, err := s.orderService.ListOrdersByProduct(ctx, req.ProductId, orderStatus, pageSize, cursor)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to list orders")
+	}
+
+	protoOrders := make([]*ordersv1.Order, len(orders))
+	for i, order := range orders {
+		protoOrders[i] = toProtoOrder(order)
+	}
+
+	return &ordersv1.ListOrdersByProductResponse{
+		Orders: protoOrders,
+		Pagination: &commonv1.PaginationResponse{
+			NextCursor: nextCursor,
+			HasMore:    hasMore,
+		},
+	}, nil
+}
+
+// ReplayOutboxEvents re-queues published outbox events for redelivery
+func (s *Server) ReplayOutboxEvents(ctx context.Context, req *ordersv1.ReplayOutboxEventsRequest) (*ordersv1.ReplayOutboxEventsResponse, error) {
+	var createdFrom, createdTo time.Time
+	if req.CreatedFrom != nil {
+		createdFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		createdTo = req.CreatedTo.AsTime()
+	}
+
+	count, err := s.orderService.ReplayOutboxEvents(ctx, req.EventIds, req.AggregateId, createdFrom, createdTo)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to replay outbox events")
+	}
+
+	return &ordersv1.ReplayOutboxEventsResponse{ReplayedCount: count}, nil
+}
+
+// ListDeadLetteredEvents lists outbox events the publisher gave up on
+func (s *Server) ListDeadLetteredEvents(ctx context.Context, req *ordersv1.ListDeadLetteredEventsRequest) (*ordersv1.ListDeadLetteredEventsResponse, error) {
+	pageSize, err := s.pages.Clamp(ordersv1.OrderService_ListDeadLetteredEvents_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	events, nextCursor, hasMore, err := s.orderService.ListDeadLetteredEvents(ctx, pageSize, req.GetPagination().GetCursor())
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to list dead-lettered events")
+	}
+
+	protoEvents := make([]*ordersv1.OutboxEvent, len(events))
+	for i, event := range events {
+		protoEvents[i] = &ordersv1.OutboxEvent{
+			Id:            event.ID,
+			AggregateType: event.AggregateType,
+			AggregateId:   event.AggregateID,
+			EventType:     event.EventType,
+			Payload:       string(event.Data),
+			Attempts:      int32(event.Attempts),
+			LastError:     event.LastError,
+			CreatedAt:     timestamppb.New(event.CreatedAt),
+		}
+		if event.DeadLetteredAt != nil {
+			protoEvents[i].DeadLetteredAt = timestamppb.New(*event.DeadLetteredAt)
+		}
+	}
+
+	return &ordersv1.ListDeadLetteredEventsResponse{
+		Events: protoEvents,
+		Pagination: &commonv1.PaginationResponse{
+			NextCursor: nextCursor,
+			HasMore:    hasMore,
+		},
+	}, nil
+}
+
+// RequeueDeadLetteredEvents hands dead-lettered outbox events back to the
+// publisher
+func (s *Server) RequeueDeadLetteredEvents(ctx context.Context, req *ordersv1.RequeueDeadLetteredEventsRequest) (*ordersv1.RequeueDeadLetteredEventsResponse, error) {
+	count, err := s.orderService.RequeueDeadLetteredEvents(ctx, req.EventIds)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to requeue dead-lettered events")
+	}
+
+	return &ordersv1.RequeueDeadLetteredEventsResponse{RequeuedCount: count}, nil
+}
+
 // CancelOrder cancels an order
 func (s *Server) CancelOrder(ctx context.Context, req *ordersv1.CancelOrderRequest) (*ordersv1.CancelOrderResponse, error) {
 	if req.OrderId == "" {
 		return nil, status.Error(codes.InvalidArgument, "order_id is required")
 	}
 
-	if err := s.orderService.CancelOrder(ctx, req.OrderId, req.Reason); err != nil {
-		s.logger.Error("failed to cancel order", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to cancel order")
+	if err := s.orderService.CancelOrder(ctx, req.IdempotencyKey, req.OrderId, req.Reason); err != nil {
+		return nil, s.toStatus(ctx, err, "failed to cancel order")
 	}
 
 	order, err := s.orderService.GetOrder(ctx, req.OrderId)
 	if err != nil {
-		s.logger.Error("failed to get order", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to get order")
+		return nil, s.toStatus(ctx, err, "failed to get order")
 	}
 
 	return &ordersv1.CancelOrderResponse{
@@ -163,15 +315,13 @@ func (s *Server) UpdateOrderStatus(ctx context.Context, req *ordersv1.UpdateOrde
 	}
 
 	repoStatus := toRepoStatus(req.Status)
-	if err := s.orderService.UpdateOrderStatus(ctx, req.OrderId, repoStatus); err != nil {
-		s.logger.Error("failed to update order status", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to update order status")
+	if err := s.orderService.UpdateOrderStatus(ctx, req.IdempotencyKey, req.OrderId, repoStatus); err != nil {
+		return nil, s.toStatus(ctx, err, "failed to update order status")
 	}
 
 	order, err := s.orderService.GetOrder(ctx, req.OrderId)
 	if err != nil {
-		s.logger.Error("failed to get order", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to get order")
+		return nil, s.toStatus(ctx, err, "failed to get order")
 	}
 
 	return &ordersv1.UpdateOrderStatusResponse{
@@ -179,6 +329,95 @@ func (s *Server) UpdateOrderStatus(ctx context.Context, req *ordersv1.UpdateOrde
 	}, nil
 }
 
+// UpdateOrderItems adds, removes or changes items of a pending order
+func (s *Server) UpdateOrderItems(ctx context.Context, req *ordersv1.UpdateOrderItemsRequest) (*ordersv1.UpdateOrderItemsResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if len(req.Changes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "changes are required")
+	}
+
+	changes := make([]service.ItemChange, len(req.Changes))
+	for i, change := range req.Changes {
+		changes[i] = service.ItemChange{
+			ProductID: change.ProductId,
+			SKU:       change.Sku,
+			Quantity:  change.Quantity,
+		}
+	}
+
+	order, err := s.orderService.UpdateOrderItems(ctx, req.OrderId, changes)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to update order items")
+	}
+
+	return &ordersv1.UpdateOrderItemsResponse{
+		Order: toProtoOrder(order),
+	}, nil
+}
+
+// VerifyOrderTotal checks an order's total against its items and returns it
+func (s *Server) VerifyOrderTotal(ctx context.Context, req *ordersv1.VerifyOrderTotalRequest) (*ordersv1.VerifyOrderTotalResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	total, err := s.orderService.VerifyTotal(ctx, req.OrderId)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to verify order total")
+	}
+
+	return &ordersv1.VerifyOrderTotalResponse{
+		Total: &commonv1.Money{
+			Currency: total.Currency,
+			Amount:   total.Amount,
+		},
+	}, nil
+}
+
+// GetOrdersSummary returns daily order counts and revenue for dashboards
+func (s *Server) GetOrdersSummary(ctx context.Context, req *ordersv1.GetOrdersSummaryRequest) (*ordersv1.GetOrdersSummaryResponse, error) {
+	filter := repository.SummaryFilter{UserID: req.UserId}
+	if req.StatusFilter != ordersv1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		filter.Status = toRepoStatus(req.StatusFilter)
+	}
+	if req.CreatedFrom != nil {
+		filter.From = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.To = req.CreatedTo.AsTime()
+	}
+
+	buckets, err := s.orderService.OrdersSummary(ctx, filter)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to summarize orders")
+	}
+
+	days := make([]*ordersv1.DailyOrderSummary, len(buckets))
+	for i, b := range buckets {
+		days[i] = &ordersv1.DailyOrderSummary{
+			Day:        timestamppb.New(b.Day),
+			OrderCount: b.OrderCount,
+			TotalAmount: &commonv1.Money{
+				Currency: b.Currency,
+				Amount:   b.TotalAmount,
+			},
+		}
+	}
+
+	return &ordersv1.GetOrdersSummaryResponse{Days: days}, nil
+}
+
+// toStatus maps domain errors to gRPC status codes
+func (s *Server) toStatus(ctx context.Context, err error, msg string) error {
+	if errs.KindOf(err) == errs.KindInternal {
+		logger.FromContext(ctx).Error(msg, zap.Error(err))
+		return status.Error(codes.Internal, msg)
+	}
+	return errs.ToGRPCStatus(err).Err()
+}
+
 func toProtoOrder(order *repository.Order) *ordersv1.Order {
 	items := make([]*ordersv1.OrderItem, len(order.Items))
 	for i, item := range order.Items {
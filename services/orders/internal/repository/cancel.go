@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/database"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/middleware"
+)
+
+// ErrNotCancellable is returned when an order is in a status that can no
+// longer be canceled
+var ErrNotCancellable = errs.FailedPrecondition("ORDER_NOT_CANCELLABLE", "order cannot be canceled")
+
+// Cancel moves an order to canceled and writes event. If the order was paid,
+// including orders processing or shipped since, refundEvent is written as
+// well, so the refund request is recorded exactly once with the
+// cancellation. An order already canceled is left untouched and nothing is
+// written; delivered and refunded orders fail with ErrNotCancellable. The
+// status is checked under the order's row lock. changed reports whether the
+// order was canceled and refundRequested whether refundEvent was written.
+func (r *OrderRepository) Cancel(ctx context.Context, orderID string, event, refundEvent *OutboxEvent) (changed, refundRequested bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Lock the order so concurrent cancels request one refund
+	var current OrderStatus
+	err = tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = $1 AND ($2 = '' OR tenant_id = $2) FOR UPDATE`,
+		orderID, middleware.TenantFromContext(ctx)).Scan(&current)
+	if err == sql.ErrNoRows {
+		return false, false, ErrOrderNotFound
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("failed to lock order: %w", err)
+	}
+	if current == StatusCancelled {
+		return false, false, nil
+	}
+	if !current.Cancellable() {
+		return false, false, fmt.Errorf("%w: status %s", ErrNotCancellable, current)
+	}
+
+	query := `
+		UPDATE orders
+		SET status = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`
+
+	spanCtx, span := database.StartSpan(ctx, "orders.cancel", query)
+	_, err = tx.ExecContext(spanCtx, database.Annotate(spanCtx, query), StatusCancelled, orderID)
+	database.EndSpan(span, err)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	if err := insertOutboxEvent(ctx, tx, orderID, event); err != nil {
+		return false, false, err
+	}
+	refundRequested = current.Paid() && refundEvent != nil
+	if refundRequested {
+		if err := insertOutboxEvent(ctx, tx, orderID, refundEvent); err != nil {
+			return false, false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, refundRequested, nil
+}
+
+// insertOutboxEvent writes an order's outbox event inside tx
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, orderID string, event *OutboxEvent) error {
+	payloadJSON, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (id, aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+
+	event.ID = uuid.New().String()
+	event.AggregateID = orderID
+
+	err = tx.QueryRowContext(ctx, query,
+		event.ID,
+		event.AggregateType,
+		event.AggregateID,
+		event.EventType,
+		payloadJSON,
+	).Scan(&event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// MarkDeadLettered records a failed publish attempt of an outbox event and
+// stops the publisher from retrying it
+func (r *OrderRepository) MarkDeadLettered(ctx context.Context, eventID string, cause error) error {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $2, dead_lettered_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, eventID, cause.Error()); err != nil {
+		return fmt.Errorf("failed to dead-letter event: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeadLettered lists dead-lettered outbox events, oldest first. The
+// cursor is the ID of the last event of the previous page.
+func (r *OrderRepository) ListDeadLettered(ctx context.Context, limit int, cursor string) ([]*OutboxEvent, string, error) {
+	query := `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, attempts, COALESCE(last_error, ''), dead_lettered_at, created_at
+		FROM outbox
+		WHERE dead_lettered_at IS NOT NULL
+	`
+	var args []interface{}
+	argIdx := 1
+
+	if cursor != "" {
+		query += fmt.Sprintf(" AND (dead_lettered_at, id) > (SELECT dead_lettered_at, id FROM outbox WHERE id = $%d)", argIdx)
+		args = append(args, cursor)
+		argIdx++
+	}
+
+	query += " ORDER BY dead_lettered_at, id"
+	query += fmt.Sprintf(" LIMIT $%d", argIdx)
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list dead-lettered events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*OutboxEvent
+	for rows.Next() {
+		var event OutboxEvent
+		var deadLetteredAt sql.NullTime
+		err := rows.Scan(
+			&event.ID,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.EventType,
+			&event.Data,
+			&event.Attempts,
+			&event.LastError,
+			&deadLetteredAt,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan event: %w", err)
+		}
+		if deadLetteredAt.Valid {
+			event.DeadLetteredAt = &deadLetteredAt.Time
+		}
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("rows error: %w", err)
+	}
+
+	var nextCursor string
+	if len(events) > limit {
+		nextCursor = events[limit-1].ID
+		events = events[:limit]
+	}
+
+	return events, nextCursor, nil
+}
+
+// RequeueDeadLettered returns dead-lettered outbox events to the publisher
+// with a fresh set of attempts. It returns the number of events requeued;
+// IDs of events that are not dead-lettered are ignored.
+func (r *OrderRepository) RequeueDeadLettered(ctx context.Context, eventIDs []string) (int64, error) {
+	if len(eventIDs) == 0 {
+		return 0, nil
+	}
+
+	query := `
+		UPDATE outbox
+		SET dead_lettered_at = NULL, attempts = 0
+		WHERE id = ANY($1) AND dead_lettered_at IS NOT NULL AND published = false
+	`
+
+	result, err := r.db.ExecContext(ctx, query, pq.Array(eventIDs))
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue dead-lettered events: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return count, nil
+}
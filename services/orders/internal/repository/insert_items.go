@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// itemInsertBatchSize is the number of order items inserted per statement.
+// Each row takes itemInsertColumns parameters, well below Postgres' limit
+// of 65535 per statement.
+const itemInsertBatchSize = 500
+
+// itemInsertColumns is the number of columns insertItems sets per row
+const itemInsertColumns = 9
+
+// insertItems inserts items with multi-row INSERTs of up to
+// itemInsertBatchSize rows, so large orders take a few statements rather than
+// one per item. Items must have their ID and OrderID set; CreatedAt is filled
+// in from the database.
+func insertItems(ctx context.Context, tx *sql.Tx, items []*OrderItem) error {
+	for start := 0; start < len(items); start += itemInsertBatchSize {
+		end := min(start+itemInsertBatchSize, len(items))
+		if err := insertItemBatch(ctx, tx, items[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertItemBatch(ctx context.Context, tx *sql.Tx, items []*OrderItem) error {
+	var query strings.Builder
+	query.WriteString(`INSERT INTO order_items (id, order_id, product_id, product_name, quantity, unit_price_currency, unit_price_amount, total_price_currency, total_price_amount) VALUES `)
+
+	args := make([]interface{}, 0, len(items)*itemInsertColumns)
+	byID := make(map[string]*OrderItem, len(items))
+	for i, item := range items {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(")
+		for col := 0; col < itemInsertColumns; col++ {
+			if col > 0 {
+				query.WriteString(", ")
+			}
+			fmt.Fprintf(&query, "$%d", i*itemInsertColumns+col+1)
+		}
+		query.WriteString(")")
+
+		args = append(args,
+			item.ID,
+			item.OrderID,
+			item.ProductID,
+			item.ProductName,
+			item.Quantity,
+			item.UnitPriceCurrency,
+			item.UnitPriceAmount,
+			item.TotalPriceCurrency,
+			item.TotalPriceAmount,
+		)
+		byID[item.ID] = item
+	}
+	query.WriteString(" RETURNING id, created_at")
+
+	rows, err := tx.QueryContext(ctx, query.String(), args...)
+	if err != nil {
+		return fmt.Errorf("failed to insert order items: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			return fmt.Errorf("failed to scan order item: %w", err)
+		}
+		if item, ok := byID[id]; ok {
+			item.CreatedAt = createdAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to insert order items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// insertRecorder is a database/sql driver that records the item INSERTs it
+// receives and answers them with a created_at per row
+type insertRecorder struct {
+	mu         sync.Mutex
+	statements []recordedInsert
+	createdAt  time.Time
+}
+
+type recordedInsert struct {
+	rows int
+	args []driver.NamedValue
+}
+
+var registerInsertRecorder sync.Once
+
+func newInsertRecorder(t *testing.T) (*sql.DB, *insertRecorder) {
+	t.Helper()
+	registerInsertRecorder.Do(func() { sql.Register("orders-insert-recorder", recorderDriver{}) })
+
+	recorder := &insertRecorder{createdAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	recorders.Store(t.Name(), recorder)
+	db, err := sql.Open("orders-insert-recorder", t.Name())
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		recorders.Delete(t.Name())
+	})
+	return db, recorder
+}
+
+var recorders sync.Map
+
+type recorderDriver struct{}
+
+func (recorderDriver) Open(name string) (driver.Conn, error) {
+	recorder, ok := recorders.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("no fake database %q", name)
+	}
+	return &recorderConn{recorder: recorder.(*insertRecorder)}, nil
+}
+
+type recorderConn struct {
+	recorder *insertRecorder
+}
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *recorderConn) Close() error              { return nil }
+func (c *recorderConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *recorderConn) Commit() error             { return nil }
+func (c *recorderConn) Rollback() error           { return nil }
+
+func (c *recorderConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !strings.HasPrefix(query, "INSERT INTO order_items") {
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+	rows := strings.Count(query, "(") - 1
+	if rows*itemInsertColumns != len(args) {
+		return nil, fmt.Errorf("%d rows with %d args", rows, len(args))
+	}
+
+	r := c.recorder
+	r.mu.Lock()
+	r.statements = append(r.statements, recordedInsert{rows: rows, args: args})
+	r.mu.Unlock()
+
+	ids := make([]string, rows)
+	for i := range ids {
+		ids[i] = args[i*itemInsertColumns].Value.(string)
+	}
+	return &createdRows{ids: ids, createdAt: r.createdAt}, nil
+}
+
+// createdRows returns (id, created_at) for each inserted item
+type createdRows struct {
+	ids       []string
+	createdAt time.Time
+	next      int
+}
+
+func (r *createdRows) Columns() []string { return []string{"id", "created_at"} }
+func (r *createdRows) Close() error      { return nil }
+
+func (r *createdRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.ids) {
+		return io.EOF
+	}
+	dest[0], dest[1] = r.ids[r.next], r.createdAt
+	r.next++
+	return nil
+}
+
+func TestInsertItemsBatches(t *testing.T) {
+	tests := map[string]struct {
+		items     int
+		wantBatch []int
+	}{
+		"single item":       {items: 1, wantBatch: []int{1}},
+		"exactly one batch": {items: itemInsertBatchSize, wantBatch: []int{itemInsertBatchSize}},
+		"one over a batch":  {items: itemInsertBatchSize + 1, wantBatch: []int{itemInsertBatchSize, 1}},
+		"several batches":   {items: 2*itemInsertBatchSize + 7, wantBatch: []int{itemInsertBatchSize, itemInsertBatchSize, 7}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			db, recorder := newInsertRecorder(t)
+
+			items := make([]*OrderItem, tt.items)
+			for i := range items {
+				items[i] = &OrderItem{
+					ID:               fmt.Sprintf("item-%d", i),
+					OrderID:          "order-1",
+					ProductID:        fmt.Sprintf("product-%d", i),
+					Quantity:         int32(i + 1),
+					UnitPriceAmount:  100,
+					TotalPriceAmount: int64(100 * (i + 1)),
+				}
+			}
+
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				t.Fatalf("failed to begin: %v", err)
+			}
+			if err := insertItems(ctx, tx, items); err != nil {
+				t.Fatalf("insertItems failed: %v", err)
+			}
+			if err := tx.Commit(); err != nil {
+				t.Fatalf("failed to commit: %v", err)
+			}
+
+			if len(recorder.statements) != len(tt.wantBatch) {
+				t.Fatalf("issued %d statements, want %d", len(recorder.statements), len(tt.wantBatch))
+			}
+			for i, want := range tt.wantBatch {
+				if got := recorder.statements[i].rows; got != want {
+					t.Errorf("statement %d inserted %d rows, want %d", i, got, want)
+				}
+			}
+
+			// Rows keep their order and every item gets its created_at
+			last := recorder.statements[len(recorder.statements)-1]
+			if got := last.args[(last.rows-1)*itemInsertColumns].Value; got != items[len(items)-1].ID {
+				t.Errorf("last row inserted %v, want %s", got, items[len(items)-1].ID)
+			}
+			for _, item := range items {
+				if !item.CreatedAt.Equal(recorder.createdAt) {
+					t.Fatalf("item %s created_at = %v, want it read back", item.ID, item.CreatedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestInsertItemsStaysUnderParameterLimit(t *testing.T) {
+	if params := itemInsertBatchSize * itemInsertColumns; params > 65535 {
+		t.Errorf("a full batch binds %d parameters, over the Postgres limit", params)
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mumumio1/coldy/pkg/database"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/middleware"
+)
+
+// ErrOrderChanged is returned when an order's status or items changed after
+// it was read for an update
+var ErrOrderChanged = errs.Conflict("ORDER_CHANGED", "order changed concurrently")
+
+// ReplaceItems replaces a pending order's items and total with those of order
+// and writes event, all in one transaction. Items with an ID are updated,
+// items without one are inserted and the rest are deleted. It fails with
+// ErrOrderChanged unless the order is still pending and was last updated at
+// order.UpdatedAt, so a change based on a stale read is not applied.
+func (r *OrderRepository) ReplaceItems(ctx context.Context, order *Order, event *OutboxEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var current OrderStatus
+	var updatedAt time.Time
+	err = tx.QueryRowContext(ctx, `SELECT status, updated_at FROM orders WHERE id = $1 AND ($2 = '' OR tenant_id = $2) FOR UPDATE`,
+		order.ID, middleware.TenantFromContext(ctx)).Scan(&current, &updatedAt)
+	if err == sql.ErrNoRows {
+		return ErrOrderNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock order: %w", err)
+	}
+	if current != StatusPending || !updatedAt.Equal(order.UpdatedAt) {
+		return ErrOrderChanged
+	}
+
+	kept := make([]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		if item.ID != "" {
+			kept = append(kept, item.ID)
+		}
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM order_items WHERE order_id = $1 AND NOT (id = ANY($2))`,
+		order.ID, pq.Array(kept))
+	if err != nil {
+		return fmt.Errorf("failed to delete order items: %w", err)
+	}
+
+	updateItemQuery := `
+		UPDATE order_items
+		SET product_name = $1, quantity = $2, unit_price_currency = $3, unit_price_amount = $4,
+		    total_price_currency = $5, total_price_amount = $6
+		WHERE id = $7 AND order_id = $8
+	`
+	var inserted []*OrderItem
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.ID != "" {
+			_, err = tx.ExecContext(ctx, updateItemQuery,
+				item.ProductName,
+				item.Quantity,
+				item.UnitPriceCurrency,
+				item.UnitPriceAmount,
+				item.TotalPriceCurrency,
+				item.TotalPriceAmount,
+				item.ID,
+				order.ID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to update order item: %w", err)
+			}
+			continue
+		}
+
+		item.ID = uuid.New().String()
+		item.OrderID = order.ID
+		inserted = append(inserted, item)
+	}
+	if err := insertItems(ctx, tx, inserted); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE orders
+		SET total_currency = $1, total_amount = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	spanCtx, span := database.StartSpan(ctx, "orders.replace_items", query)
+	err = tx.QueryRowContext(spanCtx, database.Annotate(spanCtx, query),
+		order.TotalCurrency, order.TotalAmount, order.ID).Scan(&order.UpdatedAt)
+	database.EndSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to update order total: %w", err)
+	}
+
+	payloadJSON, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	outboxQuery := `
+		INSERT INTO outbox (id, aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	event.ID = uuid.New().String()
+	event.AggregateID = order.ID
+
+	_, err = tx.ExecContext(ctx, outboxQuery,
+		event.ID,
+		event.AggregateType,
+		event.AggregateID,
+		event.EventType,
+		payloadJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
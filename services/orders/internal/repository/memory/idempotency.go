@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/idempotency"
+	"github.com/mumumio1/coldy/services/orders/internal/service"
+)
+
+var _ service.IdempotencyStore = (*IdempotencyStore)(nil)
+
+// IdempotencyStore keeps idempotency results in memory, encoded as the Redis
+// store encodes them. Results never expire. It is safe for concurrent use.
+type IdempotencyStore struct {
+	mu       sync.Mutex
+	results  map[string][]byte
+	failOpen bool
+	err      error
+}
+
+// NewIdempotencyStore creates an empty store. failOpen is reported by
+// FailOpen, the policy callers apply while the store is unavailable.
+func NewIdempotencyStore(failOpen bool) *IdempotencyStore {
+	return &IdempotencyStore{
+		results:  make(map[string][]byte),
+		failOpen: failOpen,
+	}
+}
+
+// SetUnavailable makes Get and Set fail with idempotency.ErrUnavailable, as
+// during a Redis outage; false restores the store
+func (s *IdempotencyStore) SetUnavailable(unavailable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = nil
+	if unavailable {
+		s.err = fmt.Errorf("%w: store offline", idempotency.ErrUnavailable)
+	}
+}
+
+// Len returns the number of stored results
+func (s *IdempotencyStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.results)
+}
+
+// Get returns the result stored under key
+func (s *IdempotencyStore) Get(_ context.Context, _ string, key string) (*idempotency.Result, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, false, s.err
+	}
+	data, ok := s.results[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var result idempotency.Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return &result, true, nil
+}
+
+// Set stores a result under key
+func (s *IdempotencyStore) Set(_ context.Context, _ string, key string, statusCode int, body interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal body: %w", err)
+	}
+	data, err := json.Marshal(idempotency.Result{
+		StatusCode: statusCode,
+		Body:       bodyBytes,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.results[key] = data
+	return nil
+}
+
+// FailOpen reports the store's failure policy
+func (s *IdempotencyStore) FailOpen() bool {
+	return s.failOpen
+}
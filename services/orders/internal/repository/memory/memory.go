@@ -0,0 +1,562 @@
+// Package memory provides an in-memory OrderStore for exercising the order
+// service without Postgres. It mirrors the SQL repository's observable
+// behavior: tenant scoping, newest-first keyset pagination, a nil order for an
+// unknown ID and the pending/updated_at check of ReplaceItems.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/outbox"
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+	"github.com/mumumio1/coldy/services/orders/internal/service"
+)
+
+var (
+	_ service.OrderStore = (*OrderStore)(nil)
+	_ outbox.Store       = (*OrderStore)(nil)
+)
+
+type storedOrder struct {
+	order    repository.Order
+	tenantID string
+}
+
+// OrderStore keeps orders and outbox events in memory. It is safe for
+// concurrent use.
+type OrderStore struct {
+	mu     sync.Mutex
+	orders map[string]*storedOrder
+	outbox []*repository.OutboxEvent
+	last   time.Time
+}
+
+// NewOrderStore creates an empty store
+func NewOrderStore() *OrderStore {
+	return &OrderStore{orders: make(map[string]*storedOrder)}
+}
+
+// now returns a strictly increasing timestamp so ordering and the updated_at
+// check behave as they do against the database
+func (s *OrderStore) now() time.Time {
+	t := time.Now().UTC()
+	if !t.After(s.last) {
+		t = s.last.Add(time.Microsecond)
+	}
+	s.last = t
+	return t
+}
+
+// CreateWithOutbox stores order and event
+func (s *OrderStore) CreateWithOutbox(ctx context.Context, order *repository.Order, event *repository.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if order.ID == "" {
+		order.ID = uuid.New().String()
+	}
+	if _, exists := s.orders[order.ID]; exists {
+		return fmt.Errorf("failed to insert order: duplicate id %s", order.ID)
+	}
+
+	now := s.now()
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	for i := range order.Items {
+		order.Items[i].ID = uuid.New().String()
+		order.Items[i].OrderID = order.ID
+		order.Items[i].CreatedAt = now
+	}
+
+	event.AggregateID = order.ID
+	if err := s.appendEvent(event); err != nil {
+		return err
+	}
+
+	s.orders[order.ID] = &storedOrder{order: cloneOrder(order), tenantID: middleware.TenantFromContext(ctx)}
+	return nil
+}
+
+// InsertOutboxEvent stores an event not tied to an order change
+func (s *OrderStore) InsertOutboxEvent(ctx context.Context, event *repository.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.appendEvent(event)
+}
+
+// GetByID returns the order with its items, or nil if it does not exist
+func (s *OrderStore) GetByID(ctx context.Context, id string) (*repository.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.lookup(ctx, id)
+	if stored == nil {
+		return nil, nil
+	}
+	order := cloneOrder(&stored.order)
+	return &order, nil
+}
+
+// UpdateStatus moves an order to status and stores event. An order already in
+// status is left untouched.
+func (s *OrderStore) UpdateStatus(ctx context.Context, orderID string, status repository.OrderStatus, event *repository.OutboxEvent) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.lookup(ctx, orderID)
+	if stored == nil {
+		return false, repository.ErrOrderNotFound
+	}
+	if stored.order.Status == status {
+		return false, nil
+	}
+
+	if event != nil {
+		event.AggregateID = orderID
+		if err := s.appendEvent(event); err != nil {
+			return false, err
+		}
+	}
+
+	stored.order.Status = status
+	stored.order.UpdatedAt = s.now()
+	return true, nil
+}
+
+// Cancel moves an order to canceled and stores event, and refundEvent if the
+// order was paid. An order already canceled is left untouched and one that
+// can't be canceled fails with repository.ErrNotCancellable.
+func (s *OrderStore) Cancel(ctx context.Context, orderID string, event, refundEvent *repository.OutboxEvent) (bool, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.lookup(ctx, orderID)
+	if stored == nil {
+		return false, false, repository.ErrOrderNotFound
+	}
+	if stored.order.Status == repository.StatusCancelled {
+		return false, false, nil
+	}
+	if !stored.order.Status.Cancellable() {
+		return false, false, fmt.Errorf("%w: status %s", repository.ErrNotCancellable, stored.order.Status)
+	}
+
+	event.AggregateID = orderID
+	if err := s.appendEvent(event); err != nil {
+		return false, false, err
+	}
+	refundRequested := stored.order.Status.Paid() && refundEvent != nil
+	if refundRequested {
+		refundEvent.AggregateID = orderID
+		if err := s.appendEvent(refundEvent); err != nil {
+			return false, false, err
+		}
+	}
+
+	stored.order.Status = repository.StatusCancelled
+	stored.order.UpdatedAt = s.now()
+	return true, refundRequested, nil
+}
+
+// ReplaceItems replaces a pending order's items and total and stores event.
+// It fails with repository.ErrOrderChanged unless the order is still pending
+// and was last updated at order.UpdatedAt.
+func (s *OrderStore) ReplaceItems(ctx context.Context, order *repository.Order, event *repository.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.lookup(ctx, order.ID)
+	if stored == nil {
+		return repository.ErrOrderNotFound
+	}
+	if stored.order.Status != repository.StatusPending || !stored.order.UpdatedAt.Equal(order.UpdatedAt) {
+		return repository.ErrOrderChanged
+	}
+
+	now := s.now()
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.ID == "" {
+			item.ID = uuid.New().String()
+			item.OrderID = order.ID
+			item.CreatedAt = now
+		}
+	}
+
+	event.AggregateID = order.ID
+	if err := s.appendEvent(event); err != nil {
+		return err
+	}
+
+	order.UpdatedAt = now
+	stored.order.Items = cloneOrder(order).Items
+	stored.order.TotalCurrency = order.TotalCurrency
+	stored.order.TotalAmount = order.TotalAmount
+	stored.order.UpdatedAt = now
+	return nil
+}
+
+// List returns a user's orders, newest first
+func (s *OrderStore) List(ctx context.Context, userID string, status repository.OrderStatus, limit int, cursor string) ([]*repository.Order, string, error) {
+	return s.listPage(ctx, limit, cursor, func(o *repository.Order) bool {
+		return o.UserID == userID && (status == "" || o.Status == status)
+	})
+}
+
+// ListAllByStatus returns orders of every user in status, newest first
+func (s *OrderStore) ListAllByStatus(ctx context.Context, status repository.OrderStatus, createdFrom, createdTo time.Time, limit int, cursor string) ([]*repository.Order, string, error) {
+	return s.listPage(ctx, limit, cursor, func(o *repository.Order) bool {
+		return o.Status == status &&
+			(createdFrom.IsZero() || !o.CreatedAt.Before(createdFrom)) &&
+			(createdTo.IsZero() || o.CreatedAt.Before(createdTo))
+	})
+}
+
+// ListByProduct returns orders containing productID, newest first
+func (s *OrderStore) ListByProduct(ctx context.Context, productID string, status repository.OrderStatus, limit int, cursor string) ([]*repository.Order, string, error) {
+	return s.listPage(ctx, limit, cursor, func(o *repository.Order) bool {
+		if status != "" && o.Status != status {
+			return false
+		}
+		for _, item := range o.Items {
+			if item.ProductID == productID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Summary counts orders and sums their totals per UTC day and currency
+func (s *OrderStore) Summary(ctx context.Context, filter repository.SummaryFilter) ([]repository.DailyBucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type key struct {
+		day      time.Time
+		currency string
+	}
+	totals := make(map[key]*repository.DailyBucket)
+	tenantID := middleware.TenantFromContext(ctx)
+	for _, stored := range s.orders {
+		o := &stored.order
+		if tenantID != "" && stored.tenantID != tenantID {
+			continue
+		}
+		if o.CreatedAt.Before(filter.From) || !o.CreatedAt.Before(filter.To) {
+			continue
+		}
+		if (filter.UserID != "" && o.UserID != filter.UserID) || (filter.Status != "" && o.Status != filter.Status) {
+			continue
+		}
+
+		created := o.CreatedAt.UTC()
+		k := key{day: time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC), currency: o.TotalCurrency}
+		bucket, ok := totals[k]
+		if !ok {
+			bucket = &repository.DailyBucket{Day: k.day, Currency: k.currency}
+			totals[k] = bucket
+		}
+		bucket.OrderCount++
+		bucket.TotalAmount += o.TotalAmount
+	}
+
+	buckets := make([]repository.DailyBucket, 0, len(totals))
+	for _, bucket := range totals {
+		buckets = append(buckets, *bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if !buckets[i].Day.Equal(buckets[j].Day) {
+			return buckets[i].Day.Before(buckets[j].Day)
+		}
+		return buckets[i].Currency < buckets[j].Currency
+	})
+	return buckets, nil
+}
+
+// ResetPublished marks published events unpublished again
+func (s *OrderStore) ResetPublished(ctx context.Context, eventIDs []string) (int64, error) {
+	ids := make(map[string]bool, len(eventIDs))
+	for _, id := range eventIDs {
+		ids[id] = true
+	}
+	return s.resetPublished(func(e *repository.OutboxEvent) bool { return ids[e.ID] }), nil
+}
+
+// ResetPublishedForAggregate marks the published events of an aggregate
+// unpublished again
+func (s *OrderStore) ResetPublishedForAggregate(ctx context.Context, aggregateID string, createdFrom, createdTo time.Time) (int64, error) {
+	return s.resetPublished(func(e *repository.OutboxEvent) bool {
+		return e.AggregateID == aggregateID &&
+			(createdFrom.IsZero() || !e.CreatedAt.Before(createdFrom)) &&
+			(createdTo.IsZero() || e.CreatedAt.Before(createdTo))
+	}), nil
+}
+
+// GetUnpublished returns up to limit unpublished events, oldest first
+func (s *OrderStore) GetUnpublished(ctx context.Context, limit int) ([]outbox.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []outbox.Event
+	for _, event := range s.outbox {
+		if len(events) == limit {
+			break
+		}
+		if !event.Published && event.DeadLetteredAt == nil {
+			events = append(events, outbox.Event{
+				ID:            event.ID,
+				AggregateType: event.AggregateType,
+				AggregateID:   event.AggregateID,
+				EventType:     event.EventType,
+				Data:          event.Data,
+				Attempts:      event.Attempts,
+			})
+		}
+	}
+	return events, nil
+}
+
+// MarkPublished marks an event as published
+func (s *OrderStore) MarkPublished(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range s.outbox {
+		if event.ID == eventID {
+			now := s.now()
+			event.Published = true
+			event.PublishedAt = &now
+			return nil
+		}
+	}
+	return fmt.Errorf("event not found")
+}
+
+// MarkFailed records a failed publish attempt
+func (s *OrderStore) MarkFailed(ctx context.Context, eventID string, cause error) error {
+	return s.markFailed(eventID, cause, false)
+}
+
+// MarkDeadLettered records a failed publish attempt and stops returning the
+// event from GetUnpublished
+func (s *OrderStore) MarkDeadLettered(ctx context.Context, eventID string, cause error) error {
+	return s.markFailed(eventID, cause, true)
+}
+
+func (s *OrderStore) markFailed(eventID string, cause error, deadLetter bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range s.outbox {
+		if event.ID == eventID {
+			event.Attempts++
+			event.LastError = cause.Error()
+			if deadLetter {
+				now := s.now()
+				event.DeadLetteredAt = &now
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("event not found")
+}
+
+// ListDeadLettered returns dead-lettered events, oldest first. The cursor is
+// the ID of the last event of the previous page; an unknown one yields an
+// empty page.
+func (s *OrderStore) ListDeadLettered(ctx context.Context, limit int, cursor string) ([]*repository.OutboxEvent, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []*repository.OutboxEvent
+	for _, event := range s.outbox {
+		if event.DeadLetteredAt != nil {
+			matched = append(matched, event)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i].DeadLetteredAt, matched[j].DeadLetteredAt
+		if !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	start := 0
+	if cursor != "" {
+		start = len(matched)
+		for i, event := range matched {
+			if event.ID == cursor {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	var page []*repository.OutboxEvent
+	for _, event := range matched[start:] {
+		if len(page) == limit+1 {
+			break
+		}
+		copied := *event
+		page = append(page, &copied)
+	}
+
+	var nextCursor string
+	if len(page) > limit {
+		nextCursor = page[limit-1].ID
+		page = page[:limit]
+	}
+	return page, nextCursor, nil
+}
+
+// RequeueDeadLettered returns dead-lettered events to GetUnpublished with
+// their attempts reset
+func (s *OrderStore) RequeueDeadLettered(ctx context.Context, eventIDs []string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make(map[string]bool, len(eventIDs))
+	for _, id := range eventIDs {
+		ids[id] = true
+	}
+
+	var count int64
+	for _, event := range s.outbox {
+		if ids[event.ID] && event.DeadLetteredAt != nil && !event.Published {
+			event.DeadLetteredAt = nil
+			event.Attempts = 0
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Events returns every stored outbox event, oldest first. Each carries its
+// JSON payload in Data.
+func (s *OrderStore) Events() []repository.OutboxEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]repository.OutboxEvent, len(s.outbox))
+	for i, event := range s.outbox {
+		events[i] = *event
+	}
+	return events
+}
+
+// lookup returns the order with id if it is visible to the caller's tenant
+func (s *OrderStore) lookup(ctx context.Context, id string) *storedOrder {
+	stored, ok := s.orders[id]
+	if !ok {
+		return nil
+	}
+	if tenantID := middleware.TenantFromContext(ctx); tenantID != "" && stored.tenantID != tenantID {
+		return nil
+	}
+	return stored
+}
+
+// appendEvent assigns event an ID and creation time and stores it with its
+// payload marshaled, as the outbox table holds it
+func (s *OrderStore) appendEvent(event *repository.OutboxEvent) error {
+	payloadJSON, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	event.ID = uuid.New().String()
+	event.CreatedAt = s.now()
+	s.outbox = append(s.outbox, &repository.OutboxEvent{
+		ID:            event.ID,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		EventType:     event.EventType,
+		Payload:       event.Payload,
+		Data:          payloadJSON,
+		CreatedAt:     event.CreatedAt,
+	})
+	return nil
+}
+
+// listPage returns the matching orders visible to the caller's tenant after
+// cursor, newest first, and the cursor of the next page
+func (s *OrderStore) listPage(ctx context.Context, limit int, cursor string, match func(*repository.Order) bool) ([]*repository.Order, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var after *repository.Order
+	if cursor != "" {
+		stored, ok := s.orders[cursor]
+		if !ok {
+			// The SQL keyset comparison against a missing row matches nothing
+			return nil, "", nil
+		}
+		after = &stored.order
+	}
+
+	tenantID := middleware.TenantFromContext(ctx)
+	var orders []*repository.Order
+	for _, stored := range s.orders {
+		o := &stored.order
+		if tenantID != "" && stored.tenantID != tenantID {
+			continue
+		}
+		if after != nil && !newer(after, o) {
+			continue
+		}
+		if !match(o) {
+			continue
+		}
+		// List queries don't load items
+		order := cloneOrder(o)
+		order.Items = nil
+		orders = append(orders, &order)
+	}
+	sort.Slice(orders, func(i, j int) bool { return newer(orders[i], orders[j]) })
+
+	var nextCursor string
+	if len(orders) > limit {
+		nextCursor = orders[limit-1].ID
+		orders = orders[:limit]
+	}
+	return orders, nextCursor, nil
+}
+
+func (s *OrderStore) resetPublished(match func(*repository.OutboxEvent) bool) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	for _, event := range s.outbox {
+		if event.Published && match(event) {
+			event.Published = false
+			event.PublishedAt = nil
+			count++
+		}
+	}
+	return count
+}
+
+// newer reports whether a sorts before b in (created_at, id) descending order
+func newer(a, b *repository.Order) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+func cloneOrder(order *repository.Order) repository.Order {
+	cloned := *order
+	cloned.Items = append([]repository.OrderItem(nil), order.Items...)
+	return cloned
+}
@@ -8,6 +8,17 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mumumio1/coldy/pkg/database"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/outbox"
+)
+
+var (
+	// ErrOrderNotFound is returned when an order does not exist
+	ErrOrderNotFound = errs.NotFound("ORDER_NOT_FOUND", "order not found")
 )
 
 // OrderStatus represents the order status
@@ -24,6 +35,22 @@ const (
 	StatusRefunded   OrderStatus = "refunded"
 )
 
+// Paid reports whether an order in status s has been paid for and not
+// refunded, i.e. canceling it needs a refund
+func (s OrderStatus) Paid() bool {
+	switch s {
+	case StatusPaid, StatusProcessing, StatusShipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cancellable reports whether an order in status s can still be canceled
+func (s OrderStatus) Cancellable() bool {
+	return s != StatusDelivered && s != StatusRefunded
+}
+
 // Order represents an order entity
 type Order struct {
 	ID                 string
@@ -56,16 +83,23 @@ type OrderItem struct {
 	CreatedAt          time.Time
 }
 
-// OutboxEvent represents an outbox event
+// OutboxEvent represents an outbox event. Payload is the typed event written
+// to the outbox; events read back carry the stored JSON in Data instead.
 type OutboxEvent struct {
 	ID            string
 	AggregateType string
 	AggregateID   string
 	EventType     string
-	Payload       map[string]interface{}
+	Payload       events.Event
+	Data          json.RawMessage
 	Published     bool
 	PublishedAt   *time.Time
 	CreatedAt     time.Time
+	// Attempts and LastError describe failed publishes
+	Attempts  int
+	LastError string
+	// DeadLetteredAt is set once the publisher gave up on the event
+	DeadLetteredAt *time.Time
 }
 
 // OrderRepository handles order data access
@@ -88,13 +122,17 @@ func (r *OrderRepository) CreateWithOutbox(ctx context.Context, order *Order, ev
 
 	// Insert order
 	orderQuery := `
-		INSERT INTO orders (id, user_id, total_currency, total_amount, status, shipping_street, shipping_city, shipping_state, shipping_postal_code, shipping_country)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO orders (id, user_id, total_currency, total_amount, status, shipping_street, shipping_city, shipping_state, shipping_postal_code, shipping_country, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at
 	`
 
-	order.ID = uuid.New().String()
-	err = tx.QueryRowContext(ctx, orderQuery,
+	// Callers may assign the ID up front so it can go into the event payload
+	if order.ID == "" {
+		order.ID = uuid.New().String()
+	}
+	spanCtx, span := database.StartSpan(ctx, "orders.insert", orderQuery)
+	err = tx.QueryRowContext(spanCtx, database.Annotate(spanCtx, orderQuery),
 		order.ID,
 		order.UserID,
 		order.TotalCurrency,
@@ -105,39 +143,24 @@ func (r *OrderRepository) CreateWithOutbox(ctx context.Context, order *Order, ev
 		order.ShippingState,
 		order.ShippingPostalCode,
 		order.ShippingCountry,
+		middleware.TenantFromContext(ctx),
 	).Scan(&order.CreatedAt, &order.UpdatedAt)
+	database.EndSpan(span, err)
 
 	if err != nil {
 		return fmt.Errorf("failed to insert order: %w", err)
 	}
 
 	// Insert order items
-	itemQuery := `
-		INSERT INTO order_items (id, order_id, product_id, product_name, quantity, unit_price_currency, unit_price_amount, total_price_currency, total_price_amount)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING created_at
-	`
-
+	items := make([]*OrderItem, len(order.Items))
 	for i := range order.Items {
 		item := &order.Items[i]
 		item.ID = uuid.New().String()
 		item.OrderID = order.ID
-
-		err = tx.QueryRowContext(ctx, itemQuery,
-			item.ID,
-			item.OrderID,
-			item.ProductID,
-			item.ProductName,
-			item.Quantity,
-			item.UnitPriceCurrency,
-			item.UnitPriceAmount,
-			item.TotalPriceCurrency,
-			item.TotalPriceAmount,
-		).Scan(&item.CreatedAt)
-
-		if err != nil {
-			return fmt.Errorf("failed to insert order item: %w", err)
-		}
+		items[i] = item
+	}
+	if err := insertItems(ctx, tx, items); err != nil {
+		return err
 	}
 
 	// Insert outbox event
@@ -174,18 +197,49 @@ func (r *OrderRepository) CreateWithOutbox(ctx context.Context, order *Order, ev
 	return nil
 }
 
+// InsertOutboxEvent writes an outbox event that is not tied to an order change
+func (r *OrderRepository) InsertOutboxEvent(ctx context.Context, event *OutboxEvent) error {
+	payloadJSON, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (id, aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+
+	event.ID = uuid.New().String()
+
+	err = r.db.QueryRowContext(ctx, query,
+		event.ID,
+		event.AggregateType,
+		event.AggregateID,
+		event.EventType,
+		payloadJSON,
+	).Scan(&event.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+
+	return nil
+}
+
 // GetByID retrieves an order by ID with items
 func (r *OrderRepository) GetByID(ctx context.Context, id string) (*Order, error) {
 	orderQuery := `
 		SELECT id, user_id, total_currency, total_amount, status, payment_id, shipping_street, shipping_city, shipping_state, shipping_postal_code, shipping_country, created_at, updated_at
 		FROM orders
-		WHERE id = $1
+		WHERE id = $1 AND ($2 = '' OR tenant_id = $2)
 	`
 
 	var order Order
 	var paymentID sql.NullString
 
-	err := r.db.QueryRowContext(ctx, orderQuery, id).Scan(
+	spanCtx, span := database.StartSpan(ctx, "orders.get_by_id", orderQuery)
+	err := r.db.QueryRowContext(spanCtx, database.Annotate(spanCtx, orderQuery), id, middleware.TenantFromContext(ctx)).Scan(
 		&order.ID,
 		&order.UserID,
 		&order.TotalCurrency,
@@ -200,6 +254,7 @@ func (r *OrderRepository) GetByID(ctx context.Context, id string) (*Order, error
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
+	database.EndSpan(span, err)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -220,7 +275,9 @@ func (r *OrderRepository) GetByID(ctx context.Context, id string) (*Order, error
 		ORDER BY created_at
 	`
 
-	rows, err := r.db.QueryContext(ctx, itemsQuery, id)
+	spanCtx, span = database.StartSpan(ctx, "orders.get_items", itemsQuery)
+	rows, err := r.db.QueryContext(spanCtx, database.Annotate(spanCtx, itemsQuery), id)
+	database.EndSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order items: %w", err)
 	}
@@ -249,14 +306,30 @@ func (r *OrderRepository) GetByID(ctx context.Context, id string) (*Order, error
 	return &order, nil
 }
 
-// UpdateStatus updates order status with outbox event
-func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID string, status OrderStatus, event *OutboxEvent) error {
+// UpdateStatus updates order status with outbox event. An order already in
+// status is left untouched and no event is written; changed reports whether
+// the status moved.
+func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID string, status OrderStatus, event *OutboxEvent) (bool, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Lock the order so concurrent updates to the same status emit one event
+	var current OrderStatus
+	err = tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = $1 AND ($2 = '' OR tenant_id = $2) FOR UPDATE`,
+		orderID, middleware.TenantFromContext(ctx)).Scan(&current)
+	if err == sql.ErrNoRows {
+		return false, ErrOrderNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock order: %w", err)
+	}
+	if current == status {
+		return false, nil
+	}
+
 	// Update order status
 	query := `
 		UPDATE orders
@@ -264,24 +337,18 @@ func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID string, stat
 		WHERE id = $2
 	`
 
-	result, err := tx.ExecContext(ctx, query, status, orderID)
-	if err != nil {
-		return fmt.Errorf("failed to update order status: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
+	spanCtx, span := database.StartSpan(ctx, "orders.update_status", query)
+	_, err = tx.ExecContext(spanCtx, database.Annotate(spanCtx, query), status, orderID)
+	database.EndSpan(span, err)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("order not found")
+		return false, fmt.Errorf("failed to update order status: %w", err)
 	}
 
 	// Insert outbox event if provided
 	if event != nil {
 		payloadJSON, err := json.Marshal(event.Payload)
 		if err != nil {
-			return fmt.Errorf("failed to marshal event payload: %w", err)
+			return false, fmt.Errorf("failed to marshal event payload: %w", err)
 		}
 
 		outboxQuery := `
@@ -301,21 +368,21 @@ func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID string, stat
 		)
 
 		if err != nil {
-			return fmt.Errorf("failed to insert outbox event: %w", err)
+			return false, fmt.Errorf("failed to insert outbox event: %w", err)
 		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return nil
+	return true, nil
 }
 
 // List retrieves orders with pagination
 func (r *OrderRepository) List(ctx context.Context, userID string, status OrderStatus, limit int, cursor string) ([]*Order, string, error) {
 	query := `
-		SELECT id, user_id, total_currency, total_amount, status, payment_id, shipping_street, shipping_city, shipping_state, shipping_postal_code, shipping_country, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
 		WHERE user_id = $1
 	`
@@ -329,6 +396,69 @@ func (r *OrderRepository) List(ctx context.Context, userID string, status OrderS
 		argIdx++
 	}
 
+	return r.listPage(ctx, query, args, argIdx, limit, cursor)
+}
+
+// ListAllByStatus retrieves orders of every user in a status, newest first.
+// Zero createdFrom/createdTo leave that end of the created_at range open;
+// createdTo is exclusive.
+func (r *OrderRepository) ListAllByStatus(ctx context.Context, status OrderStatus, createdFrom, createdTo time.Time, limit int, cursor string) ([]*Order, string, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE status = $1
+	`
+
+	args := []interface{}{status}
+	argIdx := 2
+
+	if !createdFrom.IsZero() {
+		query += fmt.Sprintf(" AND created_at >= $%d", argIdx)
+		args = append(args, createdFrom)
+		argIdx++
+	}
+	if !createdTo.IsZero() {
+		query += fmt.Sprintf(" AND created_at < $%d", argIdx)
+		args = append(args, createdTo)
+		argIdx++
+	}
+
+	return r.listPage(ctx, query, args, argIdx, limit, cursor)
+}
+
+// ListByProduct retrieves orders of every user that contain productID,
+// newest first. An order is returned once even when several of its line
+// items reference the product. An empty status matches every status.
+func (r *OrderRepository) ListByProduct(ctx context.Context, productID string, status OrderStatus, limit int, cursor string) ([]*Order, string, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE EXISTS (SELECT 1 FROM order_items WHERE order_items.order_id = orders.id AND order_items.product_id = $1)
+	`
+
+	args := []interface{}{productID}
+	argIdx := 2
+
+	if status != "" {
+		query += fmt.Sprintf(" AND status = $%d", argIdx)
+		args = append(args, status)
+		argIdx++
+	}
+
+	return r.listPage(ctx, query, args, argIdx, limit, cursor)
+}
+
+const orderColumns = `id, user_id, total_currency, total_amount, status, payment_id, shipping_street, shipping_city, shipping_state, shipping_postal_code, shipping_country, created_at, updated_at`
+
+// listPage scopes query to the caller's tenant, appends keyset pagination on
+// (created_at, id) and runs it
+func (r *OrderRepository) listPage(ctx context.Context, query string, args []interface{}, argIdx, limit int, cursor string) ([]*Order, string, error) {
+	if tenantID := middleware.TenantFromContext(ctx); tenantID != "" {
+		query += fmt.Sprintf(" AND tenant_id = $%d", argIdx)
+		args = append(args, tenantID)
+		argIdx++
+	}
+
 	if cursor != "" {
 		query += fmt.Sprintf(" AND (created_at, id) < (SELECT created_at, id FROM orders WHERE id = $%d)", argIdx)
 		args = append(args, cursor)
@@ -339,7 +469,9 @@ func (r *OrderRepository) List(ctx context.Context, userID string, status OrderS
 	query += fmt.Sprintf(" LIMIT $%d", argIdx)
 	args = append(args, limit+1)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	spanCtx, span := database.StartSpan(ctx, "orders.list", query)
+	rows, err := r.db.QueryContext(spanCtx, database.Annotate(spanCtx, query), args...)
+	database.EndSpan(span, err)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to list orders: %w", err)
 	}
@@ -390,12 +522,14 @@ func (r *OrderRepository) List(ctx context.Context, userID string, status OrderS
 	return orders, nextCursor, nil
 }
 
-// GetUnpublishedEvents retrieves unpublished outbox events
-func (r *OrderRepository) GetUnpublishedEvents(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+var _ outbox.Store = (*OrderRepository)(nil)
+
+// GetUnpublished retrieves unpublished outbox events, oldest first
+func (r *OrderRepository) GetUnpublished(ctx context.Context, limit int) ([]outbox.Event, error) {
 	query := `
-		SELECT id, aggregate_type, aggregate_id, event_type, payload, published, published_at, created_at
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, attempts
 		FROM outbox
-		WHERE published = false
+		WHERE published = false AND dead_lettered_at IS NULL
 		ORDER BY created_at
 		LIMIT $1
 	`
@@ -406,42 +540,37 @@ func (r *OrderRepository) GetUnpublishedEvents(ctx context.Context, limit int) (
 	}
 	defer func() { _ = rows.Close() }()
 
-	var events []*OutboxEvent
+	var events []outbox.Event
 	for rows.Next() {
-		var event OutboxEvent
-		var payloadJSON []byte
-		var publishedAt sql.NullTime
-
+		var event outbox.Event
 		err := rows.Scan(
 			&event.ID,
 			&event.AggregateType,
 			&event.AggregateID,
 			&event.EventType,
-			&payloadJSON,
-			&event.Published,
-			&publishedAt,
-			&event.CreatedAt,
+			&event.Data,
+			&event.Attempts,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
 
-		if err := json.Unmarshal(payloadJSON, &event.Payload); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+		if !json.Valid(event.Data) {
+			return nil, fmt.Errorf("invalid payload for event %s", event.ID)
 		}
 
-		if publishedAt.Valid {
-			event.PublishedAt = &publishedAt.Time
-		}
+		events = append(events, event)
+	}
 
-		events = append(events, &event)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
 	return events, nil
 }
 
-// MarkEventPublished marks an outbox event as published
-func (r *OrderRepository) MarkEventPublished(ctx context.Context, eventID string) error {
+// MarkPublished marks an outbox event as published
+func (r *OrderRepository) MarkPublished(ctx context.Context, eventID string) error {
 	query := `
 		UPDATE outbox
 		SET published = true, published_at = CURRENT_TIMESTAMP
@@ -463,3 +592,74 @@ func (r *OrderRepository) MarkEventPublished(ctx context.Context, eventID string
 
 	return nil
 }
+
+// MarkFailed records a failed publish attempt of an outbox event
+func (r *OrderRepository) MarkFailed(ctx context.Context, eventID string, cause error) error {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $2
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, eventID, cause.Error()); err != nil {
+		return fmt.Errorf("failed to mark event failed: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPublished marks published outbox events unpublished again so the
+// outbox publisher re-sends them. It returns the number of events reset.
+func (r *OrderRepository) ResetPublished(ctx context.Context, eventIDs []string) (int64, error) {
+	if len(eventIDs) == 0 {
+		return 0, nil
+	}
+
+	query := `
+		UPDATE outbox
+		SET published = false, published_at = NULL
+		WHERE id = ANY($1) AND published = true
+	`
+
+	return r.resetPublished(ctx, query, pq.Array(eventIDs))
+}
+
+// ResetPublishedForAggregate marks the published outbox events of an
+// aggregate unpublished again. Zero createdFrom/createdTo leave that end of
+// the created_at range open; createdTo is exclusive.
+func (r *OrderRepository) ResetPublishedForAggregate(ctx context.Context, aggregateID string, createdFrom, createdTo time.Time) (int64, error) {
+	query := `
+		UPDATE outbox
+		SET published = false, published_at = NULL
+		WHERE aggregate_id = $1 AND published = true
+	`
+
+	args := []interface{}{aggregateID}
+	argIdx := 2
+
+	if !createdFrom.IsZero() {
+		query += fmt.Sprintf(" AND created_at >= $%d", argIdx)
+		args = append(args, createdFrom)
+		argIdx++
+	}
+	if !createdTo.IsZero() {
+		query += fmt.Sprintf(" AND created_at < $%d", argIdx)
+		args = append(args, createdTo)
+	}
+
+	return r.resetPublished(ctx, query, args...)
+}
+
+func (r *OrderRepository) resetPublished(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset published events: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/middleware"
+)
+
+// SummaryFilter selects the orders aggregated by Summary. From is inclusive
+// and To exclusive; an empty UserID or Status matches every user or status.
+type SummaryFilter struct {
+	UserID string
+	Status OrderStatus
+	From   time.Time
+	To     time.Time
+}
+
+// DailyBucket holds the orders of one UTC day in one currency
+type DailyBucket struct {
+	Day         time.Time
+	Currency    string
+	OrderCount  int64
+	TotalAmount int64
+}
+
+// Summary counts orders and sums their totals per UTC day and currency,
+// ordered by day then currency. Amounts are never summed across currencies.
+func (r *OrderRepository) Summary(ctx context.Context, filter SummaryFilter) ([]DailyBucket, error) {
+	query := `
+		SELECT date_trunc('day', created_at AT TIME ZONE 'UTC') AS day, total_currency, COUNT(*), COALESCE(SUM(total_amount), 0)
+		FROM orders
+		WHERE created_at >= $1 AND created_at < $2
+	`
+
+	args := []interface{}{filter.From, filter.To}
+	argIdx := 3
+
+	if filter.UserID != "" {
+		query += fmt.Sprintf(" AND user_id = $%d", argIdx)
+		args = append(args, filter.UserID)
+		argIdx++
+	}
+	if filter.Status != "" {
+		query += fmt.Sprintf(" AND status = $%d", argIdx)
+		args = append(args, filter.Status)
+		argIdx++
+	}
+	if tenantID := middleware.TenantFromContext(ctx); tenantID != "" {
+		query += fmt.Sprintf(" AND tenant_id = $%d", argIdx)
+		args = append(args, tenantID)
+	}
+
+	query += " GROUP BY day, total_currency ORDER BY day, total_currency"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize orders: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var buckets []DailyBucket
+	for rows.Next() {
+		var b DailyBucket
+		if err := rows.Scan(&b.Day, &b.Currency, &b.OrderCount, &b.TotalAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan summary: %w", err)
+		}
+		// The truncated day carries no zone; it is a UTC midnight
+		b.Day = time.Date(b.Day.Year(), b.Day.Month(), b.Day.Day(), 0, 0, 0, 0, time.UTC)
+		buckets = append(buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateShippingAddress trims the shipping fields, normalizes the country
+// code and checks that the address is deliverable
+func validateShippingAddress(req *CreateOrderRequest) error {
+	req.ShippingStreet = strings.TrimSpace(req.ShippingStreet)
+	req.ShippingCity = strings.TrimSpace(req.ShippingCity)
+	req.ShippingState = strings.TrimSpace(req.ShippingState)
+	req.ShippingPostalCode = strings.TrimSpace(req.ShippingPostalCode)
+	req.ShippingCountry = strings.ToUpper(strings.TrimSpace(req.ShippingCountry))
+
+	if req.ShippingStreet == "" && req.ShippingCity == "" && req.ShippingPostalCode == "" && req.ShippingCountry == "" {
+		return fmt.Errorf("%w: shipping_address is required", ErrInvalidOrder)
+	}
+	if req.ShippingStreet == "" {
+		return fmt.Errorf("%w: shipping_address.street is required", ErrInvalidOrder)
+	}
+	if req.ShippingCity == "" {
+		return fmt.Errorf("%w: shipping_address.city is required", ErrInvalidOrder)
+	}
+	if req.ShippingCountry == "" {
+		return fmt.Errorf("%w: shipping_address.country is required", ErrInvalidOrder)
+	}
+	if _, ok := countries[req.ShippingCountry]; !ok {
+		return fmt.Errorf("%w: shipping_address.country %q is not an ISO 3166-1 alpha-2 code", ErrInvalidOrder, req.ShippingCountry)
+	}
+	if req.ShippingPostalCode == "" {
+		if _, exempt := noPostalCode[req.ShippingCountry]; !exempt {
+			return fmt.Errorf("%w: shipping_address.postal_code is required", ErrInvalidOrder)
+		}
+	}
+
+	return nil
+}
+
+// noPostalCode lists countries that do not use postal codes
+var noPostalCode = map[string]struct{}{
+	"AE": {}, "AG": {}, "AO": {}, "BF": {}, "BI": {}, "BJ": {}, "BS": {}, "BW": {}, "BZ": {}, "CF": {},
+	"CG": {}, "CI": {}, "CM": {}, "DJ": {}, "DM": {}, "ER": {}, "FJ": {}, "GA": {}, "GD": {}, "GM": {},
+	"GQ": {}, "GY": {}, "HK": {}, "KI": {}, "KM": {}, "KN": {}, "LY": {}, "ML": {}, "MO": {}, "MR": {},
+	"NR": {}, "QA": {}, "RW": {}, "SB": {}, "SC": {}, "SL": {}, "SR": {}, "ST": {}, "SY": {}, "TD": {},
+	"TG": {}, "TK": {}, "TL": {}, "TO": {}, "TV": {}, "UG": {}, "VU": {}, "YE": {}, "ZW": {},
+}
+
+// countries is the set of ISO 3166-1 alpha-2 codes
+var countries = map[string]struct{}{
+	"AD": {}, "AE": {}, "AF": {}, "AG": {}, "AI": {}, "AL": {}, "AM": {}, "AO": {}, "AQ": {}, "AR": {},
+	"AS": {}, "AT": {}, "AU": {}, "AW": {}, "AX": {}, "AZ": {}, "BA": {}, "BB": {}, "BD": {}, "BE": {},
+	"BF": {}, "BG": {}, "BH": {}, "BI": {}, "BJ": {}, "BL": {}, "BM": {}, "BN": {}, "BO": {}, "BQ": {},
+	"BR": {}, "BS": {}, "BT": {}, "BV": {}, "BW": {}, "BY": {}, "BZ": {}, "CA": {}, "CC": {}, "CD": {},
+	"CF": {}, "CG": {}, "CH": {}, "CI": {}, "CK": {}, "CL": {}, "CM": {}, "CN": {}, "CO": {}, "CR": {},
+	"CU": {}, "CV": {}, "CW": {}, "CX": {}, "CY": {}, "CZ": {}, "DE": {}, "DJ": {}, "DK": {}, "DM": {},
+	"DO": {}, "DZ": {}, "EC": {}, "EE": {}, "EG": {}, "EH": {}, "ER": {}, "ES": {}, "ET": {}, "FI": {},
+	"FJ": {}, "FK": {}, "FM": {}, "FO": {}, "FR": {}, "GA": {}, "GB": {}, "GD": {}, "GE": {}, "GF": {},
+	"GG": {}, "GH": {}, "GI": {}, "GL": {}, "GM": {}, "GN": {}, "GP": {}, "GQ": {}, "GR": {}, "GS": {},
+	"GT": {}, "GU": {}, "GW": {}, "GY": {}, "HK": {}, "HM": {}, "HN": {}, "HR": {}, "HT": {}, "HU": {},
+	"ID": {}, "IE": {}, "IL": {}, "IM": {}, "IN": {}, "IO": {}, "IQ": {}, "IR": {}, "IS": {}, "IT": {},
+	"JE": {}, "JM": {}, "JO": {}, "JP": {}, "KE": {}, "KG": {}, "KH": {}, "KI": {}, "KM": {}, "KN": {},
+	"KP": {}, "KR": {}, "KW": {}, "KY": {}, "KZ": {}, "LA": {}, "LB": {}, "LC": {}, "LI": {}, "LK": {},
+	"LR": {}, "LS": {}, "LT": {}, "LU": {}, "LV": {}, "LY": {}, "MA": {}, "MC": {}, "MD": {}, "ME": {},
+	"MF": {}, "MG": {}, "MH": {}, "MK": {}, "ML": {}, "MM": {}, "MN": {}, "MO": {}, "MP": {}, "MQ": {},
+	"MR": {}, "MS": {}, "MT": {}, "MU": {}, "MV": {}, "MW": {}, "MX": {}, "MY": {}, "MZ": {}, "NA": {},
+	"NC": {}, "NE": {}, "NF": {}, "NG": {}, "NI": {}, "NL": {}, "NO": {}, "NP": {}, "NR": {}, "NU": {},
+	"NZ": {}, "OM": {}, "PA": {}, "PE": {}, "PF": {}, "PG": {}, "PH": {}, "PK": {}, "PL": {}, "PM": {},
+	"PN": {}, "PR": {}, "PS": {}, "PT": {}, "PW": {}, "PY": {}, "QA": {}, "RE": {}, "RO": {}, "RS": {},
+	"RU": {}, "RW": {}, "SA": {}, "SB": {}, "SC": {}, "SD": {}, "SE": {}, "SG": {}, "SH": {}, "SI": {},
+	"SJ": {}, "SK": {}, "SL": {}, "SM": {}, "SN": {}, "SO": {}, "SR": {}, "SS": {}, "ST": {}, "SV": {},
+	"SX": {}, "SY": {}, "SZ": {}, "TC": {}, "TD": {}, "TF": {}, "TG": {}, "TH": {}, "TJ": {}, "TK": {},
+	"TL": {}, "TM": {}, "TN": {}, "TO": {}, "TR": {}, "TT": {}, "TV": {}, "TW": {}, "TZ": {}, "UA": {},
+	"UG": {}, "UM": {}, "US": {}, "UY": {}, "UZ": {}, "VA": {}, "VC": {}, "VE": {}, "VG": {}, "VI": {},
+	"VN": {}, "VU": {}, "WF": {}, "WS": {}, "YE": {}, "YT": {}, "ZA": {}, "ZM": {}, "ZW": {},
+}
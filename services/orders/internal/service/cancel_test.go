@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+	"github.com/mumumio1/coldy/services/orders/internal/repository/memory"
+	"github.com/mumumio1/coldy/services/orders/internal/service"
+	"go.uber.org/zap"
+)
+
+// newTestOrder stores a pending order and moves it to status
+func newTestOrder(t *testing.T, store *memory.OrderStore, status repository.OrderStatus) *repository.Order {
+	t.Helper()
+	ctx := context.Background()
+
+	order := &repository.Order{
+		UserID:        "user-1",
+		TotalCurrency: "USD",
+		TotalAmount:   1000,
+		Status:        repository.StatusPending,
+	}
+	event := &repository.OutboxEvent{
+		AggregateType: "order",
+		EventType:     events.TypeOrderCreated,
+		Payload:       events.OrderCreated{UserID: order.UserID},
+	}
+	if err := store.CreateWithOutbox(ctx, order, event); err != nil {
+		t.Fatalf("failed to create order: %v", err)
+	}
+	if status != repository.StatusPending {
+		if _, err := store.UpdateStatus(ctx, order.ID, status, nil); err != nil {
+			t.Fatalf("failed to move order to %s: %v", status, err)
+		}
+	}
+	return order
+}
+
+func countEvents(store *memory.OrderStore, eventType string) int {
+	count := 0
+	for _, event := range store.Events() {
+		if event.EventType == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCancelOrderRequestsRefundAfterPayment(t *testing.T) {
+	for _, status := range []repository.OrderStatus{
+		repository.StatusPaid,
+		repository.StatusProcessing,
+		repository.StatusShipped,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			store := memory.NewOrderStore()
+			svc := service.NewOrderService(store, nil, nil, service.VelocityConfig{}, nil, "USD", zap.NewNop())
+			order := newTestOrder(t, store, status)
+
+			if err := svc.CancelOrder(context.Background(), "", order.ID, "changed my mind"); err != nil {
+				t.Fatalf("CancelOrder failed: %v", err)
+			}
+			if n := countEvents(store, events.TypeOrderRefundRequested); n != 1 {
+				t.Errorf("expected 1 refund request, got %d", n)
+			}
+		})
+	}
+}
+
+func TestCancelOrderWithoutPaymentRequestsNoRefund(t *testing.T) {
+	store := memory.NewOrderStore()
+	svc := service.NewOrderService(store, nil, nil, service.VelocityConfig{}, nil, "USD", zap.NewNop())
+	order := newTestOrder(t, store, repository.StatusConfirmed)
+
+	if err := svc.CancelOrder(context.Background(), "", order.ID, "changed my mind"); err != nil {
+		t.Fatalf("CancelOrder failed: %v", err)
+	}
+	if n := countEvents(store, events.TypeOrderRefundRequested); n != 0 {
+		t.Errorf("expected no refund request, got %d", n)
+	}
+}
+
+func TestCancelOrderRejectsFinalStatuses(t *testing.T) {
+	for _, status := range []repository.OrderStatus{repository.StatusDelivered, repository.StatusRefunded} {
+		t.Run(string(status), func(t *testing.T) {
+			store := memory.NewOrderStore()
+			svc := service.NewOrderService(store, nil, nil, service.VelocityConfig{}, nil, "USD", zap.NewNop())
+			order := newTestOrder(t, store, status)
+
+			err := svc.CancelOrder(context.Background(), "", order.ID, "too late")
+			if !errors.Is(err, service.ErrInvalidOrderState) {
+				t.Fatalf("expected ErrInvalidOrderState, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCancelRechecksStatusUnderLock(t *testing.T) {
+	store := memory.NewOrderStore()
+	order := newTestOrder(t, store, repository.StatusDelivered)
+
+	event := &repository.OutboxEvent{
+		AggregateType: "order",
+		EventType:     events.TypeOrderCanceled,
+		Payload:       events.OrderCanceled{OrderID: order.ID},
+	}
+	_, _, err := store.Cancel(context.Background(), order.ID, event, nil)
+	if !errors.Is(err, repository.ErrNotCancellable) {
+		t.Fatalf("expected ErrNotCancellable, got %v", err)
+	}
+}
+
+func TestCancelOrderEventsHaveTopics(t *testing.T) {
+	store := memory.NewOrderStore()
+	svc := service.NewOrderService(store, nil, nil, service.VelocityConfig{}, nil, "USD", zap.NewNop())
+	order := newTestOrder(t, store, repository.StatusPaid)
+
+	if err := svc.CancelOrder(context.Background(), "", order.ID, "changed my mind"); err != nil {
+		t.Fatalf("CancelOrder failed: %v", err)
+	}
+
+	// Every event the outbox carries must be in EventTypes, or its topic is
+	// never checked at startup
+	known := make(map[string]bool)
+	for _, eventType := range service.EventTypes() {
+		known[eventType] = true
+	}
+	for _, event := range store.Events() {
+		if !known[event.EventType] {
+			t.Errorf("event type %s is missing from EventTypes", event.EventType)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/pkg/idempotency"
+	"github.com/mumumio1/coldy/services/orders/internal/repository/memory"
+	"github.com/mumumio1/coldy/services/orders/internal/service"
+	"go.uber.org/zap"
+)
+
+func newCreateRequest(userID string) *service.CreateOrderRequest {
+	return &service.CreateOrderRequest{
+		UserID: userID,
+		Items: []service.OrderItemRequest{
+			{ProductID: "product-1", Quantity: 2},
+		},
+		ShippingStreet:     "1 Main St",
+		ShippingCity:       "Springfield",
+		ShippingPostalCode: "12345",
+		ShippingCountry:    "US",
+	}
+}
+
+// newPricedCatalog returns a catalog pricing the product of newCreateRequest
+func newPricedCatalog() *fakeCatalog {
+	catalog := newFakeCatalog()
+	catalog.setPrice("product-1", "Mug", "USD", 1250)
+	return catalog
+}
+
+func TestCreateOrderReplaysSameKey(t *testing.T) {
+	store := memory.NewOrderStore()
+	idem := memory.NewIdempotencyStore(false)
+	svc := service.NewOrderService(store, nil, idem, service.VelocityConfig{}, newPricedCatalog(), "USD", zap.NewNop())
+	ctx := context.Background()
+
+	first, replayed, err := svc.CreateOrder(ctx, "key-1", newCreateRequest("user-1"))
+	if err != nil {
+		t.Fatalf("first CreateOrder failed: %v", err)
+	}
+	if replayed {
+		t.Error("first call reported a replay")
+	}
+
+	second, replayed, err := svc.CreateOrder(ctx, "key-1", newCreateRequest("user-1"))
+	if err != nil {
+		t.Fatalf("second CreateOrder failed: %v", err)
+	}
+	if !replayed {
+		t.Error("second call with the same key was not a replay")
+	}
+	if second.ID != first.ID || second.TotalAmount != first.TotalAmount || len(second.Items) != len(first.Items) {
+		t.Errorf("replay returned %+v, want the first order %+v", second, first)
+	}
+	if n := countEvents(store, events.TypeOrderCreated); n != 1 {
+		t.Errorf("expected 1 order.created event, got %d", n)
+	}
+}
+
+func TestCreateOrderDistinctKeys(t *testing.T) {
+	for name, requests := range map[string][2]struct{ user, key string }{
+		"different keys":  {{"user-1", "key-1"}, {"user-1", "key-2"}},
+		"different users": {{"user-1", "key-1"}, {"user-2", "key-1"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := memory.NewOrderStore()
+			svc := service.NewOrderService(store, nil, memory.NewIdempotencyStore(false), service.VelocityConfig{}, newPricedCatalog(), "USD", zap.NewNop())
+
+			ids := make(map[string]bool)
+			for _, r := range requests {
+				order, replayed, err := svc.CreateOrder(context.Background(), r.key, newCreateRequest(r.user))
+				if err != nil {
+					t.Fatalf("CreateOrder failed: %v", err)
+				}
+				if replayed {
+					t.Errorf("%s/%s was replayed", r.user, r.key)
+				}
+				ids[order.ID] = true
+			}
+			if len(ids) != 2 {
+				t.Errorf("expected 2 orders, got %d", len(ids))
+			}
+			if n := countEvents(store, events.TypeOrderCreated); n != 2 {
+				t.Errorf("expected 2 order.created events, got %d", n)
+			}
+		})
+	}
+}
+
+func TestCreateOrderIdempotencyUnavailable(t *testing.T) {
+	t.Run("fail closed", func(t *testing.T) {
+		store := memory.NewOrderStore()
+		idem := memory.NewIdempotencyStore(false)
+		idem.SetUnavailable(true)
+		svc := service.NewOrderService(store, nil, idem, service.VelocityConfig{}, newPricedCatalog(), "USD", zap.NewNop())
+
+		_, _, err := svc.CreateOrder(context.Background(), "key-1", newCreateRequest("user-1"))
+		if !errors.Is(err, idempotency.ErrUnavailable) {
+			t.Fatalf("expected idempotency.ErrUnavailable, got %v", err)
+		}
+		if n := len(store.Events()); n != 0 {
+			t.Errorf("expected no order to be created, got %d events", n)
+		}
+	})
+
+	t.Run("fail open", func(t *testing.T) {
+		store := memory.NewOrderStore()
+		idem := memory.NewIdempotencyStore(true)
+		idem.SetUnavailable(true)
+		svc := service.NewOrderService(store, nil, idem, service.VelocityConfig{}, newPricedCatalog(), "USD", zap.NewNop())
+
+		order, replayed, err := svc.CreateOrder(context.Background(), "key-1", newCreateRequest("user-1"))
+		if err != nil {
+			t.Fatalf("CreateOrder failed: %v", err)
+		}
+		if replayed || order == nil {
+			t.Errorf("got order=%v replayed=%v, want a new order", order, replayed)
+		}
+		if n := countEvents(store, events.TypeOrderCreated); n != 1 {
+			t.Errorf("expected 1 order.created event, got %d", n)
+		}
+	})
+}
+
+func TestCreateOrderRejectsTooManyItemsBeforeResolvingSKUs(t *testing.T) {
+	catalog := newFakeCatalog()
+	svc := service.NewOrderService(memory.NewOrderStore(), nil, memory.NewIdempotencyStore(false), service.VelocityConfig{}, catalog, "USD", zap.NewNop())
+
+	req := newCreateRequest("user-1")
+	req.Items = make([]service.OrderItemRequest, service.MaxOrderItems+1)
+	for i := range req.Items {
+		req.Items[i] = service.OrderItemRequest{SKU: fmt.Sprintf("SKU-%d", i), Quantity: 1}
+	}
+
+	if _, _, err := svc.CreateOrder(context.Background(), "key-1", req); !errors.Is(err, service.ErrInvalidOrder) {
+		t.Fatalf("expected ErrInvalidOrder, got %v", err)
+	}
+	if catalog.resolveCalls != 0 {
+		t.Errorf("resolved %d SKUs of an oversized order, want none", catalog.resolveCalls)
+	}
+}
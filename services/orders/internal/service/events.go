@@ -0,0 +1,35 @@
+package service
+
+import (
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+)
+
+// orderStatuses are the statuses an order can be moved to, each published as
+// an order.<status> event
+var orderStatuses = []repository.OrderStatus{
+	repository.StatusPending,
+	repository.StatusConfirmed,
+	repository.StatusPaid,
+	repository.StatusProcessing,
+	repository.StatusShipped,
+	repository.StatusDelivered,
+	repository.StatusCancelled,
+	repository.StatusRefunded,
+}
+
+// EventTypes lists the event types the order outbox carries, so the topics
+// they are published to can be verified at startup
+func EventTypes() []string {
+	types := []string{
+		events.TypeOrderCreated,
+		events.TypeOrderUpdated,
+		events.TypeOrderCanceled,
+		events.TypeOrderRefundRequested,
+		events.TypeOrderVelocityExceeded,
+	}
+	for _, status := range orderStatuses {
+		types = append(types, events.OrderStatusChanged{Status: string(status)}.EventType())
+	}
+	return types
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/money"
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+	"go.uber.org/zap"
+)
+
+// ItemChange sets the quantity of a product in an order. A product not yet in
+// the order is added and a zero quantity removes it.
+type ItemChange struct {
+	ProductID string
+	// SKU identifies the product instead of ProductID
+	SKU      string
+	Quantity int32
+}
+
+// UpdateOrderItems applies item changes to a pending order and reprices every
+// item at its current catalog price. Orders past pending fail with
+// ErrInvalidOrderState. A higher total counts against the user's velocity
+// limits. Changes set absolute quantities, so retrying the same request leaves
+// the order as it is.
+func (s *OrderService) UpdateOrderItems(ctx context.Context, orderID string, changes []ItemChange) (*repository.Order, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: changes are required", ErrInvalidOrder)
+	}
+	if s.products == nil {
+		return nil, fmt.Errorf("%w: changing order items is not supported", ErrInvalidOrder)
+	}
+
+	requests := make([]OrderItemRequest, len(changes))
+	for i, change := range changes {
+		requests[i] = OrderItemRequest{ProductID: change.ProductID, SKU: change.SKU, Quantity: change.Quantity}
+	}
+	if err := s.resolveSKUs(ctx, requests); err != nil {
+		return nil, err
+	}
+
+	order, err := s.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != repository.StatusPending {
+		return nil, fmt.Errorf("%w: order items cannot be changed in status %s", ErrInvalidOrderState, order.Status)
+	}
+
+	productIDs, quantities, err := applyItemChanges(order.Items, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, err := s.products.QuotePrices(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price order items: %w", err)
+	}
+
+	existing := make(map[string]repository.OrderItem, len(order.Items))
+	for _, item := range order.Items {
+		existing[item.ProductID] = item
+	}
+
+	items := make([]repository.OrderItem, len(productIDs))
+	lineTotals := make([]money.Money, len(productIDs))
+	eventItems := make([]events.OrderItem, len(productIDs))
+	for i, productID := range productIDs {
+		quote, ok := quotes[productID]
+		if !ok {
+			return nil, fmt.Errorf("%w: product %s is not in the catalog", ErrInvalidOrder, productID)
+		}
+		if quote.Currency != order.TotalCurrency {
+			return nil, fmt.Errorf("%w: product %s is priced in %s, order currency is %s",
+				ErrInvalidOrder, productID, quote.Currency, order.TotalCurrency)
+		}
+
+		unitPrice := money.Money{Currency: quote.Currency, Amount: quote.Amount}
+		lineTotal, err := unitPrice.Multiply(int64(quantities[productID]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: product %s: %v", ErrInvalidOrder, productID, err)
+		}
+		lineTotals[i] = lineTotal
+
+		// Existing items keep their ID so they are updated in place
+		items[i] = repository.OrderItem{
+			ID:                 existing[productID].ID,
+			ProductID:          productID,
+			ProductName:        quote.Name,
+			Quantity:           quantities[productID],
+			UnitPriceCurrency:  unitPrice.Currency,
+			UnitPriceAmount:    unitPrice.Amount,
+			TotalPriceCurrency: lineTotal.Currency,
+			TotalPriceAmount:   lineTotal.Amount,
+		}
+		eventItems[i] = events.OrderItem{
+			ProductID:   productID,
+			ProductName: quote.Name,
+			Quantity:    quantities[productID],
+			UnitPrice:   unitPrice.Amount,
+		}
+	}
+
+	total, err := money.Sum(lineTotals...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrder, err)
+	}
+
+	previousTotal := order.TotalAmount
+	order.Items = items
+	order.TotalAmount = total.Amount
+
+	event := orderEvent(events.OrderUpdated{
+		OrderID:       order.ID,
+		UserID:        order.UserID,
+		Total:         total.Amount,
+		PreviousTotal: previousTotal,
+		Currency:      total.Currency,
+		Items:         eventItems,
+	})
+
+	// Growing an order counts against the velocity limits like ordering the
+	// difference would, so small orders can't be raised past them
+	var reservation *velocityReservation
+	if increase := total.Amount - previousTotal; increase > 0 {
+		if reservation, err = s.reserveVelocity(ctx, order.UserID, total.Currency, 0, increase); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.ReplaceItems(ctx, order, event); err != nil {
+		s.releaseVelocity(ctx, reservation)
+		return nil, fmt.Errorf("failed to update order items: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("order items updated",
+		zap.String("order_id", order.ID),
+		zap.Int("items", len(order.Items)),
+		zap.Int64("previous_total", previousTotal),
+		zap.Int64("total", total.Amount),
+	)
+
+	return order, nil
+}
+
+// applyItemChanges returns the product IDs and quantities an order has after
+// changes, keeping the order of existing items and appending new ones
+func applyItemChanges(items []repository.OrderItem, changes []OrderItemRequest) ([]string, map[string]int32, error) {
+	productIDs := make([]string, 0, len(items)+len(changes))
+	quantities := make(map[string]int32, len(items)+len(changes))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+		quantities[item.ProductID] = item.Quantity
+	}
+
+	seen := make(map[string]bool, len(changes))
+	for i, change := range changes {
+		if change.ProductID == "" {
+			return nil, nil, fmt.Errorf("%w: change %d: product_id is required", ErrInvalidOrder, i)
+		}
+		if change.Quantity < 0 || change.Quantity > MaxItemQuantity {
+			return nil, nil, fmt.Errorf("%w: change %d: quantity must be between 0 and %d, got %d",
+				ErrInvalidOrder, i, MaxItemQuantity, change.Quantity)
+		}
+		if seen[change.ProductID] {
+			return nil, nil, fmt.Errorf("%w: product %s listed twice", ErrInvalidOrder, change.ProductID)
+		}
+		seen[change.ProductID] = true
+
+		if _, exists := quantities[change.ProductID]; !exists {
+			productIDs = append(productIDs, change.ProductID)
+		}
+		quantities[change.ProductID] = change.Quantity
+	}
+
+	remaining := productIDs[:0]
+	for _, productID := range productIDs {
+		if quantities[productID] > 0 {
+			remaining = append(remaining, productID)
+		}
+	}
+
+	if len(remaining) == 0 {
+		return nil, nil, fmt.Errorf("%w: an order needs at least one item; cancel it instead", ErrInvalidOrder)
+	}
+	if len(remaining) > MaxOrderItems {
+		return nil, nil, fmt.Errorf("%w: order has %d items, maximum is %d", ErrInvalidOrder, len(remaining), MaxOrderItems)
+	}
+
+	return remaining, quantities, nil
+}
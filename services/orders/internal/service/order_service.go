@@ -3,27 +3,70 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/events"
 	"github.com/mumumio1/coldy/pkg/idempotency"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/money"
 	"github.com/mumumio1/coldy/services/orders/internal/repository"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
+const (
+	// MaxOrderItems caps the number of distinct line items in an order
+	MaxOrderItems = 100
+	// MaxItemQuantity caps the quantity of a single line item
+	MaxItemQuantity = 1000
+	// ScopeOrdersAdmin grants access to cross-user order queries
+	ScopeOrdersAdmin = "orders:admin"
+)
+
+var (
+	// ErrInvalidOrder is returned when an order request fails validation
+	ErrInvalidOrder = errs.InvalidArgument("INVALID_ORDER", "invalid order")
+	// ErrOrderNotFound is returned when an order does not exist
+	ErrOrderNotFound = repository.ErrOrderNotFound
+	// ErrInvalidOrderState is returned when an operation is not allowed in the order's current status
+	ErrInvalidOrderState = errs.FailedPrecondition("INVALID_ORDER_STATE", "invalid order state")
+)
+
 // OrderService handles order business logic
 type OrderService struct {
-	repo        *repository.OrderRepository
-	idempotency *idempotency.Store
-	logger      *zap.Logger
+	repo        OrderStore
+	idempotency IdempotencyStore
+	redis       *redis.Client
+	velocity    VelocityConfig
+	products    ProductResolver
+	// defaultCurrency prices orders whose request and items carry no
+	// currency; empty means such orders are rejected
+	defaultCurrency string
+	logger          *zap.Logger
 }
 
 // NewOrderService creates a new order service
-func NewOrderService(repo *repository.OrderRepository, redis *redis.Client, logger *zap.Logger) *OrderService {
+func NewOrderService(
+	repo OrderStore,
+	redis *redis.Client,
+	idempotencyStore IdempotencyStore,
+	velocity VelocityConfig,
+	products ProductResolver,
+	defaultCurrency string,
+	logger *zap.Logger,
+) *OrderService {
 	return &OrderService{
-		repo:        repo,
-		idempotency: idempotency.NewStore(redis),
-		logger:      logger,
+		repo:            repo,
+		idempotency:     idempotencyStore,
+		redis:           redis,
+		velocity:        velocity,
+		products:        products,
+		defaultCurrency: defaultCurrency,
+		logger:          logger,
 	}
 }
 
@@ -36,13 +79,19 @@ type CreateOrderRequest struct {
 	ShippingState      string
 	ShippingPostalCode string
 	ShippingCountry    string
+	// Currency is optional; when set every priced item must match it
+	Currency string
 }
 
 // OrderItemRequest represents an order item request
 type OrderItemRequest struct {
-	ProductID   string
+	ProductID string
+	// SKU identifies the product instead of ProductID
+	SKU      string
+	Quantity int32
+	// ProductName and UnitPrice are set from the catalog when the order is
+	// priced; values passed in are ignored
 	ProductName string
-	Quantity    int32
 	UnitPrice   Money
 }
 
@@ -56,12 +105,15 @@ type Money struct {
 func (s *OrderService) CreateOrder(ctx context.Context, idempotencyKey string, req *CreateOrderRequest) (*repository.Order, bool, error) {
 	// Check idempotency
 	key := idempotency.GenerateKey(req.UserID, "create_order", idempotencyKey)
-	cached, found, err := s.idempotency.Get(ctx, key)
+	cached, found, err := s.idempotency.Get(ctx, "create_order", key)
 	if err != nil {
-		s.logger.Warn("idempotency check failed", zap.Error(err))
+		if !s.idempotency.FailOpen() {
+			return nil, false, err
+		}
+		logger.FromContext(ctx).Warn("idempotency check failed", zap.Error(err))
 	}
 	if found {
-		s.logger.Info("idempotent request, returning cached result",
+		logger.FromContext(ctx).Info("idempotent request, returning cached result",
 			zap.String("user_id", req.UserID),
 			zap.String("idempotency_key", idempotencyKey),
 		)
@@ -75,20 +127,63 @@ func (s *OrderService) CreateOrder(ctx context.Context, idempotencyKey string, r
 		return &order, true, nil
 	}
 
-	// Calculate total
-	var totalAmount int64
-	currency := "USD"
-	for _, item := range req.Items {
-		totalAmount += item.UnitPrice.Amount * int64(item.Quantity)
-		if item.UnitPrice.Currency != "" {
-			currency = item.UnitPrice.Currency
+	// Checked again after merging; this bound keeps an oversized request from
+	// resolving every SKU first
+	if len(req.Items) > MaxOrderItems {
+		return nil, false, fmt.Errorf("%w: order has %d items, maximum is %d", ErrInvalidOrder, len(req.Items), MaxOrderItems)
+	}
+	if err := s.resolveSKUs(ctx, req.Items); err != nil {
+		return nil, false, err
+	}
+
+	items, err := normalizeItems(req.Items)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Items = items
+
+	if err := validateShippingAddress(req); err != nil {
+		return nil, false, err
+	}
+
+	if err := s.priceItems(ctx, req.Items); err != nil {
+		return nil, false, err
+	}
+
+	// Calculate totals; every item must be priced in the same currency
+	currency, err := s.orderCurrency(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	lineTotals := make([]money.Money, len(req.Items))
+	for i, item := range req.Items {
+		unitPrice := money.Money{Currency: item.UnitPrice.Currency, Amount: item.UnitPrice.Amount}
+		if unitPrice.Currency == "" {
+			unitPrice.Currency = currency
 		}
+		if err := money.ValidateCurrency(unitPrice.Currency); err != nil {
+			return nil, false, fmt.Errorf("%w: product %s: %v", ErrInvalidOrder, item.ProductID, err)
+		}
+
+		lineTotal, err := unitPrice.Multiply(int64(item.Quantity))
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: product %s: %v", ErrInvalidOrder, item.ProductID, err)
+		}
+		lineTotals[i] = lineTotal
 	}
 
-	// Create order
+	total, err := money.Sum(lineTotals...)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidOrder, err)
+	}
+	totalAmount := total.Amount
+
+	// Create order; the ID is assigned here so the event can carry it
 	order := &repository.Order{
+		ID:                 uuid.New().String(),
 		UserID:             req.UserID,
-		TotalCurrency:      currency,
+		TotalCurrency:      total.Currency,
 		TotalAmount:        totalAmount,
 		Status:             repository.StatusPending,
 		ShippingStreet:     req.ShippingStreet,
@@ -99,45 +194,52 @@ func (s *OrderService) CreateOrder(ctx context.Context, idempotencyKey string, r
 	}
 
 	// Create order items
-	for _, item := range req.Items {
-		totalPrice := item.UnitPrice.Amount * int64(item.Quantity)
+	eventItems := make([]events.OrderItem, len(req.Items))
+	for i, item := range req.Items {
 		order.Items = append(order.Items, repository.OrderItem{
 			ProductID:          item.ProductID,
 			ProductName:        item.ProductName,
 			Quantity:           item.Quantity,
-			UnitPriceCurrency:  item.UnitPrice.Currency,
+			UnitPriceCurrency:  lineTotals[i].Currency,
 			UnitPriceAmount:    item.UnitPrice.Amount,
-			TotalPriceCurrency: item.UnitPrice.Currency,
-			TotalPriceAmount:   totalPrice,
+			TotalPriceCurrency: lineTotals[i].Currency,
+			TotalPriceAmount:   lineTotals[i].Amount,
 		})
+		eventItems[i] = events.OrderItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice.Amount,
+		}
 	}
 
 	// Create outbox event
-	event := &repository.OutboxEvent{
-		AggregateType: "order",
-		EventType:     "order.created",
-		Payload: map[string]interface{}{
-			"order_id": order.ID,
-			"user_id":  order.UserID,
-			"total":    totalAmount,
-			"currency": currency,
-			"status":   string(order.Status),
-			"items":    req.Items,
-		},
+	event := orderEvent(events.OrderCreated{
+		OrderID:  order.ID,
+		UserID:   order.UserID,
+		Total:    totalAmount,
+		Currency: total.Currency,
+		Status:   string(order.Status),
+		Items:    eventItems,
+	})
+
+	reservation, err := s.reserveVelocity(ctx, req.UserID, total.Currency, 1, totalAmount)
+	if err != nil {
+		return nil, false, err
 	}
 
 	// Create order with outbox event in transaction
 	if err := s.repo.CreateWithOutbox(ctx, order, event); err != nil {
+		s.releaseVelocity(ctx, reservation)
 		return nil, false, fmt.Errorf("failed to create order: %w", err)
 	}
 
 	// Cache the result for idempotency
-	orderJSON, _ := json.Marshal(order)
-	if err := s.idempotency.Set(ctx, key, 200, orderJSON); err != nil {
-		s.logger.Warn("failed to cache idempotency result", zap.Error(err))
+	if err := s.idempotency.Set(ctx, "create_order", key, 200, order); err != nil {
+		logger.FromContext(ctx).Warn("failed to cache idempotency result", zap.Error(err))
 	}
 
-	s.logger.Info("order created",
+	logger.FromContext(ctx).Info("order created",
 		zap.String("order_id", order.ID),
 		zap.String("user_id", order.UserID),
 		zap.Int64("total", totalAmount),
@@ -153,28 +255,49 @@ func (s *OrderService) GetOrder(ctx context.Context, orderID string) (*repositor
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
 	if order == nil {
-		return nil, fmt.Errorf("order not found")
+		return nil, ErrOrderNotFound
 	}
 	return order, nil
 }
 
-// UpdateOrderStatus updates order status
-func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID string, status repository.OrderStatus) error {
-	// Create status change event
-	event := &repository.OutboxEvent{
-		AggregateType: "order",
-		EventType:     fmt.Sprintf("order.%s", status),
-		Payload: map[string]interface{}{
-			"order_id": orderID,
-			"status":   string(status),
-		},
+// UpdateOrderStatus updates order status. Setting the status the order is
+// already in succeeds without emitting another event, and a non-empty
+// idempotencyKey makes retries of the same request no-ops. An order is only
+// confirmed or marked paid if its total passes VerifyTotal, so a stale or
+// tampered total is not charged.
+func (s *OrderService) UpdateOrderStatus(ctx context.Context, idempotencyKey, orderID string, status repository.OrderStatus) error {
+	key := idempotency.GenerateKey(orderID, "update_order_status", idempotencyKey)
+	if seen, err := s.seenIdempotencyKey(ctx, "update_order_status", idempotencyKey, key); err != nil || seen {
+		return err
+	}
+
+	if status == repository.StatusConfirmed || status == repository.StatusPaid {
+		if _, err := s.VerifyTotal(ctx, orderID); err != nil {
+			return err
+		}
 	}
 
-	if err := s.repo.UpdateStatus(ctx, orderID, status, event); err != nil {
+	// Create status change event
+	event := orderEvent(events.OrderStatusChanged{
+		OrderID: orderID,
+		Status:  string(status),
+	})
+
+	changed, err := s.repo.UpdateStatus(ctx, orderID, status, event)
+	if err != nil {
 		return fmt.Errorf("failed to update order status: %w", err)
 	}
+	s.rememberIdempotencyKey(ctx, "update_order_status", idempotencyKey, key)
+
+	if !changed {
+		logger.FromContext(ctx).Info("order already in status",
+			zap.String("order_id", orderID),
+			zap.String("status", string(status)),
+		)
+		return nil
+	}
 
-	s.logger.Info("order status updated",
+	logger.FromContext(ctx).Info("order status updated",
 		zap.String("order_id", orderID),
 		zap.String("status", string(status)),
 	)
@@ -182,44 +305,111 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID string, st
 	return nil
 }
 
-// CancelOrder cancels an order
-func (s *OrderService) CancelOrder(ctx context.Context, orderID, reason string) error {
+// CancelOrder cancels an order. Canceling an order that was paid, including
+// one processing or shipped since, also requests a refund of it. Delivered
+// and refunded orders can't be canceled. Canceling an already canceled order succeeds without
+// emitting another event or refund request, and a non-empty idempotencyKey
+// makes retries of the same request no-ops.
+func (s *OrderService) CancelOrder(ctx context.Context, idempotencyKey, orderID, reason string) error {
+	key := idempotency.GenerateKey(orderID, "cancel_order", idempotencyKey)
+	if seen, err := s.seenIdempotencyKey(ctx, "cancel_order", idempotencyKey, key); err != nil || seen {
+		return err
+	}
+
 	// Get current order
 	order, err := s.repo.GetByID(ctx, orderID)
 	if err != nil {
 		return fmt.Errorf("failed to get order: %w", err)
 	}
 	if order == nil {
-		return fmt.Errorf("order not found")
+		return ErrOrderNotFound
 	}
 
-	// Check if order can be canceled
-	if order.Status == repository.StatusDelivered || order.Status == repository.StatusCancelled {
-		return fmt.Errorf("order cannot be canceled in status: %s", order.Status)
+	// Fail early here; the status is checked again under the row lock
+	if !order.Status.Cancellable() {
+		return fmt.Errorf("%w: order cannot be canceled in status %s", ErrInvalidOrderState, order.Status)
 	}
 
 	// Create cancellation event
-	event := &repository.OutboxEvent{
-		AggregateType: "order",
-		EventType:     "order.canceled",
-		Payload: map[string]interface{}{
-			"order_id": orderID,
-			"reason":   reason,
-		},
+	event := orderEvent(events.OrderCanceled{
+		OrderID: orderID,
+		UserID:  order.UserID,
+		Reason:  reason,
+	})
+	// Only written if the order is still paid when it is canceled
+	refundEvent := orderEvent(events.OrderRefundRequested{
+		OrderID:   orderID,
+		UserID:    order.UserID,
+		PaymentID: order.PaymentID,
+		Amount:    order.TotalAmount,
+		Currency:  order.TotalCurrency,
+		Reason:    reason,
+	})
+
+	changed, refundRequested, err := s.repo.Cancel(ctx, orderID, event, refundEvent)
+	if errors.Is(err, repository.ErrNotCancellable) {
+		return fmt.Errorf("%w: %w", ErrInvalidOrderState, err)
 	}
-
-	if err := s.repo.UpdateStatus(ctx, orderID, repository.StatusCancelled, event); err != nil {
+	if err != nil {
 		return fmt.Errorf("failed to cancel order: %w", err)
 	}
+	s.rememberIdempotencyKey(ctx, "cancel_order", idempotencyKey, key)
 
-	s.logger.Info("order canceled",
+	if !changed {
+		logger.FromContext(ctx).Info("order already canceled", zap.String("order_id", orderID))
+		return nil
+	}
+
+	logger.FromContext(ctx).Info("order canceled",
 		zap.String("order_id", orderID),
 		zap.String("reason", reason),
+		zap.Bool("refund_requested", refundRequested),
 	)
 
 	return nil
 }
 
+// orderEvent wraps a typed event for the order outbox
+func orderEvent(payload events.Event) *repository.OutboxEvent {
+	return &repository.OutboxEvent{
+		AggregateType: "order",
+		EventType:     payload.EventType(),
+		Payload:       payload,
+	}
+}
+
+// seenIdempotencyKey reports whether a request with idempotencyKey already
+// succeeded. An empty idempotencyKey is never seen.
+func (s *OrderService) seenIdempotencyKey(ctx context.Context, operation, idempotencyKey, key string) (bool, error) {
+	if idempotencyKey == "" {
+		return false, nil
+	}
+
+	_, found, err := s.idempotency.Get(ctx, operation, key)
+	if err != nil {
+		if !s.idempotency.FailOpen() {
+			return false, err
+		}
+		logger.FromContext(ctx).Warn("idempotency check failed", zap.Error(err))
+	}
+	if found {
+		logger.FromContext(ctx).Info("idempotent request, already applied",
+			zap.String("idempotency_key", idempotencyKey),
+		)
+	}
+	return found, nil
+}
+
+// rememberIdempotencyKey records that a request with idempotencyKey succeeded
+func (s *OrderService) rememberIdempotencyKey(ctx context.Context, operation, idempotencyKey, key string) {
+	if idempotencyKey == "" {
+		return
+	}
+	if err := s.idempotency.Set(ctx, operation, key, 200, nil); err != nil {
+		logger.FromContext(ctx).Warn("failed to cache idempotency result", zap.Error(err))
+	}
+}
+
 // ListOrders lists orders
 func (s *OrderService) ListOrders(ctx context.Context, userID string, status repository.OrderStatus, limit int, cursor string) ([]*repository.Order, string, bool, error) {
 	orders, nextCursor, err := s.repo.List(ctx, userID, status, limit, cursor)
@@ -231,7 +421,7 @@ func (s *OrderService) ListOrders(ctx context.Context, userID string, status rep
 	for _, order := range orders {
 		fullOrder, err := s.repo.GetByID(ctx, order.ID)
 		if err != nil {
-			s.logger.Warn("failed to load order items", zap.Error(err))
+			logger.FromContext(ctx).Warn("failed to load order items", zap.Error(err))
 			continue
 		}
 		order.Items = fullOrder.Items
@@ -240,3 +430,200 @@ func (s *OrderService) ListOrders(ctx context.Context, userID string, status rep
 	hasMore := nextCursor != ""
 	return orders, nextCursor, hasMore, nil
 }
+
+// ListOrdersByStatus lists orders of all users in a status, for operations tooling
+func (s *OrderService) ListOrdersByStatus(ctx context.Context, status repository.OrderStatus, createdFrom, createdTo time.Time, limit int, cursor string) ([]*repository.Order, string, bool, error) {
+	if status == "" {
+		return nil, "", false, fmt.Errorf("%w: status is required", ErrInvalidOrder)
+	}
+	if !createdFrom.IsZero() && !createdTo.IsZero() && !createdFrom.Before(createdTo) {
+		return nil, "", false, fmt.Errorf("%w: created_from must be before created_to", ErrInvalidOrder)
+	}
+
+	orders, nextCursor, err := s.repo.ListAllByStatus(ctx, status, createdFrom, createdTo, limit, cursor)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	// Load items for each order
+	for _, order := range orders {
+		fullOrder, err := s.repo.GetByID(ctx, order.ID)
+		if err != nil {
+			logger.FromContext(ctx).Warn("failed to load order items", zap.Error(err))
+			continue
+		}
+		order.Items = fullOrder.Items
+	}
+
+	hasMore := nextCursor != ""
+	return orders, nextCursor, hasMore, nil
+}
+
+// ListOrdersByProduct lists orders of all users containing a product, for
+// recalls and support tooling. An empty status matches every status.
+func (s *OrderService) ListOrdersByProduct(ctx context.Context, productID string, status repository.OrderStatus, limit int, cursor string) ([]*repository.Order, string, bool, error) {
+	if productID == "" {
+		return nil, "", false, fmt.Errorf("%w: product_id is required", ErrInvalidOrder)
+	}
+
+	orders, nextCursor, err := s.repo.ListByProduct(ctx, productID, status, limit, cursor)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	// Load items for each order
+	for _, order := range orders {
+		fullOrder, err := s.repo.GetByID(ctx, order.ID)
+		if err != nil {
+			logger.FromContext(ctx).Warn("failed to load order items", zap.Error(err))
+			continue
+		}
+		order.Items = fullOrder.Items
+	}
+
+	hasMore := nextCursor != ""
+	return orders, nextCursor, hasMore, nil
+}
+
+// ReplayOutboxEvents re-queues already published outbox events for the outbox
+// publisher, either by event ID or by aggregate (order) ID within an optional
+// created_at range. Redelivery is safe because consumers dedup on the
+// message_id, which is derived from the outbox ID.
+func (s *OrderService) ReplayOutboxEvents(ctx context.Context, eventIDs []string, aggregateID string, createdFrom, createdTo time.Time) (int64, error) {
+	if len(eventIDs) == 0 && aggregateID == "" {
+		return 0, fmt.Errorf("%w: event_ids or aggregate_id is required", ErrInvalidOrder)
+	}
+	if len(eventIDs) > 0 && aggregateID != "" {
+		return 0, fmt.Errorf("%w: event_ids and aggregate_id are mutually exclusive", ErrInvalidOrder)
+	}
+	if !createdFrom.IsZero() && !createdTo.IsZero() && !createdFrom.Before(createdTo) {
+		return 0, fmt.Errorf("%w: created_from must be before created_to", ErrInvalidOrder)
+	}
+
+	var count int64
+	var err error
+	if len(eventIDs) > 0 {
+		count, err = s.repo.ResetPublished(ctx, eventIDs)
+	} else {
+		count, err = s.repo.ResetPublishedForAggregate(ctx, aggregateID, createdFrom, createdTo)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to replay outbox events: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("outbox events queued for replay",
+		zap.Strings("event_ids", eventIDs),
+		zap.String("aggregate_id", aggregateID),
+		zap.Int64("count", count),
+	)
+
+	return count, nil
+}
+
+// ListDeadLetteredEvents lists outbox events the publisher gave up on, with
+// their attempt count and last error
+func (s *OrderService) ListDeadLetteredEvents(ctx context.Context, limit int, cursor string) ([]*repository.OutboxEvent, string, bool, error) {
+	events, nextCursor, err := s.repo.ListDeadLettered(ctx, limit, cursor)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to list dead-lettered events: %w", err)
+	}
+
+	hasMore := nextCursor != ""
+	return events, nextCursor, hasMore, nil
+}
+
+// RequeueDeadLetteredEvents hands dead-lettered outbox events back to the
+// publisher, e.g. once the cause of their failures is fixed
+func (s *OrderService) RequeueDeadLetteredEvents(ctx context.Context, eventIDs []string) (int64, error) {
+	if len(eventIDs) == 0 {
+		return 0, fmt.Errorf("%w: event_ids is required", ErrInvalidOrder)
+	}
+
+	count, err := s.repo.RequeueDeadLettered(ctx, eventIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue dead-lettered events: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("dead-lettered outbox events requeued",
+		zap.Strings("event_ids", eventIDs),
+		zap.Int64("count", count),
+	)
+
+	return count, nil
+}
+
+// orderCurrency determines the currency of an order from the request, then
+// from its priced items, then from the configured default
+func (s *OrderService) orderCurrency(req *CreateOrderRequest) (string, error) {
+	currency := req.Currency
+	if currency != "" {
+		normalized, err := money.NormalizeCurrency(currency)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidOrder, err)
+		}
+		currency = normalized
+	}
+
+	for _, item := range req.Items {
+		if item.UnitPrice.Currency == "" {
+			continue
+		}
+		if currency == "" {
+			currency = item.UnitPrice.Currency
+			continue
+		}
+		if item.UnitPrice.Currency != currency {
+			return "", fmt.Errorf("%w: product %s is priced in %s, order currency is %s",
+				ErrInvalidOrder, item.ProductID, item.UnitPrice.Currency, currency)
+		}
+	}
+
+	if currency == "" {
+		currency = s.defaultCurrency
+	}
+	if currency == "" {
+		return "", fmt.Errorf("%w: currency is required", ErrInvalidOrder)
+	}
+	return currency, nil
+}
+
+// normalizeItems validates order items and collapses duplicate product IDs
+func normalizeItems(items []OrderItemRequest) ([]OrderItemRequest, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: items are required", ErrInvalidOrder)
+	}
+
+	merged := make([]OrderItemRequest, 0, len(items))
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		if item.ProductID == "" {
+			return nil, fmt.Errorf("%w: item %d: product_id is required", ErrInvalidOrder, i)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: item %d: quantity must be positive, got %d", ErrInvalidOrder, i, item.Quantity)
+		}
+		if item.Quantity > MaxItemQuantity {
+			return nil, fmt.Errorf("%w: item %d: quantity exceeds maximum of %d", ErrInvalidOrder, i, MaxItemQuantity)
+		}
+
+		// Collapse duplicate product IDs into a single line item. The sum is
+		// checked in int64 so it can't wrap around past the maximum.
+		if idx, exists := index[item.ProductID]; exists {
+			total := int64(merged[idx].Quantity) + int64(item.Quantity)
+			if total > MaxItemQuantity {
+				return nil, fmt.Errorf("%w: product %s: quantity exceeds maximum of %d", ErrInvalidOrder, item.ProductID, MaxItemQuantity)
+			}
+			merged[idx].Quantity = int32(total)
+			continue
+		}
+
+		index[item.ProductID] = len(merged)
+		merged = append(merged, item)
+	}
+
+	if len(merged) > MaxOrderItems {
+		return nil, fmt.Errorf("%w: order has %d items, maximum is %d", ErrInvalidOrder, len(merged), MaxOrderItems)
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNormalizeItemsMergesDuplicates(t *testing.T) {
+	items, err := normalizeItems([]OrderItemRequest{
+		{ProductID: "p1", Quantity: 2},
+		{ProductID: "p2", Quantity: 1},
+		{ProductID: "p1", Quantity: 3},
+	})
+	if err != nil {
+		t.Fatalf("normalizeItems failed: %v", err)
+	}
+	if len(items) != 2 || items[0].ProductID != "p1" || items[0].Quantity != 5 || items[1].Quantity != 1 {
+		t.Errorf("got %+v, want p1x5 and p2x1", items)
+	}
+}
+
+func TestNormalizeItemsRejectsQuantities(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []OrderItemRequest
+	}{
+		{name: "zero", items: []OrderItemRequest{{ProductID: "p1", Quantity: 0}}},
+		{name: "negative", items: []OrderItemRequest{{ProductID: "p1", Quantity: -1}}},
+		{name: "above maximum", items: []OrderItemRequest{{ProductID: "p1", Quantity: MaxItemQuantity + 1}}},
+		{name: "merged above maximum", items: []OrderItemRequest{
+			{ProductID: "p1", Quantity: MaxItemQuantity},
+			{ProductID: "p1", Quantity: 1},
+		}},
+		// Summed in int32 these wrap around to a small positive quantity
+		{name: "merged overflow", items: []OrderItemRequest{
+			{ProductID: "p1", Quantity: 2},
+			{ProductID: "p1", Quantity: math.MaxInt32},
+		}},
+		{name: "negative after large", items: []OrderItemRequest{
+			{ProductID: "p1", Quantity: math.MaxInt32},
+			{ProductID: "p1", Quantity: math.MinInt32},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := normalizeItems(tt.items)
+			if !errors.Is(err, ErrInvalidOrder) {
+				t.Errorf("expected ErrInvalidOrder, got items %+v, err %v", items, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+	"github.com/mumumio1/coldy/services/orders/internal/repository/memory"
+	"github.com/mumumio1/coldy/services/orders/internal/service"
+	"go.uber.org/zap"
+)
+
+func TestCreateOrderPricesFromCatalog(t *testing.T) {
+	catalog := newFakeCatalog()
+	catalog.setPrice("product-1", "Mug", "USD", 1250)
+	catalog.setPrice("product-2", "Plate", "USD", 800)
+	svc := service.NewOrderService(memory.NewOrderStore(), nil, memory.NewIdempotencyStore(false), service.VelocityConfig{}, catalog, "USD", zap.NewNop())
+
+	req := newCreateRequest("user-1")
+	req.Items = []service.OrderItemRequest{
+		// A client-supplied price is ignored
+		{ProductID: "product-1", Quantity: 2, ProductName: "Free mug", UnitPrice: service.Money{Currency: "USD", Amount: 1}},
+		{ProductID: "product-2", Quantity: 3},
+	}
+
+	order, _, err := svc.CreateOrder(context.Background(), "key-1", req)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if order.TotalCurrency != "USD" || order.TotalAmount != 2*1250+3*800 {
+		t.Errorf("total = %d %s, want 4900 USD", order.TotalAmount, order.TotalCurrency)
+	}
+
+	want := map[string]struct {
+		name  string
+		unit  int64
+		total int64
+	}{
+		"product-1": {name: "Mug", unit: 1250, total: 2500},
+		"product-2": {name: "Plate", unit: 800, total: 2400},
+	}
+	for _, item := range order.Items {
+		w := want[item.ProductID]
+		if item.ProductName != w.name || item.UnitPriceAmount != w.unit || item.TotalPriceAmount != w.total {
+			t.Errorf("item %s = %s at %d for %d, want %s at %d for %d",
+				item.ProductID, item.ProductName, item.UnitPriceAmount, item.TotalPriceAmount, w.name, w.unit, w.total)
+		}
+	}
+}
+
+func TestCreateOrderPricingFailures(t *testing.T) {
+	tests := map[string]struct {
+		currency string
+		prices   map[string]service.ProductQuote
+	}{
+		"product not in catalog": {
+			prices: map[string]service.ProductQuote{},
+		},
+		"mixed currencies": {
+			prices: map[string]service.ProductQuote{
+				"product-1": {Name: "Mug", Currency: "USD", Amount: 1250},
+				"product-2": {Name: "Plate", Currency: "EUR", Amount: 800},
+			},
+		},
+		"currency differs from request": {
+			currency: "EUR",
+			prices: map[string]service.ProductQuote{
+				"product-1": {Name: "Mug", Currency: "USD", Amount: 1250},
+				"product-2": {Name: "Plate", Currency: "USD", Amount: 800},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			catalog := newFakeCatalog()
+			catalog.prices = tt.prices
+			store := memory.NewOrderStore()
+			svc := service.NewOrderService(store, nil, memory.NewIdempotencyStore(false), service.VelocityConfig{}, catalog, "USD", zap.NewNop())
+
+			req := newCreateRequest("user-1")
+			req.Currency = tt.currency
+			req.Items = append(req.Items, service.OrderItemRequest{ProductID: "product-2", Quantity: 1})
+
+			if _, _, err := svc.CreateOrder(context.Background(), "key-1", req); !errors.Is(err, service.ErrInvalidOrder) {
+				t.Fatalf("expected ErrInvalidOrder, got %v", err)
+			}
+			if n := len(store.Events()); n != 0 {
+				t.Errorf("expected no order to be created, got %d events", n)
+			}
+		})
+	}
+}
+
+func TestCreateOrderRequiresCatalog(t *testing.T) {
+	svc := service.NewOrderService(memory.NewOrderStore(), nil, memory.NewIdempotencyStore(false), service.VelocityConfig{}, nil, "USD", zap.NewNop())
+
+	if _, _, err := svc.CreateOrder(context.Background(), "key-1", newCreateRequest("user-1")); !errors.Is(err, service.ErrInvalidOrder) {
+		t.Errorf("expected ErrInvalidOrder without a catalog to price from, got %v", err)
+	}
+}
+
+func TestUpdateOrderItemsRepricesFromCatalog(t *testing.T) {
+	ctx := context.Background()
+	catalog := newFakeCatalog()
+	catalog.setPrice("product-1", "Mug", "USD", 1250)
+	store := memory.NewOrderStore()
+	svc := service.NewOrderService(store, nil, memory.NewIdempotencyStore(false), service.VelocityConfig{}, catalog, "USD", zap.NewNop())
+
+	order, _, err := svc.CreateOrder(ctx, "key-1", newCreateRequest("user-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	// The mug's price went up since the order was placed
+	catalog.setPrice("product-1", "Mug", "USD", 1500)
+	catalog.setPrice("product-2", "Plate", "USD", 800)
+
+	updated, err := svc.UpdateOrderItems(ctx, order.ID, []service.ItemChange{{ProductID: "product-2", Quantity: 1}})
+	if err != nil {
+		t.Fatalf("UpdateOrderItems failed: %v", err)
+	}
+	if updated.TotalAmount != 2*1500+800 {
+		t.Errorf("total = %d, want every item at its current price: 3800", updated.TotalAmount)
+	}
+
+	stored, err := svc.GetOrder(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetOrder failed: %v", err)
+	}
+	for _, item := range stored.Items {
+		if item.ProductID == "product-1" && (item.UnitPriceAmount != 1500 || item.ID != order.Items[0].ID) {
+			t.Errorf("mug = %+v, want it repriced in place at 1500", item)
+		}
+	}
+}
+
+func TestUpdateOrderItemsRejectsUnpricedProducts(t *testing.T) {
+	ctx := context.Background()
+	catalog := newPricedCatalog()
+	svc := service.NewOrderService(memory.NewOrderStore(), nil, memory.NewIdempotencyStore(false), service.VelocityConfig{}, catalog, "USD", zap.NewNop())
+
+	order, _, err := svc.CreateOrder(ctx, "key-1", newCreateRequest("user-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	if _, err := svc.UpdateOrderItems(ctx, order.ID, []service.ItemChange{{ProductID: "missing", Quantity: 1}}); !errors.Is(err, service.ErrInvalidOrder) {
+		t.Errorf("expected ErrInvalidOrder for a product the catalog can't price, got %v", err)
+	}
+
+	stored, err := svc.GetOrder(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetOrder failed: %v", err)
+	}
+	if stored.Status != repository.StatusPending || len(stored.Items) != 1 || stored.TotalAmount != order.TotalAmount {
+		t.Errorf("order changed by a rejected update: %+v", stored)
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/errs"
+)
+
+// ErrUnknownSKU is returned when an order item names a SKU the catalog doesn't have
+var ErrUnknownSKU = errs.InvalidArgument("UNKNOWN_SKU", "unknown sku")
+
+// ProductResolver resolves merchant SKUs to catalog product IDs and looks up
+// current catalog prices
+type ProductResolver interface {
+	// ResolveSKU returns "" for an unknown SKU
+	ResolveSKU(ctx context.Context, sku string) (string, error)
+	// QuotePrices leaves unknown products out of the result
+	QuotePrices(ctx context.Context, productIDs []string) (map[string]ProductQuote, error)
+}
+
+// ProductQuote is a product's current catalog name and unit price
+type ProductQuote struct {
+	Name     string
+	Currency string
+	Amount   int64
+}
+
+// resolveSKUs fills in the product ID of items given by SKU. Orders only
+// store product IDs, so the SKU is dropped once resolved.
+func (s *OrderService) resolveSKUs(ctx context.Context, items []OrderItemRequest) error {
+	resolved := make(map[string]string)
+	for i := range items {
+		item := &items[i]
+		if item.SKU == "" {
+			continue
+		}
+		if item.ProductID != "" {
+			return fmt.Errorf("%w: item %d: set either product_id or sku, not both", ErrInvalidOrder, i)
+		}
+		if s.products == nil {
+			return fmt.Errorf("%w: item %d: ordering by sku is not supported", ErrInvalidOrder, i)
+		}
+
+		productID, ok := resolved[item.SKU]
+		if !ok {
+			var err error
+			productID, err = s.products.ResolveSKU(ctx, item.SKU)
+			if err != nil {
+				return fmt.Errorf("failed to resolve sku %s: %w", item.SKU, err)
+			}
+			if productID == "" {
+				return fmt.Errorf("%w: %s", ErrUnknownSKU, item.SKU)
+			}
+			resolved[item.SKU] = productID
+		}
+
+		item.ProductID = productID
+		item.SKU = ""
+	}
+	return nil
+}
+
+// priceItems sets the name and unit price of each item to the product's
+// current catalog values, as UpdateOrderItems does, so the client never
+// decides what it pays
+func (s *OrderService) priceItems(ctx context.Context, items []OrderItemRequest) error {
+	if s.products == nil {
+		return fmt.Errorf("%w: pricing orders is not supported", ErrInvalidOrder)
+	}
+
+	productIDs := make([]string, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+	quotes, err := s.products.QuotePrices(ctx, productIDs)
+	if err != nil {
+		return fmt.Errorf("failed to price order items: %w", err)
+	}
+
+	for i := range items {
+		item := &items[i]
+		quote, ok := quotes[item.ProductID]
+		if !ok {
+			return fmt.Errorf("%w: product %s is not in the catalog", ErrInvalidOrder, item.ProductID)
+		}
+		item.ProductName = quote.Name
+		item.UnitPrice = Money{Currency: quote.Currency, Amount: quote.Amount}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mumumio1/coldy/services/orders/internal/service"
+)
+
+// fakeCatalog resolves SKUs and quotes prices from fixed maps and counts the
+// calls it receives
+type fakeCatalog struct {
+	mu           sync.Mutex
+	skus         map[string]string
+	prices       map[string]service.ProductQuote
+	resolveCalls int
+	quoteCalls   int
+}
+
+func newFakeCatalog() *fakeCatalog {
+	return &fakeCatalog{
+		skus:   make(map[string]string),
+		prices: make(map[string]service.ProductQuote),
+	}
+}
+
+func (c *fakeCatalog) setPrice(productID, name, currency string, amount int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prices[productID] = service.ProductQuote{Name: name, Currency: currency, Amount: amount}
+}
+
+func (c *fakeCatalog) ResolveSKU(ctx context.Context, sku string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resolveCalls++
+	return c.skus[sku], nil
+}
+
+func (c *fakeCatalog) QuotePrices(ctx context.Context, productIDs []string) (map[string]service.ProductQuote, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.quoteCalls++
+
+	quotes := make(map[string]service.ProductQuote, len(productIDs))
+	for _, productID := range productIDs {
+		if quote, ok := c.prices[productID]; ok {
+			quotes[productID] = quote
+		}
+	}
+	return quotes, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/idempotency"
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+)
+
+// OrderStore is the order persistence OrderService depends on. The SQL
+// repository implements it; memory.OrderStore is an in-memory stand-in.
+type OrderStore interface {
+	CreateWithOutbox(ctx context.Context, order *repository.Order, event *repository.OutboxEvent) error
+	InsertOutboxEvent(ctx context.Context, event *repository.OutboxEvent) error
+	GetByID(ctx context.Context, id string) (*repository.Order, error)
+	UpdateStatus(ctx context.Context, orderID string, status repository.OrderStatus, event *repository.OutboxEvent) (bool, error)
+	Cancel(ctx context.Context, orderID string, event, refundEvent *repository.OutboxEvent) (bool, bool, error)
+	ReplaceItems(ctx context.Context, order *repository.Order, event *repository.OutboxEvent) error
+	List(ctx context.Context, userID string, status repository.OrderStatus, limit int, cursor string) ([]*repository.Order, string, error)
+	ListAllByStatus(ctx context.Context, status repository.OrderStatus, createdFrom, createdTo time.Time, limit int, cursor string) ([]*repository.Order, string, error)
+	ListByProduct(ctx context.Context, productID string, status repository.OrderStatus, limit int, cursor string) ([]*repository.Order, string, error)
+	Summary(ctx context.Context, filter repository.SummaryFilter) ([]repository.DailyBucket, error)
+	ResetPublished(ctx context.Context, eventIDs []string) (int64, error)
+	ResetPublishedForAggregate(ctx context.Context, aggregateID string, createdFrom, createdTo time.Time) (int64, error)
+	ListDeadLettered(ctx context.Context, limit int, cursor string) ([]*repository.OutboxEvent, string, error)
+	RequeueDeadLettered(ctx context.Context, eventIDs []string) (int64, error)
+}
+
+var _ OrderStore = (*repository.OrderRepository)(nil)
+
+// IdempotencyStore caches the results of requests made with an idempotency
+// key. *idempotency.Store implements it; memory.IdempotencyStore is an
+// in-memory stand-in.
+type IdempotencyStore interface {
+	Get(ctx context.Context, operation, key string) (*idempotency.Result, bool, error)
+	Set(ctx context.Context, operation, key string, statusCode int, body interface{}) error
+	FailOpen() bool
+}
+
+var _ IdempotencyStore = (*idempotency.Store)(nil)
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+)
+
+// MaxSummaryRange bounds the created_at range of an orders summary so a
+// dashboard query can't scan the whole table
+const MaxSummaryRange = 366 * 24 * time.Hour
+
+// OrdersSummary returns daily order counts and revenue per currency, for one
+// user or, with an empty UserID, for all users
+func (s *OrderService) OrdersSummary(ctx context.Context, filter repository.SummaryFilter) ([]repository.DailyBucket, error) {
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return nil, fmt.Errorf("%w: created_from and created_to are required", ErrInvalidOrder)
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: created_from must be before created_to", ErrInvalidOrder)
+	}
+	if filter.To.Sub(filter.From) > MaxSummaryRange {
+		return nil, fmt.Errorf("%w: range exceeds %d days", ErrInvalidOrder, int(MaxSummaryRange.Hours()/24))
+	}
+
+	buckets, err := s.repo.Summary(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize orders: %w", err)
+	}
+	return buckets, nil
+}
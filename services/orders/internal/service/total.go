@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/money"
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+	"go.uber.org/zap"
+)
+
+// ErrTotalMismatch is returned when an order's stored total does not match
+// the total of its items
+var ErrTotalMismatch = errs.FailedPrecondition("ORDER_TOTAL_MISMATCH", "order total does not match its items")
+
+// VerifyTotal recomputes an order's total from the unit prices and
+// quantities of its stored items and fails with ErrTotalMismatch unless the
+// stored line totals and order total agree with it. It returns the verified
+// total, which payments charge.
+func (s *OrderService) VerifyTotal(ctx context.Context, orderID string) (money.Money, error) {
+	order, err := s.GetOrder(ctx, orderID)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return verifyTotal(ctx, order)
+}
+
+func verifyTotal(ctx context.Context, order *repository.Order) (money.Money, error) {
+	if len(order.Items) == 0 {
+		return money.Money{}, fmt.Errorf("%w: order %s has no items", ErrTotalMismatch, order.ID)
+	}
+
+	lineTotals := make([]money.Money, len(order.Items))
+	for i, item := range order.Items {
+		if item.UnitPriceCurrency != order.TotalCurrency || item.TotalPriceCurrency != order.TotalCurrency {
+			return money.Money{}, fmt.Errorf("%w: item %s is priced in %s, order currency is %s",
+				ErrTotalMismatch, item.ProductID, item.UnitPriceCurrency, order.TotalCurrency)
+		}
+
+		unitPrice := money.Money{Currency: item.UnitPriceCurrency, Amount: item.UnitPriceAmount}
+		lineTotal, err := unitPrice.Multiply(int64(item.Quantity))
+		if err != nil {
+			return money.Money{}, fmt.Errorf("%w: item %s: %v", ErrTotalMismatch, item.ProductID, err)
+		}
+		if lineTotal.Amount != item.TotalPriceAmount {
+			return money.Money{}, fmt.Errorf("%w: item %s total is %d, expected %d",
+				ErrTotalMismatch, item.ProductID, item.TotalPriceAmount, lineTotal.Amount)
+		}
+		lineTotals[i] = lineTotal
+	}
+
+	total, err := money.Sum(lineTotals...)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%w: %v", ErrTotalMismatch, err)
+	}
+	if total.Amount != order.TotalAmount {
+		logger.FromContext(ctx).Error("order total mismatch",
+			zap.String("order_id", order.ID),
+			zap.Int64("stored_total", order.TotalAmount),
+			zap.Int64("computed_total", total.Amount),
+		)
+		return money.Money{}, fmt.Errorf("%w: order %s total is %d, items add up to %d",
+			ErrTotalMismatch, order.ID, order.TotalAmount, total.Amount)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/services/orders/internal/repository"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const velocityKeyPrefix = "orders:velocity:"
+
+// ErrVelocityExceeded is returned when a user places too many or too large
+// orders within the velocity window
+var ErrVelocityExceeded = errs.ResourceExhausted("ORDER_VELOCITY_EXCEEDED", "order velocity limit exceeded")
+
+// VelocityConfig limits how much a single user can order within any period
+// of length Window; orders leave the window as it slides past them. A zero
+// Window disables the check.
+type VelocityConfig struct {
+	Window time.Duration
+	// MaxOrders caps the number of orders per window; 0 is unlimited
+	MaxOrders int64
+	// MaxAmount caps the order total per currency in minor units; currencies
+	// without an entry are unlimited
+	MaxAmount map[string]int64
+}
+
+func (c VelocityConfig) enabled() bool {
+	return c.Window > 0 && (c.MaxOrders > 0 || len(c.MaxAmount) > 0)
+}
+
+// velocityScript keeps a user's orders and amounts in sorted sets scored by
+// the time they were reserved, each member "<reservation>:<value>". Members
+// older than the window are dropped, and the new order and amount are added
+// only if the sums left stay within their limits (-1 is unlimited). ARGV is
+// now and window in milliseconds, orders, amount, the two limits and the
+// reservation ID. Members are built from the arguments as sent, not from Lua
+// numbers, so releaseVelocity can name them. It returns {allowed, count, amount}.
+var velocityScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local orders = tonumber(ARGV[3])
+local delta = tonumber(ARGV[4])
+local maxCount = tonumber(ARGV[5])
+local maxAmount = tonumber(ARGV[6])
+
+local function windowSum(key)
+	redis.call("ZREMRANGEBYSCORE", key, "-inf", now - window)
+	local sum = 0
+	for _, member in ipairs(redis.call("ZRANGE", key, 0, -1)) do
+		sum = sum + tonumber(string.match(member, ":(%d+)$"))
+	end
+	return sum
+end
+
+local count = windowSum(KEYS[1])
+local amount = windowSum(KEYS[2])
+if (maxCount >= 0 and count + orders > maxCount) or (maxAmount >= 0 and amount + delta > maxAmount) then
+	return {0, count, amount}
+end
+
+if orders > 0 then
+	redis.call("ZADD", KEYS[1], now, ARGV[7] .. ":" .. ARGV[3])
+	redis.call("PEXPIRE", KEYS[1], window)
+end
+if delta > 0 then
+	redis.call("ZADD", KEYS[2], now, ARGV[7] .. ":" .. ARGV[4])
+	redis.call("PEXPIRE", KEYS[2], window)
+end
+return {1, count + orders, amount + delta}
+`)
+
+func velocityKeys(userID, currency string) []string {
+	return []string{
+		velocityKeyPrefix + userID + ":orders",
+		velocityKeyPrefix + userID + ":amounts:" + currency,
+	}
+}
+
+// velocityReservation identifies what reserveVelocity added, so it can be
+// released
+type velocityReservation struct {
+	id       string
+	userID   string
+	currency string
+	orders   int64
+	amount   int64
+}
+
+// reserveVelocity counts a number of new orders and amount against the user's
+// limits; an order whose total grows reserves the increase with no new order.
+// It returns ErrVelocityExceeded, and records an order.velocity_exceeded event
+// for review, when they would be exceeded. The reservation is nil when nothing
+// was counted.
+func (s *OrderService) reserveVelocity(ctx context.Context, userID, currency string, orders, amount int64) (*velocityReservation, error) {
+	if !s.velocity.enabled() {
+		return nil, nil
+	}
+
+	maxCount := int64(-1)
+	if s.velocity.MaxOrders > 0 {
+		maxCount = s.velocity.MaxOrders
+	}
+	maxAmount := int64(-1)
+	if limit, ok := s.velocity.MaxAmount[currency]; ok {
+		maxAmount = limit
+	}
+
+	reservation := &velocityReservation{
+		id:       uuid.New().String(),
+		userID:   userID,
+		currency: currency,
+		orders:   orders,
+		amount:   amount,
+	}
+	res, err := velocityScript.Run(ctx, s.redis, velocityKeys(userID, currency),
+		time.Now().UnixMilli(), s.velocity.Window.Milliseconds(),
+		orders, amount, maxCount, maxAmount, reservation.id,
+	).Int64Slice()
+	if err != nil {
+		// A Redis outage must not block ordering; the guard is best effort
+		logger.FromContext(ctx).Warn("order velocity check failed", zap.Error(err))
+		return nil, nil
+	}
+
+	if res[0] == 1 {
+		return reservation, nil
+	}
+
+	count, total := res[1], res[2]
+	logger.FromContext(ctx).Warn("order velocity exceeded",
+		zap.String("user_id", userID),
+		zap.Int64("orders", count),
+		zap.Int64("amount", total),
+		zap.String("currency", currency),
+	)
+
+	payload := events.OrderVelocityExceeded{
+		UserID:          userID,
+		Currency:        currency,
+		AttemptedAmount: amount,
+		WindowOrders:    count,
+		WindowAmount:    total,
+		MaxOrders:       s.velocity.MaxOrders,
+		MaxAmount:       maxAmount,
+	}
+	event := &repository.OutboxEvent{
+		AggregateType: "user",
+		AggregateID:   userID,
+		EventType:     payload.EventType(),
+		Payload:       payload,
+	}
+	if err := s.repo.InsertOutboxEvent(ctx, event); err != nil {
+		logger.FromContext(ctx).Error("failed to record velocity event", zap.Error(err))
+	}
+
+	return nil, fmt.Errorf("%w: %d orders totaling %d %s in the last %s", ErrVelocityExceeded, count, total, currency, s.velocity.Window)
+}
+
+// releaseVelocity undoes reserveVelocity for an order that was not created or
+// changed; a nil reservation is ignored
+func (s *OrderService) releaseVelocity(ctx context.Context, reservation *velocityReservation) {
+	if reservation == nil {
+		return
+	}
+
+	keys := velocityKeys(reservation.userID, reservation.currency)
+	pipe := s.redis.Pipeline()
+	if reservation.orders > 0 {
+		pipe.ZRem(ctx, keys[0], fmt.Sprintf("%s:%d", reservation.id, reservation.orders))
+	}
+	if reservation.amount > 0 {
+		pipe.ZRem(ctx, keys[1], fmt.Sprintf("%s:%d", reservation.id, reservation.amount))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.FromContext(ctx).Warn("failed to release order velocity", zap.Error(err))
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func TestReserveVelocityDisabled(t *testing.T) {
+	svc := NewOrderService(nil, nil, nil, VelocityConfig{MaxOrders: 5}, nil, "USD", zap.NewNop())
+
+	reservation, err := svc.reserveVelocity(context.Background(), "user-1", "USD", 1, 1000)
+	if err != nil || reservation != nil {
+		t.Fatalf("got %+v, %v; want no reservation without a window", reservation, err)
+	}
+	// Releasing nothing is a no-op, even without Redis
+	svc.releaseVelocity(context.Background(), reservation)
+}
+
+func TestReserveVelocityFailsOpen(t *testing.T) {
+	// Nothing listens on the discard port, so every Redis call fails
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:9", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer func() { _ = client.Close() }()
+
+	config := VelocityConfig{Window: time.Hour, MaxOrders: 1}
+	svc := NewOrderService(nil, client, nil, config, nil, "USD", zap.NewNop())
+
+	reservation, err := svc.reserveVelocity(context.Background(), "user-1", "USD", 1, 1000)
+	if err != nil {
+		t.Fatalf("expected a Redis outage not to block ordering, got %v", err)
+	}
+	if reservation != nil {
+		t.Errorf("got reservation %+v, want none when nothing was counted", reservation)
+	}
+}
+
+func TestVelocityKeysSeparateCurrencies(t *testing.T) {
+	usd, eur := velocityKeys("user-1", "USD"), velocityKeys("user-1", "EUR")
+	if usd[0] != eur[0] {
+		t.Errorf("order keys differ by currency: %s, %s", usd[0], eur[0])
+	}
+	if usd[1] == eur[1] {
+		t.Errorf("amount keys are shared across currencies: %s", usd[1])
+	}
+}
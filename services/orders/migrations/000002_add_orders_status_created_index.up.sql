@@ -0,0 +1,2 @@
+-- Keyset pagination of all orders in a status
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders(status, created_at DESC, id DESC);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_order_items_product_id;
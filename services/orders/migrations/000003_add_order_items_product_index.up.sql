@@ -0,0 +1,2 @@
+-- Lookup of orders containing a product
+CREATE INDEX IF NOT EXISTS idx_order_items_product_id ON order_items(product_id, order_id);
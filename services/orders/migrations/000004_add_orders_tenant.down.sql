@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_tenant_user_created_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,4 @@
+-- Orders belong to a tenant; '' is the single-tenant default
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_orders_tenant_user_created_at ON orders(tenant_id, user_id, created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE outbox DROP COLUMN IF EXISTS last_error;
+ALTER TABLE outbox DROP COLUMN IF EXISTS attempts;
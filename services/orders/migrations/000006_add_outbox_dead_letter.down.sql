@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outbox_dead_lettered;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS dead_lettered_at;
@@ -0,0 +1,5 @@
+-- Events that used up their publish attempts; the publisher skips them until
+-- they are requeued
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS dead_lettered_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dead_lettered ON outbox(dead_lettered_at, id) WHERE dead_lettered_at IS NOT NULL;
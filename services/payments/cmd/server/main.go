@@ -2,18 +2,29 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
+	"github.com/mumumio1/coldy/pkg/config"
 	"github.com/mumumio1/coldy/pkg/database"
+	grpcserverpkg "github.com/mumumio1/coldy/pkg/grpcserver"
 	"github.com/mumumio1/coldy/pkg/logger"
 	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/outbox"
+	"github.com/mumumio1/coldy/pkg/pagination"
+	"github.com/mumumio1/coldy/pkg/pubsub"
+	"github.com/mumumio1/coldy/pkg/shutdown"
 	"github.com/mumumio1/coldy/pkg/telemetry"
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+	ordersv1 "github.com/mumumio1/coldy/proto/orders/v1"
+	paymentsv1 "github.com/mumumio1/coldy/proto/payments/v1"
+	grpcserver "github.com/mumumio1/coldy/services/payments/internal/grpc"
+	"github.com/mumumio1/coldy/services/payments/internal/inventory"
+	"github.com/mumumio1/coldy/services/payments/internal/orders"
 	"github.com/mumumio1/coldy/services/payments/internal/provider"
 	"github.com/mumumio1/coldy/services/payments/internal/service"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -41,15 +52,25 @@ func run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	log, err := logger.NewLogger(serviceName, getEnv("ENV", "development"))
+	// Refuse to start without a JWT secret, or with the placeholder one
+	// outside development
+	jwtSecret := config.Getenv("JWT_SECRET", config.InsecureJWTSecret)
+	if err := config.CheckJWTSecret(config.Getenv("ENV", "development"), jwtSecret); err != nil {
+		return err
+	}
+
+	log, err := logger.NewLogger(serviceName, config.Getenv("ENV", "development"))
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	defer func() { _ = log.Sync() }()
 
+	// Background work started from ctx logs with the service logger
+	ctx = logger.WithLogger(ctx, log)
+
 	log.Info("starting payments service", zap.String("version", version))
 
-	tracingEndpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	tracingEndpoint := config.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
 	shutdownTracer, err := telemetry.InitTracer(ctx, serviceName, version, tracingEndpoint)
 	if err != nil {
 		log.Warn("failed to initialize tracer", zap.Error(err))
@@ -60,12 +81,12 @@ func run() error {
 	metrics := telemetry.NewMetrics("coldy", serviceName)
 
 	dbConfig := database.Config{
-		Host:            getEnv("DB_HOST", "localhost"),
+		Host:            config.Getenv("DB_HOST", "localhost"),
 		Port:            5432,
-		User:            getEnv("DB_USER", "coldy"),
-		Password:        getEnv("DB_PASSWORD", "coldy123"),
-		Database:        getEnv("DB_NAME", "coldy"),
-		SSLMode:         getEnv("DB_SSLMODE", "disable"),
+		User:            config.Getenv("DB_USER", "coldy"),
+		Password:        config.Getenv("DB_PASSWORD", "coldy123"),
+		Database:        config.Getenv("DB_NAME", "coldy"),
+		SSLMode:         config.Getenv("DB_SSLMODE", "disable"),
 		MaxOpenConns:    25,
 		MaxIdleConns:    5,
 		ConnMaxLifetime: 5 * time.Minute,
@@ -78,41 +99,176 @@ func run() error {
 	}
 	defer func() { _ = db.Close() }()
 
+	redisDB := config.GetenvInt("REDIS_DB", 0)
 	redisClient := redis.NewClient(&redis.Options{
-		Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
-		Password: getEnv("REDIS_PASSWORD", ""),
-		DB:       0,
+		Addr:     config.Getenv("REDIS_ADDR", "localhost:6379"),
+		Password: config.Getenv("REDIS_PASSWORD", ""),
+		DB:       redisDB,
 	})
 	defer func() { _ = redisClient.Close() }()
 
+	// Idempotency keys can live in their own Redis DB, so they can be
+	// inspected or flushed without touching other keys
+	idempotencyRedis := redisClient
+	if idempotencyDB := config.GetenvInt("IDEMPOTENCY_REDIS_DB", redisDB); idempotencyDB != redisDB {
+		idempotencyRedis = redis.NewClient(&redis.Options{
+			Addr:     config.Getenv("REDIS_ADDR", "localhost:6379"),
+			Password: config.Getenv("REDIS_PASSWORD", ""),
+			DB:       idempotencyDB,
+		})
+		defer func() { _ = idempotencyRedis.Close() }()
+	}
+
+	projectID := config.Getenv("GCP_PROJECT_ID", "coldy-local")
+	publisher, err := pubsub.NewPublisher(ctx, projectID, log)
+	if err != nil {
+		return fmt.Errorf("failed to create pubsub publisher: %w", err)
+	}
+	defer func() { _ = publisher.Close() }()
+
+	// Plaintext gRPC is only allowed with GRPC_INSECURE=true
+	transport, err := grpcserverpkg.LoadTransportConfig()
+	if err != nil {
+		return err
+	}
+	serverCreds, err := transport.ServerCredentials()
+	if err != nil {
+		return err
+	}
+	clientCreds, err := transport.ClientCredentials()
+	if err != nil {
+		return err
+	}
+
+	// Connect to the inventory service to release reservations of failed payments
+	inventoryConn, err := grpc.NewClient(config.Getenv("INVENTORY_ADDR", "localhost:50055"),
+		clientCreds,
+		grpc.WithUnaryInterceptor(middleware.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create inventory client: %w", err)
+	}
+	defer func() { _ = inventoryConn.Close() }()
+	inventoryClient := inventory.NewClient(inventoryv1.NewInventoryServiceClient(inventoryConn))
+
+	// Connect to the orders service to check payment amounts against order
+	// totals before charging
+	ordersConn, err := grpc.NewClient(config.Getenv("ORDERS_ADDR", "localhost:50053"),
+		clientCreds,
+		grpc.WithUnaryInterceptor(middleware.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create orders client: %w", err)
+	}
+	defer func() { _ = ordersConn.Close() }()
+	ordersClient := orders.NewClient(ordersv1.NewOrderServiceClient(ordersConn))
+
 	// Mock payment provider (10% failure rate, 500ms delay)
 	paymentProvider := provider.NewMockProvider(log, 0.1, 500)
 
-	paymentService := service.NewPaymentService(db, paymentProvider, redisClient, log)
+	providerConfig := service.DefaultProviderConfig()
+	providerConfig.CallTimeout = config.GetenvDuration("PAYMENT_PROVIDER_CALL_TIMEOUT", providerConfig.CallTimeout)
+	providerConfig.BreakerTimeout = config.GetenvDuration("PAYMENT_PROVIDER_BREAKER_TIMEOUT", providerConfig.BreakerTimeout)
+
+	paymentService := service.NewPaymentService(db, paymentProvider, idempotencyRedis, providerConfig, inventoryClient, ordersClient, log)
+
+	// Check the outbox topics up front so a misconfigured project shows up at
+	// startup rather than at the first publish. Unless PUBSUB_REQUIRE_TOPICS
+	// is set, a failed check only keeps the service unready.
+	topics := pubsub.NewPrefixResolver(config.Getenv("PUBSUB_TOPIC_PREFIX", ""), pubsub.IdentityResolver{})
+	eventTypes := service.EventTypes()
+	topicNames := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		topicNames[i] = topics.Topic(eventType)
+	}
+	topicCheck := publisher.NewTopicCheck(topicNames, config.Getenv("PUBSUB_CREATE_TOPICS", "true") == "true")
+	if err := topicCheck.Verify(ctx); err != nil {
+		if config.Getenv("PUBSUB_REQUIRE_TOPICS", "false") == "true" {
+			return fmt.Errorf("failed to verify pubsub topics: %w", err)
+		}
+		log.Warn("pubsub topics not verified", zap.Error(err))
+	}
+
+	// Start outbox publisher worker; events that keep failing are
+	// dead-lettered after OUTBOX_MAX_ATTEMPTS publishes, 0 retries forever
+	outboxPublisher := outbox.NewPublisher(service.NewOutboxStore(db), publisher, topics, log, 5*time.Second,
+		config.GetenvInt("OUTBOX_MAX_ATTEMPTS", outbox.DefaultMaxAttempts))
+	go func() {
+		if err := outboxPublisher.Start(ctx); err != nil && err != context.Canceled {
+			log.Error("outbox publisher stopped", zap.Error(err))
+		}
+	}()
 
-	grpcPort := getEnv("GRPC_PORT", "50054")
+	grpcPort := config.Getenv("GRPC_PORT", "50054")
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	grpcServer := grpc.NewServer(
+	// Access tokens come from the users service. Payment changes and the admin
+	// RPCs require one; reads stay open until their callers send tokens.
+	authConfig := middleware.AuthConfig{
+		Validate: middleware.JWTValidator(
+			jwtSecret,
+			"coldy-users",
+			"coldy-access",
+			config.GetenvDuration("JWT_LEEWAY", middleware.DefaultJWTLeeway),
+		),
+		// Probes and tooling call the infrastructure RPCs without a token
+		PublicMethods: append([]string{
+			paymentsv1.PaymentService_GetPayment_FullMethodName,
+			paymentsv1.PaymentService_GetPaymentsByOrderID_FullMethodName,
+		}, middleware.InfrastructureMethods...),
+		MethodScopes: map[string]string{
+			paymentsv1.PaymentService_GetProviderCircuit_FullMethodName:   service.ScopePaymentsAdmin,
+			paymentsv1.PaymentService_ResetProviderCircuit_FullMethodName: service.ScopePaymentsAdmin,
+			paymentsv1.PaymentService_ReconcilePayment_FullMethodName:     service.ScopePaymentsAdmin,
+			paymentsv1.PaymentService_ListPayments_FullMethodName:         service.ScopePaymentsAdmin,
+		},
+	}
+
+	keepalive, err := grpcserverpkg.LoadKeepaliveConfig()
+	if err != nil {
+		return err
+	}
+
+	// Shed load past these limits instead of letting it queue on the database pool
+	concurrency, err := middleware.LoadConcurrencyConfig()
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpcserverpkg.New(keepalive,
+		serverCreds,
+		grpcserverpkg.MaxConcurrentStreams(config.GetenvInt("GRPC_MAX_CONCURRENT_STREAMS", grpcserverpkg.DefaultMaxConcurrentStreams)),
 		grpc.ChainUnaryInterceptor(
+			middleware.MetricsInterceptor(),
 			middleware.RecoveryInterceptor(log),
 			middleware.UnaryServerInterceptor(log),
+			middleware.CompressionInterceptor(config.GetenvInt("GRPC_COMPRESS_MIN_SIZE", middleware.DefaultCompressMinSize)),
+			middleware.ConcurrencyLimitInterceptor(concurrency),
 			middleware.TracingInterceptor(serviceName),
+			middleware.AuthInterceptor(authConfig),
+			middleware.ValidationInterceptor(nil),
 		),
 	)
 
+	pages, err := pagination.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	paymentsv1.RegisterPaymentServiceServer(grpcServer, grpcserver.NewServer(paymentService, pages, log))
+
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_SERVING)
 
-	if getEnv("ENV", "development") == "development" {
+	if config.Getenv("ENV", "development") == "development" {
 		reflection.Register(grpcServer)
 	}
 
-	metricsPort := getEnv("METRICS_PORT", "9093")
+	metricsPort := config.Getenv("METRICS_PORT", "9093")
 	go func() {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
@@ -120,6 +276,30 @@ func run() error {
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write([]byte("OK"))
 		})
+		mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+			// The body reports pool saturation alongside the verdict
+			dbStatus, err := database.HealthCheckDetailed(r.Context(), db, database.DefaultHealthCheckTimeout)
+			w.Header().Set("Content-Type", "application/json")
+			if err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_ = json.NewEncoder(w).Encode(dbStatus)
+				return
+			}
+			// Events stop flowing if the outbox worker dies or its topics are
+			// missing; report not ready
+			if err := topicCheck.Verify(r.Context()); err != nil {
+				log.Warn("readiness check failed", zap.Error(err))
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if err := outboxPublisher.CheckHeartbeat(3); err != nil {
+				log.Warn("readiness check failed", zap.Error(err))
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(dbStatus)
+		})
 
 		log.Info("starting metrics server", zap.String("port", metricsPort))
 		if err := http.ListenAndServe(":"+metricsPort, mux); err != nil {
@@ -149,24 +329,24 @@ func run() error {
 		}
 	}()
 
-	_ = paymentService // Use service in future gRPC implementation
-
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
-
-	log.Info("shutting down gracefully...")
-	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
-	time.Sleep(5 * time.Second)
-	grpcServer.GracefulStop()
+	// Fail health checks, let load balancers drain, then stop the server
+	shutdowner := shutdown.New(shutdown.Config{
+		DrainDelay: config.GetenvDuration("SHUTDOWN_DRAIN_DELAY", shutdown.DefaultDrainDelay),
+		Timeout:    config.GetenvDuration("SHUTDOWN_TIMEOUT", shutdown.DefaultTimeout),
+	}, log)
+	shutdowner.PreStop("health", func(ctx context.Context) error {
+		healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		return nil
+	})
+	shutdowner.OnShutdown("grpc", func(ctx context.Context) error {
+		// Stop forces remaining RPCs closed after its own timeout
+		grpcserverpkg.Stop(grpcServer, config.GetenvDuration("GRPC_SHUTDOWN_TIMEOUT", grpcserverpkg.DefaultShutdownTimeout), log)
+		return nil
+	})
+	if err := shutdowner.Wait(ctx); err != nil {
+		log.Warn("shutdown did not complete cleanly", zap.Error(err))
+	}
 
 	log.Info("server stopped")
 	return nil
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
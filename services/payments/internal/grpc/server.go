@@ -0,0 +1,359 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/mumumio1/coldy/pkg/circuitbreaker"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/pagination"
+	commonv1 "github.com/mumumio1/coldy/proto/common/v1"
+	paymentsv1 "github.com/mumumio1/coldy/proto/payments/v1"
+	"github.com/mumumio1/coldy/services/payments/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements the Payment gRPC service
+type Server struct {
+	paymentsv1.UnimplementedPaymentServiceServer
+	paymentService *service.PaymentService
+	pages          pagination.Config
+	logger         *zap.Logger
+}
+
+// NewServer creates a new gRPC server
+func NewServer(paymentService *service.PaymentService, pages pagination.Config, logger *zap.Logger) *Server {
+	return &Server{
+		paymentService: paymentService,
+		pages:          pages,
+		logger:         logger,
+	}
+}
+
+// CreatePayment creates a new payment
+func (s *Server) CreatePayment(ctx context.Context, req *paymentsv1.CreatePaymentRequest) (*paymentsv1.CreatePaymentResponse, error) {
+	if req.IdempotencyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+	if req.OrderId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id and user_id are required")
+	}
+	if req.Amount == nil || req.Amount.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+
+	method := toRepoMethod(req.Method)
+	if method == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment method is required")
+	}
+
+	payment, fromCache, err := s.paymentService.CreatePayment(ctx, req.IdempotencyKey, &service.CreatePaymentRequest{
+		OrderID:       req.OrderId,
+		UserID:        req.UserId,
+		Amount:        req.Amount.Amount,
+		Currency:      req.Amount.Currency,
+		PaymentMethod: method,
+		CardNumber:    req.PaymentDetails["card_number"],
+		CVV:           req.PaymentDetails["cvv"],
+	})
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to create payment")
+	}
+
+	return &paymentsv1.CreatePaymentResponse{
+		Payment:   toProtoPayment(payment),
+		FromCache: fromCache,
+	}, nil
+}
+
+// GetPayment retrieves a payment
+func (s *Server) GetPayment(ctx context.Context, req *paymentsv1.GetPaymentRequest) (*paymentsv1.GetPaymentResponse, error) {
+	if req.PaymentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment_id is required")
+	}
+
+	payment, err := s.paymentService.GetPayment(ctx, req.PaymentId)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get payment")
+	}
+
+	return &paymentsv1.GetPaymentResponse{
+		Payment: toProtoPayment(payment),
+	}, nil
+}
+
+// GetPaymentsByOrderID lists the payments for an order, newest first
+func (s *Server) GetPaymentsByOrderID(ctx context.Context, req *paymentsv1.GetPaymentsByOrderIDRequest) (*paymentsv1.GetPaymentsByOrderIDResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	payments, err := s.paymentService.GetPaymentsByOrderID(ctx, req.OrderId)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get payments")
+	}
+
+	protoPayments := make([]*paymentsv1.Payment, len(payments))
+	for i, payment := range payments {
+		protoPayments[i] = toProtoPayment(payment)
+	}
+
+	return &paymentsv1.GetPaymentsByOrderIDResponse{
+		Payments: protoPayments,
+	}, nil
+}
+
+// ConfirmPayment processes a pending payment with the provider
+func (s *Server) ConfirmPayment(ctx context.Context, req *paymentsv1.ConfirmPaymentRequest) (*paymentsv1.ConfirmPaymentResponse, error) {
+	if req.PaymentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment_id is required")
+	}
+
+	payment, err := s.paymentService.ConfirmPayment(ctx, req.PaymentId)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to confirm payment")
+	}
+
+	return &paymentsv1.ConfirmPaymentResponse{
+		Payment: toProtoPayment(payment),
+	}, nil
+}
+
+// CancelPayment cancels a pending or processing payment
+func (s *Server) CancelPayment(ctx context.Context, req *paymentsv1.CancelPaymentRequest) (*paymentsv1.CancelPaymentResponse, error) {
+	if req.PaymentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment_id is required")
+	}
+
+	payment, err := s.paymentService.CancelPayment(ctx, req.PaymentId, req.Reason)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to cancel payment")
+	}
+
+	return &paymentsv1.CancelPaymentResponse{
+		Payment: toProtoPayment(payment),
+	}, nil
+}
+
+// RefundPayment refunds a succeeded payment
+func (s *Server) RefundPayment(ctx context.Context, req *paymentsv1.RefundPaymentRequest) (*paymentsv1.RefundPaymentResponse, error) {
+	if req.PaymentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment_id is required")
+	}
+
+	var amount int64
+	var currency string
+	if req.Amount != nil {
+		amount = req.Amount.Amount
+		currency = req.Amount.Currency
+	}
+
+	payment, refundID, err := s.paymentService.RefundPayment(ctx, req.PaymentId, amount, currency, req.Reason)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to refund payment")
+	}
+
+	return &paymentsv1.RefundPaymentResponse{
+		Payment:  toProtoPayment(payment),
+		RefundId: refundID,
+	}, nil
+}
+
+// GetProviderCircuit returns the provider circuit breaker state
+func (s *Server) GetProviderCircuit(ctx context.Context, req *paymentsv1.GetProviderCircuitRequest) (*paymentsv1.GetProviderCircuitResponse, error) {
+	state, failures := s.paymentService.ProviderCircuit()
+
+	return &paymentsv1.GetProviderCircuitResponse{
+		Circuit: toProtoCircuit(state, failures),
+	}, nil
+}
+
+// ResetProviderCircuit force-closes the provider circuit breaker
+func (s *Server) ResetProviderCircuit(ctx context.Context, req *paymentsv1.ResetProviderCircuitRequest) (*paymentsv1.ResetProviderCircuitResponse, error) {
+	prevState, prevFailures := s.paymentService.ResetProviderCircuit(ctx, req.Reason)
+	state, failures := s.paymentService.ProviderCircuit()
+
+	return &paymentsv1.ResetProviderCircuitResponse{
+		Previous: toProtoCircuit(prevState, prevFailures),
+		Circuit:  toProtoCircuit(state, failures),
+	}, nil
+}
+
+// ReconcilePayment corrects a payment's status from the provider's record
+func (s *Server) ReconcilePayment(ctx context.Context, req *paymentsv1.ReconcilePaymentRequest) (*paymentsv1.ReconcilePaymentResponse, error) {
+	if req.PaymentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment_id is required")
+	}
+
+	payment, corrected, err := s.paymentService.ReconcilePayment(ctx, req.PaymentId)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to reconcile payment")
+	}
+
+	return &paymentsv1.ReconcilePaymentResponse{
+		Payment:   toProtoPayment(payment),
+		Corrected: corrected,
+	}, nil
+}
+
+// ListPayments lists payments matching the request's filters
+func (s *Server) ListPayments(ctx context.Context, req *paymentsv1.ListPaymentsRequest) (*paymentsv1.ListPaymentsResponse, error) {
+	pageSize, err := s.pages.Clamp(paymentsv1.PaymentService_ListPayments_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	filter := service.PaymentFilter{
+		Status:  fromProtoStatus(req.Status),
+		UserID:  req.UserId,
+		OrderID: req.OrderId,
+		Limit:   pageSize,
+		Cursor:  req.GetPagination().GetCursor(),
+	}
+	if req.CreatedFrom != nil {
+		filter.From = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.To = req.CreatedTo.AsTime()
+	}
+
+	payments, nextCursor, totals, err := s.paymentService.ListPayments(ctx, filter)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to list payments")
+	}
+
+	protoPayments := make([]*paymentsv1.Payment, len(payments))
+	for i, payment := range payments {
+		protoPayments[i] = toProtoPayment(payment)
+	}
+
+	protoTotals := make([]*paymentsv1.PaymentStatusTotal, len(totals))
+	for i, total := range totals {
+		protoTotals[i] = &paymentsv1.PaymentStatusTotal{
+			Status: toProtoStatus(total.Status),
+			Count:  total.Count,
+			Amount: &commonv1.Money{Currency: total.Currency, Amount: total.Amount},
+		}
+	}
+
+	return &paymentsv1.ListPaymentsResponse{
+		Payments: protoPayments,
+		Pagination: &commonv1.PaginationResponse{
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
+		},
+		Totals: protoTotals,
+	}, nil
+}
+
+// toStatus maps domain errors to gRPC status codes
+func (s *Server) toStatus(ctx context.Context, err error, msg string) error {
+	if errs.KindOf(err) == errs.KindInternal {
+		logger.FromContext(ctx).Error(msg, zap.Error(err))
+		return status.Error(codes.Internal, msg)
+	}
+	return errs.ToGRPCStatus(err).Err()
+}
+
+func toProtoPayment(payment *service.Payment) *paymentsv1.Payment {
+	return &paymentsv1.Payment{
+		Id:      payment.ID,
+		OrderId: payment.OrderID,
+		UserId:  payment.UserID,
+		Amount: &commonv1.Money{
+			Currency: payment.AmountCurrency,
+			Amount:   payment.AmountValue,
+		},
+		Status:                toProtoStatus(payment.Status),
+		Method:                toProtoMethod(payment.Method),
+		ProviderTransactionId: payment.ProviderTransactionID,
+		ErrorMessage:          payment.ErrorMessage,
+		CreatedAt:             timestamppb.New(payment.CreatedAt),
+		UpdatedAt:             timestamppb.New(payment.UpdatedAt),
+		RefundedAmount: &commonv1.Money{
+			Currency: payment.AmountCurrency,
+			Amount:   payment.RefundedAmount,
+		},
+	}
+}
+
+func toProtoCircuit(state circuitbreaker.State, failures uint32) *paymentsv1.ProviderCircuit {
+	circuit := &paymentsv1.ProviderCircuit{Failures: failures}
+	switch state {
+	case circuitbreaker.StateClosed:
+		circuit.State = paymentsv1.CircuitState_CIRCUIT_STATE_CLOSED
+	case circuitbreaker.StateHalfOpen:
+		circuit.State = paymentsv1.CircuitState_CIRCUIT_STATE_HALF_OPEN
+	case circuitbreaker.StateOpen:
+		circuit.State = paymentsv1.CircuitState_CIRCUIT_STATE_OPEN
+	}
+	return circuit
+}
+
+func toProtoStatus(status string) paymentsv1.PaymentStatus {
+	switch status {
+	case "pending":
+		return paymentsv1.PaymentStatus_PAYMENT_STATUS_PENDING
+	case "processing":
+		return paymentsv1.PaymentStatus_PAYMENT_STATUS_PROCESSING
+	case "succeeded":
+		return paymentsv1.PaymentStatus_PAYMENT_STATUS_SUCCEEDED
+	case "failed":
+		return paymentsv1.PaymentStatus_PAYMENT_STATUS_FAILED
+	case "cancelled":
+		return paymentsv1.PaymentStatus_PAYMENT_STATUS_CANCELED
+	case "refunded":
+		return paymentsv1.PaymentStatus_PAYMENT_STATUS_REFUNDED
+	default:
+		return paymentsv1.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+	}
+}
+
+// fromProtoStatus maps a status filter to the stored status; unspecified matches every status
+func fromProtoStatus(status paymentsv1.PaymentStatus) string {
+	switch status {
+	case paymentsv1.PaymentStatus_PAYMENT_STATUS_PENDING:
+		return "pending"
+	case paymentsv1.PaymentStatus_PAYMENT_STATUS_PROCESSING:
+		return "processing"
+	case paymentsv1.PaymentStatus_PAYMENT_STATUS_SUCCEEDED:
+		return "succeeded"
+	case paymentsv1.PaymentStatus_PAYMENT_STATUS_FAILED:
+		return "failed"
+	case paymentsv1.PaymentStatus_PAYMENT_STATUS_CANCELED:
+		return "cancelled"
+	case paymentsv1.PaymentStatus_PAYMENT_STATUS_REFUNDED:
+		return "refunded"
+	default:
+		return ""
+	}
+}
+
+func toProtoMethod(method string) paymentsv1.PaymentMethod {
+	switch method {
+	case "card":
+		return paymentsv1.PaymentMethod_PAYMENT_METHOD_CARD
+	case "paypal":
+		return paymentsv1.PaymentMethod_PAYMENT_METHOD_PAYPAL
+	case "bank_transfer":
+		return paymentsv1.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER
+	default:
+		return paymentsv1.PaymentMethod_PAYMENT_METHOD_UNSPECIFIED
+	}
+}
+
+func toRepoMethod(method paymentsv1.PaymentMethod) string {
+	switch method {
+	case paymentsv1.PaymentMethod_PAYMENT_METHOD_CARD:
+		return "card"
+	case paymentsv1.PaymentMethod_PAYMENT_METHOD_PAYPAL:
+		return "paypal"
+	case paymentsv1.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER:
+		return "bank_transfer"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,34 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	inventoryv1 "github.com/mumumio1/coldy/proto/inventory/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Client releases stock reservations in the inventory service
+type Client struct {
+	client inventoryv1.InventoryServiceClient
+}
+
+// NewClient creates a new inventory client
+func NewClient(client inventoryv1.InventoryServiceClient) *Client {
+	return &Client{client: client}
+}
+
+// ReleaseReservation releases the active items of a reservation. A reservation
+// with nothing active, e.g. one already released, is not an error, so a
+// repeated release is a no-op.
+func (c *Client) ReleaseReservation(ctx context.Context, reservationID string) error {
+	_, err := c.client.ReleaseStock(ctx, &inventoryv1.ReleaseStockRequest{ReservationId: reservationID})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("inventory release failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/money"
+	ordersv1 "github.com/mumumio1/coldy/proto/orders/v1"
+	"github.com/mumumio1/coldy/services/payments/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Client looks up order totals in the orders service
+type Client struct {
+	client ordersv1.OrderServiceClient
+}
+
+// NewClient creates a new orders client
+func NewClient(client ordersv1.OrderServiceClient) *Client {
+	return &Client{client: client}
+}
+
+// VerifiedTotal returns an order's total once the orders service has checked
+// it against the order's items. An unknown order or one whose total doesn't
+// match its items fails with service.ErrAmountMismatch.
+func (c *Client) VerifiedTotal(ctx context.Context, orderID string) (money.Money, error) {
+	resp, err := c.client.VerifyOrderTotal(ctx, &ordersv1.VerifyOrderTotalRequest{OrderId: orderID})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound, codes.FailedPrecondition:
+		return money.Money{}, fmt.Errorf("%w: %s", service.ErrAmountMismatch, status.Convert(err).Message())
+	default:
+		return money.Money{}, fmt.Errorf("orders verify total failed: %w", err)
+	}
+	return money.Money{Currency: resp.GetTotal().GetCurrency(), Amount: resp.GetTotal().GetAmount()}, nil
+}
@@ -5,28 +5,61 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/mumumio1/coldy/pkg/errs"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrPaymentDeclined is returned when the provider declines a payment
+	ErrPaymentDeclined = errs.FailedPrecondition("PAYMENT_DECLINED", "payment declined by provider")
+	// ErrTransactionNotFound is returned when the provider has no record of a transaction
+	ErrTransactionNotFound = errs.NotFound("TRANSACTION_NOT_FOUND", "transaction not found at provider")
+)
+
+// Provider-side transaction statuses
+const (
+	TransactionSucceeded = "succeeded"
+	TransactionFailed    = "failed"
+	TransactionCanceled  = "canceled"
+	TransactionRefunded  = "refunded"
+)
+
 // PaymentProvider defines the interface for payment providers
 type PaymentProvider interface {
 	ProcessPayment(ctx context.Context, req *ProcessPaymentRequest) (*ProcessPaymentResponse, error)
 	CancelPayment(ctx context.Context, transactionID string) error
 	RefundPayment(ctx context.Context, transactionID string, amount int64) (*RefundResponse, error)
+	// GetTransactionStatus returns the provider's view of a transaction. The
+	// idempotency key of a charge also finds it when its response was lost.
+	// Unknown references fail with ErrTransactionNotFound.
+	GetTransactionStatus(ctx context.Context, transactionID string) (*TransactionStatus, error)
 }
 
-// ProcessPaymentRequest represents a payment processing request
-type ProcessPaymentRequest struct {
-	OrderID       string
+// TransactionStatus is a transaction's status at the provider
+type TransactionStatus struct {
+	TransactionID string
+	Status        string
 	Amount        int64
 	Currency      string
-	PaymentMethod string
-	CardNumber    string
-	CVV           string
-	ExpiryMonth   int
-	ExpiryYear    int
+}
+
+// ProcessPaymentRequest represents a payment processing request
+type ProcessPaymentRequest struct {
+	// IdempotencyKey identifies the charge. A request repeating the key of an
+	// earlier charge returns that charge instead of charging again, so a call
+	// whose outcome is unknown can be retried.
+	IdempotencyKey string
+	OrderID        string
+	Amount         int64
+	Currency       string
+	PaymentMethod  string
+	CardNumber     string
+	CVV            string
+	ExpiryMonth    int
+	ExpiryYear     int
 }
 
 // ProcessPaymentResponse represents a payment processing response
@@ -47,14 +80,20 @@ type MockProvider struct {
 	logger      *zap.Logger
 	failureRate float64
 	delayMs     int
+
+	mu sync.Mutex
+	// transactions holds processed charges by transaction ID and by
+	// idempotency key
+	transactions map[string]*TransactionStatus
 }
 
 // NewMockProvider creates a new mock payment provider
 func NewMockProvider(logger *zap.Logger, failureRate float64, delayMs int) *MockProvider {
 	return &MockProvider{
-		logger:      logger,
-		failureRate: failureRate,
-		delayMs:     delayMs,
+		logger:       logger,
+		failureRate:  failureRate,
+		delayMs:      delayMs,
+		transactions: make(map[string]*TransactionStatus),
 	}
 }
 
@@ -64,20 +103,68 @@ func randomFloat() float64 {
 	return float64(binary.LittleEndian.Uint64(b[:])) / float64(^uint64(0))
 }
 
+// delay simulates provider latency, giving up when ctx is done. A canceled
+// ctx always wins, even without a delay, as it would against a real provider.
+func (p *MockProvider) delay(ctx context.Context) error {
+	if err := ctx.Err(); err != nil || p.delayMs <= 0 {
+		return err
+	}
+
+	timer := time.NewTimer(time.Duration(p.delayMs) * time.Millisecond)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // ProcessPayment processes a payment (mock implementation)
 func (p *MockProvider) ProcessPayment(ctx context.Context, req *ProcessPaymentRequest) (*ProcessPaymentResponse, error) {
-	time.Sleep(time.Duration(p.delayMs) * time.Millisecond)
+	if err := p.delay(ctx); err != nil {
+		return nil, err
+	}
+
+	// Holding the lock throughout makes concurrent requests with the same key
+	// charge once
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if txn, ok := p.transactions[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		p.logger.Info("repeated payment request (mock)",
+			zap.String("order_id", req.OrderID),
+			zap.String("transaction_id", txn.TransactionID),
+		)
+		return &ProcessPaymentResponse{
+			TransactionID: txn.TransactionID,
+			Status:        "succeeded",
+			Message:       "Payment processed successfully",
+		}, nil
+	}
 
 	if randomFloat() < p.failureRate {
 		p.logger.Warn("payment processing failed (simulated)",
 			zap.String("order_id", req.OrderID),
 		)
-		return nil, fmt.Errorf("payment declined by provider")
+		return nil, ErrPaymentDeclined
 	}
 
 	// Generate mock transaction ID
 	transactionID := fmt.Sprintf("TXN-%d", time.Now().UnixNano())
 
+	txn := &TransactionStatus{
+		TransactionID: transactionID,
+		Status:        TransactionSucceeded,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+	}
+	p.transactions[transactionID] = txn
+	if req.IdempotencyKey != "" {
+		p.transactions[req.IdempotencyKey] = txn
+	}
+
 	p.logger.Info("payment processed successfully (mock)",
 		zap.String("order_id", req.OrderID),
 		zap.String("transaction_id", transactionID),
@@ -93,7 +180,11 @@ func (p *MockProvider) ProcessPayment(ctx context.Context, req *ProcessPaymentRe
 
 // CancelPayment cancels a payment (mock implementation)
 func (p *MockProvider) CancelPayment(ctx context.Context, transactionID string) error {
-	time.Sleep(time.Duration(p.delayMs) * time.Millisecond)
+	if err := p.delay(ctx); err != nil {
+		return err
+	}
+
+	p.setStatus(transactionID, TransactionCanceled)
 
 	p.logger.Info("payment canceled (mock)",
 		zap.String("transaction_id", transactionID),
@@ -104,9 +195,12 @@ func (p *MockProvider) CancelPayment(ctx context.Context, transactionID string)
 
 // RefundPayment refunds a payment (mock implementation)
 func (p *MockProvider) RefundPayment(ctx context.Context, transactionID string, amount int64) (*RefundResponse, error) {
-	time.Sleep(time.Duration(p.delayMs) * time.Millisecond)
+	if err := p.delay(ctx); err != nil {
+		return nil, err
+	}
 
 	refundID := fmt.Sprintf("REFUND-%d", time.Now().UnixNano())
+	p.setStatus(transactionID, TransactionRefunded)
 
 	p.logger.Info("payment refunded (mock)",
 		zap.String("transaction_id", transactionID),
@@ -119,3 +213,28 @@ func (p *MockProvider) RefundPayment(ctx context.Context, transactionID string,
 		Status:   "succeeded",
 	}, nil
 }
+
+// GetTransactionStatus returns the status of a charge made by this provider
+func (p *MockProvider) GetTransactionStatus(ctx context.Context, transactionID string) (*TransactionStatus, error) {
+	if err := p.delay(ctx); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	txn, ok := p.transactions[transactionID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, transactionID)
+	}
+	status := *txn
+	return &status, nil
+}
+
+func (p *MockProvider) setStatus(transactionID, status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if txn, ok := p.transactions[transactionID]; ok {
+		txn.Status = status
+	}
+}
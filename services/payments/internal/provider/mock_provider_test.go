@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestMockProviderCanceledContext(t *testing.T) {
+	tests := map[string]int{
+		"no delay":   0,
+		"with delay": 50,
+	}
+
+	for name, delayMs := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewMockProvider(zap.NewNop(), 0, delayMs)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// select picks randomly between ready cases, so repeat to catch a
+			// canceled call that still goes through
+			for i := 0; i < 50; i++ {
+				_, err := p.ProcessPayment(ctx, &ProcessPaymentRequest{OrderID: "order-1", Amount: 1000, Currency: "USD"})
+				if !errors.Is(err, context.Canceled) {
+					t.Fatalf("attempt %d: expected context.Canceled, got %v", i, err)
+				}
+			}
+			if len(p.transactions) != 0 {
+				t.Errorf("canceled calls recorded %d transactions", len(p.transactions))
+			}
+		})
+	}
+}
+
+func TestMockProviderDelayStopsOnCancel(t *testing.T) {
+	p := NewMockProvider(zap.NewNop(), 0, int(time.Minute/time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := p.ProcessPayment(ctx, &ProcessPaymentRequest{OrderID: "order-1", Amount: 1000, Currency: "USD"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("call took %v, want it to end with the context, not the 1m delay", elapsed)
+	}
+}
+
+func TestMockProviderDelay(t *testing.T) {
+	p := NewMockProvider(zap.NewNop(), 0, 20)
+
+	start := time.Now()
+	if _, err := p.ProcessPayment(context.Background(), &ProcessPaymentRequest{OrderID: "order-1", Amount: 1000, Currency: "USD"}); err != nil {
+		t.Fatalf("ProcessPayment failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("call took %v, want at least the 20ms delay", elapsed)
+	}
+}
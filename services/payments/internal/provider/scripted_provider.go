@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrScriptedFailure is the default error for OutcomeError steps
+var ErrScriptedFailure = errors.New("scripted provider failure")
+
+// Outcome is the result a ScriptedProvider step produces
+type Outcome int
+
+const (
+	// OutcomeSucceed completes the call
+	OutcomeSucceed Outcome = iota
+	// OutcomeDecline fails with ErrPaymentDeclined
+	OutcomeDecline
+	// OutcomeTimeout blocks until the context is done, or fails with
+	// context.DeadlineExceeded after the step delay
+	OutcomeTimeout
+	// OutcomeError fails with the step error
+	OutcomeError
+	// OutcomeLost completes a charge but fails with
+	// context.DeadlineExceeded, as when the provider's response is lost
+	OutcomeLost
+)
+
+// Step is one scripted provider call
+type Step struct {
+	Outcome Outcome
+	// Delay is waited before the outcome is applied
+	Delay time.Duration
+	// Err is returned by OutcomeError steps; defaults to ErrScriptedFailure
+	Err error
+}
+
+// ScriptedProvider is a deterministic payment provider for tests. Every call
+// (process, cancel, refund or status) consumes the next scripted step; once the
+// script is exhausted calls succeed.
+type ScriptedProvider struct {
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	steps    []Step
+	failNext int
+	failErr  error
+	calls    int
+	// transactions are the statuses reported by GetTransactionStatus
+	transactions map[string]TransactionStatus
+	// charges maps the idempotency keys of completed charges to their
+	// transaction IDs
+	charges  map[string]string
+	requests []ProcessPaymentRequest
+}
+
+// NewScriptedProvider creates a new scripted provider
+func NewScriptedProvider(logger *zap.Logger, steps ...Step) *ScriptedProvider {
+	return &ScriptedProvider{
+		logger:       logger,
+		steps:        steps,
+		transactions: make(map[string]TransactionStatus),
+		charges:      make(map[string]string),
+	}
+}
+
+// SetTransaction sets the status GetTransactionStatus reports for reference,
+// which may be a transaction ID or an idempotency key
+func (p *ScriptedProvider) SetTransaction(reference string, status TransactionStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.transactions[reference] = status
+}
+
+// Script appends steps to the script
+func (p *ScriptedProvider) Script(steps ...Step) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.steps = append(p.steps, steps...)
+}
+
+// FailNext makes the next n calls fail with err, ahead of any scripted steps.
+// A nil err fails with ErrScriptedFailure.
+func (p *ScriptedProvider) FailNext(n int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failNext = n
+	p.failErr = err
+}
+
+// Calls returns the number of provider calls made so far
+func (p *ScriptedProvider) Calls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+// Requests returns the ProcessPayment requests received so far
+func (p *ScriptedProvider) Requests() []ProcessPaymentRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]ProcessPaymentRequest(nil), p.requests...)
+}
+
+// next consumes the next step and returns it with the call number
+func (p *ScriptedProvider) next() (Step, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+
+	if p.failNext > 0 {
+		p.failNext--
+		return Step{Outcome: OutcomeError, Err: p.failErr}, p.calls
+	}
+	if len(p.steps) == 0 {
+		return Step{Outcome: OutcomeSucceed}, p.calls
+	}
+
+	step := p.steps[0]
+	p.steps = p.steps[1:]
+	return step, p.calls
+}
+
+// run applies the next step, returning it with the call number
+func (p *ScriptedProvider) run(ctx context.Context, operation string) (Step, int, error) {
+	step, seq := p.next()
+
+	if step.Delay > 0 && step.Outcome != OutcomeTimeout {
+		timer := time.NewTimer(step.Delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return step, seq, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	var err error
+	switch step.Outcome {
+	case OutcomeSucceed:
+	case OutcomeDecline:
+		err = ErrPaymentDeclined
+	case OutcomeTimeout:
+		err = waitTimeout(ctx, step.Delay)
+	case OutcomeLost:
+		err = context.DeadlineExceeded
+	case OutcomeError:
+		err = step.Err
+		if err == nil {
+			err = ErrScriptedFailure
+		}
+	default:
+		err = fmt.Errorf("unknown scripted outcome %d", step.Outcome)
+	}
+
+	p.logger.Debug("scripted provider call",
+		zap.String("operation", operation),
+		zap.Int("call", seq),
+		zap.Error(err),
+	)
+
+	return step, seq, err
+}
+
+// waitTimeout blocks until ctx is done, or until delay if it is positive
+func waitTimeout(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return context.DeadlineExceeded
+	}
+}
+
+// ProcessPayment processes a payment according to the script. A request
+// repeating the idempotency key of a completed charge returns that charge
+// without consuming a step.
+func (p *ScriptedProvider) ProcessPayment(ctx context.Context, req *ProcessPaymentRequest) (*ProcessPaymentResponse, error) {
+	p.mu.Lock()
+	p.requests = append(p.requests, *req)
+	transactionID, charged := p.charges[req.IdempotencyKey]
+	p.mu.Unlock()
+	if charged && req.IdempotencyKey != "" {
+		return &ProcessPaymentResponse{
+			TransactionID: transactionID,
+			Status:        "succeeded",
+			Message:       "Payment processed successfully",
+		}, nil
+	}
+
+	step, seq, err := p.run(ctx, "process")
+	if err == nil || step.Outcome == OutcomeLost {
+		transactionID = fmt.Sprintf("TXN-SCRIPTED-%d", seq)
+		p.mu.Lock()
+		if req.IdempotencyKey != "" {
+			p.charges[req.IdempotencyKey] = transactionID
+		}
+		p.mu.Unlock()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessPaymentResponse{
+		TransactionID: transactionID,
+		Status:        "succeeded",
+		Message:       "Payment processed successfully",
+	}, nil
+}
+
+// CancelPayment cancels a payment according to the script
+func (p *ScriptedProvider) CancelPayment(ctx context.Context, transactionID string) error {
+	_, _, err := p.run(ctx, "cancel")
+	return err
+}
+
+// RefundPayment refunds a payment according to the script
+func (p *ScriptedProvider) RefundPayment(ctx context.Context, transactionID string, amount int64) (*RefundResponse, error) {
+	_, seq, err := p.run(ctx, "refund")
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefundResponse{
+		RefundID: fmt.Sprintf("REFUND-SCRIPTED-%d", seq),
+		Status:   "succeeded",
+	}, nil
+}
+
+// GetTransactionStatus reports the status set with SetTransaction, after
+// applying the next scripted step
+func (p *ScriptedProvider) GetTransactionStatus(ctx context.Context, transactionID string) (*TransactionStatus, error) {
+	if _, _, err := p.run(ctx, "status"); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	txn, ok := p.transactions[transactionID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, transactionID)
+	}
+	return &txn, nil
+}
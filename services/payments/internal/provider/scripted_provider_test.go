@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestScriptedProviderDeclineThenSuccess(t *testing.T) {
+	p := NewScriptedProvider(zap.NewNop(),
+		Step{Outcome: OutcomeDecline},
+		Step{Outcome: OutcomeSucceed},
+	)
+	req := &ProcessPaymentRequest{IdempotencyKey: "payment-1", OrderID: "order-1", Amount: 1000, Currency: "USD"}
+
+	if _, err := p.ProcessPayment(context.Background(), req); !errors.Is(err, ErrPaymentDeclined) {
+		t.Fatalf("first call: expected ErrPaymentDeclined, got %v", err)
+	}
+
+	// A decline records no charge, so the retry runs the next step
+	resp, err := p.ProcessPayment(context.Background(), req)
+	if err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	if resp.TransactionID != "TXN-SCRIPTED-2" {
+		t.Errorf("transaction ID = %q, want TXN-SCRIPTED-2", resp.TransactionID)
+	}
+	if p.Calls() != 2 {
+		t.Errorf("expected 2 calls, got %d", p.Calls())
+	}
+}
+
+func TestScriptedProviderRepeatedKeyReturnsCharge(t *testing.T) {
+	p := NewScriptedProvider(zap.NewNop(), Step{Outcome: OutcomeLost})
+	req := &ProcessPaymentRequest{IdempotencyKey: "payment-1"}
+
+	if _, err := p.ProcessPayment(context.Background(), req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a lost response, got %v", err)
+	}
+	resp, err := p.ProcessPayment(context.Background(), req)
+	if err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	if resp.TransactionID != "TXN-SCRIPTED-1" {
+		t.Errorf("transaction ID = %q, want the lost charge TXN-SCRIPTED-1", resp.TransactionID)
+	}
+	if p.Calls() != 1 {
+		t.Errorf("the retry consumed a step: %d calls", p.Calls())
+	}
+	if len(p.Requests()) != 2 {
+		t.Errorf("expected 2 recorded requests, got %d", len(p.Requests()))
+	}
+}
+
+func TestScriptedProviderFailNext(t *testing.T) {
+	errDown := errors.New("provider down")
+	p := NewScriptedProvider(zap.NewNop(), Step{Outcome: OutcomeDecline})
+	p.FailNext(2, errDown)
+
+	for i := range 2 {
+		if err := p.CancelPayment(context.Background(), "TXN-1"); !errors.Is(err, errDown) {
+			t.Fatalf("call %d: expected the FailNext error, got %v", i+1, err)
+		}
+	}
+	// Scripted steps run once the forced failures are used up
+	if _, err := p.RefundPayment(context.Background(), "TXN-1", 100); !errors.Is(err, ErrPaymentDeclined) {
+		t.Fatalf("expected the scripted decline, got %v", err)
+	}
+	// An exhausted script succeeds
+	if _, err := p.RefundPayment(context.Background(), "TXN-1", 100); err != nil {
+		t.Fatalf("expected success after the script, got %v", err)
+	}
+}
+
+func TestScriptedProviderTimeout(t *testing.T) {
+	p := NewScriptedProvider(zap.NewNop(), Step{Outcome: OutcomeTimeout})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.ProcessPayment(ctx, &ProcessPaymentRequest{IdempotencyKey: "payment-1"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// A timed out charge was not made, so the key charges anew
+	resp, err := p.ProcessPayment(context.Background(), &ProcessPaymentRequest{IdempotencyKey: "payment-1"})
+	if err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	if resp.TransactionID != "TXN-SCRIPTED-2" {
+		t.Errorf("transaction ID = %q, want TXN-SCRIPTED-2", resp.TransactionID)
+	}
+}
+
+func TestScriptedProviderTransactionStatus(t *testing.T) {
+	p := NewScriptedProvider(zap.NewNop())
+	p.SetTransaction("payment-1", TransactionStatus{TransactionID: "TXN-1", Status: TransactionSucceeded})
+
+	txn, err := p.GetTransactionStatus(context.Background(), "payment-1")
+	if err != nil {
+		t.Fatalf("GetTransactionStatus failed: %v", err)
+	}
+	if txn.Status != TransactionSucceeded || txn.TransactionID != "TXN-1" {
+		t.Errorf("got %+v, want the status that was set", txn)
+	}
+
+	if _, err := p.GetTransactionStatus(context.Background(), "payment-2"); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("expected ErrTransactionNotFound, got %v", err)
+	}
+}
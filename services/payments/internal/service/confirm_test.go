@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/services/payments/internal/provider"
+	"go.uber.org/zap"
+)
+
+// blockingProvider holds every charge until release is closed, and records
+// the transactions it is asked to void or refund
+type blockingProvider struct {
+	*provider.ScriptedProvider
+	charging chan struct{}
+	release  chan struct{}
+
+	mu       sync.Mutex
+	voided   []string
+	refunded []string
+}
+
+func newBlockingProvider(steps ...provider.Step) *blockingProvider {
+	return &blockingProvider{
+		ScriptedProvider: provider.NewScriptedProvider(zap.NewNop(), steps...),
+		charging:         make(chan struct{}, 1),
+		release:          make(chan struct{}),
+	}
+}
+
+func (p *blockingProvider) ProcessPayment(ctx context.Context, req *provider.ProcessPaymentRequest) (*provider.ProcessPaymentResponse, error) {
+	p.charging <- struct{}{}
+	<-p.release
+	return p.ScriptedProvider.ProcessPayment(ctx, req)
+}
+
+func (p *blockingProvider) CancelPayment(ctx context.Context, transactionID string) error {
+	p.mu.Lock()
+	p.voided = append(p.voided, transactionID)
+	p.mu.Unlock()
+	return p.ScriptedProvider.CancelPayment(ctx, transactionID)
+}
+
+func (p *blockingProvider) RefundPayment(ctx context.Context, transactionID string, amount int64) (*provider.RefundResponse, error) {
+	p.mu.Lock()
+	p.refunded = append(p.refunded, transactionID)
+	p.mu.Unlock()
+	return p.ScriptedProvider.RefundPayment(ctx, transactionID, amount)
+}
+
+type releaseRecorder struct {
+	mu     sync.Mutex
+	orders []string
+}
+
+func (r *releaseRecorder) ReleaseReservation(_ context.Context, reservationID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orders = append(r.orders, reservationID)
+	return nil
+}
+
+func pendingPayment() Payment {
+	return Payment{
+		ID:             "payment-1",
+		OrderID:        "order-1",
+		UserID:         "user-1",
+		AmountCurrency: "USD",
+		AmountValue:    2500,
+		Status:         "pending",
+		Method:         "card",
+	}
+}
+
+// confirmInBackground starts ConfirmPayment and waits until its charge reaches
+// the provider
+func confirmInBackground(s *PaymentService, p *blockingProvider, paymentID string) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.ConfirmPayment(context.Background(), paymentID)
+		done <- err
+	}()
+	<-p.charging
+	return done
+}
+
+func TestCancelDuringChargeReversesCharge(t *testing.T) {
+	tests := map[string]struct {
+		// steps script the charge and the provider calls after it
+		steps        []provider.Step
+		wantVoided   int
+		wantRefunded int
+	}{
+		"voided": {
+			wantVoided: 1,
+		},
+		"refunded when the void fails": {
+			steps:        []provider.Step{{Outcome: provider.OutcomeSucceed}, {Outcome: provider.OutcomeDecline}},
+			wantVoided:   1,
+			wantRefunded: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			db, fake := newFakeDB(t, pendingPayment())
+			p := newBlockingProvider(tt.steps...)
+			reservations := &releaseRecorder{}
+			s := NewPaymentService(db, p, nil, ProviderConfig{}, reservations, nil, zap.NewNop())
+
+			done := confirmInBackground(s, p, "payment-1")
+
+			canceled, err := s.CancelPayment(context.Background(), "payment-1", "customer changed their mind")
+			if err != nil {
+				t.Fatalf("CancelPayment failed: %v", err)
+			}
+			if canceled.Status != "cancelled" {
+				t.Fatalf("status after cancel = %s, want cancelled", canceled.Status)
+			}
+
+			close(p.release)
+			if err := <-done; !errors.Is(err, ErrInvalidPaymentState) {
+				t.Fatalf("expected ErrInvalidPaymentState from the late charge, got %v", err)
+			}
+
+			if status := fake.payment("payment-1").Status; status != "cancelled" {
+				t.Errorf("status = %s, want the cancel to stand", status)
+			}
+			if len(p.voided) != tt.wantVoided || len(p.refunded) != tt.wantRefunded {
+				t.Errorf("voided %v and refunded %v, want %d voids and %d refunds",
+					p.voided, p.refunded, tt.wantVoided, tt.wantRefunded)
+			}
+			for _, txn := range append(p.voided, p.refunded...) {
+				if txn != "TXN-SCRIPTED-1" {
+					t.Errorf("reversed transaction %q, want TXN-SCRIPTED-1", txn)
+				}
+			}
+
+			wantEvents := []string{events.TypePaymentCanceled}
+			if got := fake.eventTypes(); !slices.Equal(got, wantEvents) {
+				t.Errorf("events = %v, want %v", got, wantEvents)
+			}
+			if len(reservations.orders) != 1 {
+				t.Errorf("released reservations %v, want the cancel's release only", reservations.orders)
+			}
+		})
+	}
+}
+
+func TestCancelDuringFailedChargeKeepsCancel(t *testing.T) {
+	db, fake := newFakeDB(t, pendingPayment())
+	p := newBlockingProvider(provider.Step{Outcome: provider.OutcomeDecline})
+	reservations := &releaseRecorder{}
+	s := NewPaymentService(db, p, nil, ProviderConfig{}, reservations, nil, zap.NewNop())
+
+	done := confirmInBackground(s, p, "payment-1")
+	if _, err := s.CancelPayment(context.Background(), "payment-1", "fraud"); err != nil {
+		t.Fatalf("CancelPayment failed: %v", err)
+	}
+	close(p.release)
+	if err := <-done; !errors.Is(err, provider.ErrPaymentDeclined) {
+		t.Fatalf("expected the decline, got %v", err)
+	}
+
+	if status := fake.payment("payment-1").Status; status != "cancelled" {
+		t.Errorf("status = %s, want cancelled", status)
+	}
+	wantEvents := []string{events.TypePaymentCanceled}
+	if got := fake.eventTypes(); !slices.Equal(got, wantEvents) {
+		t.Errorf("events = %v, want %v", got, wantEvents)
+	}
+	if len(reservations.orders) != 1 {
+		t.Errorf("released reservations %v, want one release", reservations.orders)
+	}
+}
+
+func TestConcurrentConfirmsChargeOnce(t *testing.T) {
+	db, fake := newFakeDB(t, pendingPayment())
+	p := newBlockingProvider()
+	s := NewPaymentService(db, p, nil, ProviderConfig{}, nil, nil, zap.NewNop())
+
+	done := confirmInBackground(s, p, "payment-1")
+
+	// The second confirm finds the payment processing and leaves it alone
+	payment, err := s.ConfirmPayment(context.Background(), "payment-1")
+	if err != nil {
+		t.Fatalf("second ConfirmPayment failed: %v", err)
+	}
+	if payment.Status != "processing" {
+		t.Errorf("second confirm saw status %s, want processing", payment.Status)
+	}
+
+	close(p.release)
+	if err := <-done; err != nil {
+		t.Fatalf("ConfirmPayment failed: %v", err)
+	}
+
+	if calls := p.Calls(); calls != 1 {
+		t.Errorf("provider calls = %d, want a single charge", calls)
+	}
+	if got := fake.payment("payment-1"); got.Status != "succeeded" || got.ProviderTransactionID != "TXN-SCRIPTED-1" {
+		t.Errorf("payment = %s %q, want succeeded with TXN-SCRIPTED-1", got.Status, got.ProviderTransactionID)
+	}
+	wantEvents := []string{events.TypePaymentSucceeded}
+	if got := fake.eventTypes(); !slices.Equal(got, wantEvents) {
+		t.Errorf("events = %v, want %v", got, wantEvents)
+	}
+}
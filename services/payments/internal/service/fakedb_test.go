@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDB is an in-memory payments table and outbox, answering the statements
+// PaymentService issues through database/sql
+type fakeDB struct {
+	mu       sync.Mutex
+	payments map[string]Payment
+	events   []string
+}
+
+var (
+	registerFakeDriver sync.Once
+	fakeDBs            sync.Map
+)
+
+func newFakeDB(t *testing.T, payments ...Payment) (*sql.DB, *fakeDB) {
+	t.Helper()
+	registerFakeDriver.Do(func() { sql.Register("payments-fake", fakeDriver{}) })
+
+	fake := &fakeDB{payments: make(map[string]Payment)}
+	for _, payment := range payments {
+		fake.payments[payment.ID] = payment
+	}
+	fakeDBs.Store(t.Name(), fake)
+
+	db, err := sql.Open("payments-fake", t.Name())
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		fakeDBs.Delete(t.Name())
+	})
+	return db, fake
+}
+
+func (f *fakeDB) payment(id string) Payment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.payments[id]
+}
+
+func (f *fakeDB) eventTypes() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.events...)
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fake, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("no fake database %q", name)
+	}
+	return &fakeConn{db: fake.(*fakeDB)}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions are not supported")
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case strings.Contains(query, "INSERT INTO payment_outbox"):
+		f.events = append(f.events, args[3].Value.(string))
+		return driver.RowsAffected(1), nil
+
+	case strings.Contains(query, "status = ANY($4)"):
+		id := args[2].Value.(string)
+		payment, ok := f.payments[id]
+		if !ok || !strings.Contains(args[3].Value.(string), `"`+payment.Status+`"`) {
+			return driver.RowsAffected(0), nil
+		}
+		payment.Status = args[0].Value.(string)
+		payment.ErrorMessage = args[1].Value.(string)
+		f.payments[id] = payment
+		return driver.RowsAffected(1), nil
+
+	case strings.Contains(query, "status = 'processing'"):
+		id := args[2].Value.(string)
+		payment, ok := f.payments[id]
+		if !ok || payment.Status != "processing" {
+			return driver.RowsAffected(0), nil
+		}
+		payment.Status = args[0].Value.(string)
+		payment.ProviderTransactionID = args[1].Value.(string)
+		f.payments[id] = payment
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement: %s", query)
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !strings.Contains(query, "FROM payments") || !strings.Contains(query, "WHERE id = $1") {
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rows := &fakeRows{}
+	if payment, ok := f.payments[args[0].Value.(string)]; ok {
+		rows.values = append(rows.values, []driver.Value{
+			payment.ID, payment.OrderID, payment.UserID, payment.AmountCurrency, payment.AmountValue,
+			payment.Status, payment.Method, nullString(payment.ProviderTransactionID),
+			nullString(payment.ErrorMessage), payment.RefundedAmount, time.Now(), time.Now(),
+		})
+	}
+	return rows, nil
+}
+
+func nullString(s string) driver.Value {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+type fakeRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return strings.Split("id order_id user_id amount_currency amount_value status method "+
+		"provider_transaction_id error_message refunded_amount created_at updated_at", " ")
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
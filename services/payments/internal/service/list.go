@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/pkg/errs"
+)
+
+// ErrInvalidPaymentFilter is returned for a malformed ListPayments filter
+var ErrInvalidPaymentFilter = errs.InvalidArgument("INVALID_PAYMENT_FILTER", "invalid payment filter")
+
+// PaymentFilter selects the payments returned by ListPayments. Empty fields
+// match every payment; From is inclusive and To exclusive.
+type PaymentFilter struct {
+	Status  string
+	UserID  string
+	OrderID string
+	From    time.Time
+	To      time.Time
+	Limit   int
+	// Cursor is the ID of the last payment of the previous page
+	Cursor string
+}
+
+// StatusTotal counts and sums the payments matching a filter in one status
+// and currency. Amounts are never summed across currencies.
+type StatusTotal struct {
+	Status   string
+	Currency string
+	Count    int64
+	Amount   int64
+}
+
+// ListPayments returns payments matching filter, newest first, with the totals
+// per status and currency of every matching payment, not just the page
+func (s *PaymentService) ListPayments(ctx context.Context, filter PaymentFilter) ([]*Payment, string, []StatusTotal, error) {
+	where, args, err := paymentFilterClause(filter)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	totals, err := s.paymentTotals(ctx, where, args)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE ` + where
+	argIdx := len(args) + 1
+
+	if filter.Cursor != "" {
+		query += fmt.Sprintf(" AND (created_at, id) < (SELECT created_at, id FROM payments WHERE id = $%d)", argIdx)
+		args = append(args, filter.Cursor)
+		argIdx++
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+	query += fmt.Sprintf(" LIMIT $%d", argIdx)
+	args = append(args, filter.Limit+1)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to query payments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var payments []*Payment
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", nil, fmt.Errorf("failed to iterate payments: %w", err)
+	}
+
+	var nextCursor string
+	if len(payments) > filter.Limit {
+		nextCursor = payments[filter.Limit-1].ID
+		payments = payments[:filter.Limit]
+	}
+
+	return payments, nextCursor, totals, nil
+}
+
+func (s *PaymentService) paymentTotals(ctx context.Context, where string, args []interface{}) ([]StatusTotal, error) {
+	query := `
+		SELECT status, amount_currency, COUNT(*), COALESCE(SUM(amount_value), 0)
+		FROM payments
+		WHERE ` + where + `
+		GROUP BY status, amount_currency
+		ORDER BY status, amount_currency
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum payments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var totals []StatusTotal
+	for rows.Next() {
+		var total StatusTotal
+		if err := rows.Scan(&total.Status, &total.Currency, &total.Count, &total.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan payment totals: %w", err)
+		}
+		totals = append(totals, total)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payment totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+// paymentFilterClause builds the WHERE condition for filter, excluding pagination
+func paymentFilterClause(filter PaymentFilter) (string, []interface{}, error) {
+	if filter.Cursor != "" {
+		if _, err := uuid.Parse(filter.Cursor); err != nil {
+			return "", nil, fmt.Errorf("%w: malformed cursor", ErrInvalidPaymentFilter)
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return "", nil, fmt.Errorf("%w: from must be before to", ErrInvalidPaymentFilter)
+	}
+
+	where := "TRUE"
+	var args []interface{}
+	add := func(cond string, value interface{}) {
+		args = append(args, value)
+		where += fmt.Sprintf(" AND "+cond, len(args))
+	}
+
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.UserID != "" {
+		if _, err := uuid.Parse(filter.UserID); err != nil {
+			return "", nil, fmt.Errorf("%w: malformed user_id", ErrInvalidPaymentFilter)
+		}
+		add("user_id = $%d", filter.UserID)
+	}
+	if filter.OrderID != "" {
+		if _, err := uuid.Parse(filter.OrderID); err != nil {
+			return "", nil, fmt.Errorf("%w: malformed order_id", ErrInvalidPaymentFilter)
+		}
+		add("order_id = $%d", filter.OrderID)
+	}
+	if !filter.From.IsZero() {
+		add("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("created_at < $%d", filter.To)
+	}
+
+	return where, args, nil
+}
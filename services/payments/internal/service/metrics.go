@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/circuitbreaker"
+	"github.com/mumumio1/coldy/services/payments/internal/provider"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Provider call outcomes
+const (
+	OutcomeSuccess  = "success"
+	OutcomeDeclined = "declined"
+	OutcomeTimeout  = "timeout"
+	OutcomeOpen     = "open" // rejected by the circuit breaker without calling the provider
+	OutcomeError    = "error"
+)
+
+var (
+	providerCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "coldy",
+		Subsystem: "payments",
+		Name:      "provider_calls_total",
+		Help:      "Total number of payment provider calls by operation and outcome",
+	}, []string{"operation", "outcome"})
+	providerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "coldy",
+		Subsystem: "payments",
+		Name:      "provider_call_duration_seconds",
+		Help:      "Payment provider call duration in seconds by operation and outcome",
+		Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"operation", "outcome"})
+)
+
+// callProvider runs fn through the circuit breaker and records its outcome and latency
+func (s *PaymentService) callProvider(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	start := time.Now()
+
+	// The call deadline is independent of the breaker's own timeout, which
+	// only decides when a slow call counts as a failure
+	callCtx, cancel := context.WithTimeout(ctx, s.callTimeout)
+	defer cancel()
+
+	err := s.circuitBreaker.Execute(callCtx, func() error {
+		return fn(callCtx)
+	})
+
+	outcome := providerOutcome(err)
+	providerCalls.WithLabelValues(operation, outcome).Inc()
+	providerDuration.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+func providerOutcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, circuitbreaker.ErrCircuitOpen):
+		return OutcomeOpen
+	case errors.Is(err, provider.ErrPaymentDeclined):
+		return OutcomeDeclined
+	case errors.Is(err, context.DeadlineExceeded):
+		return OutcomeTimeout
+	default:
+		return OutcomeError
+	}
+}
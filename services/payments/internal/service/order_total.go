@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/money"
+	"go.uber.org/zap"
+)
+
+// ErrAmountMismatch is returned when a payment's amount is not the verified
+// total of its order
+var ErrAmountMismatch = errs.FailedPrecondition("PAYMENT_AMOUNT_MISMATCH", "payment amount does not match the order total")
+
+// OrderTotals looks up order totals verified against the orders' items
+type OrderTotals interface {
+	VerifiedTotal(ctx context.Context, orderID string) (money.Money, error)
+}
+
+// verifyAmount fails with ErrAmountMismatch unless the payment charges
+// exactly its order's verified total, so a client can't pay less than the
+// order costs or pay for an order whose items changed since
+func (s *PaymentService) verifyAmount(ctx context.Context, orderID, currency string, amount int64) error {
+	if s.orders == nil {
+		return nil
+	}
+
+	total, err := s.orders.VerifiedTotal(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to verify order total: %w", err)
+	}
+	if total.Currency != currency || total.Amount != amount {
+		logger.FromContext(ctx).Warn("payment amount does not match order total",
+			zap.String("order_id", orderID),
+			zap.Int64("amount", amount),
+			zap.String("currency", currency),
+			zap.Int64("order_total", total.Amount),
+			zap.String("order_currency", total.Currency),
+		)
+		return fmt.Errorf("%w: order %s total is %d %s, payment is %d %s",
+			ErrAmountMismatch, orderID, total.Amount, total.Currency, amount, currency)
+	}
+	return nil
+}
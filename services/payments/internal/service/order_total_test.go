@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mumumio1/coldy/pkg/money"
+	"go.uber.org/zap"
+)
+
+type fixedTotals map[string]money.Money
+
+func (f fixedTotals) VerifiedTotal(_ context.Context, orderID string) (money.Money, error) {
+	total, ok := f[orderID]
+	if !ok {
+		return money.Money{}, ErrAmountMismatch
+	}
+	return total, nil
+}
+
+func TestVerifyAmount(t *testing.T) {
+	totals := fixedTotals{"order-1": {Currency: "USD", Amount: 2500}}
+	s := NewPaymentService(nil, nil, nil, ProviderConfig{}, nil, totals, zap.NewNop())
+
+	tests := []struct {
+		name     string
+		orderID  string
+		currency string
+		amount   int64
+		wantErr  bool
+	}{
+		{name: "matching total", orderID: "order-1", currency: "USD", amount: 2500},
+		{name: "lower amount", orderID: "order-1", currency: "USD", amount: 1, wantErr: true},
+		{name: "higher amount", orderID: "order-1", currency: "USD", amount: 2501, wantErr: true},
+		{name: "other currency", orderID: "order-1", currency: "EUR", amount: 2500, wantErr: true},
+		{name: "unknown order", orderID: "order-2", currency: "USD", amount: 2500, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.verifyAmount(context.Background(), tt.orderID, tt.currency, tt.amount)
+			if tt.wantErr && !errors.Is(err, ErrAmountMismatch) {
+				t.Errorf("expected ErrAmountMismatch, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -4,37 +4,94 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/mumumio1/coldy/pkg/circuitbreaker"
+	"github.com/mumumio1/coldy/pkg/errs"
+	"github.com/mumumio1/coldy/pkg/events"
 	"github.com/mumumio1/coldy/pkg/idempotency"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/money"
+	"github.com/mumumio1/coldy/pkg/retry"
 	"github.com/mumumio1/coldy/services/payments/internal/provider"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
+// ScopePaymentsAdmin grants access to operational payment RPCs
+const ScopePaymentsAdmin = "payments:admin"
+
+var (
+	// ErrPaymentNotFound is returned when a payment does not exist
+	ErrPaymentNotFound = errs.NotFound("PAYMENT_NOT_FOUND", "payment not found")
+	// ErrInvalidPaymentState is returned when an operation is not allowed in the payment's current status
+	ErrInvalidPaymentState = errs.FailedPrecondition("INVALID_PAYMENT_STATE", "invalid payment state")
+	// ErrInvalidRefundAmount is returned when a refund amount is out of range
+	ErrInvalidRefundAmount = errs.InvalidArgument("INVALID_REFUND_AMOUNT", "invalid refund amount")
+	// ErrInvalidAmount is returned when a payment amount or currency is invalid
+	ErrInvalidAmount = errs.InvalidArgument("INVALID_AMOUNT", "invalid payment amount")
+)
+
+// ProviderConfig configures calls to the payment provider
+type ProviderConfig struct {
+	// CallTimeout bounds a single provider request; its deadline is passed to
+	// the provider
+	CallTimeout time.Duration
+	// BreakerTimeout is how long the circuit breaker waits on a call before
+	// counting it as a failure
+	BreakerTimeout time.Duration
+}
+
+// DefaultProviderConfig returns the default provider call settings
+func DefaultProviderConfig() ProviderConfig {
+	return ProviderConfig{
+		CallTimeout:    5 * time.Second,
+		BreakerTimeout: 10 * time.Second,
+	}
+}
+
 // PaymentService handles payment business logic
 type PaymentService struct {
 	db             *sql.DB
 	provider       provider.PaymentProvider
+	callTimeout    time.Duration
 	circuitBreaker *circuitbreaker.CircuitBreaker
+	retryPolicy    retry.Policy
 	idempotency    *idempotency.Store
+	reservations   ReservationReleaser
+	orders         OrderTotals
 	logger         *zap.Logger
 }
 
-// NewPaymentService creates a new payment service
+// NewPaymentService creates a new payment service. reservations may be nil,
+// in which case failed and canceled payments leave their order's inventory
+// reservation to expire. orders may be nil only where amounts need no
+// checking against order totals, e.g. in tests.
 func NewPaymentService(
 	db *sql.DB,
 	provider provider.PaymentProvider,
 	redis *redis.Client,
+	providerConfig ProviderConfig,
+	reservations ReservationReleaser,
+	orders OrderTotals,
 	logger *zap.Logger,
 ) *PaymentService {
+	defaults := DefaultProviderConfig()
+	if providerConfig.CallTimeout <= 0 {
+		providerConfig.CallTimeout = defaults.CallTimeout
+	}
+	if providerConfig.BreakerTimeout <= 0 {
+		providerConfig.BreakerTimeout = defaults.BreakerTimeout
+	}
+
 	// Configure circuit breaker for payment provider
 	cb := circuitbreaker.New(circuitbreaker.Config{
 		MaxFailures:  5,
-		Timeout:      10 * time.Second,
+		Timeout:      providerConfig.BreakerTimeout,
 		ResetTimeout: 30 * time.Second,
 	})
 
@@ -46,11 +103,26 @@ func NewPaymentService(
 		)
 	})
 
+	// Retry transient provider failures; declines and an open breaker are final.
+	// Every attempt at a charge carries the payment ID as its idempotency key,
+	// so retrying a call whose outcome is unknown, such as one that timed out
+	// while the provider kept processing it, cannot charge twice.
+	policy := retry.Policy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+		Retryable:   isRetryableProviderError,
+	}
+
 	return &PaymentService{
 		db:             db,
 		provider:       provider,
+		callTimeout:    providerConfig.CallTimeout,
 		circuitBreaker: cb,
+		retryPolicy:    policy,
 		idempotency:    idempotency.NewStore(redis),
+		reservations:   reservations,
+		orders:         orders,
 		logger:         logger,
 	}
 }
@@ -77,20 +149,23 @@ type Payment struct {
 	Method                string
 	ProviderTransactionID string
 	ErrorMessage          string
-	CreatedAt             time.Time
-	UpdatedAt             time.Time
+	// RefundedAmount is the sum of the refunds issued so far. A payment stays
+	// succeeded until it is refunded in full.
+	RefundedAmount int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 // CreatePayment creates a new payment with idempotency
 func (s *PaymentService) CreatePayment(ctx context.Context, idempotencyKey string, req *CreatePaymentRequest) (*Payment, bool, error) {
 	// Check idempotency
 	key := idempotency.GenerateKey(req.UserID, "create_payment", idempotencyKey)
-	cached, found, err := s.idempotency.Get(ctx, key)
+	cached, found, err := s.idempotency.Get(ctx, "create_payment", key)
 	if err != nil {
-		s.logger.Warn("idempotency check failed", zap.Error(err))
+		logger.FromContext(ctx).Warn("idempotency check failed", zap.Error(err))
 	}
 	if found {
-		s.logger.Info("idempotent payment request",
+		logger.FromContext(ctx).Info("idempotent payment request",
 			zap.String("user_id", req.UserID),
 			zap.String("order_id", req.OrderID),
 		)
@@ -103,6 +178,16 @@ func (s *PaymentService) CreatePayment(ctx context.Context, idempotencyKey strin
 		return &payment, true, nil
 	}
 
+	if req.Amount <= 0 {
+		return nil, false, fmt.Errorf("%w: amount must be positive", ErrInvalidAmount)
+	}
+	if err := money.ValidateCurrency(req.Currency); err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+	if err := s.verifyAmount(ctx, req.OrderID, req.Currency, req.Amount); err != nil {
+		return nil, false, err
+	}
+
 	// Create payment record
 	payment := &Payment{
 		ID:             uuid.New().String(),
@@ -136,12 +221,11 @@ func (s *PaymentService) CreatePayment(ctx context.Context, idempotencyKey strin
 	}
 
 	// Cache result for idempotency
-	paymentJSON, _ := json.Marshal(payment)
-	if err := s.idempotency.Set(ctx, key, 200, paymentJSON); err != nil {
-		s.logger.Warn("failed to cache idempotency result", zap.Error(err))
+	if err := s.idempotency.Set(ctx, "create_payment", key, 200, payment); err != nil {
+		logger.FromContext(ctx).Warn("failed to cache idempotency result", zap.Error(err))
 	}
 
-	s.logger.Info("payment created",
+	logger.FromContext(ctx).Info("payment created",
 		zap.String("payment_id", payment.ID),
 		zap.String("order_id", payment.OrderID),
 	)
@@ -161,58 +245,70 @@ func (s *PaymentService) ConfirmPayment(ctx context.Context, paymentID string) (
 		return payment, nil // Already processed
 	}
 
-	// Update status to processing
-	if err := s.updatePaymentStatus(ctx, paymentID, "processing", ""); err != nil {
+	// The order's items may have changed since the payment was created
+	if err := s.verifyAmount(ctx, payment.OrderID, payment.AmountCurrency, payment.AmountValue); err != nil {
 		return nil, err
 	}
 
-	// Process payment with circuit breaker
-	var providerResp *provider.ProcessPaymentResponse
-	err = s.circuitBreaker.Execute(ctx, func() error {
-		var provErr error
-		providerResp, provErr = s.provider.ProcessPayment(ctx, &provider.ProcessPaymentRequest{
-			OrderID:       payment.OrderID,
-			Amount:        payment.AmountValue,
-			Currency:      payment.AmountCurrency,
-			PaymentMethod: payment.Method,
-		})
-		return provErr
-	})
+	// Only one confirm gets to charge; a concurrent one finds the payment
+	// already processing
+	started, err := s.transitionPayment(ctx, paymentID, "processing", "", "pending")
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment status: %w", err)
+	}
+	if !started {
+		return s.GetPayment(ctx, paymentID)
+	}
 
+	providerResp, err := s.charge(ctx, payment)
 	if err != nil {
 		// Payment failed
-		s.logger.Error("payment processing failed",
+		logger.FromContext(ctx).Error("payment processing failed",
 			zap.String("payment_id", paymentID),
 			zap.Error(err),
 		)
 
-		if err := s.updatePaymentStatusWithError(ctx, paymentID, "failed", err.Error()); err != nil {
-			s.logger.Error("failed to update payment status", zap.Error(err))
+		failed, updateErr := s.transitionPayment(ctx, paymentID, "failed", err.Error(), "processing")
+		if updateErr != nil {
+			logger.FromContext(ctx).Error("failed to update payment status", zap.Error(updateErr))
+		} else if !failed {
+			// Canceled meanwhile; the cancel has published and released
+			return nil, fmt.Errorf("payment processing failed: %w", err)
 		}
 
 		// Publish failure event
-		s.publishEvent(ctx, paymentID, "payment.failed", map[string]interface{}{
-			"payment_id": paymentID,
-			"order_id":   payment.OrderID,
-			"error":      err.Error(),
+		s.publishEvent(ctx, paymentID, events.PaymentFailed{
+			PaymentID: paymentID,
+			OrderID:   payment.OrderID,
+			UserID:    payment.UserID,
+			Error:     err.Error(),
 		})
+		s.releaseReservation(ctx, payment)
 
 		return nil, fmt.Errorf("payment processing failed: %w", err)
 	}
 
-	// Payment succeeded
-	if err := s.updatePaymentStatusWithTransaction(ctx, paymentID, "succeeded", providerResp.TransactionID); err != nil {
-		return nil, err
+	// Payment succeeded, unless it was canceled while the charge was in flight
+	settled, err := s.settleProcessingPayment(ctx, paymentID, "succeeded", providerResp.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment status: %w", err)
+	}
+	if !settled {
+		// The cancel has already released the order's stock, so the charge
+		// must not stand
+		s.reverseCharge(ctx, payment, providerResp.TransactionID)
+		return nil, fmt.Errorf("%w: payment was canceled while being charged", ErrInvalidPaymentState)
 	}
 
 	// Publish success event
-	s.publishEvent(ctx, paymentID, "payment.succeeded", map[string]interface{}{
-		"payment_id":     paymentID,
-		"order_id":       payment.OrderID,
-		"transaction_id": providerResp.TransactionID,
+	s.publishEvent(ctx, paymentID, events.PaymentSucceeded{
+		PaymentID:     paymentID,
+		OrderID:       payment.OrderID,
+		UserID:        payment.UserID,
+		TransactionID: providerResp.TransactionID,
 	})
 
-	s.logger.Info("payment confirmed",
+	logger.FromContext(ctx).Info("payment confirmed",
 		zap.String("payment_id", paymentID),
 		zap.String("transaction_id", providerResp.TransactionID),
 	)
@@ -220,19 +316,193 @@ func (s *PaymentService) ConfirmPayment(ctx context.Context, paymentID string) (
 	return s.GetPayment(ctx, paymentID)
 }
 
+// CancelPayment cancels a pending or processing payment
+func (s *PaymentService) CancelPayment(ctx context.Context, paymentID, reason string) (*Payment, error) {
+	payment, err := s.GetPayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status != "pending" && payment.Status != "processing" {
+		return nil, fmt.Errorf("%w: cannot cancel payment in status %s", ErrInvalidPaymentState, payment.Status)
+	}
+
+	// Void at the provider if a transaction was already created
+	if payment.ProviderTransactionID != "" {
+		err = s.callProvider(ctx, "cancel", func(callCtx context.Context) error {
+			return s.provider.CancelPayment(callCtx, payment.ProviderTransactionID)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("provider cancel failed: %w", err)
+		}
+	}
+
+	// A charge that settled the payment first wins over the cancel; one that
+	// settles after finds the payment canceled and reverses itself
+	canceled, err := s.transitionPayment(ctx, paymentID, "cancelled", reason, "pending", "processing")
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment status: %w", err)
+	}
+	if !canceled {
+		return nil, fmt.Errorf("%w: payment changed status while being canceled", ErrInvalidPaymentState)
+	}
+
+	s.publishEvent(ctx, paymentID, events.PaymentCanceled{
+		PaymentID: paymentID,
+		OrderID:   payment.OrderID,
+		UserID:    payment.UserID,
+		Reason:    reason,
+	})
+	s.releaseReservation(ctx, payment)
+
+	logger.FromContext(ctx).Info("payment canceled",
+		zap.String("payment_id", paymentID),
+		zap.String("reason", reason),
+	)
+
+	return s.GetPayment(ctx, paymentID)
+}
+
+// RefundPayment refunds a succeeded payment, in part or in full. A zero amount
+// refunds whatever has not been refunded yet. The payment becomes refunded
+// once the refunds add up to its amount.
+func (s *PaymentService) RefundPayment(ctx context.Context, paymentID string, amount int64, currency, reason string) (*Payment, string, error) {
+	payment, err := s.GetPayment(ctx, paymentID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if payment.Status != "succeeded" {
+		return nil, "", fmt.Errorf("%w: cannot refund payment in status %s", ErrInvalidPaymentState, payment.Status)
+	}
+
+	refundable := payment.AmountValue - payment.RefundedAmount
+	if amount == 0 {
+		amount = refundable
+	}
+	if amount <= 0 || amount > refundable {
+		return nil, "", fmt.Errorf("%w: %d exceeds refundable amount %d", ErrInvalidRefundAmount, amount, refundable)
+	}
+	if currency != "" {
+		refund := money.Money{Currency: currency, Amount: -amount}
+		if _, err := (money.Money{Currency: payment.AmountCurrency, Amount: payment.AmountValue}).Add(refund); err != nil {
+			return nil, "", fmt.Errorf("%w: %v", ErrInvalidRefundAmount, err)
+		}
+	}
+
+	// Record the refund before asking the provider, so concurrent refunds
+	// can't together exceed the payment
+	recorded, err := s.recordRefund(ctx, paymentID, amount)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to record refund: %w", err)
+	}
+	if !recorded {
+		return nil, "", fmt.Errorf("%w: payment was refunded or changed concurrently", ErrInvalidPaymentState)
+	}
+
+	var refundResp *provider.RefundResponse
+	err = s.callProvider(ctx, "refund", func(callCtx context.Context) error {
+		var provErr error
+		refundResp, provErr = s.provider.RefundPayment(callCtx, payment.ProviderTransactionID, amount)
+		return provErr
+	})
+	if err != nil {
+		if undoErr := s.undoRefund(ctx, paymentID, amount); undoErr != nil {
+			logger.FromContext(ctx).Error("failed to undo refund",
+				zap.String("payment_id", paymentID),
+				zap.Int64("amount", amount),
+				zap.Error(undoErr),
+			)
+		}
+		return nil, "", fmt.Errorf("provider refund failed: %w", err)
+	}
+
+	s.publishEvent(ctx, paymentID, events.PaymentRefunded{
+		PaymentID:      paymentID,
+		OrderID:        payment.OrderID,
+		UserID:         payment.UserID,
+		RefundID:       refundResp.RefundID,
+		Amount:         amount,
+		RefundedAmount: payment.RefundedAmount + amount,
+		Currency:       payment.AmountCurrency,
+		Reason:         reason,
+	})
+
+	logger.FromContext(ctx).Info("payment refunded",
+		zap.String("payment_id", paymentID),
+		zap.String("refund_id", refundResp.RefundID),
+		zap.Int64("amount", amount),
+	)
+
+	refunded, err := s.GetPayment(ctx, paymentID)
+	if err != nil {
+		return nil, "", err
+	}
+	return refunded, refundResp.RefundID, nil
+}
+
 // GetPayment retrieves a payment by ID
 func (s *PaymentService) GetPayment(ctx context.Context, paymentID string) (*Payment, error) {
 	query := `
-		SELECT id, order_id, user_id, amount_currency, amount_value, status, method, 
-		       provider_transaction_id, error_message, created_at, updated_at
+		SELECT ` + paymentColumns + `
 		FROM payments
 		WHERE id = $1
 	`
 
+	payment, err := scanPayment(s.db.QueryRowContext(ctx, query, paymentID))
+	if err == sql.ErrNoRows {
+		return nil, ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// GetPaymentsByOrderID returns every payment attempt for an order, newest first
+func (s *PaymentService) GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE order_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var payments []*Payment
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+const paymentColumns = `id, order_id, user_id, amount_currency, amount_value, status, method,
+		       provider_transaction_id, error_message, refunded_amount, created_at, updated_at`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPayment(row rowScanner) (*Payment, error) {
 	var payment Payment
 	var transactionID, errorMsg sql.NullString
 
-	err := s.db.QueryRowContext(ctx, query, paymentID).Scan(
+	err := row.Scan(
 		&payment.ID,
 		&payment.OrderID,
 		&payment.UserID,
@@ -242,15 +512,12 @@ func (s *PaymentService) GetPayment(ctx context.Context, paymentID string) (*Pay
 		&payment.Method,
 		&transactionID,
 		&errorMsg,
+		&payment.RefundedAmount,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("payment not found")
-	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get payment: %w", err)
+		return nil, err
 	}
 
 	if transactionID.Valid {
@@ -263,33 +530,96 @@ func (s *PaymentService) GetPayment(ctx context.Context, paymentID string) (*Pay
 	return &payment, nil
 }
 
-func (s *PaymentService) updatePaymentStatus(ctx context.Context, paymentID, status, errorMsg string) error {
+// transitionPayment sets a payment's status only if it is still in one of
+// from. It reports false when the payment had moved on.
+func (s *PaymentService) transitionPayment(ctx context.Context, paymentID, status, errorMsg string, from ...string) (bool, error) {
 	query := `
 		UPDATE payments
 		SET status = $1, error_message = $2, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $3
+		WHERE id = $3 AND status = ANY($4)
 	`
 
-	_, err := s.db.ExecContext(ctx, query, status, errorMsg, paymentID)
-	return err
+	result, err := s.db.ExecContext(ctx, query, status, errorMsg, paymentID, pq.Array(from))
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
 }
 
-func (s *PaymentService) updatePaymentStatusWithError(ctx context.Context, paymentID, status, errorMsg string) error {
-	return s.updatePaymentStatus(ctx, paymentID, status, errorMsg)
+// recordRefund adds amount to a succeeded payment's refunded amount, marking
+// it refunded once the refunds cover it. It reports false when the payment is
+// no longer succeeded or amount exceeds what is left to refund.
+func (s *PaymentService) recordRefund(ctx context.Context, paymentID string, amount int64) (bool, error) {
+	query := `
+		UPDATE payments
+		SET refunded_amount = refunded_amount + $2,
+		    status = CASE WHEN refunded_amount + $2 = amount_value THEN 'refunded' ELSE status END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'succeeded' AND refunded_amount + $2 <= amount_value
+	`
+
+	result, err := s.db.ExecContext(ctx, query, paymentID, amount)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
 }
 
-func (s *PaymentService) updatePaymentStatusWithTransaction(ctx context.Context, paymentID, status, transactionID string) error {
+// undoRefund takes back a refund recordRefund recorded but the provider
+// did not make
+func (s *PaymentService) undoRefund(ctx context.Context, paymentID string, amount int64) error {
 	query := `
 		UPDATE payments
-		SET status = $1, provider_transaction_id = $2, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $3
+		SET refunded_amount = refunded_amount - $2, status = 'succeeded', updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND refunded_amount >= $2
 	`
 
-	_, err := s.db.ExecContext(ctx, query, status, transactionID, paymentID)
+	_, err := s.db.ExecContext(ctx, query, paymentID, amount)
 	return err
 }
 
-func (s *PaymentService) publishEvent(ctx context.Context, paymentID, eventType string, payload map[string]interface{}) {
+// reverseCharge voids a charge made for a payment canceled while it was in
+// flight, refunding it in full if the provider can no longer void it
+func (s *PaymentService) reverseCharge(ctx context.Context, payment *Payment, transactionID string) {
+	err := s.callProvider(ctx, "cancel", func(callCtx context.Context) error {
+		return s.provider.CancelPayment(callCtx, transactionID)
+	})
+	if err == nil {
+		logger.FromContext(ctx).Warn("voided charge of canceled payment",
+			zap.String("payment_id", payment.ID),
+			zap.String("transaction_id", transactionID),
+		)
+		return
+	}
+
+	err = s.callProvider(ctx, "refund", func(callCtx context.Context) error {
+		_, provErr := s.provider.RefundPayment(callCtx, transactionID, payment.AmountValue)
+		return provErr
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to reverse charge of canceled payment",
+			zap.String("payment_id", payment.ID),
+			zap.String("transaction_id", transactionID),
+			zap.Int64("amount", payment.AmountValue),
+			zap.Error(err),
+		)
+		return
+	}
+	logger.FromContext(ctx).Warn("refunded charge of canceled payment",
+		zap.String("payment_id", payment.ID),
+		zap.String("transaction_id", transactionID),
+	)
+}
+
+func (s *PaymentService) publishEvent(ctx context.Context, paymentID string, payload events.Event) {
 	// Insert into outbox
 	payloadJSON, _ := json.Marshal(payload)
 
@@ -302,13 +632,62 @@ func (s *PaymentService) publishEvent(ctx context.Context, paymentID, eventType
 		uuid.New().String(),
 		"payment",
 		paymentID,
-		eventType,
+		payload.EventType(),
 		payloadJSON,
 	)
 
 	if err != nil {
-		s.logger.Error("failed to publish event to outbox", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to publish event to outbox", zap.Error(err))
+	}
+}
+
+// charge processes payment with the provider through the circuit breaker,
+// retrying transient failures. Every attempt sends the same idempotency key.
+func (s *PaymentService) charge(ctx context.Context, payment *Payment) (*provider.ProcessPaymentResponse, error) {
+	req := &provider.ProcessPaymentRequest{
+		IdempotencyKey: payment.ID,
+		OrderID:        payment.OrderID,
+		Amount:         payment.AmountValue,
+		Currency:       payment.AmountCurrency,
+		PaymentMethod:  payment.Method,
+	}
+
+	var resp *provider.ProcessPaymentResponse
+	err := retry.Do(ctx, s.retryPolicy, func() error {
+		return s.callProvider(ctx, "process", func(callCtx context.Context) error {
+			var provErr error
+			resp, provErr = s.provider.ProcessPayment(callCtx, req)
+			return provErr
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
+	return resp, nil
+}
+
+func isRetryableProviderError(err error) bool {
+	return !errors.Is(err, provider.ErrPaymentDeclined) && !errors.Is(err, circuitbreaker.ErrCircuitOpen)
+}
+
+// ProviderCircuit returns the state and failure count of the provider circuit breaker
+func (s *PaymentService) ProviderCircuit() (circuitbreaker.State, uint32) {
+	return s.circuitBreaker.GetState(), s.circuitBreaker.GetFailures()
+}
+
+// ResetProviderCircuit force-closes the provider circuit breaker, e.g. once an
+// operator knows the provider recovered. It returns the state before the reset.
+func (s *PaymentService) ResetProviderCircuit(ctx context.Context, reason string) (circuitbreaker.State, uint32) {
+	state, failures := s.ProviderCircuit()
+	s.circuitBreaker.Reset()
+
+	logger.FromContext(ctx).Warn("provider circuit breaker reset",
+		zap.String("previous_state", stateString(state)),
+		zap.Uint32("previous_failures", failures),
+		zap.String("reason", reason),
+	)
+
+	return state, failures
 }
 
 func stateString(state circuitbreaker.State) string {
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/services/payments/internal/provider"
+	"go.uber.org/zap"
+)
+
+func newTestService(p provider.PaymentProvider) *PaymentService {
+	return NewPaymentService(nil, p, nil, ProviderConfig{}, nil, nil, zap.NewNop())
+}
+
+func TestChargeRetriesLostResponseWithSameKey(t *testing.T) {
+	scripted := provider.NewScriptedProvider(zap.NewNop(),
+		provider.Step{Outcome: provider.OutcomeLost},
+	)
+	s := newTestService(scripted)
+
+	payment := &Payment{ID: "payment-1", OrderID: "order-1", AmountValue: 1000, AmountCurrency: "USD"}
+	resp, err := s.charge(context.Background(), payment)
+	if err != nil {
+		t.Fatalf("charge failed: %v", err)
+	}
+
+	requests := scripted.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 provider requests, got %d", len(requests))
+	}
+	for i, req := range requests {
+		if req.IdempotencyKey != payment.ID {
+			t.Errorf("request %d: idempotency key = %q, want %q", i, req.IdempotencyKey, payment.ID)
+		}
+	}
+	// The retry must return the charge the lost response made, not a new one
+	if scripted.Calls() != 1 {
+		t.Errorf("expected 1 charge at the provider, got %d", scripted.Calls())
+	}
+	if resp.TransactionID != "TXN-SCRIPTED-1" {
+		t.Errorf("transaction ID = %q, want TXN-SCRIPTED-1", resp.TransactionID)
+	}
+}
+
+func TestChargeDoesNotRetryDecline(t *testing.T) {
+	scripted := provider.NewScriptedProvider(zap.NewNop(),
+		provider.Step{Outcome: provider.OutcomeDecline},
+	)
+	s := newTestService(scripted)
+
+	_, err := s.charge(context.Background(), &Payment{ID: "payment-1", OrderID: "order-1"})
+	if !errors.Is(err, provider.ErrPaymentDeclined) {
+		t.Fatalf("expected ErrPaymentDeclined, got %v", err)
+	}
+	if scripted.Calls() != 1 {
+		t.Errorf("expected 1 provider call, got %d", scripted.Calls())
+	}
+}
+
+func TestChargeRetriesTransientError(t *testing.T) {
+	scripted := provider.NewScriptedProvider(zap.NewNop(),
+		provider.Step{Outcome: provider.OutcomeError},
+		provider.Step{Outcome: provider.OutcomeSucceed},
+	)
+	s := newTestService(scripted)
+
+	resp, err := s.charge(context.Background(), &Payment{ID: "payment-1", OrderID: "order-1"})
+	if err != nil {
+		t.Fatalf("charge failed: %v", err)
+	}
+	if scripted.Calls() != 2 {
+		t.Errorf("expected 2 provider calls, got %d", scripted.Calls())
+	}
+	if resp.TransactionID != "TXN-SCRIPTED-2" {
+		t.Errorf("transaction ID = %q, want TXN-SCRIPTED-2", resp.TransactionID)
+	}
+}
+
+func TestChargeWindowCoversEveryAttempt(t *testing.T) {
+	s := NewPaymentService(nil, nil, nil, ProviderConfig{CallTimeout: 5 * time.Second}, nil, nil, zap.NewNop())
+
+	// Three attempts timing out, with the longest backoff between each
+	want := 3*5*time.Second + 2*s.retryPolicy.MaxDelay
+	if got := s.chargeWindow(); got != want {
+		t.Errorf("chargeWindow() = %s, want %s", got, want)
+	}
+}
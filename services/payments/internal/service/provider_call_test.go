@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mumumio1/coldy/services/payments/internal/provider"
+	"go.uber.org/zap"
+)
+
+func TestNewPaymentServiceDefaultsProviderConfig(t *testing.T) {
+	s := NewPaymentService(nil, nil, nil, ProviderConfig{}, nil, nil, zap.NewNop())
+
+	if want := DefaultProviderConfig().CallTimeout; s.callTimeout != want {
+		t.Errorf("call timeout = %s, want the default %s", s.callTimeout, want)
+	}
+}
+
+func TestCallProviderBoundsCallByCallTimeout(t *testing.T) {
+	scripted := provider.NewScriptedProvider(zap.NewNop(), provider.Step{Outcome: provider.OutcomeTimeout})
+	s := NewPaymentService(nil, scripted, nil, ProviderConfig{
+		CallTimeout:    50 * time.Millisecond,
+		BreakerTimeout: time.Minute,
+	}, nil, nil, zap.NewNop())
+
+	var deadline time.Time
+	start := time.Now()
+	err := s.callProvider(context.Background(), "cancel", func(ctx context.Context) error {
+		deadline, _ = ctx.Deadline()
+		return scripted.CancelPayment(ctx, "TXN-1")
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the call to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("call took %s, want it cut off at the 50ms call timeout", elapsed)
+	}
+	// The breaker's own minute-long timeout must not be the deadline
+	if deadline.IsZero() || deadline.Sub(start) > time.Second {
+		t.Errorf("provider saw deadline %s after the call started, want about 50ms", deadline.Sub(start))
+	}
+}
+
+func TestCallProviderKeepsEarlierCallerDeadline(t *testing.T) {
+	s := NewPaymentService(nil, nil, nil, ProviderConfig{CallTimeout: time.Minute}, nil, nil, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	err := s.callProvider(ctx, "status", func(callCtx context.Context) error {
+		if got, _ := callCtx.Deadline(); !got.Equal(want) {
+			t.Errorf("provider deadline = %s, want the caller's %s", got, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("callProvider failed: %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/events"
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/services/payments/internal/provider"
+	"go.uber.org/zap"
+)
+
+// localStatuses maps provider transaction statuses to payment statuses
+var localStatuses = map[string]string{
+	provider.TransactionSucceeded: "succeeded",
+	provider.TransactionFailed:    "failed",
+	provider.TransactionCanceled:  "cancelled",
+	provider.TransactionRefunded:  "refunded",
+}
+
+// reconcileEvent returns the event emitted when a payment is corrected to
+// status
+func reconcileEvent(payment *Payment, status, transactionID string) events.Event {
+	reconciliation := events.Reconciliation{Reconciled: true, PreviousStatus: payment.Status}
+	switch status {
+	case "succeeded":
+		return events.PaymentSucceeded{
+			PaymentID:      payment.ID,
+			OrderID:        payment.OrderID,
+			UserID:         payment.UserID,
+			TransactionID:  transactionID,
+			Reconciliation: reconciliation,
+		}
+	case "cancelled":
+		return events.PaymentCanceled{
+			PaymentID:      payment.ID,
+			OrderID:        payment.OrderID,
+			UserID:         payment.UserID,
+			Reconciliation: reconciliation,
+		}
+	case "refunded":
+		return events.PaymentRefunded{
+			PaymentID:      payment.ID,
+			OrderID:        payment.OrderID,
+			UserID:         payment.UserID,
+			Currency:       payment.AmountCurrency,
+			Reconciliation: reconciliation,
+		}
+	default:
+		return events.PaymentFailed{
+			PaymentID:      payment.ID,
+			OrderID:        payment.OrderID,
+			UserID:         payment.UserID,
+			Error:          "the provider has no successful charge for the payment",
+			Reconciliation: reconciliation,
+		}
+	}
+}
+
+// ReconcilePayment compares a payment stuck in processing with the provider's
+// record of it and, if they disagree, corrects the local status and emits the
+// event of the corrected status, e.g. payment.succeeded for a charge that
+// timed out locally but went through. Only payments that have been processing
+// longer than a charge can take are reconciled; the others may still be
+// settled by the charge itself. It reports whether the payment was corrected.
+func (s *PaymentService) ReconcilePayment(ctx context.Context, paymentID string) (*Payment, bool, error) {
+	payment, err := s.GetPayment(ctx, paymentID)
+	if err != nil {
+		return nil, false, err
+	}
+	if payment.Status != "processing" {
+		return payment, false, nil // Settled by the charge, a cancel or a refund
+	}
+	if age := time.Since(payment.UpdatedAt); age < s.chargeWindow() {
+		return nil, false, fmt.Errorf("%w: charge may still be in flight, retry after %s",
+			ErrInvalidPaymentState, (s.chargeWindow() - age).Round(time.Second))
+	}
+
+	// A lost response leaves no transaction ID, but the charge is also found
+	// by its idempotency key, the payment ID
+	reference := payment.ProviderTransactionID
+	if reference == "" {
+		reference = payment.ID
+	}
+
+	var txn *provider.TransactionStatus
+	err = s.callProvider(ctx, "status", func(callCtx context.Context) error {
+		var provErr error
+		txn, provErr = s.provider.GetTransactionStatus(callCtx, reference)
+		// Not found is an answer, not a provider failure
+		if errors.Is(provErr, provider.ErrTransactionNotFound) {
+			txn = nil
+			return nil
+		}
+		return provErr
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("provider status query failed: %w", err)
+	}
+
+	status := "failed"
+	transactionID := payment.ProviderTransactionID
+	if txn != nil {
+		var ok bool
+		if status, ok = localStatuses[txn.Status]; !ok {
+			return nil, false, fmt.Errorf("unknown provider transaction status %q", txn.Status)
+		}
+		transactionID = txn.TransactionID
+	}
+
+	if status == payment.Status {
+		return payment, false, nil
+	}
+
+	// Only a payment still processing is corrected, so a charge, cancel or
+	// refund that settled it meanwhile is not overwritten
+	updated, err := s.settleProcessingPayment(ctx, paymentID, status, transactionID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to update payment status: %w", err)
+	}
+	if !updated {
+		current, err := s.GetPayment(ctx, paymentID)
+		if err != nil {
+			return nil, false, err
+		}
+		return current, false, nil
+	}
+
+	s.publishEvent(ctx, paymentID, reconcileEvent(payment, status, transactionID))
+	if status == "failed" || status == "cancelled" {
+		s.releaseReservation(ctx, payment)
+	}
+
+	logger.FromContext(ctx).Warn("payment reconciled with provider",
+		zap.String("payment_id", paymentID),
+		zap.String("previous_status", payment.Status),
+		zap.String("status", status),
+	)
+
+	reconciled, err := s.GetPayment(ctx, paymentID)
+	if err != nil {
+		return nil, false, err
+	}
+	return reconciled, true, nil
+}
+
+// chargeWindow is the longest a charge can stay in flight: every attempt
+// timing out, with the longest backoff between them
+func (s *PaymentService) chargeWindow() time.Duration {
+	attempts := time.Duration(s.retryPolicy.MaxAttempts)
+	return attempts*s.callTimeout + (attempts-1)*s.retryPolicy.MaxDelay
+}
+
+// settleProcessingPayment sets the status of a payment that is still
+// processing. It reports false when the payment had already left processing.
+func (s *PaymentService) settleProcessingPayment(ctx context.Context, paymentID, status, transactionID string) (bool, error) {
+	query := `
+		UPDATE payments
+		SET status = $1, provider_transaction_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND status = 'processing'
+	`
+
+	result, err := s.db.ExecContext(ctx, query, status, transactionID, paymentID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
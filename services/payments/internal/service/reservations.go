@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mumumio1/coldy/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ReservationReleaser releases the inventory reservation held for an order.
+// Releasing a reservation that is no longer active must be a no-op.
+type ReservationReleaser interface {
+	ReleaseReservation(ctx context.Context, reservationID string) error
+}
+
+// releaseReservation returns the stock held for a failed or canceled payment's
+// order; reservations are keyed by order ID. Failures are logged and left to
+// the reservation's expiry, since the payment outcome is already recorded.
+func (s *PaymentService) releaseReservation(ctx context.Context, payment *Payment) {
+	if s.reservations == nil {
+		return
+	}
+	if err := s.reservations.ReleaseReservation(ctx, payment.OrderID); err != nil {
+		logger.FromContext(ctx).Error("failed to release inventory reservation",
+			zap.String("payment_id", payment.ID),
+			zap.String("order_id", payment.OrderID),
+			zap.Error(err),
+		)
+		return
+	}
+	logger.FromContext(ctx).Info("inventory reservation released",
+		zap.String("payment_id", payment.ID),
+		zap.String("order_id", payment.OrderID),
+	)
+}
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id);
+DROP INDEX IF EXISTS idx_payments_order_id_created_at;
@@ -0,0 +1,3 @@
+-- Serve newest-first payment lookups by order from the index
+CREATE INDEX IF NOT EXISTS idx_payments_order_id_created_at ON payments(order_id, created_at DESC);
+DROP INDEX IF EXISTS idx_payments_order_id;
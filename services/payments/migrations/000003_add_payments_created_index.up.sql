@@ -0,0 +1,2 @@
+-- Keyset pagination of all payments for ListPayments
+CREATE INDEX IF NOT EXISTS idx_payments_created_at ON payments(created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE payment_outbox DROP COLUMN IF EXISTS last_error;
+ALTER TABLE payment_outbox DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,4 @@
+-- Failed publish attempts of an outbox event and the last error, for spotting
+-- events the publisher keeps retrying
+ALTER TABLE payment_outbox ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE payment_outbox ADD COLUMN IF NOT EXISTS last_error TEXT;
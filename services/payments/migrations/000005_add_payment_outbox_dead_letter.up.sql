@@ -0,0 +1,2 @@
+-- Events that used up their publish attempts; the publisher skips them
+ALTER TABLE payment_outbox ADD COLUMN IF NOT EXISTS dead_lettered_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,2 @@
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_refunded_amount_check;
+ALTER TABLE payments DROP COLUMN IF EXISTS refunded_amount;
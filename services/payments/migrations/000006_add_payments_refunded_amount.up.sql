@@ -0,0 +1,7 @@
+-- Partial refunds keep the payment succeeded and add up here
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount BIGINT NOT NULL DEFAULT 0;
+
+UPDATE payments SET refunded_amount = amount_value WHERE status = 'refunded';
+
+ALTER TABLE payments ADD CONSTRAINT payments_refunded_amount_check
+    CHECK (refunded_amount >= 0 AND refunded_amount <= amount_value);
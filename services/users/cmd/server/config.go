@@ -0,0 +1,96 @@
+package main
+
+import (
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/config"
+	grpcserverpkg "github.com/mumumio1/coldy/pkg/grpcserver"
+	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/pagination"
+	"github.com/mumumio1/coldy/pkg/shutdown"
+	"github.com/mumumio1/coldy/services/users/internal/service"
+)
+
+// serverConfig is the users service configuration, loaded from the environment
+type serverConfig struct {
+	Env             string `env:"ENV" default:"development" validate:"oneof=development staging production"`
+	TracingEndpoint string `env:"OTEL_EXPORTER_OTLP_ENDPOINT" default:"localhost:4317"`
+	MetricsPort     int    `env:"METRICS_PORT" default:"9090" validate:"port"`
+
+	DB struct {
+		Host     string `env:"DB_HOST" default:"localhost"`
+		User     string `env:"DB_USER" default:"coldy"`
+		Password string `env:"DB_PASSWORD" default:"coldy123"`
+		Name     string `env:"DB_NAME" default:"coldy"`
+		SSLMode  string `env:"DB_SSLMODE" default:"disable" validate:"oneof=disable allow prefer require verify-ca verify-full"`
+	}
+
+	Redis struct {
+		Addr     string `env:"REDIS_ADDR" default:"localhost:6379"`
+		Password string `env:"REDIS_PASSWORD"`
+		DB       int    `env:"REDIS_DB" default:"0" validate:"min=0"`
+		// Serve from the database instead of waiting on an unreachable Redis
+		FailFast bool `env:"REDIS_FAIL_FAST" default:"true"`
+	}
+
+	JWTSecret string        `env:"JWT_SECRET" default:"your-secret-key-change-in-production"`
+	JWTLeeway time.Duration `env:"JWT_LEEWAY" validate:"min=0s"`
+	// PasswordPeppers holds "version:secret" pairs; the highest version
+	// hashes new passwords and older ones are kept until rotated out
+	PasswordPeppers    string        `env:"PASSWORD_PEPPERS"`
+	UserCacheTTL       time.Duration `env:"USER_CACHE_TTL" validate:"min=0s"`
+	EmailStripPlusTags bool          `env:"EMAIL_STRIP_PLUS_TAGS" default:"false"`
+
+	GRPC struct {
+		Port int `env:"GRPC_PORT" default:"50051" validate:"port"`
+		// Plaintext gRPC is only allowed with GRPC_INSECURE=true
+		Transport grpcserverpkg.TransportConfig
+		Keepalive grpcserverpkg.KeepaliveConfig
+
+		MethodLimits         string `env:"GRPC_METHOD_LIMITS"`
+		MaxInFlight          int    `env:"GRPC_MAX_IN_FLIGHT" validate:"min=0"`
+		MaxConcurrentStreams int    `env:"GRPC_MAX_CONCURRENT_STREAMS" validate:"min=1"`
+		CompressMinSize      int    `env:"GRPC_COMPRESS_MIN_SIZE" validate:"min=0"`
+
+		ShutdownTimeout time.Duration `env:"GRPC_SHUTDOWN_TIMEOUT" validate:"min=0s"`
+	}
+
+	Pages struct {
+		MethodMax   string `env:"PAGE_SIZE_METHOD_MAX"`
+		DefaultSize int    `env:"PAGE_SIZE_DEFAULT" validate:"min=1"`
+		MaxSize     int    `env:"PAGE_SIZE_MAX" validate:"min=1"`
+	}
+
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" validate:"min=0s"`
+	ShutdownTimeout    time.Duration `env:"SHUTDOWN_TIMEOUT" validate:"min=0s"`
+}
+
+// Validate checks the settings that depend on each other
+func (c *serverConfig) Validate() error {
+	return config.CheckJWTSecret(c.Env, c.JWTSecret)
+}
+
+// loadConfig loads the configuration over the defaults defined in code
+func loadConfig() (*serverConfig, error) {
+	cfg := &serverConfig{
+		JWTLeeway:    middleware.DefaultJWTLeeway,
+		UserCacheTTL: service.DefaultUserCacheTTL,
+
+		ShutdownDrainDelay: shutdown.DefaultDrainDelay,
+		ShutdownTimeout:    shutdown.DefaultTimeout,
+	}
+
+	cfg.GRPC.Keepalive = grpcserverpkg.DefaultKeepaliveConfig()
+	cfg.GRPC.MaxInFlight = middleware.DefaultMaxInFlight
+	cfg.GRPC.MaxConcurrentStreams = grpcserverpkg.DefaultMaxConcurrentStreams
+	cfg.GRPC.CompressMinSize = middleware.DefaultCompressMinSize
+	cfg.GRPC.ShutdownTimeout = grpcserverpkg.DefaultShutdownTimeout
+
+	cfg.Pages.DefaultSize = pagination.DefaultPageSize
+	cfg.Pages.MaxSize = pagination.DefaultMaxPageSize
+
+	if err := config.Load(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
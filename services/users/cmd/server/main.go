@@ -2,17 +2,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
+	"github.com/mumumio1/coldy/pkg/cache"
 	"github.com/mumumio1/coldy/pkg/database"
+	grpcserverpkg "github.com/mumumio1/coldy/pkg/grpcserver"
 	"github.com/mumumio1/coldy/pkg/logger"
 	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/pagination"
+	"github.com/mumumio1/coldy/pkg/shutdown"
 	"github.com/mumumio1/coldy/pkg/telemetry"
 	usersv1 "github.com/mumumio1/coldy/proto/users/v1"
 	grpcserver "github.com/mumumio1/coldy/services/users/internal/grpc"
@@ -43,18 +46,26 @@ func run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Refuse to start on invalid configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
 	// Initialize logger
-	log, err := logger.NewLogger(serviceName, getEnv("ENV", "development"))
+	log, err := logger.NewLogger(serviceName, cfg.Env)
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	defer func() { _ = log.Sync() }()
 
+	// Background work started from ctx logs with the service logger
+	ctx = logger.WithLogger(ctx, log)
+
 	log.Info("starting users service", zap.String("version", version))
 
 	// Initialize tracing
-	tracingEndpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
-	shutdownTracer, err := telemetry.InitTracer(ctx, serviceName, version, tracingEndpoint)
+	shutdownTracer, err := telemetry.InitTracer(ctx, serviceName, version, cfg.TracingEndpoint)
 	if err != nil {
 		log.Warn("failed to initialize tracer", zap.Error(err))
 	} else {
@@ -66,12 +77,12 @@ func run() error {
 
 	// Initialize database
 	dbConfig := database.Config{
-		Host:            getEnv("DB_HOST", "localhost"),
+		Host:            cfg.DB.Host,
 		Port:            5432,
-		User:            getEnv("DB_USER", "coldy"),
-		Password:        getEnv("DB_PASSWORD", "coldy123"),
-		Database:        getEnv("DB_NAME", "coldy"),
-		SSLMode:         getEnv("DB_SSLMODE", "disable"),
+		User:            cfg.DB.User,
+		Password:        cfg.DB.Password,
+		Database:        cfg.DB.Name,
+		SSLMode:         cfg.DB.SSLMode,
 		MaxOpenConns:    25,
 		MaxIdleConns:    5,
 		ConnMaxLifetime: 5 * time.Minute,
@@ -84,24 +95,90 @@ func run() error {
 	}
 	defer func() { _ = db.Close() }()
 
+	// Initialize Redis cache
+	redisConfig := cache.Config{
+		Addr:         cfg.Redis.Addr,
+		Password:     cfg.Redis.Password,
+		DB:           cfg.Redis.DB,
+		PoolSize:     10,
+		MinIdleConns: 2,
+		DialTimeout:  5 * time.Second,
+		ReadTimeout:  3 * time.Second,
+		WriteTimeout: 3 * time.Second,
+		FailFast:     cfg.Redis.FailFast,
+	}
+
+	redisCache, err := cache.NewRedisCache(ctx, redisConfig, log)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	defer func() { _ = redisCache.Close() }()
+
 	// Initialize repository and services
 	userRepo := repository.NewUserRepository(db)
-	jwtSecret := getEnv("JWT_SECRET", "your-secret-key-change-in-production")
-	authService := service.NewAuthService(jwtSecret)
-	userService := service.NewUserService(userRepo, authService, log)
+	peppers, err := service.ParsePeppers(cfg.PasswordPeppers)
+	if err != nil {
+		return fmt.Errorf("invalid PASSWORD_PEPPERS: %w", err)
+	}
+	authService := service.NewAuthService(cfg.JWTSecret, peppers, cfg.JWTLeeway)
+	userService := service.NewUserService(userRepo, authService, redisCache,
+		cfg.UserCacheTTL, cfg.EmailStripPlusTags, log)
 
 	// Start gRPC server
-	grpcPort := getEnv("GRPC_PORT", "50051")
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	grpcServer := grpc.NewServer(
+	// Only access tokens are accepted; scopes gate the per-method permissions
+	authConfig := middleware.AuthConfig{
+		Validate: func(ctx context.Context, token string) (*middleware.AuthInfo, error) {
+			claims, err := authService.ValidateTokenForAudience(ctx, token, service.AudienceAccess)
+			if err != nil {
+				return nil, err
+			}
+			return &middleware.AuthInfo{UserID: claims.UserID, Scopes: claims.Scopes}, nil
+		},
+		// Probes and tooling call the infrastructure RPCs without a token
+		PublicMethods: append([]string{
+			usersv1.UserService_Register_FullMethodName,
+			usersv1.UserService_Login_FullMethodName,
+		}, middleware.InfrastructureMethods...),
+		MethodScopes: map[string]string{
+			usersv1.UserService_GetUser_FullMethodName:        service.ScopeUsersRead,
+			usersv1.UserService_GetMe_FullMethodName:          service.ScopeUsersRead,
+			usersv1.UserService_UpdateUser_FullMethodName:     service.ScopeUsersWrite,
+			usersv1.UserService_ListUsers_FullMethodName:      service.ScopeUsersAdmin,
+			usersv1.UserService_GetUserByEmail_FullMethodName: service.ScopeUsersAdmin,
+		},
+	}
+
+	serverCreds, err := cfg.GRPC.Transport.ServerCredentials()
+	if err != nil {
+		return err
+	}
+
+	// Shed load past these limits instead of letting it queue on the database pool
+	methodLimits, err := middleware.ParseMethodLimits(cfg.GRPC.MethodLimits)
+	if err != nil {
+		return fmt.Errorf("invalid GRPC_METHOD_LIMITS: %w", err)
+	}
+	concurrency := middleware.ConcurrencyConfig{
+		MaxInFlight:  cfg.GRPC.MaxInFlight,
+		MethodLimits: methodLimits,
+	}
+	grpcServer := grpcserverpkg.New(cfg.GRPC.Keepalive,
+		serverCreds,
+		grpc.MaxConcurrentStreams(uint32(cfg.GRPC.MaxConcurrentStreams)),
 		grpc.ChainUnaryInterceptor(
+			middleware.MetricsInterceptor(),
 			middleware.RecoveryInterceptor(log),
 			middleware.UnaryServerInterceptor(log),
+			middleware.CompressionInterceptor(cfg.GRPC.CompressMinSize),
+			middleware.ConcurrencyLimitInterceptor(concurrency),
 			middleware.TracingInterceptor(serviceName),
+			middleware.AuthInterceptor(authConfig),
+			middleware.ValidationInterceptor(nil),
 		),
 		grpc.ChainStreamInterceptor(
 			middleware.StreamServerInterceptor(log),
@@ -109,7 +186,17 @@ func run() error {
 	)
 
 	// Register services
-	usersv1.RegisterUserServiceServer(grpcServer, grpcserver.NewServer(userService, log))
+	pageSizes, err := pagination.ParseMethodMaxSize(cfg.Pages.MethodMax)
+	if err != nil {
+		return fmt.Errorf("invalid PAGE_SIZE_METHOD_MAX: %w", err)
+	}
+	pages := pagination.Config{
+		DefaultSize:   cfg.Pages.DefaultSize,
+		MaxSize:       cfg.Pages.MaxSize,
+		MethodMaxSize: pageSizes,
+	}
+
+	usersv1.RegisterUserServiceServer(grpcServer, grpcserver.NewServer(userService, pages, log))
 
 	// Register health check
 	healthServer := health.NewServer()
@@ -117,12 +204,11 @@ func run() error {
 	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_SERVING)
 
 	// Register reflection for development
-	if getEnv("ENV", "development") == "development" {
+	if cfg.Env == "development" {
 		reflection.Register(grpcServer)
 	}
 
 	// Start metrics server
-	metricsPort := getEnv("METRICS_PORT", "9090")
 	go func() {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
@@ -131,23 +217,27 @@ func run() error {
 			_, _ = w.Write([]byte("OK"))
 		})
 		mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
-			if err := database.HealthCheck(r.Context(), db); err != nil {
+			// The body reports pool saturation alongside the verdict
+			dbStatus, err := database.HealthCheckDetailed(r.Context(), db, database.DefaultHealthCheckTimeout)
+			w.Header().Set("Content-Type", "application/json")
+			if err != nil {
 				w.WriteHeader(http.StatusServiceUnavailable)
+				_ = json.NewEncoder(w).Encode(dbStatus)
 				return
 			}
 			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("READY"))
+			_ = json.NewEncoder(w).Encode(dbStatus)
 		})
 
-		log.Info("starting metrics server", zap.String("port", metricsPort))
-		if err := http.ListenAndServe(":"+metricsPort, mux); err != nil {
+		log.Info("starting metrics server", zap.Int("port", cfg.MetricsPort))
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", cfg.MetricsPort), mux); err != nil {
 			log.Error("metrics server failed", zap.Error(err))
 		}
 	}()
 
 	// Start gRPC server in goroutine
 	go func() {
-		log.Info("starting gRPC server", zap.String("port", grpcPort))
+		log.Info("starting gRPC server", zap.Int("port", cfg.GRPC.Port))
 		if err := grpcServer.Serve(lis); err != nil {
 			log.Error("gRPC server failed", zap.Error(err))
 		}
@@ -169,29 +259,24 @@ func run() error {
 		}
 	}()
 
-	// Wait for interrupt signal
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
-
-	log.Info("shutting down gracefully...")
-
-	// Graceful shutdown
-	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
-
-	// Give time for load balancers to remove this instance
-	time.Sleep(5 * time.Second)
-
-	// Stop accepting new connections
-	grpcServer.GracefulStop()
+	// Fail health checks, let load balancers drain, then stop the server
+	shutdowner := shutdown.New(shutdown.Config{
+		DrainDelay: cfg.ShutdownDrainDelay,
+		Timeout:    cfg.ShutdownTimeout,
+	}, log)
+	shutdowner.PreStop("health", func(ctx context.Context) error {
+		healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		return nil
+	})
+	shutdowner.OnShutdown("grpc", func(ctx context.Context) error {
+		// Stop forces remaining RPCs closed after its own timeout
+		grpcserverpkg.Stop(grpcServer, cfg.GRPC.ShutdownTimeout, log)
+		return nil
+	})
+	if err := shutdowner.Wait(ctx); err != nil {
+		log.Warn("shutdown did not complete cleanly", zap.Error(err))
+	}
 
 	log.Info("server stopped")
 	return nil
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
@@ -2,9 +2,14 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/pagination"
 	commonv1 "github.com/mumumio1/coldy/proto/common/v1"
 	usersv1 "github.com/mumumio1/coldy/proto/users/v1"
+	"github.com/mumumio1/coldy/services/users/internal/repository"
 	"github.com/mumumio1/coldy/services/users/internal/service"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -16,13 +21,15 @@ import (
 type Server struct {
 	usersv1.UnimplementedUserServiceServer
 	userService *service.UserService
+	pages       pagination.Config
 	logger      *zap.Logger
 }
 
 // NewServer creates a new gRPC server
-func NewServer(userService *service.UserService, logger *zap.Logger) *Server {
+func NewServer(userService *service.UserService, pages pagination.Config, logger *zap.Logger) *Server {
 	return &Server{
 		userService: userService,
+		pages:       pages,
 		logger:      logger,
 	}
 }
@@ -40,8 +47,11 @@ func (s *Server) Register(ctx context.Context, req *usersv1.RegisterRequest) (*u
 		req.FullName,
 		req.Phone,
 	)
+	if errors.Is(err, repository.ErrEmailExists) {
+		return nil, status.Error(codes.AlreadyExists, "user with this email already exists")
+	}
 	if err != nil {
-		s.logger.Error("failed to register user", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to register user", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to register user")
 	}
 
@@ -67,7 +77,7 @@ func (s *Server) Login(ctx context.Context, req *usersv1.LoginRequest) (*usersv1
 
 	user, accessToken, refreshToken, err := s.userService.Login(ctx, req.Email, req.Password)
 	if err != nil {
-		s.logger.Error("failed to login", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to login", zap.Error(err))
 		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
 	}
 
@@ -90,10 +100,13 @@ func (s *Server) GetUser(ctx context.Context, req *usersv1.GetUserRequest) (*use
 	if req.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
+	if err := authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
 
 	user, err := s.userService.GetUser(ctx, req.UserId)
 	if err != nil {
-		s.logger.Error("failed to get user", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to get user", zap.Error(err))
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
@@ -109,15 +122,90 @@ func (s *Server) GetUser(ctx context.Context, req *usersv1.GetUserRequest) (*use
 	}, nil
 }
 
+// GetMe retrieves the authenticated caller
+func (s *Server) GetMe(ctx context.Context, req *usersv1.GetMeRequest) (*usersv1.GetMeResponse, error) {
+	caller, ok := middleware.AuthFromContext(ctx)
+	if !ok || caller.UserID == "" {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	user, err := s.userService.GetUser(ctx, caller.UserID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to get user", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	return &usersv1.GetMeResponse{
+		User: &usersv1.User{
+			Id:        user.ID,
+			Email:     user.Email,
+			FullName:  user.FullName,
+			Phone:     user.Phone,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+			UpdatedAt: timestamppb.New(user.UpdatedAt),
+		},
+	}, nil
+}
+
+// authorizeUser allows the caller to access the record of userID if it is
+// their own or they hold the users admin scope
+func authorizeUser(ctx context.Context, userID string) error {
+	caller, ok := middleware.AuthFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if caller.UserID != userID && !caller.HasScope(service.ScopeUsersAdmin) {
+		return status.Error(codes.PermissionDenied, "cannot access another user")
+	}
+	return nil
+}
+
+// GetUserByEmail looks up a user by email for support
+func (s *Server) GetUserByEmail(ctx context.Context, req *usersv1.GetUserByEmailRequest) (*usersv1.GetUserByEmailResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	user, err := s.userService.GetUserByEmail(ctx, req.Email)
+	if errors.Is(err, service.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to get user by email", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	return &usersv1.GetUserByEmailResponse{
+		User: &usersv1.User{
+			Id:        user.ID,
+			Email:     user.Email,
+			FullName:  user.FullName,
+			Phone:     user.Phone,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+			UpdatedAt: timestamppb.New(user.UpdatedAt),
+		},
+	}, nil
+}
+
 // UpdateUser updates a user
 func (s *Server) UpdateUser(ctx context.Context, req *usersv1.UpdateUserRequest) (*usersv1.UpdateUserResponse, error) {
 	if req.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
+	if err := authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
 
-	user, err := s.userService.UpdateUser(ctx, req.UserId, req.FullName, req.Phone)
+	update := service.UserUpdate{FullName: req.FullName, Phone: req.Phone}
+	user, err := s.userService.UpdateUser(ctx, req.UserId, update, userUpdateMask(req))
+	if errors.Is(err, service.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if errors.Is(err, service.ErrInvalidUpdateMask) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
-		s.logger.Error("failed to update user", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to update user", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to update user")
 	}
 
@@ -133,19 +221,33 @@ func (s *Server) UpdateUser(ctx context.Context, req *usersv1.UpdateUserRequest)
 	}, nil
 }
 
+// userUpdateMask returns the fields an UpdateUser request sets. Requests
+// without a mask set their non-empty fields.
+func userUpdateMask(req *usersv1.UpdateUserRequest) []string {
+	if req.UpdateMask != nil {
+		return req.UpdateMask.Paths
+	}
+
+	var mask []string
+	if req.FullName != "" {
+		mask = append(mask, "full_name")
+	}
+	if req.Phone != "" {
+		mask = append(mask, "phone")
+	}
+	return mask
+}
+
 // ListUsers lists users with pagination
 func (s *Server) ListUsers(ctx context.Context, req *usersv1.ListUsersRequest) (*usersv1.ListUsersResponse, error) {
-	pageSize := int(req.Pagination.PageSize)
-	if pageSize <= 0 {
-		pageSize = 20
-	}
-	if pageSize > 100 {
-		pageSize = 100
+	pageSize, err := s.pages.Clamp(usersv1.UserService_ListUsers_FullMethodName, req.GetPagination().GetPageSize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	users, nextCursor, hasMore, err := s.userService.ListUsers(ctx, pageSize, req.Pagination.Cursor)
+	users, nextCursor, hasMore, err := s.userService.ListUsers(ctx, pageSize, req.GetPagination().GetCursor())
 	if err != nil {
-		s.logger.Error("failed to list users", zap.Error(err))
+		logger.FromContext(ctx).Error("failed to list users", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to list users")
 	}
 
@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mumumio1/coldy/pkg/middleware"
+	"github.com/mumumio1/coldy/pkg/pagination"
+	usersv1 "github.com/mumumio1/coldy/proto/users/v1"
+	"github.com/mumumio1/coldy/services/users/internal/repository/memory"
+	"github.com/mumumio1/coldy/services/users/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer(t *testing.T) (*Server, string, string) {
+	t.Helper()
+
+	auth := service.NewAuthService("test-secret", nil, 0)
+	users := service.NewUserService(memory.NewUserStore(), auth, nil, 0, false, zap.NewNop())
+	s := NewServer(users, pagination.Config{}, zap.NewNop())
+
+	ada, _, _, err := users.Register(context.Background(), "ada@example.com", "hunter22", "Ada", "")
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	bob, _, _, err := users.Register(context.Background(), "bob@example.com", "hunter22", "Bob", "")
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	return s, ada.ID, bob.ID
+}
+
+// asCaller runs call behind AuthInterceptor authenticated as caller, the way
+// the server chain would
+func asCaller(caller *middleware.AuthInfo, call func(ctx context.Context) error) error {
+	interceptor := middleware.AuthInterceptor(middleware.AuthConfig{
+		Validate: func(ctx context.Context, token string) (*middleware.AuthInfo, error) {
+			return caller, nil
+		},
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(middleware.AuthorizationHeader, "Bearer token"))
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, call(ctx)
+	})
+	return err
+}
+
+func TestGetMeReturnsCaller(t *testing.T) {
+	s, ada, _ := newTestServer(t)
+
+	err := asCaller(&middleware.AuthInfo{UserID: ada}, func(ctx context.Context) error {
+		resp, err := s.GetMe(ctx, &usersv1.GetMeRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.User.Id != ada || resp.User.Email != "ada@example.com" {
+			t.Errorf("GetMe returned %s (%s), want %s", resp.User.Id, resp.User.Email, ada)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GetMe failed: %v", err)
+	}
+
+	if _, err := s.GetMe(context.Background(), &usersv1.GetMeRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a caller, got %v", err)
+	}
+}
+
+func TestUserAccessIsRestrictedToCaller(t *testing.T) {
+	tests := map[string]struct {
+		caller func(ada string) *middleware.AuthInfo
+		target func(ada, bob string) string
+		want   codes.Code
+	}{
+		"own record": {
+			caller: func(ada string) *middleware.AuthInfo { return &middleware.AuthInfo{UserID: ada} },
+			target: func(ada, bob string) string { return ada },
+			want:   codes.OK,
+		},
+		"another user's record": {
+			caller: func(ada string) *middleware.AuthInfo { return &middleware.AuthInfo{UserID: ada} },
+			target: func(ada, bob string) string { return bob },
+			want:   codes.PermissionDenied,
+		},
+		"another user's record as admin": {
+			caller: func(ada string) *middleware.AuthInfo {
+				return &middleware.AuthInfo{UserID: ada, Scopes: []string{service.ScopeUsersAdmin}}
+			},
+			target: func(ada, bob string) string { return bob },
+			want:   codes.OK,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, ada, bob := newTestServer(t)
+			target := tt.target(ada, bob)
+
+			err := asCaller(tt.caller(ada), func(ctx context.Context) error {
+				_, err := s.GetUser(ctx, &usersv1.GetUserRequest{UserId: target})
+				return err
+			})
+			if status.Code(err) != tt.want {
+				t.Errorf("GetUser: expected %v, got %v", tt.want, err)
+			}
+
+			err = asCaller(tt.caller(ada), func(ctx context.Context) error {
+				_, err := s.UpdateUser(ctx, &usersv1.UpdateUserRequest{UserId: target, FullName: "Changed"})
+				return err
+			})
+			if status.Code(err) != tt.want {
+				t.Errorf("UpdateUser: expected %v, got %v", tt.want, err)
+			}
+
+			updated, err := s.userService.GetUser(context.Background(), target)
+			if err != nil {
+				t.Fatalf("GetUser failed: %v", err)
+			}
+			if changed := updated.FullName == "Changed"; changed != (tt.want == codes.OK) {
+				t.Errorf("full name = %q after an update that returned %v", updated.FullName, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeUserRequiresCaller(t *testing.T) {
+	if err := authorizeUser(context.Background(), "user-1"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a caller, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Package memory provides an in-memory UserStore for exercising the users
+// service without Postgres. It mirrors the SQL repository's observable
+// behavior: case-insensitive unique emails, a nil user for an unknown ID or
+// email, no password hash outside GetByEmail and oldest-first pagination.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mumumio1/coldy/services/users/internal/repository"
+	"github.com/mumumio1/coldy/services/users/internal/service"
+)
+
+var _ service.UserStore = (*UserStore)(nil)
+
+// UserStore keeps users in memory. It is safe for concurrent use.
+type UserStore struct {
+	mu    sync.Mutex
+	users map[string]*repository.User
+	last  time.Time
+}
+
+// NewUserStore creates an empty store
+func NewUserStore() *UserStore {
+	return &UserStore{users: make(map[string]*repository.User)}
+}
+
+// now returns a strictly increasing timestamp so ordering behaves as it does
+// against the database
+func (s *UserStore) now() time.Time {
+	t := time.Now().UTC()
+	if !t.After(s.last) {
+		t = s.last.Add(time.Microsecond)
+	}
+	s.last = t
+	return t
+}
+
+// Create stores user, assigning its ID and timestamps
+func (s *UserStore) Create(ctx context.Context, user *repository.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.users {
+		if strings.EqualFold(existing.Email, user.Email) {
+			return fmt.Errorf("%w: %s", repository.ErrEmailExists, user.Email)
+		}
+	}
+
+	user.ID = uuid.New().String()
+	user.CreatedAt = s.now()
+	user.UpdatedAt = user.CreatedAt
+	stored := *user
+	s.users[user.ID] = &stored
+	return nil
+}
+
+// GetByID returns a copy of the user without its password hash, or nil
+func (s *UserStore) GetByID(ctx context.Context, id string) (*repository.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return profile(user), nil
+}
+
+// GetByEmail returns a copy of the user with email, ignoring case, or nil
+func (s *UserStore) GetByEmail(ctx context.Context, email string) (*repository.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, user := range s.users {
+		if strings.EqualFold(user.Email, email) {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+// Update stores the user's name and phone
+func (s *UserStore) Update(ctx context.Context, user *repository.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.users[user.ID]
+	if !ok {
+		return fmt.Errorf("failed to update user: user %s not found", user.ID)
+	}
+	stored.FullName = user.FullName
+	stored.Phone = user.Phone
+	stored.UpdatedAt = s.now()
+	user.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// UpdatePasswordHash replaces a user's password hash
+func (s *UserStore) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.users[id]; ok {
+		stored.PasswordHash = passwordHash
+		stored.UpdatedAt = s.now()
+	}
+	return nil
+}
+
+// List returns up to limit users created after the cursor user, oldest first
+func (s *UserStore) List(ctx context.Context, limit int, cursor string) ([]*repository.User, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]*repository.User, 0, len(s.users))
+	for _, user := range s.users {
+		all = append(all, user)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].CreatedAt.Equal(all[j].CreatedAt) {
+			return all[i].CreatedAt.Before(all[j].CreatedAt)
+		}
+		return all[i].ID < all[j].ID
+	})
+
+	var users []*repository.User
+	after := cursor == ""
+	for _, user := range all {
+		if !after {
+			after = user.ID == cursor
+			continue
+		}
+		users = append(users, profile(user))
+	}
+
+	var nextCursor string
+	if len(users) > limit {
+		nextCursor = users[limit-1].ID
+		users = users[:limit]
+	}
+	return users, nextCursor, nil
+}
+
+// PasswordHash returns the stored password hash of a user, for tests checking
+// a rehash
+func (s *UserStore) PasswordHash(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, ok := s.users[id]; ok {
+		return user.PasswordHash
+	}
+	return ""
+}
+
+// profile copies user without its password hash, as the SQL repository's
+// profile queries return it
+func profile(user *repository.User) *repository.User {
+	copied := *user
+	copied.PasswordHash = ""
+	return &copied
+}
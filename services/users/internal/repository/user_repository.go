@@ -3,12 +3,19 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const uniqueViolation = "23505"
+
+// ErrEmailExists is returned when a user with the same email already exists
+var ErrEmailExists = errors.New("email already exists")
+
 // User represents a user entity
 type User struct {
 	ID           string
@@ -62,6 +69,12 @@ func (r *UserRepository) Create(ctx context.Context, user *User) error {
 		user.Phone,
 	).Scan(&user.CreatedAt, &user.UpdatedAt)
 
+	// The unique indexes settle concurrent registrations that both passed the
+	// service's existence check, including ones differing only by case
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && isEmailConstraint(pqErr.Constraint) {
+		return fmt.Errorf("%w: %s", ErrEmailExists, user.Email)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -69,25 +82,20 @@ func (r *UserRepository) Create(ctx context.Context, user *User) error {
 	return nil
 }
 
-// GetByID retrieves a user by ID
+// isEmailConstraint reports whether constraint enforces email uniqueness
+func isEmailConstraint(constraint string) bool {
+	return constraint == "users_email_key" || constraint == "idx_users_email_lower"
+}
+
+// GetByID retrieves a user by ID. PasswordHash is not loaded.
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*User, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, phone, created_at, updated_at
+		SELECT ` + profileColumns + `
 		FROM users
 		WHERE id = $1
 	`
 
-	var user User
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FullName,
-		&user.Phone,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanProfile(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -95,15 +103,17 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*User, error)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
-// GetByEmail retrieves a user by email
+// GetByEmail retrieves a user by email, including PasswordHash for
+// authentication. The match ignores case and is served by the unique index on
+// LOWER(email), so at most one account matches.
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
 	query := `
 		SELECT id, email, password_hash, full_name, phone, created_at, updated_at
 		FROM users
-		WHERE email = $1
+		WHERE LOWER(email) = LOWER($1)
 	`
 
 	var user User
@@ -127,6 +137,67 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*User, e
 	return &user, nil
 }
 
+// profileColumns is the user projection for non-auth paths; it never
+// includes password_hash
+const profileColumns = `id, email, full_name, phone, created_at, updated_at`
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanProfile scans a row selected with profileColumns
+func scanProfile(row rowScanner) (*User, error) {
+	var user User
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.FullName,
+		&user.Phone,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByIDs retrieves several users in one query, keyed by ID.
+// Missing users are absent from the result and PasswordHash is never loaded.
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + profileColumns + `
+		FROM users
+		WHERE id = ANY($1)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	users := make(map[string]*User, len(ids))
+	for rows.Next() {
+		user, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users[user.ID] = user
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return users, nil
+}
+
 // Update updates a user
 func (r *UserRepository) Update(ctx context.Context, user *User) error {
 	query := `
@@ -149,10 +220,25 @@ func (r *UserRepository) Update(ctx context.Context, user *User) error {
 	return nil
 }
 
-// List retrieves users with pagination
+// UpdatePasswordHash replaces a user's password hash
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	query := `
+		UPDATE users
+		SET password_hash = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, passwordHash, id); err != nil {
+		return fmt.Errorf("failed to update password hash: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves users with pagination. PasswordHash is not loaded.
 func (r *UserRepository) List(ctx context.Context, limit int, cursor string) ([]*User, string, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, phone, created_at, updated_at
+		SELECT ` + profileColumns + `
 		FROM users
 		WHERE ($1 = '' OR (created_at, id) > (
 			SELECT created_at, id FROM users WHERE id = $1
@@ -169,20 +255,11 @@ func (r *UserRepository) List(ctx context.Context, limit int, cursor string) ([]
 
 	var users []*User
 	for rows.Next() {
-		var user User
-		err := rows.Scan(
-			&user.ID,
-			&user.Email,
-			&user.PasswordHash,
-			&user.FullName,
-			&user.Phone,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
+		user, err := scanProfile(rows)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan user: %w", err)
 		}
-		users = append(users, &user)
+		users = append(users, user)
 	}
 
 	if err := rows.Err(); err != nil {
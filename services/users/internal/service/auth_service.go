@@ -3,80 +3,125 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/mumumio1/coldy/pkg/errs"
 	"golang.org/x/crypto/bcrypt"
 )
 
 const (
 	AccessTokenExpiry  = 15 * time.Minute
 	RefreshTokenExpiry = 7 * 24 * time.Hour
+
+	// Token audiences
+	AudienceAccess  = "coldy-access"
+	AudienceRefresh = "coldy-refresh"
+
+	tokenIssuer = "coldy-users"
+)
+
+// Scopes granted to user access tokens
+const (
+	ScopeUsersRead  = "users:read"
+	ScopeUsersWrite = "users:write"
+	ScopeUsersAdmin = "users:admin"
 )
 
+// DefaultScopes are granted to access tokens issued at register and login
+var DefaultScopes = []string{ScopeUsersRead, ScopeUsersWrite}
+
+// ErrInvalidToken is returned when a token fails validation
+var ErrInvalidToken = errs.Unauthenticated("INVALID_TOKEN", "invalid token")
+
 // AuthService handles authentication logic
 type AuthService struct {
 	jwtSecret []byte
+	peppers   Peppers
+	// leeway tolerates clock drift when checking exp, nbf and iat
+	leeway time.Duration
 }
 
-// NewAuthService creates a new auth service
-func NewAuthService(jwtSecret string) *AuthService {
+// NewAuthService creates a new auth service. Passwords are HMACed with the
+// newest of peppers before hashing; with no peppers they are hashed as is.
+func NewAuthService(jwtSecret string, peppers Peppers, leeway time.Duration) *AuthService {
 	return &AuthService{
 		jwtSecret: []byte(jwtSecret),
+		peppers:   peppers,
+		leeway:    leeway,
 	}
 }
 
-// Claims represents JWT claims
+// Claims represents JWT claims. The token audience is the registered "aud" claim.
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID string   `json:"user_id"`
+	Email  string   `json:"email"`
+	Scopes []string `json:"scopes,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// HashPassword hashes a password using bcrypt
+// HasScope reports whether the claims grant scope
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// HashPassword hashes a password using bcrypt and the current pepper
 func (s *AuthService) HashPassword(ctx context.Context, password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	version := s.peppers.current()
+	input, err := s.peppers.apply(password, version)
+	if err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword(input, bcrypt.DefaultCost)
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
-	return string(hash), nil
+	return encodeHash(hash, version), nil
 }
 
-// VerifyPassword verifies a password against its hash
+// VerifyPassword verifies a password against its hash, using the pepper
+// version the hash was made with
 func (s *AuthService) VerifyPassword(ctx context.Context, password, hash string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-}
-
-// GenerateAccessToken generates an access token
-func (s *AuthService) GenerateAccessToken(ctx context.Context, userID, email string) (string, error) {
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "coldy-users",
-		},
+	version, bcryptHash, err := decodeHash(hash)
+	if err != nil {
+		return err
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.jwtSecret)
+	input, err := s.peppers.apply(password, version)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
+		return err
 	}
+	return bcrypt.CompareHashAndPassword(bcryptHash, input)
+}
 
-	return tokenString, nil
+// NeedsRehash reports whether hash was made with a pepper other than the
+// current one, so it should be replaced after the next successful login
+func (s *AuthService) NeedsRehash(hash string) bool {
+	version, _, err := decodeHash(hash)
+	return err == nil && version != s.peppers.current()
 }
 
-// GenerateRefreshToken generates a refresh token
+// GenerateAccessToken generates an access token granting scopes
+func (s *AuthService) GenerateAccessToken(ctx context.Context, userID, email string, scopes ...string) (string, error) {
+	return s.signToken(userID, email, AudienceAccess, scopes, AccessTokenExpiry)
+}
+
+// GenerateRefreshToken generates a refresh token; refresh tokens carry no scopes
 func (s *AuthService) GenerateRefreshToken(ctx context.Context, userID, email string) (string, error) {
+	return s.signToken(userID, email, AudienceRefresh, nil, RefreshTokenExpiry)
+}
+
+func (s *AuthService) signToken(userID, email, audience string, scopes []string, expiry time.Duration) (string, error) {
+	now := time.Now()
 	claims := &Claims{
 		UserID: userID,
 		Email:  email,
+		Scopes: scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "coldy-users",
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    tokenIssuer,
+			Audience:  jwt.ClaimStrings{audience},
 		},
 	}
 
@@ -89,22 +134,40 @@ func (s *AuthService) GenerateRefreshToken(ctx context.Context, userID, email st
 	return tokenString, nil
 }
 
-// ValidateToken validates a JWT token
+// ValidateToken validates a JWT token of any audience. Tokens must be issued
+// by this service and carry exp, iat and a user ID.
 func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*Claims, error) {
+	return s.parseToken(tokenString)
+}
+
+// ValidateTokenForAudience validates a JWT token and rejects it unless it was
+// issued for expectedAudience, so a refresh token cannot be used as an access token
+func (s *AuthService) ValidateTokenForAudience(ctx context.Context, tokenString, expectedAudience string) (*Claims, error) {
+	return s.parseToken(tokenString, jwt.WithAudience(expectedAudience))
+}
+
+func (s *AuthService) parseToken(tokenString string, opts ...jwt.ParserOption) (*Claims, error) {
+	opts = append(opts,
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(s.leeway),
+	)
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return s.jwtSecret, nil
-	})
+	}, opts...)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+	// WithIssuedAt only checks an iat that is present
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid && claims.IssuedAt != nil && claims.UserID != "" {
 		return claims, nil
 	}
 
-	return nil, fmt.Errorf("invalid token")
+	return nil, ErrInvalidToken
 }
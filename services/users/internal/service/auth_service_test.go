@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func signClaims(t *testing.T, claims *Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func accessClaims(now time.Time) *Claims {
+	return &Claims{
+		UserID: "user-1",
+		Email:  "ada@example.com",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Audience:  jwt.ClaimStrings{AudienceAccess},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenExpiry)),
+		},
+	}
+}
+
+func TestGeneratedTokensValidate(t *testing.T) {
+	ctx := context.Background()
+	auth := NewAuthService("secret", nil, 0)
+
+	access, err := auth.GenerateAccessToken(ctx, "user-1", "ada@example.com", ScopeUsersRead)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken failed: %v", err)
+	}
+	claims, err := auth.ValidateTokenForAudience(ctx, access, AudienceAccess)
+	if err != nil {
+		t.Fatalf("ValidateTokenForAudience failed: %v", err)
+	}
+	if claims.UserID != "user-1" || !claims.HasScope(ScopeUsersRead) {
+		t.Errorf("claims = %+v, want user-1 with %s", claims, ScopeUsersRead)
+	}
+
+	refresh, err := auth.GenerateRefreshToken(ctx, "user-1", "ada@example.com")
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken failed: %v", err)
+	}
+	if _, err := auth.ValidateTokenForAudience(ctx, refresh, AudienceAccess); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("a refresh token passed as an access token: %v", err)
+	}
+}
+
+func TestValidateTokenRejectsInvalidClaims(t *testing.T) {
+	now := time.Now()
+	tests := map[string]func(*Claims){
+		"expired beyond leeway": func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute)) },
+		"no exp":                func(c *Claims) { c.ExpiresAt = nil },
+		"no iat":                func(c *Claims) { c.IssuedAt = nil },
+		"no user ID":            func(c *Claims) { c.UserID = "" },
+		"other issuer":          func(c *Claims) { c.Issuer = "someone-else" },
+	}
+
+	auth := NewAuthService("secret", nil, 30*time.Second)
+	for name, adjust := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims := accessClaims(now)
+			adjust(claims)
+			if _, err := auth.ValidateToken(context.Background(), signClaims(t, claims)); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateTokenLeeway(t *testing.T) {
+	claims := accessClaims(time.Now())
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-10 * time.Second))
+	token := signClaims(t, claims)
+
+	if _, err := NewAuthService("secret", nil, 30*time.Second).ValidateToken(context.Background(), token); err != nil {
+		t.Errorf("token expired 10s ago rejected with 30s leeway: %v", err)
+	}
+	if _, err := NewAuthService("secret", nil, 0).ValidateToken(context.Background(), token); err == nil {
+		t.Error("token expired 10s ago accepted without leeway")
+	}
+}
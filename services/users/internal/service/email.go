@@ -0,0 +1,25 @@
+package service
+
+import "strings"
+
+// NormalizeEmail lowercases and trims an email address so the same address
+// always maps to one account. With stripPlusTag the "+tag" suffix of the
+// local part is dropped too, so "user+shop@x.com" and "user@x.com" are the
+// same account; accounts registered with a tag before it was enabled can then
+// only log in without the tag if their stored address is updated.
+func NormalizeEmail(email string, stripPlusTag bool) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !stripPlusTag {
+		return email
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at:]
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	return local + domain
+}
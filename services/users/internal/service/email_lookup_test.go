@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mumumio1/coldy/services/users/internal/repository"
+	"github.com/mumumio1/coldy/services/users/internal/repository/memory"
+	"github.com/mumumio1/coldy/services/users/internal/service"
+)
+
+func TestRegisterNormalizesEmail(t *testing.T) {
+	ctx := context.Background()
+	s := newUserService(memory.NewUserStore(), nil, true)
+
+	user, _, _, err := s.Register(ctx, " Ada+Shop@Example.com ", "hunter22", "Ada", "")
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if user.Email != "ada@example.com" {
+		t.Errorf("registered email = %q, want ada@example.com", user.Email)
+	}
+
+	// The same address in another form is the same account
+	if _, _, _, err := s.Register(ctx, "ADA@example.com", "hunter22", "Ada", ""); !errors.Is(err, repository.ErrEmailExists) {
+		t.Errorf("expected ErrEmailExists for a differently written address, got %v", err)
+	}
+	if _, _, _, err := s.Login(ctx, "ada+other@EXAMPLE.com", "hunter22"); err != nil {
+		t.Errorf("Login with another form of the address failed: %v", err)
+	}
+}
+
+func TestGetUserByEmail(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewUserStore()
+	seedUser(t, store, nil, "ada@example.com", "hunter22")
+	s := newUserService(store, nil, true)
+
+	user, err := s.GetUserByEmail(ctx, "Ada+Support@Example.com")
+	if err != nil {
+		t.Fatalf("GetUserByEmail failed: %v", err)
+	}
+	if user.Email != "ada@example.com" {
+		t.Errorf("found %q, want ada@example.com", user.Email)
+	}
+	if user.PasswordHash != "" {
+		t.Error("GetUserByEmail returned the password hash")
+	}
+
+	if _, err := s.GetUserByEmail(ctx, "grace@example.com"); !errors.Is(err, service.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email        string
+		stripPlusTag bool
+		want         string
+	}{
+		{"  Ada@Example.COM ", false, "ada@example.com"},
+		{"ada+shop@example.com", false, "ada+shop@example.com"},
+		{"Ada+Shop@Example.com", true, "ada@example.com"},
+		{"ada+a+b@example.com", true, "ada@example.com"},
+		// A leading plus is the whole local part, not a tag
+		{"+ada@example.com", true, "+ada@example.com"},
+		// Only the local part loses its tag
+		{"ada@mail+relay.example.com", true, "ada@mail+relay.example.com"},
+		{"not-an-email+tag", true, "not-an-email+tag"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeEmail(tt.email, tt.stripPlusTag); got != tt.want {
+			t.Errorf("NormalizeEmail(%q, %v) = %q, want %q", tt.email, tt.stripPlusTag, got, tt.want)
+		}
+	}
+}
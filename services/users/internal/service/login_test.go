@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mumumio1/coldy/services/users/internal/repository"
+	"github.com/mumumio1/coldy/services/users/internal/repository/memory"
+	"github.com/mumumio1/coldy/services/users/internal/service"
+	"go.uber.org/zap"
+)
+
+func newUserService(store *memory.UserStore, peppers service.Peppers, stripPlusTags bool) *service.UserService {
+	auth := service.NewAuthService("test-secret", peppers, 0)
+	return service.NewUserService(store, auth, nil, 0, stripPlusTags, zap.NewNop())
+}
+
+// seedUser stores a user whose password was hashed under peppers
+func seedUser(t *testing.T, store *memory.UserStore, peppers service.Peppers, email, password string) *repository.User {
+	t.Helper()
+
+	hash, err := service.NewAuthService("test-secret", peppers, 0).HashPassword(context.Background(), password)
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	user := &repository.User{Email: email, PasswordHash: hash}
+	if err := store.Create(context.Background(), user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	return user
+}
+
+func TestLoginRehashesToCurrentPepper(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewUserStore()
+	user := seedUser(t, store, service.Peppers{1: "old"}, "ada@example.com", "hunter22")
+
+	s := newUserService(store, service.Peppers{1: "old", 2: "new"}, false)
+	if _, _, _, err := s.Login(ctx, "ada@example.com", "hunter22"); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	hash := store.PasswordHash(user.ID)
+	if !strings.HasPrefix(hash, "pv2:") {
+		t.Fatalf("stored hash %q, want it rehashed with pepper version 2", hash)
+	}
+
+	// Version 1 can now be retired without locking the user out
+	retired := newUserService(store, service.Peppers{2: "new"}, false)
+	if _, _, _, err := retired.Login(ctx, "ada@example.com", "hunter22"); err != nil {
+		t.Errorf("Login after retiring the old pepper failed: %v", err)
+	}
+}
+
+func TestLoginKeepsCurrentHash(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewUserStore()
+	peppers := service.Peppers{1: "only"}
+	user := seedUser(t, store, peppers, "ada@example.com", "hunter22")
+	before := store.PasswordHash(user.ID)
+
+	if _, _, _, err := newUserService(store, peppers, false).Login(ctx, "ada@example.com", "hunter22"); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if after := store.PasswordHash(user.ID); after != before {
+		t.Error("a hash with the current pepper was rewritten")
+	}
+}
+
+func TestLoginWrongPasswordDoesNotRehash(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewUserStore()
+	user := seedUser(t, store, nil, "ada@example.com", "hunter22")
+	before := store.PasswordHash(user.ID)
+
+	s := newUserService(store, service.Peppers{1: "new"}, false)
+	if _, _, _, err := s.Login(ctx, "ada@example.com", "wrong"); err == nil {
+		t.Fatal("Login accepted the wrong password")
+	}
+	if after := store.PasswordHash(user.ID); after != before {
+		t.Error("a failed login rewrote the hash")
+	}
+}
+
+func TestLoginResultHasNoPasswordHash(t *testing.T) {
+	store := memory.NewUserStore()
+	seedUser(t, store, nil, "ada@example.com", "hunter22")
+
+	user, _, _, err := newUserService(store, nil, false).Login(context.Background(), "ada@example.com", "hunter22")
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if user.PasswordHash != "" {
+		t.Error("Login returned the password hash")
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParsePeppers(t *testing.T) {
+	peppers, err := ParsePeppers(" 2:new-secret , 1:old:secret ")
+	if err != nil {
+		t.Fatalf("ParsePeppers failed: %v", err)
+	}
+	if peppers[2] != "new-secret" || peppers[1] != "old:secret" {
+		t.Errorf("peppers = %v, want versions 1 and 2", peppers)
+	}
+	if peppers.current() != 2 {
+		t.Errorf("current version = %d, want 2", peppers.current())
+	}
+
+	empty, err := ParsePeppers("")
+	if err != nil || len(empty) != 0 || empty.current() != 0 {
+		t.Errorf("ParsePeppers(\"\") = %v, %v, want no pepper", empty, err)
+	}
+}
+
+func TestParsePeppersRejectsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no secret":         "1:",
+		"no separator":      "secret",
+		"zero version":      "0:secret",
+		"negative version":  "-1:secret",
+		"duplicate version": "1:a,1:b",
+	}
+
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParsePeppers(spec); err == nil {
+				t.Errorf("ParsePeppers(%q) succeeded, want an error", spec)
+			}
+		})
+	}
+}
+
+func TestParsePeppersErrorHidesSecret(t *testing.T) {
+	_, err := ParsePeppers("topsecret")
+	if err == nil || strings.Contains(err.Error(), "topsecret") {
+		t.Errorf("error %v, want one that does not echo the secret", err)
+	}
+}
+
+func TestHashPasswordUsesCurrentPepper(t *testing.T) {
+	ctx := context.Background()
+	auth := NewAuthService("secret", Peppers{1: "old", 2: "new"}, 0)
+
+	hash, err := auth.HashPassword(ctx, "hunter22")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	if !strings.HasPrefix(hash, "pv2:") {
+		t.Errorf("hash %q, want it made with pepper version 2", hash)
+	}
+	if err := auth.VerifyPassword(ctx, "hunter22", hash); err != nil {
+		t.Errorf("VerifyPassword failed: %v", err)
+	}
+	if err := auth.VerifyPassword(ctx, "hunter23", hash); err == nil {
+		t.Error("VerifyPassword accepted the wrong password")
+	}
+	if auth.NeedsRehash(hash) {
+		t.Error("a hash with the current pepper needs no rehash")
+	}
+}
+
+func TestVerifyPasswordAcrossPepperVersions(t *testing.T) {
+	ctx := context.Background()
+
+	legacy, err := NewAuthService("secret", nil, 0).HashPassword(ctx, "hunter22")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	old, err := NewAuthService("secret", Peppers{1: "old"}, 0).HashPassword(ctx, "hunter22")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+
+	rotated := NewAuthService("secret", Peppers{1: "old", 2: "new"}, 0)
+	for name, hash := range map[string]string{"unpeppered": legacy, "version 1": old} {
+		t.Run(name, func(t *testing.T) {
+			if err := rotated.VerifyPassword(ctx, "hunter22", hash); err != nil {
+				t.Errorf("VerifyPassword failed: %v", err)
+			}
+			if !rotated.NeedsRehash(hash) {
+				t.Error("a hash with an older pepper needs a rehash")
+			}
+		})
+	}
+
+	// Dropping version 1 before its hashes are rotated locks them out
+	dropped := NewAuthService("secret", Peppers{2: "new"}, 0)
+	if err := dropped.VerifyPassword(ctx, "hunter22", old); !errors.Is(err, ErrUnknownPepper) {
+		t.Errorf("expected ErrUnknownPepper, got %v", err)
+	}
+}
+
+func TestDecodeHash(t *testing.T) {
+	tests := map[string]struct {
+		stored      string
+		wantVersion int
+		wantHash    string
+		wantErr     bool
+	}{
+		"unpeppered":       {stored: "$2a$10$abc", wantHash: "$2a$10$abc"},
+		"peppered":         {stored: "pv3:$2a$10$abc", wantVersion: 3, wantHash: "$2a$10$abc"},
+		"missing hash":     {stored: "pv3", wantErr: true},
+		"bad version":      {stored: "pvx:$2a$10$abc", wantErr: true},
+		"zero version":     {stored: "pv0:$2a$10$abc", wantErr: true},
+		"negative version": {stored: "pv-1:$2a$10$abc", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, hash, err := decodeHash(tt.stored)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("decodeHash(%q) succeeded, want an error", tt.stored)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeHash failed: %v", err)
+			}
+			if version != tt.wantVersion || string(hash) != tt.wantHash {
+				t.Errorf("decodeHash = %d %q, want %d %q", version, hash, tt.wantVersion, tt.wantHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mumumio1/coldy/services/users/internal/repository"
+)
+
+// UserStore persists users. *repository.UserRepository implements it;
+// memory.UserStore is an in-memory stand-in.
+type UserStore interface {
+	Create(ctx context.Context, user *repository.User) error
+	GetByID(ctx context.Context, id string) (*repository.User, error)
+	GetByEmail(ctx context.Context, email string) (*repository.User, error)
+	Update(ctx context.Context, user *repository.User) error
+	UpdatePasswordHash(ctx context.Context, id, passwordHash string) error
+	List(ctx context.Context, limit int, cursor string) ([]*repository.User, string, error)
+}
+
+var _ UserStore = (*repository.UserRepository)(nil)
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mumumio1/coldy/pkg/logger"
+	"github.com/mumumio1/coldy/services/users/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	userCacheKeyPrefix = "user:"
+	// DefaultUserCacheTTL bounds how stale a cached profile can get if an
+	// invalidation is missed
+	DefaultUserCacheTTL = 5 * time.Minute
+)
+
+// cachedUser is the cached projection of a user; it has no password hash so
+// one can never be written to Redis
+type cachedUser struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	FullName  string    `json:"full_name"`
+	Phone     string    `json:"phone"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toCachedUser(user *repository.User) cachedUser {
+	return cachedUser{
+		ID:        user.ID,
+		Email:     user.Email,
+		FullName:  user.FullName,
+		Phone:     user.Phone,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+func (c cachedUser) toUser() *repository.User {
+	return &repository.User{
+		ID:        c.ID,
+		Email:     c.Email,
+		FullName:  c.FullName,
+		Phone:     c.Phone,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// invalidateUser drops a user's cached profile. Failures are logged; the TTL
+// bounds how long the stale entry can be served.
+func (s *UserService) invalidateUser(ctx context.Context, userID string) {
+	if s.users == nil {
+		return
+	}
+	if err := s.users.Delete(ctx, userID); err != nil {
+		logger.FromContext(ctx).Warn("failed to invalidate user cache",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+	}
+}
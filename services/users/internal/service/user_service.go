@@ -2,37 +2,73 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/mumumio1/coldy/pkg/cache"
+	"github.com/mumumio1/coldy/pkg/logger"
 	"github.com/mumumio1/coldy/services/users/internal/repository"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrUserNotFound is returned when a user does not exist
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidUpdateMask is returned when an update mask names a field that
+	// cannot be updated
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+)
+
+// UserUpdate holds the new values of the fields named in an update mask
+type UserUpdate struct {
+	FullName string
+	Phone    string
+}
+
 // UserService handles user business logic
 type UserService struct {
-	repo        *repository.UserRepository
+	repo        UserStore
 	authService *AuthService
-	logger      *zap.Logger
+	// users is nil without a cache
+	users    *cache.Typed[cachedUser]
+	cacheTTL time.Duration
+	// stripPlusTags drops "+tag" from emails when normalizing them
+	stripPlusTags bool
+	logger        *zap.Logger
 }
 
-// NewUserService creates a new user service
-func NewUserService(repo *repository.UserRepository, authService *AuthService, logger *zap.Logger) *UserService {
-	return &UserService{
-		repo:        repo,
-		authService: authService,
-		logger:      logger,
+// NewUserService creates a new user service. GetUser reads through
+// redisCache, keeping profiles for cacheTTL. redisCache may be nil, e.g. in
+// tests, in which case every read goes to repo.
+func NewUserService(repo UserStore, authService *AuthService, redisCache *cache.RedisCache, cacheTTL time.Duration, stripPlusTags bool, logger *zap.Logger) *UserService {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultUserCacheTTL
 	}
+	s := &UserService{
+		repo:          repo,
+		authService:   authService,
+		cacheTTL:      cacheTTL,
+		stripPlusTags: stripPlusTags,
+		logger:        logger,
+	}
+	if redisCache != nil {
+		s.users = cache.NewTyped[cachedUser](redisCache, userCacheKeyPrefix)
+	}
+	return s
 }
 
 // Register registers a new user
 func (s *UserService) Register(ctx context.Context, email, password, fullName, phone string) (*repository.User, string, string, error) {
+	email = NormalizeEmail(email, s.stripPlusTags)
+
 	// Check if user exists
 	existing, err := s.repo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, "", "", fmt.Errorf("failed to check existing user: %w", err)
 	}
 	if existing != nil {
-		return nil, "", "", fmt.Errorf("user with email %s already exists", email)
+		return nil, "", "", fmt.Errorf("%w: %s", repository.ErrEmailExists, email)
 	}
 
 	// Hash password
@@ -50,11 +86,12 @@ func (s *UserService) Register(ctx context.Context, email, password, fullName, p
 	}
 
 	if err := s.repo.Create(ctx, user); err != nil {
-		return nil, "", "", fmt.Errorf("failed to create user: %w", err)
+		return nil, "", "", err
 	}
+	user.PasswordHash = ""
 
 	// Generate tokens
-	accessToken, err := s.authService.GenerateAccessToken(ctx, user.ID, user.Email)
+	accessToken, err := s.authService.GenerateAccessToken(ctx, user.ID, user.Email, DefaultScopes...)
 	if err != nil {
 		return nil, "", "", fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -64,7 +101,7 @@ func (s *UserService) Register(ctx context.Context, email, password, fullName, p
 		return nil, "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	s.logger.Info("user registered",
+	logger.FromContext(ctx).Info("user registered",
 		zap.String("user_id", user.ID),
 		zap.String("email", user.Email),
 	)
@@ -74,6 +111,8 @@ func (s *UserService) Register(ctx context.Context, email, password, fullName, p
 
 // Login authenticates a user
 func (s *UserService) Login(ctx context.Context, email, password string) (*repository.User, string, string, error) {
+	email = NormalizeEmail(email, s.stripPlusTags)
+
 	// Get user by email
 	user, err := s.repo.GetByEmail(ctx, email)
 	if err != nil {
@@ -88,8 +127,17 @@ func (s *UserService) Login(ctx context.Context, email, password string) (*repos
 		return nil, "", "", fmt.Errorf("invalid credentials")
 	}
 
+	// Move the hash to the current pepper while the password is at hand. A
+	// failure only delays the rotation to a later login.
+	if s.authService.NeedsRehash(user.PasswordHash) {
+		s.rehashPassword(ctx, user.ID, password)
+	}
+
+	// The hash is only needed for verification; don't hand it to callers
+	user.PasswordHash = ""
+
 	// Generate tokens
-	accessToken, err := s.authService.GenerateAccessToken(ctx, user.ID, user.Email)
+	accessToken, err := s.authService.GenerateAccessToken(ctx, user.ID, user.Email, DefaultScopes...)
 	if err != nil {
 		return nil, "", "", fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -99,7 +147,7 @@ func (s *UserService) Login(ctx context.Context, email, password string) (*repos
 		return nil, "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	s.logger.Info("user logged in",
+	logger.FromContext(ctx).Info("user logged in",
 		zap.String("user_id", user.ID),
 		zap.String("email", user.Email),
 	)
@@ -107,36 +155,95 @@ func (s *UserService) Login(ctx context.Context, email, password string) (*repos
 	return user, accessToken, refreshToken, nil
 }
 
-// GetUser retrieves a user by ID
+func (s *UserService) rehashPassword(ctx context.Context, userID, password string) {
+	hash, err := s.authService.HashPassword(ctx, password)
+	if err == nil {
+		err = s.repo.UpdatePasswordHash(ctx, userID, hash)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to rehash password",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	logger.FromContext(ctx).Info("password rehashed", zap.String("user_id", userID))
+}
+
+// GetUser retrieves a user by ID, reading through the cache
 func (s *UserService) GetUser(ctx context.Context, userID string) (*repository.User, error) {
-	user, err := s.repo.GetByID(ctx, userID)
+	load := func(ctx context.Context) (cachedUser, error) {
+		user, err := s.repo.GetByID(ctx, userID)
+		if err != nil {
+			return cachedUser{}, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil {
+			return cachedUser{}, ErrUserNotFound
+		}
+		return toCachedUser(user), nil
+	}
+
+	var cached cachedUser
+	var err error
+	if s.users == nil {
+		cached, err = load(ctx)
+	} else {
+		cached, err = s.users.GetOrLoad(ctx, userID, s.cacheTTL, load)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cached.toUser(), nil
+}
+
+// GetUserByEmail looks up a user by email for support. The email is
+// normalized the same way as at register and login.
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*repository.User, error) {
+	user, err := s.repo.GetByEmail(ctx, NormalizeEmail(email, s.stripPlusTags))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, ErrUserNotFound
 	}
+
+	// The lookup loads the hash for login; never return it
+	user.PasswordHash = ""
 	return user, nil
 }
 
-// UpdateUser updates a user
-func (s *UserService) UpdateUser(ctx context.Context, userID, fullName, phone string) (*repository.User, error) {
+// UpdateUser sets the fields of a user named in mask to their values in
+// update, leaving the others as they are. An empty mask changes nothing.
+func (s *UserService) UpdateUser(ctx context.Context, userID string, update UserUpdate, mask []string) (*repository.User, error) {
 	user, err := s.repo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, ErrUserNotFound
+	}
+	if len(mask) == 0 {
+		return user, nil
 	}
 
-	user.FullName = fullName
-	user.Phone = phone
+	for _, path := range mask {
+		switch path {
+		case "full_name":
+			user.FullName = update.FullName
+		case "phone":
+			user.Phone = update.Phone
+		default:
+			return nil, fmt.Errorf("%w: field %q cannot be updated", ErrInvalidUpdateMask, path)
+		}
+	}
 
 	if err := s.repo.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	s.invalidateUser(ctx, user.ID)
 
-	s.logger.Info("user updated", zap.String("user_id", user.ID))
+	logger.FromContext(ctx).Info("user updated", zap.String("user_id", user.ID))
 
 	return user, nil
 }
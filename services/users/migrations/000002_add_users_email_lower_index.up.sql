@@ -0,0 +1,2 @@
+-- Case-insensitive email lookup for login and admin search
+CREATE INDEX IF NOT EXISTS idx_users_email_lower ON users(LOWER(email));
@@ -0,0 +1,17 @@
+-- Accounts whose emails differ only by case predate email normalization.
+-- The oldest account keeps the address, the same one login has resolved
+-- to; the others move to an undeliverable placeholder so they can be merged
+-- or removed by support without blocking the unique index.
+UPDATE users u
+SET email = 'duplicate+' || u.id || '@invalid'
+WHERE EXISTS (
+    SELECT 1 FROM users older
+    WHERE LOWER(older.email) = LOWER(u.email)
+      AND (older.created_at, older.id) < (u.created_at, u.id)
+);
+
+-- Register stores emails lowercased; bring older rows in line
+UPDATE users SET email = LOWER(email) WHERE email <> LOWER(email);
+
+DROP INDEX IF EXISTS idx_users_email_lower;
+CREATE UNIQUE INDEX idx_users_email_lower ON users(LOWER(email));